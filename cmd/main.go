@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/jadiazinf/inventory/internal/adapters/http/handlers"
@@ -10,6 +12,8 @@ import (
 	postgresRepo "github.com/jadiazinf/inventory/internal/adapters/repository/postgres"
 	"github.com/jadiazinf/inventory/internal/api"
 	"github.com/jadiazinf/inventory/internal/config"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	servicePorts "github.com/jadiazinf/inventory/internal/core/ports/services"
 	"github.com/jadiazinf/inventory/internal/core/services"
 	"github.com/jadiazinf/inventory/internal/platform/database"
 	"github.com/jadiazinf/inventory/internal/platform/firebase"
@@ -57,28 +61,138 @@ func main() {
 	customerRepo := postgresRepo.NewCustomerRepository(db)
 	customerChildRepo := postgresRepo.NewCustomerChildRepository(db)
 	inventoryRepo := postgresRepo.NewInventoryRepository(db)
+	warehouseRepo := postgresRepo.NewWarehouseRepository(db)
 	saleRepo := postgresRepo.NewSaleRepository(db)
 	reservationRepo := postgresRepo.NewReservationRepository(db)
+	preOrderRepo := postgresRepo.NewPreOrderRepository(db)
 	arRepo := postgresRepo.NewAccountsReceivableRepository(db)
+	apRepo := postgresRepo.NewAccountsPayableRepository(db)
+	expenseRepo := postgresRepo.NewExpenseRepository(db)
+	payrollRepo := postgresRepo.NewPayrollRepository(db)
+	employeeRepo := postgresRepo.NewEmployeeRepository(db)
+	campaignRepo := postgresRepo.NewCampaignRepository(db)
+	loyaltyRepo := postgresRepo.NewLoyaltyRepository(db)
+	storedValueRepo := postgresRepo.NewStoredValueRepository(db)
+	quotationRepo := postgresRepo.NewQuotationRepository(db)
+	schoolRepo := postgresRepo.NewSchoolRepository(db)
+	schoolSupplyListRepo := postgresRepo.NewSchoolSupplyListRepository(db)
+	forecastRepo := postgresRepo.NewDemandForecastRepository(db)
+	jobRunRepo := postgresRepo.NewJobRunRepository(db)
 
 	// 7. Initialize Services
 	log.Info("Initializing services...")
 	productService := services.NewProductService(productRepo, inventoryRepo, db)
-	inventoryService := services.NewInventoryService(inventoryRepo, productRepo, db)
-	arService := services.NewAccountsReceivableService(arRepo, db)
 	notificationService := services.NewNotificationService(reservationRepo, customerRepo, db)
-	saleService := services.NewSaleService(saleRepo, productRepo, inventoryRepo, customerRepo, db)
+	campaignService := services.NewCampaignService(campaignRepo, db)
+	pricingService := services.NewPricingService(campaignRepo, db)
+	loyaltyService := services.NewLoyaltyService(loyaltyRepo, customerRepo, saleRepo, db)
+	storedValueService := services.NewStoredValueService(storedValueRepo, customerRepo, saleRepo, db)
+	arService := services.NewAccountsReceivableService(arRepo, customerRepo, userRepo, storedValueService, notificationService, db, services.CreditPolicy{
+		SuspensionDays: cfg.CreditSuspensionDays,
+		OverrideRoles:  cfg.CreditOverrideRoles,
+	})
+	apService := services.NewAccountsPayableService(apRepo, db)
+	expenseService := services.NewExpenseService(expenseRepo, userRepo)
+	payrollService := services.NewPayrollService(payrollRepo)
+	employeeService := services.NewEmployeeService(employeeRepo, userRepo)
+	allocationService := services.NewAllocationService(warehouseRepo, inventoryRepo, db, domain.AllocationStrategy(cfg.AllocationStrategy))
+	saleService := services.NewSaleService(saleRepo, productRepo, inventoryRepo, customerRepo, pricingService, loyaltyService, storedValueService, allocationService, arService, db)
 	reservationService := services.NewReservationService(
 		reservationRepo,
 		customerRepo,
+		customerChildRepo,
 		productRepo,
 		inventoryRepo,
 		saleRepo,
 		notificationService,
+		pricingService,
+		loyaltyService,
+		allocationService,
 		db,
 	)
+	preOrderService := services.NewPreOrderService(
+		preOrderRepo,
+		customerRepo,
+		productRepo,
+		inventoryRepo,
+		saleRepo,
+		notificationService,
+		loyaltyService,
+		allocationService,
+		db,
+	)
+	inventoryService := services.NewInventoryService(inventoryRepo, productRepo, preOrderService, db)
+	quotationService := services.NewQuotationService(
+		quotationRepo,
+		customerRepo,
+		productRepo,
+		pricingService,
+		saleService,
+		reservationService,
+		notificationService,
+		db,
+	)
+	schoolService := services.NewSchoolService(schoolRepo, db)
+	schoolSupplyListService := services.NewSchoolSupplyListService(
+		schoolSupplyListRepo,
+		schoolRepo,
+		productRepo,
+		inventoryRepo,
+		customerChildRepo,
+		saleService,
+		reservationService,
+		db,
+	)
+	listImportService := services.NewListImportService(productRepo, schoolSupplyListService, db)
+	forecastService := services.NewDemandForecastService(forecastRepo, productRepo, schoolSupplyListRepo, db)
+	schedulerService := services.NewSchedulerService(jobRunRepo)
 
-	// 8. Initialize Middleware
+	// 8. Register Background Jobs
+	log.Info("Registering background jobs...")
+	jobs := []struct {
+		name     string
+		schedule string
+		fn       servicePorts.JobFunc
+	}{
+		{"reservations.expire", "*/15 * * * *", reservationService.ExpireReservations},
+		{"reservations.remind", "0 * * * *", func(ctx context.Context) (int, error) {
+			return reservationService.SendReminders(ctx, 24)
+		}},
+		{"pre-orders.expire", "30 0 * * *", func(ctx context.Context) (int, error) {
+			return preOrderService.ExpireUncollected(ctx, time.Now())
+		}},
+		{"quotations.expire", "0 1 * * *", func(ctx context.Context) (int, error) {
+			return quotationService.ExpireQuotations(ctx, time.Now())
+		}},
+		{"receivables.overdue", "0 2 * * *", func(ctx context.Context) (int, error) {
+			return arService.MarkOverdueReceivables(ctx, time.Now())
+		}},
+		{"receivables.late-fees", "15 2 * * *", func(ctx context.Context) (int, error) {
+			return arService.ApplyLateFees(ctx, time.Now())
+		}},
+		{"payables.overdue", "5 2 * * *", func(ctx context.Context) (int, error) {
+			return apService.MarkOverdueInvoices(ctx, time.Now())
+		}},
+		{"receivables.installment-reminders", "0 9 * * *", func(ctx context.Context) (int, error) {
+			return arService.SendInstallmentReminders(ctx, cfg.InstallmentReminderDays, time.Now())
+		}},
+		{"loyalty.expire-points", "30 2 * * *", func(ctx context.Context) (int, error) {
+			return loyaltyService.ExpirePoints(ctx, time.Now())
+		}},
+		{"stored-value.expire", "0 3 * * *", func(ctx context.Context) (int, error) {
+			return storedValueService.ExpireAccounts(ctx, time.Now())
+		}},
+		{"school-lists.recalculate-costs", "0 4 * * *", schoolSupplyListService.RecalculateActiveCosts},
+		{"notifications.dispatch", "* * * * *", notificationService.ProcessPendingNotifications},
+	}
+	for _, job := range jobs {
+		if err := schedulerService.RegisterJob(job.name, job.schedule, job.fn); err != nil {
+			log.Error("Failed to register job ", job.name, ": ", err)
+			os.Exit(1)
+		}
+	}
+
+	// 9. Initialize Middleware
 	log.Info("Initializing middleware...")
 	var authMiddleware *middleware.AuthMiddleware
 	if firebaseApp != nil {
@@ -87,19 +201,33 @@ func main() {
 		log.Warn("Running without authentication middleware (Firebase not initialized)")
 	}
 
-	// 9. Initialize Handlers
+	// 10. Initialize Handlers
 	log.Info("Initializing handlers...")
 	apiHandlers := &api.Handlers{
-		ProductHandler:     handlers.NewProductHandler(productService),
-		CustomerHandler:    handlers.NewCustomerHandler(customerRepo, customerChildRepo),
-		SaleHandler:        handlers.NewSaleHandler(saleService, arService),
-		ReservationHandler: handlers.NewReservationHandler(reservationService),
-		InventoryHandler:   handlers.NewInventoryHandler(inventoryService),
+		ProductHandler:            handlers.NewProductHandler(productService),
+		CustomerHandler:           handlers.NewCustomerHandler(customerRepo, customerChildRepo),
+		SaleHandler:               handlers.NewSaleHandler(saleService),
+		AccountsReceivableHandler: handlers.NewAccountsReceivableHandler(arService),
+		AccountsPayableHandler:    handlers.NewAccountsPayableHandler(apService),
+		ExpenseHandler:            handlers.NewExpenseHandler(expenseService),
+		PayrollHandler:            handlers.NewPayrollHandler(payrollService),
+		EmployeeHandler:           handlers.NewEmployeeHandler(employeeService),
+		ReservationHandler:        handlers.NewReservationHandler(reservationService),
+		PreOrderHandler:           handlers.NewPreOrderHandler(preOrderService),
+		InventoryHandler:          handlers.NewInventoryHandler(inventoryService, allocationService),
+		CampaignHandler:           handlers.NewCampaignHandler(campaignService),
+		LoyaltyHandler:            handlers.NewLoyaltyHandler(loyaltyService),
+		StoredValueHandler:        handlers.NewStoredValueHandler(storedValueService),
+		QuotationHandler:          handlers.NewQuotationHandler(quotationService),
+		SchoolHandler:             handlers.NewSchoolHandler(schoolService),
+		SchoolSupplyListHandler:   handlers.NewSchoolSupplyListHandler(schoolSupplyListService, listImportService),
+		ForecastHandler:           handlers.NewForecastHandler(forecastService),
+		SchedulerHandler:          handlers.NewSchedulerHandler(schedulerService),
 	}
 
 	log.Info("All handlers initialized successfully")
 
-	// 10. Create Server
+	// 11. Create Server
 	log.Info("Creating server...")
 	server := api.NewServer(cfg, db)
 	server.SetHandlers(apiHandlers)
@@ -107,7 +235,15 @@ func main() {
 		server.SetAuthMiddleware(authMiddleware)
 	}
 
-	// 11. Start Server
+	// 12. Start Background Jobs
+	if cfg.SchedulerEnabled {
+		schedulerService.Start(context.Background())
+		defer schedulerService.Stop()
+	} else {
+		log.Warn("Background jobs disabled (SCHEDULER_ENABLED=false)")
+	}
+
+	// 13. Start Server
 	log.Info("Starting server on port ", cfg.AppPort)
 	if err := server.Run(); err != nil {
 		log.Error("Failed to start server: ", err)
@@ -0,0 +1,263 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+// CreateSupplierInvoiceRequest represents a request to record a supplier invoice
+type CreateSupplierInvoiceRequest struct {
+	SupplierID    uuid.UUID           `json:"supplier_id" validate:"required"`
+	StoreID       *uuid.UUID          `json:"store_id,omitempty"`
+	InvoiceNumber string              `json:"invoice_number" validate:"required"`
+	ReceiptID     *uuid.UUID          `json:"receipt_id,omitempty"` // reference_id of the inbound movements of the purchase receipt
+	IssueDate     *time.Time          `json:"issue_date,omitempty"`
+	DueDate       *time.Time          `json:"due_date,omitempty"`
+	TotalAmount   float64             `json:"total_amount" validate:"required,gt=0"`
+	Currency      domain.CurrencyCode `json:"currency"`
+	ExchangeRate  *float64            `json:"exchange_rate,omitempty"`
+	Notes         *string             `json:"notes,omitempty"`
+}
+
+// SupplierPaymentRequest represents a payment to a supplier invoice
+type SupplierPaymentRequest struct {
+	Amount        float64              `json:"amount" validate:"required,gt=0"`
+	Currency      domain.CurrencyCode  `json:"currency,omitempty"`
+	ExchangeRate  *float64             `json:"exchange_rate,omitempty"`
+	PaymentMethod domain.PaymentMethod `json:"payment_method" validate:"required"`
+	Reference     *string              `json:"reference,omitempty"`
+	Notes         *string              `json:"notes,omitempty"`
+}
+
+// CancelSupplierInvoiceRequest represents the request to cancel a supplier invoice
+type CancelSupplierInvoiceRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+// SupplierInvoiceResponse represents a supplier invoice in API responses
+type SupplierInvoiceResponse struct {
+	InvoiceID          uuid.UUID                 `json:"invoice_id"`
+	SupplierID         uuid.UUID                 `json:"supplier_id"`
+	SupplierName       string                    `json:"supplier_name,omitempty"`
+	StoreID            *uuid.UUID                `json:"store_id,omitempty"`
+	InvoiceNumber      string                    `json:"invoice_number"`
+	ReceiptID          *uuid.UUID                `json:"receipt_id,omitempty"`
+	IssueDate          time.Time                 `json:"issue_date"`
+	DueDate            time.Time                 `json:"due_date"`
+	TotalAmount        float64                   `json:"total_amount"`
+	PaidAmount         float64                   `json:"paid_amount"`
+	Balance            float64                   `json:"balance"`
+	Currency           domain.CurrencyCode       `json:"currency"`
+	ExchangeRate       *float64                  `json:"exchange_rate,omitempty"`
+	Status             domain.AccountStatus      `json:"status"`
+	Notes              *string                   `json:"notes,omitempty"`
+	CancellationReason *string                   `json:"cancellation_reason,omitempty"`
+	Payments           []SupplierPaymentResponse `json:"payments,omitempty"`
+	CreatedAt          time.Time                 `json:"created_at"`
+}
+
+// SupplierInvoiceListResponse represents a paginated list of supplier invoices
+type SupplierInvoiceListResponse struct {
+	Invoices []SupplierInvoiceResponse `json:"invoices"`
+	Total    int64                     `json:"total"`
+	Limit    int                       `json:"limit"`
+	Offset   int                       `json:"offset"`
+}
+
+// SupplierPaymentResponse represents a payment to a supplier in API responses
+type SupplierPaymentResponse struct {
+	PaymentID     uuid.UUID            `json:"payment_id"`
+	InvoiceID     uuid.UUID            `json:"invoice_id"`
+	SupplierID    uuid.UUID            `json:"supplier_id"`
+	Amount        float64              `json:"amount"`
+	Currency      domain.CurrencyCode  `json:"currency"`
+	ExchangeRate  *float64             `json:"exchange_rate,omitempty"`
+	AppliedAmount float64              `json:"applied_amount"`
+	PaymentMethod domain.PaymentMethod `json:"payment_method"`
+	Reference     *string              `json:"reference,omitempty"`
+	PaymentDate   time.Time            `json:"payment_date"`
+	Notes         *string              `json:"notes,omitempty"`
+}
+
+// PayableAgingRowResponse represents the aging of a supplier
+type PayableAgingRowResponse struct {
+	SupplierID   uuid.UUID            `json:"supplier_id"`
+	SupplierName string               `json:"supplier_name"`
+	Currency     domain.CurrencyCode  `json:"currency"`
+	Invoices     int                  `json:"invoices"`
+	Buckets      AgingBucketsResponse `json:"buckets"`
+}
+
+// PayableAgingReportResponse represents the accounts payable aging report
+type PayableAgingReportResponse struct {
+	AsOf   time.Time                                    `json:"as_of"`
+	Rows   []PayableAgingRowResponse                    `json:"rows"`
+	Totals map[domain.CurrencyCode]AgingBucketsResponse `json:"totals"`
+}
+
+// CashRequirementDayResponse represents the money due to suppliers on a date
+type CashRequirementDayResponse struct {
+	Date       time.Time                       `json:"date"`
+	Invoices   int                             `json:"invoices"`
+	Amounts    map[domain.CurrencyCode]float64 `json:"amounts"`
+	Cumulative map[domain.CurrencyCode]float64 `json:"cumulative"`
+}
+
+// CashRequirementsResponse represents the cash requirements forecast
+type CashRequirementsResponse struct {
+	From    time.Time                       `json:"from"`
+	To      time.Time                       `json:"to"`
+	Overdue map[domain.CurrencyCode]float64 `json:"overdue"`
+	Days    []CashRequirementDayResponse    `json:"days"`
+	Totals  map[domain.CurrencyCode]float64 `json:"totals"`
+}
+
+// ToServiceRequest converts CreateSupplierInvoiceRequest to a service request
+func (r *CreateSupplierInvoiceRequest) ToServiceRequest(userID uuid.UUID) services.CreateSupplierInvoiceRequest {
+	return services.CreateSupplierInvoiceRequest{
+		SupplierID:    r.SupplierID,
+		StoreID:       r.StoreID,
+		InvoiceNumber: r.InvoiceNumber,
+		ReceiptID:     r.ReceiptID,
+		IssueDate:     r.IssueDate,
+		DueDate:       r.DueDate,
+		TotalAmount:   r.TotalAmount,
+		Currency:      r.Currency,
+		ExchangeRate:  r.ExchangeRate,
+		Notes:         r.Notes,
+		UserID:        userID,
+	}
+}
+
+// ToServiceRequest converts SupplierPaymentRequest to a service request
+func (r *SupplierPaymentRequest) ToServiceRequest(invoiceID, userID uuid.UUID) services.SupplierPaymentRequest {
+	return services.SupplierPaymentRequest{
+		InvoiceID:     invoiceID,
+		Amount:        r.Amount,
+		Currency:      r.Currency,
+		ExchangeRate:  r.ExchangeRate,
+		PaymentMethod: r.PaymentMethod,
+		Reference:     r.Reference,
+		Notes:         r.Notes,
+		UserID:        userID,
+	}
+}
+
+// ToSupplierInvoiceResponse converts a supplier invoice to response
+func ToSupplierInvoiceResponse(invoice *domain.SupplierInvoice) SupplierInvoiceResponse {
+	response := SupplierInvoiceResponse{
+		InvoiceID:          invoice.InvoiceID,
+		SupplierID:         invoice.SupplierID,
+		StoreID:            invoice.StoreID,
+		InvoiceNumber:      invoice.InvoiceNumber,
+		ReceiptID:          invoice.ReceiptID,
+		IssueDate:          invoice.IssueDate,
+		DueDate:            invoice.DueDate,
+		TotalAmount:        invoice.TotalAmount,
+		PaidAmount:         invoice.PaidAmount,
+		Balance:            invoice.Balance,
+		Currency:           invoice.Currency,
+		ExchangeRate:       invoice.ExchangeRate,
+		Status:             invoice.Status,
+		Notes:              invoice.Notes,
+		CancellationReason: invoice.CancellationReason,
+		CreatedAt:          invoice.CreatedAt,
+	}
+	if invoice.Supplier != nil {
+		response.SupplierName = invoice.Supplier.BusinessName
+	}
+	if len(invoice.Payments) > 0 {
+		response.Payments = ToSupplierPaymentResponses(invoice.Payments)
+	}
+	return response
+}
+
+// ToSupplierInvoiceListResponse converts a supplier invoice slice to list response
+func ToSupplierInvoiceListResponse(invoices []domain.SupplierInvoice, total int64, limit, offset int) SupplierInvoiceListResponse {
+	responses := make([]SupplierInvoiceResponse, len(invoices))
+	for i := range invoices {
+		responses[i] = ToSupplierInvoiceResponse(&invoices[i])
+	}
+	return SupplierInvoiceListResponse{
+		Invoices: responses,
+		Total:    total,
+		Limit:    limit,
+		Offset:   offset,
+	}
+}
+
+// ToSupplierPaymentResponse converts a supplier payment to response
+func ToSupplierPaymentResponse(p *domain.SupplierPayment) SupplierPaymentResponse {
+	return SupplierPaymentResponse{
+		PaymentID:     p.PaymentID,
+		InvoiceID:     p.InvoiceID,
+		SupplierID:    p.SupplierID,
+		Amount:        p.Amount,
+		Currency:      p.Currency,
+		ExchangeRate:  p.ExchangeRate,
+		AppliedAmount: p.AppliedAmount,
+		PaymentMethod: p.PaymentMethod,
+		Reference:     p.Reference,
+		PaymentDate:   p.PaymentDate,
+		Notes:         p.Notes,
+	}
+}
+
+// ToSupplierPaymentResponses converts a supplier payment slice to responses
+func ToSupplierPaymentResponses(payments []domain.SupplierPayment) []SupplierPaymentResponse {
+	responses := make([]SupplierPaymentResponse, len(payments))
+	for i := range payments {
+		responses[i] = ToSupplierPaymentResponse(&payments[i])
+	}
+	return responses
+}
+
+// ToPayableAgingReportResponse converts a service payable aging report to response
+func ToPayableAgingReportResponse(r *services.PayableAgingReport) PayableAgingReportResponse {
+	rows := make([]PayableAgingRowResponse, len(r.Rows))
+	for i, row := range r.Rows {
+		rows[i] = PayableAgingRowResponse{
+			SupplierID:   row.SupplierID,
+			SupplierName: row.SupplierName,
+			Currency:     row.Currency,
+			Invoices:     row.Invoices,
+			Buckets:      toAgingBucketsResponse(row.Buckets),
+		}
+	}
+
+	totals := make(map[domain.CurrencyCode]AgingBucketsResponse, len(r.Totals))
+	for currency, buckets := range r.Totals {
+		totals[currency] = toAgingBucketsResponse(buckets)
+	}
+
+	return PayableAgingReportResponse{
+		AsOf:   r.AsOf,
+		Rows:   rows,
+		Totals: totals,
+	}
+}
+
+// ToCashRequirementsResponse converts a service cash requirements forecast to response
+func ToCashRequirementsResponse(f *services.CashRequirements) CashRequirementsResponse {
+	days := make([]CashRequirementDayResponse, len(f.Days))
+	for i, day := range f.Days {
+		days[i] = CashRequirementDayResponse{
+			Date:       day.Date,
+			Invoices:   day.Invoices,
+			Amounts:    day.Amounts,
+			Cumulative: day.Cumulative,
+		}
+	}
+
+	return CashRequirementsResponse{
+		From:    f.From,
+		To:      f.To,
+		Overdue: f.Overdue,
+		Days:    days,
+		Totals:  f.Totals,
+	}
+}
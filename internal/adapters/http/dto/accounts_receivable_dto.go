@@ -0,0 +1,580 @@
+package dto
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+// AccountsReceivableListResponse represents a paginated list of receivables
+type AccountsReceivableListResponse struct {
+	Receivables []AccountsReceivableResponse `json:"receivables"`
+	Total       int64                        `json:"total"`
+	Limit       int                          `json:"limit"`
+	Offset      int                          `json:"offset"`
+}
+
+// CustomerPaymentResponse represents a payment on a receivable
+type CustomerPaymentResponse struct {
+	PaymentID     uuid.UUID            `json:"payment_id"`
+	ReceivableID  uuid.UUID            `json:"receivable_id"`
+	PaymentDate   time.Time            `json:"payment_date"`
+	Amount        float64              `json:"amount"`
+	Currency      domain.CurrencyCode  `json:"currency"`
+	PaymentMethod domain.PaymentMethod `json:"payment_method"`
+	Reference     *string              `json:"reference,omitempty"`
+	Notes         *string              `json:"notes,omitempty"`
+	ReceiptID     *uuid.UUID           `json:"receipt_id,omitempty"`
+	ExchangeRate  *float64             `json:"exchange_rate,omitempty"`
+	ReversedAt    *time.Time           `json:"reversed_at,omitempty"`
+	VoidReason    *string              `json:"void_reason,omitempty"`
+	CreatedBy     *uuid.UUID           `json:"created_by,omitempty"`
+}
+
+// VoidPaymentRequest represents the request to void a payment
+type VoidPaymentRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+// CreditNoteRequest represents the request to issue a credit note on a receivable
+type CreditNoteRequest struct {
+	Amount      float64                 `json:"amount" validate:"required,gt=0"`
+	Reason      domain.CreditNoteReason `json:"reason" validate:"required"`
+	Reference   *string                 `json:"reference,omitempty"`
+	Description string                  `json:"description" validate:"required"`
+}
+
+// WriteOffRequest represents the request to write off a receivable
+type WriteOffRequest struct {
+	Amount      *float64 `json:"amount,omitempty"`
+	Description string   `json:"description" validate:"required"`
+}
+
+// ReviewAdjustmentRequest represents the approval or rejection of a write-off
+type ReviewAdjustmentRequest struct {
+	Notes *string `json:"notes,omitempty"`
+}
+
+// ReceivableAdjustmentResponse represents a credit note or write-off in API responses
+type ReceivableAdjustmentResponse struct {
+	AdjustmentID     uuid.UUID                `json:"adjustment_id"`
+	ReceivableID     uuid.UUID                `json:"receivable_id"`
+	CustomerID       uuid.UUID                `json:"customer_id"`
+	AdjustmentType   domain.AdjustmentType    `json:"adjustment_type"`
+	CreditNoteReason *domain.CreditNoteReason `json:"credit_note_reason,omitempty"`
+	Amount           float64                  `json:"amount"`
+	Currency         domain.CurrencyCode      `json:"currency"`
+	Reference        *string                  `json:"reference,omitempty"`
+	Description      string                   `json:"description"`
+	Status           domain.AdjustmentStatus  `json:"status"`
+	RequestedBy      uuid.UUID                `json:"requested_by"`
+	ReviewedBy       *uuid.UUID               `json:"reviewed_by,omitempty"`
+	ReviewedAt       *time.Time               `json:"reviewed_at,omitempty"`
+	ReviewNotes      *string                  `json:"review_notes,omitempty"`
+	AppliedAt        *time.Time               `json:"applied_at,omitempty"`
+	CreatedAt        time.Time                `json:"created_at"`
+}
+
+// ReceivableAdjustmentListResponse represents a paginated list of adjustments
+type ReceivableAdjustmentListResponse struct {
+	Adjustments []ReceivableAdjustmentResponse `json:"adjustments"`
+	Total       int64                          `json:"total"`
+	Limit       int                            `json:"limit"`
+	Offset      int                            `json:"offset"`
+}
+
+// CustomerPaymentRequest represents a lump sum paid by a customer against their receivables
+type CustomerPaymentRequest struct {
+	CustomerID    uuid.UUID                  `json:"customer_id" validate:"required"`
+	Amount        float64                    `json:"amount" validate:"required,gt=0"`
+	Currency      domain.CurrencyCode        `json:"currency" validate:"required"`
+	ExchangeRate  *float64                   `json:"exchange_rate,omitempty"`
+	PaymentMethod domain.PaymentMethod       `json:"payment_method" validate:"required"`
+	Reference     *string                    `json:"reference,omitempty"`
+	Notes         *string                    `json:"notes,omitempty"`
+	Allocations   []PaymentAllocationRequest `json:"allocations,omitempty"`
+}
+
+// PaymentAllocationRequest applies part of a customer payment to one receivable
+type PaymentAllocationRequest struct {
+	ReceivableID uuid.UUID `json:"receivable_id" validate:"required"`
+	Amount       float64   `json:"amount" validate:"required,gt=0"`
+}
+
+// ReverseReceiptRequest represents the request to reverse a customer receipt
+type ReverseReceiptRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+// CustomerReceiptResponse represents a customer receipt and its allocations in API responses
+type CustomerReceiptResponse struct {
+	ReceiptID       uuid.UUID                 `json:"receipt_id"`
+	CustomerID      uuid.UUID                 `json:"customer_id"`
+	ReceiptDate     time.Time                 `json:"receipt_date"`
+	Amount          float64                   `json:"amount"`
+	Currency        domain.CurrencyCode       `json:"currency"`
+	ExchangeRate    *float64                  `json:"exchange_rate,omitempty"`
+	PaymentMethod   domain.PaymentMethod      `json:"payment_method"`
+	Reference       *string                   `json:"reference,omitempty"`
+	AllocatedAmount float64                   `json:"allocated_amount"`
+	CreditAmount    float64                   `json:"credit_amount"`
+	CreditAccountID *uuid.UUID                `json:"credit_account_id,omitempty"`
+	Status          domain.ReceiptStatus      `json:"status"`
+	ReversedAt      *time.Time                `json:"reversed_at,omitempty"`
+	ReversalReason  *string                   `json:"reversal_reason,omitempty"`
+	Notes           *string                   `json:"notes,omitempty"`
+	Allocations     []CustomerPaymentResponse `json:"allocations"`
+	CreatedAt       time.Time                 `json:"created_at"`
+}
+
+// CustomerReceiptListResponse represents a paginated list of customer receipts
+type CustomerReceiptListResponse struct {
+	Receipts []CustomerReceiptResponse `json:"receipts"`
+	Total    int64                     `json:"total"`
+	Limit    int                       `json:"limit"`
+	Offset   int                       `json:"offset"`
+}
+
+// LateFeePolicyRequest represents the request to create/update a late fee policy
+type LateFeePolicyRequest struct {
+	Name      string              `json:"name" validate:"required"`
+	Currency  domain.CurrencyCode `json:"currency,omitempty"`
+	FeeType   domain.LateFeeType  `json:"fee_type" validate:"required"`
+	Amount    float64             `json:"amount" validate:"required,gt=0"`
+	GraceDays int                 `json:"grace_days"`
+	MaxAmount *float64            `json:"max_amount,omitempty"`
+	IsActive  *bool               `json:"is_active,omitempty"`
+}
+
+// LateFeePolicyResponse represents a late fee policy in API responses
+type LateFeePolicyResponse struct {
+	PolicyID  uuid.UUID           `json:"policy_id"`
+	Name      string              `json:"name"`
+	Currency  domain.CurrencyCode `json:"currency"`
+	FeeType   domain.LateFeeType  `json:"fee_type"`
+	Amount    float64             `json:"amount"`
+	GraceDays int                 `json:"grace_days"`
+	MaxAmount *float64            `json:"max_amount,omitempty"`
+	IsActive  bool                `json:"is_active"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+// CreditOverrideResponse represents a supervisor's approval of a credit sale outside the customer's terms
+type CreditOverrideResponse struct {
+	OverrideID     uuid.UUID `json:"override_id"`
+	SaleID         uuid.UUID `json:"sale_id"`
+	InvoiceNumber  string    `json:"invoice_number,omitempty"`
+	CustomerID     uuid.UUID `json:"customer_id"`
+	SupervisorID   uuid.UUID `json:"supervisor_id"`
+	SupervisorName string    `json:"supervisor_name,omitempty"`
+	Reason         string    `json:"reason"`
+	Violations     []string  `json:"violations"`
+	CreditLimit    float64   `json:"credit_limit"`
+	Outstanding    float64   `json:"outstanding"`
+	Amount         float64   `json:"amount"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// CreditOverrideListResponse represents a paginated list of credit overrides
+type CreditOverrideListResponse struct {
+	Overrides []CreditOverrideResponse `json:"overrides"`
+	Total     int64                    `json:"total"`
+	Limit     int                      `json:"limit"`
+	Offset    int                      `json:"offset"`
+}
+
+// InstallmentPlanResponse represents a credit sale split into installments
+type InstallmentPlanResponse struct {
+	PlanID           uuid.UUID                    `json:"plan_id"`
+	SaleID           uuid.UUID                    `json:"sale_id"`
+	InvoiceNumber    string                       `json:"invoice_number,omitempty"`
+	CustomerID       uuid.UUID                    `json:"customer_id"`
+	Frequency        domain.InstallmentFrequency  `json:"frequency"`
+	InstallmentCount int                          `json:"installment_count"`
+	TotalAmount      float64                      `json:"total_amount"`
+	Balance          float64                      `json:"balance"`
+	Currency         domain.CurrencyCode          `json:"currency"`
+	FirstDueDate     time.Time                    `json:"first_due_date"`
+	NextDueDate      *time.Time                   `json:"next_due_date,omitempty"` // Earliest installment still unpaid
+	Installments     []AccountsReceivableResponse `json:"installments"`
+	CreatedAt        time.Time                    `json:"created_at"`
+}
+
+// InstallmentPlanListResponse represents a paginated list of installment plans
+type InstallmentPlanListResponse struct {
+	Plans  []InstallmentPlanResponse `json:"plans"`
+	Total  int64                     `json:"total"`
+	Limit  int                       `json:"limit"`
+	Offset int                       `json:"offset"`
+}
+
+// AgingBucketsResponse represents balances split by days past due
+type AgingBucketsResponse struct {
+	Current    float64 `json:"current"`
+	Days1To30  float64 `json:"days_1_30"`
+	Days31To60 float64 `json:"days_31_60"`
+	Days61To90 float64 `json:"days_61_90"`
+	Over90     float64 `json:"over_90"`
+	Total      float64 `json:"total"`
+}
+
+// AgingRowResponse represents the aging of a customer at a store
+type AgingRowResponse struct {
+	CustomerID   uuid.UUID            `json:"customer_id"`
+	CustomerName string               `json:"customer_name"`
+	StoreID      *uuid.UUID           `json:"store_id,omitempty"`
+	Currency     domain.CurrencyCode  `json:"currency"`
+	Receivables  int                  `json:"receivables"`
+	Buckets      AgingBucketsResponse `json:"buckets"`
+}
+
+// AgingReportResponse represents the accounts receivable aging report
+type AgingReportResponse struct {
+	AsOf   time.Time                                    `json:"as_of"`
+	Rows   []AgingRowResponse                           `json:"rows"`
+	Totals map[domain.CurrencyCode]AgingBucketsResponse `json:"totals"`
+}
+
+// StatementLineResponse represents a movement in a customer statement
+type StatementLineResponse struct {
+	Date         time.Time                  `json:"date"`
+	Type         services.StatementLineType `json:"type"`
+	ReceivableID uuid.UUID                  `json:"receivable_id"`
+	Reference    string                     `json:"reference"`
+	Charge       float64                    `json:"charge"`
+	Payment      float64                    `json:"payment"`
+	Balance      float64                    `json:"balance"`
+}
+
+// CustomerStatementResponse represents a customer account statement
+type CustomerStatementResponse struct {
+	CustomerID       uuid.UUID               `json:"customer_id"`
+	CustomerName     string                  `json:"customer_name"`
+	Currency         domain.CurrencyCode     `json:"currency"`
+	From             time.Time               `json:"from"`
+	To               time.Time               `json:"to"`
+	OpeningBalance   float64                 `json:"opening_balance"`
+	TotalCharges     float64                 `json:"total_charges"`
+	TotalPayments    float64                 `json:"total_payments"`
+	TotalAdjustments float64                 `json:"total_adjustments"`
+	ClosingBalance   float64                 `json:"closing_balance"`
+	Lines            []StatementLineResponse `json:"lines"`
+}
+
+// ToAccountsReceivableListResponse converts a receivable slice to list response
+func ToAccountsReceivableListResponse(receivables []domain.AccountsReceivable, total int64, limit, offset int) AccountsReceivableListResponse {
+	responses := make([]AccountsReceivableResponse, len(receivables))
+	for i, ar := range receivables {
+		responses[i] = ToAccountsReceivableResponse(&ar)
+	}
+	return AccountsReceivableListResponse{
+		Receivables: responses,
+		Total:       total,
+		Limit:       limit,
+		Offset:      offset,
+	}
+}
+
+// ToCustomerPaymentResponse converts domain.CustomerPayment to response
+func ToCustomerPaymentResponse(p *domain.CustomerPayment) CustomerPaymentResponse {
+	return CustomerPaymentResponse{
+		PaymentID:     p.PaymentID,
+		ReceivableID:  p.ReceivableID,
+		PaymentDate:   p.PaymentDate,
+		Amount:        p.Amount,
+		Currency:      p.Currency,
+		PaymentMethod: p.PaymentMethod,
+		Reference:     p.Reference,
+		Notes:         p.Notes,
+		ReceiptID:     p.ReceiptID,
+		ExchangeRate:  p.ExchangeRate,
+		ReversedAt:    p.ReversedAt,
+		VoidReason:    p.VoidReason,
+		CreatedBy:     p.CreatedBy,
+	}
+}
+
+// ToReceivableAdjustmentResponse converts domain.ReceivableAdjustment to response
+func ToReceivableAdjustmentResponse(a *domain.ReceivableAdjustment) ReceivableAdjustmentResponse {
+	return ReceivableAdjustmentResponse{
+		AdjustmentID:     a.AdjustmentID,
+		ReceivableID:     a.ReceivableID,
+		CustomerID:       a.CustomerID,
+		AdjustmentType:   a.AdjustmentType,
+		CreditNoteReason: a.CreditNoteReason,
+		Amount:           a.Amount,
+		Currency:         a.Currency,
+		Reference:        a.Reference,
+		Description:      a.Description,
+		Status:           a.Status,
+		RequestedBy:      a.RequestedBy,
+		ReviewedBy:       a.ReviewedBy,
+		ReviewedAt:       a.ReviewedAt,
+		ReviewNotes:      a.ReviewNotes,
+		AppliedAt:        a.AppliedAt,
+		CreatedAt:        a.CreatedAt,
+	}
+}
+
+// ToReceivableAdjustmentListResponse converts an adjustment slice to list response
+func ToReceivableAdjustmentListResponse(adjustments []domain.ReceivableAdjustment, total int64, limit, offset int) ReceivableAdjustmentListResponse {
+	responses := make([]ReceivableAdjustmentResponse, len(adjustments))
+	for i, a := range adjustments {
+		responses[i] = ToReceivableAdjustmentResponse(&a)
+	}
+	return ReceivableAdjustmentListResponse{
+		Adjustments: responses,
+		Total:       total,
+		Limit:       limit,
+		Offset:      offset,
+	}
+}
+
+// ToServiceRequest converts CustomerPaymentRequest to a service request
+func (r *CustomerPaymentRequest) ToServiceRequest(userID uuid.UUID) services.CustomerPaymentRequest {
+	allocations := make([]services.PaymentAllocation, len(r.Allocations))
+	for i, a := range r.Allocations {
+		allocations[i] = services.PaymentAllocation{
+			ReceivableID: a.ReceivableID,
+			Amount:       a.Amount,
+		}
+	}
+
+	return services.CustomerPaymentRequest{
+		CustomerID:    r.CustomerID,
+		Amount:        r.Amount,
+		Currency:      r.Currency,
+		ExchangeRate:  r.ExchangeRate,
+		PaymentMethod: r.PaymentMethod,
+		Reference:     r.Reference,
+		Notes:         r.Notes,
+		Allocations:   allocations,
+		UserID:        userID,
+	}
+}
+
+// ToCustomerReceiptResponse converts domain.CustomerReceipt to response
+func ToCustomerReceiptResponse(r *domain.CustomerReceipt) CustomerReceiptResponse {
+	allocations := make([]CustomerPaymentResponse, len(r.Payments))
+	for i, p := range r.Payments {
+		allocations[i] = ToCustomerPaymentResponse(&p)
+	}
+
+	return CustomerReceiptResponse{
+		ReceiptID:       r.ReceiptID,
+		CustomerID:      r.CustomerID,
+		ReceiptDate:     r.ReceiptDate,
+		Amount:          r.Amount,
+		Currency:        r.Currency,
+		ExchangeRate:    r.ExchangeRate,
+		PaymentMethod:   r.PaymentMethod,
+		Reference:       r.Reference,
+		AllocatedAmount: r.AllocatedAmount,
+		CreditAmount:    r.CreditAmount,
+		CreditAccountID: r.CreditAccountID,
+		Status:          r.Status,
+		ReversedAt:      r.ReversedAt,
+		ReversalReason:  r.ReversalReason,
+		Notes:           r.Notes,
+		Allocations:     allocations,
+		CreatedAt:       r.CreatedAt,
+	}
+}
+
+// ToCustomerReceiptListResponse converts a receipt slice to list response
+func ToCustomerReceiptListResponse(receipts []domain.CustomerReceipt, total int64, limit, offset int) CustomerReceiptListResponse {
+	responses := make([]CustomerReceiptResponse, len(receipts))
+	for i, r := range receipts {
+		responses[i] = ToCustomerReceiptResponse(&r)
+	}
+	return CustomerReceiptListResponse{
+		Receipts: responses,
+		Total:    total,
+		Limit:    limit,
+		Offset:   offset,
+	}
+}
+
+// ToLateFeePolicyDomain converts LateFeePolicyRequest to domain.LateFeePolicy
+func (r *LateFeePolicyRequest) ToLateFeePolicyDomain() *domain.LateFeePolicy {
+	isActive := true
+	if r.IsActive != nil {
+		isActive = *r.IsActive
+	}
+
+	return &domain.LateFeePolicy{
+		PolicyID:  uuid.New(),
+		Name:      r.Name,
+		Currency:  r.Currency,
+		FeeType:   r.FeeType,
+		Amount:    r.Amount,
+		GraceDays: r.GraceDays,
+		MaxAmount: r.MaxAmount,
+		IsActive:  isActive,
+	}
+}
+
+// ToLateFeePolicyResponse converts domain.LateFeePolicy to response
+func ToLateFeePolicyResponse(p *domain.LateFeePolicy) LateFeePolicyResponse {
+	return LateFeePolicyResponse{
+		PolicyID:  p.PolicyID,
+		Name:      p.Name,
+		Currency:  p.Currency,
+		FeeType:   p.FeeType,
+		Amount:    p.Amount,
+		GraceDays: p.GraceDays,
+		MaxAmount: p.MaxAmount,
+		IsActive:  p.IsActive,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+// ToCreditOverrideListResponse converts a credit override slice to list response
+func ToCreditOverrideListResponse(overrides []domain.CreditOverride, total int64, limit, offset int) CreditOverrideListResponse {
+	responses := make([]CreditOverrideResponse, len(overrides))
+	for i, o := range overrides {
+		responses[i] = CreditOverrideResponse{
+			OverrideID:   o.OverrideID,
+			SaleID:       o.SaleID,
+			CustomerID:   o.CustomerID,
+			SupervisorID: o.SupervisorID,
+			Reason:       o.Reason,
+			Violations:   strings.Split(o.Violations, "\n"),
+			CreditLimit:  o.CreditLimit,
+			Outstanding:  o.Outstanding,
+			Amount:       o.Amount,
+			CreatedAt:    o.CreatedAt,
+		}
+		if o.Sale != nil {
+			responses[i].InvoiceNumber = o.Sale.InvoiceNumber
+		}
+		if o.Supervisor != nil {
+			responses[i].SupervisorName = o.Supervisor.FirstName + " " + o.Supervisor.LastName
+		}
+	}
+	return CreditOverrideListResponse{
+		Overrides: responses,
+		Total:     total,
+		Limit:     limit,
+		Offset:    offset,
+	}
+}
+
+// ToAgingReportResponse converts a service aging report to response
+func ToAgingReportResponse(r *services.AgingReport) AgingReportResponse {
+	rows := make([]AgingRowResponse, len(r.Rows))
+	for i, row := range r.Rows {
+		rows[i] = AgingRowResponse{
+			CustomerID:   row.CustomerID,
+			CustomerName: row.CustomerName,
+			StoreID:      row.StoreID,
+			Currency:     row.Currency,
+			Receivables:  row.Receivables,
+			Buckets:      toAgingBucketsResponse(row.Buckets),
+		}
+	}
+
+	totals := make(map[domain.CurrencyCode]AgingBucketsResponse, len(r.Totals))
+	for currency, buckets := range r.Totals {
+		totals[currency] = toAgingBucketsResponse(buckets)
+	}
+
+	return AgingReportResponse{
+		AsOf:   r.AsOf,
+		Rows:   rows,
+		Totals: totals,
+	}
+}
+
+// ToCustomerStatementResponse converts a service statement to response
+func ToCustomerStatementResponse(s *services.CustomerStatement) CustomerStatementResponse {
+	lines := make([]StatementLineResponse, len(s.Lines))
+	for i, line := range s.Lines {
+		lines[i] = StatementLineResponse{
+			Date:         line.Date,
+			Type:         line.Type,
+			ReceivableID: line.ReceivableID,
+			Reference:    line.Reference,
+			Charge:       line.Charge,
+			Payment:      line.Payment,
+			Balance:      line.Balance,
+		}
+	}
+
+	return CustomerStatementResponse{
+		CustomerID:       s.CustomerID,
+		CustomerName:     s.CustomerName,
+		Currency:         s.Currency,
+		From:             s.From,
+		To:               s.To,
+		OpeningBalance:   s.OpeningBalance,
+		TotalCharges:     s.TotalCharges,
+		TotalPayments:    s.TotalPayments,
+		TotalAdjustments: s.TotalAdjustments,
+		ClosingBalance:   s.ClosingBalance,
+		Lines:            lines,
+	}
+}
+
+func toAgingBucketsResponse(b services.AgingBuckets) AgingBucketsResponse {
+	return AgingBucketsResponse{
+		Current:    b.Current,
+		Days1To30:  b.Days1To30,
+		Days31To60: b.Days31To60,
+		Days61To90: b.Days61To90,
+		Over90:     b.Over90,
+		Total:      b.Total,
+	}
+}
+
+// ToInstallmentPlanResponse converts an installment plan to response
+func ToInstallmentPlanResponse(plan *domain.InstallmentPlan) InstallmentPlanResponse {
+	response := InstallmentPlanResponse{
+		PlanID:           plan.PlanID,
+		SaleID:           plan.SaleID,
+		CustomerID:       plan.CustomerID,
+		Frequency:        plan.Frequency,
+		InstallmentCount: plan.InstallmentCount,
+		TotalAmount:      plan.TotalAmount,
+		Currency:         plan.Currency,
+		FirstDueDate:     plan.FirstDueDate,
+		Installments:     make([]AccountsReceivableResponse, len(plan.Installments)),
+		CreatedAt:        plan.CreatedAt,
+	}
+	if plan.Sale != nil {
+		response.InvoiceNumber = plan.Sale.InvoiceNumber
+	}
+
+	for i := range plan.Installments {
+		installment := &plan.Installments[i]
+		response.Installments[i] = ToAccountsReceivableResponse(installment)
+		response.Balance += installment.Balance
+		if installment.Balance > 0 && response.NextDueDate == nil {
+			response.NextDueDate = &installment.DueDate
+		}
+	}
+	response.Balance = math.Round(response.Balance*100) / 100
+
+	return response
+}
+
+// ToInstallmentPlanListResponse converts an installment plan slice to list response
+func ToInstallmentPlanListResponse(plans []domain.InstallmentPlan, total int64, limit, offset int) InstallmentPlanListResponse {
+	responses := make([]InstallmentPlanResponse, len(plans))
+	for i := range plans {
+		responses[i] = ToInstallmentPlanResponse(&plans[i])
+	}
+	return InstallmentPlanListResponse{
+		Plans:  responses,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}
+}
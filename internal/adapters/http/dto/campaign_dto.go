@@ -0,0 +1,130 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+// CampaignRequest represents the request to create/update a campaign
+type CampaignRequest struct {
+	CampaignName       string               `json:"campaign_name" validate:"required"`
+	Description        *string              `json:"description,omitempty"`
+	StartDate          time.Time            `json:"start_date" validate:"required"`
+	EndDate            time.Time            `json:"end_date" validate:"required"`
+	PromotionType      domain.PromotionType `json:"promotion_type,omitempty"`
+	DiscountPercentage *float64             `json:"discount_percentage,omitempty"`
+	BuyQuantity        *int                 `json:"buy_quantity,omitempty"`
+	GetQuantity        *int                 `json:"get_quantity,omitempty"`
+	BundleProducts     []uuid.UUID          `json:"bundle_products,omitempty"`
+	BundlePrice        *float64             `json:"bundle_price,omitempty"`
+	TargetProducts     []uuid.UUID          `json:"target_products,omitempty"`
+	TargetSchoolLevels []domain.SchoolLevel `json:"target_school_levels,omitempty"`
+	TargetCategories   []uuid.UUID          `json:"target_categories,omitempty"`
+	TargetLocations    []uuid.UUID          `json:"target_locations,omitempty"`
+	IsActive           *bool                `json:"is_active,omitempty"`
+	Budget             *float64             `json:"budget,omitempty"`
+}
+
+// CampaignResponse represents a campaign in API responses
+type CampaignResponse struct {
+	CampaignID         uuid.UUID            `json:"campaign_id"`
+	CampaignName       string               `json:"campaign_name"`
+	Description        *string              `json:"description,omitempty"`
+	StartDate          time.Time            `json:"start_date"`
+	EndDate            time.Time            `json:"end_date"`
+	PromotionType      domain.PromotionType `json:"promotion_type"`
+	DiscountPercentage *float64             `json:"discount_percentage,omitempty"`
+	BuyQuantity        *int                 `json:"buy_quantity,omitempty"`
+	GetQuantity        *int                 `json:"get_quantity,omitempty"`
+	BundleProducts     []uuid.UUID          `json:"bundle_products,omitempty"`
+	BundlePrice        *float64             `json:"bundle_price,omitempty"`
+	TargetProducts     []uuid.UUID          `json:"target_products,omitempty"`
+	TargetSchoolLevels []domain.SchoolLevel `json:"target_school_levels,omitempty"`
+	TargetCategories   []uuid.UUID          `json:"target_categories,omitempty"`
+	TargetLocations    []uuid.UUID          `json:"target_locations,omitempty"`
+	IsActive           bool                 `json:"is_active"`
+	Budget             *float64             `json:"budget,omitempty"`
+	RemainingBudget    *float64             `json:"remaining_budget,omitempty"`
+	DiscountGranted    float64              `json:"discount_granted"`
+	ActualSales        float64              `json:"actual_sales"`
+	CreatedAt          time.Time            `json:"created_at"`
+}
+
+// CampaignListResponse represents paginated campaign list
+type CampaignListResponse struct {
+	Campaigns []CampaignResponse `json:"campaigns"`
+	Total     int64              `json:"total"`
+	Limit     int                `json:"limit"`
+	Offset    int                `json:"offset"`
+}
+
+// ToCampaignDomain converts CampaignRequest to domain.Campaign
+func (r *CampaignRequest) ToCampaignDomain() *domain.Campaign {
+	isActive := true
+	if r.IsActive != nil {
+		isActive = *r.IsActive
+	}
+
+	return &domain.Campaign{
+		CampaignID:         uuid.New(),
+		CampaignName:       r.CampaignName,
+		Description:        r.Description,
+		StartDate:          r.StartDate,
+		EndDate:            r.EndDate,
+		PromotionType:      r.PromotionType,
+		DiscountPercentage: r.DiscountPercentage,
+		BuyQuantity:        r.BuyQuantity,
+		GetQuantity:        r.GetQuantity,
+		BundleProducts:     domain.UUIDArray(r.BundleProducts),
+		BundlePrice:        r.BundlePrice,
+		TargetProducts:     domain.UUIDArray(r.TargetProducts),
+		TargetSchoolLevels: domain.SchoolLevelArray(r.TargetSchoolLevels),
+		TargetCategories:   domain.UUIDArray(r.TargetCategories),
+		TargetLocations:    domain.UUIDArray(r.TargetLocations),
+		IsActive:           isActive,
+		Budget:             r.Budget,
+	}
+}
+
+// ToCampaignResponse converts domain.Campaign to CampaignResponse
+func ToCampaignResponse(c *domain.Campaign) CampaignResponse {
+	return CampaignResponse{
+		CampaignID:         c.CampaignID,
+		CampaignName:       c.CampaignName,
+		Description:        c.Description,
+		StartDate:          c.StartDate,
+		EndDate:            c.EndDate,
+		PromotionType:      c.PromotionType,
+		DiscountPercentage: c.DiscountPercentage,
+		BuyQuantity:        c.BuyQuantity,
+		GetQuantity:        c.GetQuantity,
+		BundleProducts:     c.BundleProducts,
+		BundlePrice:        c.BundlePrice,
+		TargetProducts:     c.TargetProducts,
+		TargetSchoolLevels: c.TargetSchoolLevels,
+		TargetCategories:   c.TargetCategories,
+		TargetLocations:    c.TargetLocations,
+		IsActive:           c.IsActive,
+		Budget:             c.Budget,
+		RemainingBudget:    c.RemainingBudget(),
+		DiscountGranted:    c.DiscountGranted,
+		ActualSales:        c.ActualSales,
+		CreatedAt:          c.CreatedAt,
+	}
+}
+
+// ToCampaignListResponse converts campaign slice to list response
+func ToCampaignListResponse(campaigns []domain.Campaign, total int64, limit, offset int) CampaignListResponse {
+	responses := make([]CampaignResponse, len(campaigns))
+	for i, c := range campaigns {
+		responses[i] = ToCampaignResponse(&c)
+	}
+	return CampaignListResponse{
+		Campaigns: responses,
+		Total:     total,
+		Limit:     limit,
+		Offset:    offset,
+	}
+}
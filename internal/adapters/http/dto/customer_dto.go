@@ -52,6 +52,7 @@ type CustomerChildRequest struct {
 	DateOfBirth  *time.Time          `json:"date_of_birth,omitempty"`
 	SchoolLevel  *domain.SchoolLevel `json:"school_level,omitempty"`
 	Grade        *string             `json:"grade,omitempty"`
+	SchoolID     *uuid.UUID          `json:"school_id,omitempty"`
 	SchoolName   *string             `json:"school_name,omitempty"`
 	Notes        *string             `json:"notes,omitempty"`
 }
@@ -65,6 +66,7 @@ type CustomerChildResponse struct {
 	DateOfBirth  *time.Time          `json:"date_of_birth,omitempty"`
 	SchoolLevel  *domain.SchoolLevel `json:"school_level,omitempty"`
 	Grade        *string             `json:"grade,omitempty"`
+	SchoolID     *uuid.UUID          `json:"school_id,omitempty"`
 	SchoolName   *string             `json:"school_name,omitempty"`
 	Notes        *string             `json:"notes,omitempty"`
 	CreatedAt    time.Time           `json:"created_at"`
@@ -138,6 +140,7 @@ func (r *CustomerChildRequest) ToCustomerChildDomain(customerID uuid.UUID) *doma
 		DateOfBirth: r.DateOfBirth,
 		SchoolLevel: r.SchoolLevel,
 		Grade:       r.Grade,
+		SchoolID:    r.SchoolID,
 		SchoolName:  r.SchoolName,
 		Notes:       r.Notes,
 	}
@@ -145,6 +148,11 @@ func (r *CustomerChildRequest) ToCustomerChildDomain(customerID uuid.UUID) *doma
 
 // ToCustomerChildResponse converts domain.CustomerChild to CustomerChildResponse
 func ToCustomerChildResponse(c *domain.CustomerChild) CustomerChildResponse {
+	schoolName := c.SchoolName
+	if c.School != nil {
+		schoolName = &c.School.Name
+	}
+
 	return CustomerChildResponse{
 		ChildID:     c.ChildID,
 		CustomerID:  c.CustomerID,
@@ -153,7 +161,8 @@ func ToCustomerChildResponse(c *domain.CustomerChild) CustomerChildResponse {
 		DateOfBirth: c.DateOfBirth,
 		SchoolLevel: c.SchoolLevel,
 		Grade:       c.Grade,
-		SchoolName:  c.SchoolName,
+		SchoolID:    c.SchoolID,
+		SchoolName:  schoolName,
 		Notes:       c.Notes,
 		CreatedAt:   c.CreatedAt,
 		UpdatedAt:   c.UpdatedAt,
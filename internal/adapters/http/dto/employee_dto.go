@@ -0,0 +1,190 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+// EmployeeRequest represents the request to create/update an employee
+type EmployeeRequest struct {
+	NationalID     string              `json:"national_id" validate:"required"`
+	FirstName      string              `json:"first_name" validate:"required"`
+	LastName       string              `json:"last_name" validate:"required"`
+	DateOfBirth    *time.Time          `json:"date_of_birth,omitempty"`
+	Gender         *domain.GenderType  `json:"gender,omitempty"`
+	LocationID     *uuid.UUID          `json:"location_id,omitempty"`
+	Address        *string             `json:"address,omitempty"`
+	Phone          *string             `json:"phone,omitempty"`
+	Email          *string             `json:"email,omitempty" validate:"omitempty,email"`
+	HireDate       time.Time           `json:"hire_date" validate:"required"`
+	JobTitle       string              `json:"job_title" validate:"required"`
+	Department     *string             `json:"department,omitempty"`
+	StoreID        *uuid.UUID          `json:"store_id,omitempty"` // Only on create; use the store endpoint afterwards
+	BaseSalary     float64             `json:"base_salary" validate:"required,gt=0"`
+	SalaryCurrency domain.CurrencyCode `json:"salary_currency,omitempty"`
+	PhotoURL       *string             `json:"photo_url,omitempty"`
+	UserID         *uuid.UUID          `json:"user_id,omitempty"` // Only on create; use the user endpoint afterwards
+}
+
+// EmployeeStatusRequest represents a status transition of an employee
+type EmployeeStatusRequest struct {
+	Date   *time.Time `json:"date,omitempty"`   // Termination or rehire date, defaults to today
+	Reason *string    `json:"reason,omitempty"` // Required to terminate
+}
+
+// AssignStoreRequest represents the move of an employee to another store
+type AssignStoreRequest struct {
+	StoreID   uuid.UUID  `json:"store_id" validate:"required"`
+	StartDate *time.Time `json:"start_date,omitempty"`
+	Reason    *string    `json:"reason,omitempty"`
+}
+
+// LinkUserRequest represents the link of a system user to an employee
+type LinkUserRequest struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+}
+
+// EmployeeResponse represents an employee in API responses
+type EmployeeResponse struct {
+	EmployeeID        uuid.UUID             `json:"employee_id"`
+	NationalID        string                `json:"national_id"`
+	FirstName         string                `json:"first_name"`
+	LastName          string                `json:"last_name"`
+	DateOfBirth       *time.Time            `json:"date_of_birth,omitempty"`
+	Gender            *domain.GenderType    `json:"gender,omitempty"`
+	LocationID        *uuid.UUID            `json:"location_id,omitempty"`
+	Address           *string               `json:"address,omitempty"`
+	Phone             *string               `json:"phone,omitempty"`
+	Email             *string               `json:"email,omitempty"`
+	HireDate          time.Time             `json:"hire_date"`
+	TerminationDate   *time.Time            `json:"termination_date,omitempty"`
+	TerminationReason *string               `json:"termination_reason,omitempty"`
+	JobTitle          string                `json:"job_title"`
+	Department        *string               `json:"department,omitempty"`
+	StoreID           *uuid.UUID            `json:"store_id,omitempty"`
+	StoreName         string                `json:"store_name,omitempty"`
+	BaseSalary        float64               `json:"base_salary"`
+	SalaryCurrency    domain.CurrencyCode   `json:"salary_currency"`
+	Status            domain.EmployeeStatus `json:"status"`
+	PhotoURL          *string               `json:"photo_url,omitempty"`
+	UserID            *uuid.UUID            `json:"user_id,omitempty"`
+	UserEmail         string                `json:"user_email,omitempty"`
+	UserStatus        domain.UserStatus     `json:"user_status,omitempty"`
+	CreatedAt         time.Time             `json:"created_at"`
+	UpdatedAt         time.Time             `json:"updated_at"`
+}
+
+// EmployeeListResponse represents a paginated list of employees
+type EmployeeListResponse struct {
+	Employees []EmployeeResponse `json:"employees"`
+	Total     int64              `json:"total"`
+	Limit     int                `json:"limit"`
+	Offset    int                `json:"offset"`
+}
+
+// EmployeeStoreAssignmentResponse represents a period an employee worked at a store
+type EmployeeStoreAssignmentResponse struct {
+	AssignmentID uuid.UUID  `json:"assignment_id"`
+	StoreID      uuid.UUID  `json:"store_id"`
+	StoreName    string     `json:"store_name,omitempty"`
+	StartDate    time.Time  `json:"start_date"`
+	EndDate      *time.Time `json:"end_date,omitempty"`
+	Reason       *string    `json:"reason,omitempty"`
+	AssignedBy   *uuid.UUID `json:"assigned_by,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// ToEmployeeDomain converts EmployeeRequest to domain.Employee
+func (r *EmployeeRequest) ToEmployeeDomain() *domain.Employee {
+	return &domain.Employee{
+		EmployeeID:     uuid.New(),
+		NationalID:     r.NationalID,
+		FirstName:      r.FirstName,
+		LastName:       r.LastName,
+		DateOfBirth:    r.DateOfBirth,
+		Gender:         r.Gender,
+		LocationID:     r.LocationID,
+		Address:        r.Address,
+		Phone:          r.Phone,
+		Email:          r.Email,
+		HireDate:       r.HireDate,
+		JobTitle:       r.JobTitle,
+		Department:     r.Department,
+		StoreID:        r.StoreID,
+		BaseSalary:     r.BaseSalary,
+		SalaryCurrency: r.SalaryCurrency,
+		PhotoURL:       r.PhotoURL,
+		UserID:         r.UserID,
+	}
+}
+
+// ToEmployeeResponse converts domain.Employee to EmployeeResponse
+func ToEmployeeResponse(e *domain.Employee) EmployeeResponse {
+	response := EmployeeResponse{
+		EmployeeID:        e.EmployeeID,
+		NationalID:        e.NationalID,
+		FirstName:         e.FirstName,
+		LastName:          e.LastName,
+		DateOfBirth:       e.DateOfBirth,
+		Gender:            e.Gender,
+		LocationID:        e.LocationID,
+		Address:           e.Address,
+		Phone:             e.Phone,
+		Email:             e.Email,
+		HireDate:          e.HireDate,
+		TerminationDate:   e.TerminationDate,
+		TerminationReason: e.TerminationReason,
+		JobTitle:          e.JobTitle,
+		Department:        e.Department,
+		StoreID:           e.StoreID,
+		BaseSalary:        e.BaseSalary,
+		SalaryCurrency:    e.SalaryCurrency,
+		Status:            e.Status,
+		PhotoURL:          e.PhotoURL,
+		UserID:            e.UserID,
+		CreatedAt:         e.CreatedAt,
+		UpdatedAt:         e.UpdatedAt,
+	}
+	if e.Store != nil {
+		response.StoreName = e.Store.Name
+	}
+	if e.User != nil {
+		response.UserEmail = e.User.Email
+		response.UserStatus = e.User.Status
+	}
+	return response
+}
+
+// ToEmployeeListResponse converts an employee slice to list response
+func ToEmployeeListResponse(employees []domain.Employee, total int64, limit, offset int) EmployeeListResponse {
+	responses := make([]EmployeeResponse, len(employees))
+	for i := range employees {
+		responses[i] = ToEmployeeResponse(&employees[i])
+	}
+	return EmployeeListResponse{
+		Employees: responses,
+		Total:     total,
+		Limit:     limit,
+		Offset:    offset,
+	}
+}
+
+// ToEmployeeStoreAssignmentResponse converts domain.EmployeeStoreAssignment to response
+func ToEmployeeStoreAssignmentResponse(a *domain.EmployeeStoreAssignment) EmployeeStoreAssignmentResponse {
+	response := EmployeeStoreAssignmentResponse{
+		AssignmentID: a.AssignmentID,
+		StoreID:      a.StoreID,
+		StartDate:    a.StartDate,
+		EndDate:      a.EndDate,
+		Reason:       a.Reason,
+		AssignedBy:   a.AssignedBy,
+		CreatedAt:    a.CreatedAt,
+	}
+	if a.Store != nil {
+		response.StoreName = a.Store.Name
+	}
+	return response
+}
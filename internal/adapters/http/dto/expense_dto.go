@@ -0,0 +1,253 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+// CreateExpenseRequest represents a request to register a store expense
+type CreateExpenseRequest struct {
+	StoreID       uuid.UUID              `json:"store_id" validate:"required"`
+	Category      domain.ExpenseCategory `json:"category" validate:"required"`
+	Description   string                 `json:"description" validate:"required"`
+	Amount        float64                `json:"amount" validate:"required,gt=0"`
+	Currency      domain.CurrencyCode    `json:"currency,omitempty"`
+	ExchangeRate  *float64               `json:"exchange_rate,omitempty"`
+	SupplierID    *uuid.UUID             `json:"supplier_id,omitempty"`
+	Reference     *string                `json:"reference,omitempty"`
+	AttachmentURL *string                `json:"attachment_url,omitempty"`
+	ExpenseDate   *time.Time             `json:"expense_date,omitempty"`
+}
+
+// ExpenseDecisionRequest represents an approval or rejection of an expense
+type ExpenseDecisionRequest struct {
+	Comments *string `json:"comments,omitempty"` // Required to reject
+}
+
+// ExpenseApprovalRuleRequest represents the request to create/update an expense approval rule
+type ExpenseApprovalRuleRequest struct {
+	Name      string     `json:"name" validate:"required"`
+	Level     int        `json:"level" validate:"required,min=1"`
+	RoleName  string     `json:"role_name" validate:"required"`
+	MinAmount float64    `json:"min_amount"`
+	StoreID   *uuid.UUID `json:"store_id,omitempty"`
+	IsActive  *bool      `json:"is_active,omitempty"`
+}
+
+// ExpenseResponse represents an expense in API responses
+type ExpenseResponse struct {
+	ExpenseID     uuid.UUID                    `json:"expense_id"`
+	StoreID       uuid.UUID                    `json:"store_id"`
+	StoreName     string                       `json:"store_name,omitempty"`
+	Category      domain.ExpenseCategory       `json:"category"`
+	Description   string                       `json:"description"`
+	Amount        float64                      `json:"amount"`
+	Currency      domain.CurrencyCode          `json:"currency"`
+	ExchangeRate  *float64                     `json:"exchange_rate,omitempty"`
+	AmountVES     float64                      `json:"amount_ves"`
+	SupplierID    *uuid.UUID                   `json:"supplier_id,omitempty"`
+	SupplierName  string                       `json:"supplier_name,omitempty"`
+	Reference     *string                      `json:"reference,omitempty"`
+	AttachmentURL *string                      `json:"attachment_url,omitempty"`
+	ExpenseDate   time.Time                    `json:"expense_date"`
+	Status        domain.ExpenseApprovalStatus `json:"status"`
+	RequestedBy   uuid.UUID                    `json:"requested_by"`
+	RequesterName string                       `json:"requester_name,omitempty"`
+	DecidedAt     *time.Time                   `json:"decided_at,omitempty"`
+	Approvals     []ExpenseApprovalResponse    `json:"approvals"`
+	CreatedAt     time.Time                    `json:"created_at"`
+}
+
+// ExpenseApprovalResponse represents a step of the approval chain of an expense
+type ExpenseApprovalResponse struct {
+	ApprovalID   uuid.UUID                    `json:"approval_id"`
+	Level        int                          `json:"level"`
+	RoleName     string                       `json:"role_name"`
+	Status       domain.ExpenseApprovalStatus `json:"status"`
+	ApproverID   *uuid.UUID                   `json:"approver_id,omitempty"`
+	ApproverName string                       `json:"approver_name,omitempty"`
+	Comments     *string                      `json:"comments,omitempty"`
+	DecidedAt    *time.Time                   `json:"decided_at,omitempty"`
+}
+
+// ExpenseListResponse represents a paginated list of expenses
+type ExpenseListResponse struct {
+	Expenses []ExpenseResponse `json:"expenses"`
+	Total    int64             `json:"total"`
+	Limit    int               `json:"limit"`
+	Offset   int               `json:"offset"`
+}
+
+// ExpenseApprovalRuleResponse represents an expense approval rule in API responses
+type ExpenseApprovalRuleResponse struct {
+	RuleID    uuid.UUID  `json:"rule_id"`
+	Name      string     `json:"name"`
+	Level     int        `json:"level"`
+	RoleName  string     `json:"role_name"`
+	MinAmount float64    `json:"min_amount"`
+	StoreID   *uuid.UUID `json:"store_id,omitempty"`
+	IsActive  bool       `json:"is_active"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// ExpenseSummaryRowResponse represents what a store spent on a category in a month
+type ExpenseSummaryRowResponse struct {
+	Month     string                          `json:"month"` // YYYY-MM
+	StoreID   uuid.UUID                       `json:"store_id"`
+	StoreName string                          `json:"store_name,omitempty"`
+	Category  domain.ExpenseCategory          `json:"category"`
+	Expenses  int                             `json:"expenses"`
+	Amounts   map[domain.CurrencyCode]float64 `json:"amounts"`
+	AmountVES float64                         `json:"amount_ves"`
+}
+
+// ExpenseSummaryResponse represents the monthly expense summary
+type ExpenseSummaryResponse struct {
+	From       time.Time                          `json:"from"`
+	To         time.Time                          `json:"to"`
+	Rows       []ExpenseSummaryRowResponse        `json:"rows"`
+	ByCategory map[domain.ExpenseCategory]float64 `json:"by_category"`
+	TotalVES   float64                            `json:"total_ves"`
+}
+
+// ToServiceRequest converts CreateExpenseRequest to a service request
+func (r *CreateExpenseRequest) ToServiceRequest(userID uuid.UUID) services.CreateExpenseRequest {
+	return services.CreateExpenseRequest{
+		StoreID:       r.StoreID,
+		Category:      r.Category,
+		Description:   r.Description,
+		Amount:        r.Amount,
+		Currency:      r.Currency,
+		ExchangeRate:  r.ExchangeRate,
+		SupplierID:    r.SupplierID,
+		Reference:     r.Reference,
+		AttachmentURL: r.AttachmentURL,
+		ExpenseDate:   r.ExpenseDate,
+		UserID:        userID,
+	}
+}
+
+// ToApprovalRuleDomain converts ExpenseApprovalRuleRequest to domain.ExpenseApprovalRule
+func (r *ExpenseApprovalRuleRequest) ToApprovalRuleDomain() *domain.ExpenseApprovalRule {
+	isActive := true
+	if r.IsActive != nil {
+		isActive = *r.IsActive
+	}
+
+	return &domain.ExpenseApprovalRule{
+		RuleID:    uuid.New(),
+		Name:      r.Name,
+		Level:     r.Level,
+		RoleName:  r.RoleName,
+		MinAmount: r.MinAmount,
+		StoreID:   r.StoreID,
+		IsActive:  isActive,
+	}
+}
+
+// ToExpenseResponse converts an expense to response
+func ToExpenseResponse(expense *domain.Expense) ExpenseResponse {
+	response := ExpenseResponse{
+		ExpenseID:     expense.ExpenseID,
+		StoreID:       expense.StoreID,
+		Category:      expense.Category,
+		Description:   expense.Description,
+		Amount:        expense.Amount,
+		Currency:      expense.Currency,
+		ExchangeRate:  expense.ExchangeRate,
+		AmountVES:     expense.AmountVES,
+		SupplierID:    expense.SupplierID,
+		Reference:     expense.Reference,
+		AttachmentURL: expense.AttachmentURL,
+		ExpenseDate:   expense.ExpenseDate,
+		Status:        expense.Status,
+		RequestedBy:   expense.RequestedBy,
+		DecidedAt:     expense.DecidedAt,
+		Approvals:     make([]ExpenseApprovalResponse, len(expense.Approvals)),
+		CreatedAt:     expense.CreatedAt,
+	}
+	if expense.Store != nil {
+		response.StoreName = expense.Store.Name
+	}
+	if expense.Supplier != nil {
+		response.SupplierName = expense.Supplier.BusinessName
+	}
+	if expense.Requester != nil {
+		response.RequesterName = expense.Requester.FirstName + " " + expense.Requester.LastName
+	}
+
+	for i, a := range expense.Approvals {
+		response.Approvals[i] = ExpenseApprovalResponse{
+			ApprovalID: a.ApprovalID,
+			Level:      a.Level,
+			RoleName:   a.RoleName,
+			Status:     a.Status,
+			ApproverID: a.ApproverID,
+			Comments:   a.Comments,
+			DecidedAt:  a.DecidedAt,
+		}
+		if a.Approver != nil {
+			response.Approvals[i].ApproverName = a.Approver.FirstName + " " + a.Approver.LastName
+		}
+	}
+
+	return response
+}
+
+// ToExpenseListResponse converts an expense slice to list response
+func ToExpenseListResponse(expenses []domain.Expense, total int64, limit, offset int) ExpenseListResponse {
+	responses := make([]ExpenseResponse, len(expenses))
+	for i := range expenses {
+		responses[i] = ToExpenseResponse(&expenses[i])
+	}
+	return ExpenseListResponse{
+		Expenses: responses,
+		Total:    total,
+		Limit:    limit,
+		Offset:   offset,
+	}
+}
+
+// ToExpenseApprovalRuleResponse converts domain.ExpenseApprovalRule to response
+func ToExpenseApprovalRuleResponse(r *domain.ExpenseApprovalRule) ExpenseApprovalRuleResponse {
+	return ExpenseApprovalRuleResponse{
+		RuleID:    r.RuleID,
+		Name:      r.Name,
+		Level:     r.Level,
+		RoleName:  r.RoleName,
+		MinAmount: r.MinAmount,
+		StoreID:   r.StoreID,
+		IsActive:  r.IsActive,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+// ToExpenseSummaryResponse converts a service expense summary to response
+func ToExpenseSummaryResponse(s *services.ExpenseSummary) ExpenseSummaryResponse {
+	rows := make([]ExpenseSummaryRowResponse, len(s.Rows))
+	for i, row := range s.Rows {
+		rows[i] = ExpenseSummaryRowResponse{
+			Month:     row.Month.Format("2006-01"),
+			StoreID:   row.StoreID,
+			StoreName: row.StoreName,
+			Category:  row.Category,
+			Expenses:  row.Expenses,
+			Amounts:   row.Amounts,
+			AmountVES: row.AmountVES,
+		}
+	}
+
+	return ExpenseSummaryResponse{
+		From:       s.From,
+		To:         s.To,
+		Rows:       rows,
+		ByCategory: s.ByCategory,
+		TotalVES:   s.TotalVES,
+	}
+}
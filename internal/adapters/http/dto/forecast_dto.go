@@ -0,0 +1,142 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+// GenerateForecastRequest represents the request to forecast a school season
+type GenerateForecastRequest struct {
+	SchoolYear        string                 `json:"school_year" validate:"required"`
+	ProductIDs        []uuid.UUID            `json:"product_ids,omitempty"`
+	Method            *domain.ForecastMethod `json:"method,omitempty"`
+	HistorySeasons    int                    `json:"history_seasons,omitempty"`
+	DefaultEnrollment int                    `json:"default_enrollment,omitempty"`
+}
+
+// EvaluateForecastRequest represents the request to compare a season's forecasts with its sales
+type EvaluateForecastRequest struct {
+	SchoolYear string `json:"school_year" validate:"required"`
+}
+
+// DemandForecastResponse represents a demand forecast in API responses
+type DemandForecastResponse struct {
+	ForecastID         uuid.UUID              `json:"forecast_id"`
+	ProductID          uuid.UUID              `json:"product_id"`
+	ProductName        string                 `json:"product_name,omitempty"`
+	ProductSKU         string                 `json:"product_sku,omitempty"`
+	SchoolYear         string                 `json:"school_year"`
+	ForecastedQuantity int                    `json:"forecasted_quantity"`
+	ConfidenceLevel    *float64               `json:"confidence_level,omitempty"`
+	ForecastMethod     *string                `json:"forecast_method,omitempty"`
+	HistoricalData     map[string]interface{} `json:"historical_data,omitempty"`
+	ActualQuantity     *int                   `json:"actual_quantity,omitempty"`
+	EvaluatedAt        *time.Time             `json:"evaluated_at,omitempty"`
+	CreatedAt          time.Time              `json:"created_at"`
+}
+
+// DemandForecastListResponse represents paginated forecast list
+type DemandForecastListResponse struct {
+	Forecasts []DemandForecastResponse `json:"forecasts"`
+	Total     int64                    `json:"total"`
+	Limit     int                      `json:"limit"`
+	Offset    int                      `json:"offset"`
+}
+
+// ForecastAccuracyResponse represents the accuracy of a product forecast
+type ForecastAccuracyResponse struct {
+	ForecastID      uuid.UUID `json:"forecast_id"`
+	ProductID       uuid.UUID `json:"product_id"`
+	ProductName     string    `json:"product_name,omitempty"`
+	Method          string    `json:"method,omitempty"`
+	Forecasted      int       `json:"forecasted"`
+	Actual          int       `json:"actual"`
+	Error           int       `json:"error"`
+	AbsPercentError *float64  `json:"abs_percent_error,omitempty"`
+}
+
+// ForecastEvaluationResponse represents the accuracy of a season's forecasts
+type ForecastEvaluationResponse struct {
+	SchoolYear string                     `json:"school_year"`
+	Items      []ForecastAccuracyResponse `json:"items"`
+	MAPE       *float64                   `json:"mape,omitempty"`
+	Bias       float64                    `json:"bias"`
+}
+
+// ToServiceRequest converts DTO to service request
+func (r *GenerateForecastRequest) ToServiceRequest(userID uuid.UUID) services.GenerateForecastRequest {
+	return services.GenerateForecastRequest{
+		SchoolYear:        r.SchoolYear,
+		ProductIDs:        r.ProductIDs,
+		Method:            r.Method,
+		HistorySeasons:    r.HistorySeasons,
+		DefaultEnrollment: r.DefaultEnrollment,
+		UserID:            userID,
+	}
+}
+
+// ToDemandForecastResponse converts domain.DemandForecast to DemandForecastResponse
+func ToDemandForecastResponse(f *domain.DemandForecast) DemandForecastResponse {
+	response := DemandForecastResponse{
+		ForecastID:         f.ForecastID,
+		ProductID:          f.ProductID,
+		SchoolYear:         f.SchoolYear,
+		ForecastedQuantity: f.ForecastedQuantity,
+		ConfidenceLevel:    f.ConfidenceLevel,
+		ForecastMethod:     f.ForecastMethod,
+		HistoricalData:     f.HistoricalData,
+		ActualQuantity:     f.ActualQuantity,
+		EvaluatedAt:        f.EvaluatedAt,
+		CreatedAt:          f.CreatedAt,
+	}
+	if f.Product != nil {
+		response.ProductName = f.Product.Name
+		response.ProductSKU = f.Product.SKU
+	}
+	return response
+}
+
+// ToDemandForecastResponses converts a forecast slice to responses
+func ToDemandForecastResponses(forecasts []domain.DemandForecast) []DemandForecastResponse {
+	responses := make([]DemandForecastResponse, len(forecasts))
+	for i := range forecasts {
+		responses[i] = ToDemandForecastResponse(&forecasts[i])
+	}
+	return responses
+}
+
+// ToDemandForecastListResponse converts forecast slice to list response
+func ToDemandForecastListResponse(forecasts []domain.DemandForecast, total int64, limit, offset int) DemandForecastListResponse {
+	return DemandForecastListResponse{
+		Forecasts: ToDemandForecastResponses(forecasts),
+		Total:     total,
+		Limit:     limit,
+		Offset:    offset,
+	}
+}
+
+// ToForecastEvaluationResponse converts a service evaluation to its response
+func ToForecastEvaluationResponse(e *services.ForecastEvaluation) ForecastEvaluationResponse {
+	items := make([]ForecastAccuracyResponse, len(e.Items))
+	for i, item := range e.Items {
+		items[i] = ForecastAccuracyResponse{
+			ForecastID:      item.ForecastID,
+			ProductID:       item.ProductID,
+			ProductName:     item.ProductName,
+			Method:          item.Method,
+			Forecasted:      item.Forecasted,
+			Actual:          item.Actual,
+			Error:           item.Error,
+			AbsPercentError: item.AbsPercentError,
+		}
+	}
+	return ForecastEvaluationResponse{
+		SchoolYear: e.SchoolYear,
+		Items:      items,
+		MAPE:       e.MAPE,
+		Bias:       e.Bias,
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
 )
 
 // InventoryMovementRequest represents a request to create an inventory movement
@@ -152,3 +153,82 @@ type AdjustmentRequest struct {
 	Quantity    float64   `json:"quantity" validate:"required"`
 	Notes       string    `json:"notes" validate:"required"`
 }
+
+// AllocationItemRequest represents a product quantity to allocate
+type AllocationItemRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  float64   `json:"quantity" validate:"required,gt=0"`
+}
+
+// AllocationPlanRequest represents a request to preview which warehouses serve an order
+type AllocationPlanRequest struct {
+	StoreID     uuid.UUID                 `json:"store_id" validate:"required"`
+	Strategy    domain.AllocationStrategy `json:"strategy,omitempty"`
+	WarehouseID *uuid.UUID                `json:"warehouse_id,omitempty"`
+	Items       []AllocationItemRequest   `json:"items" validate:"required,min=1"`
+}
+
+// AllocationLineResponse represents the quantity of a product taken from a warehouse
+type AllocationLineResponse struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	WarehouseID uuid.UUID `json:"warehouse_id"`
+	Quantity    float64   `json:"quantity"`
+}
+
+// AllocationShortageResponse represents a quantity no warehouse can cover
+type AllocationShortageResponse struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Requested float64   `json:"requested"`
+	Available float64   `json:"available"`
+}
+
+// AllocationPlanResponse represents an allocation plan in API responses
+type AllocationPlanResponse struct {
+	StoreID   uuid.UUID                    `json:"store_id"`
+	Strategy  domain.AllocationStrategy    `json:"strategy"`
+	Complete  bool                         `json:"complete"`
+	Lines     []AllocationLineResponse     `json:"lines"`
+	Shortages []AllocationShortageResponse `json:"shortages,omitempty"`
+}
+
+// ToServiceRequest converts DTO to service request
+func (r *AllocationPlanRequest) ToServiceRequest() services.AllocationRequest {
+	items := make([]services.AllocationItem, len(r.Items))
+	for i, item := range r.Items {
+		items[i] = services.AllocationItem{ProductID: item.ProductID, Quantity: item.Quantity}
+	}
+
+	return services.AllocationRequest{
+		StoreID:     r.StoreID,
+		Items:       items,
+		Strategy:    r.Strategy,
+		WarehouseID: r.WarehouseID,
+	}
+}
+
+// ToAllocationPlanResponse converts an allocation plan to response
+func ToAllocationPlanResponse(p *services.AllocationPlan) AllocationPlanResponse {
+	response := AllocationPlanResponse{
+		StoreID:  p.StoreID,
+		Strategy: p.Strategy,
+		Complete: len(p.Shortages) == 0,
+		Lines:    make([]AllocationLineResponse, len(p.Lines)),
+	}
+
+	for i, line := range p.Lines {
+		response.Lines[i] = AllocationLineResponse{
+			ProductID:   line.ProductID,
+			WarehouseID: line.WarehouseID,
+			Quantity:    line.Quantity,
+		}
+	}
+	for _, shortage := range p.Shortages {
+		response.Shortages = append(response.Shortages, AllocationShortageResponse{
+			ProductID: shortage.ProductID,
+			Requested: shortage.Requested,
+			Available: shortage.Available,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,91 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+// JobRunResponse represents a job run in API responses
+type JobRunResponse struct {
+	RunID          uuid.UUID  `json:"run_id"`
+	JobName        string     `json:"job_name"`
+	Trigger        string     `json:"trigger"`
+	Status         string     `json:"status"`
+	ScheduledFor   *time.Time `json:"scheduled_for,omitempty"`
+	StartedAt      time.Time  `json:"started_at"`
+	FinishedAt     *time.Time `json:"finished_at,omitempty"`
+	DurationMs     int64      `json:"duration_ms"`
+	ItemsProcessed int        `json:"items_processed"`
+	Error          *string    `json:"error,omitempty"`
+	Instance       string     `json:"instance"`
+	TriggeredBy    *uuid.UUID `json:"triggered_by,omitempty"`
+}
+
+// JobRunListResponse represents a paginated list of job runs
+type JobRunListResponse struct {
+	Runs   []JobRunResponse `json:"runs"`
+	Total  int64            `json:"total"`
+	Limit  int              `json:"limit"`
+	Offset int              `json:"offset"`
+}
+
+// JobResponse represents a registered job in API responses
+type JobResponse struct {
+	Name     string          `json:"name"`
+	Schedule string          `json:"schedule"`
+	NextRun  *time.Time      `json:"next_run,omitempty"`
+	LastRun  *JobRunResponse `json:"last_run,omitempty"`
+}
+
+// ToJobRunResponse converts domain.JobRun to response
+func ToJobRunResponse(r *domain.JobRun) JobRunResponse {
+	return JobRunResponse{
+		RunID:          r.RunID,
+		JobName:        r.JobName,
+		Trigger:        string(r.Trigger),
+		Status:         string(r.Status),
+		ScheduledFor:   r.ScheduledFor,
+		StartedAt:      r.StartedAt,
+		FinishedAt:     r.FinishedAt,
+		DurationMs:     r.DurationMs,
+		ItemsProcessed: r.ItemsProcessed,
+		Error:          r.Error,
+		Instance:       r.Instance,
+		TriggeredBy:    r.TriggeredBy,
+	}
+}
+
+// ToJobRunListResponse converts job run slice to list response
+func ToJobRunListResponse(runs []domain.JobRun, total int64, limit, offset int) JobRunListResponse {
+	responses := make([]JobRunResponse, len(runs))
+	for i := range runs {
+		responses[i] = ToJobRunResponse(&runs[i])
+	}
+
+	return JobRunListResponse{
+		Runs:   responses,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}
+}
+
+// ToJobResponses converts the registered jobs to responses
+func ToJobResponses(jobs []services.JobInfo) []JobResponse {
+	responses := make([]JobResponse, len(jobs))
+	for i, job := range jobs {
+		responses[i] = JobResponse{
+			Name:     job.Name,
+			Schedule: job.Schedule,
+			NextRun:  job.NextRun,
+		}
+		if job.LastRun != nil {
+			lastRun := ToJobRunResponse(job.LastRun)
+			responses[i].LastRun = &lastRun
+		}
+	}
+	return responses
+}
@@ -0,0 +1,178 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+// LoyaltyRuleRequest represents the request to create/update an earn rule
+type LoyaltyRuleRequest struct {
+	Name        string              `json:"name" validate:"required"`
+	Currency    domain.CurrencyCode `json:"currency,omitempty"`
+	CategoryID  *uuid.UUID          `json:"category_id,omitempty"`
+	SpendAmount float64             `json:"spend_amount" validate:"required,gt=0"`
+	Points      int                 `json:"points" validate:"required,gt=0"`
+	PointValue  *float64            `json:"point_value,omitempty"`
+	ExpiryDays  *int                `json:"expiry_days,omitempty"`
+	IsActive    *bool               `json:"is_active,omitempty"`
+}
+
+// LoyaltyRuleResponse represents an earn rule in API responses
+type LoyaltyRuleResponse struct {
+	RuleID      uuid.UUID           `json:"rule_id"`
+	Name        string              `json:"name"`
+	Currency    domain.CurrencyCode `json:"currency"`
+	CategoryID  *uuid.UUID          `json:"category_id,omitempty"`
+	SpendAmount float64             `json:"spend_amount"`
+	Points      int                 `json:"points"`
+	PointValue  *float64            `json:"point_value,omitempty"`
+	ExpiryDays  *int                `json:"expiry_days,omitempty"`
+	IsActive    bool                `json:"is_active"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// LoyaltyRuleListResponse represents paginated earn rule list
+type LoyaltyRuleListResponse struct {
+	Rules  []LoyaltyRuleResponse `json:"rules"`
+	Total  int64                 `json:"total"`
+	Limit  int                   `json:"limit"`
+	Offset int                   `json:"offset"`
+}
+
+// AdjustPointsRequest represents a manual loyalty balance correction
+type AdjustPointsRequest struct {
+	Points int    `json:"points" validate:"required"`
+	Reason string `json:"reason" validate:"required"`
+}
+
+// LoyaltyTransactionResponse represents a ledger entry in API responses
+type LoyaltyTransactionResponse struct {
+	TransactionID       uuid.UUID                     `json:"transaction_id"`
+	CustomerID          uuid.UUID                     `json:"customer_id"`
+	TransactionType     domain.LoyaltyTransactionType `json:"transaction_type"`
+	Points              int                           `json:"points"`
+	BalanceAfter        int                           `json:"balance_after"`
+	RemainingPoints     int                           `json:"remaining_points"`
+	ExpiresAt           *time.Time                    `json:"expires_at,omitempty"`
+	Amount              *float64                      `json:"amount,omitempty"`
+	Currency            *domain.CurrencyCode          `json:"currency,omitempty"`
+	SaleID              *uuid.UUID                    `json:"sale_id,omitempty"`
+	RuleID              *uuid.UUID                    `json:"rule_id,omitempty"`
+	SourceTransactionID *uuid.UUID                    `json:"source_transaction_id,omitempty"`
+	Description         *string                       `json:"description,omitempty"`
+	CreatedAt           time.Time                     `json:"created_at"`
+	CreatedBy           *uuid.UUID                    `json:"created_by,omitempty"`
+}
+
+// LoyaltyStatementResponse represents a customer points statement
+type LoyaltyStatementResponse struct {
+	CustomerID     uuid.UUID                    `json:"customer_id"`
+	From           time.Time                    `json:"from"`
+	To             time.Time                    `json:"to"`
+	OpeningBalance int                          `json:"opening_balance"`
+	ClosingBalance int                          `json:"closing_balance"`
+	TotalEarned    int                          `json:"total_earned"`
+	TotalRedeemed  int                          `json:"total_redeemed"`
+	TotalExpired   int                          `json:"total_expired"`
+	TotalAdjusted  int                          `json:"total_adjusted"`
+	TotalReturned  int                          `json:"total_returned"`
+	Transactions   []LoyaltyTransactionResponse `json:"transactions"`
+}
+
+// ToLoyaltyRuleDomain converts LoyaltyRuleRequest to domain.LoyaltyRule
+func (r *LoyaltyRuleRequest) ToLoyaltyRuleDomain() *domain.LoyaltyRule {
+	isActive := true
+	if r.IsActive != nil {
+		isActive = *r.IsActive
+	}
+
+	return &domain.LoyaltyRule{
+		RuleID:      uuid.New(),
+		Name:        r.Name,
+		Currency:    r.Currency,
+		CategoryID:  r.CategoryID,
+		SpendAmount: r.SpendAmount,
+		Points:      r.Points,
+		PointValue:  r.PointValue,
+		ExpiryDays:  r.ExpiryDays,
+		IsActive:    isActive,
+	}
+}
+
+// ToLoyaltyRuleResponse converts domain.LoyaltyRule to LoyaltyRuleResponse
+func ToLoyaltyRuleResponse(r *domain.LoyaltyRule) LoyaltyRuleResponse {
+	return LoyaltyRuleResponse{
+		RuleID:      r.RuleID,
+		Name:        r.Name,
+		Currency:    r.Currency,
+		CategoryID:  r.CategoryID,
+		SpendAmount: r.SpendAmount,
+		Points:      r.Points,
+		PointValue:  r.PointValue,
+		ExpiryDays:  r.ExpiryDays,
+		IsActive:    r.IsActive,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+// ToLoyaltyRuleListResponse converts rule slice to list response
+func ToLoyaltyRuleListResponse(rules []domain.LoyaltyRule, total int64, limit, offset int) LoyaltyRuleListResponse {
+	responses := make([]LoyaltyRuleResponse, len(rules))
+	for i, r := range rules {
+		responses[i] = ToLoyaltyRuleResponse(&r)
+	}
+	return LoyaltyRuleListResponse{
+		Rules:  responses,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}
+}
+
+// ToLoyaltyTransactionResponse converts domain.LoyaltyTransaction to response
+func ToLoyaltyTransactionResponse(t *domain.LoyaltyTransaction) LoyaltyTransactionResponse {
+	return LoyaltyTransactionResponse{
+		TransactionID:       t.TransactionID,
+		CustomerID:          t.CustomerID,
+		TransactionType:     t.TransactionType,
+		Points:              t.Points,
+		BalanceAfter:        t.BalanceAfter,
+		RemainingPoints:     t.RemainingPoints,
+		ExpiresAt:           t.ExpiresAt,
+		Amount:              t.Amount,
+		Currency:            t.Currency,
+		SaleID:              t.SaleID,
+		RuleID:              t.RuleID,
+		SourceTransactionID: t.SourceTransactionID,
+		Description:         t.Description,
+		CreatedAt:           t.CreatedAt,
+		CreatedBy:           t.CreatedBy,
+	}
+}
+
+// ToLoyaltyStatementResponse converts a service statement to response
+func ToLoyaltyStatementResponse(s *services.LoyaltyStatement) LoyaltyStatementResponse {
+	transactions := make([]LoyaltyTransactionResponse, len(s.Transactions))
+	for i, t := range s.Transactions {
+		transactions[i] = ToLoyaltyTransactionResponse(&t)
+	}
+
+	return LoyaltyStatementResponse{
+		CustomerID:     s.CustomerID,
+		From:           s.From,
+		To:             s.To,
+		OpeningBalance: s.OpeningBalance,
+		ClosingBalance: s.ClosingBalance,
+		TotalEarned:    s.TotalEarned,
+		TotalRedeemed:  s.TotalRedeemed,
+		TotalExpired:   s.TotalExpired,
+		TotalAdjusted:  s.TotalAdjusted,
+		TotalReturned:  s.TotalReturned,
+		Transactions:   transactions,
+	}
+}
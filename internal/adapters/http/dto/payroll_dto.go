@@ -0,0 +1,314 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+// PayrollConceptRequest represents the request to create/update a payroll concept
+type PayrollConceptRequest struct {
+	Code            string                        `json:"code" validate:"required"`
+	Name            string                        `json:"name" validate:"required"`
+	Type            domain.PayrollConceptType     `json:"type" validate:"required"`
+	CalculationType domain.PayrollCalculationType `json:"calculation_type" validate:"required"`
+	Amount          float64                       `json:"amount,omitempty"`
+	Currency        domain.CurrencyCode           `json:"currency,omitempty"`
+	Formula         *string                       `json:"formula,omitempty"`
+	IsActive        *bool                         `json:"is_active,omitempty"`
+}
+
+// CreatePayrollPeriodRequest represents a request to open a pay period
+type CreatePayrollPeriodRequest struct {
+	Name         string                  `json:"name" validate:"required"`
+	StoreID      *uuid.UUID              `json:"store_id,omitempty"`
+	Frequency    domain.PayrollFrequency `json:"frequency" validate:"required"`
+	StartDate    time.Time               `json:"start_date" validate:"required"`
+	EndDate      time.Time               `json:"end_date" validate:"required"`
+	PayDate      *time.Time              `json:"pay_date,omitempty"`
+	ExchangeRate *float64                `json:"exchange_rate,omitempty"`
+	Notes        *string                 `json:"notes,omitempty"`
+}
+
+// CalculatePayrollRequest represents a calculation run of a pay period
+type CalculatePayrollRequest struct {
+	ExchangeRate *float64 `json:"exchange_rate,omitempty"` // Replaces the period exchange rate
+}
+
+// PayrollConceptResponse represents a payroll concept in API responses
+type PayrollConceptResponse struct {
+	ConceptID       uuid.UUID                     `json:"concept_id"`
+	Code            string                        `json:"code"`
+	Name            string                        `json:"name"`
+	Type            domain.PayrollConceptType     `json:"type"`
+	CalculationType domain.PayrollCalculationType `json:"calculation_type"`
+	Amount          float64                       `json:"amount,omitempty"`
+	Currency        domain.CurrencyCode           `json:"currency,omitempty"`
+	Formula         *string                       `json:"formula,omitempty"`
+	IsActive        bool                          `json:"is_active"`
+	CreatedAt       time.Time                     `json:"created_at"`
+	UpdatedAt       time.Time                     `json:"updated_at"`
+}
+
+// PayrollPeriodResponse represents a pay period in API responses
+type PayrollPeriodResponse struct {
+	PeriodID     uuid.UUID                  `json:"period_id"`
+	Name         string                     `json:"name"`
+	StoreID      *uuid.UUID                 `json:"store_id,omitempty"`
+	StoreName    string                     `json:"store_name,omitempty"`
+	Frequency    domain.PayrollFrequency    `json:"frequency"`
+	StartDate    time.Time                  `json:"start_date"`
+	EndDate      time.Time                  `json:"end_date"`
+	PayDate      time.Time                  `json:"pay_date"`
+	Status       domain.PayrollPeriodStatus `json:"status"`
+	ExchangeRate *float64                   `json:"exchange_rate,omitempty"`
+	Notes        *string                    `json:"notes,omitempty"`
+	CalculatedAt *time.Time                 `json:"calculated_at,omitempty"`
+	ProcessedAt  *time.Time                 `json:"processed_at,omitempty"`
+	PaidAt       *time.Time                 `json:"paid_at,omitempty"`
+	ClosedAt     *time.Time                 `json:"closed_at,omitempty"`
+	CreatedAt    time.Time                  `json:"created_at"`
+}
+
+// PayrollPeriodListResponse represents a paginated list of pay periods
+type PayrollPeriodListResponse struct {
+	Periods []PayrollPeriodResponse `json:"periods"`
+	Total   int64                   `json:"total"`
+	Limit   int                     `json:"limit"`
+	Offset  int                     `json:"offset"`
+}
+
+// PayslipLineResponse represents a concept of a payslip
+type PayslipLineResponse struct {
+	Code   string                    `json:"code"`
+	Name   string                    `json:"name"`
+	Type   domain.PayrollConceptType `json:"type"`
+	Amount float64                   `json:"amount"`
+}
+
+// PayslipResponse represents a payslip in API responses
+type PayslipResponse struct {
+	PayslipID             uuid.UUID             `json:"payslip_id"`
+	PeriodID              uuid.UUID             `json:"period_id"`
+	EmployeeID            uuid.UUID             `json:"employee_id"`
+	EmployeeName          string                `json:"employee_name,omitempty"`
+	NationalID            string                `json:"national_id,omitempty"`
+	StoreID               *uuid.UUID            `json:"store_id,omitempty"`
+	StoreName             string                `json:"store_name,omitempty"`
+	Currency              domain.CurrencyCode   `json:"currency"`
+	BaseSalary            float64               `json:"base_salary"`
+	PeriodDays            int                   `json:"period_days"`
+	WorkedDays            int                   `json:"worked_days"`
+	BasicPay              float64               `json:"basic_pay"`
+	TotalAllowances       float64               `json:"total_allowances"`
+	TotalDeductions       float64               `json:"total_deductions"`
+	EmployerContributions float64               `json:"employer_contributions"`
+	NetPay                float64               `json:"net_pay"`
+	Lines                 []PayslipLineResponse `json:"lines"`
+}
+
+// PayrollAmountsResponse represents payroll totals in one currency
+type PayrollAmountsResponse struct {
+	Employees             int     `json:"employees"`
+	BasicPay              float64 `json:"basic_pay"`
+	Allowances            float64 `json:"allowances"`
+	Deductions            float64 `json:"deductions"`
+	EmployerContributions float64 `json:"employer_contributions"`
+	NetPay                float64 `json:"net_pay"`
+}
+
+// PayrollTotalsRowResponse represents what a period pays the employees of a store in one currency
+type PayrollTotalsRowResponse struct {
+	StoreID   *uuid.UUID          `json:"store_id,omitempty"`
+	StoreName string              `json:"store_name,omitempty"`
+	Currency  domain.CurrencyCode `json:"currency"`
+	PayrollAmountsResponse
+}
+
+// PayrollTotalsResponse represents the totals of a pay period
+type PayrollTotalsResponse struct {
+	PeriodID        uuid.UUID                                      `json:"period_id"`
+	Rows            []PayrollTotalsRowResponse                     `json:"rows"`
+	ByCurrency      map[domain.CurrencyCode]PayrollAmountsResponse `json:"by_currency"`
+	NetPayVES       *float64                                       `json:"net_pay_ves,omitempty"`
+	EmployerCostVES *float64                                       `json:"employer_cost_ves,omitempty"`
+}
+
+// ToPayrollConceptDomain converts PayrollConceptRequest to domain.PayrollConcept
+func (r *PayrollConceptRequest) ToPayrollConceptDomain() *domain.PayrollConcept {
+	isActive := true
+	if r.IsActive != nil {
+		isActive = *r.IsActive
+	}
+
+	return &domain.PayrollConcept{
+		ConceptID:       uuid.New(),
+		Code:            r.Code,
+		Name:            r.Name,
+		Type:            r.Type,
+		CalculationType: r.CalculationType,
+		Amount:          r.Amount,
+		Currency:        r.Currency,
+		Formula:         r.Formula,
+		IsActive:        isActive,
+	}
+}
+
+// ToServiceRequest converts CreatePayrollPeriodRequest to a service request
+func (r *CreatePayrollPeriodRequest) ToServiceRequest(userID uuid.UUID) services.CreatePayrollPeriodRequest {
+	return services.CreatePayrollPeriodRequest{
+		Name:         r.Name,
+		StoreID:      r.StoreID,
+		Frequency:    r.Frequency,
+		StartDate:    r.StartDate,
+		EndDate:      r.EndDate,
+		PayDate:      r.PayDate,
+		ExchangeRate: r.ExchangeRate,
+		Notes:        r.Notes,
+		UserID:       userID,
+	}
+}
+
+// ToPayrollConceptResponse converts domain.PayrollConcept to response
+func ToPayrollConceptResponse(c *domain.PayrollConcept) PayrollConceptResponse {
+	response := PayrollConceptResponse{
+		ConceptID:       c.ConceptID,
+		Code:            c.Code,
+		Name:            c.Name,
+		Type:            c.Type,
+		CalculationType: c.CalculationType,
+		Formula:         c.Formula,
+		IsActive:        c.IsActive,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+	if c.CalculationType == domain.PayrollCalculationFixed {
+		response.Amount = c.Amount
+		response.Currency = c.Currency
+	}
+	return response
+}
+
+// ToPayrollPeriodResponse converts a pay period to response
+func ToPayrollPeriodResponse(p *domain.PayrollPeriod) PayrollPeriodResponse {
+	response := PayrollPeriodResponse{
+		PeriodID:     p.PeriodID,
+		Name:         p.Name,
+		StoreID:      p.StoreID,
+		Frequency:    p.Frequency,
+		StartDate:    p.StartDate,
+		EndDate:      p.EndDate,
+		PayDate:      p.PayDate,
+		Status:       p.Status,
+		ExchangeRate: p.ExchangeRate,
+		Notes:        p.Notes,
+		CalculatedAt: p.CalculatedAt,
+		ProcessedAt:  p.ProcessedAt,
+		PaidAt:       p.PaidAt,
+		ClosedAt:     p.ClosedAt,
+		CreatedAt:    p.CreatedAt,
+	}
+	if p.Store != nil {
+		response.StoreName = p.Store.Name
+	}
+	return response
+}
+
+// ToPayrollPeriodListResponse converts a pay period slice to list response
+func ToPayrollPeriodListResponse(periods []domain.PayrollPeriod, total int64, limit, offset int) PayrollPeriodListResponse {
+	responses := make([]PayrollPeriodResponse, len(periods))
+	for i := range periods {
+		responses[i] = ToPayrollPeriodResponse(&periods[i])
+	}
+	return PayrollPeriodListResponse{
+		Periods: responses,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+	}
+}
+
+// ToPayslipResponse converts a payslip to response
+func ToPayslipResponse(p *domain.Payslip) PayslipResponse {
+	response := PayslipResponse{
+		PayslipID:             p.PayslipID,
+		PeriodID:              p.PeriodID,
+		EmployeeID:            p.EmployeeID,
+		StoreID:               p.StoreID,
+		Currency:              p.Currency,
+		BaseSalary:            p.BaseSalary,
+		PeriodDays:            p.PeriodDays,
+		WorkedDays:            p.WorkedDays,
+		BasicPay:              p.BasicPay,
+		TotalAllowances:       p.TotalAllowances,
+		TotalDeductions:       p.TotalDeductions,
+		EmployerContributions: p.EmployerContributions,
+		NetPay:                p.NetPay,
+		Lines:                 make([]PayslipLineResponse, len(p.Lines)),
+	}
+	if p.Employee != nil {
+		response.EmployeeName = p.Employee.FirstName + " " + p.Employee.LastName
+		response.NationalID = p.Employee.NationalID
+	}
+	if p.Store != nil {
+		response.StoreName = p.Store.Name
+	}
+	for i, line := range p.Lines {
+		response.Lines[i] = PayslipLineResponse{
+			Code:   line.Code,
+			Name:   line.Name,
+			Type:   line.Type,
+			Amount: line.Amount,
+		}
+	}
+	return response
+}
+
+// ToPayslipResponses converts a payslip slice to responses
+func ToPayslipResponses(payslips []domain.Payslip) []PayslipResponse {
+	responses := make([]PayslipResponse, len(payslips))
+	for i := range payslips {
+		responses[i] = ToPayslipResponse(&payslips[i])
+	}
+	return responses
+}
+
+// ToPayrollTotalsResponse converts service payroll totals to response
+func ToPayrollTotalsResponse(t *services.PayrollTotals) PayrollTotalsResponse {
+	rows := make([]PayrollTotalsRowResponse, len(t.Rows))
+	for i, row := range t.Rows {
+		rows[i] = PayrollTotalsRowResponse{
+			StoreID:                row.StoreID,
+			StoreName:              row.StoreName,
+			Currency:               row.Currency,
+			PayrollAmountsResponse: toPayrollAmountsResponse(row.PayrollAmounts),
+		}
+	}
+
+	byCurrency := make(map[domain.CurrencyCode]PayrollAmountsResponse, len(t.ByCurrency))
+	for currency, amounts := range t.ByCurrency {
+		byCurrency[currency] = toPayrollAmountsResponse(amounts)
+	}
+
+	return PayrollTotalsResponse{
+		PeriodID:        t.PeriodID,
+		Rows:            rows,
+		ByCurrency:      byCurrency,
+		NetPayVES:       t.NetPayVES,
+		EmployerCostVES: t.EmployerCostVES,
+	}
+}
+
+func toPayrollAmountsResponse(a services.PayrollAmounts) PayrollAmountsResponse {
+	return PayrollAmountsResponse{
+		Employees:             a.Employees,
+		BasicPay:              a.BasicPay,
+		Allowances:            a.Allowances,
+		Deductions:            a.Deductions,
+		EmployerContributions: a.EmployerContributions,
+		NetPay:                a.NetPay,
+	}
+}
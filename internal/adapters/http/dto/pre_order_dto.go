@@ -0,0 +1,187 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+// PreOrderItemRequest represents an item in a pre-order
+type PreOrderItemRequest struct {
+	ProductID           uuid.UUID  `json:"product_id" validate:"required"`
+	Quantity            float64    `json:"quantity" validate:"required,gt=0"`
+	ExpectedArrivalDate *time.Time `json:"expected_arrival_date,omitempty"`
+}
+
+// CreatePreOrderRequest represents a request to create a pre-order
+type CreatePreOrderRequest struct {
+	CustomerID        uuid.UUID             `json:"customer_id" validate:"required"`
+	StoreID           uuid.UUID             `json:"store_id" validate:"required"`
+	Items             []PreOrderItemRequest `json:"items" validate:"required,min=1"`
+	DepositAmount     float64               `json:"deposit_amount" validate:"gte=0"`
+	Currency          domain.CurrencyCode   `json:"currency" validate:"required"`
+	ExpectedReadyDate *time.Time            `json:"expected_ready_date,omitempty"`
+	Notes             *string               `json:"notes,omitempty"`
+}
+
+// MarkPreOrderReadyRequest represents a request to mark a pre-order as ready for pickup
+type MarkPreOrderReadyRequest struct {
+	PickupDays int `json:"pickup_days" validate:"gte=0"`
+}
+
+// DeliverPreOrderRequest represents a request to deliver a ready pre-order
+type DeliverPreOrderRequest struct {
+	PaymentMethod    domain.PaymentMethod `json:"payment_method" validate:"required"`
+	PaymentReference *string              `json:"payment_reference,omitempty"`
+	ExchangeRate     *float64             `json:"exchange_rate,omitempty"`
+}
+
+// CancelPreOrderRequest represents a request to cancel a pre-order
+type CancelPreOrderRequest struct {
+	Reason string `json:"reason"`
+}
+
+// PreOrderItemResponse represents a pre-order item in API responses
+type PreOrderItemResponse struct {
+	PreOrderItemID      uuid.UUID  `json:"pre_order_item_id"`
+	PreOrderID          uuid.UUID  `json:"pre_order_id"`
+	ProductID           uuid.UUID  `json:"product_id"`
+	ProductName         string     `json:"product_name,omitempty"`
+	Quantity            float64    `json:"quantity"`
+	UnitPrice           float64    `json:"unit_price"`
+	TotalAmount         float64    `json:"total_amount"`
+	IsAvailable         bool       `json:"is_available"`
+	WarehouseID         *uuid.UUID `json:"warehouse_id,omitempty"`
+	ExpectedArrivalDate *time.Time `json:"expected_arrival_date,omitempty"`
+}
+
+// PreOrderResponse represents a pre-order in API responses
+type PreOrderResponse struct {
+	PreOrderID         uuid.UUID              `json:"pre_order_id"`
+	PreOrderNumber     string                 `json:"pre_order_number"`
+	CustomerID         uuid.UUID              `json:"customer_id"`
+	StoreID            *uuid.UUID             `json:"store_id,omitempty"`
+	Status             domain.PreOrderStatus  `json:"status"`
+	OrderDate          time.Time              `json:"order_date"`
+	ExpectedReadyDate  *time.Time             `json:"expected_ready_date,omitempty"`
+	ReadyDate          *time.Time             `json:"ready_date,omitempty"`
+	PickupDeadline     *time.Time             `json:"pickup_deadline,omitempty"`
+	NotificationSentAt *time.Time             `json:"notification_sent_at,omitempty"`
+	TotalAmount        float64                `json:"total_amount"`
+	DepositPaid        float64                `json:"deposit_paid"`
+	Balance            float64                `json:"balance"`
+	Currency           domain.CurrencyCode    `json:"currency"`
+	Notes              *string                `json:"notes,omitempty"`
+	ConfirmedAt        *time.Time             `json:"confirmed_at,omitempty"`
+	Items              []PreOrderItemResponse `json:"items,omitempty"`
+	CreatedAt          time.Time              `json:"created_at"`
+}
+
+// PreOrderListResponse represents paginated pre-order list
+type PreOrderListResponse struct {
+	PreOrders []PreOrderResponse `json:"pre_orders"`
+	Total     int64              `json:"total"`
+	Limit     int                `json:"limit"`
+	Offset    int                `json:"offset"`
+}
+
+// ToServiceRequest converts DTO to service request
+func (r *CreatePreOrderRequest) ToServiceRequest(userID uuid.UUID) services.CreatePreOrderRequest {
+	items := make([]services.PreOrderItem, len(r.Items))
+	for i, item := range r.Items {
+		items[i] = services.PreOrderItem{
+			ProductID:           item.ProductID,
+			Quantity:            item.Quantity,
+			ExpectedArrivalDate: item.ExpectedArrivalDate,
+		}
+	}
+
+	return services.CreatePreOrderRequest{
+		CustomerID:        r.CustomerID,
+		StoreID:           r.StoreID,
+		Items:             items,
+		DepositAmount:     r.DepositAmount,
+		Currency:          r.Currency,
+		ExpectedReadyDate: r.ExpectedReadyDate,
+		Notes:             r.Notes,
+		UserID:            userID,
+	}
+}
+
+// ToServiceRequest converts DTO to service request
+func (r *DeliverPreOrderRequest) ToServiceRequest(preOrderID, userID uuid.UUID) services.DeliverPreOrderRequest {
+	return services.DeliverPreOrderRequest{
+		PreOrderID:       preOrderID,
+		PaymentMethod:    r.PaymentMethod,
+		PaymentReference: r.PaymentReference,
+		ExchangeRate:     r.ExchangeRate,
+		UserID:           userID,
+	}
+}
+
+// ToPreOrderItemResponse converts domain.PreOrderItem to response
+func ToPreOrderItemResponse(i *domain.PreOrderItem) PreOrderItemResponse {
+	response := PreOrderItemResponse{
+		PreOrderItemID:      i.PreOrderItemID,
+		PreOrderID:          i.PreOrderID,
+		ProductID:           i.ProductID,
+		Quantity:            i.Quantity,
+		UnitPrice:           i.UnitPrice,
+		TotalAmount:         i.TotalAmount,
+		IsAvailable:         i.IsAvailable,
+		WarehouseID:         i.WarehouseID,
+		ExpectedArrivalDate: i.ExpectedArrivalDate,
+	}
+	if i.Product != nil {
+		response.ProductName = i.Product.Name
+	}
+	return response
+}
+
+// ToPreOrderResponse converts domain.PreOrder to response
+func ToPreOrderResponse(p *domain.PreOrder) PreOrderResponse {
+	var items []PreOrderItemResponse
+	if p.Items != nil {
+		items = make([]PreOrderItemResponse, len(p.Items))
+		for i, item := range p.Items {
+			items[i] = ToPreOrderItemResponse(&item)
+		}
+	}
+
+	return PreOrderResponse{
+		PreOrderID:         p.PreOrderID,
+		PreOrderNumber:     p.PreOrderNumber,
+		CustomerID:         p.CustomerID,
+		StoreID:            p.StoreID,
+		Status:             p.Status,
+		OrderDate:          p.OrderDate,
+		ExpectedReadyDate:  p.ExpectedReadyDate,
+		ReadyDate:          p.ReadyDate,
+		PickupDeadline:     p.PickupDeadline,
+		NotificationSentAt: p.NotificationSentAt,
+		TotalAmount:        p.TotalAmount,
+		DepositPaid:        p.DepositPaid,
+		Balance:            p.TotalAmount - p.DepositPaid,
+		Currency:           p.Currency,
+		Notes:              p.Notes,
+		ConfirmedAt:        p.ConfirmedAt,
+		Items:              items,
+		CreatedAt:          p.CreatedAt,
+	}
+}
+
+// ToPreOrderListResponse converts pre-order slice to list response
+func ToPreOrderListResponse(preOrders []domain.PreOrder, total int64, limit, offset int) PreOrderListResponse {
+	responses := make([]PreOrderResponse, len(preOrders))
+	for i, p := range preOrders {
+		responses[i] = ToPreOrderResponse(&p)
+	}
+	return PreOrderListResponse{
+		PreOrders: responses,
+		Total:     total,
+		Limit:     limit,
+		Offset:    offset,
+	}
+}
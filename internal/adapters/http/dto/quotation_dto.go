@@ -0,0 +1,229 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+// QuotationItemRequest represents an item in a quotation
+type QuotationItemRequest struct {
+	ProductID      uuid.UUID `json:"product_id" validate:"required"`
+	Quantity       float64   `json:"quantity" validate:"required,gt=0"`
+	UnitPrice      *float64  `json:"unit_price,omitempty"`
+	DiscountAmount float64   `json:"discount_amount,omitempty"`
+}
+
+// CreateQuotationRequest represents a request to create a quotation
+type CreateQuotationRequest struct {
+	CustomerID   *uuid.UUID             `json:"customer_id,omitempty"`
+	StoreID      uuid.UUID              `json:"store_id" validate:"required"`
+	Currency     domain.CurrencyCode    `json:"currency,omitempty"`
+	Items        []QuotationItemRequest `json:"items" validate:"required,min=1"`
+	ValidityDays int                    `json:"validity_days,omitempty"`
+	Notes        *string                `json:"notes,omitempty"`
+}
+
+// UpdateQuotationRequest represents a request to update a draft quotation
+type UpdateQuotationRequest struct {
+	CustomerID   *uuid.UUID             `json:"customer_id,omitempty"`
+	Items        []QuotationItemRequest `json:"items" validate:"required,min=1"`
+	ValidityDays int                    `json:"validity_days,omitempty"`
+	Notes        *string                `json:"notes,omitempty"`
+}
+
+// ConvertQuotationRequest represents a request to convert a quotation
+type ConvertQuotationRequest struct {
+	ConvertTo        domain.QuotationConversionType `json:"convert_to" validate:"required"`
+	WarehouseID      *uuid.UUID                     `json:"warehouse_id,omitempty"`
+	PaymentMethod    *domain.PaymentMethod          `json:"payment_method,omitempty"`
+	PaymentReference *string                        `json:"payment_reference,omitempty"`
+	ExchangeRate     *float64                       `json:"exchange_rate,omitempty"`
+	CreditDays       *int                           `json:"credit_days,omitempty"`
+	ChildID          *uuid.UUID                     `json:"child_id,omitempty"`
+	DepositAmount    float64                        `json:"deposit_amount,omitempty"`
+	ExpirationDays   int                            `json:"expiration_days,omitempty"`
+}
+
+// QuotationItemResponse represents a quotation item in API responses
+type QuotationItemResponse struct {
+	QuotationItemID uuid.UUID  `json:"quotation_item_id"`
+	ProductID       uuid.UUID  `json:"product_id"`
+	ProductName     string     `json:"product_name,omitempty"`
+	Quantity        float64    `json:"quantity"`
+	UnitPrice       float64    `json:"unit_price"`
+	DiscountAmount  float64    `json:"discount_amount"`
+	CampaignID      *uuid.UUID `json:"campaign_id,omitempty"`
+	TotalAmount     float64    `json:"total_amount"`
+}
+
+// QuotationResponse represents a quotation in API responses
+type QuotationResponse struct {
+	QuotationID     uuid.UUID                       `json:"quotation_id"`
+	QuotationNumber string                          `json:"quotation_number"`
+	CustomerID      *uuid.UUID                      `json:"customer_id,omitempty"`
+	StoreID         uuid.UUID                       `json:"store_id"`
+	Status          domain.QuotationStatus          `json:"status"`
+	Currency        domain.CurrencyCode             `json:"currency"`
+	Subtotal        float64                         `json:"subtotal"`
+	DiscountAmount  float64                         `json:"discount_amount"`
+	TotalAmount     float64                         `json:"total_amount"`
+	ValidUntil      time.Time                       `json:"valid_until"`
+	Notes           *string                         `json:"notes,omitempty"`
+	SentAt          *time.Time                      `json:"sent_at,omitempty"`
+	AcceptedAt      *time.Time                      `json:"accepted_at,omitempty"`
+	ConvertedAt     *time.Time                      `json:"converted_at,omitempty"`
+	ConvertedTo     *domain.QuotationConversionType `json:"converted_to,omitempty"`
+	SaleID          *uuid.UUID                      `json:"sale_id,omitempty"`
+	ReservationID   *uuid.UUID                      `json:"reservation_id,omitempty"`
+	Items           []QuotationItemResponse         `json:"items,omitempty"`
+	CreatedAt       time.Time                       `json:"created_at"`
+}
+
+// QuotationListResponse represents paginated quotation list
+type QuotationListResponse struct {
+	Quotations []QuotationResponse `json:"quotations"`
+	Total      int64               `json:"total"`
+	Limit      int                 `json:"limit"`
+	Offset     int                 `json:"offset"`
+}
+
+// QuotationConversionResponse contains the documents created from a quotation
+type QuotationConversionResponse struct {
+	Quotation          QuotationResponse           `json:"quotation"`
+	Sale               *SaleResponse               `json:"sale,omitempty"`
+	AccountsReceivable *AccountsReceivableResponse `json:"accounts_receivable,omitempty"`
+	Reservation        *ReservationResponse        `json:"reservation,omitempty"`
+}
+
+func toQuotationItems(items []QuotationItemRequest) []services.QuotationItem {
+	result := make([]services.QuotationItem, len(items))
+	for i, item := range items {
+		result[i] = services.QuotationItem{
+			ProductID:      item.ProductID,
+			Quantity:       item.Quantity,
+			UnitPrice:      item.UnitPrice,
+			DiscountAmount: item.DiscountAmount,
+		}
+	}
+	return result
+}
+
+// ToServiceRequest converts DTO to service request
+func (r *CreateQuotationRequest) ToServiceRequest(userID uuid.UUID) services.CreateQuotationRequest {
+	return services.CreateQuotationRequest{
+		CustomerID:   r.CustomerID,
+		StoreID:      r.StoreID,
+		Currency:     r.Currency,
+		Items:        toQuotationItems(r.Items),
+		ValidityDays: r.ValidityDays,
+		Notes:        r.Notes,
+		UserID:       userID,
+	}
+}
+
+// ToServiceRequest converts DTO to service request
+func (r *UpdateQuotationRequest) ToServiceRequest(quotationID, userID uuid.UUID) services.UpdateQuotationRequest {
+	return services.UpdateQuotationRequest{
+		QuotationID:  quotationID,
+		CustomerID:   r.CustomerID,
+		Items:        toQuotationItems(r.Items),
+		ValidityDays: r.ValidityDays,
+		Notes:        r.Notes,
+		UserID:       userID,
+	}
+}
+
+// ToServiceRequest converts DTO to service request
+func (r *ConvertQuotationRequest) ToServiceRequest(quotationID, userID uuid.UUID) services.ConvertQuotationRequest {
+	return services.ConvertQuotationRequest{
+		QuotationID:      quotationID,
+		ConvertTo:        r.ConvertTo,
+		WarehouseID:      r.WarehouseID,
+		PaymentMethod:    r.PaymentMethod,
+		PaymentReference: r.PaymentReference,
+		ExchangeRate:     r.ExchangeRate,
+		CreditDays:       r.CreditDays,
+		ChildID:          r.ChildID,
+		DepositAmount:    r.DepositAmount,
+		ExpirationDays:   r.ExpirationDays,
+		UserID:           userID,
+	}
+}
+
+// ToQuotationResponse converts domain.Quotation to response
+func ToQuotationResponse(q *domain.Quotation) QuotationResponse {
+	items := make([]QuotationItemResponse, len(q.Items))
+	for i, item := range q.Items {
+		items[i] = QuotationItemResponse{
+			QuotationItemID: item.QuotationItemID,
+			ProductID:       item.ProductID,
+			Quantity:        item.Quantity,
+			UnitPrice:       item.UnitPrice,
+			DiscountAmount:  item.DiscountAmount,
+			CampaignID:      item.CampaignID,
+			TotalAmount:     item.TotalAmount,
+		}
+		if item.Product != nil {
+			items[i].ProductName = item.Product.Name
+		}
+	}
+
+	return QuotationResponse{
+		QuotationID:     q.QuotationID,
+		QuotationNumber: q.QuotationNumber,
+		CustomerID:      q.CustomerID,
+		StoreID:         q.StoreID,
+		Status:          q.Status,
+		Currency:        q.Currency,
+		Subtotal:        q.Subtotal,
+		DiscountAmount:  q.DiscountAmount,
+		TotalAmount:     q.TotalAmount,
+		ValidUntil:      q.ValidUntil,
+		Notes:           q.Notes,
+		SentAt:          q.SentAt,
+		AcceptedAt:      q.AcceptedAt,
+		ConvertedAt:     q.ConvertedAt,
+		ConvertedTo:     q.ConvertedTo,
+		SaleID:          q.SaleID,
+		ReservationID:   q.ReservationID,
+		Items:           items,
+		CreatedAt:       q.CreatedAt,
+	}
+}
+
+// ToQuotationListResponse converts quotation slice to list response
+func ToQuotationListResponse(quotations []domain.Quotation, total int64, limit, offset int) QuotationListResponse {
+	responses := make([]QuotationResponse, len(quotations))
+	for i, q := range quotations {
+		responses[i] = ToQuotationResponse(&q)
+	}
+	return QuotationListResponse{
+		Quotations: responses,
+		Total:      total,
+		Limit:      limit,
+		Offset:     offset,
+	}
+}
+
+// ToQuotationConversionResponse converts a conversion result to response
+func ToQuotationConversionResponse(c *services.QuotationConversion) QuotationConversionResponse {
+	response := QuotationConversionResponse{
+		Quotation: ToQuotationResponse(c.Quotation),
+	}
+	if c.Sale != nil {
+		sale := ToSaleResponse(c.Sale)
+		response.Sale = &sale
+	}
+	if c.Receivable != nil {
+		ar := ToAccountsReceivableResponse(c.Receivable)
+		response.AccountsReceivable = &ar
+	}
+	if c.Reservation != nil {
+		reservation := ToReservationResponse(c.Reservation)
+		response.Reservation = &reservation
+	}
+	return response
+}
@@ -20,6 +20,7 @@ type CreateReservationRequest struct {
 	ChildID        *uuid.UUID               `json:"child_id,omitempty"`
 	ListID         *uuid.UUID               `json:"list_id,omitempty"`
 	StoreID        uuid.UUID                `json:"store_id" validate:"required"`
+	WarehouseID    *uuid.UUID               `json:"warehouse_id,omitempty"`
 	Items          []ReservationItemRequest `json:"items" validate:"required,min=1"`
 	DepositAmount  float64                  `json:"deposit_amount" validate:"gte=0"`
 	Currency       domain.CurrencyCode      `json:"currency" validate:"required"`
@@ -27,25 +28,117 @@ type CreateReservationRequest struct {
 	Notes          *string                  `json:"notes,omitempty"`
 }
 
-// FulfillReservationRequest represents a request to fulfill a reservation
+// FulfillReservationItemRequest represents the quantity of a reservation item picked up
+type FulfillReservationItemRequest struct {
+	ReservationItemID uuid.UUID `json:"reservation_item_id" validate:"required"`
+	Quantity          float64   `json:"quantity" validate:"required,gt=0"`
+}
+
+// FulfillReservationRequest represents a request to fulfill a reservation.
+// Leaving items empty picks up everything still reserved.
 type FulfillReservationRequest struct {
-	PaymentMethod    domain.PaymentMethod `json:"payment_method" validate:"required"`
-	PaymentReference *string              `json:"payment_reference,omitempty"`
-	ExchangeRate     *float64             `json:"exchange_rate,omitempty"`
+	Items              []FulfillReservationItemRequest `json:"items,omitempty"`
+	DepositApplication domain.DepositApplication       `json:"deposit_application,omitempty"`
+	PaymentMethod      domain.PaymentMethod            `json:"payment_method" validate:"required"`
+	PaymentReference   *string                         `json:"payment_reference,omitempty"`
+	ExchangeRate       *float64                        `json:"exchange_rate,omitempty"`
+}
+
+// ReservationPaymentRequest represents a deposit or installment paid on a reservation
+type ReservationPaymentRequest struct {
+	Amount        float64              `json:"amount" validate:"gt=0"`
+	Currency      domain.CurrencyCode  `json:"currency,omitempty"`
+	ExchangeRate  *float64             `json:"exchange_rate,omitempty"`
+	PaymentMethod domain.PaymentMethod `json:"payment_method" validate:"required"`
+	Reference     *string              `json:"reference,omitempty"`
+	Notes         *string              `json:"notes,omitempty"`
+}
+
+// CancelReservationRequest represents a request to cancel a reservation
+type CancelReservationRequest struct {
+	Reason          string                `json:"reason"`
+	ForfeitDeposit  bool                  `json:"forfeit_deposit"`
+	RefundMethod    *domain.PaymentMethod `json:"refund_method,omitempty"`
+	RefundReference *string               `json:"refund_reference,omitempty"`
+}
+
+// ReservationItemChangeRequest represents a change to a reservation item.
+// Omit reservation_item_id and set product_id to add an item; quantity 0 removes one.
+type ReservationItemChangeRequest struct {
+	ReservationItemID *uuid.UUID `json:"reservation_item_id,omitempty"`
+	ProductID         *uuid.UUID `json:"product_id,omitempty"`
+	Quantity          float64    `json:"quantity" validate:"gte=0"`
+}
+
+// ModifyReservationItemsRequest represents a request to add, remove or resize reservation items
+type ModifyReservationItemsRequest struct {
+	Items  []ReservationItemChangeRequest `json:"items" validate:"required,min=1"`
+	Reason string                         `json:"reason"`
+}
+
+// ExtendReservationRequest represents a request to postpone a reservation expiration
+type ExtendReservationRequest struct {
+	Days   int    `json:"days" validate:"required,gt=0"`
+	Reason string `json:"reason"`
+}
+
+// ChangeReservationStoreRequest represents a request to change the pickup store
+type ChangeReservationStoreRequest struct {
+	StoreID uuid.UUID `json:"store_id" validate:"required"`
+	Reason  string    `json:"reason"`
+}
+
+// ReservationModificationResponse represents an entry of a reservation change history
+type ReservationModificationResponse struct {
+	ModificationID     uuid.UUID                          `json:"modification_id"`
+	ReservationID      uuid.UUID                          `json:"reservation_id"`
+	ModificationType   domain.ReservationModificationType `json:"modification_type"`
+	ReservationItemID  *uuid.UUID                         `json:"reservation_item_id,omitempty"`
+	ProductID          *uuid.UUID                         `json:"product_id,omitempty"`
+	PreviousQuantity   *float64                           `json:"previous_quantity,omitempty"`
+	NewQuantity        *float64                           `json:"new_quantity,omitempty"`
+	PreviousExpiration *time.Time                         `json:"previous_expiration,omitempty"`
+	NewExpiration      *time.Time                         `json:"new_expiration,omitempty"`
+	PreviousStoreID    *uuid.UUID                         `json:"previous_store_id,omitempty"`
+	NewStoreID         *uuid.UUID                         `json:"new_store_id,omitempty"`
+	PreviousTotal      float64                            `json:"previous_total"`
+	NewTotal           float64                            `json:"new_total"`
+	Reason             *string                            `json:"reason,omitempty"`
+	CreatedAt          time.Time                          `json:"created_at"`
+	CreatedBy          *uuid.UUID                         `json:"created_by,omitempty"`
+}
+
+// ReservationPaymentResponse represents a reservation payment in API responses
+type ReservationPaymentResponse struct {
+	PaymentID     uuid.UUID                     `json:"payment_id"`
+	ReservationID uuid.UUID                     `json:"reservation_id"`
+	PaymentType   domain.ReservationPaymentType `json:"payment_type"`
+	Amount        float64                       `json:"amount"`
+	Currency      domain.CurrencyCode           `json:"currency"`
+	ExchangeRate  *float64                      `json:"exchange_rate,omitempty"`
+	AppliedAmount float64                       `json:"applied_amount"`
+	PaymentMethod *domain.PaymentMethod         `json:"payment_method,omitempty"`
+	Reference     *string                       `json:"reference,omitempty"`
+	Notes         *string                       `json:"notes,omitempty"`
+	PaymentDate   time.Time                     `json:"payment_date"`
+	ReceivedBy    *uuid.UUID                    `json:"received_by,omitempty"`
 }
 
 // ReservationItemResponse represents a reservation item in API responses
 // ReservationItemResponse represents a reservation item in API responses
 type ReservationItemResponse struct {
-	ReservationItemID uuid.UUID `json:"reservation_item_id"`
-	ReservationID     uuid.UUID `json:"reservation_id"`
-	ProductID         uuid.UUID `json:"product_id"`
-	Quantity          float64   `json:"quantity"`
-	ReservedQuantity  float64   `json:"reserved_quantity"`
-	FulfilledQuantity float64   `json:"fulfilled_quantity"`
-	UnitPrice         float64   `json:"unit_price"`
-	TotalAmount       float64   `json:"total_amount"`
-	IsFulfilled       bool      `json:"is_fulfilled"`
+	ReservationItemID uuid.UUID  `json:"reservation_item_id"`
+	ReservationID     uuid.UUID  `json:"reservation_id"`
+	ProductID         uuid.UUID  `json:"product_id"`
+	Quantity          float64    `json:"quantity"`
+	ReservedQuantity  float64    `json:"reserved_quantity"`
+	FulfilledQuantity float64    `json:"fulfilled_quantity"`
+	UnitPrice         float64    `json:"unit_price"`
+	DiscountAmount    float64    `json:"discount_amount"`
+	CampaignID        *uuid.UUID `json:"campaign_id,omitempty"`
+	WarehouseID       *uuid.UUID `json:"warehouse_id,omitempty"`
+	TotalAmount       float64    `json:"total_amount"`
+	IsFulfilled       bool       `json:"is_fulfilled"`
 }
 
 // ReservationResponse represents a reservation in API responses
@@ -62,12 +155,18 @@ type ReservationResponse struct {
 	PickupDate        *time.Time               `json:"pickup_date,omitempty"`
 	TotalAmount       float64                  `json:"total_amount"`
 	DepositAmount     float64                  `json:"deposit_amount"`
+	AmountPaid        float64                  `json:"amount_paid"`
+	DepositApplied    float64                  `json:"deposit_applied"`
+	ForfeitedAmount   float64                  `json:"forfeited_amount"`
 	Balance           float64                  `json:"balance"`
 	Currency          domain.CurrencyCode      `json:"currency"`
 	Notes             *string                  `json:"notes,omitempty"`
 	ReminderSentAt    *time.Time               `json:"reminder_sent_at,omitempty"`
+	ExtensionCount    int                      `json:"extension_count"`
 	FulfilledAt       *time.Time               `json:"fulfilled_at,omitempty"`
 	Items             []ReservationItemResponse `json:"items,omitempty"`
+	Payments          []ReservationPaymentResponse `json:"payments,omitempty"`
+	Modifications     []ReservationModificationResponse `json:"modifications,omitempty"`
 	CreatedAt         time.Time                `json:"created_at"`
 }
 
@@ -94,6 +193,7 @@ func (r *CreateReservationRequest) ToServiceRequest(userID uuid.UUID) services.C
 		ChildID:        r.ChildID,
 		ListID:         r.ListID,
 		StoreID:        r.StoreID,
+		WarehouseID:    r.WarehouseID,
 		Items:          items,
 		DepositAmount:  r.DepositAmount,
 		Currency:       r.Currency,
@@ -105,15 +205,143 @@ func (r *CreateReservationRequest) ToServiceRequest(userID uuid.UUID) services.C
 
 // ToFulfillReservationServiceRequest converts DTO to service request
 func (r *FulfillReservationRequest) ToServiceRequest(reservationID, userID uuid.UUID) services.FulfillReservationRequest {
+	items := make([]services.FulfillReservationItem, len(r.Items))
+	for i, item := range r.Items {
+		items[i] = services.FulfillReservationItem{
+			ReservationItemID: item.ReservationItemID,
+			Quantity:          item.Quantity,
+		}
+	}
+
 	return services.FulfillReservationRequest{
-		ReservationID:    reservationID,
-		PaymentMethod:    r.PaymentMethod,
-		PaymentReference: r.PaymentReference,
-		ExchangeRate:     r.ExchangeRate,
-		UserID:           userID,
+		ReservationID:      reservationID,
+		Items:              items,
+		DepositApplication: r.DepositApplication,
+		PaymentMethod:      r.PaymentMethod,
+		PaymentReference:   r.PaymentReference,
+		ExchangeRate:       r.ExchangeRate,
+		UserID:             userID,
+	}
+}
+
+// ToServiceRequest converts DTO to service request
+func (r *ReservationPaymentRequest) ToServiceRequest(reservationID, userID uuid.UUID) services.ReservationPaymentRequest {
+	return services.ReservationPaymentRequest{
+		ReservationID: reservationID,
+		Amount:        r.Amount,
+		Currency:      r.Currency,
+		ExchangeRate:  r.ExchangeRate,
+		PaymentMethod: r.PaymentMethod,
+		Reference:     r.Reference,
+		Notes:         r.Notes,
+		UserID:        userID,
+	}
+}
+
+// ToServiceRequest converts DTO to service request
+func (r *CancelReservationRequest) ToServiceRequest(reservationID, userID uuid.UUID) services.CancelReservationRequest {
+	return services.CancelReservationRequest{
+		ReservationID:   reservationID,
+		Reason:          r.Reason,
+		ForfeitDeposit:  r.ForfeitDeposit,
+		RefundMethod:    r.RefundMethod,
+		RefundReference: r.RefundReference,
+		UserID:          userID,
 	}
 }
 
+// ToServiceRequest converts DTO to service request
+func (r *ModifyReservationItemsRequest) ToServiceRequest(reservationID, userID uuid.UUID) services.ModifyReservationItemsRequest {
+	changes := make([]services.ReservationItemChange, len(r.Items))
+	for i, item := range r.Items {
+		changes[i] = services.ReservationItemChange{
+			ReservationItemID: item.ReservationItemID,
+			ProductID:         item.ProductID,
+			Quantity:          item.Quantity,
+		}
+	}
+
+	return services.ModifyReservationItemsRequest{
+		ReservationID: reservationID,
+		Changes:       changes,
+		Reason:        r.Reason,
+		UserID:        userID,
+	}
+}
+
+// ToServiceRequest converts DTO to service request
+func (r *ExtendReservationRequest) ToServiceRequest(reservationID, userID uuid.UUID) services.ExtendReservationRequest {
+	return services.ExtendReservationRequest{
+		ReservationID: reservationID,
+		Days:          r.Days,
+		Reason:        r.Reason,
+		UserID:        userID,
+	}
+}
+
+// ToServiceRequest converts DTO to service request
+func (r *ChangeReservationStoreRequest) ToServiceRequest(reservationID, userID uuid.UUID) services.ChangeReservationStoreRequest {
+	return services.ChangeReservationStoreRequest{
+		ReservationID: reservationID,
+		StoreID:       r.StoreID,
+		Reason:        r.Reason,
+		UserID:        userID,
+	}
+}
+
+// ToReservationModificationResponses converts a reservation change history to responses
+func ToReservationModificationResponses(modifications []domain.ReservationModification) []ReservationModificationResponse {
+	responses := make([]ReservationModificationResponse, len(modifications))
+	for i, m := range modifications {
+		responses[i] = ReservationModificationResponse{
+			ModificationID:     m.ModificationID,
+			ReservationID:      m.ReservationID,
+			ModificationType:   m.ModificationType,
+			ReservationItemID:  m.ReservationItemID,
+			ProductID:          m.ProductID,
+			PreviousQuantity:   m.PreviousQuantity,
+			NewQuantity:        m.NewQuantity,
+			PreviousExpiration: m.PreviousExpiration,
+			NewExpiration:      m.NewExpiration,
+			PreviousStoreID:    m.PreviousStoreID,
+			NewStoreID:         m.NewStoreID,
+			PreviousTotal:      m.PreviousTotal,
+			NewTotal:           m.NewTotal,
+			Reason:             m.Reason,
+			CreatedAt:          m.CreatedAt,
+			CreatedBy:          m.CreatedBy,
+		}
+	}
+	return responses
+}
+
+// ToReservationPaymentResponse converts domain.ReservationPayment to response
+func ToReservationPaymentResponse(p *domain.ReservationPayment) ReservationPaymentResponse {
+	return ReservationPaymentResponse{
+		PaymentID:     p.PaymentID,
+		ReservationID: p.ReservationID,
+		PaymentType:   p.PaymentType,
+		Amount:        p.Amount,
+		Currency:      p.Currency,
+		ExchangeRate:  p.ExchangeRate,
+		AppliedAmount: p.AppliedAmount,
+		PaymentMethod: p.PaymentMethod,
+		Reference:     p.Reference,
+		Notes:         p.Notes,
+		PaymentDate:   p.PaymentDate,
+		ReceivedBy:    p.ReceivedBy,
+	}
+}
+
+// ToReservationPaymentResponses converts reservation payments to responses
+func ToReservationPaymentResponses(payments []domain.ReservationPayment) []ReservationPaymentResponse {
+	responses := make([]ReservationPaymentResponse, len(payments))
+	for i, p := range payments {
+		responses[i] = ToReservationPaymentResponse(&p)
+	}
+	return responses
+}
+
 // ToReservationItemResponse converts domain.ReservationItem to response
 // ToReservationItemResponse converts domain.ReservationItem to response
 func ToReservationItemResponse(i *domain.ReservationItem) ReservationItemResponse {
@@ -125,6 +353,9 @@ func ToReservationItemResponse(i *domain.ReservationItem) ReservationItemRespons
 		ReservedQuantity:  i.ReservedQuantity,
 		FulfilledQuantity: i.FulfilledQuantity,
 		UnitPrice:         i.UnitPrice,
+		DiscountAmount:    i.DiscountAmount,
+		CampaignID:        i.CampaignID,
+		WarehouseID:       i.WarehouseID,
 		TotalAmount:       i.TotalAmount,
 		IsFulfilled:       i.IsFulfilled,
 	}
@@ -140,6 +371,16 @@ func ToReservationResponse(r *domain.Reservation) ReservationResponse {
 		}
 	}
 
+	var payments []ReservationPaymentResponse
+	if r.Payments != nil {
+		payments = ToReservationPaymentResponses(r.Payments)
+	}
+
+	var modifications []ReservationModificationResponse
+	if r.Modifications != nil {
+		modifications = ToReservationModificationResponses(r.Modifications)
+	}
+
 	return ReservationResponse{
 		ReservationID:     r.ReservationID,
 		ReservationNumber: r.ReservationNumber,
@@ -153,12 +394,18 @@ func ToReservationResponse(r *domain.Reservation) ReservationResponse {
 		PickupDate:        r.PickupDate,
 		TotalAmount:       r.TotalAmount,
 		DepositAmount:     r.DepositAmount,
+		AmountPaid:        r.AmountPaid,
+		DepositApplied:    r.DepositApplied,
+		ForfeitedAmount:   r.ForfeitedAmount,
 		Balance:           r.Balance,
 		Currency:          r.Currency,
 		Notes:             r.Notes,
 		ReminderSentAt:    r.ReminderSentAt,
+		ExtensionCount:    r.ExtensionCount,
 		FulfilledAt:       r.FulfilledAt,
 		Items:             items,
+		Payments:          payments,
+		Modifications:     modifications,
 		CreatedAt:         r.CreatedAt,
 	}
 }
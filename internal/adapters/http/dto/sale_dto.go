@@ -18,37 +18,55 @@ type SaleItemRequest struct {
 
 // CreateSaleRequest represents a request to create a sale
 type CreateSaleRequest struct {
-	CustomerID       *uuid.UUID           `json:"customer_id,omitempty"`
-	StoreID          uuid.UUID            `json:"store_id" validate:"required"`
-	WarehouseID      uuid.UUID            `json:"warehouse_id" validate:"required"`
-	SaleType         domain.SaleType      `json:"sale_type" validate:"required"`
-	Currency         domain.CurrencyCode  `json:"currency" validate:"required"`
-	ExchangeRate     *float64             `json:"exchange_rate,omitempty"`
-	DiscountAmount   *float64             `json:"discount_amount,omitempty"`
-	PaymentMethod    domain.PaymentMethod `json:"payment_method" validate:"required"`
-	PaymentReference *string              `json:"payment_reference,omitempty"`
-	Notes            *string              `json:"notes,omitempty"`
-	SalespersonID    uuid.UUID            `json:"salesperson_id" validate:"required"`
-	Items            []SaleItemRequest    `json:"items" validate:"required,min=1"`
+	CustomerID         *uuid.UUID                 `json:"customer_id,omitempty"`
+	StoreID            uuid.UUID                  `json:"store_id" validate:"required"`
+	WarehouseID        *uuid.UUID                 `json:"warehouse_id,omitempty"`
+	SaleType           domain.SaleType            `json:"sale_type" validate:"required"`
+	Currency           domain.CurrencyCode        `json:"currency" validate:"required"`
+	ExchangeRate       *float64                   `json:"exchange_rate,omitempty"`
+	DiscountAmount     *float64                   `json:"discount_amount,omitempty"`
+	PaymentMethod      domain.PaymentMethod       `json:"payment_method" validate:"required"`
+	PaymentReference   *string                    `json:"payment_reference,omitempty"`
+	Notes              *string                    `json:"notes,omitempty"`
+	SalespersonID      uuid.UUID                  `json:"salesperson_id" validate:"required"`
+	Items              []SaleItemRequest          `json:"items" validate:"required,min=1"`
+	RedeemPoints       int                        `json:"redeem_points,omitempty"`
+	StoredValueTenders []StoredValueTenderRequest `json:"stored_value_tenders,omitempty"`
 }
 
 // CreateCreditSaleRequest represents a request to create a credit sale
 type CreateCreditSaleRequest struct {
 	CreateSaleRequest
-	CreditDays int `json:"credit_days" validate:"required,gt=0"`
+	CreditDays     *int    `json:"credit_days,omitempty" validate:"omitempty,gte=0"`
+	OverrideReason *string `json:"override_reason,omitempty"`
+
+	// Installments split the sale into installments instead of a single due date
+	Installments         *int                         `json:"installments,omitempty" validate:"omitempty,gte=2"`
+	InstallmentFrequency *domain.InstallmentFrequency `json:"installment_frequency,omitempty"`
 }
 
 // SaleDetailResponse represents a sale detail in API responses
 type SaleDetailResponse struct {
-	DetailID       uuid.UUID `json:"sale_detail_id"`
-	SaleID         uuid.UUID `json:"sale_id"`
-	ProductID      uuid.UUID `json:"product_id"`
-	Quantity       float64   `json:"quantity"`
-	UnitPrice      float64   `json:"unit_price"`
-	DiscountAmount float64   `json:"discount_amount"`
-	Subtotal       float64   `json:"subtotal"`
-	TaxAmount      float64   `json:"tax_amount"`
-	Total          float64   `json:"total"`
+	DetailID       uuid.UUID  `json:"sale_detail_id"`
+	SaleID         uuid.UUID  `json:"sale_id"`
+	ProductID      uuid.UUID  `json:"product_id"`
+	Quantity       float64    `json:"quantity"`
+	UnitPrice      float64    `json:"unit_price"`
+	DiscountAmount float64    `json:"discount_amount"`
+	CampaignID     *uuid.UUID `json:"campaign_id,omitempty"`
+	WarehouseID    *uuid.UUID `json:"warehouse_id,omitempty"`
+	Subtotal       float64    `json:"subtotal"`
+	TaxAmount      float64    `json:"tax_amount"`
+	Total          float64    `json:"total"`
+}
+
+// SaleTenderResponse represents a non-cash tender in API responses
+type SaleTenderResponse struct {
+	TenderID   uuid.UUID           `json:"tender_id"`
+	TenderType domain.TenderType   `json:"tender_type"`
+	Amount     float64             `json:"amount"`
+	Currency   domain.CurrencyCode `json:"currency"`
+	Reference  *string             `json:"reference,omitempty"`
 }
 
 // SaleResponse represents a sale in API responses
@@ -71,6 +89,7 @@ type SaleResponse struct {
 	Notes            *string                 `json:"notes,omitempty"`
 	SalespersonID    *uuid.UUID              `json:"salesperson_id,omitempty"`
 	Details          []SaleDetailResponse    `json:"details,omitempty"`
+	Tenders          []SaleTenderResponse    `json:"tenders,omitempty"`
 	CreatedAt        time.Time               `json:"created_at"`
 }
 
@@ -86,6 +105,7 @@ type SaleListResponse struct {
 type CreditSaleResponse struct {
 	Sale              SaleResponse               `json:"sale"`
 	AccountsReceivable *AccountsReceivableResponse `json:"accounts_receivable,omitempty"`
+	InstallmentPlan    *InstallmentPlanResponse    `json:"installment_plan,omitempty"`
 }
 
 // AccountsReceivableResponse represents an AR in API responses
@@ -95,10 +115,17 @@ type AccountsReceivableResponse struct {
 	CustomerID   uuid.UUID           `json:"customer_id"`
 	TotalAmount  float64             `json:"total_amount"`
 	PaidAmount   float64             `json:"paid_amount"`
+	CreditedAmount   float64         `json:"credited_amount"`
+	WrittenOffAmount float64         `json:"written_off_amount"`
 	Balance      float64             `json:"balance"`
 	Currency     domain.CurrencyCode `json:"currency"`
 	DueDate      time.Time           `json:"due_date"`
 	Status       domain.AccountStatus `json:"status"`
+	Kind         domain.ReceivableKind `json:"kind"`
+	SourceReceivableID *uuid.UUID     `json:"source_receivable_id,omitempty"`
+	InstallmentPlanID  *uuid.UUID     `json:"installment_plan_id,omitempty"`
+	InstallmentNumber  *int           `json:"installment_number,omitempty"`
+	Notes        *string             `json:"notes,omitempty"`
 	CreatedAt    time.Time           `json:"created_at"`
 }
 
@@ -145,6 +172,8 @@ func (r *CreateSaleRequest) ToServiceRequest() services.CreateSaleRequest {
 		Notes:            r.Notes,
 		SalespersonID:    r.SalespersonID,
 		Items:            items,
+		RedeemPoints:       r.RedeemPoints,
+		StoredValueTenders: ToStoredValueTenders(r.StoredValueTenders),
 	}
 }
 
@@ -157,6 +186,8 @@ func ToSaleDetailResponse(d *domain.SaleDetail) SaleDetailResponse {
 		Quantity:       d.Quantity,
 		UnitPrice:      d.UnitPrice,
 		DiscountAmount: d.DiscountAmount,
+		CampaignID:     d.CampaignID,
+		WarehouseID:    d.WarehouseID,
 		Subtotal:       d.Subtotal,
 		TaxAmount:      d.TaxAmount,
 		Total:          d.Total,
@@ -173,6 +204,20 @@ func ToSaleResponse(s *domain.Sale) SaleResponse {
 		}
 	}
 
+	var tenders []SaleTenderResponse
+	if s.Tenders != nil {
+		tenders = make([]SaleTenderResponse, len(s.Tenders))
+		for i, t := range s.Tenders {
+			tenders[i] = SaleTenderResponse{
+				TenderID:   t.TenderID,
+				TenderType: t.TenderType,
+				Amount:     t.Amount,
+				Currency:   t.Currency,
+				Reference:  t.Reference,
+			}
+		}
+	}
+
 	return SaleResponse{
 		SaleID:           s.SaleID,
 		InvoiceNumber:    s.InvoiceNumber,
@@ -192,6 +237,7 @@ func ToSaleResponse(s *domain.Sale) SaleResponse {
 		Notes:            s.Notes,
 		SalespersonID:    s.SalespersonID,
 		Details:          details,
+		Tenders:          tenders,
 		CreatedAt:        s.CreatedAt,
 	}
 }
@@ -204,10 +250,17 @@ func ToAccountsReceivableResponse(ar *domain.AccountsReceivable) AccountsReceiva
 		CustomerID:   ar.CustomerID,
 		TotalAmount:  ar.TotalAmount,
 		PaidAmount:   ar.PaidAmount,
+		CreditedAmount:   ar.CreditedAmount,
+		WrittenOffAmount: ar.WrittenOffAmount,
 		Balance:      ar.Balance,
 		Currency:     ar.Currency,
 		DueDate:      ar.DueDate,
 		Status:       ar.Status,
+		Kind:         ar.Kind,
+		SourceReceivableID: ar.SourceReceivableID,
+		InstallmentPlanID:  ar.InstallmentPlanID,
+		InstallmentNumber:  ar.InstallmentNumber,
+		Notes:        ar.Notes,
 		CreatedAt:    ar.CreatedAt,
 	}
 }
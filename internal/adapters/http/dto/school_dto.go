@@ -0,0 +1,99 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+// SchoolRequest represents the request to create/update a school
+type SchoolRequest struct {
+	Name        string               `json:"name" validate:"required"`
+	LocationID  *uuid.UUID           `json:"location_id,omitempty"`
+	Address     *string              `json:"address,omitempty"`
+	Levels      []domain.SchoolLevel `json:"levels,omitempty"`
+	ContactName *string              `json:"contact_name,omitempty"`
+	Phone       *string              `json:"phone,omitempty"`
+	Email       *string              `json:"email,omitempty"`
+	IsActive    *bool                `json:"is_active,omitempty"`
+}
+
+// SchoolResponse represents a school in API responses
+type SchoolResponse struct {
+	SchoolID     uuid.UUID            `json:"school_id"`
+	Name         string               `json:"name"`
+	LocationID   *uuid.UUID           `json:"location_id,omitempty"`
+	LocationName *string              `json:"location_name,omitempty"`
+	Address      *string              `json:"address,omitempty"`
+	Levels       []domain.SchoolLevel `json:"levels,omitempty"`
+	ContactName  *string              `json:"contact_name,omitempty"`
+	Phone        *string              `json:"phone,omitempty"`
+	Email        *string              `json:"email,omitempty"`
+	IsActive     bool                 `json:"is_active"`
+	CreatedAt    time.Time            `json:"created_at"`
+	UpdatedAt    time.Time            `json:"updated_at"`
+}
+
+// SchoolListResponse represents paginated school list
+type SchoolListResponse struct {
+	Schools []SchoolResponse `json:"schools"`
+	Total   int64            `json:"total"`
+	Limit   int              `json:"limit"`
+	Offset  int              `json:"offset"`
+}
+
+// ToSchoolDomain converts SchoolRequest to domain.School
+func (r *SchoolRequest) ToSchoolDomain() *domain.School {
+	isActive := true
+	if r.IsActive != nil {
+		isActive = *r.IsActive
+	}
+
+	return &domain.School{
+		SchoolID:    uuid.New(),
+		Name:        r.Name,
+		LocationID:  r.LocationID,
+		Address:     r.Address,
+		Levels:      domain.SchoolLevelArray(r.Levels),
+		ContactName: r.ContactName,
+		Phone:       r.Phone,
+		Email:       r.Email,
+		IsActive:    isActive,
+	}
+}
+
+// ToSchoolResponse converts domain.School to SchoolResponse
+func ToSchoolResponse(s *domain.School) SchoolResponse {
+	response := SchoolResponse{
+		SchoolID:    s.SchoolID,
+		Name:        s.Name,
+		LocationID:  s.LocationID,
+		Address:     s.Address,
+		Levels:      s.Levels,
+		ContactName: s.ContactName,
+		Phone:       s.Phone,
+		Email:       s.Email,
+		IsActive:    s.IsActive,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+	if s.Location != nil {
+		response.LocationName = &s.Location.FullPath
+	}
+	return response
+}
+
+// ToSchoolListResponse converts school slice to list response
+func ToSchoolListResponse(schools []domain.School, total int64, limit, offset int) SchoolListResponse {
+	responses := make([]SchoolResponse, len(schools))
+	for i := range schools {
+		responses[i] = ToSchoolResponse(&schools[i])
+	}
+	return SchoolListResponse{
+		Schools: responses,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+	}
+}
@@ -0,0 +1,513 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+// ListItemAlternativeRequest represents an alternative product for a list item
+type ListItemAlternativeRequest struct {
+	ProductID     uuid.UUID `json:"product_id" validate:"required"`
+	IsRecommended bool      `json:"is_recommended,omitempty"`
+}
+
+// SchoolSupplyListItemRequest represents an item in a school supply list
+type SchoolSupplyListItemRequest struct {
+	ProductID           uuid.UUID                    `json:"product_id" validate:"required"`
+	Quantity            int                          `json:"quantity" validate:"required,gt=0"`
+	IsOptional          bool                         `json:"is_optional,omitempty"`
+	AlternativesAllowed *bool                        `json:"alternatives_allowed,omitempty"`
+	Notes               *string                      `json:"notes,omitempty"`
+	DisplayOrder        *int                         `json:"display_order,omitempty"`
+	Alternatives        []ListItemAlternativeRequest `json:"alternatives,omitempty"`
+}
+
+// SchoolSupplyListRequest represents the request to create/update a school supply list
+type SchoolSupplyListRequest struct {
+	ListName           string             `json:"list_name" validate:"required"`
+	SchoolLevel        domain.SchoolLevel `json:"school_level" validate:"required"`
+	Grade              *string            `json:"grade,omitempty"`
+	SchoolYear         string             `json:"school_year" validate:"required"`
+	Description        *string            `json:"description,omitempty"`
+	ExpirationDate     *time.Time         `json:"expiration_date,omitempty"`
+	IsTemplate         bool               `json:"is_template,omitempty"`
+	SchoolID           *uuid.UUID         `json:"school_id,omitempty"`
+	ExpectedEnrollment *int               `json:"expected_enrollment,omitempty"`
+}
+
+// CreateSchoolSupplyListRequest represents the request to create a list with its items
+type CreateSchoolSupplyListRequest struct {
+	SchoolSupplyListRequest
+	Items []SchoolSupplyListItemRequest `json:"items" validate:"required,min=1"`
+}
+
+// ReplaceListItemsRequest represents the request to replace the items of a list
+type ReplaceListItemsRequest struct {
+	Items []SchoolSupplyListItemRequest `json:"items" validate:"required,min=1"`
+}
+
+// CloneSchoolSupplyListRequest represents the request to clone a list into a school year
+type CloneSchoolSupplyListRequest struct {
+	SchoolYear string `json:"school_year" validate:"required"`
+}
+
+// ListItemAlternativeResponse represents an alternative product in API responses
+type ListItemAlternativeResponse struct {
+	AlternativeID uuid.UUID `json:"alternative_id"`
+	ProductID     uuid.UUID `json:"product_id"`
+	ProductName   string    `json:"product_name,omitempty"`
+	SellingPrice  float64   `json:"selling_price,omitempty"`
+	IsRecommended bool      `json:"is_recommended"`
+}
+
+// SchoolSupplyListItemResponse represents a list item in API responses
+type SchoolSupplyListItemResponse struct {
+	ListItemID          uuid.UUID                     `json:"list_item_id"`
+	ProductID           uuid.UUID                     `json:"product_id"`
+	ProductName         string                        `json:"product_name,omitempty"`
+	SellingPrice        float64                       `json:"selling_price,omitempty"`
+	Quantity            int                           `json:"quantity"`
+	IsRequired          bool                          `json:"is_required"`
+	IsOptional          bool                          `json:"is_optional"`
+	AlternativesAllowed bool                          `json:"alternatives_allowed"`
+	Notes               *string                       `json:"notes,omitempty"`
+	DisplayOrder        *int                          `json:"display_order,omitempty"`
+	Alternatives        []ListItemAlternativeResponse `json:"alternatives,omitempty"`
+}
+
+// SchoolSupplyListResponse represents a school supply list in API responses
+type SchoolSupplyListResponse struct {
+	ListID             uuid.UUID                      `json:"list_id"`
+	ListName           string                         `json:"list_name"`
+	SchoolLevel        domain.SchoolLevel             `json:"school_level"`
+	Grade              *string                        `json:"grade,omitempty"`
+	SchoolYear         string                         `json:"school_year"`
+	Status             domain.SchoolListStatus        `json:"status"`
+	Description        *string                        `json:"description,omitempty"`
+	PublishDate        *time.Time                     `json:"publish_date,omitempty"`
+	ExpirationDate     *time.Time                     `json:"expiration_date,omitempty"`
+	TotalEstimatedCost *float64                       `json:"total_estimated_cost,omitempty"`
+	IsTemplate         bool                           `json:"is_template"`
+	SchoolID           *uuid.UUID                     `json:"school_id,omitempty"`
+	SchoolName         *string                        `json:"school_name,omitempty"`
+	ExpectedEnrollment *int                           `json:"expected_enrollment,omitempty"`
+	Items              []SchoolSupplyListItemResponse `json:"items,omitempty"`
+	CreatedAt          time.Time                      `json:"created_at"`
+	UpdatedAt          time.Time                      `json:"updated_at"`
+}
+
+// SchoolSupplyListListResponse represents paginated school supply lists
+type SchoolSupplyListListResponse struct {
+	Lists  []SchoolSupplyListResponse `json:"lists"`
+	Total  int64                      `json:"total"`
+	Limit  int                        `json:"limit"`
+	Offset int                        `json:"offset"`
+}
+
+// ToSchoolSupplyListDomain converts SchoolSupplyListRequest to domain.SchoolSupplyList
+func (r *SchoolSupplyListRequest) ToSchoolSupplyListDomain() *domain.SchoolSupplyList {
+	return &domain.SchoolSupplyList{
+		ListName:           r.ListName,
+		SchoolLevel:        r.SchoolLevel,
+		Grade:              r.Grade,
+		SchoolYear:         r.SchoolYear,
+		Description:        r.Description,
+		ExpirationDate:     r.ExpirationDate,
+		IsTemplate:         r.IsTemplate,
+		SchoolID:           r.SchoolID,
+		ExpectedEnrollment: r.ExpectedEnrollment,
+	}
+}
+
+// ToSchoolSupplyListItems converts item requests to domain items
+func ToSchoolSupplyListItems(items []SchoolSupplyListItemRequest) []domain.SchoolSupplyListItem {
+	result := make([]domain.SchoolSupplyListItem, len(items))
+	for i, item := range items {
+		alternativesAllowed := true
+		if item.AlternativesAllowed != nil {
+			alternativesAllowed = *item.AlternativesAllowed
+		}
+
+		alternatives := make([]domain.ListItemAlternative, len(item.Alternatives))
+		for j, alt := range item.Alternatives {
+			alternatives[j] = domain.ListItemAlternative{
+				AlternativeProductID: alt.ProductID,
+				IsRecommended:        alt.IsRecommended,
+			}
+		}
+
+		result[i] = domain.SchoolSupplyListItem{
+			ProductID:           item.ProductID,
+			Quantity:            item.Quantity,
+			IsOptional:          item.IsOptional,
+			AlternativesAllowed: alternativesAllowed,
+			Notes:               item.Notes,
+			DisplayOrder:        item.DisplayOrder,
+			Alternatives:        alternatives,
+		}
+	}
+	return result
+}
+
+// ToSchoolSupplyListResponse converts domain.SchoolSupplyList to SchoolSupplyListResponse
+func ToSchoolSupplyListResponse(l *domain.SchoolSupplyList) SchoolSupplyListResponse {
+	response := SchoolSupplyListResponse{
+		ListID:             l.ListID,
+		ListName:           l.ListName,
+		SchoolLevel:        l.SchoolLevel,
+		Grade:              l.Grade,
+		SchoolYear:         l.SchoolYear,
+		Status:             l.Status,
+		Description:        l.Description,
+		PublishDate:        l.PublishDate,
+		ExpirationDate:     l.ExpirationDate,
+		TotalEstimatedCost: l.TotalEstimatedCost,
+		IsTemplate:         l.IsTemplate,
+		SchoolID:           l.SchoolID,
+		ExpectedEnrollment: l.ExpectedEnrollment,
+		CreatedAt:          l.CreatedAt,
+		UpdatedAt:          l.UpdatedAt,
+	}
+
+	if l.School != nil {
+		response.SchoolName = &l.School.Name
+	}
+
+	for _, item := range l.Items {
+		itemResponse := SchoolSupplyListItemResponse{
+			ListItemID:          item.ListItemID,
+			ProductID:           item.ProductID,
+			Quantity:            item.Quantity,
+			IsRequired:          item.IsRequired,
+			IsOptional:          item.IsOptional,
+			AlternativesAllowed: item.AlternativesAllowed,
+			Notes:               item.Notes,
+			DisplayOrder:        item.DisplayOrder,
+		}
+		if item.Product != nil {
+			itemResponse.ProductName = item.Product.Name
+			itemResponse.SellingPrice = item.Product.SellingPrice
+		}
+
+		for _, alt := range item.Alternatives {
+			altResponse := ListItemAlternativeResponse{
+				AlternativeID: alt.AlternativeID,
+				ProductID:     alt.AlternativeProductID,
+				IsRecommended: alt.IsRecommended,
+			}
+			if alt.AlternativeProduct != nil {
+				altResponse.ProductName = alt.AlternativeProduct.Name
+				altResponse.SellingPrice = alt.AlternativeProduct.SellingPrice
+			}
+			itemResponse.Alternatives = append(itemResponse.Alternatives, altResponse)
+		}
+
+		response.Items = append(response.Items, itemResponse)
+	}
+
+	return response
+}
+
+// ToSchoolSupplyListListResponse converts list slice to list response
+func ToSchoolSupplyListListResponse(lists []domain.SchoolSupplyList, total int64, limit, offset int) SchoolSupplyListListResponse {
+	responses := make([]SchoolSupplyListResponse, len(lists))
+	for i := range lists {
+		responses[i] = ToSchoolSupplyListResponse(&lists[i])
+	}
+	return SchoolSupplyListListResponse{
+		Lists:  responses,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}
+}
+
+// AlternativeAvailabilityResponse represents the stock of an alternative product
+type AlternativeAvailabilityResponse struct {
+	ProductID     uuid.UUID `json:"product_id"`
+	Available     float64   `json:"available"`
+	IsRecommended bool      `json:"is_recommended"`
+}
+
+// ListItemAvailabilityResponse represents the stock of a list item
+type ListItemAvailabilityResponse struct {
+	ListItemID   uuid.UUID                         `json:"list_item_id"`
+	ProductID    uuid.UUID                         `json:"product_id"`
+	Required     float64                           `json:"required"`
+	Available    float64                           `json:"available"`
+	InStock      bool                              `json:"in_stock"`
+	IsOptional   bool                              `json:"is_optional"`
+	Alternatives []AlternativeAvailabilityResponse `json:"alternatives,omitempty"`
+}
+
+// ListAvailabilityResponse represents the availability of a list in a warehouse
+type ListAvailabilityResponse struct {
+	ListID         uuid.UUID                      `json:"list_id"`
+	WarehouseID    uuid.UUID                      `json:"warehouse_id"`
+	FullyAvailable bool                           `json:"fully_available"`
+	Items          []ListItemAvailabilityResponse `json:"items"`
+}
+
+// ToListAvailabilityResponse converts services.ListAvailability to ListAvailabilityResponse
+func ToListAvailabilityResponse(a *services.ListAvailability) ListAvailabilityResponse {
+	response := ListAvailabilityResponse{
+		ListID:         a.ListID,
+		WarehouseID:    a.WarehouseID,
+		FullyAvailable: a.FullyAvailable,
+		Items:          make([]ListItemAvailabilityResponse, len(a.Items)),
+	}
+
+	for i, item := range a.Items {
+		alternatives := make([]AlternativeAvailabilityResponse, len(item.Alternatives))
+		for j, alt := range item.Alternatives {
+			alternatives[j] = AlternativeAvailabilityResponse{
+				ProductID:     alt.ProductID,
+				Available:     alt.Available,
+				IsRecommended: alt.IsRecommended,
+			}
+		}
+
+		response.Items[i] = ListItemAvailabilityResponse{
+			ListItemID:   item.ListItemID,
+			ProductID:    item.ProductID,
+			Required:     item.Required,
+			Available:    item.Available,
+			InStock:      item.InStock,
+			IsOptional:   item.IsOptional,
+			Alternatives: alternatives,
+		}
+	}
+
+	return response
+}
+
+// ConvertListRequest represents a request to build a sale or reservation from a list
+type ConvertListRequest struct {
+	ConvertTo        domain.ListConversionType   `json:"convert_to" validate:"required"`
+	CustomerID       *uuid.UUID                  `json:"customer_id,omitempty"`
+	ChildID          *uuid.UUID                  `json:"child_id,omitempty"`
+	StoreID          uuid.UUID                   `json:"store_id" validate:"required"`
+	WarehouseID      uuid.UUID                   `json:"warehouse_id" validate:"required"`
+	BudgetPreference domain.ListBudgetPreference `json:"budget_preference,omitempty"`
+	OptionalItemIDs  []uuid.UUID                 `json:"optional_item_ids,omitempty"`
+	Preview          bool                        `json:"preview,omitempty"`
+	Currency         domain.CurrencyCode         `json:"currency,omitempty"`
+	ExchangeRate     *float64                    `json:"exchange_rate,omitempty"`
+	PaymentMethod    *domain.PaymentMethod       `json:"payment_method,omitempty"`
+	PaymentReference *string                     `json:"payment_reference,omitempty"`
+	DepositAmount    float64                     `json:"deposit_amount,omitempty"`
+	ExpirationDays   int                         `json:"expiration_days,omitempty"`
+}
+
+// ListConversionLineResponse represents a product chosen for a list item
+type ListConversionLineResponse struct {
+	ListItemID         uuid.UUID `json:"list_item_id"`
+	RequestedProductID uuid.UUID `json:"requested_product_id"`
+	ProductID          uuid.UUID `json:"product_id"`
+	ProductName        string    `json:"product_name"`
+	Quantity           float64   `json:"quantity"`
+	UnitPrice          float64   `json:"unit_price"`
+	Substituted        bool      `json:"substituted"`
+}
+
+// UnfulfilledListItemResponse represents a list item that could not be served
+type UnfulfilledListItemResponse struct {
+	ListItemID uuid.UUID `json:"list_item_id"`
+	ProductID  uuid.UUID `json:"product_id"`
+	Required   float64   `json:"required"`
+	Missing    float64   `json:"missing"`
+	Reason     string    `json:"reason"`
+}
+
+// ListConversionResponse contains the plan built from a list and the created document
+type ListConversionResponse struct {
+	ListID         uuid.UUID                     `json:"list_id"`
+	Lines          []ListConversionLineResponse  `json:"lines"`
+	Unfulfilled    []UnfulfilledListItemResponse `json:"unfulfilled"`
+	EstimatedTotal float64                       `json:"estimated_total"`
+	Sale           *SaleResponse                 `json:"sale,omitempty"`
+	Reservation    *ReservationResponse          `json:"reservation,omitempty"`
+}
+
+// ToServiceRequest converts DTO to service request
+func (r *ConvertListRequest) ToServiceRequest(listID, userID uuid.UUID) services.ConvertListRequest {
+	return services.ConvertListRequest{
+		ListID:           listID,
+		ConvertTo:        r.ConvertTo,
+		CustomerID:       r.CustomerID,
+		ChildID:          r.ChildID,
+		StoreID:          r.StoreID,
+		WarehouseID:      r.WarehouseID,
+		BudgetPreference: r.BudgetPreference,
+		OptionalItemIDs:  r.OptionalItemIDs,
+		Preview:          r.Preview,
+		Currency:         r.Currency,
+		ExchangeRate:     r.ExchangeRate,
+		PaymentMethod:    r.PaymentMethod,
+		PaymentReference: r.PaymentReference,
+		DepositAmount:    r.DepositAmount,
+		ExpirationDays:   r.ExpirationDays,
+		UserID:           userID,
+	}
+}
+
+// ToListConversionResponse converts services.ListConversion to ListConversionResponse
+func ToListConversionResponse(c *services.ListConversion) ListConversionResponse {
+	response := ListConversionResponse{
+		ListID:         c.List.ListID,
+		Lines:          make([]ListConversionLineResponse, len(c.Lines)),
+		Unfulfilled:    make([]UnfulfilledListItemResponse, len(c.Unfulfilled)),
+		EstimatedTotal: c.EstimatedTotal,
+	}
+
+	for i, line := range c.Lines {
+		response.Lines[i] = ListConversionLineResponse{
+			ListItemID:         line.ListItemID,
+			RequestedProductID: line.RequestedProductID,
+			ProductID:          line.ProductID,
+			ProductName:        line.ProductName,
+			Quantity:           line.Quantity,
+			UnitPrice:          line.UnitPrice,
+			Substituted:        line.Substituted,
+		}
+	}
+
+	for i, item := range c.Unfulfilled {
+		response.Unfulfilled[i] = UnfulfilledListItemResponse{
+			ListItemID: item.ListItemID,
+			ProductID:  item.ProductID,
+			Required:   item.Required,
+			Missing:    item.Missing,
+			Reason:     item.Reason,
+		}
+	}
+
+	if c.Sale != nil {
+		sale := ToSaleResponse(c.Sale)
+		response.Sale = &sale
+	}
+	if c.Reservation != nil {
+		reservation := ToReservationResponse(c.Reservation)
+		response.Reservation = &reservation
+	}
+
+	return response
+}
+
+// PreviewListImportRequest represents a document to parse into list lines
+type PreviewListImportRequest struct {
+	Format  domain.ListImportFormat `json:"format" validate:"required"`
+	Content string                  `json:"content" validate:"required"`
+}
+
+// ImportMappingRequest represents a line confirmed by staff
+type ImportMappingRequest struct {
+	LineNumber int       `json:"line_number"`
+	ProductID  uuid.UUID `json:"product_id" validate:"required"`
+	Quantity   int       `json:"quantity" validate:"required,gt=0"`
+	IsOptional bool      `json:"is_optional,omitempty"`
+	Notes      *string   `json:"notes,omitempty"`
+}
+
+// ConfirmListImportRequest represents the request to save an imported list as draft
+type ConfirmListImportRequest struct {
+	SchoolSupplyListRequest
+	Mappings []ImportMappingRequest `json:"mappings" validate:"required,min=1"`
+}
+
+// ProductMatchResponse represents a product proposed for an imported line
+type ProductMatchResponse struct {
+	ProductID    uuid.UUID `json:"product_id"`
+	ProductName  string    `json:"product_name"`
+	SKU          string    `json:"sku"`
+	SellingPrice float64   `json:"selling_price"`
+	Confidence   float64   `json:"confidence"`
+	MatchedBy    string    `json:"matched_by"`
+}
+
+// ImportedLineResponse represents a parsed line and its candidates
+type ImportedLineResponse struct {
+	LineNumber  int                    `json:"line_number"`
+	Raw         string                 `json:"raw"`
+	Quantity    int                    `json:"quantity"`
+	Description string                 `json:"description"`
+	SKU         *string                `json:"sku,omitempty"`
+	Barcode     *string                `json:"barcode,omitempty"`
+	IsOptional  bool                   `json:"is_optional"`
+	Matched     bool                   `json:"matched"`
+	Candidates  []ProductMatchResponse `json:"candidates"`
+}
+
+// ListImportPreviewResponse represents the result of parsing a list document
+type ListImportPreviewResponse struct {
+	Format    domain.ListImportFormat `json:"format"`
+	Lines     []ImportedLineResponse  `json:"lines"`
+	Unmatched []ImportedLineResponse  `json:"unmatched"`
+}
+
+// ToServiceRequest converts DTO to service request
+func (r *ConfirmListImportRequest) ToServiceRequest(userID uuid.UUID) services.ConfirmListImportRequest {
+	list := r.ToSchoolSupplyListDomain()
+	list.CreatedBy = &userID
+
+	mappings := make([]services.ImportMapping, len(r.Mappings))
+	for i, m := range r.Mappings {
+		mappings[i] = services.ImportMapping{
+			LineNumber: m.LineNumber,
+			ProductID:  m.ProductID,
+			Quantity:   m.Quantity,
+			IsOptional: m.IsOptional,
+			Notes:      m.Notes,
+		}
+	}
+
+	return services.ConfirmListImportRequest{
+		List:     list,
+		Mappings: mappings,
+	}
+}
+
+func toImportedLineResponse(line services.ImportedLine) ImportedLineResponse {
+	candidates := make([]ProductMatchResponse, len(line.Candidates))
+	for i, c := range line.Candidates {
+		candidates[i] = ProductMatchResponse{
+			ProductID:    c.ProductID,
+			ProductName:  c.ProductName,
+			SKU:          c.SKU,
+			SellingPrice: c.SellingPrice,
+			Confidence:   c.Confidence,
+			MatchedBy:    c.MatchedBy,
+		}
+	}
+
+	return ImportedLineResponse{
+		LineNumber:  line.LineNumber,
+		Raw:         line.Raw,
+		Quantity:    line.Quantity,
+		Description: line.Description,
+		SKU:         line.SKU,
+		Barcode:     line.Barcode,
+		IsOptional:  line.IsOptional,
+		Matched:     line.Matched,
+		Candidates:  candidates,
+	}
+}
+
+// ToListImportPreviewResponse converts services.ListImportPreview to ListImportPreviewResponse
+func ToListImportPreviewResponse(p *services.ListImportPreview) ListImportPreviewResponse {
+	response := ListImportPreviewResponse{
+		Format:    p.Format,
+		Lines:     make([]ImportedLineResponse, len(p.Lines)),
+		Unmatched: make([]ImportedLineResponse, len(p.Unmatched)),
+	}
+	for i, line := range p.Lines {
+		response.Lines[i] = toImportedLineResponse(line)
+	}
+	for i, line := range p.Unmatched {
+		response.Unmatched[i] = toImportedLineResponse(line)
+	}
+	return response
+}
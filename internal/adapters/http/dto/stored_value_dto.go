@@ -0,0 +1,164 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+// IssueGiftCardRequest represents the request to issue a gift card
+type IssueGiftCardRequest struct {
+	Code       *string             `json:"code,omitempty"`
+	Amount     float64             `json:"amount" validate:"required,gt=0"`
+	Currency   domain.CurrencyCode `json:"currency,omitempty"`
+	CustomerID *uuid.UUID          `json:"customer_id,omitempty"`
+	ExpiresAt  *time.Time          `json:"expires_at,omitempty"`
+	SaleID     *uuid.UUID          `json:"sale_id,omitempty"`
+	Notes      *string             `json:"notes,omitempty"`
+}
+
+// IssueStoreCreditRequest represents the request to credit a customer store-credit account
+type IssueStoreCreditRequest struct {
+	CustomerID uuid.UUID           `json:"customer_id" validate:"required"`
+	Amount     float64             `json:"amount" validate:"required,gt=0"`
+	Currency   domain.CurrencyCode `json:"currency,omitempty"`
+	ExpiresAt  *time.Time          `json:"expires_at,omitempty"`
+	SaleID     *uuid.UUID          `json:"sale_id,omitempty"`
+	Reason     string              `json:"reason" validate:"required"`
+}
+
+// StoredValueTenderRequest represents a gift card or store credit used to pay a sale
+type StoredValueTenderRequest struct {
+	AccountID *uuid.UUID `json:"account_id,omitempty"`
+	Code      *string    `json:"code,omitempty"`
+	Amount    float64    `json:"amount" validate:"required,gt=0"`
+}
+
+// StoredValueAccountResponse represents a gift card or store-credit account in API responses
+type StoredValueAccountResponse struct {
+	AccountID     uuid.UUID                `json:"account_id"`
+	AccountType   domain.StoredValueType   `json:"account_type"`
+	Code          *string                  `json:"code,omitempty"`
+	CustomerID    *uuid.UUID               `json:"customer_id,omitempty"`
+	Currency      domain.CurrencyCode      `json:"currency"`
+	InitialAmount float64                  `json:"initial_amount"`
+	Balance       float64                  `json:"balance"`
+	Status        domain.StoredValueStatus `json:"status"`
+	ExpiresAt     *time.Time               `json:"expires_at,omitempty"`
+	IssuedAt      time.Time                `json:"issued_at"`
+	Notes         *string                  `json:"notes,omitempty"`
+}
+
+// StoredValueListResponse represents paginated stored-value account list
+type StoredValueListResponse struct {
+	Accounts []StoredValueAccountResponse `json:"accounts"`
+	Total    int64                        `json:"total"`
+	Limit    int                          `json:"limit"`
+	Offset   int                          `json:"offset"`
+}
+
+// StoredValueTransactionResponse represents a balance movement in API responses
+type StoredValueTransactionResponse struct {
+	TransactionID   uuid.UUID                         `json:"transaction_id"`
+	AccountID       uuid.UUID                         `json:"account_id"`
+	TransactionType domain.StoredValueTransactionType `json:"transaction_type"`
+	Amount          float64                           `json:"amount"`
+	BalanceAfter    float64                           `json:"balance_after"`
+	ExchangeRate    *float64                          `json:"exchange_rate,omitempty"`
+	SaleID          *uuid.UUID                        `json:"sale_id,omitempty"`
+	Description     *string                           `json:"description,omitempty"`
+	CreatedAt       time.Time                         `json:"created_at"`
+	CreatedBy       *uuid.UUID                        `json:"created_by,omitempty"`
+}
+
+// ToServiceRequest converts DTO to service request
+func (r *IssueGiftCardRequest) ToServiceRequest(userID uuid.UUID) services.IssueGiftCardRequest {
+	return services.IssueGiftCardRequest{
+		Code:       r.Code,
+		Amount:     r.Amount,
+		Currency:   r.Currency,
+		CustomerID: r.CustomerID,
+		ExpiresAt:  r.ExpiresAt,
+		SaleID:     r.SaleID,
+		Notes:      r.Notes,
+		UserID:     userID,
+	}
+}
+
+// ToServiceRequest converts DTO to service request
+func (r *IssueStoreCreditRequest) ToServiceRequest(userID uuid.UUID) services.IssueStoreCreditRequest {
+	return services.IssueStoreCreditRequest{
+		CustomerID: r.CustomerID,
+		Amount:     r.Amount,
+		Currency:   r.Currency,
+		ExpiresAt:  r.ExpiresAt,
+		SaleID:     r.SaleID,
+		Reason:     r.Reason,
+		UserID:     userID,
+	}
+}
+
+// ToStoredValueTenders converts tender DTOs to service tenders
+func ToStoredValueTenders(tenders []StoredValueTenderRequest) []services.StoredValueTender {
+	if len(tenders) == 0 {
+		return nil
+	}
+	result := make([]services.StoredValueTender, len(tenders))
+	for i, t := range tenders {
+		result[i] = services.StoredValueTender{
+			AccountID: t.AccountID,
+			Code:      t.Code,
+			Amount:    t.Amount,
+		}
+	}
+	return result
+}
+
+// ToStoredValueAccountResponse converts domain.StoredValueAccount to response
+func ToStoredValueAccountResponse(a *domain.StoredValueAccount) StoredValueAccountResponse {
+	return StoredValueAccountResponse{
+		AccountID:     a.AccountID,
+		AccountType:   a.AccountType,
+		Code:          a.Code,
+		CustomerID:    a.CustomerID,
+		Currency:      a.Currency,
+		InitialAmount: a.InitialAmount,
+		Balance:       a.Balance,
+		Status:        a.Status,
+		ExpiresAt:     a.ExpiresAt,
+		IssuedAt:      a.IssuedAt,
+		Notes:         a.Notes,
+	}
+}
+
+// ToStoredValueListResponse converts account slice to list response
+func ToStoredValueListResponse(accounts []domain.StoredValueAccount, total int64, limit, offset int) StoredValueListResponse {
+	responses := make([]StoredValueAccountResponse, len(accounts))
+	for i, a := range accounts {
+		responses[i] = ToStoredValueAccountResponse(&a)
+	}
+	return StoredValueListResponse{
+		Accounts: responses,
+		Total:    total,
+		Limit:    limit,
+		Offset:   offset,
+	}
+}
+
+// ToStoredValueTransactionResponse converts domain.StoredValueTransaction to response
+func ToStoredValueTransactionResponse(t *domain.StoredValueTransaction) StoredValueTransactionResponse {
+	return StoredValueTransactionResponse{
+		TransactionID:   t.TransactionID,
+		AccountID:       t.AccountID,
+		TransactionType: t.TransactionType,
+		Amount:          t.Amount,
+		BalanceAfter:    t.BalanceAfter,
+		ExchangeRate:    t.ExchangeRate,
+		SaleID:          t.SaleID,
+		Description:     t.Description,
+		CreatedAt:       t.CreatedAt,
+		CreatedBy:       t.CreatedBy,
+	}
+}
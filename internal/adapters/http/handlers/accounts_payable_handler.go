@@ -0,0 +1,318 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/adapters/http/dto"
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type AccountsPayableHandler struct {
+	apService services.AccountsPayableService
+}
+
+func NewAccountsPayableHandler(apService services.AccountsPayableService) *AccountsPayableHandler {
+	return &AccountsPayableHandler{
+		apService: apService,
+	}
+}
+
+// CreateInvoice godoc
+// @Summary Record a supplier invoice
+// @Description The due date defaults to the issue date plus the supplier's credit days. Send receipt_id to bill
+// @Description a purchase receipt, the reference_id of its inbound inventory movements.
+// @Tags accounts-payable
+// @Accept json
+// @Produce json
+// @Param invoice body dto.CreateSupplierInvoiceRequest true "Invoice data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.SupplierInvoiceResponse}
+// @Router /accounts-payable/invoices [post]
+func (h *AccountsPayableHandler) CreateInvoice(c *fiber.Ctx) error {
+	var req dto.CreateSupplierInvoiceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	invoice, err := h.apService.CreateInvoice(c.Context(), req.ToServiceRequest(userID))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusCreated, dto.ToSupplierInvoiceResponse(invoice), "Supplier invoice recorded successfully")
+}
+
+// ListInvoices godoc
+// @Summary List supplier invoices
+// @Tags accounts-payable
+// @Produce json
+// @Param supplier_id query string false "Filter by supplier"
+// @Param store_id query string false "Filter by store"
+// @Param status query string false "Filter by status"
+// @Param currency query string false "Filter by currency"
+// @Param due_from query string false "Due on or after (YYYY-MM-DD)"
+// @Param due_to query string false "Due on or before (YYYY-MM-DD)"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} dto.SuccessResponse{data=dto.SupplierInvoiceListResponse}
+// @Router /accounts-payable/invoices [get]
+func (h *AccountsPayableHandler) ListInvoices(c *fiber.Ctx) error {
+	params := dto.GetPaginationParams(c)
+
+	filters, err := parsePayableFilters(c)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := domain.AccountStatus(statusStr)
+		filters.Status = &status
+	}
+
+	dueFrom, err := ParseDateQuery(c, "due_from")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	filters.DueFrom = dueFrom
+
+	dueTo, err := ParseDateQuery(c, "due_to")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	filters.DueTo = dueTo
+
+	invoices, total, err := h.apService.ListInvoices(c.Context(), filters, params.Limit, params.Offset)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToSupplierInvoiceListResponse(invoices, total, params.Limit, params.Offset)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// GetInvoice godoc
+// @Summary Get a supplier invoice with its payments
+// @Tags accounts-payable
+// @Produce json
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SupplierInvoiceResponse}
+// @Router /accounts-payable/invoices/{id} [get]
+func (h *AccountsPayableHandler) GetInvoice(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	invoice, err := h.apService.GetInvoice(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToSupplierInvoiceResponse(invoice), "")
+}
+
+// CancelInvoice godoc
+// @Summary Cancel a supplier invoice recorded by mistake
+// @Tags accounts-payable
+// @Accept json
+// @Produce json
+// @Param id path string true "Invoice ID"
+// @Param cancellation body dto.CancelSupplierInvoiceRequest true "Cancellation reason"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SupplierInvoiceResponse}
+// @Router /accounts-payable/invoices/{id}/cancel [post]
+func (h *AccountsPayableHandler) CancelInvoice(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.CancelSupplierInvoiceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	invoice, err := h.apService.CancelInvoice(c.Context(), id, req.Reason, userID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToSupplierInvoiceResponse(invoice), "Supplier invoice cancelled successfully")
+}
+
+// PayInvoice godoc
+// @Summary Pay a supplier invoice
+// @Description Payments in another currency than the invoice need an exchange_rate (VES per unit of foreign currency).
+// @Tags accounts-payable
+// @Accept json
+// @Produce json
+// @Param id path string true "Invoice ID"
+// @Param payment body dto.SupplierPaymentRequest true "Payment data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.SupplierPaymentResponse}
+// @Router /accounts-payable/invoices/{id}/payments [post]
+func (h *AccountsPayableHandler) PayInvoice(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.SupplierPaymentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	payment, err := h.apService.PayInvoice(c.Context(), req.ToServiceRequest(id, userID))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusCreated, dto.ToSupplierPaymentResponse(payment), "Payment registered successfully")
+}
+
+// GetPayments godoc
+// @Summary Get the payments of a supplier invoice
+// @Tags accounts-payable
+// @Produce json
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.SupplierPaymentResponse}
+// @Router /accounts-payable/invoices/{id}/payments [get]
+func (h *AccountsPayableHandler) GetPayments(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	payments, err := h.apService.GetPayments(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToSupplierPaymentResponses(payments), "")
+}
+
+// GetAgingReport godoc
+// @Summary Get the accounts payable aging report
+// @Description Unpaid balances by supplier in current, 1-30, 31-60, 61-90 and 90+ days past due
+// @Tags accounts-payable
+// @Produce json
+// @Param supplier_id query string false "Filter by supplier"
+// @Param store_id query string false "Filter by store"
+// @Param currency query string false "Filter by currency"
+// @Param as_of query string false "Report date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PayableAgingReportResponse}
+// @Router /accounts-payable/aging [get]
+func (h *AccountsPayableHandler) GetAgingReport(c *fiber.Ctx) error {
+	filters, err := parsePayableFilters(c)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	asOf := time.Now()
+	date, err := ParseDateQuery(c, "as_of")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	if date != nil {
+		asOf = *date
+	}
+
+	report, err := h.apService.GetAgingReport(c.Context(), filters, asOf)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToPayableAgingReportResponse(report), "")
+}
+
+// GetCashRequirements godoc
+// @Summary Forecast the cash needed to pay suppliers by due date
+// @Description Unpaid balances falling due each day of the period, with overdue balances and running totals per currency
+// @Tags accounts-payable
+// @Produce json
+// @Param supplier_id query string false "Filter by supplier"
+// @Param store_id query string false "Filter by store"
+// @Param currency query string false "Filter by currency"
+// @Param from query string false "Start date (YYYY-MM-DD), defaults to today"
+// @Param to query string false "End date (YYYY-MM-DD), defaults to 30 days after the start"
+// @Success 200 {object} dto.SuccessResponse{data=dto.CashRequirementsResponse}
+// @Router /accounts-payable/cash-requirements [get]
+func (h *AccountsPayableHandler) GetCashRequirements(c *fiber.Ctx) error {
+	filters, err := parsePayableFilters(c)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	from, err := ParseDateQuery(c, "from")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	to, err := ParseDateQuery(c, "to")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	start := time.Now()
+	if from != nil {
+		start = *from
+	}
+
+	end := start.AddDate(0, 0, 30)
+	if to != nil {
+		end = *to
+	}
+
+	forecast, err := h.apService.GetCashRequirements(c.Context(), filters, start, end)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToCashRequirementsResponse(forecast), "")
+}
+
+// parsePayableFilters reads the supplier, store and currency filters shared by listings and reports
+func parsePayableFilters(c *fiber.Ctx) (repositories.SupplierInvoiceFilters, error) {
+	filters := repositories.SupplierInvoiceFilters{}
+
+	if supplierStr := c.Query("supplier_id"); supplierStr != "" {
+		supplierID, err := uuid.Parse(supplierStr)
+		if err != nil {
+			return filters, errors.InvalidInput("Invalid supplier_id")
+		}
+		filters.SupplierID = &supplierID
+	}
+
+	if storeStr := c.Query("store_id"); storeStr != "" {
+		storeID, err := uuid.Parse(storeStr)
+		if err != nil {
+			return filters, errors.InvalidInput("Invalid store_id")
+		}
+		filters.StoreID = &storeID
+	}
+
+	if currencyStr := c.Query("currency"); currencyStr != "" {
+		currency := domain.CurrencyCode(currencyStr)
+		filters.Currency = &currency
+	}
+
+	return filters, nil
+}
@@ -0,0 +1,866 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/adapters/http/dto"
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type AccountsReceivableHandler struct {
+	arService services.AccountsReceivableService
+}
+
+func NewAccountsReceivableHandler(arService services.AccountsReceivableService) *AccountsReceivableHandler {
+	return &AccountsReceivableHandler{
+		arService: arService,
+	}
+}
+
+// ListReceivables godoc
+// @Summary List accounts receivable
+// @Tags accounts-receivable
+// @Produce json
+// @Param customer_id query string false "Filter by customer"
+// @Param store_id query string false "Filter by store of the sale"
+// @Param status query string false "Filter by status"
+// @Param currency query string false "Filter by currency"
+// @Param kind query string false "Filter by kind (SALE or LATE_FEE)"
+// @Param due_from query string false "Due on or after (YYYY-MM-DD)"
+// @Param due_to query string false "Due on or before (YYYY-MM-DD)"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} dto.SuccessResponse{data=dto.AccountsReceivableListResponse}
+// @Router /accounts-receivable [get]
+func (h *AccountsReceivableHandler) ListReceivables(c *fiber.Ctx) error {
+	params := dto.GetPaginationParams(c)
+
+	filters, err := parseReceivableFilters(c)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := domain.AccountStatus(statusStr)
+		filters.Status = &status
+	}
+
+	dueFrom, err := ParseDateQuery(c, "due_from")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	filters.DueFrom = dueFrom
+
+	dueTo, err := ParseDateQuery(c, "due_to")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	filters.DueTo = dueTo
+
+	receivables, total, err := h.arService.ListReceivables(c.Context(), filters, params.Limit, params.Offset)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToAccountsReceivableListResponse(receivables, total, params.Limit, params.Offset)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// GetOverdueReceivables godoc
+// @Summary List unpaid receivables past their due date
+// @Tags accounts-receivable
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.AccountsReceivableResponse}
+// @Router /accounts-receivable/overdue [get]
+func (h *AccountsReceivableHandler) GetOverdueReceivables(c *fiber.Ctx) error {
+	receivables, err := h.arService.GetOverdueReceivables(c.Context())
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	responses := make([]dto.AccountsReceivableResponse, len(receivables))
+	for i, ar := range receivables {
+		responses[i] = dto.ToAccountsReceivableResponse(&ar)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, responses, "")
+}
+
+// GetAgingReport godoc
+// @Summary Get the accounts receivable aging report
+// @Description Outstanding balances by customer and store in current, 1-30, 31-60, 61-90 and 90+ days past due
+// @Tags accounts-receivable
+// @Produce json
+// @Param customer_id query string false "Filter by customer"
+// @Param store_id query string false "Filter by store of the sale"
+// @Param currency query string false "Filter by currency"
+// @Param as_of query string false "Report date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} dto.SuccessResponse{data=dto.AgingReportResponse}
+// @Router /accounts-receivable/aging [get]
+func (h *AccountsReceivableHandler) GetAgingReport(c *fiber.Ctx) error {
+	filters, err := parseReceivableFilters(c)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	asOf := time.Now()
+	date, err := ParseDateQuery(c, "as_of")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	if date != nil {
+		asOf = *date
+	}
+
+	report, err := h.arService.GetAgingReport(c.Context(), filters, asOf)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToAgingReportResponse(report)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// GetCustomerStatement godoc
+// @Summary Get a customer account statement
+// @Tags accounts-receivable
+// @Produce json
+// @Param customerId path string true "Customer ID"
+// @Param currency query string false "Statement currency" default(VES)
+// @Param from query string false "Start date (YYYY-MM-DD), defaults to the first day of the month"
+// @Param to query string false "End date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} dto.SuccessResponse{data=dto.CustomerStatementResponse}
+// @Router /accounts-receivable/customers/{customerId}/statement [get]
+func (h *AccountsReceivableHandler) GetCustomerStatement(c *fiber.Ctx) error {
+	customerID, currency, from, to, err := parseStatementParams(c)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	statement, err := h.arService.GetCustomerStatement(c.Context(), customerID, currency, from, to)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToCustomerStatementResponse(statement)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// GetCustomerStatementPDF godoc
+// @Summary Download a customer account statement as PDF
+// @Tags accounts-receivable
+// @Produce application/pdf
+// @Param customerId path string true "Customer ID"
+// @Param currency query string false "Statement currency" default(VES)
+// @Param from query string false "Start date (YYYY-MM-DD), defaults to the first day of the month"
+// @Param to query string false "End date (YYYY-MM-DD), defaults to today"
+// @Success 200 {file} binary
+// @Router /accounts-receivable/customers/{customerId}/statement/pdf [get]
+func (h *AccountsReceivableHandler) GetCustomerStatementPDF(c *fiber.Ctx) error {
+	customerID, currency, from, to, err := parseStatementParams(c)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	content, err := h.arService.RenderStatementPDF(c.Context(), customerID, currency, from, to)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("inline; filename=\"statement-%s-%s.pdf\"", customerID, to.Format("2006-01-02")))
+	return c.Send(content)
+}
+
+// CreateLateFeePolicy godoc
+// @Summary Create a late fee policy
+// @Description FLAT charges the amount once the grace days pass; DAILY_PERCENT charges the amount as a percent of the balance per day late
+// @Tags accounts-receivable
+// @Accept json
+// @Produce json
+// @Param policy body dto.LateFeePolicyRequest true "Policy data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.LateFeePolicyResponse}
+// @Router /accounts-receivable/late-fee-policies [post]
+func (h *AccountsReceivableHandler) CreateLateFeePolicy(c *fiber.Ctx) error {
+	var req dto.LateFeePolicyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	policy := req.ToLateFeePolicyDomain()
+
+	userID, ok := GetUserID(c)
+	if ok {
+		policy.CreatedBy = &userID
+	}
+
+	if err := h.arService.CreateLateFeePolicy(c.Context(), policy); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToLateFeePolicyResponse(policy)
+	return dto.SendSuccess(c, fiber.StatusCreated, response, "Late fee policy created successfully")
+}
+
+// ListLateFeePolicies godoc
+// @Summary List late fee policies
+// @Tags accounts-receivable
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.LateFeePolicyResponse}
+// @Router /accounts-receivable/late-fee-policies [get]
+func (h *AccountsReceivableHandler) ListLateFeePolicies(c *fiber.Ctx) error {
+	policies, err := h.arService.ListLateFeePolicies(c.Context())
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	responses := make([]dto.LateFeePolicyResponse, len(policies))
+	for i, policy := range policies {
+		responses[i] = dto.ToLateFeePolicyResponse(&policy)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, responses, "")
+}
+
+// ListCreditOverrides godoc
+// @Summary List credit sales approved outside the customer's terms
+// @Tags accounts-receivable
+// @Produce json
+// @Param customer_id query string false "Customer ID"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} dto.SuccessResponse{data=dto.CreditOverrideListResponse}
+// @Router /accounts-receivable/credit-overrides [get]
+func (h *AccountsReceivableHandler) ListCreditOverrides(c *fiber.Ctx) error {
+	params := dto.GetPaginationParams(c)
+
+	filters, err := parseReceivableFilters(c)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	overrides, total, err := h.arService.ListCreditOverrides(c.Context(), filters.CustomerID, params.Limit, params.Offset)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToCreditOverrideListResponse(overrides, total, params.Limit, params.Offset)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// ListInstallmentPlans godoc
+// @Summary List credit sales split into installments
+// @Tags accounts-receivable
+// @Produce json
+// @Param customer_id query string false "Customer ID"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} dto.SuccessResponse{data=dto.InstallmentPlanListResponse}
+// @Router /accounts-receivable/installment-plans [get]
+func (h *AccountsReceivableHandler) ListInstallmentPlans(c *fiber.Ctx) error {
+	params := dto.GetPaginationParams(c)
+
+	filters, err := parseReceivableFilters(c)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	plans, total, err := h.arService.ListInstallmentPlans(c.Context(), filters.CustomerID, params.Limit, params.Offset)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToInstallmentPlanListResponse(plans, total, params.Limit, params.Offset)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// GetInstallmentPlan godoc
+// @Summary Get an installment plan with its installments
+// @Tags accounts-receivable
+// @Produce json
+// @Param id path string true "Plan ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.InstallmentPlanResponse}
+// @Router /accounts-receivable/installment-plans/{id} [get]
+func (h *AccountsReceivableHandler) GetInstallmentPlan(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	plan, err := h.arService.GetInstallmentPlan(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToInstallmentPlanResponse(plan)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// GetLateFeePolicy godoc
+// @Summary Get a late fee policy by ID
+// @Tags accounts-receivable
+// @Produce json
+// @Param id path string true "Policy ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.LateFeePolicyResponse}
+// @Router /accounts-receivable/late-fee-policies/{id} [get]
+func (h *AccountsReceivableHandler) GetLateFeePolicy(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	policy, err := h.arService.GetLateFeePolicy(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToLateFeePolicyResponse(policy)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// UpdateLateFeePolicy godoc
+// @Summary Update a late fee policy
+// @Tags accounts-receivable
+// @Accept json
+// @Produce json
+// @Param id path string true "Policy ID"
+// @Param policy body dto.LateFeePolicyRequest true "Policy data"
+// @Success 200 {object} dto.SuccessResponse{data=dto.LateFeePolicyResponse}
+// @Router /accounts-receivable/late-fee-policies/{id} [put]
+func (h *AccountsReceivableHandler) UpdateLateFeePolicy(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.LateFeePolicyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	policy := req.ToLateFeePolicyDomain()
+	policy.PolicyID = id
+
+	if err := h.arService.UpdateLateFeePolicy(c.Context(), policy); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToLateFeePolicyResponse(policy)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "Late fee policy updated successfully")
+}
+
+// DeleteLateFeePolicy godoc
+// @Summary Delete a late fee policy
+// @Tags accounts-receivable
+// @Produce json
+// @Param id path string true "Policy ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Router /accounts-receivable/late-fee-policies/{id} [delete]
+func (h *AccountsReceivableHandler) DeleteLateFeePolicy(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err := h.arService.DeleteLateFeePolicy(c.Context(), id); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, nil, "Late fee policy deleted successfully")
+}
+
+// GetAccountsReceivable godoc
+// @Summary Get accounts receivable by ID
+// @Tags accounts-receivable
+// @Produce json
+// @Param id path string true "Receivable ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.AccountsReceivableResponse}
+// @Router /accounts-receivable/{id} [get]
+func (h *AccountsReceivableHandler) GetAccountsReceivable(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	ar, err := h.arService.GetAccountsReceivable(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToAccountsReceivableResponse(ar)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// GetPaymentHistory godoc
+// @Summary List the payments of an accounts receivable
+// @Tags accounts-receivable
+// @Produce json
+// @Param id path string true "Receivable ID"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.CustomerPaymentResponse}
+// @Router /accounts-receivable/{id}/payments [get]
+func (h *AccountsReceivableHandler) GetPaymentHistory(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	payments, err := h.arService.GetPaymentHistory(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	responses := make([]dto.CustomerPaymentResponse, len(payments))
+	for i, payment := range payments {
+		responses[i] = dto.ToCustomerPaymentResponse(&payment)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, responses, "")
+}
+
+// RegisterPayment godoc
+// @Summary Register a payment on accounts receivable
+// @Tags accounts-receivable
+// @Accept json
+// @Produce json
+// @Param id path string true "Receivable ID"
+// @Param payment body dto.PaymentRequest true "Payment data"
+// @Success 200 {object} dto.SuccessResponse
+// @Router /accounts-receivable/{id}/payments [post]
+func (h *AccountsReceivableHandler) RegisterPayment(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.PaymentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, _ := GetUserID(c)
+	if err := h.arService.RegisterPayment(c.Context(), id, req.Amount, req.Currency, req.PaymentMethod, req.Reference, req.Notes, userID); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, nil, "Payment registered successfully")
+}
+
+// VoidPayment godoc
+// @Summary Void a payment registered by mistake, restoring the receivable balance
+// @Tags accounts-receivable
+// @Accept json
+// @Produce json
+// @Param paymentId path string true "Payment ID"
+// @Param void body dto.VoidPaymentRequest true "Void reason"
+// @Success 200 {object} dto.SuccessResponse{data=dto.CustomerPaymentResponse}
+// @Router /accounts-receivable/payments/{paymentId}/void [post]
+func (h *AccountsReceivableHandler) VoidPayment(c *fiber.Ctx) error {
+	paymentID, err := ParseUUID(c, "paymentId")
+	if err != nil {
+		return err
+	}
+
+	var req dto.VoidPaymentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	payment, err := h.arService.VoidPayment(c.Context(), paymentID, req.Reason, userID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToCustomerPaymentResponse(payment), "Payment voided successfully")
+}
+
+// IssueCreditNote godoc
+// @Summary Issue a credit note reducing a receivable for a return or discount
+// @Tags accounts-receivable
+// @Accept json
+// @Produce json
+// @Param id path string true "Receivable ID"
+// @Param creditNote body dto.CreditNoteRequest true "Credit note data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.ReceivableAdjustmentResponse}
+// @Router /accounts-receivable/{id}/credit-notes [post]
+func (h *AccountsReceivableHandler) IssueCreditNote(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreditNoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	adjustment, err := h.arService.IssueCreditNote(c.Context(), services.CreditNoteRequest{
+		ReceivableID: id,
+		Amount:       req.Amount,
+		Reason:       req.Reason,
+		Reference:    req.Reference,
+		Description:  req.Description,
+		UserID:       userID,
+	})
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusCreated, dto.ToReceivableAdjustmentResponse(adjustment), "Credit note issued successfully")
+}
+
+// RequestWriteOff godoc
+// @Summary Request a bad-debt write-off of a receivable, pending supervisor approval
+// @Tags accounts-receivable
+// @Accept json
+// @Produce json
+// @Param id path string true "Receivable ID"
+// @Param writeOff body dto.WriteOffRequest true "Write-off data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.ReceivableAdjustmentResponse}
+// @Router /accounts-receivable/{id}/write-offs [post]
+func (h *AccountsReceivableHandler) RequestWriteOff(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.WriteOffRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	adjustment, err := h.arService.RequestWriteOff(c.Context(), services.WriteOffRequest{
+		ReceivableID: id,
+		Amount:       req.Amount,
+		Description:  req.Description,
+		UserID:       userID,
+	})
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusCreated, dto.ToReceivableAdjustmentResponse(adjustment), "Write-off requested successfully")
+}
+
+// ListAdjustments godoc
+// @Summary List credit notes and write-offs
+// @Tags accounts-receivable
+// @Produce json
+// @Param customer_id query string false "Customer ID"
+// @Param receivable_id query string false "Receivable ID"
+// @Param type query string false "CREDIT_NOTE or WRITE_OFF"
+// @Param status query string false "PENDING, APPLIED or REJECTED"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReceivableAdjustmentListResponse}
+// @Router /accounts-receivable/adjustments [get]
+func (h *AccountsReceivableHandler) ListAdjustments(c *fiber.Ctx) error {
+	params := dto.GetPaginationParams(c)
+
+	receivableFilters, err := parseReceivableFilters(c)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	filters := repositories.AdjustmentFilters{CustomerID: receivableFilters.CustomerID}
+
+	if receivableStr := c.Query("receivable_id"); receivableStr != "" {
+		receivableID, err := uuid.Parse(receivableStr)
+		if err != nil {
+			return HandleServiceError(c, errors.InvalidInput("Invalid receivable_id"))
+		}
+		filters.ReceivableID = &receivableID
+	}
+
+	if typeStr := c.Query("type"); typeStr != "" {
+		adjustmentType := domain.AdjustmentType(typeStr)
+		filters.Type = &adjustmentType
+	}
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := domain.AdjustmentStatus(statusStr)
+		filters.Status = &status
+	}
+
+	adjustments, total, err := h.arService.ListAdjustments(c.Context(), filters, params.Limit, params.Offset)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToReceivableAdjustmentListResponse(adjustments, total, params.Limit, params.Offset)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// GetAdjustment godoc
+// @Summary Get a credit note or write-off by ID
+// @Tags accounts-receivable
+// @Produce json
+// @Param id path string true "Adjustment ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReceivableAdjustmentResponse}
+// @Router /accounts-receivable/adjustments/{id} [get]
+func (h *AccountsReceivableHandler) GetAdjustment(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	adjustment, err := h.arService.GetAdjustment(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToReceivableAdjustmentResponse(adjustment), "")
+}
+
+// ApproveWriteOff godoc
+// @Summary Approve a pending write-off, applying it to the receivable
+// @Tags accounts-receivable
+// @Accept json
+// @Produce json
+// @Param id path string true "Adjustment ID"
+// @Param review body dto.ReviewAdjustmentRequest false "Review notes"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReceivableAdjustmentResponse}
+// @Router /accounts-receivable/adjustments/{id}/approve [post]
+func (h *AccountsReceivableHandler) ApproveWriteOff(c *fiber.Ctx) error {
+	return h.reviewWriteOff(c, h.arService.ApproveWriteOff, "Write-off approved successfully")
+}
+
+// RejectWriteOff godoc
+// @Summary Reject a pending write-off
+// @Tags accounts-receivable
+// @Accept json
+// @Produce json
+// @Param id path string true "Adjustment ID"
+// @Param review body dto.ReviewAdjustmentRequest false "Review notes"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReceivableAdjustmentResponse}
+// @Router /accounts-receivable/adjustments/{id}/reject [post]
+func (h *AccountsReceivableHandler) RejectWriteOff(c *fiber.Ctx) error {
+	return h.reviewWriteOff(c, h.arService.RejectWriteOff, "Write-off rejected")
+}
+
+// reviewWriteOff runs an approval or rejection of a write-off by the authenticated user
+func (h *AccountsReceivableHandler) reviewWriteOff(
+	c *fiber.Ctx,
+	review func(ctx context.Context, id uuid.UUID, approverID uuid.UUID, notes *string) (*domain.ReceivableAdjustment, error),
+	message string,
+) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.ReviewAdjustmentRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+		}
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	adjustment, err := review(c.Context(), id, userID, req.Notes)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToReceivableAdjustmentResponse(adjustment), message)
+}
+
+// ReceiveCustomerPayment godoc
+// @Summary Receive a customer payment across several receivables
+// @Description Allocates to the oldest receivables first unless allocations are given. Any excess
+// @Description becomes store credit. Receivables in another currency need exchange_rate.
+// @Tags accounts-receivable
+// @Accept json
+// @Produce json
+// @Param payment body dto.CustomerPaymentRequest true "Payment data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.CustomerReceiptResponse}
+// @Router /accounts-receivable/receipts [post]
+func (h *AccountsReceivableHandler) ReceiveCustomerPayment(c *fiber.Ctx) error {
+	var req dto.CustomerPaymentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	receipt, err := h.arService.ReceiveCustomerPayment(c.Context(), req.ToServiceRequest(userID))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusCreated, dto.ToCustomerReceiptResponse(receipt), "Payment received successfully")
+}
+
+// ListCustomerReceipts godoc
+// @Summary List customer receipts
+// @Tags accounts-receivable
+// @Produce json
+// @Param customer_id query string false "Customer ID"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} dto.SuccessResponse{data=dto.CustomerReceiptListResponse}
+// @Router /accounts-receivable/receipts [get]
+func (h *AccountsReceivableHandler) ListCustomerReceipts(c *fiber.Ctx) error {
+	params := dto.GetPaginationParams(c)
+
+	filters, err := parseReceivableFilters(c)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	receipts, total, err := h.arService.ListCustomerReceipts(c.Context(), filters.CustomerID, params.Limit, params.Offset)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToCustomerReceiptListResponse(receipts, total, params.Limit, params.Offset)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// GetCustomerReceipt godoc
+// @Summary Get a customer receipt with its allocations
+// @Tags accounts-receivable
+// @Produce json
+// @Param id path string true "Receipt ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.CustomerReceiptResponse}
+// @Router /accounts-receivable/receipts/{id} [get]
+func (h *AccountsReceivableHandler) GetCustomerReceipt(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	receipt, err := h.arService.GetCustomerReceipt(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToCustomerReceiptResponse(receipt), "")
+}
+
+// ReverseCustomerReceipt godoc
+// @Summary Reverse a customer receipt, undoing its allocations and store credit
+// @Tags accounts-receivable
+// @Accept json
+// @Produce json
+// @Param id path string true "Receipt ID"
+// @Param reversal body dto.ReverseReceiptRequest true "Reversal reason"
+// @Success 200 {object} dto.SuccessResponse{data=dto.CustomerReceiptResponse}
+// @Router /accounts-receivable/receipts/{id}/reverse [post]
+func (h *AccountsReceivableHandler) ReverseCustomerReceipt(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.ReverseReceiptRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	receipt, err := h.arService.ReverseCustomerPayment(c.Context(), id, req.Reason, userID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToCustomerReceiptResponse(receipt), "Receipt reversed successfully")
+}
+
+// parseReceivableFilters reads the customer, store and currency filters shared by listings and reports
+func parseReceivableFilters(c *fiber.Ctx) (repositories.AccountsReceivableFilters, error) {
+	filters := repositories.AccountsReceivableFilters{}
+
+	if customerStr := c.Query("customer_id"); customerStr != "" {
+		customerID, err := uuid.Parse(customerStr)
+		if err != nil {
+			return filters, errors.InvalidInput("Invalid customer_id")
+		}
+		filters.CustomerID = &customerID
+	}
+
+	if storeStr := c.Query("store_id"); storeStr != "" {
+		storeID, err := uuid.Parse(storeStr)
+		if err != nil {
+			return filters, errors.InvalidInput("Invalid store_id")
+		}
+		filters.StoreID = &storeID
+	}
+
+	if currencyStr := c.Query("currency"); currencyStr != "" {
+		currency := domain.CurrencyCode(currencyStr)
+		filters.Currency = &currency
+	}
+
+	if kindStr := c.Query("kind"); kindStr != "" {
+		kind := domain.ReceivableKind(kindStr)
+		filters.Kind = &kind
+	}
+
+	return filters, nil
+}
+
+// parseStatementParams reads the customer, currency and period of a statement
+func parseStatementParams(c *fiber.Ctx) (uuid.UUID, domain.CurrencyCode, time.Time, time.Time, error) {
+	customerID, err := uuid.Parse(c.Params("customerId"))
+	if err != nil {
+		return uuid.Nil, "", time.Time{}, time.Time{}, errors.InvalidInput("Invalid customer ID format")
+	}
+
+	currency := domain.CurrencyCode(c.Query("currency", string(domain.CurrencyVES)))
+
+	from, err := ParseDateQuery(c, "from")
+	if err != nil {
+		return uuid.Nil, "", time.Time{}, time.Time{}, err
+	}
+
+	to, err := ParseDateQuery(c, "to")
+	if err != nil {
+		return uuid.Nil, "", time.Time{}, time.Time{}, err
+	}
+
+	end := time.Now()
+	if to != nil {
+		end = to.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	start := time.Date(end.Year(), end.Month(), 1, 0, 0, 0, 0, end.Location())
+	if from != nil {
+		start = *from
+	}
+
+	return customerID, currency, start, end, nil
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jadiazinf/inventory/internal/adapters/http/dto"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type CampaignHandler struct {
+	campaignService services.CampaignService
+}
+
+func NewCampaignHandler(campaignService services.CampaignService) *CampaignHandler {
+	return &CampaignHandler{
+		campaignService: campaignService,
+	}
+}
+
+// CreateCampaign godoc
+// @Summary Create a new campaign
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Param campaign body dto.CampaignRequest true "Campaign data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.CampaignResponse}
+// @Router /campaigns [post]
+func (h *CampaignHandler) CreateCampaign(c *fiber.Ctx) error {
+	var req dto.CampaignRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	campaign := req.ToCampaignDomain()
+
+	userID, ok := GetUserID(c)
+	if ok {
+		campaign.CreatedBy = &userID
+	}
+
+	if err := h.campaignService.CreateCampaign(c.Context(), campaign); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToCampaignResponse(campaign)
+	return dto.SendSuccess(c, fiber.StatusCreated, response, "Campaign created successfully")
+}
+
+// GetCampaign godoc
+// @Summary Get a campaign by ID
+// @Tags campaigns
+// @Produce json
+// @Param id path string true "Campaign ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.CampaignResponse}
+// @Router /campaigns/{id} [get]
+func (h *CampaignHandler) GetCampaign(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	campaign, err := h.campaignService.GetCampaign(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToCampaignResponse(campaign)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// ListCampaigns godoc
+// @Summary List campaigns with filters and pagination
+// @Tags campaigns
+// @Produce json
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param active query bool false "Only active campaigns"
+// @Param type query string false "Promotion type filter"
+// @Param search query string false "Search term"
+// @Success 200 {object} dto.SuccessResponse{data=dto.CampaignListResponse}
+// @Router /campaigns [get]
+func (h *CampaignHandler) ListCampaigns(c *fiber.Ctx) error {
+	params := dto.GetPaginationParams(c)
+	filters := repositories.CampaignFilters{
+		Search: c.Query("search", ""),
+	}
+
+	if activeStr := c.Query("active"); activeStr != "" {
+		active := activeStr == "true"
+		filters.IsActive = &active
+	}
+
+	if typeStr := c.Query("type"); typeStr != "" {
+		promotionType := domain.PromotionType(typeStr)
+		filters.PromotionType = &promotionType
+	}
+
+	campaigns, total, err := h.campaignService.ListCampaigns(c.Context(), filters, params.Limit, params.Offset)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToCampaignListResponse(campaigns, total, params.Limit, params.Offset)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// UpdateCampaign godoc
+// @Summary Update a campaign
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Param id path string true "Campaign ID"
+// @Param campaign body dto.CampaignRequest true "Campaign data"
+// @Success 200 {object} dto.SuccessResponse{data=dto.CampaignResponse}
+// @Router /campaigns/{id} [put]
+func (h *CampaignHandler) UpdateCampaign(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.CampaignRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	campaign := req.ToCampaignDomain()
+	campaign.CampaignID = id
+
+	if err := h.campaignService.UpdateCampaign(c.Context(), campaign); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	updated, err := h.campaignService.GetCampaign(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToCampaignResponse(updated)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "Campaign updated successfully")
+}
+
+// DeleteCampaign godoc
+// @Summary Delete a campaign
+// @Tags campaigns
+// @Produce json
+// @Param id path string true "Campaign ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Router /campaigns/{id} [delete]
+func (h *CampaignHandler) DeleteCampaign(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err := h.campaignService.DeleteCampaign(c.Context(), id); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, nil, "Campaign deleted successfully")
+}
@@ -322,32 +322,3 @@ func (h *CustomerHandler) DeleteChild(c *fiber.Ctx) error {
 
 	return dto.SendSuccess(c, fiber.StatusOK, nil, "Child deleted successfully")
 }
-
-// UpdateLoyaltyPoints godoc
-// @Summary Update customer loyalty points
-// @Tags customers
-// @Accept json
-// @Produce json
-// @Param id path string true "Customer ID"
-// @Param points body map[string]int true "Points to add/subtract"
-// @Success 200 {object} dto.SuccessResponse
-// @Router /customers/{id}/loyalty-points [put]
-func (h *CustomerHandler) UpdateLoyaltyPoints(c *fiber.Ctx) error {
-	customerID, err := ParseUUID(c, "id")
-	if err != nil {
-		return err
-	}
-
-	var req struct {
-		Points int `json:"points"`
-	}
-	if err := c.BodyParser(&req); err != nil {
-		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
-	}
-
-	if err := h.customerRepo.UpdateLoyaltyPoints(c.Context(), customerID, req.Points); err != nil {
-		return HandleServiceError(c, err)
-	}
-
-	return dto.SendSuccess(c, fiber.StatusOK, nil, "Loyalty points updated successfully")
-}
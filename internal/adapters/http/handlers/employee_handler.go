@@ -0,0 +1,388 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jadiazinf/inventory/internal/adapters/http/dto"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type EmployeeHandler struct {
+	employeeService services.EmployeeService
+}
+
+func NewEmployeeHandler(employeeService services.EmployeeService) *EmployeeHandler {
+	return &EmployeeHandler{
+		employeeService: employeeService,
+	}
+}
+
+// CreateEmployee godoc
+// @Summary Hire an employee
+// @Description The employee starts ACTIVE and, when store_id is given, assigned to that store from its hire date
+// @Tags employees
+// @Accept json
+// @Produce json
+// @Param employee body dto.EmployeeRequest true "Employee data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.EmployeeResponse}
+// @Router /employees [post]
+func (h *EmployeeHandler) CreateEmployee(c *fiber.Ctx) error {
+	var req dto.EmployeeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	employee := req.ToEmployeeDomain()
+
+	userID, ok := GetUserID(c)
+	if ok {
+		employee.CreatedBy = &userID
+	}
+
+	if err := h.employeeService.CreateEmployee(c.Context(), employee); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	created, err := h.employeeService.GetEmployee(c.Context(), employee.EmployeeID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusCreated, dto.ToEmployeeResponse(created), "Employee created successfully")
+}
+
+// ListEmployees godoc
+// @Summary List employees
+// @Tags employees
+// @Produce json
+// @Param store_id query string false "Filter by store"
+// @Param department query string false "Filter by department"
+// @Param status query string false "Filter by status"
+// @Param search query string false "Search by name, national ID or email"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} dto.SuccessResponse{data=dto.EmployeeListResponse}
+// @Router /employees [get]
+func (h *EmployeeHandler) ListEmployees(c *fiber.Ctx) error {
+	params := dto.GetPaginationParams(c)
+	filters := repositories.EmployeeFilters{
+		Department: c.Query("department"),
+		Search:     c.Query("search"),
+	}
+
+	storeID, err := parseOptionalUUIDQuery(c, "store_id")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	filters.StoreID = storeID
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := domain.EmployeeStatus(statusStr)
+		filters.Status = &status
+	}
+
+	employees, total, err := h.employeeService.ListEmployees(c.Context(), filters, params.Limit, params.Offset)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToEmployeeListResponse(employees, total, params.Limit, params.Offset)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// GetEmployee godoc
+// @Summary Get an employee by ID
+// @Tags employees
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.EmployeeResponse}
+// @Router /employees/{id} [get]
+func (h *EmployeeHandler) GetEmployee(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	employee, err := h.employeeService.GetEmployee(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToEmployeeResponse(employee), "")
+}
+
+// UpdateEmployee godoc
+// @Summary Update an employee
+// @Description Store, status and user link are kept; they change through their own endpoints
+// @Tags employees
+// @Accept json
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Param employee body dto.EmployeeRequest true "Employee data"
+// @Success 200 {object} dto.SuccessResponse{data=dto.EmployeeResponse}
+// @Router /employees/{id} [put]
+func (h *EmployeeHandler) UpdateEmployee(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.EmployeeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	employee := req.ToEmployeeDomain()
+	employee.EmployeeID = id
+
+	userID, ok := GetUserID(c)
+	if ok {
+		employee.UpdatedBy = &userID
+	}
+
+	if err := h.employeeService.UpdateEmployee(c.Context(), employee); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	// Reload updated employee
+	updated, err := h.employeeService.GetEmployee(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToEmployeeResponse(updated), "Employee updated successfully")
+}
+
+// DeleteEmployee godoc
+// @Summary Delete an employee (soft delete)
+// @Description Only terminated or inactive employees can be deleted
+// @Tags employees
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Router /employees/{id} [delete]
+func (h *EmployeeHandler) DeleteEmployee(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err := h.employeeService.DeleteEmployee(c.Context(), id); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, nil, "Employee deleted successfully")
+}
+
+// ActivateEmployee godoc
+// @Summary Return an employee to ACTIVE
+// @Description Ends a leave or reactivates an inactive employee. A terminated employee is rehired from date,
+// @Description which must be after its termination date; its user stays inactive until reactivated.
+// @Tags employees
+// @Accept json
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Param status body dto.EmployeeStatusRequest false "Rehire date"
+// @Success 200 {object} dto.SuccessResponse{data=dto.EmployeeResponse}
+// @Router /employees/{id}/activate [post]
+func (h *EmployeeHandler) ActivateEmployee(c *fiber.Ctx) error {
+	return h.changeStatus(c, domain.EmployeeStatusActive, "Employee activated successfully")
+}
+
+// PutOnLeave godoc
+// @Summary Put an active employee on leave
+// @Tags employees
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.EmployeeResponse}
+// @Router /employees/{id}/leave [post]
+func (h *EmployeeHandler) PutOnLeave(c *fiber.Ctx) error {
+	return h.changeStatus(c, domain.EmployeeStatusOnLeave, "Employee put on leave successfully")
+}
+
+// DeactivateEmployee godoc
+// @Summary Deactivate an active employee
+// @Tags employees
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.EmployeeResponse}
+// @Router /employees/{id}/deactivate [post]
+func (h *EmployeeHandler) DeactivateEmployee(c *fiber.Ctx) error {
+	return h.changeStatus(c, domain.EmployeeStatusInactive, "Employee deactivated successfully")
+}
+
+// TerminateEmployee godoc
+// @Summary Terminate an employee
+// @Description Records the termination date and reason, closes the current store assignment and deactivates
+// @Description the linked user
+// @Tags employees
+// @Accept json
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Param status body dto.EmployeeStatusRequest true "Termination date and reason"
+// @Success 200 {object} dto.SuccessResponse{data=dto.EmployeeResponse}
+// @Router /employees/{id}/terminate [post]
+func (h *EmployeeHandler) TerminateEmployee(c *fiber.Ctx) error {
+	return h.changeStatus(c, domain.EmployeeStatusTerminated, "Employee terminated successfully")
+}
+
+// AssignStore godoc
+// @Summary Move an employee to another store
+// @Description Closes the current store assignment the day the new one starts
+// @Tags employees
+// @Accept json
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Param assignment body dto.AssignStoreRequest true "Store and start date"
+// @Success 200 {object} dto.SuccessResponse{data=dto.EmployeeResponse}
+// @Router /employees/{id}/store [post]
+func (h *EmployeeHandler) AssignStore(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.AssignStoreRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	employee, err := h.employeeService.AssignStore(c.Context(), services.AssignStoreRequest{
+		EmployeeID: id,
+		StoreID:    req.StoreID,
+		StartDate:  req.StartDate,
+		Reason:     req.Reason,
+		UserID:     userID,
+	})
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToEmployeeResponse(employee), "Employee assigned to store successfully")
+}
+
+// GetStoreHistory godoc
+// @Summary Get the store assignments of an employee, latest first
+// @Tags employees
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.EmployeeStoreAssignmentResponse}
+// @Router /employees/{id}/store-history [get]
+func (h *EmployeeHandler) GetStoreHistory(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	assignments, err := h.employeeService.GetStoreHistory(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	responses := make([]dto.EmployeeStoreAssignmentResponse, len(assignments))
+	for i := range assignments {
+		responses[i] = dto.ToEmployeeStoreAssignmentResponse(&assignments[i])
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, responses, "")
+}
+
+// LinkUser godoc
+// @Summary Link a system user to an employee
+// @Description A user can be linked to one employee only
+// @Tags employees
+// @Accept json
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Param user body dto.LinkUserRequest true "User to link"
+// @Success 200 {object} dto.SuccessResponse{data=dto.EmployeeResponse}
+// @Router /employees/{id}/user [post]
+func (h *EmployeeHandler) LinkUser(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.LinkUserRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	employee, err := h.employeeService.LinkUser(c.Context(), id, req.UserID, userID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToEmployeeResponse(employee), "User linked successfully")
+}
+
+// UnlinkUser godoc
+// @Summary Remove the system user of an employee
+// @Tags employees
+// @Produce json
+// @Param id path string true "Employee ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.EmployeeResponse}
+// @Router /employees/{id}/user [delete]
+func (h *EmployeeHandler) UnlinkUser(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	employee, err := h.employeeService.UnlinkUser(c.Context(), id, userID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToEmployeeResponse(employee), "User unlinked successfully")
+}
+
+// changeStatus applies a status transition to the employee of the request
+func (h *EmployeeHandler) changeStatus(c *fiber.Ctx, status domain.EmployeeStatus, message string) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.EmployeeStatusRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+		}
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	employee, err := h.employeeService.ChangeStatus(c.Context(), services.ChangeEmployeeStatusRequest{
+		EmployeeID: id,
+		Status:     status,
+		Date:       req.Date,
+		Reason:     req.Reason,
+		UserID:     userID,
+	})
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToEmployeeResponse(employee), message)
+}
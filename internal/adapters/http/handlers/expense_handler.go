@@ -0,0 +1,391 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/adapters/http/dto"
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type ExpenseHandler struct {
+	expenseService services.ExpenseService
+}
+
+func NewExpenseHandler(expenseService services.ExpenseService) *ExpenseHandler {
+	return &ExpenseHandler{
+		expenseService: expenseService,
+	}
+}
+
+// CreateExpense godoc
+// @Summary Register a store expense
+// @Description The expense gets the approval chain of the rules its amount in VES reaches; with no rule applying
+// @Description it is approved right away. Expenses in another currency than VES need an exchange_rate.
+// @Tags expenses
+// @Accept json
+// @Produce json
+// @Param expense body dto.CreateExpenseRequest true "Expense data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.ExpenseResponse}
+// @Router /expenses [post]
+func (h *ExpenseHandler) CreateExpense(c *fiber.Ctx) error {
+	var req dto.CreateExpenseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	expense, err := h.expenseService.CreateExpense(c.Context(), req.ToServiceRequest(userID))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusCreated, dto.ToExpenseResponse(expense), "Expense registered successfully")
+}
+
+// ListExpenses godoc
+// @Summary List expenses
+// @Tags expenses
+// @Produce json
+// @Param store_id query string false "Filter by store"
+// @Param category query string false "Filter by category"
+// @Param status query string false "Filter by approval status"
+// @Param supplier_id query string false "Filter by supplier"
+// @Param from query string false "Spent on or after (YYYY-MM-DD)"
+// @Param to query string false "Spent on or before (YYYY-MM-DD)"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} dto.SuccessResponse{data=dto.ExpenseListResponse}
+// @Router /expenses [get]
+func (h *ExpenseHandler) ListExpenses(c *fiber.Ctx) error {
+	params := dto.GetPaginationParams(c)
+	filters := repositories.ExpenseFilters{}
+
+	storeID, err := parseOptionalUUIDQuery(c, "store_id")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	filters.StoreID = storeID
+
+	supplierID, err := parseOptionalUUIDQuery(c, "supplier_id")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	filters.SupplierID = supplierID
+
+	if categoryStr := c.Query("category"); categoryStr != "" {
+		category := domain.ExpenseCategory(categoryStr)
+		filters.Category = &category
+	}
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := domain.ExpenseApprovalStatus(statusStr)
+		filters.Status = &status
+	}
+
+	from, err := ParseDateQuery(c, "from")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	filters.DateFrom = from
+
+	to, err := ParseDateQuery(c, "to")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	filters.DateTo = to
+
+	expenses, total, err := h.expenseService.ListExpenses(c.Context(), filters, params.Limit, params.Offset)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToExpenseListResponse(expenses, total, params.Limit, params.Offset)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// GetExpense godoc
+// @Summary Get an expense with its approval chain
+// @Tags expenses
+// @Produce json
+// @Param id path string true "Expense ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ExpenseResponse}
+// @Router /expenses/{id} [get]
+func (h *ExpenseHandler) GetExpense(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	expense, err := h.expenseService.GetExpense(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToExpenseResponse(expense), "")
+}
+
+// ApproveExpense godoc
+// @Summary Approve the next pending level of an expense
+// @Description The approver must hold the role of the level and cannot be the requester nor have decided another level
+// @Tags expenses
+// @Accept json
+// @Produce json
+// @Param id path string true "Expense ID"
+// @Param decision body dto.ExpenseDecisionRequest false "Comments"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ExpenseResponse}
+// @Router /expenses/{id}/approve [post]
+func (h *ExpenseHandler) ApproveExpense(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.ExpenseDecisionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+		}
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	expense, err := h.expenseService.ApproveExpense(c.Context(), id, userID, req.Comments)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToExpenseResponse(expense), "Expense approved successfully")
+}
+
+// RejectExpense godoc
+// @Summary Reject an expense at its next pending level
+// @Tags expenses
+// @Accept json
+// @Produce json
+// @Param id path string true "Expense ID"
+// @Param decision body dto.ExpenseDecisionRequest true "Rejection comments"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ExpenseResponse}
+// @Router /expenses/{id}/reject [post]
+func (h *ExpenseHandler) RejectExpense(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.ExpenseDecisionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	comments := ""
+	if req.Comments != nil {
+		comments = *req.Comments
+	}
+
+	expense, err := h.expenseService.RejectExpense(c.Context(), id, userID, comments)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToExpenseResponse(expense), "Expense rejected successfully")
+}
+
+// GetMonthlySummary godoc
+// @Summary Get the approved expenses per month, store and category
+// @Tags expenses
+// @Produce json
+// @Param store_id query string false "Filter by store"
+// @Param from query string false "First month (YYYY-MM), defaults to the current month"
+// @Param to query string false "Last month (YYYY-MM), defaults to the first month"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ExpenseSummaryResponse}
+// @Router /expenses/summary [get]
+func (h *ExpenseHandler) GetMonthlySummary(c *fiber.Ctx) error {
+	storeID, err := parseOptionalUUIDQuery(c, "store_id")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	from, err := ParseMonthQuery(c, "from")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	to, err := ParseMonthQuery(c, "to")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	start := time.Now()
+	if from != nil {
+		start = *from
+	}
+
+	end := start
+	if to != nil {
+		end = *to
+	}
+
+	summary, err := h.expenseService.GetMonthlySummary(c.Context(), storeID, start, end)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToExpenseSummaryResponse(summary), "")
+}
+
+// CreateApprovalRule godoc
+// @Summary Create an expense approval rule
+// @Description Expenses whose amount in VES reaches min_amount need the approval of a user with role_name at the
+// @Description rule's level. Levels are decided in ascending order; store_id limits the rule to one store.
+// @Tags expenses
+// @Accept json
+// @Produce json
+// @Param rule body dto.ExpenseApprovalRuleRequest true "Rule data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.ExpenseApprovalRuleResponse}
+// @Router /expenses/approval-rules [post]
+func (h *ExpenseHandler) CreateApprovalRule(c *fiber.Ctx) error {
+	var req dto.ExpenseApprovalRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	rule := req.ToApprovalRuleDomain()
+
+	userID, ok := GetUserID(c)
+	if ok {
+		rule.CreatedBy = &userID
+	}
+
+	if err := h.expenseService.CreateApprovalRule(c.Context(), rule); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToExpenseApprovalRuleResponse(rule)
+	return dto.SendSuccess(c, fiber.StatusCreated, response, "Expense approval rule created successfully")
+}
+
+// ListApprovalRules godoc
+// @Summary List expense approval rules
+// @Tags expenses
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.ExpenseApprovalRuleResponse}
+// @Router /expenses/approval-rules [get]
+func (h *ExpenseHandler) ListApprovalRules(c *fiber.Ctx) error {
+	rules, err := h.expenseService.ListApprovalRules(c.Context())
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	responses := make([]dto.ExpenseApprovalRuleResponse, len(rules))
+	for i := range rules {
+		responses[i] = dto.ToExpenseApprovalRuleResponse(&rules[i])
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, responses, "")
+}
+
+// GetApprovalRule godoc
+// @Summary Get an expense approval rule by ID
+// @Tags expenses
+// @Produce json
+// @Param id path string true "Rule ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ExpenseApprovalRuleResponse}
+// @Router /expenses/approval-rules/{id} [get]
+func (h *ExpenseHandler) GetApprovalRule(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	rule, err := h.expenseService.GetApprovalRule(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToExpenseApprovalRuleResponse(rule), "")
+}
+
+// UpdateApprovalRule godoc
+// @Summary Update an expense approval rule
+// @Description Expenses already registered keep their approval chain
+// @Tags expenses
+// @Accept json
+// @Produce json
+// @Param id path string true "Rule ID"
+// @Param rule body dto.ExpenseApprovalRuleRequest true "Rule data"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ExpenseApprovalRuleResponse}
+// @Router /expenses/approval-rules/{id} [put]
+func (h *ExpenseHandler) UpdateApprovalRule(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.ExpenseApprovalRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	rule := req.ToApprovalRuleDomain()
+	rule.RuleID = id
+
+	if err := h.expenseService.UpdateApprovalRule(c.Context(), rule); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToExpenseApprovalRuleResponse(rule)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "Expense approval rule updated successfully")
+}
+
+// DeleteApprovalRule godoc
+// @Summary Delete an expense approval rule
+// @Description Expenses already registered keep their approval chain
+// @Tags expenses
+// @Produce json
+// @Param id path string true "Rule ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Router /expenses/approval-rules/{id} [delete]
+func (h *ExpenseHandler) DeleteApprovalRule(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err := h.expenseService.DeleteApprovalRule(c.Context(), id); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, nil, "Expense approval rule deleted successfully")
+}
+
+// parseOptionalUUIDQuery parses an optional UUID query parameter
+func parseOptionalUUIDQuery(c *fiber.Ctx, key string) (*uuid.UUID, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, errors.InvalidInput("Invalid " + key)
+	}
+	return &id, nil
+}
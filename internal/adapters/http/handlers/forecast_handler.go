@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/adapters/http/dto"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type ForecastHandler struct {
+	forecastService services.DemandForecastService
+}
+
+func NewForecastHandler(forecastService services.DemandForecastService) *ForecastHandler {
+	return &ForecastHandler{
+		forecastService: forecastService,
+	}
+}
+
+// GenerateForecasts godoc
+// @Summary Forecast the demand of a school season
+// @Description Combines past seasons' sales, published lists times expected enrollment and open reservations
+// @Tags forecasts
+// @Accept json
+// @Produce json
+// @Param request body dto.GenerateForecastRequest true "Forecast parameters"
+// @Success 201 {object} dto.SuccessResponse{data=[]dto.DemandForecastResponse}
+// @Router /forecasts [post]
+func (h *ForecastHandler) GenerateForecasts(c *fiber.Ctx) error {
+	var req dto.GenerateForecastRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	forecasts, err := h.forecastService.GenerateForecasts(c.Context(), req.ToServiceRequest(userID))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToDemandForecastResponses(forecasts)
+	return dto.SendSuccess(c, fiber.StatusCreated, response, "Demand forecasts generated successfully")
+}
+
+// GetForecast godoc
+// @Summary Get a demand forecast by ID
+// @Tags forecasts
+// @Produce json
+// @Param id path string true "Forecast ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.DemandForecastResponse}
+// @Router /forecasts/{id} [get]
+func (h *ForecastHandler) GetForecast(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	forecast, err := h.forecastService.GetForecast(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToDemandForecastResponse(forecast)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// ListForecasts godoc
+// @Summary List demand forecasts with filters and pagination
+// @Tags forecasts
+// @Produce json
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param product_id query string false "Product ID"
+// @Param school_year query string false "School year (YYYY-YYYY)"
+// @Param method query string false "Forecast method"
+// @Param evaluated query bool false "Whether actual sales were recorded"
+// @Success 200 {object} dto.SuccessResponse{data=dto.DemandForecastListResponse}
+// @Router /forecasts [get]
+func (h *ForecastHandler) ListForecasts(c *fiber.Ctx) error {
+	params := dto.GetPaginationParams(c)
+	filters := repositories.DemandForecastFilters{}
+
+	if productStr := c.Query("product_id"); productStr != "" {
+		if productID, err := uuid.Parse(productStr); err == nil {
+			filters.ProductID = &productID
+		}
+	}
+
+	if schoolYear := c.Query("school_year"); schoolYear != "" {
+		filters.SchoolYear = &schoolYear
+	}
+
+	if methodStr := c.Query("method"); methodStr != "" {
+		method := domain.ForecastMethod(methodStr)
+		filters.Method = &method
+	}
+
+	if evaluatedStr := c.Query("evaluated"); evaluatedStr != "" {
+		if evaluated, err := strconv.ParseBool(evaluatedStr); err == nil {
+			filters.Evaluated = &evaluated
+		}
+	}
+
+	forecasts, total, err := h.forecastService.ListForecasts(c.Context(), filters, params.Limit, params.Offset)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToDemandForecastListResponse(forecasts, total, params.Limit, params.Offset)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// EvaluateForecasts godoc
+// @Summary Compare a season's forecasts with its actual sales
+// @Tags forecasts
+// @Accept json
+// @Produce json
+// @Param request body dto.EvaluateForecastRequest true "Season to evaluate"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ForecastEvaluationResponse}
+// @Router /forecasts/evaluate [post]
+func (h *ForecastHandler) EvaluateForecasts(c *fiber.Ctx) error {
+	var req dto.EvaluateForecastRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	evaluation, err := h.forecastService.EvaluateForecasts(c.Context(), req.SchoolYear)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToForecastEvaluationResponse(evaluation)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "Demand forecasts evaluated successfully")
+}
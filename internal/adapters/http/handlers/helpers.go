@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
@@ -32,3 +34,32 @@ func ParseUUID(c *fiber.Ctx, param string) (uuid.UUID, error) {
 	}
 	return id, nil
 }
+
+// ParseDateQuery parses an optional YYYY-MM-DD query parameter.
+// Errors are AppErrors meant to be passed to HandleServiceError.
+func ParseDateQuery(c *fiber.Ctx, key string) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, errors.InvalidInput("Invalid " + key + " format. Use YYYY-MM-DD")
+	}
+	return &date, nil
+}
+
+// ParseMonthQuery parses an optional YYYY-MM query parameter into the first day of that month
+func ParseMonthQuery(c *fiber.Ctx, key string) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	month, err := time.Parse("2006-01", value)
+	if err != nil {
+		return nil, errors.InvalidInput("Invalid " + key + " format. Use YYYY-MM")
+	}
+	return &month, nil
+}
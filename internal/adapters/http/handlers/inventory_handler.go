@@ -11,12 +11,14 @@ import (
 )
 
 type InventoryHandler struct {
-	inventoryService services.InventoryService
+	inventoryService  services.InventoryService
+	allocationService services.AllocationService
 }
 
-func NewInventoryHandler(inventoryService services.InventoryService) *InventoryHandler {
+func NewInventoryHandler(inventoryService services.InventoryService, allocationService services.AllocationService) *InventoryHandler {
 	return &InventoryHandler{
-		inventoryService: inventoryService,
+		inventoryService:  inventoryService,
+		allocationService: allocationService,
 	}
 }
 
@@ -294,3 +296,25 @@ func (h *InventoryHandler) GetWarehouseMovements(c *fiber.Ctx) error {
 	response := dto.ToInventoryMovementListResponse(movements, total, params.Limit, params.Offset)
 	return dto.SendSuccess(c, fiber.StatusOK, response, "")
 }
+
+// PlanAllocation godoc
+// @Summary Preview which warehouses would serve an order
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param request body dto.AllocationPlanRequest true "Store and items to allocate"
+// @Success 200 {object} dto.SuccessResponse{data=dto.AllocationPlanResponse}
+// @Router /inventory/allocation-plan [post]
+func (h *InventoryHandler) PlanAllocation(c *fiber.Ctx) error {
+	var req dto.AllocationPlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	plan, err := h.allocationService.Plan(c.Context(), req.ToServiceRequest())
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToAllocationPlanResponse(plan), "")
+}
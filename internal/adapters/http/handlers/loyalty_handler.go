@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/adapters/http/dto"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type LoyaltyHandler struct {
+	loyaltyService services.LoyaltyService
+}
+
+func NewLoyaltyHandler(loyaltyService services.LoyaltyService) *LoyaltyHandler {
+	return &LoyaltyHandler{
+		loyaltyService: loyaltyService,
+	}
+}
+
+// CreateRule godoc
+// @Summary Create a loyalty earn rule
+// @Tags loyalty
+// @Accept json
+// @Produce json
+// @Param rule body dto.LoyaltyRuleRequest true "Rule data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.LoyaltyRuleResponse}
+// @Router /loyalty/rules [post]
+func (h *LoyaltyHandler) CreateRule(c *fiber.Ctx) error {
+	var req dto.LoyaltyRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	rule := req.ToLoyaltyRuleDomain()
+
+	userID, ok := GetUserID(c)
+	if ok {
+		rule.CreatedBy = &userID
+	}
+
+	if err := h.loyaltyService.CreateRule(c.Context(), rule); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToLoyaltyRuleResponse(rule)
+	return dto.SendSuccess(c, fiber.StatusCreated, response, "Loyalty rule created successfully")
+}
+
+// GetRule godoc
+// @Summary Get a loyalty earn rule by ID
+// @Tags loyalty
+// @Produce json
+// @Param id path string true "Rule ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.LoyaltyRuleResponse}
+// @Router /loyalty/rules/{id} [get]
+func (h *LoyaltyHandler) GetRule(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	rule, err := h.loyaltyService.GetRule(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToLoyaltyRuleResponse(rule)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// ListRules godoc
+// @Summary List loyalty earn rules
+// @Tags loyalty
+// @Produce json
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param currency query string false "Currency filter"
+// @Param active query bool false "Only active rules"
+// @Success 200 {object} dto.SuccessResponse{data=dto.LoyaltyRuleListResponse}
+// @Router /loyalty/rules [get]
+func (h *LoyaltyHandler) ListRules(c *fiber.Ctx) error {
+	params := dto.GetPaginationParams(c)
+	filters := repositories.LoyaltyRuleFilters{}
+
+	if currencyStr := c.Query("currency"); currencyStr != "" {
+		currency := domain.CurrencyCode(currencyStr)
+		filters.Currency = &currency
+	}
+
+	if categoryStr := c.Query("category_id"); categoryStr != "" {
+		if categoryID, err := uuid.Parse(categoryStr); err == nil {
+			filters.CategoryID = &categoryID
+		}
+	}
+
+	if activeStr := c.Query("active"); activeStr != "" {
+		active := activeStr == "true"
+		filters.IsActive = &active
+	}
+
+	rules, total, err := h.loyaltyService.ListRules(c.Context(), filters, params.Limit, params.Offset)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToLoyaltyRuleListResponse(rules, total, params.Limit, params.Offset)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// UpdateRule godoc
+// @Summary Update a loyalty earn rule
+// @Tags loyalty
+// @Accept json
+// @Produce json
+// @Param id path string true "Rule ID"
+// @Param rule body dto.LoyaltyRuleRequest true "Rule data"
+// @Success 200 {object} dto.SuccessResponse{data=dto.LoyaltyRuleResponse}
+// @Router /loyalty/rules/{id} [put]
+func (h *LoyaltyHandler) UpdateRule(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.LoyaltyRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	rule := req.ToLoyaltyRuleDomain()
+	rule.RuleID = id
+
+	if err := h.loyaltyService.UpdateRule(c.Context(), rule); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToLoyaltyRuleResponse(rule)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "Loyalty rule updated successfully")
+}
+
+// DeleteRule godoc
+// @Summary Delete a loyalty earn rule
+// @Tags loyalty
+// @Produce json
+// @Param id path string true "Rule ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Router /loyalty/rules/{id} [delete]
+func (h *LoyaltyHandler) DeleteRule(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err := h.loyaltyService.DeleteRule(c.Context(), id); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, nil, "Loyalty rule deleted successfully")
+}
+
+// AdjustPoints godoc
+// @Summary Adjust customer loyalty points through the ledger
+// @Tags loyalty
+// @Accept json
+// @Produce json
+// @Param id path string true "Customer ID"
+// @Param adjustment body dto.AdjustPointsRequest true "Points to add/subtract and reason"
+// @Success 200 {object} dto.SuccessResponse{data=dto.LoyaltyTransactionResponse}
+// @Router /customers/{id}/loyalty-points [put]
+func (h *LoyaltyHandler) AdjustPoints(c *fiber.Ctx) error {
+	customerID, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.AdjustPointsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, _ := GetUserID(c)
+
+	txn, err := h.loyaltyService.AdjustPoints(c.Context(), services.AdjustPointsRequest{
+		CustomerID: customerID,
+		Points:     req.Points,
+		Reason:     req.Reason,
+		UserID:     userID,
+	})
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToLoyaltyTransactionResponse(txn)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "Loyalty points updated successfully")
+}
+
+// GetStatement godoc
+// @Summary Get customer loyalty points statement
+// @Tags loyalty
+// @Produce json
+// @Param id path string true "Customer ID"
+// @Param from query string false "Start date (YYYY-MM-DD), defaults to one year ago"
+// @Param to query string false "End date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} dto.SuccessResponse{data=dto.LoyaltyStatementResponse}
+// @Router /customers/{id}/loyalty/statement [get]
+func (h *LoyaltyHandler) GetStatement(c *fiber.Ctx) error {
+	customerID, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	from, err := ParseDateQuery(c, "from")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	to, err := ParseDateQuery(c, "to")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	end := time.Now()
+	if to != nil {
+		end = to.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	start := end.AddDate(-1, 0, 0)
+	if from != nil {
+		start = *from
+	}
+
+	statement, err := h.loyaltyService.GetStatement(c.Context(), customerID, start, end)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToLoyaltyStatementResponse(statement)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// ExpirePoints godoc
+// @Summary Expire loyalty points past their expiry date
+// @Tags loyalty
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=map[string]int}
+// @Router /loyalty/expire [post]
+func (h *LoyaltyHandler) ExpirePoints(c *fiber.Ctx) error {
+	count, err := h.loyaltyService.ExpirePoints(c.Context(), time.Now())
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, map[string]int{"expired_lots": count}, "Loyalty points expired successfully")
+}
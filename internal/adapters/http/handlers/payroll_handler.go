@@ -0,0 +1,403 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/adapters/http/dto"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type PayrollHandler struct {
+	payrollService services.PayrollService
+}
+
+func NewPayrollHandler(payrollService services.PayrollService) *PayrollHandler {
+	return &PayrollHandler{
+		payrollService: payrollService,
+	}
+}
+
+// CreateConcept godoc
+// @Summary Create a payroll concept
+// @Description FIXED concepts add the same amount every period, converted at the period exchange rate when their
+// @Description currency differs from the salary's. FORMULA concepts evaluate an expression over BaseSalary,
+// @Description PeriodSalary, WorkedDays and PeriodDays, e.g. "PeriodSalary * 0.04".
+// @Tags payroll
+// @Accept json
+// @Produce json
+// @Param concept body dto.PayrollConceptRequest true "Concept data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.PayrollConceptResponse}
+// @Router /payroll/concepts [post]
+func (h *PayrollHandler) CreateConcept(c *fiber.Ctx) error {
+	var req dto.PayrollConceptRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	concept := req.ToPayrollConceptDomain()
+
+	userID, ok := GetUserID(c)
+	if ok {
+		concept.CreatedBy = &userID
+	}
+
+	if err := h.payrollService.CreateConcept(c.Context(), concept); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusCreated, dto.ToPayrollConceptResponse(concept), "Payroll concept created successfully")
+}
+
+// ListConcepts godoc
+// @Summary List payroll concepts
+// @Tags payroll
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.PayrollConceptResponse}
+// @Router /payroll/concepts [get]
+func (h *PayrollHandler) ListConcepts(c *fiber.Ctx) error {
+	concepts, err := h.payrollService.ListConcepts(c.Context())
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	responses := make([]dto.PayrollConceptResponse, len(concepts))
+	for i := range concepts {
+		responses[i] = dto.ToPayrollConceptResponse(&concepts[i])
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, responses, "")
+}
+
+// GetConcept godoc
+// @Summary Get a payroll concept by ID
+// @Tags payroll
+// @Produce json
+// @Param id path string true "Concept ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PayrollConceptResponse}
+// @Router /payroll/concepts/{id} [get]
+func (h *PayrollHandler) GetConcept(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	concept, err := h.payrollService.GetConcept(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToPayrollConceptResponse(concept), "")
+}
+
+// UpdateConcept godoc
+// @Summary Update a payroll concept
+// @Description Payslips already calculated keep their amounts until the period is recalculated
+// @Tags payroll
+// @Accept json
+// @Produce json
+// @Param id path string true "Concept ID"
+// @Param concept body dto.PayrollConceptRequest true "Concept data"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PayrollConceptResponse}
+// @Router /payroll/concepts/{id} [put]
+func (h *PayrollHandler) UpdateConcept(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.PayrollConceptRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	concept := req.ToPayrollConceptDomain()
+	concept.ConceptID = id
+
+	if err := h.payrollService.UpdateConcept(c.Context(), concept); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToPayrollConceptResponse(concept), "Payroll concept updated successfully")
+}
+
+// DeleteConcept godoc
+// @Summary Delete a payroll concept
+// @Description Payslips keep the lines the concept produced
+// @Tags payroll
+// @Produce json
+// @Param id path string true "Concept ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Router /payroll/concepts/{id} [delete]
+func (h *PayrollHandler) DeleteConcept(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err := h.payrollService.DeleteConcept(c.Context(), id); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, nil, "Payroll concept deleted successfully")
+}
+
+// CreatePeriod godoc
+// @Summary Open a pay period
+// @Description Without store_id the period pays the employees of every store. Periods paying the same employees cannot overlap.
+// @Tags payroll
+// @Accept json
+// @Produce json
+// @Param period body dto.CreatePayrollPeriodRequest true "Period data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.PayrollPeriodResponse}
+// @Router /payroll/periods [post]
+func (h *PayrollHandler) CreatePeriod(c *fiber.Ctx) error {
+	var req dto.CreatePayrollPeriodRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	period, err := h.payrollService.CreatePeriod(c.Context(), req.ToServiceRequest(userID))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusCreated, dto.ToPayrollPeriodResponse(period), "Payroll period created successfully")
+}
+
+// ListPeriods godoc
+// @Summary List pay periods
+// @Tags payroll
+// @Produce json
+// @Param store_id query string false "Filter by store"
+// @Param status query string false "Filter by status"
+// @Param frequency query string false "Filter by frequency"
+// @Param from query string false "Periods ending on or after (YYYY-MM-DD)"
+// @Param to query string false "Periods starting on or before (YYYY-MM-DD)"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} dto.SuccessResponse{data=dto.PayrollPeriodListResponse}
+// @Router /payroll/periods [get]
+func (h *PayrollHandler) ListPeriods(c *fiber.Ctx) error {
+	params := dto.GetPaginationParams(c)
+	filters := repositories.PayrollPeriodFilters{}
+
+	storeID, err := parseOptionalUUIDQuery(c, "store_id")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	filters.StoreID = storeID
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := domain.PayrollPeriodStatus(statusStr)
+		filters.Status = &status
+	}
+
+	if frequencyStr := c.Query("frequency"); frequencyStr != "" {
+		frequency := domain.PayrollFrequency(frequencyStr)
+		filters.Frequency = &frequency
+	}
+
+	from, err := ParseDateQuery(c, "from")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	filters.From = from
+
+	to, err := ParseDateQuery(c, "to")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	filters.To = to
+
+	periods, total, err := h.payrollService.ListPeriods(c.Context(), filters, params.Limit, params.Offset)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToPayrollPeriodListResponse(periods, total, params.Limit, params.Offset)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// GetPeriod godoc
+// @Summary Get a pay period by ID
+// @Tags payroll
+// @Produce json
+// @Param id path string true "Period ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PayrollPeriodResponse}
+// @Router /payroll/periods/{id} [get]
+func (h *PayrollHandler) GetPeriod(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	period, err := h.payrollService.GetPeriod(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToPayrollPeriodResponse(period), "")
+}
+
+// CalculatePeriod godoc
+// @Summary Calculate the payslips of a pay period
+// @Description Computes a payslip for each employee the period pays, replacing those of a previous run, and moves
+// @Description the period to PROCESSING. Not allowed once the period is PROCESSED.
+// @Tags payroll
+// @Accept json
+// @Produce json
+// @Param id path string true "Period ID"
+// @Param run body dto.CalculatePayrollRequest false "Exchange rate for the run"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PayrollPeriodResponse}
+// @Router /payroll/periods/{id}/calculate [post]
+func (h *PayrollHandler) CalculatePeriod(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.CalculatePayrollRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+		}
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	period, err := h.payrollService.CalculatePeriod(c.Context(), id, req.ExchangeRate, userID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToPayrollPeriodResponse(period), "Payroll calculated successfully")
+}
+
+// ProcessPeriod godoc
+// @Summary Process a calculated pay period, locking its payslips
+// @Tags payroll
+// @Produce json
+// @Param id path string true "Period ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PayrollPeriodResponse}
+// @Router /payroll/periods/{id}/process [post]
+func (h *PayrollHandler) ProcessPeriod(c *fiber.Ctx) error {
+	return h.changeStatus(c, h.payrollService.ProcessPeriod, "Payroll processed successfully")
+}
+
+// PayPeriod godoc
+// @Summary Mark a processed pay period as paid
+// @Tags payroll
+// @Produce json
+// @Param id path string true "Period ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PayrollPeriodResponse}
+// @Router /payroll/periods/{id}/pay [post]
+func (h *PayrollHandler) PayPeriod(c *fiber.Ctx) error {
+	return h.changeStatus(c, h.payrollService.PayPeriod, "Payroll marked as paid successfully")
+}
+
+// ClosePeriod godoc
+// @Summary Close a paid pay period
+// @Tags payroll
+// @Produce json
+// @Param id path string true "Period ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PayrollPeriodResponse}
+// @Router /payroll/periods/{id}/close [post]
+func (h *PayrollHandler) ClosePeriod(c *fiber.Ctx) error {
+	return h.changeStatus(c, h.payrollService.ClosePeriod, "Payroll period closed successfully")
+}
+
+// GetPayslips godoc
+// @Summary List the payslips of a pay period
+// @Tags payroll
+// @Produce json
+// @Param id path string true "Period ID"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.PayslipResponse}
+// @Router /payroll/periods/{id}/payslips [get]
+func (h *PayrollHandler) GetPayslips(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	payslips, err := h.payrollService.GetPayslips(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToPayslipResponses(payslips), "")
+}
+
+// GetPeriodTotals godoc
+// @Summary Get the totals of a pay period per store and currency
+// @Tags payroll
+// @Produce json
+// @Param id path string true "Period ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PayrollTotalsResponse}
+// @Router /payroll/periods/{id}/totals [get]
+func (h *PayrollHandler) GetPeriodTotals(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	totals, err := h.payrollService.GetPeriodTotals(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToPayrollTotalsResponse(totals), "")
+}
+
+// GetPayslip godoc
+// @Summary Get a payslip with its concepts
+// @Tags payroll
+// @Produce json
+// @Param id path string true "Payslip ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PayslipResponse}
+// @Router /payroll/payslips/{id} [get]
+func (h *PayrollHandler) GetPayslip(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	payslip, err := h.payrollService.GetPayslip(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToPayslipResponse(payslip), "")
+}
+
+// changeStatus runs a status transition of the period in the path
+func (h *PayrollHandler) changeStatus(c *fiber.Ctx, transition func(ctx context.Context, id, userID uuid.UUID) (*domain.PayrollPeriod, error), message string) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	period, err := transition(c.Context(), id, userID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, dto.ToPayrollPeriodResponse(period), message)
+}
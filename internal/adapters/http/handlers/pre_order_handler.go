@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/adapters/http/dto"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type PreOrderHandler struct {
+	preOrderService services.PreOrderService
+}
+
+func NewPreOrderHandler(preOrderService services.PreOrderService) *PreOrderHandler {
+	return &PreOrderHandler{
+		preOrderService: preOrderService,
+	}
+}
+
+// CreatePreOrder godoc
+// @Summary Create a pre-order for products that are not in stock yet
+// @Tags pre-orders
+// @Accept json
+// @Produce json
+// @Param preOrder body dto.CreatePreOrderRequest true "Pre-order data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.PreOrderResponse}
+// @Router /pre-orders [post]
+func (h *PreOrderHandler) CreatePreOrder(c *fiber.Ctx) error {
+	var req dto.CreatePreOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	preOrder, err := h.preOrderService.CreatePreOrder(c.Context(), req.ToServiceRequest(userID))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToPreOrderResponse(preOrder)
+	return dto.SendSuccess(c, fiber.StatusCreated, response, "Pre-order created successfully")
+}
+
+// GetPreOrder godoc
+// @Summary Get a pre-order by ID
+// @Tags pre-orders
+// @Produce json
+// @Param id path string true "Pre-order ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PreOrderResponse}
+// @Router /pre-orders/{id} [get]
+func (h *PreOrderHandler) GetPreOrder(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	preOrder, err := h.preOrderService.GetPreOrder(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToPreOrderResponse(preOrder)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// GetPreOrderByNumber godoc
+// @Summary Get a pre-order by pre-order number
+// @Tags pre-orders
+// @Produce json
+// @Param number path string true "Pre-order number"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PreOrderResponse}
+// @Router /pre-orders/number/{number} [get]
+func (h *PreOrderHandler) GetPreOrderByNumber(c *fiber.Ctx) error {
+	number := c.Params("number")
+	if number == "" {
+		return dto.SendError(c, fiber.StatusBadRequest, "Pre-order number is required", nil)
+	}
+
+	preOrder, err := h.preOrderService.GetPreOrderByNumber(c.Context(), number)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToPreOrderResponse(preOrder)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// ListPreOrders godoc
+// @Summary List pre-orders with filters and pagination
+// @Tags pre-orders
+// @Produce json
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param status query string false "Status filter"
+// @Param customer_id query string false "Customer ID"
+// @Param store_id query string false "Store ID"
+// @Param from query string false "Ordered from (YYYY-MM-DD)"
+// @Param to query string false "Ordered until (YYYY-MM-DD)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PreOrderListResponse}
+// @Router /pre-orders [get]
+func (h *PreOrderHandler) ListPreOrders(c *fiber.Ctx) error {
+	params := dto.GetPaginationParams(c)
+	filters := repositories.PreOrderFilters{}
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := domain.PreOrderStatus(statusStr)
+		filters.Status = &status
+	}
+
+	if customerStr := c.Query("customer_id"); customerStr != "" {
+		if customerID, err := uuid.Parse(customerStr); err == nil {
+			filters.CustomerID = &customerID
+		}
+	}
+
+	if storeStr := c.Query("store_id"); storeStr != "" {
+		if storeID, err := uuid.Parse(storeStr); err == nil {
+			filters.StoreID = &storeID
+		}
+	}
+
+	from, err := ParseDateQuery(c, "from")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	filters.DateFrom = from
+
+	to, err := ParseDateQuery(c, "to")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	if to != nil {
+		endOfDay := to.Add(24*time.Hour - time.Nanosecond)
+		filters.DateTo = &endOfDay
+	}
+
+	preOrders, total, err := h.preOrderService.ListPreOrders(c.Context(), filters, params.Limit, params.Offset)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToPreOrderListResponse(preOrders, total, params.Limit, params.Offset)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// ConfirmPreOrder godoc
+// @Summary Confirm a pending pre-order
+// @Tags pre-orders
+// @Produce json
+// @Param id path string true "Pre-order ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PreOrderResponse}
+// @Router /pre-orders/{id}/confirm [post]
+func (h *PreOrderHandler) ConfirmPreOrder(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	preOrder, err := h.preOrderService.ConfirmPreOrder(c.Context(), id, userID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToPreOrderResponse(preOrder)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "Pre-order confirmed successfully")
+}
+
+// MarkPreOrderReady godoc
+// @Summary Hold the stock of a pre-order and notify the customer it is ready
+// @Tags pre-orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Pre-order ID"
+// @Param ready body dto.MarkPreOrderReadyRequest false "Pickup period"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PreOrderResponse}
+// @Router /pre-orders/{id}/ready [post]
+func (h *PreOrderHandler) MarkPreOrderReady(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.MarkPreOrderReadyRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+		}
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	preOrder, err := h.preOrderService.MarkAsReady(c.Context(), id, req.PickupDays, userID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToPreOrderResponse(preOrder)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "Pre-order marked as ready")
+}
+
+// NotifyPreOrder godoc
+// @Summary Resend the ready-for-pickup notification of a pre-order
+// @Tags pre-orders
+// @Produce json
+// @Param id path string true "Pre-order ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Router /pre-orders/{id}/notify [post]
+func (h *PreOrderHandler) NotifyPreOrder(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err := h.preOrderService.SendReadyNotification(c.Context(), id); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, nil, "Notification sent successfully")
+}
+
+// DeliverPreOrder godoc
+// @Summary Deliver a ready pre-order (convert to sale)
+// @Tags pre-orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Pre-order ID"
+// @Param delivery body dto.DeliverPreOrderRequest true "Payment of the balance"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SaleResponse}
+// @Router /pre-orders/{id}/deliver [post]
+func (h *PreOrderHandler) DeliverPreOrder(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.DeliverPreOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	sale, err := h.preOrderService.DeliverPreOrder(c.Context(), req.ToServiceRequest(id, userID))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToSaleResponse(sale)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "Pre-order delivered successfully")
+}
+
+// CancelPreOrder godoc
+// @Summary Cancel a pre-order and release any held stock
+// @Tags pre-orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Pre-order ID"
+// @Param cancellation body dto.CancelPreOrderRequest false "Cancellation reason"
+// @Success 200 {object} dto.SuccessResponse
+// @Router /pre-orders/{id}/cancel [post]
+func (h *PreOrderHandler) CancelPreOrder(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.CancelPreOrderRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+		}
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	reason := req.Reason
+	if reason == "" {
+		reason = "Cancelled by user"
+	}
+
+	if err := h.preOrderService.CancelPreOrder(c.Context(), id, userID, reason); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, nil, "Pre-order cancelled successfully")
+}
+
+// ExpireUncollected godoc
+// @Summary Cancel ready pre-orders whose pickup deadline has passed
+// @Tags pre-orders
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=map[string]int}
+// @Router /pre-orders/expire [post]
+func (h *PreOrderHandler) ExpireUncollected(c *fiber.Ctx) error {
+	count, err := h.preOrderService.ExpireUncollected(c.Context(), time.Now())
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, map[string]int{"expired_pre_orders": count}, "Uncollected pre-orders expired successfully")
+}
@@ -0,0 +1,304 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/adapters/http/dto"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type QuotationHandler struct {
+	quotationService services.QuotationService
+}
+
+func NewQuotationHandler(quotationService services.QuotationService) *QuotationHandler {
+	return &QuotationHandler{
+		quotationService: quotationService,
+	}
+}
+
+// CreateQuotation godoc
+// @Summary Create a new quotation
+// @Tags quotations
+// @Accept json
+// @Produce json
+// @Param quotation body dto.CreateQuotationRequest true "Quotation data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.QuotationResponse}
+// @Router /quotations [post]
+func (h *QuotationHandler) CreateQuotation(c *fiber.Ctx) error {
+	var req dto.CreateQuotationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	quotation, err := h.quotationService.CreateQuotation(c.Context(), req.ToServiceRequest(userID))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToQuotationResponse(quotation)
+	return dto.SendSuccess(c, fiber.StatusCreated, response, "Quotation created successfully")
+}
+
+// GetQuotation godoc
+// @Summary Get a quotation by ID
+// @Tags quotations
+// @Produce json
+// @Param id path string true "Quotation ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.QuotationResponse}
+// @Router /quotations/{id} [get]
+func (h *QuotationHandler) GetQuotation(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	quotation, err := h.quotationService.GetQuotation(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToQuotationResponse(quotation)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// GetQuotationByNumber godoc
+// @Summary Get a quotation by quotation number
+// @Tags quotations
+// @Produce json
+// @Param number path string true "Quotation number"
+// @Success 200 {object} dto.SuccessResponse{data=dto.QuotationResponse}
+// @Router /quotations/number/{number} [get]
+func (h *QuotationHandler) GetQuotationByNumber(c *fiber.Ctx) error {
+	number := c.Params("number")
+	if number == "" {
+		return dto.SendError(c, fiber.StatusBadRequest, "Quotation number is required", nil)
+	}
+
+	quotation, err := h.quotationService.GetQuotationByNumber(c.Context(), number)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToQuotationResponse(quotation)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// ListQuotations godoc
+// @Summary List quotations with filters and pagination
+// @Tags quotations
+// @Produce json
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param status query string false "Status filter"
+// @Param customer_id query string false "Customer ID"
+// @Param store_id query string false "Store ID"
+// @Param from query string false "Created from (YYYY-MM-DD)"
+// @Param to query string false "Created until (YYYY-MM-DD)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.QuotationListResponse}
+// @Router /quotations [get]
+func (h *QuotationHandler) ListQuotations(c *fiber.Ctx) error {
+	params := dto.GetPaginationParams(c)
+	filters := repositories.QuotationFilters{}
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := domain.QuotationStatus(statusStr)
+		filters.Status = &status
+	}
+
+	if customerStr := c.Query("customer_id"); customerStr != "" {
+		if customerID, err := uuid.Parse(customerStr); err == nil {
+			filters.CustomerID = &customerID
+		}
+	}
+
+	if storeStr := c.Query("store_id"); storeStr != "" {
+		if storeID, err := uuid.Parse(storeStr); err == nil {
+			filters.StoreID = &storeID
+		}
+	}
+
+	from, err := ParseDateQuery(c, "from")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	filters.DateFrom = from
+
+	to, err := ParseDateQuery(c, "to")
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	if to != nil {
+		endOfDay := to.Add(24*time.Hour - time.Nanosecond)
+		filters.DateTo = &endOfDay
+	}
+
+	quotations, total, err := h.quotationService.ListQuotations(c.Context(), filters, params.Limit, params.Offset)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToQuotationListResponse(quotations, total, params.Limit, params.Offset)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// UpdateQuotation godoc
+// @Summary Replace the items of a draft quotation and price it again
+// @Tags quotations
+// @Accept json
+// @Produce json
+// @Param id path string true "Quotation ID"
+// @Param quotation body dto.UpdateQuotationRequest true "Quotation data"
+// @Success 200 {object} dto.SuccessResponse{data=dto.QuotationResponse}
+// @Router /quotations/{id} [put]
+func (h *QuotationHandler) UpdateQuotation(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateQuotationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	quotation, err := h.quotationService.UpdateQuotation(c.Context(), req.ToServiceRequest(id, userID))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToQuotationResponse(quotation)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "Quotation updated successfully")
+}
+
+// SendQuotation godoc
+// @Summary Mark a quotation as sent to the customer
+// @Tags quotations
+// @Produce json
+// @Param id path string true "Quotation ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.QuotationResponse}
+// @Router /quotations/{id}/send [post]
+func (h *QuotationHandler) SendQuotation(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	quotation, err := h.quotationService.SendQuotation(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToQuotationResponse(quotation)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "Quotation sent successfully")
+}
+
+// AcceptQuotation godoc
+// @Summary Record the customer's acceptance of a quotation
+// @Tags quotations
+// @Produce json
+// @Param id path string true "Quotation ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.QuotationResponse}
+// @Router /quotations/{id}/accept [post]
+func (h *QuotationHandler) AcceptQuotation(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	quotation, err := h.quotationService.AcceptQuotation(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToQuotationResponse(quotation)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "Quotation accepted successfully")
+}
+
+// ConvertQuotation godoc
+// @Summary Convert a quotation into a sale, credit sale or reservation at the quoted prices
+// @Tags quotations
+// @Accept json
+// @Produce json
+// @Param id path string true "Quotation ID"
+// @Param conversion body dto.ConvertQuotationRequest true "Conversion data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.QuotationConversionResponse}
+// @Router /quotations/{id}/convert [post]
+func (h *QuotationHandler) ConvertQuotation(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.ConvertQuotationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	result, err := h.quotationService.ConvertQuotation(c.Context(), req.ToServiceRequest(id, userID))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToQuotationConversionResponse(result)
+	return dto.SendSuccess(c, fiber.StatusCreated, response, "Quotation converted successfully")
+}
+
+// GetQuotationPDF godoc
+// @Summary Download a quotation as PDF
+// @Tags quotations
+// @Produce application/pdf
+// @Param id path string true "Quotation ID"
+// @Success 200 {file} binary
+// @Router /quotations/{id}/pdf [get]
+func (h *QuotationHandler) GetQuotationPDF(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	content, err := h.quotationService.RenderPDF(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("inline; filename=\"quotation-%s.pdf\"", id))
+	return c.Send(content)
+}
+
+// ExpireQuotations godoc
+// @Summary Expire quotations past their validity date
+// @Tags quotations
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=map[string]int}
+// @Router /quotations/expire [post]
+func (h *QuotationHandler) ExpireQuotations(c *fiber.Ctx) error {
+	count, err := h.quotationService.ExpireQuotations(c.Context(), time.Now())
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, map[string]int{"expired_quotations": count}, "Quotations expired successfully")
+}
@@ -121,10 +121,12 @@ func (h *ReservationHandler) ListReservations(c *fiber.Ctx) error {
 }
 
 // ConfirmReservation godoc
-// @Summary Confirm a reservation
+// @Summary Record the deposit payment and confirm a reservation
 // @Tags reservations
+// @Accept json
 // @Produce json
 // @Param id path string true "Reservation ID"
+// @Param payment body dto.ReservationPaymentRequest false "Deposit payment, optional when no deposit is required"
 // @Success 200 {object} dto.SuccessResponse
 // @Router /reservations/{id}/confirm [post]
 func (h *ReservationHandler) ConfirmReservation(c *fiber.Ctx) error {
@@ -133,15 +135,209 @@ func (h *ReservationHandler) ConfirmReservation(c *fiber.Ctx) error {
 		return err
 	}
 
-	if err := h.reservationService.ConfirmReservation(c.Context(), id); err != nil {
+	var req dto.ReservationPaymentRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+		}
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	if err := h.reservationService.ConfirmReservation(c.Context(), req.ToServiceRequest(id, userID)); err != nil {
 		return HandleServiceError(c, err)
 	}
 
 	return dto.SendSuccess(c, fiber.StatusOK, nil, "Reservation confirmed successfully")
 }
 
+// RecordPayment godoc
+// @Summary Record an installment paid on a reservation before pickup
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Param payment body dto.ReservationPaymentRequest true "Payment data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.ReservationPaymentResponse}
+// @Router /reservations/{id}/payments [post]
+func (h *ReservationHandler) RecordPayment(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.ReservationPaymentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	payment, err := h.reservationService.RecordPayment(c.Context(), req.ToServiceRequest(id, userID))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToReservationPaymentResponse(payment)
+	return dto.SendSuccess(c, fiber.StatusCreated, response, "Payment recorded successfully")
+}
+
+// GetPayments godoc
+// @Summary List the payments, refunds and forfeits of a reservation
+// @Tags reservations
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.ReservationPaymentResponse}
+// @Router /reservations/{id}/payments [get]
+func (h *ReservationHandler) GetPayments(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	payments, err := h.reservationService.GetPayments(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToReservationPaymentResponses(payments)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// ModifyReservationItems godoc
+// @Summary Add, remove or change the quantity of reservation items
+// @Description Reserves or releases the stock difference and recalculates totals and balance
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Param changes body dto.ModifyReservationItemsRequest true "Item changes"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReservationResponse}
+// @Router /reservations/{id}/items [put]
+func (h *ReservationHandler) ModifyReservationItems(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.ModifyReservationItemsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	reservation, err := h.reservationService.ModifyItems(c.Context(), req.ToServiceRequest(id, userID))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToReservationResponse(reservation)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "Reservation items updated successfully")
+}
+
+// ExtendReservation godoc
+// @Summary Postpone the expiration date of a reservation
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Param extension body dto.ExtendReservationRequest true "Extension data"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReservationResponse}
+// @Router /reservations/{id}/extend [post]
+func (h *ReservationHandler) ExtendReservation(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.ExtendReservationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	reservation, err := h.reservationService.ExtendReservation(c.Context(), req.ToServiceRequest(id, userID))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToReservationResponse(reservation)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "Reservation extended successfully")
+}
+
+// ChangeReservationStore godoc
+// @Summary Change the pickup store of a reservation
+// @Description Moves the stock still held to the warehouse of the new store
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Param store body dto.ChangeReservationStoreRequest true "New pickup store"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReservationResponse}
+// @Router /reservations/{id}/store [put]
+func (h *ReservationHandler) ChangeReservationStore(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.ChangeReservationStoreRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	reservation, err := h.reservationService.ChangeStore(c.Context(), req.ToServiceRequest(id, userID))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToReservationResponse(reservation)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "Reservation store changed successfully")
+}
+
+// GetModifications godoc
+// @Summary List the modification history of a reservation
+// @Tags reservations
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.ReservationModificationResponse}
+// @Router /reservations/{id}/modifications [get]
+func (h *ReservationHandler) GetModifications(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	modifications, err := h.reservationService.GetModifications(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToReservationModificationResponses(modifications)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
 // FulfillReservation godoc
-// @Summary Fulfill a reservation (convert to sale)
+// @Summary Fulfill all or part of a reservation (convert the pickup to a sale)
 // @Tags reservations
 // @Accept json
 // @Produce json
@@ -165,13 +361,7 @@ func (h *ReservationHandler) FulfillReservation(c *fiber.Ctx) error {
 		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
 	}
 
-	serviceReq := services.FulfillReservationRequest{
-		ReservationID:    id,
-		PaymentMethod:    req.PaymentMethod,
-		PaymentReference: req.PaymentReference,
-		ExchangeRate:     req.ExchangeRate,
-		UserID:           userID,
-	}
+	serviceReq := req.ToServiceRequest(id, userID)
 
 	sale, err := h.reservationService.FulfillReservation(c.Context(), serviceReq)
 	if err != nil {
@@ -183,10 +373,12 @@ func (h *ReservationHandler) FulfillReservation(c *fiber.Ctx) error {
 }
 
 // CancelReservation godoc
-// @Summary Cancel a reservation
+// @Summary Cancel a reservation, refunding or retaining the amount paid
 // @Tags reservations
+// @Accept json
 // @Produce json
 // @Param id path string true "Reservation ID"
+// @Param cancellation body dto.CancelReservationRequest false "Cancellation and refund data"
 // @Param reason query string false "Cancellation reason"
 // @Success 200 {object} dto.SuccessResponse
 // @Router /reservations/{id}/cancel [post]
@@ -196,13 +388,23 @@ func (h *ReservationHandler) CancelReservation(c *fiber.Ctx) error {
 		return err
 	}
 
+	var req dto.CancelReservationRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+		}
+	}
+
 	userID, ok := GetUserID(c)
 	if !ok {
 		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
 	}
 
-	reason := c.Query("reason", "Cancelled by user")
-	if err := h.reservationService.CancelReservation(c.Context(), id, userID, reason); err != nil {
+	if req.Reason == "" {
+		req.Reason = c.Query("reason", "Cancelled by user")
+	}
+
+	if err := h.reservationService.CancelReservation(c.Context(), req.ToServiceRequest(id, userID)); err != nil {
 		return HandleServiceError(c, err)
 	}
 
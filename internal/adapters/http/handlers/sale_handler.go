@@ -12,13 +12,11 @@ import (
 
 type SaleHandler struct {
 	saleService services.SaleService
-	arService   services.AccountsReceivableService
 }
 
-func NewSaleHandler(saleService services.SaleService, arService services.AccountsReceivableService) *SaleHandler {
+func NewSaleHandler(saleService services.SaleService) *SaleHandler {
 	return &SaleHandler{
 		saleService: saleService,
-		arService:   arService,
 	}
 }
 
@@ -48,6 +46,9 @@ func (h *SaleHandler) CreateSale(c *fiber.Ctx) error {
 
 // CreateCreditSale godoc
 // @Summary Create a credit sale with accounts receivable
+// @Description Credit days default to the customer's terms. Sales over the credit limit, beyond the
+// @Description customer's credit days or with long overdue balances need an override_reason from a supervisor.
+// @Description Send installments and installment_frequency instead of credit_days to split the sale into installments.
 // @Tags sales
 // @Accept json
 // @Produce json
@@ -60,8 +61,30 @@ func (h *SaleHandler) CreateCreditSale(c *fiber.Ctx) error {
 		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
 	}
 
+	terms := services.CreditTerms{CreditDays: req.CreditDays}
+	if req.Installments != nil {
+		if req.InstallmentFrequency == nil {
+			return dto.SendError(c, fiber.StatusBadRequest, "installment_frequency is required with installments", nil)
+		}
+		terms.Installments = &services.InstallmentTerms{
+			Count:     *req.Installments,
+			Frequency: *req.InstallmentFrequency,
+		}
+	}
+	if req.OverrideReason != nil {
+		// The authenticated user approves the override
+		userID, ok := GetUserID(c)
+		if !ok {
+			return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+		}
+		terms.Override = &services.CreditOverrideRequest{
+			SupervisorID: userID,
+			Reason:       *req.OverrideReason,
+		}
+	}
+
 	serviceReq := req.CreateSaleRequest.ToServiceRequest()
-	sale, ar, err := h.saleService.CreateCreditSale(c.Context(), serviceReq, req.CreditDays)
+	sale, ar, err := h.saleService.CreateCreditSale(c.Context(), serviceReq, terms)
 	if err != nil {
 		return HandleServiceError(c, err)
 	}
@@ -73,6 +96,10 @@ func (h *SaleHandler) CreateCreditSale(c *fiber.Ctx) error {
 	if ar != nil {
 		arResp := dto.ToAccountsReceivableResponse(ar)
 		response.AccountsReceivable = &arResp
+		if ar.InstallmentPlan != nil {
+			planResp := dto.ToInstallmentPlanResponse(ar.InstallmentPlan)
+			response.InstallmentPlan = &planResp
+		}
 	}
 
 	return dto.SendSuccess(c, fiber.StatusCreated, response, "Credit sale created successfully")
@@ -194,53 +221,3 @@ func (h *SaleHandler) GetDailySales(c *fiber.Ctx) error {
 
 	return dto.SendSuccess(c, fiber.StatusOK, responses, "")
 }
-
-// RegisterPayment godoc
-// @Summary Register a payment on accounts receivable
-// @Tags accounts-receivable
-// @Accept json
-// @Produce json
-// @Param id path string true "Receivable ID"
-// @Param payment body dto.PaymentRequest true "Payment data"
-// @Success 200 {object} dto.SuccessResponse
-// @Router /accounts-receivable/{id}/payments [post]
-func (h *SaleHandler) RegisterPayment(c *fiber.Ctx) error {
-	id, err := ParseUUID(c, "id")
-	if err != nil {
-		return err
-	}
-
-	var req dto.PaymentRequest
-	if err := c.BodyParser(&req); err != nil {
-		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
-	}
-
-	userID, _ := GetUserID(c)
-	if err := h.arService.RegisterPayment(c.Context(), id, req.Amount, req.Currency, req.PaymentMethod, req.Reference, req.Notes, userID); err != nil {
-		return HandleServiceError(c, err)
-	}
-
-	return dto.SendSuccess(c, fiber.StatusOK, nil, "Payment registered successfully")
-}
-
-// GetAccountsReceivable godoc
-// @Summary Get accounts receivable by ID
-// @Tags accounts-receivable
-// @Produce json
-// @Param id path string true "Receivable ID"
-// @Success 200 {object} dto.SuccessResponse{data=dto.AccountsReceivableResponse}
-// @Router /accounts-receivable/{id} [get]
-func (h *SaleHandler) GetAccountsReceivable(c *fiber.Ctx) error {
-	id, err := ParseUUID(c, "id")
-	if err != nil {
-		return err
-	}
-
-	ar, err := h.arService.GetAccountsReceivable(c.Context(), id)
-	if err != nil {
-		return HandleServiceError(c, err)
-	}
-
-	response := dto.ToAccountsReceivableResponse(ar)
-	return dto.SendSuccess(c, fiber.StatusOK, response, "")
-}
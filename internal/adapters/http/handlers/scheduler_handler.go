@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jadiazinf/inventory/internal/adapters/http/dto"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type SchedulerHandler struct {
+	schedulerService services.SchedulerService
+}
+
+func NewSchedulerHandler(schedulerService services.SchedulerService) *SchedulerHandler {
+	return &SchedulerHandler{
+		schedulerService: schedulerService,
+	}
+}
+
+// ListJobs godoc
+// @Summary List the background jobs with their schedule and last run
+// @Tags jobs
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.JobResponse}
+// @Router /jobs [get]
+func (h *SchedulerHandler) ListJobs(c *fiber.Ctx) error {
+	jobs, err := h.schedulerService.ListJobs(c.Context())
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToJobResponses(jobs)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// TriggerJob godoc
+// @Summary Run a background job now
+// @Description Runs the job and waits for it to finish. Fails with 409 while another instance is running it.
+// @Tags jobs
+// @Produce json
+// @Param name path string true "Job name"
+// @Success 200 {object} dto.SuccessResponse{data=dto.JobRunResponse}
+// @Router /jobs/{name}/run [post]
+func (h *SchedulerHandler) TriggerJob(c *fiber.Ctx) error {
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	run, err := h.schedulerService.TriggerJob(c.Context(), c.Params("name"), &userID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToJobRunResponse(run)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "Job run finished")
+}
+
+// ListJobRuns godoc
+// @Summary List the job run history with filters and pagination
+// @Tags jobs
+// @Produce json
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param job_name query string false "Job name"
+// @Param status query string false "Run status"
+// @Success 200 {object} dto.SuccessResponse{data=dto.JobRunListResponse}
+// @Router /jobs/runs [get]
+func (h *SchedulerHandler) ListJobRuns(c *fiber.Ctx) error {
+	params := dto.GetPaginationParams(c)
+	filters := repositories.JobRunFilters{}
+
+	if jobName := c.Query("job_name"); jobName != "" {
+		filters.JobName = &jobName
+	}
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := domain.JobRunStatus(statusStr)
+		filters.Status = &status
+	}
+
+	runs, total, err := h.schedulerService.ListRuns(c.Context(), filters, params.Limit, params.Offset)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToJobRunListResponse(runs, total, params.Limit, params.Offset)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// GetJobRun godoc
+// @Summary Get a job run by ID
+// @Tags jobs
+// @Produce json
+// @Param id path string true "Run ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.JobRunResponse}
+// @Router /jobs/runs/{id} [get]
+func (h *SchedulerHandler) GetJobRun(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	run, err := h.schedulerService.GetRun(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToJobRunResponse(run)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/adapters/http/dto"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type SchoolHandler struct {
+	schoolService services.SchoolService
+}
+
+func NewSchoolHandler(schoolService services.SchoolService) *SchoolHandler {
+	return &SchoolHandler{
+		schoolService: schoolService,
+	}
+}
+
+// CreateSchool godoc
+// @Summary Register a new school
+// @Tags schools
+// @Accept json
+// @Produce json
+// @Param school body dto.SchoolRequest true "School data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.SchoolResponse}
+// @Router /schools [post]
+func (h *SchoolHandler) CreateSchool(c *fiber.Ctx) error {
+	var req dto.SchoolRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	school := req.ToSchoolDomain()
+
+	if err := h.schoolService.CreateSchool(c.Context(), school); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	created, err := h.schoolService.GetSchool(c.Context(), school.SchoolID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToSchoolResponse(created)
+	return dto.SendSuccess(c, fiber.StatusCreated, response, "School created successfully")
+}
+
+// GetSchool godoc
+// @Summary Get a school by ID
+// @Tags schools
+// @Produce json
+// @Param id path string true "School ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SchoolResponse}
+// @Router /schools/{id} [get]
+func (h *SchoolHandler) GetSchool(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	school, err := h.schoolService.GetSchool(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToSchoolResponse(school)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// ListSchools godoc
+// @Summary List schools with filters and pagination
+// @Tags schools
+// @Produce json
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param location_id query string false "Location ID"
+// @Param level query string false "School level offered"
+// @Param is_active query bool false "Active filter"
+// @Param search query string false "Search by name"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SchoolListResponse}
+// @Router /schools [get]
+func (h *SchoolHandler) ListSchools(c *fiber.Ctx) error {
+	params := dto.GetPaginationParams(c)
+	filters := repositories.SchoolFilters{}
+
+	if locationStr := c.Query("location_id"); locationStr != "" {
+		if locationID, err := uuid.Parse(locationStr); err == nil {
+			filters.LocationID = &locationID
+		}
+	}
+
+	if levelStr := c.Query("level"); levelStr != "" {
+		level := domain.SchoolLevel(levelStr)
+		filters.Level = &level
+	}
+
+	if activeStr := c.Query("is_active"); activeStr != "" {
+		if isActive, err := strconv.ParseBool(activeStr); err == nil {
+			filters.IsActive = &isActive
+		}
+	}
+
+	if search := c.Query("search"); search != "" {
+		filters.Search = &search
+	}
+
+	schools, total, err := h.schoolService.ListSchools(c.Context(), filters, params.Limit, params.Offset)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToSchoolListResponse(schools, total, params.Limit, params.Offset)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// UpdateSchool godoc
+// @Summary Update a school
+// @Tags schools
+// @Accept json
+// @Produce json
+// @Param id path string true "School ID"
+// @Param school body dto.SchoolRequest true "School data"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SchoolResponse}
+// @Router /schools/{id} [put]
+func (h *SchoolHandler) UpdateSchool(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.SchoolRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	school := req.ToSchoolDomain()
+	school.SchoolID = id
+
+	if err := h.schoolService.UpdateSchool(c.Context(), school); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	updated, err := h.schoolService.GetSchool(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToSchoolResponse(updated)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "School updated successfully")
+}
+
+// DeleteSchool godoc
+// @Summary Delete a school without supply lists
+// @Tags schools
+// @Produce json
+// @Param id path string true "School ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Router /schools/{id} [delete]
+func (h *SchoolHandler) DeleteSchool(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err := h.schoolService.DeleteSchool(c.Context(), id); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, nil, "School deleted successfully")
+}
@@ -0,0 +1,586 @@
+package handlers
+
+import (
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/adapters/http/dto"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type SchoolSupplyListHandler struct {
+	listService   services.SchoolSupplyListService
+	importService services.ListImportService
+}
+
+func NewSchoolSupplyListHandler(listService services.SchoolSupplyListService, importService services.ListImportService) *SchoolSupplyListHandler {
+	return &SchoolSupplyListHandler{
+		listService:   listService,
+		importService: importService,
+	}
+}
+
+// CreateList godoc
+// @Summary Create a new school supply list
+// @Tags school-lists
+// @Accept json
+// @Produce json
+// @Param list body dto.CreateSchoolSupplyListRequest true "List data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.SchoolSupplyListResponse}
+// @Router /school-lists [post]
+func (h *SchoolSupplyListHandler) CreateList(c *fiber.Ctx) error {
+	var req dto.CreateSchoolSupplyListRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	list := req.ToSchoolSupplyListDomain()
+	list.CreatedBy = &userID
+
+	if err := h.listService.CreateList(c.Context(), list, dto.ToSchoolSupplyListItems(req.Items)); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	created, err := h.listService.GetList(c.Context(), list.ListID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToSchoolSupplyListResponse(created)
+	return dto.SendSuccess(c, fiber.StatusCreated, response, "School supply list created successfully")
+}
+
+// GetList godoc
+// @Summary Get a school supply list by ID
+// @Tags school-lists
+// @Produce json
+// @Param id path string true "List ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SchoolSupplyListResponse}
+// @Router /school-lists/{id} [get]
+func (h *SchoolSupplyListHandler) GetList(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	list, err := h.listService.GetList(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToSchoolSupplyListResponse(list)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// ListLists godoc
+// @Summary List school supply lists with filters and pagination
+// @Tags school-lists
+// @Produce json
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param school_level query string false "School level"
+// @Param grade query string false "Grade"
+// @Param school_year query string false "School year (YYYY-YYYY)"
+// @Param school_id query string false "School ID"
+// @Param status query string false "Status filter"
+// @Param is_template query bool false "Only templates or only regular lists"
+// @Param search query string false "Search by name"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SchoolSupplyListListResponse}
+// @Router /school-lists [get]
+func (h *SchoolSupplyListHandler) ListLists(c *fiber.Ctx) error {
+	params := dto.GetPaginationParams(c)
+	filters := parseSchoolListFilters(c)
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		filters.Statuses = []domain.SchoolListStatus{domain.SchoolListStatus(statusStr)}
+	}
+
+	if templateStr := c.Query("is_template"); templateStr != "" {
+		if isTemplate, err := strconv.ParseBool(templateStr); err == nil {
+			filters.IsTemplate = &isTemplate
+		}
+	}
+
+	if search := c.Query("search"); search != "" {
+		filters.Search = &search
+	}
+
+	lists, total, err := h.listService.ListLists(c.Context(), filters, params.Limit, params.Offset)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToSchoolSupplyListListResponse(lists, total, params.Limit, params.Offset)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// UpdateList godoc
+// @Summary Update the header of a draft school supply list
+// @Tags school-lists
+// @Accept json
+// @Produce json
+// @Param id path string true "List ID"
+// @Param list body dto.SchoolSupplyListRequest true "List data"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SchoolSupplyListResponse}
+// @Router /school-lists/{id} [put]
+func (h *SchoolSupplyListHandler) UpdateList(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.SchoolSupplyListRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	list := req.ToSchoolSupplyListDomain()
+	list.ListID = id
+	list.UpdatedBy = &userID
+
+	if err := h.listService.UpdateList(c.Context(), list); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	updated, err := h.listService.GetList(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToSchoolSupplyListResponse(updated)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "School supply list updated successfully")
+}
+
+// ReplaceItems godoc
+// @Summary Replace the items of a draft school supply list
+// @Tags school-lists
+// @Accept json
+// @Produce json
+// @Param id path string true "List ID"
+// @Param items body dto.ReplaceListItemsRequest true "List items"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SchoolSupplyListResponse}
+// @Router /school-lists/{id}/items [put]
+func (h *SchoolSupplyListHandler) ReplaceItems(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.ReplaceListItemsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	list, err := h.listService.ReplaceItems(c.Context(), id, dto.ToSchoolSupplyListItems(req.Items), userID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToSchoolSupplyListResponse(list)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "School supply list items updated successfully")
+}
+
+// DeleteList godoc
+// @Summary Delete a draft school supply list
+// @Tags school-lists
+// @Param id path string true "List ID"
+// @Success 204
+// @Router /school-lists/{id} [delete]
+func (h *SchoolSupplyListHandler) DeleteList(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err := h.listService.DeleteList(c.Context(), id); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// PublishList godoc
+// @Summary Publish a draft school supply list
+// @Tags school-lists
+// @Produce json
+// @Param id path string true "List ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SchoolSupplyListResponse}
+// @Router /school-lists/{id}/publish [post]
+func (h *SchoolSupplyListHandler) PublishList(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err := h.listService.PublishList(c.Context(), id); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	list, err := h.listService.GetList(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToSchoolSupplyListResponse(list)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "School supply list published successfully")
+}
+
+// ArchiveList godoc
+// @Summary Archive a school supply list
+// @Tags school-lists
+// @Produce json
+// @Param id path string true "List ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SchoolSupplyListResponse}
+// @Router /school-lists/{id}/archive [post]
+func (h *SchoolSupplyListHandler) ArchiveList(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err := h.listService.ArchiveList(c.Context(), id); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	list, err := h.listService.GetList(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToSchoolSupplyListResponse(list)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "School supply list archived successfully")
+}
+
+// CloneList godoc
+// @Summary Clone a list or template into a new draft for another school year
+// @Tags school-lists
+// @Accept json
+// @Produce json
+// @Param id path string true "List ID"
+// @Param clone body dto.CloneSchoolSupplyListRequest true "Target school year"
+// @Success 201 {object} dto.SuccessResponse{data=dto.SchoolSupplyListResponse}
+// @Router /school-lists/{id}/clone [post]
+func (h *SchoolSupplyListHandler) CloneList(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.CloneSchoolSupplyListRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	list, err := h.listService.CloneList(c.Context(), id, req.SchoolYear, userID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToSchoolSupplyListResponse(list)
+	return dto.SendSuccess(c, fiber.StatusCreated, response, "School supply list cloned successfully")
+}
+
+// RecalculateCost godoc
+// @Summary Recompute the estimated cost of a list from current prices
+// @Tags school-lists
+// @Produce json
+// @Param id path string true "List ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SchoolSupplyListResponse}
+// @Router /school-lists/{id}/recalculate-cost [post]
+func (h *SchoolSupplyListHandler) RecalculateCost(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	list, err := h.listService.RecalculateCost(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToSchoolSupplyListResponse(list)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "Estimated cost recalculated successfully")
+}
+
+// RecalculateActiveCosts godoc
+// @Summary Recompute the estimated cost of every published list
+// @Tags school-lists
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=map[string]int}
+// @Router /school-lists/recalculate-costs [post]
+func (h *SchoolSupplyListHandler) RecalculateActiveCosts(c *fiber.Ctx) error {
+	count, err := h.listService.RecalculateActiveCosts(c.Context())
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, map[string]int{"updated_lists": count}, "Estimated costs recalculated successfully")
+}
+
+// CheckAvailability godoc
+// @Summary Check the stock of a list in a warehouse, including alternatives
+// @Tags school-lists
+// @Produce json
+// @Param id path string true "List ID"
+// @Param warehouse_id query string true "Warehouse ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ListAvailabilityResponse}
+// @Router /school-lists/{id}/availability [get]
+func (h *SchoolSupplyListHandler) CheckAvailability(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	warehouseID, err := uuid.Parse(c.Query("warehouse_id"))
+	if err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Valid warehouse_id is required", nil)
+	}
+
+	availability, err := h.listService.CheckAvailability(c.Context(), id, warehouseID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToListAvailabilityResponse(availability)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// ConvertList godoc
+// @Summary Build a sale or reservation from a list, substituting out-of-stock items with alternatives
+// @Tags school-lists
+// @Accept json
+// @Produce json
+// @Param id path string true "List ID"
+// @Param conversion body dto.ConvertListRequest true "Conversion data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.ListConversionResponse}
+// @Success 200 {object} dto.SuccessResponse{data=dto.ListConversionResponse} "Preview"
+// @Router /school-lists/{id}/convert [post]
+func (h *SchoolSupplyListHandler) ConvertList(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.ConvertListRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	result, err := h.listService.ConvertList(c.Context(), req.ToServiceRequest(id, userID))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToListConversionResponse(result)
+	if req.Preview {
+		return dto.SendSuccess(c, fiber.StatusOK, response, "")
+	}
+	return dto.SendSuccess(c, fiber.StatusCreated, response, "School supply list converted successfully")
+}
+
+// PreviewImport godoc
+// @Summary Parse a CSV or plain text list and propose a product for every line
+// @Description Accepts a JSON body or a multipart upload with a "file" field. Nothing is saved.
+// @Tags school-lists
+// @Accept json,mpfd
+// @Produce json
+// @Param document body dto.PreviewListImportRequest false "Document to import"
+// @Param file formData file false "CSV or text file"
+// @Param format formData string false "CSV or TEXT, inferred from the file extension when omitted"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ListImportPreviewResponse}
+// @Router /school-lists/import/preview [post]
+func (h *SchoolSupplyListHandler) PreviewImport(c *fiber.Ctx) error {
+	var req dto.PreviewListImportRequest
+
+	if file, err := c.FormFile("file"); err == nil {
+		f, err := file.Open()
+		if err != nil {
+			return dto.SendError(c, fiber.StatusBadRequest, "Invalid file", err.Error())
+		}
+		defer f.Close()
+
+		content, err := io.ReadAll(f)
+		if err != nil {
+			return dto.SendError(c, fiber.StatusBadRequest, "Invalid file", err.Error())
+		}
+
+		req.Content = string(content)
+		req.Format = domain.ListImportFormat(strings.ToUpper(c.FormValue("format")))
+		if req.Format == "" {
+			req.Format = domain.ListImportFormatText
+			if strings.EqualFold(filepath.Ext(file.Filename), ".csv") {
+				req.Format = domain.ListImportFormatCSV
+			}
+		}
+	} else if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	preview, err := h.importService.PreviewImport(c.Context(), req.Format, req.Content)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToListImportPreviewResponse(preview)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// ConfirmImport godoc
+// @Summary Save an imported list as draft with the mappings confirmed by staff
+// @Tags school-lists
+// @Accept json
+// @Produce json
+// @Param list body dto.ConfirmListImportRequest true "List data and confirmed lines"
+// @Success 201 {object} dto.SuccessResponse{data=dto.SchoolSupplyListResponse}
+// @Router /school-lists/import [post]
+func (h *SchoolSupplyListHandler) ConfirmImport(c *fiber.Ctx) error {
+	var req dto.ConfirmListImportRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		return dto.SendError(c, fiber.StatusUnauthorized, "User not authenticated", nil)
+	}
+
+	list, err := h.importService.ConfirmImport(c.Context(), req.ToServiceRequest(userID))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToSchoolSupplyListResponse(list)
+	return dto.SendSuccess(c, fiber.StatusCreated, response, "School supply list imported successfully")
+}
+
+// GetListForChild godoc
+// @Summary Resolve the published list a child needs, from the child's school, level and grade
+// @Tags school-lists
+// @Produce json
+// @Param childId path string true "Child ID"
+// @Param school_year query string false "School year (YYYY-YYYY), the current one when omitted"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SchoolSupplyListResponse}
+// @Router /school-lists/for-child/{childId} [get]
+func (h *SchoolSupplyListHandler) GetListForChild(c *fiber.Ctx) error {
+	childID, err := uuid.Parse(c.Params("childId"))
+	if err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid child ID", err.Error())
+	}
+
+	list, err := h.listService.GetListForChild(c.Context(), childID, c.Query("school_year"))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToSchoolSupplyListResponse(list)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// ListPublishedLists godoc
+// @Summary List published school supply lists (public)
+// @Tags school-lists
+// @Produce json
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param school_level query string false "School level"
+// @Param grade query string false "Grade"
+// @Param school_year query string false "School year (YYYY-YYYY)"
+// @Param school_id query string false "School ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SchoolSupplyListListResponse}
+// @Router /public/school-lists [get]
+func (h *SchoolSupplyListHandler) ListPublishedLists(c *fiber.Ctx) error {
+	params := dto.GetPaginationParams(c)
+	filters := parseSchoolListFilters(c)
+	filters.Statuses = []domain.SchoolListStatus{domain.SchoolListStatusPublished, domain.SchoolListStatusActive}
+
+	isTemplate := false
+	filters.IsTemplate = &isTemplate
+
+	lists, total, err := h.listService.ListLists(c.Context(), filters, params.Limit, params.Offset)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToSchoolSupplyListListResponse(lists, total, params.Limit, params.Offset)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// GetPublishedList godoc
+// @Summary Get a published school supply list with its items (public)
+// @Tags school-lists
+// @Produce json
+// @Param id path string true "List ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SchoolSupplyListResponse}
+// @Router /public/school-lists/{id} [get]
+func (h *SchoolSupplyListHandler) GetPublishedList(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	list, err := h.listService.GetPublishedList(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToSchoolSupplyListResponse(list)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// parseSchoolListFilters reads the school, level, grade and year filters shared by the public and staff endpoints
+func parseSchoolListFilters(c *fiber.Ctx) repositories.SchoolSupplyListFilters {
+	filters := repositories.SchoolSupplyListFilters{}
+
+	if schoolStr := c.Query("school_id"); schoolStr != "" {
+		if schoolID, err := uuid.Parse(schoolStr); err == nil {
+			filters.SchoolID = &schoolID
+		}
+	}
+
+	if levelStr := c.Query("school_level"); levelStr != "" {
+		level := domain.SchoolLevel(levelStr)
+		filters.SchoolLevel = &level
+	}
+
+	if grade := c.Query("grade"); grade != "" {
+		filters.Grade = &grade
+	}
+
+	if schoolYear := c.Query("school_year"); schoolYear != "" {
+		filters.SchoolYear = &schoolYear
+	}
+
+	return filters
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/adapters/http/dto"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type StoredValueHandler struct {
+	storedValueService services.StoredValueService
+}
+
+func NewStoredValueHandler(storedValueService services.StoredValueService) *StoredValueHandler {
+	return &StoredValueHandler{
+		storedValueService: storedValueService,
+	}
+}
+
+// IssueGiftCard godoc
+// @Summary Issue a gift card
+// @Tags stored-value
+// @Accept json
+// @Produce json
+// @Param giftCard body dto.IssueGiftCardRequest true "Gift card data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.StoredValueAccountResponse}
+// @Router /stored-value/gift-cards [post]
+func (h *StoredValueHandler) IssueGiftCard(c *fiber.Ctx) error {
+	var req dto.IssueGiftCardRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, _ := GetUserID(c)
+
+	account, err := h.storedValueService.IssueGiftCard(c.Context(), req.ToServiceRequest(userID))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToStoredValueAccountResponse(account)
+	return dto.SendSuccess(c, fiber.StatusCreated, response, "Gift card issued successfully")
+}
+
+// IssueStoreCredit godoc
+// @Summary Credit a customer store-credit account
+// @Tags stored-value
+// @Accept json
+// @Produce json
+// @Param storeCredit body dto.IssueStoreCreditRequest true "Store credit data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.StoredValueAccountResponse}
+// @Router /stored-value/store-credit [post]
+func (h *StoredValueHandler) IssueStoreCredit(c *fiber.Ctx) error {
+	var req dto.IssueStoreCreditRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dto.SendError(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	userID, _ := GetUserID(c)
+
+	account, err := h.storedValueService.IssueStoreCredit(c.Context(), req.ToServiceRequest(userID))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToStoredValueAccountResponse(account)
+	return dto.SendSuccess(c, fiber.StatusCreated, response, "Store credit issued successfully")
+}
+
+// GetAccount godoc
+// @Summary Get a gift card or store-credit account by ID
+// @Tags stored-value
+// @Produce json
+// @Param id path string true "Account ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.StoredValueAccountResponse}
+// @Router /stored-value/{id} [get]
+func (h *StoredValueHandler) GetAccount(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	account, err := h.storedValueService.GetAccount(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToStoredValueAccountResponse(account)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// GetGiftCardByCode godoc
+// @Summary Gift card balance inquiry by code
+// @Tags stored-value
+// @Produce json
+// @Param code path string true "Gift card code"
+// @Success 200 {object} dto.SuccessResponse{data=dto.StoredValueAccountResponse}
+// @Router /stored-value/code/{code} [get]
+func (h *StoredValueHandler) GetGiftCardByCode(c *fiber.Ctx) error {
+	code := c.Params("code")
+	if code == "" {
+		return dto.SendError(c, fiber.StatusBadRequest, "Code is required", nil)
+	}
+
+	account, err := h.storedValueService.GetGiftCardByCode(c.Context(), code)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToStoredValueAccountResponse(account)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// ListAccounts godoc
+// @Summary List gift cards and store-credit accounts
+// @Tags stored-value
+// @Produce json
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param type query string false "Account type (GIFT_CARD, STORE_CREDIT)"
+// @Param customer_id query string false "Customer ID"
+// @Param status query string false "Status filter"
+// @Param currency query string false "Currency filter"
+// @Success 200 {object} dto.SuccessResponse{data=dto.StoredValueListResponse}
+// @Router /stored-value [get]
+func (h *StoredValueHandler) ListAccounts(c *fiber.Ctx) error {
+	params := dto.GetPaginationParams(c)
+	filters := repositories.StoredValueFilters{}
+
+	if typeStr := c.Query("type"); typeStr != "" {
+		accountType := domain.StoredValueType(typeStr)
+		filters.AccountType = &accountType
+	}
+
+	if customerStr := c.Query("customer_id"); customerStr != "" {
+		if customerID, err := uuid.Parse(customerStr); err == nil {
+			filters.CustomerID = &customerID
+		}
+	}
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := domain.StoredValueStatus(statusStr)
+		filters.Status = &status
+	}
+
+	if currencyStr := c.Query("currency"); currencyStr != "" {
+		currency := domain.CurrencyCode(currencyStr)
+		filters.Currency = &currency
+	}
+
+	accounts, total, err := h.storedValueService.ListAccounts(c.Context(), filters, params.Limit, params.Offset)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	response := dto.ToStoredValueListResponse(accounts, total, params.Limit, params.Offset)
+	return dto.SendSuccess(c, fiber.StatusOK, response, "")
+}
+
+// GetTransactions godoc
+// @Summary Get the transaction history of a gift card or store-credit account
+// @Tags stored-value
+// @Produce json
+// @Param id path string true "Account ID"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.StoredValueTransactionResponse}
+// @Router /stored-value/{id}/transactions [get]
+func (h *StoredValueHandler) GetTransactions(c *fiber.Ctx) error {
+	id, err := ParseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	txns, err := h.storedValueService.GetTransactions(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	responses := make([]dto.StoredValueTransactionResponse, len(txns))
+	for i, txn := range txns {
+		responses[i] = dto.ToStoredValueTransactionResponse(&txn)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, responses, "")
+}
+
+// ExpireAccounts godoc
+// @Summary Expire gift cards and store credit past their expiry date
+// @Tags stored-value
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=map[string]int}
+// @Router /stored-value/expire [post]
+func (h *StoredValueHandler) ExpireAccounts(c *fiber.Ctx) error {
+	count, err := h.storedValueService.ExpireAccounts(c.Context(), time.Now())
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return dto.SendSuccess(c, fiber.StatusOK, map[string]int{"expired_accounts": count}, "Stored value accounts expired successfully")
+}
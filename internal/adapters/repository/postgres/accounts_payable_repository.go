@@ -0,0 +1,305 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type accountsPayableRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountsPayableRepository creates a new accounts payable repository
+func NewAccountsPayableRepository(db *gorm.DB) repositories.AccountsPayableRepository {
+	return &accountsPayableRepository{db: db}
+}
+
+func (r *accountsPayableRepository) FindSupplierByID(ctx context.Context, id uuid.UUID) (*domain.Supplier, error) {
+	var supplier domain.Supplier
+	err := database.Conn(ctx, r.db).First(&supplier, "supplier_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("Supplier", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find supplier")
+	}
+	return &supplier, nil
+}
+
+func (r *accountsPayableRepository) UpdateSupplierPurchases(ctx context.Context, supplierID uuid.UUID, amount float64, at time.Time) error {
+	err := database.Conn(ctx, r.db).
+		Model(&domain.Supplier{}).
+		Where("supplier_id = ?", supplierID).
+		Updates(map[string]interface{}{
+			"total_purchases":    gorm.Expr("GREATEST(total_purchases + ?, 0)", amount),
+			"last_purchase_date": at,
+		}).Error
+
+	if err != nil {
+		return errors.WrapError(err, "failed to update supplier purchases")
+	}
+	return nil
+}
+
+func (r *accountsPayableRepository) CreateInvoice(ctx context.Context, invoice *domain.SupplierInvoice) error {
+	if err := database.Conn(ctx, r.db).Omit(clause.Associations).Create(invoice).Error; err != nil {
+		return errors.WrapError(err, "failed to create supplier invoice")
+	}
+	return nil
+}
+
+func (r *accountsPayableRepository) FindInvoiceByID(ctx context.Context, id uuid.UUID) (*domain.SupplierInvoice, error) {
+	var invoice domain.SupplierInvoice
+	err := database.Conn(ctx, r.db).
+		Preload("Supplier").
+		Preload("Payments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("payment_date ASC")
+		}).
+		First(&invoice, "invoice_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("SupplierInvoice", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find supplier invoice")
+	}
+	return &invoice, nil
+}
+
+func (r *accountsPayableRepository) FindInvoiceByNumber(ctx context.Context, supplierID uuid.UUID, number string) (*domain.SupplierInvoice, error) {
+	var invoice domain.SupplierInvoice
+	err := database.Conn(ctx, r.db).
+		Where("supplier_id = ? AND invoice_number = ?", supplierID, number).
+		Where("status <> ?", domain.AccountStatusCancelled).
+		First(&invoice).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("SupplierInvoice")
+		}
+		return nil, errors.WrapError(err, "failed to find supplier invoice by number")
+	}
+	return &invoice, nil
+}
+
+func (r *accountsPayableRepository) FindInvoiceByReceipt(ctx context.Context, receiptID uuid.UUID) (*domain.SupplierInvoice, error) {
+	var invoice domain.SupplierInvoice
+	err := database.Conn(ctx, r.db).
+		Where("receipt_id = ?", receiptID).
+		Where("status <> ?", domain.AccountStatusCancelled).
+		First(&invoice).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("SupplierInvoice")
+		}
+		return nil, errors.WrapError(err, "failed to find supplier invoice by receipt")
+	}
+	return &invoice, nil
+}
+
+func (r *accountsPayableRepository) ListInvoices(ctx context.Context, filters repositories.SupplierInvoiceFilters, limit, offset int) ([]domain.SupplierInvoice, int64, error) {
+	var invoices []domain.SupplierInvoice
+	var total int64
+
+	query := database.Conn(ctx, r.db).Model(&domain.SupplierInvoice{})
+	query = r.buildFilterQuery(query, filters)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.WrapError(err, "failed to count supplier invoices")
+	}
+
+	err := query.
+		Preload("Supplier").
+		Order("due_date ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&invoices).Error
+
+	if err != nil {
+		return nil, 0, errors.WrapError(err, "failed to list supplier invoices")
+	}
+
+	return invoices, total, nil
+}
+
+func (r *accountsPayableRepository) UpdateInvoice(ctx context.Context, invoice *domain.SupplierInvoice) error {
+	if err := database.Conn(ctx, r.db).Omit(clause.Associations).Save(invoice).Error; err != nil {
+		return errors.WrapError(err, "failed to update supplier invoice")
+	}
+	return nil
+}
+
+func (r *accountsPayableRepository) GetOutstanding(ctx context.Context, filters repositories.SupplierInvoiceFilters) ([]domain.SupplierInvoice, error) {
+	var invoices []domain.SupplierInvoice
+
+	query := database.Conn(ctx, r.db).
+		Preload("Supplier").
+		Where("status IN (?, ?, ?)", domain.AccountStatusPending, domain.AccountStatusPartiallyPaid, domain.AccountStatusOverdue).
+		Where("balance > 0")
+	query = r.buildFilterQuery(query, filters)
+
+	if err := query.Order("due_date ASC").Find(&invoices).Error; err != nil {
+		return nil, errors.WrapError(err, "failed to get outstanding supplier invoices")
+	}
+	return invoices, nil
+}
+
+func (r *accountsPayableRepository) ReceiptExists(ctx context.Context, receiptID uuid.UUID) (bool, error) {
+	var count int64
+	err := database.Conn(ctx, r.db).
+		Model(&domain.InventoryMovement{}).
+		Where("reference_id = ? AND movement_type = ?", receiptID, domain.MovementTypeIn).
+		Count(&count).Error
+
+	if err != nil {
+		return false, errors.WrapError(err, "failed to find purchase receipt")
+	}
+	return count > 0, nil
+}
+
+func (r *accountsPayableRepository) AddPayment(ctx context.Context, payment *domain.SupplierPayment) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Lock the invoice record
+		var invoice domain.SupplierInvoice
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&invoice, "invoice_id = ?", payment.InvoiceID).Error
+		if err != nil {
+			return errors.WrapError(err, "failed to find supplier invoice")
+		}
+
+		if invoice.Status == domain.AccountStatusPaid || invoice.Status == domain.AccountStatusCancelled {
+			return errors.BadRequest(fmt.Sprintf("Supplier invoice %s is %s", invoice.InvoiceNumber, invoice.Status))
+		}
+
+		if math.Round(payment.AppliedAmount*100) > math.Round(invoice.Balance*100) {
+			return errors.BadRequest(fmt.Sprintf(
+				"Payment amount (%.2f %s) exceeds balance (%.2f %s)",
+				payment.AppliedAmount, invoice.Currency, invoice.Balance, invoice.Currency,
+			))
+		}
+
+		if err := tx.Create(payment).Error; err != nil {
+			return errors.WrapError(err, "failed to create supplier payment")
+		}
+
+		// Update paid amount, balance and status
+		invoice.PaidAmount += payment.AppliedAmount
+		invoice.RefreshBalance(time.Now())
+
+		if err := tx.Save(&invoice).Error; err != nil {
+			return errors.WrapError(err, "failed to update supplier invoice")
+		}
+
+		return nil
+	})
+}
+
+func (r *accountsPayableRepository) CancelInvoice(ctx context.Context, invoice *domain.SupplierInvoice) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Lock the invoice record so no payment lands while it is cancelled
+		var current domain.SupplierInvoice
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&current, "invoice_id = ?", invoice.InvoiceID).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.NotFoundWithID("SupplierInvoice", invoice.InvoiceID.String())
+			}
+			return errors.WrapError(err, "failed to find supplier invoice")
+		}
+
+		if current.Status == domain.AccountStatusCancelled {
+			return errors.Conflict("Supplier invoice is already cancelled")
+		}
+
+		var payments int64
+		if err := tx.Model(&domain.SupplierPayment{}).
+			Where("invoice_id = ?", invoice.InvoiceID).
+			Count(&payments).Error; err != nil {
+			return errors.WrapError(err, "failed to count supplier payments")
+		}
+		if current.PaidAmount > 0 || payments > 0 {
+			return errors.Conflict("Supplier invoice has payments and cannot be cancelled")
+		}
+
+		err = tx.Model(&domain.SupplierInvoice{}).
+			Where("invoice_id = ?", invoice.InvoiceID).
+			Updates(map[string]interface{}{
+				"status":              domain.AccountStatusCancelled,
+				"balance":             0,
+				"cancellation_reason": invoice.CancellationReason,
+				"updated_at":          invoice.UpdatedAt,
+			}).Error
+		if err != nil {
+			return errors.WrapError(err, "failed to cancel supplier invoice")
+		}
+		return nil
+	})
+}
+
+func (r *accountsPayableRepository) GetPayments(ctx context.Context, invoiceID uuid.UUID) ([]domain.SupplierPayment, error) {
+	var payments []domain.SupplierPayment
+	err := database.Conn(ctx, r.db).
+		Where("invoice_id = ?", invoiceID).
+		Order("payment_date DESC").
+		Find(&payments).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get supplier payments")
+	}
+	return payments, nil
+}
+
+func (r *accountsPayableRepository) MarkOverdue(ctx context.Context, at time.Time) (int, error) {
+	result := database.Conn(ctx, r.db).
+		Model(&domain.SupplierInvoice{}).
+		Where("status IN (?, ?)", domain.AccountStatusPending, domain.AccountStatusPartiallyPaid).
+		Where("due_date < ?", at).
+		Update("status", domain.AccountStatusOverdue)
+
+	if result.Error != nil {
+		return 0, errors.WrapError(result.Error, "failed to mark overdue supplier invoices")
+	}
+	return int(result.RowsAffected), nil
+}
+
+// Helper functions
+
+func (r *accountsPayableRepository) buildFilterQuery(query *gorm.DB, filters repositories.SupplierInvoiceFilters) *gorm.DB {
+	if filters.SupplierID != nil {
+		query = query.Where("supplier_id = ?", *filters.SupplierID)
+	}
+
+	if filters.StoreID != nil {
+		query = query.Where("store_id = ?", *filters.StoreID)
+	}
+
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+
+	if filters.Currency != nil {
+		query = query.Where("currency = ?", *filters.Currency)
+	}
+
+	if filters.DueFrom != nil {
+		query = query.Where("due_date >= ?", *filters.DueFrom)
+	}
+
+	if filters.DueTo != nil {
+		query = query.Where("due_date <= ?", *filters.DueTo)
+	}
+
+	return query
+}
@@ -2,13 +2,17 @@ package postgres
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jadiazinf/inventory/internal/common/errors"
 	"github.com/jadiazinf/inventory/internal/core/domain"
 	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type accountsReceivableRepository struct {
@@ -21,7 +25,7 @@ func NewAccountsReceivableRepository(db *gorm.DB) repositories.AccountsReceivabl
 }
 
 func (r *accountsReceivableRepository) Create(ctx context.Context, receivable *domain.AccountsReceivable) error {
-	if err := r.db.WithContext(ctx).Create(receivable).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Create(receivable).Error; err != nil {
 		return errors.WrapError(err, "failed to create accounts receivable")
 	}
 	return nil
@@ -29,10 +33,10 @@ func (r *accountsReceivableRepository) Create(ctx context.Context, receivable *d
 
 func (r *accountsReceivableRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.AccountsReceivable, error) {
 	var receivable domain.AccountsReceivable
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Sale").
 		Preload("Customer").
-		First(&receivable, "account_id = ?", id).Error
+		First(&receivable, "receivable_id = ?", id).Error
 
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -45,7 +49,7 @@ func (r *accountsReceivableRepository) FindByID(ctx context.Context, id uuid.UUI
 
 func (r *accountsReceivableRepository) FindBySale(ctx context.Context, saleID uuid.UUID) (*domain.AccountsReceivable, error) {
 	var receivable domain.AccountsReceivable
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Sale").
 		Preload("Customer").
 		Where("sale_id = ?", saleID).
@@ -62,7 +66,7 @@ func (r *accountsReceivableRepository) FindBySale(ctx context.Context, saleID uu
 
 func (r *accountsReceivableRepository) FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]domain.AccountsReceivable, error) {
 	var receivables []domain.AccountsReceivable
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Sale").
 		Where("customer_id = ?", customerID).
 		Order("created_at DESC").
@@ -78,10 +82,10 @@ func (r *accountsReceivableRepository) GetOverdue(ctx context.Context) ([]domain
 	var receivables []domain.AccountsReceivable
 	now := time.Now()
 
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Sale").
 		Preload("Customer").
-		Where("status IN (?, ?)", domain.AccountStatusPending, domain.AccountStatusPartiallyPaid).
+		Where("status IN (?, ?, ?)", domain.AccountStatusPending, domain.AccountStatusPartiallyPaid, domain.AccountStatusOverdue).
 		Where("due_date < ?", now).
 		Order("due_date ASC").
 		Find(&receivables).Error
@@ -92,15 +96,12 @@ func (r *accountsReceivableRepository) GetOverdue(ctx context.Context) ([]domain
 	return receivables, nil
 }
 
-func (r *accountsReceivableRepository) List(ctx context.Context, status *domain.AccountStatus, limit, offset int) ([]domain.AccountsReceivable, int64, error) {
+func (r *accountsReceivableRepository) List(ctx context.Context, filters repositories.AccountsReceivableFilters, limit, offset int) ([]domain.AccountsReceivable, int64, error) {
 	var receivables []domain.AccountsReceivable
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&domain.AccountsReceivable{})
-
-	if status != nil {
-		query = query.Where("status = ?", *status)
-	}
+	query := database.Conn(ctx, r.db).Model(&domain.AccountsReceivable{})
+	query = r.buildFilterQuery(query, filters)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, errors.WrapError(err, "failed to count accounts receivable")
@@ -122,57 +123,670 @@ func (r *accountsReceivableRepository) List(ctx context.Context, status *domain.
 }
 
 func (r *accountsReceivableRepository) Update(ctx context.Context, receivable *domain.AccountsReceivable) error {
-	if err := r.db.WithContext(ctx).Save(receivable).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Save(receivable).Error; err != nil {
 		return errors.WrapError(err, "failed to update accounts receivable")
 	}
 	return nil
 }
 
 func (r *accountsReceivableRepository) AddPayment(ctx context.Context, payment *domain.CustomerPayment) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Create payment record
-		if err := tx.Create(payment).Error; err != nil {
-			return errors.WrapError(err, "failed to create payment")
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return r.applyPayment(tx, payment)
+	})
+}
+
+func (r *accountsReceivableRepository) GetPayments(ctx context.Context, receivableID uuid.UUID) ([]domain.CustomerPayment, error) {
+	var payments []domain.CustomerPayment
+	err := database.Conn(ctx, r.db).
+		Where("receivable_id = ?", receivableID).
+		Order("payment_date DESC").
+		Find(&payments).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get payments")
+	}
+	return payments, nil
+}
+
+func (r *accountsReceivableRepository) CreateReceipt(ctx context.Context, receipt *domain.CustomerReceipt, payments []domain.CustomerPayment) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(receipt).Error; err != nil {
+			return errors.WrapError(err, "failed to create customer receipt")
 		}
 
-		// Get the accounts receivable record
-		var receivable domain.AccountsReceivable
-		if err := tx.First(&receivable, "account_id = ?", payment.ReceivableID).Error; err != nil {
-			return errors.WrapError(err, "failed to find accounts receivable")
+		for i := range payments {
+			payments[i].ReceiptID = &receipt.ReceiptID
+			if err := r.applyPayment(tx, &payments[i]); err != nil {
+				return err
+			}
 		}
 
-		// Update paid amount
-		receivable.PaidAmount += payment.Amount
+		return nil
+	})
+}
 
-		// Update status based on paid amount
-		if receivable.PaidAmount >= receivable.TotalAmount {
-			receivable.Status = domain.AccountStatusPaid
-			receivable.PaidAmount = receivable.TotalAmount // Ensure we don't overpay
-		} else if receivable.PaidAmount > 0 {
-			receivable.Status = domain.AccountStatusPartiallyPaid
+func (r *accountsReceivableRepository) FindReceiptByID(ctx context.Context, id uuid.UUID) (*domain.CustomerReceipt, error) {
+	var receipt domain.CustomerReceipt
+	err := database.Conn(ctx, r.db).
+		Preload("Customer").
+		Preload("Payments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("payment_date ASC")
+		}).
+		First(&receipt, "receipt_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("CustomerReceipt", id.String())
 		}
+		return nil, errors.WrapError(err, "failed to find customer receipt")
+	}
+	return &receipt, nil
+}
 
-		// Update balance
-		receivable.Balance = receivable.TotalAmount - receivable.PaidAmount
+func (r *accountsReceivableRepository) ListReceipts(ctx context.Context, customerID *uuid.UUID, limit, offset int) ([]domain.CustomerReceipt, int64, error) {
+	var receipts []domain.CustomerReceipt
+	var total int64
 
-		// Save updated receivable
-		if err := tx.Save(&receivable).Error; err != nil {
-			return errors.WrapError(err, "failed to update accounts receivable")
+	query := database.Conn(ctx, r.db).Model(&domain.CustomerReceipt{})
+	if customerID != nil {
+		query = query.Where("customer_id = ?", *customerID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.WrapError(err, "failed to count customer receipts")
+	}
+
+	err := query.
+		Preload("Customer").
+		Preload("Payments").
+		Order("receipt_date DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&receipts).Error
+
+	if err != nil {
+		return nil, 0, errors.WrapError(err, "failed to list customer receipts")
+	}
+
+	return receipts, total, nil
+}
+
+func (r *accountsReceivableRepository) UpdateReceipt(ctx context.Context, receipt *domain.CustomerReceipt) error {
+	if err := database.Conn(ctx, r.db).Omit(clause.Associations).Save(receipt).Error; err != nil {
+		return errors.WrapError(err, "failed to update customer receipt")
+	}
+	return nil
+}
+
+func (r *accountsReceivableRepository) ReverseReceipt(ctx context.Context, receipt *domain.CustomerReceipt) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Only a posted receipt can be reversed, even under concurrent reversals
+		result := tx.Model(&domain.CustomerReceipt{}).
+			Where("receipt_id = ? AND status <> ?", receipt.ReceiptID, domain.ReceiptStatusReversed).
+			Updates(map[string]interface{}{
+				"status":          receipt.Status,
+				"reversed_at":     receipt.ReversedAt,
+				"reversed_by":     receipt.ReversedBy,
+				"reversal_reason": receipt.ReversalReason,
+			})
+		if result.Error != nil {
+			return errors.WrapError(result.Error, "failed to update customer receipt")
+		}
+		if result.RowsAffected == 0 {
+			return errors.Conflict("Receipt was already reversed")
+		}
+
+		var payments []domain.CustomerPayment
+		err := tx.Where("receipt_id = ? AND reversed_at IS NULL", receipt.ReceiptID).Find(&payments).Error
+		if err != nil {
+			return errors.WrapError(err, "failed to find receipt payments")
+		}
+
+		for i := range payments {
+			payments[i].ReversedAt = receipt.ReversedAt
+			payments[i].ReversedBy = receipt.ReversedBy
+			payments[i].VoidReason = receipt.ReversalReason
+			if err := r.unapplyPayment(tx, &payments[i]); err != nil {
+				return err
+			}
 		}
 
 		return nil
 	})
 }
 
-func (r *accountsReceivableRepository) GetPayments(ctx context.Context, receivableID uuid.UUID) ([]domain.CustomerPayment, error) {
+func (r *accountsReceivableRepository) FindPaymentByID(ctx context.Context, id uuid.UUID) (*domain.CustomerPayment, error) {
+	var payment domain.CustomerPayment
+	err := database.Conn(ctx, r.db).
+		Preload("Receivable").
+		First(&payment, "payment_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("CustomerPayment", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find payment")
+	}
+	return &payment, nil
+}
+
+func (r *accountsReceivableRepository) VoidPayment(ctx context.Context, payment *domain.CustomerPayment) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return r.unapplyPayment(tx, payment)
+	})
+}
+
+func (r *accountsReceivableRepository) CreateAdjustment(ctx context.Context, adjustment *domain.ReceivableAdjustment) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(adjustment).Error; err != nil {
+			return errors.WrapError(err, "failed to create receivable adjustment")
+		}
+
+		if adjustment.Status == domain.AdjustmentStatusApplied {
+			return r.applyAdjustment(tx, adjustment)
+		}
+		return nil
+	})
+}
+
+func (r *accountsReceivableRepository) FindAdjustmentByID(ctx context.Context, id uuid.UUID) (*domain.ReceivableAdjustment, error) {
+	var adjustment domain.ReceivableAdjustment
+	err := database.Conn(ctx, r.db).
+		Preload("Receivable").
+		First(&adjustment, "adjustment_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("ReceivableAdjustment", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find receivable adjustment")
+	}
+	return &adjustment, nil
+}
+
+func (r *accountsReceivableRepository) ListAdjustments(ctx context.Context, filters repositories.AdjustmentFilters, limit, offset int) ([]domain.ReceivableAdjustment, int64, error) {
+	var adjustments []domain.ReceivableAdjustment
+	var total int64
+
+	query := database.Conn(ctx, r.db).Model(&domain.ReceivableAdjustment{})
+	if filters.CustomerID != nil {
+		query = query.Where("customer_id = ?", *filters.CustomerID)
+	}
+	if filters.ReceivableID != nil {
+		query = query.Where("receivable_id = ?", *filters.ReceivableID)
+	}
+	if filters.Type != nil {
+		query = query.Where("adjustment_type = ?", *filters.Type)
+	}
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.WrapError(err, "failed to count receivable adjustments")
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&adjustments).Error
+
+	if err != nil {
+		return nil, 0, errors.WrapError(err, "failed to list receivable adjustments")
+	}
+
+	return adjustments, total, nil
+}
+
+func (r *accountsReceivableRepository) ReviewAdjustment(ctx context.Context, adjustment *domain.ReceivableAdjustment) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Only a pending adjustment can be reviewed, even under concurrent reviews
+		result := tx.Model(&domain.ReceivableAdjustment{}).
+			Where("adjustment_id = ? AND status = ?", adjustment.AdjustmentID, domain.AdjustmentStatusPending).
+			Updates(map[string]interface{}{
+				"status":       adjustment.Status,
+				"reviewed_by":  adjustment.ReviewedBy,
+				"reviewed_at":  adjustment.ReviewedAt,
+				"review_notes": adjustment.ReviewNotes,
+				"applied_at":   adjustment.AppliedAt,
+				"updated_at":   time.Now(),
+			})
+		if result.Error != nil {
+			return errors.WrapError(result.Error, "failed to review receivable adjustment")
+		}
+		if result.RowsAffected == 0 {
+			return errors.Conflict("Adjustment was already reviewed")
+		}
+
+		if adjustment.Status == domain.AdjustmentStatusApplied {
+			return r.applyAdjustment(tx, adjustment)
+		}
+		return nil
+	})
+}
+
+func (r *accountsReceivableRepository) GetCustomerAdjustments(ctx context.Context, customerID uuid.UUID, to time.Time) ([]domain.ReceivableAdjustment, error) {
+	var adjustments []domain.ReceivableAdjustment
+	err := database.Conn(ctx, r.db).
+		Where("customer_id = ?", customerID).
+		Where("status = ?", domain.AdjustmentStatusApplied).
+		Where("applied_at <= ?", to).
+		Order("applied_at ASC").
+		Find(&adjustments).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get customer adjustments")
+	}
+	return adjustments, nil
+}
+
+func (r *accountsReceivableRepository) GetOutstanding(ctx context.Context, filters repositories.AccountsReceivableFilters) ([]domain.AccountsReceivable, error) {
+	var receivables []domain.AccountsReceivable
+
+	query := database.Conn(ctx, r.db).
+		Preload("Sale").
+		Preload("Customer").
+		Where("status IN (?, ?, ?)", domain.AccountStatusPending, domain.AccountStatusPartiallyPaid, domain.AccountStatusOverdue).
+		Where("balance > 0")
+	query = r.buildFilterQuery(query, filters)
+
+	if err := query.Order("due_date ASC").Find(&receivables).Error; err != nil {
+		return nil, errors.WrapError(err, "failed to get outstanding accounts")
+	}
+	return receivables, nil
+}
+
+func (r *accountsReceivableRepository) GetCustomerPayments(ctx context.Context, customerID uuid.UUID, to time.Time) ([]domain.CustomerPayment, error) {
 	var payments []domain.CustomerPayment
-	err := r.db.WithContext(ctx).
-		Where("account_id = ?", receivableID).
-		Order("payment_date DESC").
+	err := database.Conn(ctx, r.db).
+		Where("receivable_id IN (?)", r.db.Model(&domain.AccountsReceivable{}).
+			Select("receivable_id").
+			Where("customer_id = ?", customerID)).
+		Where("payment_date <= ?", to).
+		Where("reversed_at IS NULL").
+		Order("payment_date ASC").
 		Find(&payments).Error
 
 	if err != nil {
-		return nil, errors.WrapError(err, "failed to get payments")
+		return nil, errors.WrapError(err, "failed to get customer payments")
 	}
 	return payments, nil
 }
+
+func (r *accountsReceivableRepository) MarkOverdue(ctx context.Context, at time.Time) (int, error) {
+	result := database.Conn(ctx, r.db).
+		Model(&domain.AccountsReceivable{}).
+		Where("status IN (?, ?)", domain.AccountStatusPending, domain.AccountStatusPartiallyPaid).
+		Where("due_date < ?", at).
+		Update("status", domain.AccountStatusOverdue)
+
+	if result.Error != nil {
+		return 0, errors.WrapError(result.Error, "failed to mark overdue accounts")
+	}
+	return int(result.RowsAffected), nil
+}
+
+func (r *accountsReceivableRepository) GetLateFeeTotal(ctx context.Context, sourceID uuid.UUID) (float64, error) {
+	var total float64
+	err := database.Conn(ctx, r.db).
+		Model(&domain.AccountsReceivable{}).
+		Select("COALESCE(SUM(total_amount), 0)").
+		Where("source_receivable_id = ? AND kind = ?", sourceID, domain.ReceivableKindLateFee).
+		Where("status <> ?", domain.AccountStatusCancelled).
+		Scan(&total).Error
+
+	if err != nil {
+		return 0, errors.WrapError(err, "failed to sum late fees")
+	}
+	return total, nil
+}
+
+func (r *accountsReceivableRepository) CreateWithOverride(ctx context.Context, receivable *domain.AccountsReceivable, override *domain.CreditOverride) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(receivable).Error; err != nil {
+			return errors.WrapError(err, "failed to create accounts receivable")
+		}
+
+		if override != nil {
+			if err := tx.Omit(clause.Associations).Create(override).Error; err != nil {
+				return errors.WrapError(err, "failed to record credit override")
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *accountsReceivableRepository) ListCreditOverrides(ctx context.Context, customerID *uuid.UUID, limit, offset int) ([]domain.CreditOverride, int64, error) {
+	var overrides []domain.CreditOverride
+	var total int64
+
+	query := database.Conn(ctx, r.db).Model(&domain.CreditOverride{})
+	if customerID != nil {
+		query = query.Where("customer_id = ?", *customerID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.WrapError(err, "failed to count credit overrides")
+	}
+
+	err := query.
+		Preload("Sale").
+		Preload("Customer").
+		Preload("Supervisor").
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&overrides).Error
+
+	if err != nil {
+		return nil, 0, errors.WrapError(err, "failed to list credit overrides")
+	}
+
+	return overrides, total, nil
+}
+
+func (r *accountsReceivableRepository) CreateInstallmentPlan(ctx context.Context, plan *domain.InstallmentPlan, override *domain.CreditOverride) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(plan).Error; err != nil {
+			return errors.WrapError(err, "failed to create installment plan")
+		}
+
+		for i := range plan.Installments {
+			plan.Installments[i].InstallmentPlanID = &plan.PlanID
+			if err := tx.Omit(clause.Associations).Create(&plan.Installments[i]).Error; err != nil {
+				return errors.WrapError(err, "failed to create installment")
+			}
+		}
+
+		if override != nil {
+			if err := tx.Omit(clause.Associations).Create(override).Error; err != nil {
+				return errors.WrapError(err, "failed to record credit override")
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *accountsReceivableRepository) FindInstallmentPlanByID(ctx context.Context, id uuid.UUID) (*domain.InstallmentPlan, error) {
+	var plan domain.InstallmentPlan
+	err := database.Conn(ctx, r.db).
+		Preload("Sale").
+		Preload("Customer").
+		Preload("Installments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("installment_number ASC")
+		}).
+		First(&plan, "plan_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("InstallmentPlan", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find installment plan")
+	}
+	return &plan, nil
+}
+
+func (r *accountsReceivableRepository) ListInstallmentPlans(ctx context.Context, customerID *uuid.UUID, limit, offset int) ([]domain.InstallmentPlan, int64, error) {
+	var plans []domain.InstallmentPlan
+	var total int64
+
+	query := database.Conn(ctx, r.db).Model(&domain.InstallmentPlan{})
+	if customerID != nil {
+		query = query.Where("customer_id = ?", *customerID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.WrapError(err, "failed to count installment plans")
+	}
+
+	err := query.
+		Preload("Sale").
+		Preload("Customer").
+		Preload("Installments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("installment_number ASC")
+		}).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&plans).Error
+
+	if err != nil {
+		return nil, 0, errors.WrapError(err, "failed to list installment plans")
+	}
+
+	return plans, total, nil
+}
+
+func (r *accountsReceivableRepository) GetInstallmentsToRemind(ctx context.Context, from, to time.Time) ([]domain.AccountsReceivable, error) {
+	var receivables []domain.AccountsReceivable
+	err := database.Conn(ctx, r.db).
+		Where("installment_plan_id IS NOT NULL AND reminder_sent_at IS NULL").
+		Where("status IN (?, ?)", domain.AccountStatusPending, domain.AccountStatusPartiallyPaid).
+		Where("due_date >= ? AND due_date <= ?", from, to).
+		Order("due_date ASC").
+		Find(&receivables).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get installments to remind")
+	}
+	return receivables, nil
+}
+
+func (r *accountsReceivableRepository) MarkReminderSent(ctx context.Context, receivableID uuid.UUID, at time.Time) error {
+	err := database.Conn(ctx, r.db).
+		Model(&domain.AccountsReceivable{}).
+		Where("receivable_id = ?", receivableID).
+		Update("reminder_sent_at", at).Error
+
+	if err != nil {
+		return errors.WrapError(err, "failed to mark installment reminder")
+	}
+	return nil
+}
+
+func (r *accountsReceivableRepository) CreateLateFeePolicy(ctx context.Context, policy *domain.LateFeePolicy) error {
+	if err := database.Conn(ctx, r.db).Create(policy).Error; err != nil {
+		return errors.WrapError(err, "failed to create late fee policy")
+	}
+	return nil
+}
+
+func (r *accountsReceivableRepository) FindLateFeePolicyByID(ctx context.Context, id uuid.UUID) (*domain.LateFeePolicy, error) {
+	var policy domain.LateFeePolicy
+	err := database.Conn(ctx, r.db).First(&policy, "policy_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("LateFeePolicy", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find late fee policy")
+	}
+	return &policy, nil
+}
+
+func (r *accountsReceivableRepository) ListLateFeePolicies(ctx context.Context) ([]domain.LateFeePolicy, error) {
+	var policies []domain.LateFeePolicy
+	err := database.Conn(ctx, r.db).
+		Order("currency ASC").
+		Order("created_at DESC").
+		Find(&policies).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to list late fee policies")
+	}
+	return policies, nil
+}
+
+// GetActiveLateFeePolicy returns nil when no policy applies to the currency
+func (r *accountsReceivableRepository) GetActiveLateFeePolicy(ctx context.Context, currency domain.CurrencyCode) (*domain.LateFeePolicy, error) {
+	var policy domain.LateFeePolicy
+	err := database.Conn(ctx, r.db).
+		Where("is_active = ? AND currency = ?", true, currency).
+		Order("created_at DESC").
+		First(&policy).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.WrapError(err, "failed to get active late fee policy")
+	}
+	return &policy, nil
+}
+
+func (r *accountsReceivableRepository) UpdateLateFeePolicy(ctx context.Context, policy *domain.LateFeePolicy) error {
+	policy.UpdatedAt = time.Now()
+	if err := database.Conn(ctx, r.db).Save(policy).Error; err != nil {
+		return errors.WrapError(err, "failed to update late fee policy")
+	}
+	return nil
+}
+
+func (r *accountsReceivableRepository) DeleteLateFeePolicy(ctx context.Context, id uuid.UUID) error {
+	if err := database.Conn(ctx, r.db).Delete(&domain.LateFeePolicy{}, "policy_id = ?", id).Error; err != nil {
+		return errors.WrapError(err, "failed to delete late fee policy")
+	}
+	return nil
+}
+
+// Helper functions
+
+// applyPayment records a payment inside tx and updates the receivable it pays
+func (r *accountsReceivableRepository) applyPayment(tx *gorm.DB, payment *domain.CustomerPayment) error {
+	// Lock the accounts receivable record
+	var receivable domain.AccountsReceivable
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&receivable, "receivable_id = ?", payment.ReceivableID).Error
+	if err != nil {
+		return errors.WrapError(err, "failed to find accounts receivable")
+	}
+
+	if receivable.Status == domain.AccountStatusPaid || receivable.Status == domain.AccountStatusCancelled {
+		return errors.BadRequest(fmt.Sprintf("Receivable %s is %s", receivable.ReceivableID, receivable.Status))
+	}
+
+	if math.Round(payment.Amount*100) > math.Round(receivable.Balance*100) {
+		return errors.BadRequest(fmt.Sprintf(
+			"Payment amount (%.2f) exceeds balance (%.2f)",
+			payment.Amount, receivable.Balance,
+		))
+	}
+
+	// Create payment record
+	if err := tx.Create(payment).Error; err != nil {
+		return errors.WrapError(err, "failed to create payment")
+	}
+
+	// Update paid amount, balance and status
+	receivable.PaidAmount += payment.Amount
+	receivable.RefreshBalance(time.Now())
+
+	// Save updated receivable
+	if err := tx.Save(&receivable).Error; err != nil {
+		return errors.WrapError(err, "failed to update accounts receivable")
+	}
+
+	return nil
+}
+
+// applyAdjustment reduces the receivable of an applied adjustment inside tx
+func (r *accountsReceivableRepository) applyAdjustment(tx *gorm.DB, adjustment *domain.ReceivableAdjustment) error {
+	var receivable domain.AccountsReceivable
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&receivable, "receivable_id = ?", adjustment.ReceivableID).Error
+	if err != nil {
+		return errors.WrapError(err, "failed to lock accounts receivable")
+	}
+
+	if math.Round(adjustment.Amount*100) > math.Round(receivable.Balance*100) {
+		return errors.BadRequest(fmt.Sprintf(
+			"Adjustment amount (%.2f) exceeds balance (%.2f)",
+			adjustment.Amount, receivable.Balance,
+		))
+	}
+
+	switch adjustment.AdjustmentType {
+	case domain.AdjustmentTypeCreditNote:
+		receivable.CreditedAmount += adjustment.Amount
+	case domain.AdjustmentTypeWriteOff:
+		receivable.WrittenOffAmount += adjustment.Amount
+	}
+	receivable.RefreshBalance(time.Now())
+
+	if err := tx.Omit(clause.Associations).Save(&receivable).Error; err != nil {
+		return errors.WrapError(err, "failed to update accounts receivable")
+	}
+
+	return nil
+}
+
+// unapplyPayment undoes a payment inside tx, restoring the balance it paid
+func (r *accountsReceivableRepository) unapplyPayment(tx *gorm.DB, payment *domain.CustomerPayment) error {
+	// Only a live payment can be reversed, even under concurrent voids
+	result := tx.Model(&domain.CustomerPayment{}).
+		Where("payment_id = ? AND reversed_at IS NULL", payment.PaymentID).
+		Updates(map[string]interface{}{
+			"reversed_at": payment.ReversedAt,
+			"reversed_by": payment.ReversedBy,
+			"void_reason": payment.VoidReason,
+		})
+	if result.Error != nil {
+		return errors.WrapError(result.Error, "failed to reverse payment")
+	}
+	if result.RowsAffected == 0 {
+		return errors.Conflict("Payment was already reversed")
+	}
+
+	var receivable domain.AccountsReceivable
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&receivable, "receivable_id = ?", payment.ReceivableID).Error
+	if err != nil {
+		return errors.WrapError(err, "failed to lock accounts receivable")
+	}
+
+	receivable.PaidAmount = math.Max(0, math.Round((receivable.PaidAmount-payment.Amount)*100)/100)
+	receivable.RefreshBalance(time.Now())
+
+	if err := tx.Omit(clause.Associations).Save(&receivable).Error; err != nil {
+		return errors.WrapError(err, "failed to update accounts receivable")
+	}
+
+	return nil
+}
+
+func (r *accountsReceivableRepository) buildFilterQuery(query *gorm.DB, filters repositories.AccountsReceivableFilters) *gorm.DB {
+	if filters.CustomerID != nil {
+		query = query.Where("customer_id = ?", *filters.CustomerID)
+	}
+
+	if filters.StoreID != nil {
+		query = query.Where("sale_id IN (?)", r.db.Model(&domain.Sale{}).
+			Select("sale_id").
+			Where("store_id = ?", *filters.StoreID))
+	}
+
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+
+	if filters.Currency != nil {
+		query = query.Where("currency = ?", *filters.Currency)
+	}
+
+	if filters.Kind != nil {
+		query = query.Where("kind = ?", *filters.Kind)
+	}
+
+	if filters.DueFrom != nil {
+		query = query.Where("due_date >= ?", *filters.DueFrom)
+	}
+
+	if filters.DueTo != nil {
+		query = query.Where("due_date <= ?", *filters.DueTo)
+	}
+
+	return query
+}
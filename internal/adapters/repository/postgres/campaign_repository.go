@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
+	"gorm.io/gorm"
+)
+
+type campaignRepository struct {
+	db *gorm.DB
+}
+
+// NewCampaignRepository creates a new campaign repository
+func NewCampaignRepository(db *gorm.DB) repositories.CampaignRepository {
+	return &campaignRepository{db: db}
+}
+
+func (r *campaignRepository) Create(ctx context.Context, campaign *domain.Campaign) error {
+	if err := database.Conn(ctx, r.db).Create(campaign).Error; err != nil {
+		return errors.WrapError(err, "failed to create campaign")
+	}
+	return nil
+}
+
+func (r *campaignRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Campaign, error) {
+	var campaign domain.Campaign
+	err := database.Conn(ctx, r.db).First(&campaign, "campaign_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("Campaign", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find campaign")
+	}
+	return &campaign, nil
+}
+
+func (r *campaignRepository) List(ctx context.Context, filters repositories.CampaignFilters, limit, offset int) ([]domain.Campaign, int64, error) {
+	var campaigns []domain.Campaign
+	var total int64
+
+	query := r.buildFilterQuery(database.Conn(ctx, r.db).Model(&domain.Campaign{}), filters)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.WrapError(err, "failed to count campaigns")
+	}
+
+	err := query.
+		Order("start_date DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&campaigns).Error
+
+	if err != nil {
+		return nil, 0, errors.WrapError(err, "failed to list campaigns")
+	}
+
+	return campaigns, total, nil
+}
+
+func (r *campaignRepository) GetActive(ctx context.Context, at time.Time) ([]domain.Campaign, error) {
+	var campaigns []domain.Campaign
+	err := database.Conn(ctx, r.db).
+		Where("is_active = ?", true).
+		Where("start_date <= ? AND end_date >= ?", at, at).
+		Where("budget IS NULL OR discount_granted < budget").
+		Order("start_date ASC").
+		Find(&campaigns).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get active campaigns")
+	}
+	return campaigns, nil
+}
+
+func (r *campaignRepository) Update(ctx context.Context, campaign *domain.Campaign) error {
+	if err := database.Conn(ctx, r.db).Save(campaign).Error; err != nil {
+		return errors.WrapError(err, "failed to update campaign")
+	}
+	return nil
+}
+
+func (r *campaignRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := database.Conn(ctx, r.db).Delete(&domain.Campaign{}, "campaign_id = ?", id).Error; err != nil {
+		return errors.WrapError(err, "failed to delete campaign")
+	}
+	return nil
+}
+
+// RecordUsage adds the sale to the campaign totals only while the discount
+// still fits in its budget, so concurrent sales cannot overspend it
+func (r *campaignRepository) RecordUsage(ctx context.Context, id uuid.UUID, salesAmount, discountAmount float64) error {
+	result := database.Conn(ctx, r.db).
+		Model(&domain.Campaign{}).
+		Where("campaign_id = ?", id).
+		Where("budget IS NULL OR discount_granted + ? <= budget", discountAmount).
+		Updates(map[string]interface{}{
+			"actual_sales":     gorm.Expr("actual_sales + ?", salesAmount),
+			"discount_granted": gorm.Expr("discount_granted + ?", discountAmount),
+		})
+
+	if result.Error != nil {
+		return errors.WrapError(result.Error, "failed to record campaign usage")
+	}
+	if result.RowsAffected == 0 {
+		return errors.Conflict("Campaign budget exhausted, price the sale again")
+	}
+	return nil
+}
+
+// Helper functions
+
+func (r *campaignRepository) buildFilterQuery(query *gorm.DB, filters repositories.CampaignFilters) *gorm.DB {
+	if filters.IsActive != nil {
+		query = query.Where("is_active = ?", *filters.IsActive)
+	}
+
+	if filters.PromotionType != nil {
+		query = query.Where("promotion_type = ?", *filters.PromotionType)
+	}
+
+	if filters.ActiveAt != nil {
+		query = query.Where("start_date <= ? AND end_date >= ?", *filters.ActiveAt, *filters.ActiveAt)
+	}
+
+	if filters.Search != "" {
+		query = query.Where("campaign_name ILIKE ?", "%"+filters.Search+"%")
+	}
+
+	return query
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/jadiazinf/inventory/internal/common/errors"
 	"github.com/jadiazinf/inventory/internal/core/domain"
 	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
 	"gorm.io/gorm"
 )
 
@@ -20,7 +21,7 @@ func NewCustomerChildRepository(db *gorm.DB) repositories.CustomerChildRepositor
 }
 
 func (r *customerChildRepository) Create(ctx context.Context, child *domain.CustomerChild) error {
-	if err := r.db.WithContext(ctx).Create(child).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Create(child).Error; err != nil {
 		return errors.WrapError(err, "failed to create customer child")
 	}
 	return nil
@@ -28,7 +29,8 @@ func (r *customerChildRepository) Create(ctx context.Context, child *domain.Cust
 
 func (r *customerChildRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.CustomerChild, error) {
 	var child domain.CustomerChild
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
+		Preload("School").
 		First(&child, "child_id = ?", id).Error
 
 	if err != nil {
@@ -42,7 +44,8 @@ func (r *customerChildRepository) FindByID(ctx context.Context, id uuid.UUID) (*
 
 func (r *customerChildRepository) FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]domain.CustomerChild, error) {
 	var children []domain.CustomerChild
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
+		Preload("School").
 		Where("customer_id = ?", customerID).
 		Order("created_at DESC").
 		Find(&children).Error
@@ -54,14 +57,14 @@ func (r *customerChildRepository) FindByCustomer(ctx context.Context, customerID
 }
 
 func (r *customerChildRepository) Update(ctx context.Context, child *domain.CustomerChild) error {
-	if err := r.db.WithContext(ctx).Save(child).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Save(child).Error; err != nil {
 		return errors.WrapError(err, "failed to update customer child")
 	}
 	return nil
 }
 
 func (r *customerChildRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	if err := r.db.WithContext(ctx).Delete(&domain.CustomerChild{}, "child_id = ?", id).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Delete(&domain.CustomerChild{}, "child_id = ?", id).Error; err != nil {
 		return errors.WrapError(err, "failed to delete customer child")
 	}
 	return nil
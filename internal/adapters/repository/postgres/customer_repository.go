@@ -7,7 +7,9 @@ import (
 	"github.com/jadiazinf/inventory/internal/common/errors"
 	"github.com/jadiazinf/inventory/internal/core/domain"
 	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type customerRepository struct {
@@ -22,7 +24,7 @@ func NewCustomerRepository(db *gorm.DB) repositories.CustomerRepository {
 func (r *customerRepository) Create(ctx context.Context, customer *domain.Customer) error {
 	// Check for duplicate TaxID
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&domain.Customer{}).
+	if err := database.Conn(ctx, r.db).Model(&domain.Customer{}).
 		Where("tax_id = ?", customer.TaxID).Count(&count).Error; err != nil {
 		return errors.WrapError(err, "failed to check tax_id uniqueness")
 	}
@@ -30,7 +32,7 @@ func (r *customerRepository) Create(ctx context.Context, customer *domain.Custom
 		return errors.AlreadyExists("Customer", "tax_id", customer.TaxID)
 	}
 
-	if err := r.db.WithContext(ctx).Create(customer).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Create(customer).Error; err != nil {
 		return errors.WrapError(err, "failed to create customer")
 	}
 	return nil
@@ -38,7 +40,7 @@ func (r *customerRepository) Create(ctx context.Context, customer *domain.Custom
 
 func (r *customerRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Customer, error) {
 	var customer domain.Customer
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Location").
 		Preload("Children").
 		First(&customer, "customer_id = ?", id).Error
@@ -52,9 +54,24 @@ func (r *customerRepository) FindByID(ctx context.Context, id uuid.UUID) (*domai
 	return &customer, nil
 }
 
+func (r *customerRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Customer, error) {
+	var customer domain.Customer
+	err := database.Conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&customer, "customer_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("Customer", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find customer")
+	}
+	return &customer, nil
+}
+
 func (r *customerRepository) FindByTaxID(ctx context.Context, taxID string) (*domain.Customer, error) {
 	var customer domain.Customer
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Location").
 		Where("tax_id = ?", taxID).
 		First(&customer).Error
@@ -70,7 +87,7 @@ func (r *customerRepository) FindByTaxID(ctx context.Context, taxID string) (*do
 
 func (r *customerRepository) FindByEmail(ctx context.Context, email string) (*domain.Customer, error) {
 	var customer domain.Customer
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Location").
 		Where("email = ?", email).
 		First(&customer).Error
@@ -86,7 +103,7 @@ func (r *customerRepository) FindByEmail(ctx context.Context, email string) (*do
 
 func (r *customerRepository) FindByFirebaseUID(ctx context.Context, firebaseUID string) (*domain.Customer, error) {
 	var customer domain.Customer
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Location").
 		Where("firebase_uid = ?", firebaseUID).
 		First(&customer).Error
@@ -104,7 +121,7 @@ func (r *customerRepository) List(ctx context.Context, filters repositories.Cust
 	var customers []domain.Customer
 	var total int64
 
-	query := r.buildFilterQuery(r.db.WithContext(ctx).Model(&domain.Customer{}), filters)
+	query := r.buildFilterQuery(database.Conn(ctx, r.db).Model(&domain.Customer{}), filters)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, errors.WrapError(err, "failed to count customers")
@@ -125,14 +142,14 @@ func (r *customerRepository) List(ctx context.Context, filters repositories.Cust
 }
 
 func (r *customerRepository) Update(ctx context.Context, customer *domain.Customer) error {
-	if err := r.db.WithContext(ctx).Save(customer).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Save(customer).Error; err != nil {
 		return errors.WrapError(err, "failed to update customer")
 	}
 	return nil
 }
 
 func (r *customerRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	if err := r.db.WithContext(ctx).Delete(&domain.Customer{}, "customer_id = ?", id).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Delete(&domain.Customer{}, "customer_id = ?", id).Error; err != nil {
 		return errors.WrapError(err, "failed to delete customer")
 	}
 	return nil
@@ -140,7 +157,7 @@ func (r *customerRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 func (r *customerRepository) GetWithChildren(ctx context.Context, id uuid.UUID) (*domain.Customer, error) {
 	var customer domain.Customer
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Location").
 		Preload("Children").
 		First(&customer, "customer_id = ?", id).Error
@@ -155,7 +172,7 @@ func (r *customerRepository) GetWithChildren(ctx context.Context, id uuid.UUID)
 }
 
 func (r *customerRepository) UpdateLoyaltyPoints(ctx context.Context, customerID uuid.UUID, points int) error {
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Model(&domain.Customer{}).
 		Where("customer_id = ?", customerID).
 		Update("loyalty_points", gorm.Expr("loyalty_points + ?", points)).Error
@@ -166,6 +183,18 @@ func (r *customerRepository) UpdateLoyaltyPoints(ctx context.Context, customerID
 	return nil
 }
 
+func (r *customerRepository) UpdateTotalPurchases(ctx context.Context, customerID uuid.UUID, amount float64) error {
+	err := database.Conn(ctx, r.db).
+		Model(&domain.Customer{}).
+		Where("customer_id = ?", customerID).
+		Update("total_purchases", gorm.Expr("GREATEST(total_purchases + ?, 0)", amount)).Error
+
+	if err != nil {
+		return errors.WrapError(err, "failed to update total purchases")
+	}
+	return nil
+}
+
 func (r *customerRepository) buildFilterQuery(query *gorm.DB, filters repositories.CustomerFilters) *gorm.DB {
 	if filters.Status != nil {
 		query = query.Where("status = ?", *filters.Status)
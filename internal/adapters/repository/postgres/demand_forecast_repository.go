@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type demandForecastRepository struct {
+	db *gorm.DB
+}
+
+// NewDemandForecastRepository creates a new demand forecast repository
+func NewDemandForecastRepository(db *gorm.DB) repositories.DemandForecastRepository {
+	return &demandForecastRepository{db: db}
+}
+
+func (r *demandForecastRepository) CreateBatch(ctx context.Context, forecasts []domain.DemandForecast) error {
+	if len(forecasts) == 0 {
+		return nil
+	}
+	if err := database.Conn(ctx, r.db).Omit(clause.Associations).Create(&forecasts).Error; err != nil {
+		return errors.WrapError(err, "failed to create demand forecasts")
+	}
+	return nil
+}
+
+func (r *demandForecastRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.DemandForecast, error) {
+	var forecast domain.DemandForecast
+	err := database.Conn(ctx, r.db).
+		Preload("Product").
+		First(&forecast, "forecast_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("DemandForecast", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find demand forecast")
+	}
+	return &forecast, nil
+}
+
+func (r *demandForecastRepository) List(ctx context.Context, filters repositories.DemandForecastFilters, limit, offset int) ([]domain.DemandForecast, int64, error) {
+	var forecasts []domain.DemandForecast
+	var total int64
+
+	query := r.buildFilterQuery(database.Conn(ctx, r.db).Model(&domain.DemandForecast{}), filters)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.WrapError(err, "failed to count demand forecasts")
+	}
+
+	err := query.
+		Preload("Product").
+		Limit(limit).
+		Offset(offset).
+		Order("created_at DESC").
+		Find(&forecasts).Error
+
+	if err != nil {
+		return nil, 0, errors.WrapError(err, "failed to list demand forecasts")
+	}
+
+	return forecasts, total, nil
+}
+
+func (r *demandForecastRepository) Update(ctx context.Context, forecast *domain.DemandForecast) error {
+	if err := database.Conn(ctx, r.db).Omit(clause.Associations).Save(forecast).Error; err != nil {
+		return errors.WrapError(err, "failed to update demand forecast")
+	}
+	return nil
+}
+
+func (r *demandForecastRepository) GetLatestBySchoolYear(ctx context.Context, schoolYear string) ([]domain.DemandForecast, error) {
+	var forecasts []domain.DemandForecast
+	err := database.Conn(ctx, r.db).
+		Preload("Product").
+		Where("school_year = ?", schoolYear).
+		Where("created_at = (SELECT MAX(f.created_at) FROM demand_forecasts f WHERE f.product_id = demand_forecasts.product_id AND f.school_year = demand_forecasts.school_year)").
+		Order("created_at ASC").
+		Find(&forecasts).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get latest demand forecasts")
+	}
+
+	return forecasts, nil
+}
+
+func (r *demandForecastRepository) GetQuantitiesSold(ctx context.Context, from, to time.Time, productIDs []uuid.UUID) (map[uuid.UUID]float64, error) {
+	var rows []productQuantity
+
+	query := database.Conn(ctx, r.db).
+		Table("sale_details").
+		Select("sale_details.product_id, COALESCE(SUM(sale_details.quantity), 0) AS quantity").
+		Joins("INNER JOIN sales ON sales.sale_id = sale_details.sale_id").
+		Where("sales.status = ?", domain.SaleStatusCompleted).
+		Where("sales.sale_date >= ? AND sales.sale_date <= ?", from, to)
+
+	if len(productIDs) > 0 {
+		query = query.Where("sale_details.product_id IN ?", productIDs)
+	}
+
+	if err := query.Group("sale_details.product_id").Scan(&rows).Error; err != nil {
+		return nil, errors.WrapError(err, "failed to sum quantities sold")
+	}
+
+	return toQuantityMap(rows), nil
+}
+
+func (r *demandForecastRepository) GetOpenReservedQuantities(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]float64, error) {
+	var rows []productQuantity
+
+	query := database.Conn(ctx, r.db).
+		Table("reservation_items").
+		Select("reservation_items.product_id, COALESCE(SUM(reservation_items.quantity - reservation_items.fulfilled_quantity), 0) AS quantity").
+		Joins("INNER JOIN reservations ON reservations.reservation_id = reservation_items.reservation_id").
+		Where("reservations.status IN ?", []domain.ReservationStatus{
+			domain.ReservationStatusPending,
+			domain.ReservationStatusConfirmed,
+			domain.ReservationStatusPartiallyFulfilled,
+		})
+
+	if len(productIDs) > 0 {
+		query = query.Where("reservation_items.product_id IN ?", productIDs)
+	}
+
+	if err := query.Group("reservation_items.product_id").Scan(&rows).Error; err != nil {
+		return nil, errors.WrapError(err, "failed to sum reserved quantities")
+	}
+
+	return toQuantityMap(rows), nil
+}
+
+// Helper functions
+
+// productQuantity is a scan target for per-product aggregates
+type productQuantity struct {
+	ProductID uuid.UUID
+	Quantity  float64
+}
+
+func toQuantityMap(rows []productQuantity) map[uuid.UUID]float64 {
+	quantities := make(map[uuid.UUID]float64, len(rows))
+	for _, row := range rows {
+		quantities[row.ProductID] = row.Quantity
+	}
+	return quantities
+}
+
+func (r *demandForecastRepository) buildFilterQuery(query *gorm.DB, filters repositories.DemandForecastFilters) *gorm.DB {
+	if filters.ProductID != nil {
+		query = query.Where("product_id = ?", *filters.ProductID)
+	}
+
+	if filters.SchoolYear != nil {
+		query = query.Where("school_year = ?", *filters.SchoolYear)
+	}
+
+	if filters.Method != nil {
+		query = query.Where("forecast_method = ?", string(*filters.Method))
+	}
+
+	if filters.Evaluated != nil {
+		if *filters.Evaluated {
+			query = query.Where("evaluated_at IS NOT NULL")
+		} else {
+			query = query.Where("evaluated_at IS NULL")
+		}
+	}
+
+	return query
+}
@@ -0,0 +1,313 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type employeeRepository struct {
+	db *gorm.DB
+}
+
+// NewEmployeeRepository creates a new employee repository
+func NewEmployeeRepository(db *gorm.DB) repositories.EmployeeRepository {
+	return &employeeRepository{db: db}
+}
+
+func (r *employeeRepository) FindStoreByID(ctx context.Context, id uuid.UUID) (*domain.Store, error) {
+	var store domain.Store
+	err := database.Conn(ctx, r.db).First(&store, "store_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("Store", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find store")
+	}
+	return &store, nil
+}
+
+func (r *employeeRepository) Create(ctx context.Context, employee *domain.Employee) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(employee).Error; err != nil {
+			return errors.WrapError(err, "failed to create employee")
+		}
+
+		if employee.StoreID != nil {
+			assignment := &domain.EmployeeStoreAssignment{
+				AssignmentID: uuid.New(),
+				EmployeeID:   employee.EmployeeID,
+				StoreID:      *employee.StoreID,
+				StartDate:    employee.HireDate,
+				AssignedBy:   employee.CreatedBy,
+			}
+			if err := tx.Omit(clause.Associations).Create(assignment).Error; err != nil {
+				return errors.WrapError(err, "failed to create employee store assignment")
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *employeeRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Employee, error) {
+	var employee domain.Employee
+	err := database.Conn(ctx, r.db).
+		Preload("Store").
+		Preload("Location").
+		Preload("User").
+		First(&employee, "employee_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("Employee", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find employee")
+	}
+	return &employee, nil
+}
+
+func (r *employeeRepository) FindByNationalID(ctx context.Context, nationalID string) (*domain.Employee, error) {
+	var employee domain.Employee
+	err := database.Conn(ctx, r.db).
+		Where("national_id = ?", nationalID).
+		First(&employee).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("Employee")
+		}
+		return nil, errors.WrapError(err, "failed to find employee by national id")
+	}
+	return &employee, nil
+}
+
+func (r *employeeRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*domain.Employee, error) {
+	var employee domain.Employee
+	err := database.Conn(ctx, r.db).
+		Where("user_id = ?", userID).
+		First(&employee).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("Employee")
+		}
+		return nil, errors.WrapError(err, "failed to find employee by user id")
+	}
+	return &employee, nil
+}
+
+func (r *employeeRepository) List(ctx context.Context, filters repositories.EmployeeFilters, limit, offset int) ([]domain.Employee, int64, error) {
+	var employees []domain.Employee
+	var total int64
+
+	query := database.Conn(ctx, r.db).Model(&domain.Employee{})
+	query = r.buildFilterQuery(query, filters)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.WrapError(err, "failed to count employees")
+	}
+
+	err := query.
+		Preload("Store").
+		Order("last_name ASC").
+		Order("first_name ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&employees).Error
+
+	if err != nil {
+		return nil, 0, errors.WrapError(err, "failed to list employees")
+	}
+
+	return employees, total, nil
+}
+
+func (r *employeeRepository) Update(ctx context.Context, employee *domain.Employee) error {
+	employee.UpdatedAt = time.Now()
+	err := database.Conn(ctx, r.db).
+		Omit(clause.Associations, "store_id", "status", "termination_date", "termination_reason").
+		Save(employee).Error
+	if err != nil {
+		return errors.WrapError(err, "failed to update employee")
+	}
+	return nil
+}
+
+func (r *employeeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := database.Conn(ctx, r.db).Delete(&domain.Employee{}, "employee_id = ?", id).Error; err != nil {
+		return errors.WrapError(err, "failed to delete employee")
+	}
+	return nil
+}
+
+func (r *employeeRepository) ChangeStatus(ctx context.Context, employee *domain.Employee, from domain.EmployeeStatus) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if _, err := r.lockEmployee(tx, employee.EmployeeID, from); err != nil {
+			return err
+		}
+
+		err := tx.Model(&domain.Employee{}).
+			Where("employee_id = ?", employee.EmployeeID).
+			Updates(map[string]interface{}{
+				"status":             employee.Status,
+				"hire_date":          employee.HireDate,
+				"termination_date":   employee.TerminationDate,
+				"termination_reason": employee.TerminationReason,
+				"updated_by":         employee.UpdatedBy,
+				"updated_at":         time.Now(),
+			}).Error
+		if err != nil {
+			return errors.WrapError(err, "failed to update employee status")
+		}
+
+		switch {
+		case employee.Status == domain.EmployeeStatusTerminated:
+			if err := r.closeOpenAssignment(tx, employee.EmployeeID, *employee.TerminationDate); err != nil {
+				return err
+			}
+
+			if employee.UserID != nil {
+				err := tx.Model(&domain.User{}).
+					Where("user_id = ?", *employee.UserID).
+					Updates(map[string]interface{}{
+						"status":     domain.UserStatusInactive,
+						"updated_by": employee.UpdatedBy,
+						"updated_at": time.Now(),
+					}).Error
+				if err != nil {
+					return errors.WrapError(err, "failed to deactivate employee user")
+				}
+			}
+
+		case from == domain.EmployeeStatusTerminated && employee.StoreID != nil:
+			assignment := &domain.EmployeeStoreAssignment{
+				AssignmentID: uuid.New(),
+				EmployeeID:   employee.EmployeeID,
+				StoreID:      *employee.StoreID,
+				StartDate:    employee.HireDate,
+				Reason:       stringPtr("Rehired"),
+				AssignedBy:   employee.UpdatedBy,
+			}
+			if err := tx.Omit(clause.Associations).Create(assignment).Error; err != nil {
+				return errors.WrapError(err, "failed to create employee store assignment")
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *employeeRepository) AssignStore(ctx context.Context, assignment *domain.EmployeeStoreAssignment) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		current, err := r.lockEmployee(tx, assignment.EmployeeID, "")
+		if err != nil {
+			return err
+		}
+
+		if current.Status == domain.EmployeeStatusTerminated {
+			return errors.Conflict("Terminated employees cannot be assigned to a store")
+		}
+
+		if err := r.closeOpenAssignment(tx, assignment.EmployeeID, assignment.StartDate); err != nil {
+			return err
+		}
+
+		if err := tx.Omit(clause.Associations).Create(assignment).Error; err != nil {
+			return errors.WrapError(err, "failed to create employee store assignment")
+		}
+
+		err = tx.Model(&domain.Employee{}).
+			Where("employee_id = ?", assignment.EmployeeID).
+			Updates(map[string]interface{}{
+				"store_id":   assignment.StoreID,
+				"updated_by": assignment.AssignedBy,
+				"updated_at": time.Now(),
+			}).Error
+		if err != nil {
+			return errors.WrapError(err, "failed to update employee store")
+		}
+
+		return nil
+	})
+}
+
+func (r *employeeRepository) GetStoreHistory(ctx context.Context, employeeID uuid.UUID) ([]domain.EmployeeStoreAssignment, error) {
+	var assignments []domain.EmployeeStoreAssignment
+	err := database.Conn(ctx, r.db).
+		Preload("Store").
+		Where("employee_id = ?", employeeID).
+		Order("start_date DESC").
+		Order("created_at DESC").
+		Find(&assignments).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get employee store history")
+	}
+	return assignments, nil
+}
+
+// Helper functions
+
+// lockEmployee locks the employee record so concurrent changes are
+// serialized, checking it is still in the expected status when one is given
+func (r *employeeRepository) lockEmployee(tx *gorm.DB, id uuid.UUID, status domain.EmployeeStatus) (*domain.Employee, error) {
+	var current domain.Employee
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&current, "employee_id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("Employee", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find employee")
+	}
+
+	if status != "" && current.Status != status {
+		return nil, errors.Conflict(fmt.Sprintf("Employee is already %s", current.Status))
+	}
+	return &current, nil
+}
+
+// closeOpenAssignment ends the current store assignment of the employee
+func (r *employeeRepository) closeOpenAssignment(tx *gorm.DB, employeeID uuid.UUID, endDate time.Time) error {
+	err := tx.Model(&domain.EmployeeStoreAssignment{}).
+		Where("employee_id = ? AND end_date IS NULL", employeeID).
+		Update("end_date", endDate).Error
+	if err != nil {
+		return errors.WrapError(err, "failed to close employee store assignment")
+	}
+	return nil
+}
+
+func (r *employeeRepository) buildFilterQuery(query *gorm.DB, filters repositories.EmployeeFilters) *gorm.DB {
+	if filters.StoreID != nil {
+		query = query.Where("store_id = ?", *filters.StoreID)
+	}
+
+	if filters.Department != "" {
+		query = query.Where("LOWER(department) = LOWER(?)", filters.Department)
+	}
+
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+
+	if filters.Search != "" {
+		search := "%" + filters.Search + "%"
+		query = query.Where(
+			"first_name ILIKE ? OR last_name ILIKE ? OR national_id ILIKE ? OR email ILIKE ?",
+			search, search, search, search,
+		)
+	}
+
+	return query
+}
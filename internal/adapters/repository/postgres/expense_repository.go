@@ -0,0 +1,263 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type expenseRepository struct {
+	db *gorm.DB
+}
+
+// NewExpenseRepository creates a new expense repository
+func NewExpenseRepository(db *gorm.DB) repositories.ExpenseRepository {
+	return &expenseRepository{db: db}
+}
+
+func (r *expenseRepository) FindStoreByID(ctx context.Context, id uuid.UUID) (*domain.Store, error) {
+	var store domain.Store
+	err := database.Conn(ctx, r.db).First(&store, "store_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("Store", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find store")
+	}
+	return &store, nil
+}
+
+func (r *expenseRepository) FindSupplierByID(ctx context.Context, id uuid.UUID) (*domain.Supplier, error) {
+	var supplier domain.Supplier
+	err := database.Conn(ctx, r.db).First(&supplier, "supplier_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("Supplier", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find supplier")
+	}
+	return &supplier, nil
+}
+
+func (r *expenseRepository) CreateExpense(ctx context.Context, expense *domain.Expense) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(expense).Error; err != nil {
+			return errors.WrapError(err, "failed to create expense")
+		}
+
+		if len(expense.Approvals) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&expense.Approvals).Error; err != nil {
+				return errors.WrapError(err, "failed to create expense approval chain")
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *expenseRepository) FindExpenseByID(ctx context.Context, id uuid.UUID) (*domain.Expense, error) {
+	var expense domain.Expense
+	err := database.Conn(ctx, r.db).
+		Preload("Store").
+		Preload("Supplier").
+		Preload("Requester").
+		Preload("Approvals", func(db *gorm.DB) *gorm.DB {
+			return db.Order("level ASC")
+		}).
+		Preload("Approvals.Approver").
+		First(&expense, "expense_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("Expense", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find expense")
+	}
+	return &expense, nil
+}
+
+func (r *expenseRepository) ListExpenses(ctx context.Context, filters repositories.ExpenseFilters, limit, offset int) ([]domain.Expense, int64, error) {
+	var expenses []domain.Expense
+	var total int64
+
+	query := database.Conn(ctx, r.db).Model(&domain.Expense{})
+	query = r.buildFilterQuery(query, filters)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.WrapError(err, "failed to count expenses")
+	}
+
+	err := query.
+		Preload("Store").
+		Preload("Supplier").
+		Preload("Approvals", func(db *gorm.DB) *gorm.DB {
+			return db.Order("level ASC")
+		}).
+		Order("expense_date DESC").
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&expenses).Error
+
+	if err != nil {
+		return nil, 0, errors.WrapError(err, "failed to list expenses")
+	}
+
+	return expenses, total, nil
+}
+
+func (r *expenseRepository) GetExpenses(ctx context.Context, filters repositories.ExpenseFilters) ([]domain.Expense, error) {
+	var expenses []domain.Expense
+
+	query := database.Conn(ctx, r.db).Preload("Store")
+	query = r.buildFilterQuery(query, filters)
+
+	if err := query.Order("expense_date ASC").Find(&expenses).Error; err != nil {
+		return nil, errors.WrapError(err, "failed to get expenses")
+	}
+	return expenses, nil
+}
+
+func (r *expenseRepository) RecordDecision(ctx context.Context, expense *domain.Expense, approval *domain.ExpenseApproval, audit *domain.AuditLog) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Lock the expense record so concurrent decisions are serialized
+		var current domain.Expense
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&current, "expense_id = ?", expense.ExpenseID).Error
+		if err != nil {
+			return errors.WrapError(err, "failed to find expense")
+		}
+
+		if current.Status != domain.ExpenseApprovalStatusPending {
+			return errors.Conflict(fmt.Sprintf("Expense is already %s", current.Status))
+		}
+
+		result := tx.Model(&domain.ExpenseApproval{}).
+			Where("approval_id = ? AND status = ?", approval.ApprovalID, domain.ExpenseApprovalStatusPending).
+			Updates(map[string]interface{}{
+				"status":      approval.Status,
+				"approver_id": approval.ApproverID,
+				"comments":    approval.Comments,
+				"decided_at":  approval.DecidedAt,
+			})
+		if result.Error != nil {
+			return errors.WrapError(result.Error, "failed to update expense approval")
+		}
+		if result.RowsAffected == 0 {
+			return errors.Conflict(fmt.Sprintf("Level %d of the expense was already decided", approval.Level))
+		}
+
+		expense.UpdatedAt = time.Now()
+		if err := tx.Omit(clause.Associations).Save(expense).Error; err != nil {
+			return errors.WrapError(err, "failed to update expense")
+		}
+
+		if err := tx.Create(audit).Error; err != nil {
+			return errors.WrapError(err, "failed to create audit log")
+		}
+
+		return nil
+	})
+}
+
+func (r *expenseRepository) CreateApprovalRule(ctx context.Context, rule *domain.ExpenseApprovalRule) error {
+	if err := database.Conn(ctx, r.db).Create(rule).Error; err != nil {
+		return errors.WrapError(err, "failed to create expense approval rule")
+	}
+	return nil
+}
+
+func (r *expenseRepository) FindApprovalRuleByID(ctx context.Context, id uuid.UUID) (*domain.ExpenseApprovalRule, error) {
+	var rule domain.ExpenseApprovalRule
+	err := database.Conn(ctx, r.db).First(&rule, "rule_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("ExpenseApprovalRule", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find expense approval rule")
+	}
+	return &rule, nil
+}
+
+func (r *expenseRepository) ListApprovalRules(ctx context.Context) ([]domain.ExpenseApprovalRule, error) {
+	var rules []domain.ExpenseApprovalRule
+	err := database.Conn(ctx, r.db).
+		Order("level ASC").
+		Order("min_amount ASC").
+		Find(&rules).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to list expense approval rules")
+	}
+	return rules, nil
+}
+
+func (r *expenseRepository) GetActiveApprovalRules(ctx context.Context, storeID uuid.UUID) ([]domain.ExpenseApprovalRule, error) {
+	var rules []domain.ExpenseApprovalRule
+	err := database.Conn(ctx, r.db).
+		Where("is_active = ?", true).
+		Where("store_id IS NULL OR store_id = ?", storeID).
+		Order("level ASC").
+		Find(&rules).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get active expense approval rules")
+	}
+	return rules, nil
+}
+
+func (r *expenseRepository) UpdateApprovalRule(ctx context.Context, rule *domain.ExpenseApprovalRule) error {
+	rule.UpdatedAt = time.Now()
+	if err := database.Conn(ctx, r.db).Save(rule).Error; err != nil {
+		return errors.WrapError(err, "failed to update expense approval rule")
+	}
+	return nil
+}
+
+func (r *expenseRepository) DeleteApprovalRule(ctx context.Context, id uuid.UUID) error {
+	if err := database.Conn(ctx, r.db).Delete(&domain.ExpenseApprovalRule{}, "rule_id = ?", id).Error; err != nil {
+		return errors.WrapError(err, "failed to delete expense approval rule")
+	}
+	return nil
+}
+
+// Helper functions
+
+func (r *expenseRepository) buildFilterQuery(query *gorm.DB, filters repositories.ExpenseFilters) *gorm.DB {
+	if filters.StoreID != nil {
+		query = query.Where("store_id = ?", *filters.StoreID)
+	}
+
+	if filters.Category != nil {
+		query = query.Where("category = ?", *filters.Category)
+	}
+
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+
+	if filters.SupplierID != nil {
+		query = query.Where("supplier_id = ?", *filters.SupplierID)
+	}
+
+	if filters.DateFrom != nil {
+		query = query.Where("expense_date >= ?", *filters.DateFrom)
+	}
+
+	if filters.DateTo != nil {
+		query = query.Where("expense_date <= ?", *filters.DateTo)
+	}
+
+	return query
+}
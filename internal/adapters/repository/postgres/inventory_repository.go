@@ -7,6 +7,7 @@ import (
 	"github.com/jadiazinf/inventory/internal/common/errors"
 	"github.com/jadiazinf/inventory/internal/core/domain"
 	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
 	"gorm.io/gorm"
 )
 
@@ -21,7 +22,7 @@ func NewInventoryRepository(db *gorm.DB) repositories.InventoryRepository {
 
 func (r *inventoryRepository) GetByProductAndWarehouse(ctx context.Context, productID, warehouseID uuid.UUID) (*domain.Inventory, error) {
 	var inventory domain.Inventory
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Product").
 		Preload("Warehouse").
 		Where("product_id = ? AND warehouse_id = ?", productID, warehouseID).
@@ -38,7 +39,7 @@ func (r *inventoryRepository) GetByProductAndWarehouse(ctx context.Context, prod
 
 func (r *inventoryRepository) GetByWarehouse(ctx context.Context, warehouseID uuid.UUID) ([]domain.Inventory, error) {
 	var inventories []domain.Inventory
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Product").
 		Preload("Product.Category").
 		Preload("Warehouse").
@@ -53,7 +54,7 @@ func (r *inventoryRepository) GetByWarehouse(ctx context.Context, warehouseID uu
 
 func (r *inventoryRepository) GetByProduct(ctx context.Context, productID uuid.UUID) ([]domain.Inventory, error) {
 	var inventories []domain.Inventory
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Product").
 		Preload("Warehouse").
 		Where("product_id = ?", productID).
@@ -66,7 +67,7 @@ func (r *inventoryRepository) GetByProduct(ctx context.Context, productID uuid.U
 }
 
 func (r *inventoryRepository) Update(ctx context.Context, inventory *domain.Inventory) error {
-	if err := r.db.WithContext(ctx).Save(inventory).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Save(inventory).Error; err != nil {
 		return errors.WrapError(err, "failed to update inventory")
 	}
 	return nil
@@ -74,7 +75,7 @@ func (r *inventoryRepository) Update(ctx context.Context, inventory *domain.Inve
 
 func (r *inventoryRepository) CreateMovement(ctx context.Context, movement *domain.InventoryMovement) error {
 	// Create movement - trigger will automatically update inventory table
-	if err := r.db.WithContext(ctx).Create(movement).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Create(movement).Error; err != nil {
 		return errors.WrapError(err, "failed to create inventory movement")
 	}
 	return nil
@@ -84,7 +85,7 @@ func (r *inventoryRepository) GetMovements(ctx context.Context, productID uuid.U
 	var movements []domain.InventoryMovement
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&domain.InventoryMovement{}).
+	query := database.Conn(ctx, r.db).Model(&domain.InventoryMovement{}).
 		Where("product_id = ?", productID)
 
 	if err := query.Count(&total).Error; err != nil {
@@ -110,7 +111,7 @@ func (r *inventoryRepository) GetMovementsByWarehouse(ctx context.Context, wareh
 	var movements []domain.InventoryMovement
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&domain.InventoryMovement{}).
+	query := database.Conn(ctx, r.db).Model(&domain.InventoryMovement{}).
 		Where("warehouse_id = ?", warehouseID)
 
 	if err := query.Count(&total).Error; err != nil {
@@ -134,7 +135,7 @@ func (r *inventoryRepository) GetMovementsByWarehouse(ctx context.Context, wareh
 
 func (r *inventoryRepository) CheckAvailability(ctx context.Context, productID, warehouseID uuid.UUID, quantity float64) (bool, error) {
 	var inventory domain.Inventory
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Where("product_id = ? AND warehouse_id = ?", productID, warehouseID).
 		First(&inventory).Error
 
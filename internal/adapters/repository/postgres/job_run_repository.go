@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"hash/fnv"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type jobRunRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRunRepository creates a new job run repository
+func NewJobRunRepository(db *gorm.DB) repositories.JobRunRepository {
+	return &jobRunRepository{db: db}
+}
+
+func (r *jobRunRepository) Create(ctx context.Context, run *domain.JobRun) error {
+	if err := database.Conn(ctx, r.db).Create(run).Error; err != nil {
+		return errors.WrapError(err, "failed to create job run")
+	}
+	return nil
+}
+
+func (r *jobRunRepository) ClaimSlot(ctx context.Context, run *domain.JobRun) (bool, error) {
+	// The unique (job_name, scheduled_for) index turns the insert into the claim
+	result := database.Conn(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(run)
+
+	if result.Error != nil {
+		return false, errors.WrapError(result.Error, "failed to claim job slot")
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *jobRunRepository) Update(ctx context.Context, run *domain.JobRun) error {
+	if err := database.Conn(ctx, r.db).Save(run).Error; err != nil {
+		return errors.WrapError(err, "failed to update job run")
+	}
+	return nil
+}
+
+func (r *jobRunRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.JobRun, error) {
+	var run domain.JobRun
+	err := database.Conn(ctx, r.db).First(&run, "run_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("JobRun", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find job run")
+	}
+	return &run, nil
+}
+
+func (r *jobRunRepository) List(ctx context.Context, filters repositories.JobRunFilters, limit, offset int) ([]domain.JobRun, int64, error) {
+	var runs []domain.JobRun
+	var total int64
+
+	query := r.buildFilterQuery(database.Conn(ctx, r.db).Model(&domain.JobRun{}), filters)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.WrapError(err, "failed to count job runs")
+	}
+
+	err := query.
+		Limit(limit).
+		Offset(offset).
+		Order("started_at DESC").
+		Find(&runs).Error
+
+	if err != nil {
+		return nil, 0, errors.WrapError(err, "failed to list job runs")
+	}
+
+	return runs, total, nil
+}
+
+func (r *jobRunRepository) GetLastRun(ctx context.Context, jobName string) (*domain.JobRun, error) {
+	var run domain.JobRun
+	err := database.Conn(ctx, r.db).
+		Where("job_name = ?", jobName).
+		Order("started_at DESC").
+		First(&run).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("JobRun")
+		}
+		return nil, errors.WrapError(err, "failed to get last job run")
+	}
+	return &run, nil
+}
+
+// AcquireLock takes a session-level advisory lock on a dedicated connection.
+// The lock lives as long as that connection, so the connection is held until
+// release is called and dropped from the pool if the unlock fails.
+func (r *jobRunRepository) AcquireLock(ctx context.Context, jobName string) (func(), bool, error) {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return nil, false, errors.WrapError(err, "failed to get database handle")
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, false, errors.WrapError(err, "failed to get database connection")
+	}
+
+	key := jobLockKey(jobName)
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, false, errors.WrapError(err, "failed to acquire job lock")
+	}
+
+	if !acquired {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	release := func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key); err != nil {
+			log.Printf("[ERROR] Failed to release lock of job %s: %v", jobName, err)
+			// Discard the connection so the session, and its lock, ends
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+		conn.Close()
+	}
+
+	return release, true, nil
+}
+
+// Helper functions
+
+// jobLockKey maps a job name to the bigint key of its advisory lock
+func jobLockKey(jobName string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("inventory-job:" + jobName))
+	return int64(h.Sum64())
+}
+
+func (r *jobRunRepository) buildFilterQuery(query *gorm.DB, filters repositories.JobRunFilters) *gorm.DB {
+	if filters.JobName != nil {
+		query = query.Where("job_name = ?", *filters.JobName)
+	}
+
+	if filters.Status != nil {
+		query = query.Where("status = ?", string(*filters.Status))
+	}
+
+	return query
+}
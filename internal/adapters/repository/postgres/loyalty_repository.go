@@ -0,0 +1,299 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type loyaltyRepository struct {
+	db *gorm.DB
+}
+
+// NewLoyaltyRepository creates a new loyalty repository
+func NewLoyaltyRepository(db *gorm.DB) repositories.LoyaltyRepository {
+	return &loyaltyRepository{db: db}
+}
+
+func (r *loyaltyRepository) CreateRule(ctx context.Context, rule *domain.LoyaltyRule) error {
+	if err := database.Conn(ctx, r.db).Create(rule).Error; err != nil {
+		return errors.WrapError(err, "failed to create loyalty rule")
+	}
+	return nil
+}
+
+func (r *loyaltyRepository) FindRuleByID(ctx context.Context, id uuid.UUID) (*domain.LoyaltyRule, error) {
+	var rule domain.LoyaltyRule
+	err := database.Conn(ctx, r.db).
+		Preload("Category").
+		First(&rule, "rule_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("LoyaltyRule", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find loyalty rule")
+	}
+	return &rule, nil
+}
+
+func (r *loyaltyRepository) ListRules(ctx context.Context, filters repositories.LoyaltyRuleFilters, limit, offset int) ([]domain.LoyaltyRule, int64, error) {
+	var rules []domain.LoyaltyRule
+	var total int64
+
+	query := r.buildRuleFilterQuery(database.Conn(ctx, r.db).Model(&domain.LoyaltyRule{}), filters)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.WrapError(err, "failed to count loyalty rules")
+	}
+
+	err := query.
+		Preload("Category").
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&rules).Error
+
+	if err != nil {
+		return nil, 0, errors.WrapError(err, "failed to list loyalty rules")
+	}
+
+	return rules, total, nil
+}
+
+func (r *loyaltyRepository) GetActiveRules(ctx context.Context, currency domain.CurrencyCode) ([]domain.LoyaltyRule, error) {
+	var rules []domain.LoyaltyRule
+	err := database.Conn(ctx, r.db).
+		Where("is_active = ? AND currency = ?", true, currency).
+		Order("created_at ASC").
+		Find(&rules).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get active loyalty rules")
+	}
+	return rules, nil
+}
+
+func (r *loyaltyRepository) UpdateRule(ctx context.Context, rule *domain.LoyaltyRule) error {
+	rule.UpdatedAt = time.Now()
+	if err := database.Conn(ctx, r.db).Omit("Category").Save(rule).Error; err != nil {
+		return errors.WrapError(err, "failed to update loyalty rule")
+	}
+	return nil
+}
+
+func (r *loyaltyRepository) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	if err := database.Conn(ctx, r.db).Delete(&domain.LoyaltyRule{}, "rule_id = ?", id).Error; err != nil {
+		return errors.WrapError(err, "failed to delete loyalty rule")
+	}
+	return nil
+}
+
+func (r *loyaltyRepository) CreateTransaction(ctx context.Context, txn *domain.LoyaltyTransaction) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return r.appendTransaction(tx, txn)
+	})
+}
+
+func (r *loyaltyRepository) CreateRedemption(ctx context.Context, txn *domain.LoyaltyTransaction, tender *domain.SaleTender) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := r.appendTransaction(tx, txn); err != nil {
+			return err
+		}
+
+		if err := tx.Create(tender).Error; err != nil {
+			return errors.WrapError(err, "failed to create sale tender")
+		}
+		return nil
+	})
+}
+
+func (r *loyaltyRepository) FindTransactionsBySale(ctx context.Context, saleID uuid.UUID) ([]domain.LoyaltyTransaction, error) {
+	var txns []domain.LoyaltyTransaction
+	err := database.Conn(ctx, r.db).
+		Where("sale_id = ?", saleID).
+		Order("created_at ASC").
+		Find(&txns).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find loyalty transactions by sale")
+	}
+	return txns, nil
+}
+
+func (r *loyaltyRepository) ListTransactions(ctx context.Context, customerID uuid.UUID, from, to *time.Time) ([]domain.LoyaltyTransaction, error) {
+	var txns []domain.LoyaltyTransaction
+	query := database.Conn(ctx, r.db).Where("customer_id = ?", customerID)
+
+	if from != nil {
+		query = query.Where("created_at >= ?", *from)
+	}
+
+	if to != nil {
+		query = query.Where("created_at <= ?", *to)
+	}
+
+	if err := query.Order("created_at ASC").Find(&txns).Error; err != nil {
+		return nil, errors.WrapError(err, "failed to list loyalty transactions")
+	}
+	return txns, nil
+}
+
+func (r *loyaltyRepository) GetBalanceAt(ctx context.Context, customerID uuid.UUID, at time.Time) (int, error) {
+	var customer domain.Customer
+	if err := database.Conn(ctx, r.db).Select("loyalty_points").First(&customer, "customer_id = ?", customerID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, errors.NotFoundWithID("Customer", customerID.String())
+		}
+		return 0, errors.WrapError(err, "failed to find customer")
+	}
+
+	// Walk back from the current balance so balances predating the ledger are kept
+	var movedSince int
+	err := database.Conn(ctx, r.db).
+		Model(&domain.LoyaltyTransaction{}).
+		Select("COALESCE(SUM(points), 0)").
+		Where("customer_id = ? AND created_at >= ?", customerID, at).
+		Scan(&movedSince).Error
+
+	if err != nil {
+		return 0, errors.WrapError(err, "failed to calculate loyalty balance")
+	}
+
+	return customer.LoyaltyPoints - movedSince, nil
+}
+
+func (r *loyaltyRepository) GetExpiredLots(ctx context.Context, at time.Time) ([]domain.LoyaltyTransaction, error) {
+	var lots []domain.LoyaltyTransaction
+	err := database.Conn(ctx, r.db).
+		Where("remaining_points > 0 AND expires_at IS NOT NULL AND expires_at <= ?", at).
+		Order("expires_at ASC").
+		Find(&lots).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get expired loyalty points")
+	}
+	return lots, nil
+}
+
+// Helper functions
+
+// appendTransaction writes a ledger entry inside tx, keeping the customer
+// balance and the remaining points of earned lots in sync
+func (r *loyaltyRepository) appendTransaction(tx *gorm.DB, txn *domain.LoyaltyTransaction) error {
+	var customer domain.Customer
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&customer, "customer_id = ?", txn.CustomerID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.NotFoundWithID("Customer", txn.CustomerID.String())
+		}
+		return errors.WrapError(err, "failed to lock customer")
+	}
+
+	balance := customer.LoyaltyPoints + txn.Points
+	if balance < 0 {
+		return errors.BadRequest("Insufficient loyalty points balance")
+	}
+
+	if txn.Points > 0 {
+		txn.RemainingPoints = txn.Points
+	} else if txn.Points < 0 {
+		if err := r.consumeLots(tx, txn, -txn.Points); err != nil {
+			return err
+		}
+	}
+
+	txn.BalanceAfter = balance
+	if err := tx.Create(txn).Error; err != nil {
+		return errors.WrapError(err, "failed to create loyalty transaction")
+	}
+
+	err = tx.Model(&domain.Customer{}).
+		Where("customer_id = ?", txn.CustomerID).
+		Update("loyalty_points", balance).Error
+	if err != nil {
+		return errors.WrapError(err, "failed to update loyalty points")
+	}
+
+	return nil
+}
+
+// consumeLots deducts points from the source lot first, then from the
+// lots closest to expiring
+func (r *loyaltyRepository) consumeLots(tx *gorm.DB, txn *domain.LoyaltyTransaction, points int) error {
+	var lots []domain.LoyaltyTransaction
+
+	if txn.SourceTransactionID != nil {
+		var source domain.LoyaltyTransaction
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("transaction_id = ? AND remaining_points > 0", *txn.SourceTransactionID).
+			Find(&source).Error
+		if err != nil {
+			return errors.WrapError(err, "failed to find source loyalty transaction")
+		}
+		if source.TransactionID != uuid.Nil {
+			lots = append(lots, source)
+		}
+	}
+
+	var open []domain.LoyaltyTransaction
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("customer_id = ? AND remaining_points > 0", txn.CustomerID).
+		Order("expires_at ASC NULLS LAST").
+		Order("created_at ASC").
+		Find(&open).Error
+	if err != nil {
+		return errors.WrapError(err, "failed to find open loyalty points")
+	}
+	for _, lot := range open {
+		if txn.SourceTransactionID == nil || lot.TransactionID != *txn.SourceTransactionID {
+			lots = append(lots, lot)
+		}
+	}
+
+	for _, lot := range lots {
+		if points == 0 {
+			break
+		}
+
+		used := lot.RemainingPoints
+		if used > points {
+			used = points
+		}
+
+		err := tx.Model(&domain.LoyaltyTransaction{}).
+			Where("transaction_id = ?", lot.TransactionID).
+			Update("remaining_points", lot.RemainingPoints-used).Error
+		if err != nil {
+			return errors.WrapError(err, "failed to consume loyalty points")
+		}
+		points -= used
+	}
+
+	// Any remainder comes from balances recorded before the ledger existed
+	return nil
+}
+
+func (r *loyaltyRepository) buildRuleFilterQuery(query *gorm.DB, filters repositories.LoyaltyRuleFilters) *gorm.DB {
+	if filters.Currency != nil {
+		query = query.Where("currency = ?", *filters.Currency)
+	}
+
+	if filters.CategoryID != nil {
+		query = query.Where("category_id = ?", *filters.CategoryID)
+	}
+
+	if filters.IsActive != nil {
+		query = query.Where("is_active = ?", *filters.IsActive)
+	}
+
+	return query
+}
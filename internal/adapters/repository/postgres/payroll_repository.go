@@ -0,0 +1,326 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type payrollRepository struct {
+	db *gorm.DB
+}
+
+// NewPayrollRepository creates a new payroll repository
+func NewPayrollRepository(db *gorm.DB) repositories.PayrollRepository {
+	return &payrollRepository{db: db}
+}
+
+func (r *payrollRepository) FindStoreByID(ctx context.Context, id uuid.UUID) (*domain.Store, error) {
+	var store domain.Store
+	err := database.Conn(ctx, r.db).First(&store, "store_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("Store", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find store")
+	}
+	return &store, nil
+}
+
+func (r *payrollRepository) GetPayableEmployees(ctx context.Context, storeID *uuid.UUID, from, to time.Time) ([]domain.Employee, error) {
+	var employees []domain.Employee
+
+	query := database.Conn(ctx, r.db).
+		Where("hire_date <= ?", to).
+		Where("termination_date IS NULL OR termination_date >= ?", from).
+		Where("status <> ?", domain.EmployeeStatusInactive)
+
+	if storeID != nil {
+		query = query.Where("store_id = ?", *storeID)
+	}
+
+	if err := query.Order("last_name ASC, first_name ASC").Find(&employees).Error; err != nil {
+		return nil, errors.WrapError(err, "failed to get payable employees")
+	}
+	return employees, nil
+}
+
+func (r *payrollRepository) CreateConcept(ctx context.Context, concept *domain.PayrollConcept) error {
+	if err := database.Conn(ctx, r.db).Create(concept).Error; err != nil {
+		return errors.WrapError(err, "failed to create payroll concept")
+	}
+	return nil
+}
+
+func (r *payrollRepository) FindConceptByID(ctx context.Context, id uuid.UUID) (*domain.PayrollConcept, error) {
+	var concept domain.PayrollConcept
+	err := database.Conn(ctx, r.db).First(&concept, "concept_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("PayrollConcept", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find payroll concept")
+	}
+	return &concept, nil
+}
+
+func (r *payrollRepository) FindConceptByCode(ctx context.Context, code string) (*domain.PayrollConcept, error) {
+	var concept domain.PayrollConcept
+	err := database.Conn(ctx, r.db).First(&concept, "code = ?", code).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("PayrollConcept")
+		}
+		return nil, errors.WrapError(err, "failed to find payroll concept by code")
+	}
+	return &concept, nil
+}
+
+func (r *payrollRepository) ListConcepts(ctx context.Context) ([]domain.PayrollConcept, error) {
+	var concepts []domain.PayrollConcept
+	err := database.Conn(ctx, r.db).
+		Order("type ASC").
+		Order("code ASC").
+		Find(&concepts).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to list payroll concepts")
+	}
+	return concepts, nil
+}
+
+func (r *payrollRepository) GetActiveConcepts(ctx context.Context) ([]domain.PayrollConcept, error) {
+	var concepts []domain.PayrollConcept
+	err := database.Conn(ctx, r.db).
+		Where("is_active = ?", true).
+		Order("type ASC").
+		Order("code ASC").
+		Find(&concepts).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get active payroll concepts")
+	}
+	return concepts, nil
+}
+
+func (r *payrollRepository) UpdateConcept(ctx context.Context, concept *domain.PayrollConcept) error {
+	concept.UpdatedAt = time.Now()
+	if err := database.Conn(ctx, r.db).Save(concept).Error; err != nil {
+		return errors.WrapError(err, "failed to update payroll concept")
+	}
+	return nil
+}
+
+func (r *payrollRepository) DeleteConcept(ctx context.Context, id uuid.UUID) error {
+	if err := database.Conn(ctx, r.db).Delete(&domain.PayrollConcept{}, "concept_id = ?", id).Error; err != nil {
+		return errors.WrapError(err, "failed to delete payroll concept")
+	}
+	return nil
+}
+
+func (r *payrollRepository) CreatePeriod(ctx context.Context, period *domain.PayrollPeriod) error {
+	if err := database.Conn(ctx, r.db).Omit(clause.Associations).Create(period).Error; err != nil {
+		return errors.WrapError(err, "failed to create payroll period")
+	}
+	return nil
+}
+
+func (r *payrollRepository) FindPeriodByID(ctx context.Context, id uuid.UUID) (*domain.PayrollPeriod, error) {
+	var period domain.PayrollPeriod
+	err := database.Conn(ctx, r.db).
+		Preload("Store").
+		First(&period, "period_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("PayrollPeriod", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find payroll period")
+	}
+	return &period, nil
+}
+
+func (r *payrollRepository) ListPeriods(ctx context.Context, filters repositories.PayrollPeriodFilters, limit, offset int) ([]domain.PayrollPeriod, int64, error) {
+	var periods []domain.PayrollPeriod
+	var total int64
+
+	query := database.Conn(ctx, r.db).Model(&domain.PayrollPeriod{})
+	query = r.buildFilterQuery(query, filters)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.WrapError(err, "failed to count payroll periods")
+	}
+
+	err := query.
+		Preload("Store").
+		Order("start_date DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&periods).Error
+
+	if err != nil {
+		return nil, 0, errors.WrapError(err, "failed to list payroll periods")
+	}
+
+	return periods, total, nil
+}
+
+func (r *payrollRepository) FindOverlappingPeriod(ctx context.Context, storeID *uuid.UUID, start, end time.Time) (*domain.PayrollPeriod, error) {
+	var period domain.PayrollPeriod
+
+	query := database.Conn(ctx, r.db).
+		Where("start_date <= ? AND end_date >= ?", end, start)
+
+	// A period of every store overlaps the periods of each store, and vice versa
+	if storeID != nil {
+		query = query.Where("store_id IS NULL OR store_id = ?", *storeID)
+	}
+
+	if err := query.First(&period).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("PayrollPeriod")
+		}
+		return nil, errors.WrapError(err, "failed to find overlapping payroll period")
+	}
+	return &period, nil
+}
+
+func (r *payrollRepository) UpdatePeriodStatus(ctx context.Context, period *domain.PayrollPeriod, from domain.PayrollPeriodStatus) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := r.lockPeriod(tx, period.PeriodID, from); err != nil {
+			return err
+		}
+
+		period.UpdatedAt = time.Now()
+		if err := tx.Omit(clause.Associations).Save(period).Error; err != nil {
+			return errors.WrapError(err, "failed to update payroll period")
+		}
+
+		return nil
+	})
+}
+
+func (r *payrollRepository) ReplacePayslips(ctx context.Context, period *domain.PayrollPeriod, payslips []domain.Payslip) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := r.lockPeriod(tx, period.PeriodID, domain.PayrollPeriodStatusOpen, domain.PayrollPeriodStatusProcessing); err != nil {
+			return err
+		}
+
+		// Drop the previous calculation run
+		previous := tx.Model(&domain.Payslip{}).Select("payslip_id").Where("period_id = ?", period.PeriodID)
+		if err := tx.Where("payslip_id IN (?)", previous).Delete(&domain.PayslipLine{}).Error; err != nil {
+			return errors.WrapError(err, "failed to delete payslip lines")
+		}
+		if err := tx.Where("period_id = ?", period.PeriodID).Delete(&domain.Payslip{}).Error; err != nil {
+			return errors.WrapError(err, "failed to delete payslips")
+		}
+
+		for i := range payslips {
+			if err := tx.Omit(clause.Associations).Create(&payslips[i]).Error; err != nil {
+				return errors.WrapError(err, "failed to create payslip")
+			}
+			if len(payslips[i].Lines) > 0 {
+				if err := tx.Create(&payslips[i].Lines).Error; err != nil {
+					return errors.WrapError(err, "failed to create payslip lines")
+				}
+			}
+		}
+
+		period.UpdatedAt = time.Now()
+		if err := tx.Omit(clause.Associations).Save(period).Error; err != nil {
+			return errors.WrapError(err, "failed to update payroll period")
+		}
+
+		return nil
+	})
+}
+
+func (r *payrollRepository) GetPayslips(ctx context.Context, periodID uuid.UUID) ([]domain.Payslip, error) {
+	var payslips []domain.Payslip
+	err := database.Conn(ctx, r.db).
+		Preload("Employee").
+		Preload("Store").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("type ASC, code ASC")
+		}).
+		Where("period_id = ?", periodID).
+		Find(&payslips).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get payslips")
+	}
+	return payslips, nil
+}
+
+func (r *payrollRepository) FindPayslipByID(ctx context.Context, id uuid.UUID) (*domain.Payslip, error) {
+	var payslip domain.Payslip
+	err := database.Conn(ctx, r.db).
+		Preload("Employee").
+		Preload("Store").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("type ASC, code ASC")
+		}).
+		First(&payslip, "payslip_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("Payslip", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find payslip")
+	}
+	return &payslip, nil
+}
+
+// Helper functions
+
+// lockPeriod locks the period row inside tx and checks it still has one of the given statuses
+func (r *payrollRepository) lockPeriod(tx *gorm.DB, periodID uuid.UUID, statuses ...domain.PayrollPeriodStatus) error {
+	var current domain.PayrollPeriod
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&current, "period_id = ?", periodID).Error
+	if err != nil {
+		return errors.WrapError(err, "failed to find payroll period")
+	}
+
+	for _, status := range statuses {
+		if current.Status == status {
+			return nil
+		}
+	}
+	return errors.Conflict(fmt.Sprintf("Payroll period is already %s", current.Status))
+}
+
+func (r *payrollRepository) buildFilterQuery(query *gorm.DB, filters repositories.PayrollPeriodFilters) *gorm.DB {
+	if filters.StoreID != nil {
+		query = query.Where("store_id = ?", *filters.StoreID)
+	}
+
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+
+	if filters.Frequency != nil {
+		query = query.Where("frequency = ?", *filters.Frequency)
+	}
+
+	if filters.From != nil {
+		query = query.Where("end_date >= ?", *filters.From)
+	}
+
+	if filters.To != nil {
+		query = query.Where("start_date <= ?", *filters.To)
+	}
+
+	return query
+}
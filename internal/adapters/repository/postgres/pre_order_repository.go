@@ -0,0 +1,450 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type preOrderRepository struct {
+	db *gorm.DB
+}
+
+// NewPreOrderRepository creates a new pre-order repository
+func NewPreOrderRepository(db *gorm.DB) repositories.PreOrderRepository {
+	return &preOrderRepository{db: db}
+}
+
+func (r *preOrderRepository) Create(ctx context.Context, preOrder *domain.PreOrder) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if preOrder.PreOrderNumber == "" {
+			number, err := r.generatePreOrderNumber(tx)
+			if err != nil {
+				return err
+			}
+			preOrder.PreOrderNumber = number
+		}
+
+		if err := tx.Omit(clause.Associations).Create(preOrder).Error; err != nil {
+			return errors.WrapError(err, "failed to create pre-order")
+		}
+		return nil
+	})
+}
+
+func (r *preOrderRepository) CreateWithItems(ctx context.Context, preOrder *domain.PreOrder, items []domain.PreOrderItem) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if preOrder.PreOrderNumber == "" {
+			number, err := r.generatePreOrderNumber(tx)
+			if err != nil {
+				return err
+			}
+			preOrder.PreOrderNumber = number
+		}
+
+		if err := tx.Omit(clause.Associations).Create(preOrder).Error; err != nil {
+			return errors.WrapError(err, "failed to create pre-order")
+		}
+
+		for i := range items {
+			items[i].PreOrderID = preOrder.PreOrderID
+			items[i].TotalAmount = items[i].Quantity * items[i].UnitPrice
+
+			if err := tx.Omit(clause.Associations).Create(&items[i]).Error; err != nil {
+				return errors.WrapError(err, "failed to create pre-order item")
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *preOrderRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.PreOrder, error) {
+	var preOrder domain.PreOrder
+	err := database.Conn(ctx, r.db).
+		Preload("Customer").
+		Preload("Store").
+		Preload("Items").
+		Preload("Items.Product").
+		First(&preOrder, "pre_order_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("PreOrder", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find pre-order")
+	}
+	return &preOrder, nil
+}
+
+func (r *preOrderRepository) FindByNumber(ctx context.Context, preOrderNumber string) (*domain.PreOrder, error) {
+	var preOrder domain.PreOrder
+	err := database.Conn(ctx, r.db).
+		Preload("Customer").
+		Preload("Store").
+		Preload("Items").
+		Preload("Items.Product").
+		Where("pre_order_number = ?", preOrderNumber).
+		First(&preOrder).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("PreOrder")
+		}
+		return nil, errors.WrapError(err, "failed to find pre-order by number")
+	}
+	return &preOrder, nil
+}
+
+func (r *preOrderRepository) FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]domain.PreOrder, error) {
+	var preOrders []domain.PreOrder
+	err := database.Conn(ctx, r.db).
+		Preload("Store").
+		Where("customer_id = ?", customerID).
+		Order("order_date DESC").
+		Find(&preOrders).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find pre-orders by customer")
+	}
+	return preOrders, nil
+}
+
+func (r *preOrderRepository) List(ctx context.Context, filters repositories.PreOrderFilters, limit, offset int) ([]domain.PreOrder, int64, error) {
+	var preOrders []domain.PreOrder
+	var total int64
+
+	query := r.buildFilterQuery(database.Conn(ctx, r.db).Model(&domain.PreOrder{}), filters)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.WrapError(err, "failed to count pre-orders")
+	}
+
+	err := query.
+		Preload("Customer").
+		Preload("Store").
+		Order("order_date DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&preOrders).Error
+
+	if err != nil {
+		return nil, 0, errors.WrapError(err, "failed to list pre-orders")
+	}
+
+	return preOrders, total, nil
+}
+
+func (r *preOrderRepository) GetItems(ctx context.Context, preOrderID uuid.UUID) ([]domain.PreOrderItem, error) {
+	var items []domain.PreOrderItem
+	err := database.Conn(ctx, r.db).
+		Preload("Product").
+		Where("pre_order_id = ?", preOrderID).
+		Order("created_at ASC").
+		Find(&items).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get pre-order items")
+	}
+	return items, nil
+}
+
+func (r *preOrderRepository) Update(ctx context.Context, preOrder *domain.PreOrder) error {
+	if err := database.Conn(ctx, r.db).Omit(clause.Associations).Save(preOrder).Error; err != nil {
+		return errors.WrapError(err, "failed to update pre-order")
+	}
+	return nil
+}
+
+func (r *preOrderRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.PreOrderStatus) error {
+	err := database.Conn(ctx, r.db).
+		Model(&domain.PreOrder{}).
+		Where("pre_order_id = ?", id).
+		Update("status", status).Error
+
+	if err != nil {
+		return errors.WrapError(err, "failed to update pre-order status")
+	}
+	return nil
+}
+
+func (r *preOrderRepository) SetItemsAvailable(ctx context.Context, itemIDs []uuid.UUID) error {
+	if len(itemIDs) == 0 {
+		return nil
+	}
+
+	err := database.Conn(ctx, r.db).
+		Model(&domain.PreOrderItem{}).
+		Where("pre_order_item_id IN ?", itemIDs).
+		Update("is_available", true).Error
+
+	if err != nil {
+		return errors.WrapError(err, "failed to update pre-order item availability")
+	}
+	return nil
+}
+
+func (r *preOrderRepository) GetOpenItems(ctx context.Context, productID, storeID uuid.UUID) ([]domain.PreOrderItem, error) {
+	var items []domain.PreOrderItem
+	err := database.Conn(ctx, r.db).
+		Joins("INNER JOIN pre_orders ON pre_orders.pre_order_id = pre_order_items.pre_order_id").
+		Where("pre_order_items.product_id = ?", productID).
+		Where("pre_orders.store_id = ?", storeID).
+		Where("pre_orders.status IN ?", []domain.PreOrderStatus{
+			domain.PreOrderStatusConfirmed,
+			domain.PreOrderStatusInPreparation,
+		}).
+		Order("pre_orders.order_date ASC, pre_order_items.created_at ASC").
+		Find(&items).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get open pre-order items")
+	}
+	return items, nil
+}
+
+func (r *preOrderRepository) MarkAsReady(ctx context.Context, preOrder *domain.PreOrder, items []domain.PreOrderItem) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		_, err := r.lockPreOrder(tx, preOrder.PreOrderID, domain.PreOrderStatusConfirmed, domain.PreOrderStatusInPreparation)
+		if err != nil {
+			return err
+		}
+
+		for i := range items {
+			item := &items[i]
+			if item.WarehouseID == nil {
+				return errors.InvalidInput("Warehouse is required to hold pre-order stock")
+			}
+			warehouseID := *item.WarehouseID
+
+			// Lock the row so concurrent sales cannot take the stock being held
+			var inventory domain.Inventory
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("product_id = ? AND warehouse_id = ?", item.ProductID, warehouseID).
+				First(&inventory).Error
+
+			available := 0.0
+			if err == nil {
+				available = inventory.AvailableQuantity
+			} else if err != gorm.ErrRecordNotFound {
+				return errors.WrapError(err, "failed to check inventory")
+			}
+
+			if available < item.Quantity {
+				var product domain.Product
+				tx.First(&product, "product_id = ?", item.ProductID)
+				return errors.InsufficientStock(product.Name, available, item.Quantity)
+			}
+
+			// Create RESERVATION inventory movement (trigger updates inventory)
+			movement := &domain.InventoryMovement{
+				MovementID:    uuid.New(),
+				ProductID:     item.ProductID,
+				WarehouseID:   warehouseID,
+				MovementType:  domain.MovementTypeReservation,
+				Quantity:      item.Quantity,
+				UnitCost:      &item.UnitPrice,
+				Currency:      preOrder.Currency,
+				ReferenceType: stringPtr("PRE_ORDER"),
+				ReferenceID:   &preOrder.PreOrderID,
+				CreatedBy:     preOrder.ConfirmedBy,
+			}
+			if err := tx.Create(movement).Error; err != nil {
+				return errors.WrapError(err, "failed to create pre-order inventory movement")
+			}
+
+			if err := tx.Omit(clause.Associations).Save(item).Error; err != nil {
+				return errors.WrapError(err, "failed to update pre-order item")
+			}
+		}
+
+		if err := tx.Omit(clause.Associations).Save(preOrder).Error; err != nil {
+			return errors.WrapError(err, "failed to mark pre-order as ready")
+		}
+		return nil
+	})
+}
+
+func (r *preOrderRepository) Deliver(
+	ctx context.Context,
+	preOrder *domain.PreOrder,
+	sale *domain.Sale,
+	details []domain.SaleDetail,
+	deposit *domain.SaleTender,
+) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if _, err := r.lockPreOrder(tx, preOrder.PreOrderID, domain.PreOrderStatusReady); err != nil {
+			return err
+		}
+
+		// Release first so the sale can take the stock held for the pre-order
+		if err := r.releaseHeldStock(tx, preOrder, "PRE_ORDER_DELIVERY"); err != nil {
+			return err
+		}
+
+		sales := &saleRepository{db: tx}
+		if err := sales.CreateWithDetails(ctx, sale, details); err != nil {
+			return err
+		}
+
+		if deposit != nil {
+			deposit.SaleID = sale.SaleID
+			if err := tx.Create(deposit).Error; err != nil {
+				return errors.WrapError(err, "failed to create deposit tender")
+			}
+		}
+
+		if err := tx.Omit(clause.Associations).Save(preOrder).Error; err != nil {
+			return errors.WrapError(err, "failed to mark pre-order as delivered")
+		}
+		return nil
+	})
+}
+
+func (r *preOrderRepository) Cancel(ctx context.Context, preOrder *domain.PreOrder, releaseStock bool) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Only a ready pre-order holds stock, so it must still be in the
+		// status the caller decided the release on
+		expected := []domain.PreOrderStatus{
+			domain.PreOrderStatusPending, domain.PreOrderStatusConfirmed, domain.PreOrderStatusInPreparation,
+		}
+		if releaseStock {
+			expected = []domain.PreOrderStatus{domain.PreOrderStatusReady}
+		}
+		if _, err := r.lockPreOrder(tx, preOrder.PreOrderID, expected...); err != nil {
+			return err
+		}
+
+		if releaseStock {
+			if err := r.releaseHeldStock(tx, preOrder, "PRE_ORDER_CANCELLATION"); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Omit(clause.Associations).Save(preOrder).Error; err != nil {
+			return errors.WrapError(err, "failed to cancel pre-order")
+		}
+		return nil
+	})
+}
+
+func (r *preOrderRepository) GetUncollected(ctx context.Context, at time.Time) ([]domain.PreOrder, error) {
+	var preOrders []domain.PreOrder
+	err := database.Conn(ctx, r.db).
+		Preload("Customer").
+		Where("status = ?", domain.PreOrderStatusReady).
+		Where("pickup_deadline < ?", at.Format("2006-01-02")).
+		Find(&preOrders).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get uncollected pre-orders")
+	}
+	return preOrders, nil
+}
+
+// Helper functions
+
+// lockPreOrder locks the pre-order record so concurrent status changes are
+// serialized, checking it is still in one of the expected statuses
+func (r *preOrderRepository) lockPreOrder(tx *gorm.DB, id uuid.UUID, statuses ...domain.PreOrderStatus) (*domain.PreOrder, error) {
+	var current domain.PreOrder
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&current, "pre_order_id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("PreOrder", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find pre-order")
+	}
+
+	for _, status := range statuses {
+		if current.Status == status {
+			return &current, nil
+		}
+	}
+	return nil, errors.Conflict(fmt.Sprintf("Pre-order is already %s", current.Status))
+}
+
+// releaseHeldStock releases the stock of each item from the warehouse holding it
+func (r *preOrderRepository) releaseHeldStock(tx *gorm.DB, preOrder *domain.PreOrder, referenceType string) error {
+	var items []domain.PreOrderItem
+	if err := tx.Where("pre_order_id = ?", preOrder.PreOrderID).Find(&items).Error; err != nil {
+		return errors.WrapError(err, "failed to get pre-order items")
+	}
+
+	for _, item := range items {
+		var warehouseID uuid.UUID
+		if item.WarehouseID != nil {
+			warehouseID = *item.WarehouseID
+		} else {
+			primaryID, err := primaryWarehouseID(tx, preOrder.StoreID)
+			if err != nil {
+				return err
+			}
+			warehouseID = primaryID
+		}
+
+		movement := &domain.InventoryMovement{
+			MovementID:    uuid.New(),
+			ProductID:     item.ProductID,
+			WarehouseID:   warehouseID,
+			MovementType:  domain.MovementTypeReservationRelease,
+			Quantity:      item.Quantity,
+			ReferenceType: stringPtr(referenceType),
+			ReferenceID:   &preOrder.PreOrderID,
+			Notes:         stringPtr(fmt.Sprintf("Release from pre-order %s", preOrder.PreOrderNumber)),
+		}
+		if err := tx.Create(movement).Error; err != nil {
+			return errors.WrapError(err, "failed to create release inventory movement")
+		}
+	}
+
+	return nil
+}
+
+func (r *preOrderRepository) buildFilterQuery(query *gorm.DB, filters repositories.PreOrderFilters) *gorm.DB {
+	if filters.CustomerID != nil {
+		query = query.Where("customer_id = ?", *filters.CustomerID)
+	}
+
+	if filters.StoreID != nil {
+		query = query.Where("store_id = ?", *filters.StoreID)
+	}
+
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+
+	if filters.DateFrom != nil {
+		query = query.Where("order_date >= ?", *filters.DateFrom)
+	}
+
+	if filters.DateTo != nil {
+		query = query.Where("order_date <= ?", *filters.DateTo)
+	}
+
+	return query
+}
+
+func (r *preOrderRepository) generatePreOrderNumber(tx *gorm.DB) (string, error) {
+	now := time.Now()
+	prefix := now.Format("PRE-2006-01")
+
+	var count int64
+	if err := tx.Model(&domain.PreOrder{}).
+		Where("pre_order_number LIKE ?", prefix+"%").
+		Count(&count).Error; err != nil {
+		return "", errors.WrapError(err, "failed to count pre-orders for number generation")
+	}
+
+	// Generate pre-order number: PRE-YYYY-MM-NNNN
+	return fmt.Sprintf("%s-%04d", prefix, count+1), nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/jadiazinf/inventory/internal/common/errors"
 	"github.com/jadiazinf/inventory/internal/core/domain"
 	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
 	"gorm.io/gorm"
 )
 
@@ -22,14 +23,14 @@ func NewProductRepository(db *gorm.DB) repositories.ProductRepository {
 func (r *productRepository) Create(ctx context.Context, product *domain.Product) error {
 	// Check for duplicate SKU
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&domain.Product{}).Where("sku = ?", product.SKU).Count(&count).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Model(&domain.Product{}).Where("sku = ?", product.SKU).Count(&count).Error; err != nil {
 		return errors.WrapError(err, "failed to check SKU uniqueness")
 	}
 	if count > 0 {
 		return errors.AlreadyExists("Product", "SKU", product.SKU)
 	}
 
-	if err := r.db.WithContext(ctx).Create(product).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Create(product).Error; err != nil {
 		return errors.WrapError(err, "failed to create product")
 	}
 	return nil
@@ -37,7 +38,7 @@ func (r *productRepository) Create(ctx context.Context, product *domain.Product)
 
 func (r *productRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
 	var product domain.Product
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Category").
 		Preload("Unit").
 		Preload("Supplier").
@@ -54,7 +55,7 @@ func (r *productRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain
 
 func (r *productRepository) FindBySKU(ctx context.Context, sku string) (*domain.Product, error) {
 	var product domain.Product
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Category").
 		Preload("Unit").
 		Preload("Supplier").
@@ -72,7 +73,7 @@ func (r *productRepository) FindBySKU(ctx context.Context, sku string) (*domain.
 
 func (r *productRepository) FindByBarcode(ctx context.Context, barcode string) (*domain.Product, error) {
 	var product domain.Product
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Category").
 		Preload("Unit").
 		Preload("Supplier").
@@ -92,7 +93,7 @@ func (r *productRepository) List(ctx context.Context, filters repositories.Produ
 	var products []domain.Product
 	var total int64
 
-	query := r.buildFilterQuery(r.db.WithContext(ctx).Model(&domain.Product{}), filters)
+	query := r.buildFilterQuery(database.Conn(ctx, r.db).Model(&domain.Product{}), filters)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, errors.WrapError(err, "failed to count products")
@@ -117,7 +118,7 @@ func (r *productRepository) SearchByName(ctx context.Context, query string, limi
 	var products []domain.Product
 	var total int64
 
-	searchQuery := r.db.WithContext(ctx).Model(&domain.Product{}).
+	searchQuery := database.Conn(ctx, r.db).Model(&domain.Product{}).
 		Where("name ILIKE ? OR sku ILIKE ?", "%"+query+"%", "%"+query+"%")
 
 	if err := searchQuery.Count(&total).Error; err != nil {
@@ -140,14 +141,14 @@ func (r *productRepository) SearchByName(ctx context.Context, query string, limi
 }
 
 func (r *productRepository) Update(ctx context.Context, product *domain.Product) error {
-	if err := r.db.WithContext(ctx).Save(product).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Save(product).Error; err != nil {
 		return errors.WrapError(err, "failed to update product")
 	}
 	return nil
 }
 
 func (r *productRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	if err := r.db.WithContext(ctx).Delete(&domain.Product{}, "product_id = ?", id).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Delete(&domain.Product{}, "product_id = ?", id).Error; err != nil {
 		return errors.WrapError(err, "failed to delete product")
 	}
 	return nil
@@ -156,7 +157,7 @@ func (r *productRepository) Delete(ctx context.Context, id uuid.UUID) error {
 func (r *productRepository) GetLowStock(ctx context.Context, warehouseID *uuid.UUID) ([]domain.Product, error) {
 	var products []domain.Product
 
-	query := r.db.WithContext(ctx).
+	query := database.Conn(ctx, r.db).
 		Joins("INNER JOIN inventory ON inventory.product_id = products.product_id").
 		Where("inventory.available_quantity <= products.min_stock")
 
@@ -186,6 +187,10 @@ func (r *productRepository) buildFilterQuery(query *gorm.DB, filters repositorie
 		query = query.Where("is_school_supply = ?", *filters.IsSchoolSupply)
 	}
 
+	if filters.SeasonalDemand != nil {
+		query = query.Where("seasonal_demand = ?", *filters.SeasonalDemand)
+	}
+
 	if filters.SchoolLevel != nil {
 		query = query.Where("? = ANY(grade_levels)", *filters.SchoolLevel)
 	}
@@ -0,0 +1,219 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
+	"gorm.io/gorm"
+)
+
+type quotationRepository struct {
+	db *gorm.DB
+}
+
+// NewQuotationRepository creates a new quotation repository
+func NewQuotationRepository(db *gorm.DB) repositories.QuotationRepository {
+	return &quotationRepository{db: db}
+}
+
+func (r *quotationRepository) CreateWithItems(ctx context.Context, quotation *domain.Quotation, items []domain.QuotationItem) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if quotation.QuotationNumber == "" {
+			number, err := r.generateQuotationNumber(tx)
+			if err != nil {
+				return err
+			}
+			quotation.QuotationNumber = number
+		}
+
+		if err := tx.Omit("Items").Create(quotation).Error; err != nil {
+			return errors.WrapError(err, "failed to create quotation")
+		}
+
+		return r.createItems(tx, quotation.QuotationID, items)
+	})
+}
+
+func (r *quotationRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Quotation, error) {
+	var quotation domain.Quotation
+	err := database.Conn(ctx, r.db).
+		Preload("Customer").
+		Preload("Store").
+		Preload("Items").
+		Preload("Items.Product").
+		First(&quotation, "quotation_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("Quotation", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find quotation")
+	}
+	return &quotation, nil
+}
+
+func (r *quotationRepository) FindByNumber(ctx context.Context, quotationNumber string) (*domain.Quotation, error) {
+	var quotation domain.Quotation
+	err := database.Conn(ctx, r.db).
+		Preload("Customer").
+		Preload("Store").
+		Preload("Items").
+		Preload("Items.Product").
+		Where("quotation_number = ?", quotationNumber).
+		First(&quotation).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("Quotation")
+		}
+		return nil, errors.WrapError(err, "failed to find quotation by number")
+	}
+	return &quotation, nil
+}
+
+func (r *quotationRepository) List(ctx context.Context, filters repositories.QuotationFilters, limit, offset int) ([]domain.Quotation, int64, error) {
+	var quotations []domain.Quotation
+	var total int64
+
+	query := r.buildFilterQuery(database.Conn(ctx, r.db).Model(&domain.Quotation{}), filters)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.WrapError(err, "failed to count quotations")
+	}
+
+	err := query.
+		Preload("Customer").
+		Preload("Store").
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&quotations).Error
+
+	if err != nil {
+		return nil, 0, errors.WrapError(err, "failed to list quotations")
+	}
+
+	return quotations, total, nil
+}
+
+func (r *quotationRepository) Update(ctx context.Context, quotation *domain.Quotation) error {
+	quotation.UpdatedAt = time.Now()
+	if err := database.Conn(ctx, r.db).Omit("Customer", "Store", "Items").Save(quotation).Error; err != nil {
+		return errors.WrapError(err, "failed to update quotation")
+	}
+	return nil
+}
+
+func (r *quotationRepository) ReplaceItems(ctx context.Context, quotation *domain.Quotation, items []domain.QuotationItem) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("quotation_id = ?", quotation.QuotationID).Delete(&domain.QuotationItem{}).Error; err != nil {
+			return errors.WrapError(err, "failed to delete quotation items")
+		}
+
+		quotation.UpdatedAt = time.Now()
+		if err := tx.Omit("Customer", "Store", "Items").Save(quotation).Error; err != nil {
+			return errors.WrapError(err, "failed to update quotation")
+		}
+
+		return r.createItems(tx, quotation.QuotationID, items)
+	})
+}
+
+func (r *quotationRepository) MarkConverted(ctx context.Context, quotation *domain.Quotation) error {
+	quotation.UpdatedAt = time.Now()
+	result := database.Conn(ctx, r.db).
+		Model(&domain.Quotation{}).
+		Where("quotation_id = ? AND converted_at IS NULL", quotation.QuotationID).
+		Where("status IN ?", []domain.QuotationStatus{domain.QuotationStatusSent, domain.QuotationStatusAccepted}).
+		Updates(map[string]interface{}{
+			"status":       quotation.Status,
+			"accepted_at":  quotation.AcceptedAt,
+			"converted_at": quotation.ConvertedAt,
+			"converted_to": quotation.ConvertedTo,
+			"updated_at":   quotation.UpdatedAt,
+		})
+
+	if result.Error != nil {
+		return errors.WrapError(result.Error, "failed to mark quotation as converted")
+	}
+	if result.RowsAffected == 0 {
+		return errors.Conflict(fmt.Sprintf("Quotation %s was already converted", quotation.QuotationNumber))
+	}
+	return nil
+}
+
+func (r *quotationRepository) GetExpired(ctx context.Context, at time.Time) ([]domain.Quotation, error) {
+	var quotations []domain.Quotation
+	err := database.Conn(ctx, r.db).
+		Where("status IN ?", []domain.QuotationStatus{
+			domain.QuotationStatusDraft,
+			domain.QuotationStatusSent,
+			domain.QuotationStatusAccepted,
+		}).
+		Where("converted_at IS NULL").
+		Where("valid_until < ?", at).
+		Order("valid_until ASC").
+		Find(&quotations).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get expired quotations")
+	}
+	return quotations, nil
+}
+
+// Helper functions
+
+func (r *quotationRepository) createItems(tx *gorm.DB, quotationID uuid.UUID, items []domain.QuotationItem) error {
+	for i := range items {
+		items[i].QuotationID = quotationID
+		if err := tx.Omit("Product").Create(&items[i]).Error; err != nil {
+			return errors.WrapError(err, "failed to create quotation item")
+		}
+	}
+	return nil
+}
+
+func (r *quotationRepository) buildFilterQuery(query *gorm.DB, filters repositories.QuotationFilters) *gorm.DB {
+	if filters.CustomerID != nil {
+		query = query.Where("customer_id = ?", *filters.CustomerID)
+	}
+
+	if filters.StoreID != nil {
+		query = query.Where("store_id = ?", *filters.StoreID)
+	}
+
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+
+	if filters.DateFrom != nil {
+		query = query.Where("created_at >= ?", *filters.DateFrom)
+	}
+
+	if filters.DateTo != nil {
+		query = query.Where("created_at <= ?", *filters.DateTo)
+	}
+
+	return query
+}
+
+func (r *quotationRepository) generateQuotationNumber(tx *gorm.DB) (string, error) {
+	now := time.Now()
+	prefix := now.Format("QUO-2006-01")
+
+	var count int64
+	if err := tx.Model(&domain.Quotation{}).
+		Where("quotation_number LIKE ?", prefix+"%").
+		Count(&count).Error; err != nil {
+		return "", errors.WrapError(err, "failed to count quotations for number generation")
+	}
+
+	// Generate quotation number: QUO-YYYY-MM-NNNN
+	return fmt.Sprintf("%s-%04d", prefix, count+1), nil
+}
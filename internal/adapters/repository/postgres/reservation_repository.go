@@ -3,13 +3,16 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jadiazinf/inventory/internal/common/errors"
 	"github.com/jadiazinf/inventory/internal/core/domain"
 	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type reservationRepository struct {
@@ -22,14 +25,14 @@ func NewReservationRepository(db *gorm.DB) repositories.ReservationRepository {
 }
 
 func (r *reservationRepository) Create(ctx context.Context, reservation *domain.Reservation) error {
-	if err := r.db.WithContext(ctx).Create(reservation).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Create(reservation).Error; err != nil {
 		return errors.WrapError(err, "failed to create reservation")
 	}
 	return nil
 }
 
 func (r *reservationRepository) CreateWithItems(ctx context.Context, reservation *domain.Reservation, items []domain.ReservationItem) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		// 1. Generate unique reservation number if not provided
 		if reservation.ReservationNumber == "" {
 			resNum, err := r.generateReservationNumber(tx)
@@ -44,18 +47,19 @@ func (r *reservationRepository) CreateWithItems(ctx context.Context, reservation
 			return errors.WrapError(err, "failed to create reservation")
 		}
 
-		// 3. Get warehouse from store (assume first warehouse of store)
-		var warehouse domain.Warehouse
-		if err := tx.Where("store_id = ?", reservation.StoreID).First(&warehouse).Error; err != nil {
-			return errors.WrapError(err, "failed to find warehouse for store")
-		}
-
-		// 4. Create reservation items and inventory movements
+		// 3. Create reservation items and inventory movements in the warehouse
+		// allocated to each item
 		for i := range items {
 			items[i].ReservationID = reservation.ReservationID
 
+			warehouseID, err := heldWarehouse(tx, reservation, &items[i])
+			if err != nil {
+				return err
+			}
+			items[i].WarehouseID = &warehouseID
+
 			// Calculate amounts
-			items[i].TotalAmount = items[i].Quantity * items[i].UnitPrice
+			items[i].TotalAmount = items[i].Quantity*items[i].UnitPrice - items[i].DiscountAmount
 			items[i].ReservedQuantity = items[i].Quantity
 			items[i].FulfilledQuantity = 0
 			items[i].IsFulfilled = false
@@ -67,8 +71,8 @@ func (r *reservationRepository) CreateWithItems(ctx context.Context, reservation
 
 			// Check inventory availability
 			var inventory domain.Inventory
-			err := tx.Where("product_id = ? AND warehouse_id = ?",
-				items[i].ProductID, warehouse.WarehouseID).First(&inventory).Error
+			err = tx.Where("product_id = ? AND warehouse_id = ?",
+				items[i].ProductID, warehouseID).First(&inventory).Error
 
 			if err == gorm.ErrRecordNotFound {
 				return errors.InsufficientStock("Product", 0, items[i].Quantity)
@@ -92,7 +96,7 @@ func (r *reservationRepository) CreateWithItems(ctx context.Context, reservation
 			movement := &domain.InventoryMovement{
 				MovementID:    uuid.New(),
 				ProductID:     items[i].ProductID,
-				WarehouseID:   warehouse.WarehouseID,
+				WarehouseID:   warehouseID,
 				MovementType:  domain.MovementTypeReservation,
 				Quantity:      items[i].Quantity,
 				UnitCost:      &items[i].UnitPrice,
@@ -112,13 +116,34 @@ func (r *reservationRepository) CreateWithItems(ctx context.Context, reservation
 
 func (r *reservationRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Reservation, error) {
 	var reservation domain.Reservation
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Customer").
 		Preload("Child").
 		Preload("List").
 		Preload("Store").
 		Preload("Items").
 		Preload("Items.Product").
+		Preload("Payments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("payment_date ASC")
+		}).
+		Preload("Modifications", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		First(&reservation, "reservation_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("Reservation", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find reservation")
+	}
+	return &reservation, nil
+}
+
+func (r *reservationRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Reservation, error) {
+	var reservation domain.Reservation
+	err := database.Conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
 		First(&reservation, "reservation_id = ?", id).Error
 
 	if err != nil {
@@ -132,7 +157,7 @@ func (r *reservationRepository) FindByID(ctx context.Context, id uuid.UUID) (*do
 
 func (r *reservationRepository) FindByNumber(ctx context.Context, reservationNumber string) (*domain.Reservation, error) {
 	var reservation domain.Reservation
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Customer").
 		Preload("Items").
 		Preload("Items.Product").
@@ -152,7 +177,7 @@ func (r *reservationRepository) List(ctx context.Context, filters repositories.R
 	var reservations []domain.Reservation
 	var total int64
 
-	query := r.buildFilterQuery(r.db.WithContext(ctx).Model(&domain.Reservation{}), filters)
+	query := r.buildFilterQuery(database.Conn(ctx, r.db).Model(&domain.Reservation{}), filters)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, errors.WrapError(err, "failed to count reservations")
@@ -176,7 +201,7 @@ func (r *reservationRepository) List(ctx context.Context, filters repositories.R
 
 func (r *reservationRepository) GetItems(ctx context.Context, reservationID uuid.UUID) ([]domain.ReservationItem, error) {
 	var items []domain.ReservationItem
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Product").
 		Preload("Product.Category").
 		Where("reservation_id = ?", reservationID).
@@ -189,14 +214,14 @@ func (r *reservationRepository) GetItems(ctx context.Context, reservationID uuid
 }
 
 func (r *reservationRepository) Update(ctx context.Context, reservation *domain.Reservation) error {
-	if err := r.db.WithContext(ctx).Save(reservation).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Save(reservation).Error; err != nil {
 		return errors.WrapError(err, "failed to update reservation")
 	}
 	return nil
 }
 
 func (r *reservationRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.ReservationStatus) error {
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Model(&domain.Reservation{}).
 		Where("reservation_id = ?", id).
 		Update("status", status).Error
@@ -209,7 +234,7 @@ func (r *reservationRepository) UpdateStatus(ctx context.Context, id uuid.UUID,
 
 func (r *reservationRepository) MarkAsFulfilled(ctx context.Context, id uuid.UUID, fulfilledBy uuid.UUID) error {
 	now := time.Now()
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Model(&domain.Reservation{}).
 		Where("reservation_id = ?", id).
 		Updates(map[string]interface{}{
@@ -224,67 +249,405 @@ func (r *reservationRepository) MarkAsFulfilled(ctx context.Context, id uuid.UUI
 	return nil
 }
 
-func (r *reservationRepository) Cancel(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Get reservation with items
-		var reservation domain.Reservation
-		if err := tx.Preload("Items").Preload("Store").
-			First(&reservation, "reservation_id = ?", id).Error; err != nil {
+func (r *reservationRepository) Fulfill(
+	ctx context.Context,
+	reservation *domain.Reservation,
+	items []domain.ReservationItem,
+	sale *domain.Sale,
+	details []domain.SaleDetail,
+	deposit *domain.SaleTender,
+) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := lockHoldingReservation(tx, reservation); err != nil {
+			return err
+		}
+
+		// The pickup was planned on quantities read before the lock
+		var current []domain.ReservationItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("reservation_id = ?", reservation.ReservationID).
+			Find(&current).Error; err != nil {
+			return errors.WrapError(err, "failed to get reservation items")
+		}
+		if !pickupMatches(current, items, details) {
+			return errors.Conflict(fmt.Sprintf("Reservation %s changed during the pickup, try again", reservation.ReservationNumber))
+		}
+
+		// Release the picked quantities first so the sale can take that stock
+		for _, detail := range details {
+			warehouseID := detailWarehouse(sale, &detail)
+			if warehouseID == nil {
+				return errors.InvalidInput("Warehouse is required to release reserved stock")
+			}
+			movement := &domain.InventoryMovement{
+				MovementID:    uuid.New(),
+				ProductID:     detail.ProductID,
+				WarehouseID:   *warehouseID,
+				MovementType:  domain.MovementTypeReservationRelease,
+				Quantity:      detail.Quantity,
+				ReferenceType: stringPtr("RESERVATION_FULFILLMENT"),
+				ReferenceID:   &reservation.ReservationID,
+				Notes:         stringPtr(fmt.Sprintf("Pickup from reservation %s", reservation.ReservationNumber)),
+			}
+			if err := tx.Create(movement).Error; err != nil {
+				return errors.WrapError(err, "failed to create release inventory movement")
+			}
+		}
+
+		sales := &saleRepository{db: tx}
+		if err := sales.CreateWithDetails(ctx, sale, details); err != nil {
+			return err
+		}
+
+		if deposit != nil {
+			deposit.SaleID = sale.SaleID
+			if err := tx.Create(deposit).Error; err != nil {
+				return errors.WrapError(err, "failed to create deposit tender")
+			}
+		}
+
+		for i := range items {
+			if err := tx.Omit(clause.Associations).Save(&items[i]).Error; err != nil {
+				return errors.WrapError(err, "failed to update reservation item")
+			}
+		}
+
+		if err := tx.Omit(clause.Associations).Save(reservation).Error; err != nil {
+			return errors.WrapError(err, "failed to update reservation")
+		}
+		return nil
+	})
+}
+
+func (r *reservationRepository) Cancel(ctx context.Context, reservation *domain.Reservation, settlements []domain.ReservationPayment) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Lock the reservation so a concurrent pickup cannot take the stock being released
+		var current domain.Reservation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&current, "reservation_id = ?", reservation.ReservationID).Error; err != nil {
 			if err == gorm.ErrRecordNotFound {
-				return errors.NotFoundWithID("Reservation", id.String())
+				return errors.NotFoundWithID("Reservation", reservation.ReservationID.String())
 			}
 			return errors.WrapError(err, "failed to find reservation")
 		}
 
-		// Can only cancel pending or confirmed reservations
-		if reservation.Status != domain.ReservationStatusPending &&
-		   reservation.Status != domain.ReservationStatusConfirmed {
-			return errors.BadRequest("Can only cancel pending or confirmed reservations")
+		// Can only cancel reservations that still hold stock
+		if current.Status != domain.ReservationStatusPending &&
+			current.Status != domain.ReservationStatusConfirmed &&
+			current.Status != domain.ReservationStatusPartiallyFulfilled {
+			return errors.BadRequest("Can only cancel pending, confirmed or partially fulfilled reservations")
 		}
 
-		// Get warehouse
-		var warehouse domain.Warehouse
-		if err := tx.Where("store_id = ?", reservation.StoreID).First(&warehouse).Error; err != nil {
-			return errors.WrapError(err, "failed to find warehouse")
+		var items []domain.ReservationItem
+		if err := tx.Where("reservation_id = ?", reservation.ReservationID).Find(&items).Error; err != nil {
+			return errors.WrapError(err, "failed to get reservation items")
 		}
 
-		// Update reservation status
-		if err := tx.Model(&reservation).Update("status", domain.ReservationStatusCancelled).Error; err != nil {
-			return errors.WrapError(err, "failed to cancel reservation")
+		referenceType, notes := "RESERVATION_CANCELLATION", "Release from cancelled reservation"
+		if reservation.Status == domain.ReservationStatusExpired {
+			referenceType, notes = "RESERVATION_EXPIRY", "Release from expired reservation"
 		}
 
 		// Create RESERVATION_RELEASE movements to free up inventory
-		for _, item := range reservation.Items {
+		for _, item := range items {
+			// Items already picked up hold no stock
+			if item.ReservedQuantity <= 0 {
+				continue
+			}
+			warehouseID, err := heldWarehouse(tx, reservation, &item)
+			if err != nil {
+				return err
+			}
 			movement := &domain.InventoryMovement{
 				MovementID:    uuid.New(),
 				ProductID:     item.ProductID,
-				WarehouseID:   warehouse.WarehouseID,
+				WarehouseID:   warehouseID,
 				MovementType:  domain.MovementTypeReservationRelease,
 				Quantity:      item.ReservedQuantity,
-				ReferenceType: stringPtr("RESERVATION_CANCELLATION"),
+				ReferenceType: stringPtr(referenceType),
 				ReferenceID:   &reservation.ReservationID,
-				Notes:         stringPtr("Release from cancelled reservation"),
+				Notes:         stringPtr(notes),
 			}
 			if err := tx.Create(movement).Error; err != nil {
 				return errors.WrapError(err, "failed to create release inventory movement")
 			}
 		}
 
+		if err := tx.Model(&domain.ReservationItem{}).
+			Where("reservation_id = ?", reservation.ReservationID).
+			Update("reserved_quantity", 0).Error; err != nil {
+			return errors.WrapError(err, "failed to update reservation items")
+		}
+
+		// Record what is refunded and what the store retains
+		for i := range settlements {
+			settlements[i].ReservationID = reservation.ReservationID
+			if err := tx.Create(&settlements[i]).Error; err != nil {
+				return errors.WrapError(err, "failed to record reservation settlement")
+			}
+		}
+
+		if err := tx.Omit(clause.Associations).Save(reservation).Error; err != nil {
+			return errors.WrapError(err, "failed to cancel reservation")
+		}
+		return nil
+	})
+}
+
+func (r *reservationRepository) AddPayment(ctx context.Context, reservation *domain.Reservation, payment *domain.ReservationPayment) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var current domain.Reservation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&current, "reservation_id = ?", reservation.ReservationID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.NotFoundWithID("Reservation", reservation.ReservationID.String())
+			}
+			return errors.WrapError(err, "failed to find reservation")
+		}
+
+		// The deposit confirms a pending reservation; installments are paid
+		// on confirmed ones
+		deposit := payment.PaymentType == domain.ReservationPaymentDeposit
+		if (deposit && current.Status != domain.ReservationStatusPending) ||
+			(!deposit && current.Status != domain.ReservationStatusConfirmed &&
+				current.Status != domain.ReservationStatusPartiallyFulfilled) {
+			return errors.Conflict(fmt.Sprintf("Reservation is already %s", current.Status))
+		}
+
+		if payment.AppliedAmount > current.Balance {
+			return errors.InvalidInput(fmt.Sprintf(
+				"Payment of %.2f %s exceeds the outstanding balance of %.2f %s",
+				payment.AppliedAmount, current.Currency, current.Balance, current.Currency,
+			))
+		}
+
+		payment.ReservationID = reservation.ReservationID
+		if err := tx.Create(payment).Error; err != nil {
+			return errors.WrapError(err, "failed to record reservation payment")
+		}
+
+		updates := map[string]interface{}{
+			"amount_paid": gorm.Expr("amount_paid + ?", payment.AppliedAmount),
+			"balance":     gorm.Expr("GREATEST(balance - ?, 0)", payment.AppliedAmount),
+			"updated_at":  time.Now(),
+		}
+		if deposit {
+			updates["status"] = reservation.Status
+		}
+		if err := tx.Model(&domain.Reservation{}).
+			Where("reservation_id = ?", reservation.ReservationID).
+			Updates(updates).Error; err != nil {
+			return errors.WrapError(err, "failed to update reservation")
+		}
+		return nil
+	})
+}
+
+func (r *reservationRepository) GetPayments(ctx context.Context, reservationID uuid.UUID) ([]domain.ReservationPayment, error) {
+	var payments []domain.ReservationPayment
+	err := database.Conn(ctx, r.db).
+		Where("reservation_id = ?", reservationID).
+		Order("payment_date ASC").
+		Find(&payments).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get reservation payments")
+	}
+	return payments, nil
+}
+
+func (r *reservationRepository) ModifyItems(
+	ctx context.Context,
+	reservation *domain.Reservation,
+	changes repositories.ReservationItemChanges,
+	modifications []domain.ReservationModification,
+) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// The changes were planned on the items read before the lock
+		if err := lockUnchangedReservation(tx, reservation, changes.Original); err != nil {
+			return err
+		}
+		held := make(map[uuid.UUID]float64, len(changes.Original))
+		for _, item := range changes.Original {
+			held[item.ReservationItemID] = item.ReservedQuantity
+		}
+
+		// Stock is reserved or released in the warehouse holding each item
+		adjust := func(item *domain.ReservationItem, quantity float64) error {
+			warehouseID, err := heldWarehouse(tx, reservation, item)
+			if err != nil {
+				return err
+			}
+			item.WarehouseID = &warehouseID
+			return adjustReservedStock(tx, reservation, warehouseID, item.ProductID, quantity,
+				"RESERVATION_MODIFICATION", fmt.Sprintf("Change to reservation %s", reservation.ReservationNumber))
+		}
+
+		for _, item := range changes.Removed {
+			if err := adjust(&item, -held[item.ReservationItemID]); err != nil {
+				return err
+			}
+			if err := tx.Delete(&domain.ReservationItem{}, "reservation_item_id = ?", item.ReservationItemID).Error; err != nil {
+				return errors.WrapError(err, "failed to remove reservation item")
+			}
+		}
+
+		for i := range changes.Updated {
+			item := &changes.Updated[i]
+			if err := adjust(item, item.ReservedQuantity-held[item.ReservationItemID]); err != nil {
+				return err
+			}
+			if err := tx.Omit(clause.Associations).Save(item).Error; err != nil {
+				return errors.WrapError(err, "failed to update reservation item")
+			}
+		}
+
+		for i := range changes.Added {
+			item := &changes.Added[i]
+			item.ReservationID = reservation.ReservationID
+			if err := adjust(item, item.ReservedQuantity); err != nil {
+				return err
+			}
+			if err := tx.Omit(clause.Associations).Create(item).Error; err != nil {
+				return errors.WrapError(err, "failed to create reservation item")
+			}
+		}
+
+		for i := range modifications {
+			modifications[i].ReservationID = reservation.ReservationID
+			if err := tx.Omit(clause.Associations).Create(&modifications[i]).Error; err != nil {
+				return errors.WrapError(err, "failed to record reservation modification")
+			}
+		}
+
+		// Only the totals change; payments, pickups and extensions are kept
+		if err := tx.Model(&domain.Reservation{}).
+			Where("reservation_id = ?", reservation.ReservationID).
+			Updates(map[string]interface{}{
+				"total_amount":   reservation.TotalAmount,
+				"deposit_amount": reservation.DepositAmount,
+				"balance":        reservation.Balance,
+				"updated_at":     time.Now(),
+			}).Error; err != nil {
+			return errors.WrapError(err, "failed to update reservation")
+		}
 		return nil
 	})
 }
 
+func (r *reservationRepository) ChangeStore(
+	ctx context.Context,
+	reservation *domain.Reservation,
+	storeID uuid.UUID,
+	warehouses map[uuid.UUID]uuid.UUID,
+	modification *domain.ReservationModification,
+) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// The warehouses were chosen for the items read before the lock
+		if err := lockUnchangedReservation(tx, reservation, reservation.Items); err != nil {
+			return err
+		}
+
+		var items []domain.ReservationItem
+		if err := tx.Where("reservation_id = ? AND reserved_quantity > 0", reservation.ReservationID).Find(&items).Error; err != nil {
+			return errors.WrapError(err, "failed to get reservation items")
+		}
+
+		notes := fmt.Sprintf("Pickup store change of reservation %s", reservation.ReservationNumber)
+		for i := range items {
+			item := &items[i]
+			to, ok := warehouses[item.ReservationItemID]
+			if !ok {
+				return errors.Conflict(fmt.Sprintf("Reservation item %s changed while moving the reservation", item.ReservationItemID))
+			}
+
+			from, err := heldWarehouse(tx, reservation, item)
+			if err != nil {
+				return err
+			}
+
+			// Items whose warehouse also serves the new store keep their stock
+			if from != to {
+				if err := adjustReservedStock(tx, reservation, from, item.ProductID, -item.ReservedQuantity, "RESERVATION_MODIFICATION", notes); err != nil {
+					return err
+				}
+				if err := adjustReservedStock(tx, reservation, to, item.ProductID, item.ReservedQuantity, "RESERVATION_MODIFICATION", notes); err != nil {
+					return err
+				}
+			}
+
+			item.WarehouseID = &to
+			if err := tx.Model(&domain.ReservationItem{}).
+				Where("reservation_item_id = ?", item.ReservationItemID).
+				Update("warehouse_id", to).Error; err != nil {
+				return errors.WrapError(err, "failed to update reservation item")
+			}
+		}
+
+		reservation.StoreID = &storeID
+		modification.ReservationID = reservation.ReservationID
+		if err := tx.Omit(clause.Associations).Create(modification).Error; err != nil {
+			return errors.WrapError(err, "failed to record reservation modification")
+		}
+
+		if err := tx.Model(&domain.Reservation{}).
+			Where("reservation_id = ?", reservation.ReservationID).
+			Updates(map[string]interface{}{
+				"store_id":   storeID,
+				"updated_at": time.Now(),
+			}).Error; err != nil {
+			return errors.WrapError(err, "failed to update reservation")
+		}
+		return nil
+	})
+}
+
+func (r *reservationRepository) Extend(ctx context.Context, reservation *domain.Reservation, modification *domain.ReservationModification) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		modification.ReservationID = reservation.ReservationID
+		if err := tx.Omit(clause.Associations).Create(modification).Error; err != nil {
+			return errors.WrapError(err, "failed to record reservation modification")
+		}
+
+		if err := tx.Model(&domain.Reservation{}).
+			Where("reservation_id = ?", reservation.ReservationID).
+			Updates(map[string]interface{}{
+				"expiration_date":  reservation.ExpirationDate,
+				"extension_count":  reservation.ExtensionCount,
+				"reminder_sent_at": reservation.ReminderSentAt,
+				"updated_at":       time.Now(),
+			}).Error; err != nil {
+			return errors.WrapError(err, "failed to extend reservation")
+		}
+		return nil
+	})
+}
+
+func (r *reservationRepository) GetModifications(ctx context.Context, reservationID uuid.UUID) ([]domain.ReservationModification, error) {
+	var modifications []domain.ReservationModification
+	err := database.Conn(ctx, r.db).
+		Where("reservation_id = ?", reservationID).
+		Order("created_at ASC").
+		Find(&modifications).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get reservation modifications")
+	}
+	return modifications, nil
+}
+
 func (r *reservationRepository) GetExpired(ctx context.Context) ([]domain.Reservation, error) {
 	var reservations []domain.Reservation
 	now := time.Now()
 
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Customer").
 		Preload("Items").
 		Where("expiration_date < ?", now).
 		Where("status IN ?", []domain.ReservationStatus{
 			domain.ReservationStatusPending,
 			domain.ReservationStatusConfirmed,
+			domain.ReservationStatusPartiallyFulfilled,
 		}).
 		Find(&reservations).Error
 
@@ -300,12 +663,13 @@ func (r *reservationRepository) GetExpiringFor(ctx context.Context, within time.
 	now := time.Now()
 	expiryThreshold := now.Add(within)
 
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Customer").
 		Where("expiration_date BETWEEN ? AND ?", now, expiryThreshold).
 		Where("status IN ?", []domain.ReservationStatus{
 			domain.ReservationStatusPending,
 			domain.ReservationStatusConfirmed,
+			domain.ReservationStatusPartiallyFulfilled,
 		}).
 		Where("reminder_sent_at IS NULL").
 		Find(&reservations).Error
@@ -317,6 +681,18 @@ func (r *reservationRepository) GetExpiringFor(ctx context.Context, within time.
 	return reservations, nil
 }
 
+func (r *reservationRepository) MarkReminderSent(ctx context.Context, id uuid.UUID, at time.Time) error {
+	err := database.Conn(ctx, r.db).
+		Model(&domain.Reservation{}).
+		Where("reservation_id = ?", id).
+		Update("reminder_sent_at", at).Error
+
+	if err != nil {
+		return errors.WrapError(err, "failed to mark reservation reminder")
+	}
+	return nil
+}
+
 // Helper functions
 
 func (r *reservationRepository) buildFilterQuery(query *gorm.DB, filters repositories.ReservationFilters) *gorm.DB {
@@ -343,6 +719,196 @@ func (r *reservationRepository) buildFilterQuery(query *gorm.DB, filters reposit
 	return query
 }
 
+// lockHoldingReservation locks a reservation row and checks that it still holds
+// stock and received no payment since the reservation given was read, as the
+// caller saves its totals
+func lockHoldingReservation(tx *gorm.DB, reservation *domain.Reservation) error {
+	_, err := lockHoldingReservationRow(tx, reservation)
+	return err
+}
+
+// lockHoldingReservationRow is lockHoldingReservation returning the locked row
+func lockHoldingReservationRow(tx *gorm.DB, reservation *domain.Reservation) (*domain.Reservation, error) {
+	var current domain.Reservation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&current, "reservation_id = ?", reservation.ReservationID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("Reservation", reservation.ReservationID.String())
+		}
+		return nil, errors.WrapError(err, "failed to find reservation")
+	}
+
+	if current.Status != domain.ReservationStatusPending &&
+		current.Status != domain.ReservationStatusConfirmed &&
+		current.Status != domain.ReservationStatusPartiallyFulfilled {
+		return nil, errors.BadRequest(fmt.Sprintf("Cannot modify reservation with status %s", current.Status))
+	}
+
+	if math.Abs(current.AmountPaid-reservation.AmountPaid) >= 0.005 {
+		return nil, errors.Conflict(fmt.Sprintf("Reservation %s received a payment meanwhile, try again", current.ReservationNumber))
+	}
+	return &current, nil
+}
+
+// lockUnchangedReservation locks a reservation and its items and checks that
+// neither changed since the reservation and the items given were read: same
+// status, payments and deposit credited to pickups, and the same items with
+// the same quantities and warehouses
+func lockUnchangedReservation(tx *gorm.DB, reservation *domain.Reservation, items []domain.ReservationItem) error {
+	current, err := lockHoldingReservationRow(tx, reservation)
+	if err != nil {
+		return err
+	}
+
+	changed := current.Status != reservation.Status ||
+		math.Abs(current.DepositApplied-reservation.DepositApplied) >= 0.005
+
+	if !changed {
+		var currentItems []domain.ReservationItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("reservation_id = ?", reservation.ReservationID).
+			Find(&currentItems).Error; err != nil {
+			return errors.WrapError(err, "failed to get reservation items")
+		}
+		changed = !itemsMatch(currentItems, items)
+	}
+
+	if changed {
+		return errors.Conflict(fmt.Sprintf("Reservation %s changed meanwhile, try again", current.ReservationNumber))
+	}
+	return nil
+}
+
+// itemsMatch checks that two reads of the items of a reservation hold the
+// same items with the same quantities and warehouses
+func itemsMatch(current, items []domain.ReservationItem) bool {
+	if len(current) != len(items) {
+		return false
+	}
+
+	held := make(map[uuid.UUID]domain.ReservationItem, len(current))
+	for _, item := range current {
+		held[item.ReservationItemID] = item
+	}
+
+	for _, item := range items {
+		now, ok := held[item.ReservationItemID]
+		if !ok ||
+			!sameQuantity(item.Quantity, now.Quantity) ||
+			!sameQuantity(item.FulfilledQuantity, now.FulfilledQuantity) ||
+			!sameQuantity(item.ReservedQuantity, now.ReservedQuantity) {
+			return false
+		}
+		if (item.WarehouseID == nil) != (now.WarehouseID == nil) ||
+			(item.WarehouseID != nil && *item.WarehouseID != *now.WarehouseID) {
+			return false
+		}
+	}
+	return true
+}
+
+// pickupMatches checks that the items of a pickup, planned on a snapshot, only
+// differ from the current ones by the picked quantities sold in the details
+func pickupMatches(current, items []domain.ReservationItem, details []domain.SaleDetail) bool {
+	if len(current) != len(items) {
+		return false
+	}
+
+	held := make(map[uuid.UUID]domain.ReservationItem, len(current))
+	for _, item := range current {
+		held[item.ReservationItemID] = item
+	}
+
+	picked := 0.0
+	for _, item := range items {
+		now, ok := held[item.ReservationItemID]
+		if !ok || !sameQuantity(item.Quantity, now.Quantity) {
+			return false
+		}
+
+		quantity := item.FulfilledQuantity - now.FulfilledQuantity
+		if quantity < -quantityTolerance || quantity > now.ReservedQuantity+quantityTolerance {
+			return false
+		}
+		if !sameQuantity(item.ReservedQuantity, math.Max(now.ReservedQuantity-quantity, 0)) {
+			return false
+		}
+		picked += quantity
+	}
+
+	for _, detail := range details {
+		picked -= detail.Quantity
+	}
+	return sameQuantity(picked, 0)
+}
+
+// quantityTolerance absorbs rounding below the precision quantities are stored with
+const quantityTolerance = 0.0005
+
+// sameQuantity compares quantities at the precision they are stored with
+func sameQuantity(a, b float64) bool {
+	return math.Abs(a-b) < quantityTolerance
+}
+
+// heldWarehouse returns the warehouse holding the stock of a reservation item
+func heldWarehouse(tx *gorm.DB, reservation *domain.Reservation, item *domain.ReservationItem) (uuid.UUID, error) {
+	if item.WarehouseID != nil {
+		return *item.WarehouseID, nil
+	}
+	return primaryWarehouseID(tx, reservation.StoreID)
+}
+
+// adjustReservedStock reserves (positive quantity) or releases (negative
+// quantity) stock of a product for a reservation in a warehouse
+func adjustReservedStock(
+	tx *gorm.DB,
+	reservation *domain.Reservation,
+	warehouseID, productID uuid.UUID,
+	quantity float64,
+	referenceType, notes string,
+) error {
+	if quantity == 0 {
+		return nil
+	}
+
+	movementType := domain.MovementTypeReservationRelease
+	if quantity > 0 {
+		movementType = domain.MovementTypeReservation
+
+		var inventory domain.Inventory
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("product_id = ? AND warehouse_id = ?", productID, warehouseID).
+			First(&inventory).Error
+		if err == gorm.ErrRecordNotFound {
+			return errors.InsufficientStock("Product", 0, quantity)
+		} else if err != nil {
+			return errors.WrapError(err, "failed to check inventory")
+		}
+
+		if inventory.AvailableQuantity < quantity {
+			var product domain.Product
+			tx.First(&product, "product_id = ?", productID)
+			return errors.InsufficientStock(product.Name, inventory.AvailableQuantity, quantity)
+		}
+	}
+
+	movement := &domain.InventoryMovement{
+		MovementID:    uuid.New(),
+		ProductID:     productID,
+		WarehouseID:   warehouseID,
+		MovementType:  movementType,
+		Quantity:      math.Abs(quantity),
+		Currency:      reservation.Currency,
+		ReferenceType: stringPtr(referenceType),
+		ReferenceID:   &reservation.ReservationID,
+		Notes:         stringPtr(notes),
+	}
+	if err := tx.Create(movement).Error; err != nil {
+		return errors.WrapError(err, "failed to create reservation inventory movement")
+	}
+	return nil
+}
+
 func (r *reservationRepository) generateReservationNumber(tx *gorm.DB) (string, error) {
 	// Get current year and month
 	now := time.Now()
@@ -9,6 +9,7 @@ import (
 	"github.com/jadiazinf/inventory/internal/common/errors"
 	"github.com/jadiazinf/inventory/internal/core/domain"
 	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
 	"gorm.io/gorm"
 )
 
@@ -22,14 +23,14 @@ func NewSaleRepository(db *gorm.DB) repositories.SaleRepository {
 }
 
 func (r *saleRepository) Create(ctx context.Context, sale *domain.Sale) error {
-	if err := r.db.WithContext(ctx).Create(sale).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Create(sale).Error; err != nil {
 		return errors.WrapError(err, "failed to create sale")
 	}
 	return nil
 }
 
 func (r *saleRepository) CreateWithDetails(ctx context.Context, sale *domain.Sale, details []domain.SaleDetail) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		// 1. Generate unique invoice number if not provided
 		if sale.InvoiceNumber == "" {
 			invoiceNum, err := r.generateInvoiceNumber(tx)
@@ -59,12 +60,16 @@ func (r *saleRepository) CreateWithDetails(ctx context.Context, sale *domain.Sal
 		}
 
 		// 4. Create inventory movements for completed sales
-		if sale.Status == domain.SaleStatusCompleted && sale.WarehouseID != nil {
+		if sale.Status == domain.SaleStatusCompleted {
 			for _, detail := range details {
+				warehouseID := detailWarehouse(sale, &detail)
+				if warehouseID == nil {
+					continue
+				}
 				movement := &domain.InventoryMovement{
 					MovementID:    uuid.New(),
 					ProductID:     detail.ProductID,
-					WarehouseID:   *sale.WarehouseID,
+					WarehouseID:   *warehouseID,
 					MovementType:  domain.MovementTypeOut,
 					Quantity:      detail.Quantity,
 					UnitCost:      &detail.UnitPrice,
@@ -85,13 +90,14 @@ func (r *saleRepository) CreateWithDetails(ctx context.Context, sale *domain.Sal
 
 func (r *saleRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Sale, error) {
 	var sale domain.Sale
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Customer").
 		Preload("Store").
 		Preload("Warehouse").
 		Preload("Salesperson").
 		Preload("Details").
 		Preload("Details.Product").
+		Preload("Tenders").
 		First(&sale, "sale_id = ?", id).Error
 
 	if err != nil {
@@ -105,11 +111,12 @@ func (r *saleRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Sa
 
 func (r *saleRepository) FindByInvoiceNumber(ctx context.Context, invoiceNumber string) (*domain.Sale, error) {
 	var sale domain.Sale
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Customer").
 		Preload("Store").
 		Preload("Details").
 		Preload("Details.Product").
+		Preload("Tenders").
 		Where("invoice_number = ?", invoiceNumber).
 		First(&sale).Error
 
@@ -126,7 +133,7 @@ func (r *saleRepository) List(ctx context.Context, filters repositories.SaleFilt
 	var sales []domain.Sale
 	var total int64
 
-	query := r.buildFilterQuery(r.db.WithContext(ctx).Model(&domain.Sale{}), filters)
+	query := r.buildFilterQuery(database.Conn(ctx, r.db).Model(&domain.Sale{}), filters)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, errors.WrapError(err, "failed to count sales")
@@ -150,7 +157,7 @@ func (r *saleRepository) List(ctx context.Context, filters repositories.SaleFilt
 
 func (r *saleRepository) GetDetails(ctx context.Context, saleID uuid.UUID) ([]domain.SaleDetail, error) {
 	var details []domain.SaleDetail
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Product").
 		Preload("Product.Category").
 		Where("sale_id = ?", saleID).
@@ -163,14 +170,14 @@ func (r *saleRepository) GetDetails(ctx context.Context, saleID uuid.UUID) ([]do
 }
 
 func (r *saleRepository) Update(ctx context.Context, sale *domain.Sale) error {
-	if err := r.db.WithContext(ctx).Save(sale).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Save(sale).Error; err != nil {
 		return errors.WrapError(err, "failed to update sale")
 	}
 	return nil
 }
 
 func (r *saleRepository) Cancel(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		// Get sale with details
 		var sale domain.Sale
 		if err := tx.Preload("Details").First(&sale, "sale_id = ?", id).Error; err != nil {
@@ -190,22 +197,24 @@ func (r *saleRepository) Cancel(ctx context.Context, id uuid.UUID) error {
 			return errors.WrapError(err, "failed to cancel sale")
 		}
 
-		// Create reverse inventory movements (IN)
-		if sale.WarehouseID != nil {
-			for _, detail := range sale.Details {
-				movement := &domain.InventoryMovement{
-					MovementID:    uuid.New(),
-					ProductID:     detail.ProductID,
-					WarehouseID:   *sale.WarehouseID,
-					MovementType:  domain.MovementTypeIn,
-					Quantity:      detail.Quantity,
-					ReferenceType: stringPtr("SALE_CANCELLATION"),
-					ReferenceID:   &sale.SaleID,
-					Notes:         stringPtr("Reversal from cancelled sale"),
-				}
-				if err := tx.Create(movement).Error; err != nil {
-					return errors.WrapError(err, "failed to create reverse inventory movement")
-				}
+		// Create reverse inventory movements (IN) into the warehouse each line left from
+		for _, detail := range sale.Details {
+			warehouseID := detailWarehouse(&sale, &detail)
+			if warehouseID == nil {
+				continue
+			}
+			movement := &domain.InventoryMovement{
+				MovementID:    uuid.New(),
+				ProductID:     detail.ProductID,
+				WarehouseID:   *warehouseID,
+				MovementType:  domain.MovementTypeIn,
+				Quantity:      detail.Quantity,
+				ReferenceType: stringPtr("SALE_CANCELLATION"),
+				ReferenceID:   &sale.SaleID,
+				Notes:         stringPtr("Reversal from cancelled sale"),
+			}
+			if err := tx.Create(movement).Error; err != nil {
+				return errors.WrapError(err, "failed to create reverse inventory movement")
 			}
 		}
 
@@ -220,7 +229,7 @@ func (r *saleRepository) GetDailySales(ctx context.Context, storeID *uuid.UUID,
 	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
 	endOfDay := startOfDay.Add(24 * time.Hour)
 
-	query := r.db.WithContext(ctx).
+	query := database.Conn(ctx, r.db).
 		Preload("Customer").
 		Preload("Salesperson").
 		Where("sale_date >= ? AND sale_date < ?", startOfDay, endOfDay).
@@ -240,7 +249,7 @@ func (r *saleRepository) GetDailySales(ctx context.Context, storeID *uuid.UUID,
 
 func (r *saleRepository) GetSalesByPeriod(ctx context.Context, from, to time.Time) ([]domain.Sale, error) {
 	var sales []domain.Sale
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Customer").
 		Preload("Store").
 		Where("sale_date >= ? AND sale_date <= ?", from, to).
@@ -306,3 +315,11 @@ func (r *saleRepository) generateInvoiceNumber(tx *gorm.DB) (string, error) {
 	invoiceNumber := fmt.Sprintf("%s-%04d", prefix, count+1)
 	return invoiceNumber, nil
 }
+
+// detailWarehouse returns the warehouse a sale line takes its stock from
+func detailWarehouse(sale *domain.Sale, detail *domain.SaleDetail) *uuid.UUID {
+	if detail.WarehouseID != nil {
+		return detail.WarehouseID
+	}
+	return sale.WarehouseID
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type schoolRepository struct {
+	db *gorm.DB
+}
+
+// NewSchoolRepository creates a new school repository
+func NewSchoolRepository(db *gorm.DB) repositories.SchoolRepository {
+	return &schoolRepository{db: db}
+}
+
+func (r *schoolRepository) Create(ctx context.Context, school *domain.School) error {
+	if err := database.Conn(ctx, r.db).Omit(clause.Associations).Create(school).Error; err != nil {
+		return errors.WrapError(err, "failed to create school")
+	}
+	return nil
+}
+
+func (r *schoolRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.School, error) {
+	var school domain.School
+	err := database.Conn(ctx, r.db).
+		Preload("Location").
+		First(&school, "school_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("School", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find school")
+	}
+	return &school, nil
+}
+
+func (r *schoolRepository) List(ctx context.Context, filters repositories.SchoolFilters, limit, offset int) ([]domain.School, int64, error) {
+	var schools []domain.School
+	var total int64
+
+	query := r.buildFilterQuery(database.Conn(ctx, r.db).Model(&domain.School{}), filters)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.WrapError(err, "failed to count schools")
+	}
+
+	err := query.
+		Preload("Location").
+		Order("name ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&schools).Error
+
+	if err != nil {
+		return nil, 0, errors.WrapError(err, "failed to list schools")
+	}
+
+	return schools, total, nil
+}
+
+func (r *schoolRepository) Update(ctx context.Context, school *domain.School) error {
+	if err := database.Conn(ctx, r.db).Omit(clause.Associations).Save(school).Error; err != nil {
+		return errors.WrapError(err, "failed to update school")
+	}
+	return nil
+}
+
+func (r *schoolRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := database.Conn(ctx, r.db).Delete(&domain.School{}, "school_id = ?", id).Error; err != nil {
+		return errors.WrapError(err, "failed to delete school")
+	}
+	return nil
+}
+
+// Helper functions
+
+func (r *schoolRepository) buildFilterQuery(query *gorm.DB, filters repositories.SchoolFilters) *gorm.DB {
+	if filters.LocationID != nil {
+		query = query.Where("location_id = ?", *filters.LocationID)
+	}
+
+	if filters.Level != nil {
+		query = query.Where("? = ANY(levels) OR levels IS NULL OR cardinality(levels) = 0", *filters.Level)
+	}
+
+	if filters.IsActive != nil {
+		query = query.Where("is_active = ?", *filters.IsActive)
+	}
+
+	if filters.Search != nil && *filters.Search != "" {
+		query = query.Where("name ILIKE ?", "%"+*filters.Search+"%")
+	}
+
+	return query
+}
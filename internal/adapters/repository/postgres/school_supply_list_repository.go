@@ -0,0 +1,243 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type schoolSupplyListRepository struct {
+	db *gorm.DB
+}
+
+// NewSchoolSupplyListRepository creates a new school supply list repository
+func NewSchoolSupplyListRepository(db *gorm.DB) repositories.SchoolSupplyListRepository {
+	return &schoolSupplyListRepository{db: db}
+}
+
+func (r *schoolSupplyListRepository) Create(ctx context.Context, list *domain.SchoolSupplyList) error {
+	if err := database.Conn(ctx, r.db).Omit(clause.Associations).Create(list).Error; err != nil {
+		return errors.WrapError(err, "failed to create school supply list")
+	}
+	return nil
+}
+
+func (r *schoolSupplyListRepository) CreateWithItems(ctx context.Context, list *domain.SchoolSupplyList, items []domain.SchoolSupplyListItem) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(list).Error; err != nil {
+			return errors.WrapError(err, "failed to create school supply list")
+		}
+
+		return r.createItems(tx, list.ListID, items)
+	})
+}
+
+func (r *schoolSupplyListRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.SchoolSupplyList, error) {
+	var list domain.SchoolSupplyList
+	err := database.Conn(ctx, r.db).
+		Preload("School").
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("display_order ASC NULLS LAST, created_at ASC")
+		}).
+		Preload("Items.Product").
+		Preload("Items.Alternatives").
+		Preload("Items.Alternatives.AlternativeProduct").
+		First(&list, "list_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("SchoolSupplyList", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find school supply list")
+	}
+	return &list, nil
+}
+
+func (r *schoolSupplyListRepository) FindBySchoolLevel(ctx context.Context, level domain.SchoolLevel, schoolYear string) ([]domain.SchoolSupplyList, error) {
+	var lists []domain.SchoolSupplyList
+	err := database.Conn(ctx, r.db).
+		Where("school_level = ? AND school_year = ?", level, schoolYear).
+		Where("status IN ?", publicListStatuses).
+		Order("grade ASC, list_name ASC").
+		Find(&lists).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find school supply lists by level")
+	}
+	return lists, nil
+}
+
+func (r *schoolSupplyListRepository) GetActive(ctx context.Context) ([]domain.SchoolSupplyList, error) {
+	var lists []domain.SchoolSupplyList
+	err := database.Conn(ctx, r.db).
+		Where("status IN ?", publicListStatuses).
+		Where("expiration_date IS NULL OR expiration_date >= ?", time.Now().Truncate(24*time.Hour)).
+		Order("school_year DESC, school_level ASC, grade ASC").
+		Find(&lists).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get active school supply lists")
+	}
+	return lists, nil
+}
+
+func (r *schoolSupplyListRepository) List(ctx context.Context, filters repositories.SchoolSupplyListFilters, limit, offset int) ([]domain.SchoolSupplyList, int64, error) {
+	var lists []domain.SchoolSupplyList
+	var total int64
+
+	query := r.buildFilterQuery(database.Conn(ctx, r.db).Model(&domain.SchoolSupplyList{}), filters)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.WrapError(err, "failed to count school supply lists")
+	}
+
+	err := query.
+		Preload("School").
+		Order("school_year DESC, school_level ASC, grade ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&lists).Error
+
+	if err != nil {
+		return nil, 0, errors.WrapError(err, "failed to list school supply lists")
+	}
+
+	return lists, total, nil
+}
+
+func (r *schoolSupplyListRepository) GetItems(ctx context.Context, listID uuid.UUID) ([]domain.SchoolSupplyListItem, error) {
+	var items []domain.SchoolSupplyListItem
+	err := database.Conn(ctx, r.db).
+		Preload("Product").
+		Preload("Alternatives").
+		Preload("Alternatives.AlternativeProduct").
+		Where("list_id = ?", listID).
+		Order("display_order ASC NULLS LAST, created_at ASC").
+		Find(&items).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get school supply list items")
+	}
+	return items, nil
+}
+
+func (r *schoolSupplyListRepository) ReplaceItems(ctx context.Context, listID uuid.UUID, items []domain.SchoolSupplyListItem) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		existing := tx.Model(&domain.SchoolSupplyListItem{}).Select("list_item_id").Where("list_id = ?", listID)
+		if err := tx.Where("list_item_id IN (?)", existing).Delete(&domain.ListItemAlternative{}).Error; err != nil {
+			return errors.WrapError(err, "failed to delete list item alternatives")
+		}
+
+		if err := tx.Where("list_id = ?", listID).Delete(&domain.SchoolSupplyListItem{}).Error; err != nil {
+			return errors.WrapError(err, "failed to delete school supply list items")
+		}
+
+		return r.createItems(tx, listID, items)
+	})
+}
+
+func (r *schoolSupplyListRepository) Update(ctx context.Context, list *domain.SchoolSupplyList) error {
+	if err := database.Conn(ctx, r.db).Omit(clause.Associations).Save(list).Error; err != nil {
+		return errors.WrapError(err, "failed to update school supply list")
+	}
+	return nil
+}
+
+func (r *schoolSupplyListRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := database.Conn(ctx, r.db).Delete(&domain.SchoolSupplyList{}, "list_id = ?", id).Error; err != nil {
+		return errors.WrapError(err, "failed to delete school supply list")
+	}
+	return nil
+}
+
+func (r *schoolSupplyListRepository) Publish(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	err := database.Conn(ctx, r.db).
+		Model(&domain.SchoolSupplyList{}).
+		Where("list_id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       domain.SchoolListStatusPublished,
+			"publish_date": now,
+			"updated_at":   now,
+		}).Error
+
+	if err != nil {
+		return errors.WrapError(err, "failed to publish school supply list")
+	}
+	return nil
+}
+
+func (r *schoolSupplyListRepository) Archive(ctx context.Context, id uuid.UUID) error {
+	err := database.Conn(ctx, r.db).
+		Model(&domain.SchoolSupplyList{}).
+		Where("list_id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     domain.SchoolListStatusArchived,
+			"updated_at": time.Now(),
+		}).Error
+
+	if err != nil {
+		return errors.WrapError(err, "failed to archive school supply list")
+	}
+	return nil
+}
+
+// Helper functions
+
+var publicListStatuses = []domain.SchoolListStatus{domain.SchoolListStatusPublished, domain.SchoolListStatusActive}
+
+func (r *schoolSupplyListRepository) createItems(tx *gorm.DB, listID uuid.UUID, items []domain.SchoolSupplyListItem) error {
+	for i := range items {
+		items[i].ListID = listID
+		if err := tx.Omit(clause.Associations).Create(&items[i]).Error; err != nil {
+			return errors.WrapError(err, "failed to create school supply list item")
+		}
+
+		for j := range items[i].Alternatives {
+			items[i].Alternatives[j].ListItemID = items[i].ListItemID
+			if err := tx.Omit(clause.Associations).Create(&items[i].Alternatives[j]).Error; err != nil {
+				return errors.WrapError(err, "failed to create list item alternative")
+			}
+		}
+	}
+	return nil
+}
+
+func (r *schoolSupplyListRepository) buildFilterQuery(query *gorm.DB, filters repositories.SchoolSupplyListFilters) *gorm.DB {
+	if filters.SchoolID != nil {
+		query = query.Where("school_id = ?", *filters.SchoolID)
+	}
+
+	if filters.SchoolLevel != nil {
+		query = query.Where("school_level = ?", *filters.SchoolLevel)
+	}
+
+	if filters.Grade != nil {
+		query = query.Where("grade = ?", *filters.Grade)
+	}
+
+	if filters.SchoolYear != nil {
+		query = query.Where("school_year = ?", *filters.SchoolYear)
+	}
+
+	if len(filters.Statuses) > 0 {
+		query = query.Where("status IN ?", filters.Statuses)
+	}
+
+	if filters.IsTemplate != nil {
+		query = query.Where("is_template = ?", *filters.IsTemplate)
+	}
+
+	if filters.Search != nil && *filters.Search != "" {
+		query = query.Where("list_name ILIKE ?", "%"+*filters.Search+"%")
+	}
+
+	return query
+}
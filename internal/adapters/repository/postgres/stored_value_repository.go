@@ -0,0 +1,244 @@
+package postgres
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type storedValueRepository struct {
+	db *gorm.DB
+}
+
+// NewStoredValueRepository creates a new stored-value repository
+func NewStoredValueRepository(db *gorm.DB) repositories.StoredValueRepository {
+	return &storedValueRepository{db: db}
+}
+
+func (r *storedValueRepository) CreateAccount(ctx context.Context, account *domain.StoredValueAccount, issue *domain.StoredValueTransaction) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(account).Error; err != nil {
+			return errors.WrapError(err, "failed to create stored value account")
+		}
+
+		if issue != nil {
+			issue.AccountID = account.AccountID
+			issue.BalanceAfter = account.Balance
+			if err := tx.Create(issue).Error; err != nil {
+				return errors.WrapError(err, "failed to create stored value transaction")
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *storedValueRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.StoredValueAccount, error) {
+	var account domain.StoredValueAccount
+	err := database.Conn(ctx, r.db).
+		Preload("Customer").
+		First(&account, "account_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("StoredValueAccount", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find stored value account")
+	}
+	return &account, nil
+}
+
+func (r *storedValueRepository) FindByCode(ctx context.Context, code string) (*domain.StoredValueAccount, error) {
+	var account domain.StoredValueAccount
+	err := database.Conn(ctx, r.db).
+		Preload("Customer").
+		Where("code = ?", code).
+		First(&account).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("GiftCard")
+		}
+		return nil, errors.WrapError(err, "failed to find gift card by code")
+	}
+	return &account, nil
+}
+
+func (r *storedValueRepository) FindStoreCredit(ctx context.Context, customerID uuid.UUID, currency domain.CurrencyCode) (*domain.StoredValueAccount, error) {
+	var account domain.StoredValueAccount
+	err := database.Conn(ctx, r.db).
+		Where("account_type = ? AND customer_id = ? AND currency = ?", domain.StoredValueTypeStoreCredit, customerID, currency).
+		First(&account).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("StoreCredit")
+		}
+		return nil, errors.WrapError(err, "failed to find store credit account")
+	}
+	return &account, nil
+}
+
+func (r *storedValueRepository) List(ctx context.Context, filters repositories.StoredValueFilters, limit, offset int) ([]domain.StoredValueAccount, int64, error) {
+	var accounts []domain.StoredValueAccount
+	var total int64
+
+	query := r.buildFilterQuery(database.Conn(ctx, r.db).Model(&domain.StoredValueAccount{}), filters)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.WrapError(err, "failed to count stored value accounts")
+	}
+
+	err := query.
+		Preload("Customer").
+		Order("issued_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&accounts).Error
+
+	if err != nil {
+		return nil, 0, errors.WrapError(err, "failed to list stored value accounts")
+	}
+
+	return accounts, total, nil
+}
+
+func (r *storedValueRepository) GetExpired(ctx context.Context, at time.Time) ([]domain.StoredValueAccount, error) {
+	var accounts []domain.StoredValueAccount
+	err := database.Conn(ctx, r.db).
+		Where("status IN ?", []domain.StoredValueStatus{domain.StoredValueStatusActive, domain.StoredValueStatusExhausted}).
+		Where("expires_at IS NOT NULL AND expires_at <= ?", at).
+		Order("expires_at ASC").
+		Find(&accounts).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get expired stored value accounts")
+	}
+	return accounts, nil
+}
+
+func (r *storedValueRepository) CreateTransaction(ctx context.Context, txn *domain.StoredValueTransaction) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return r.appendTransaction(tx, txn)
+	})
+}
+
+func (r *storedValueRepository) CreateRedemption(ctx context.Context, txn *domain.StoredValueTransaction, tender *domain.SaleTender) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := r.appendTransaction(tx, txn); err != nil {
+			return err
+		}
+
+		if err := tx.Create(tender).Error; err != nil {
+			return errors.WrapError(err, "failed to create sale tender")
+		}
+		return nil
+	})
+}
+
+func (r *storedValueRepository) GetTransactions(ctx context.Context, accountID uuid.UUID) ([]domain.StoredValueTransaction, error) {
+	var txns []domain.StoredValueTransaction
+	err := database.Conn(ctx, r.db).
+		Where("account_id = ?", accountID).
+		Order("created_at ASC").
+		Find(&txns).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get stored value transactions")
+	}
+	return txns, nil
+}
+
+func (r *storedValueRepository) FindTransactionsBySale(ctx context.Context, saleID uuid.UUID) ([]domain.StoredValueTransaction, error) {
+	var txns []domain.StoredValueTransaction
+	err := database.Conn(ctx, r.db).
+		Where("sale_id = ?", saleID).
+		Order("created_at ASC").
+		Find(&txns).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to find stored value transactions by sale")
+	}
+	return txns, nil
+}
+
+// Helper functions
+
+// appendTransaction writes a movement inside tx and updates balance and status
+func (r *storedValueRepository) appendTransaction(tx *gorm.DB, txn *domain.StoredValueTransaction) error {
+	var account domain.StoredValueAccount
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&account, "account_id = ?", txn.AccountID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.NotFoundWithID("StoredValueAccount", txn.AccountID.String())
+		}
+		return errors.WrapError(err, "failed to lock stored value account")
+	}
+
+	if txn.TransactionType == domain.StoredValueTransactionTypeRedeem {
+		if account.Status != domain.StoredValueStatusActive || account.IsExpired(time.Now()) {
+			return errors.BadRequest("Stored value account is not active")
+		}
+	}
+
+	balance := math.Round((account.Balance+txn.Amount)*100) / 100
+	if balance < 0 {
+		return errors.BadRequest("Insufficient stored value balance")
+	}
+
+	status := account.Status
+	switch {
+	case txn.TransactionType == domain.StoredValueTransactionTypeExpire:
+		status = domain.StoredValueStatusExpired
+	case balance == 0 && status == domain.StoredValueStatusActive:
+		status = domain.StoredValueStatusExhausted
+	case balance > 0 && status == domain.StoredValueStatusExhausted:
+		status = domain.StoredValueStatusActive
+	}
+
+	txn.BalanceAfter = balance
+	if err := tx.Create(txn).Error; err != nil {
+		return errors.WrapError(err, "failed to create stored value transaction")
+	}
+
+	err = tx.Model(&domain.StoredValueAccount{}).
+		Where("account_id = ?", txn.AccountID).
+		Updates(map[string]interface{}{
+			"balance": balance,
+			"status":  status,
+		}).Error
+	if err != nil {
+		return errors.WrapError(err, "failed to update stored value balance")
+	}
+
+	return nil
+}
+
+func (r *storedValueRepository) buildFilterQuery(query *gorm.DB, filters repositories.StoredValueFilters) *gorm.DB {
+	if filters.AccountType != nil {
+		query = query.Where("account_type = ?", *filters.AccountType)
+	}
+
+	if filters.CustomerID != nil {
+		query = query.Where("customer_id = ?", *filters.CustomerID)
+	}
+
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+
+	if filters.Currency != nil {
+		query = query.Where("currency = ?", *filters.Currency)
+	}
+
+	return query
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/jadiazinf/inventory/internal/common/errors"
 	"github.com/jadiazinf/inventory/internal/core/domain"
 	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
 	"gorm.io/gorm"
 )
 
@@ -21,7 +22,7 @@ func NewUserRepository(db *gorm.DB) repositories.UserRepository {
 }
 
 func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
-	if err := r.db.WithContext(ctx).Create(user).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Create(user).Error; err != nil {
 		return errors.WrapError(err, "failed to create user")
 	}
 	return nil
@@ -29,7 +30,7 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 
 func (r *userRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	var user domain.User
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Role").
 		Preload("Location").
 		First(&user, "user_id = ?", id).Error
@@ -45,7 +46,7 @@ func (r *userRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Us
 
 func (r *userRepository) FindByFirebaseUID(ctx context.Context, firebaseUID string) (*domain.User, error) {
 	var user domain.User
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Role").
 		Preload("Role.Permissions").
 		Preload("Location").
@@ -63,7 +64,7 @@ func (r *userRepository) FindByFirebaseUID(ctx context.Context, firebaseUID stri
 
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var user domain.User
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Role").
 		Preload("Location").
 		Where("email = ?", email).
@@ -82,11 +83,11 @@ func (r *userRepository) List(ctx context.Context, limit, offset int) ([]domain.
 	var users []domain.User
 	var total int64
 
-	if err := r.db.WithContext(ctx).Model(&domain.User{}).Count(&total).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Model(&domain.User{}).Count(&total).Error; err != nil {
 		return nil, 0, errors.WrapError(err, "failed to count users")
 	}
 
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Preload("Role").
 		Preload("Location").
 		Limit(limit).
@@ -101,14 +102,14 @@ func (r *userRepository) List(ctx context.Context, limit, offset int) ([]domain.
 }
 
 func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
-	if err := r.db.WithContext(ctx).Save(user).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Save(user).Error; err != nil {
 		return errors.WrapError(err, "failed to update user")
 	}
 	return nil
 }
 
 func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	if err := r.db.WithContext(ctx).Delete(&domain.User{}, "user_id = ?", id).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Delete(&domain.User{}, "user_id = ?", id).Error; err != nil {
 		return errors.WrapError(err, "failed to delete user")
 	}
 	return nil
@@ -116,7 +117,7 @@ func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 func (r *userRepository) UpdateLastLogin(ctx context.Context, userID uuid.UUID) error {
 	now := time.Now()
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Model(&domain.User{}).
 		Where("user_id = ?", userID).
 		Update("last_login", now).Error
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/platform/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type warehouseRepository struct {
+	db *gorm.DB
+}
+
+// NewWarehouseRepository creates a new warehouse repository
+func NewWarehouseRepository(db *gorm.DB) repositories.WarehouseRepository {
+	return &warehouseRepository{db: db}
+}
+
+func (r *warehouseRepository) Create(ctx context.Context, warehouse *domain.Warehouse) error {
+	if err := database.Conn(ctx, r.db).Omit(clause.Associations).Create(warehouse).Error; err != nil {
+		return errors.WrapError(err, "failed to create warehouse")
+	}
+	return nil
+}
+
+func (r *warehouseRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Warehouse, error) {
+	var warehouse domain.Warehouse
+	err := database.Conn(ctx, r.db).
+		Preload("Location").
+		First(&warehouse, "warehouse_id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("Warehouse", id.String())
+		}
+		return nil, errors.WrapError(err, "failed to find warehouse")
+	}
+	return &warehouse, nil
+}
+
+func (r *warehouseRepository) FindByCode(ctx context.Context, code string) (*domain.Warehouse, error) {
+	var warehouse domain.Warehouse
+	err := database.Conn(ctx, r.db).
+		Preload("Location").
+		First(&warehouse, "code = ?", code).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("Warehouse", code)
+		}
+		return nil, errors.WrapError(err, "failed to find warehouse")
+	}
+	return &warehouse, nil
+}
+
+// FindByStore returns the warehouses of a store, primary first
+func (r *warehouseRepository) FindByStore(ctx context.Context, storeID uuid.UUID) ([]domain.Warehouse, error) {
+	var warehouses []domain.Warehouse
+	err := database.Conn(ctx, r.db).
+		Preload("Location").
+		Where("store_id = ?", storeID).
+		Order("is_primary DESC").
+		Order("code ASC").
+		Find(&warehouses).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to get store warehouses")
+	}
+	return warehouses, nil
+}
+
+func (r *warehouseRepository) List(ctx context.Context) ([]domain.Warehouse, error) {
+	var warehouses []domain.Warehouse
+	err := database.Conn(ctx, r.db).
+		Preload("Location").
+		Order("code ASC").
+		Find(&warehouses).Error
+
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to list warehouses")
+	}
+	return warehouses, nil
+}
+
+func (r *warehouseRepository) Update(ctx context.Context, warehouse *domain.Warehouse) error {
+	if err := database.Conn(ctx, r.db).Omit(clause.Associations).Save(warehouse).Error; err != nil {
+		return errors.WrapError(err, "failed to update warehouse")
+	}
+	return nil
+}
+
+func (r *warehouseRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := database.Conn(ctx, r.db).Delete(&domain.Warehouse{}, "warehouse_id = ?", id).Error; err != nil {
+		return errors.WrapError(err, "failed to delete warehouse")
+	}
+	return nil
+}
+
+// Helper functions
+
+// primaryWarehouseID returns the warehouse that serves a store by default.
+// Stock held before per-item warehouses were recorded lives there.
+func primaryWarehouseID(tx *gorm.DB, storeID *uuid.UUID) (uuid.UUID, error) {
+	if storeID == nil {
+		return uuid.Nil, errors.InvalidInput("Store is required to find its warehouse")
+	}
+
+	var warehouse domain.Warehouse
+	err := tx.Where("store_id = ? AND is_active = ?", *storeID, true).
+		Order("is_primary DESC").
+		Order("code ASC").
+		First(&warehouse).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return uuid.Nil, errors.NotFound(fmt.Sprintf("Warehouse for store %s", *storeID))
+		}
+		return uuid.Nil, errors.WrapError(err, "failed to find warehouse for store")
+	}
+	return warehouse.WarehouseID, nil
+}
@@ -22,8 +22,22 @@ func (s *Server) setupRoutes() {
 		s.setupProductRoutes(api)
 		s.setupCustomerRoutes(api)
 		s.setupSaleRoutes(api)
+		s.setupAccountsReceivableRoutes(api)
+		s.setupAccountsPayableRoutes(api)
+		s.setupExpenseRoutes(api)
+		s.setupPayrollRoutes(api)
+		s.setupEmployeeRoutes(api)
 		s.setupReservationRoutes(api)
 		s.setupInventoryRoutes(api)
+		s.setupCampaignRoutes(api)
+		s.setupLoyaltyRoutes(api)
+		s.setupStoredValueRoutes(api)
+		s.setupQuotationRoutes(api)
+		s.setupSchoolRoutes(api)
+		s.setupSchoolSupplyListRoutes(api)
+		s.setupForecastRoutes(api)
+		s.setupPreOrderRoutes(api)
+		s.setupJobRoutes(api)
 	}
 }
 
@@ -74,7 +88,12 @@ func (s *Server) setupCustomerRoutes(api fiber.Router) {
 	customers.Get("/:id/children", s.handlers.CustomerHandler.GetChildren)
 	customers.Put("/:id/children/:childId", s.handlers.CustomerHandler.UpdateChild)
 	customers.Delete("/:id/children/:childId", s.handlers.CustomerHandler.DeleteChild)
-	customers.Put("/:id/loyalty-points", s.handlers.CustomerHandler.UpdateLoyaltyPoints)
+
+	// Loyalty points go through the ledger
+	if s.handlers.LoyaltyHandler != nil {
+		customers.Put("/:id/loyalty-points", s.handlers.LoyaltyHandler.AdjustPoints)
+		customers.Get("/:id/loyalty/statement", s.handlers.LoyaltyHandler.GetStatement)
+	}
 }
 
 func (s *Server) setupSaleRoutes(api fiber.Router) {
@@ -96,14 +115,168 @@ func (s *Server) setupSaleRoutes(api fiber.Router) {
 	sales.Post("/", s.handlers.SaleHandler.CreateSale)
 	sales.Post("/credit", s.handlers.SaleHandler.CreateCreditSale)
 	sales.Post("/:id/cancel", s.handlers.SaleHandler.CancelSale)
+}
+
+func (s *Server) setupAccountsPayableRoutes(api fiber.Router) {
+	if s.handlers.AccountsPayableHandler == nil {
+		return
+	}
+
+	ap := api.Group("/accounts-payable")
+
+	// All accounts payable routes require authentication
+	if s.authMiddleware != nil {
+		ap.Use(s.authMiddleware.Authenticate())
+	}
+
+	ap.Get("/aging", s.handlers.AccountsPayableHandler.GetAgingReport)
+	ap.Get("/cash-requirements", s.handlers.AccountsPayableHandler.GetCashRequirements)
+
+	ap.Get("/invoices", s.handlers.AccountsPayableHandler.ListInvoices)
+	ap.Post("/invoices", s.handlers.AccountsPayableHandler.CreateInvoice)
+	ap.Get("/invoices/:id", s.handlers.AccountsPayableHandler.GetInvoice)
+	ap.Post("/invoices/:id/cancel", s.handlers.AccountsPayableHandler.CancelInvoice)
+	ap.Get("/invoices/:id/payments", s.handlers.AccountsPayableHandler.GetPayments)
+	ap.Post("/invoices/:id/payments", s.handlers.AccountsPayableHandler.PayInvoice)
+}
+
+func (s *Server) setupExpenseRoutes(api fiber.Router) {
+	if s.handlers.ExpenseHandler == nil {
+		return
+	}
+
+	expenses := api.Group("/expenses")
+
+	// All expense routes require authentication
+	if s.authMiddleware != nil {
+		expenses.Use(s.authMiddleware.Authenticate())
+	}
+
+	expenses.Get("/summary", s.handlers.ExpenseHandler.GetMonthlySummary)
+
+	expenses.Get("/approval-rules", s.handlers.ExpenseHandler.ListApprovalRules)
+	expenses.Post("/approval-rules", s.handlers.ExpenseHandler.CreateApprovalRule)
+	expenses.Get("/approval-rules/:id", s.handlers.ExpenseHandler.GetApprovalRule)
+	expenses.Put("/approval-rules/:id", s.handlers.ExpenseHandler.UpdateApprovalRule)
+	expenses.Delete("/approval-rules/:id", s.handlers.ExpenseHandler.DeleteApprovalRule)
+
+	expenses.Get("/", s.handlers.ExpenseHandler.ListExpenses)
+	expenses.Post("/", s.handlers.ExpenseHandler.CreateExpense)
+	expenses.Get("/:id", s.handlers.ExpenseHandler.GetExpense)
+	expenses.Post("/:id/approve", s.handlers.ExpenseHandler.ApproveExpense)
+	expenses.Post("/:id/reject", s.handlers.ExpenseHandler.RejectExpense)
+}
+
+func (s *Server) setupPayrollRoutes(api fiber.Router) {
+	if s.handlers.PayrollHandler == nil {
+		return
+	}
+
+	payroll := api.Group("/payroll")
+
+	// All payroll routes require authentication
+	if s.authMiddleware != nil {
+		payroll.Use(s.authMiddleware.Authenticate())
+	}
+
+	payroll.Get("/concepts", s.handlers.PayrollHandler.ListConcepts)
+	payroll.Post("/concepts", s.handlers.PayrollHandler.CreateConcept)
+	payroll.Get("/concepts/:id", s.handlers.PayrollHandler.GetConcept)
+	payroll.Put("/concepts/:id", s.handlers.PayrollHandler.UpdateConcept)
+	payroll.Delete("/concepts/:id", s.handlers.PayrollHandler.DeleteConcept)
+
+	payroll.Get("/periods", s.handlers.PayrollHandler.ListPeriods)
+	payroll.Post("/periods", s.handlers.PayrollHandler.CreatePeriod)
+	payroll.Get("/periods/:id", s.handlers.PayrollHandler.GetPeriod)
+	payroll.Post("/periods/:id/calculate", s.handlers.PayrollHandler.CalculatePeriod)
+	payroll.Post("/periods/:id/process", s.handlers.PayrollHandler.ProcessPeriod)
+	payroll.Post("/periods/:id/pay", s.handlers.PayrollHandler.PayPeriod)
+	payroll.Post("/periods/:id/close", s.handlers.PayrollHandler.ClosePeriod)
+	payroll.Get("/periods/:id/payslips", s.handlers.PayrollHandler.GetPayslips)
+	payroll.Get("/periods/:id/totals", s.handlers.PayrollHandler.GetPeriodTotals)
+
+	payroll.Get("/payslips/:id", s.handlers.PayrollHandler.GetPayslip)
+}
+
+func (s *Server) setupEmployeeRoutes(api fiber.Router) {
+	if s.handlers.EmployeeHandler == nil {
+		return
+	}
+
+	employees := api.Group("/employees")
+
+	// All employee routes require authentication
+	if s.authMiddleware != nil {
+		employees.Use(s.authMiddleware.Authenticate())
+	}
+
+	employees.Get("/", s.handlers.EmployeeHandler.ListEmployees)
+	employees.Post("/", s.handlers.EmployeeHandler.CreateEmployee)
+	employees.Get("/:id", s.handlers.EmployeeHandler.GetEmployee)
+	employees.Put("/:id", s.handlers.EmployeeHandler.UpdateEmployee)
+	employees.Delete("/:id", s.handlers.EmployeeHandler.DeleteEmployee)
+
+	employees.Post("/:id/activate", s.handlers.EmployeeHandler.ActivateEmployee)
+	employees.Post("/:id/leave", s.handlers.EmployeeHandler.PutOnLeave)
+	employees.Post("/:id/deactivate", s.handlers.EmployeeHandler.DeactivateEmployee)
+	employees.Post("/:id/terminate", s.handlers.EmployeeHandler.TerminateEmployee)
+
+	employees.Post("/:id/store", s.handlers.EmployeeHandler.AssignStore)
+	employees.Get("/:id/store-history", s.handlers.EmployeeHandler.GetStoreHistory)
+
+	employees.Post("/:id/user", s.handlers.EmployeeHandler.LinkUser)
+	employees.Delete("/:id/user", s.handlers.EmployeeHandler.UnlinkUser)
+}
+
+func (s *Server) setupAccountsReceivableRoutes(api fiber.Router) {
+	if s.handlers.AccountsReceivableHandler == nil {
+		return
+	}
 
-	// Accounts Receivable routes
 	ar := api.Group("/accounts-receivable")
+
+	// All accounts receivable routes require authentication
 	if s.authMiddleware != nil {
 		ar.Use(s.authMiddleware.Authenticate())
 	}
-	ar.Get("/:id", s.handlers.SaleHandler.GetAccountsReceivable)
-	ar.Post("/:id/payments", s.handlers.SaleHandler.RegisterPayment)
+
+	ar.Get("/", s.handlers.AccountsReceivableHandler.ListReceivables)
+	ar.Get("/overdue", s.handlers.AccountsReceivableHandler.GetOverdueReceivables)
+	ar.Get("/aging", s.handlers.AccountsReceivableHandler.GetAgingReport)
+	ar.Get("/customers/:customerId/statement", s.handlers.AccountsReceivableHandler.GetCustomerStatement)
+	ar.Get("/customers/:customerId/statement/pdf", s.handlers.AccountsReceivableHandler.GetCustomerStatementPDF)
+
+	ar.Get("/credit-overrides", s.handlers.AccountsReceivableHandler.ListCreditOverrides)
+
+	// Installment plans
+	ar.Get("/installment-plans", s.handlers.AccountsReceivableHandler.ListInstallmentPlans)
+	ar.Get("/installment-plans/:id", s.handlers.AccountsReceivableHandler.GetInstallmentPlan)
+
+	// Credit notes and write-offs
+	ar.Get("/adjustments", s.handlers.AccountsReceivableHandler.ListAdjustments)
+	ar.Get("/adjustments/:id", s.handlers.AccountsReceivableHandler.GetAdjustment)
+	ar.Post("/adjustments/:id/approve", s.handlers.AccountsReceivableHandler.ApproveWriteOff)
+	ar.Post("/adjustments/:id/reject", s.handlers.AccountsReceivableHandler.RejectWriteOff)
+	ar.Post("/payments/:paymentId/void", s.handlers.AccountsReceivableHandler.VoidPayment)
+
+	// Customer receipts
+	ar.Get("/receipts", s.handlers.AccountsReceivableHandler.ListCustomerReceipts)
+	ar.Post("/receipts", s.handlers.AccountsReceivableHandler.ReceiveCustomerPayment)
+	ar.Get("/receipts/:id", s.handlers.AccountsReceivableHandler.GetCustomerReceipt)
+	ar.Post("/receipts/:id/reverse", s.handlers.AccountsReceivableHandler.ReverseCustomerReceipt)
+
+	// Late fee policies
+	ar.Get("/late-fee-policies", s.handlers.AccountsReceivableHandler.ListLateFeePolicies)
+	ar.Post("/late-fee-policies", s.handlers.AccountsReceivableHandler.CreateLateFeePolicy)
+	ar.Get("/late-fee-policies/:id", s.handlers.AccountsReceivableHandler.GetLateFeePolicy)
+	ar.Put("/late-fee-policies/:id", s.handlers.AccountsReceivableHandler.UpdateLateFeePolicy)
+	ar.Delete("/late-fee-policies/:id", s.handlers.AccountsReceivableHandler.DeleteLateFeePolicy)
+
+	ar.Get("/:id", s.handlers.AccountsReceivableHandler.GetAccountsReceivable)
+	ar.Get("/:id/payments", s.handlers.AccountsReceivableHandler.GetPaymentHistory)
+	ar.Post("/:id/payments", s.handlers.AccountsReceivableHandler.RegisterPayment)
+	ar.Post("/:id/credit-notes", s.handlers.AccountsReceivableHandler.IssueCreditNote)
+	ar.Post("/:id/write-offs", s.handlers.AccountsReceivableHandler.RequestWriteOff)
 }
 
 func (s *Server) setupReservationRoutes(api fiber.Router) {
@@ -124,6 +297,12 @@ func (s *Server) setupReservationRoutes(api fiber.Router) {
 	reservations.Post("/", s.handlers.ReservationHandler.CreateReservation)
 	reservations.Post("/:id/confirm", s.handlers.ReservationHandler.ConfirmReservation)
 	reservations.Post("/:id/fulfill", s.handlers.ReservationHandler.FulfillReservation)
+	reservations.Get("/:id/payments", s.handlers.ReservationHandler.GetPayments)
+	reservations.Post("/:id/payments", s.handlers.ReservationHandler.RecordPayment)
+	reservations.Put("/:id/items", s.handlers.ReservationHandler.ModifyReservationItems)
+	reservations.Post("/:id/extend", s.handlers.ReservationHandler.ExtendReservation)
+	reservations.Put("/:id/store", s.handlers.ReservationHandler.ChangeReservationStore)
+	reservations.Get("/:id/modifications", s.handlers.ReservationHandler.GetModifications)
 	reservations.Post("/:id/cancel", s.handlers.ReservationHandler.CancelReservation)
 }
 
@@ -143,6 +322,7 @@ func (s *Server) setupInventoryRoutes(api fiber.Router) {
 	inventory.Get("/warehouse/:warehouseId", s.handlers.InventoryHandler.GetWarehouseInventory)
 	inventory.Get("/product/:productId", s.handlers.InventoryHandler.GetProductInventory)
 	inventory.Get("/check-availability", s.handlers.InventoryHandler.CheckAvailability)
+	inventory.Post("/allocation-plan", s.handlers.InventoryHandler.PlanAllocation)
 
 	// Movement operations
 	inventory.Post("/movements/inbound", s.handlers.InventoryHandler.RegisterInboundMovement)
@@ -151,3 +331,201 @@ func (s *Server) setupInventoryRoutes(api fiber.Router) {
 	inventory.Get("/movements/product/:productId", s.handlers.InventoryHandler.GetProductMovements)
 	inventory.Get("/movements/warehouse/:warehouseId", s.handlers.InventoryHandler.GetWarehouseMovements)
 }
+
+func (s *Server) setupCampaignRoutes(api fiber.Router) {
+	if s.handlers.CampaignHandler == nil {
+		return
+	}
+
+	campaigns := api.Group("/campaigns")
+
+	// All campaign routes require authentication
+	if s.authMiddleware != nil {
+		campaigns.Use(s.authMiddleware.Authenticate())
+	}
+
+	campaigns.Get("/", s.handlers.CampaignHandler.ListCampaigns)
+	campaigns.Get("/:id", s.handlers.CampaignHandler.GetCampaign)
+	campaigns.Post("/", s.handlers.CampaignHandler.CreateCampaign)
+	campaigns.Put("/:id", s.handlers.CampaignHandler.UpdateCampaign)
+	campaigns.Delete("/:id", s.handlers.CampaignHandler.DeleteCampaign)
+}
+
+func (s *Server) setupLoyaltyRoutes(api fiber.Router) {
+	if s.handlers.LoyaltyHandler == nil {
+		return
+	}
+
+	loyalty := api.Group("/loyalty")
+
+	// All loyalty routes require authentication
+	if s.authMiddleware != nil {
+		loyalty.Use(s.authMiddleware.Authenticate())
+	}
+
+	loyalty.Get("/rules", s.handlers.LoyaltyHandler.ListRules)
+	loyalty.Get("/rules/:id", s.handlers.LoyaltyHandler.GetRule)
+	loyalty.Post("/rules", s.handlers.LoyaltyHandler.CreateRule)
+	loyalty.Put("/rules/:id", s.handlers.LoyaltyHandler.UpdateRule)
+	loyalty.Delete("/rules/:id", s.handlers.LoyaltyHandler.DeleteRule)
+	loyalty.Post("/expire", s.handlers.LoyaltyHandler.ExpirePoints)
+}
+
+func (s *Server) setupStoredValueRoutes(api fiber.Router) {
+	if s.handlers.StoredValueHandler == nil {
+		return
+	}
+
+	storedValue := api.Group("/stored-value")
+
+	// All stored value routes require authentication
+	if s.authMiddleware != nil {
+		storedValue.Use(s.authMiddleware.Authenticate())
+	}
+
+	storedValue.Get("/", s.handlers.StoredValueHandler.ListAccounts)
+	storedValue.Get("/code/:code", s.handlers.StoredValueHandler.GetGiftCardByCode)
+	storedValue.Post("/gift-cards", s.handlers.StoredValueHandler.IssueGiftCard)
+	storedValue.Post("/store-credit", s.handlers.StoredValueHandler.IssueStoreCredit)
+	storedValue.Post("/expire", s.handlers.StoredValueHandler.ExpireAccounts)
+	storedValue.Get("/:id", s.handlers.StoredValueHandler.GetAccount)
+	storedValue.Get("/:id/transactions", s.handlers.StoredValueHandler.GetTransactions)
+}
+
+func (s *Server) setupQuotationRoutes(api fiber.Router) {
+	if s.handlers.QuotationHandler == nil {
+		return
+	}
+
+	quotations := api.Group("/quotations")
+
+	// All quotation routes require authentication
+	if s.authMiddleware != nil {
+		quotations.Use(s.authMiddleware.Authenticate())
+	}
+
+	quotations.Get("/", s.handlers.QuotationHandler.ListQuotations)
+	quotations.Get("/number/:number", s.handlers.QuotationHandler.GetQuotationByNumber)
+	quotations.Post("/", s.handlers.QuotationHandler.CreateQuotation)
+	quotations.Post("/expire", s.handlers.QuotationHandler.ExpireQuotations)
+	quotations.Get("/:id", s.handlers.QuotationHandler.GetQuotation)
+	quotations.Put("/:id", s.handlers.QuotationHandler.UpdateQuotation)
+	quotations.Get("/:id/pdf", s.handlers.QuotationHandler.GetQuotationPDF)
+	quotations.Post("/:id/send", s.handlers.QuotationHandler.SendQuotation)
+	quotations.Post("/:id/accept", s.handlers.QuotationHandler.AcceptQuotation)
+	quotations.Post("/:id/convert", s.handlers.QuotationHandler.ConvertQuotation)
+}
+
+func (s *Server) setupSchoolRoutes(api fiber.Router) {
+	if s.handlers.SchoolHandler == nil {
+		return
+	}
+
+	schools := api.Group("/schools")
+
+	// Public routes
+	schools.Get("/", s.handlers.SchoolHandler.ListSchools)
+	schools.Get("/:id", s.handlers.SchoolHandler.GetSchool)
+
+	// Protected routes (require authentication)
+	if s.authMiddleware != nil {
+		schools.Post("/", s.authMiddleware.Authenticate(), s.handlers.SchoolHandler.CreateSchool)
+		schools.Put("/:id", s.authMiddleware.Authenticate(), s.handlers.SchoolHandler.UpdateSchool)
+		schools.Delete("/:id", s.authMiddleware.Authenticate(), s.handlers.SchoolHandler.DeleteSchool)
+	}
+}
+
+func (s *Server) setupSchoolSupplyListRoutes(api fiber.Router) {
+	if s.handlers.SchoolSupplyListHandler == nil {
+		return
+	}
+
+	// Public routes (published lists only)
+	public := api.Group("/public/school-lists")
+	public.Get("/", s.handlers.SchoolSupplyListHandler.ListPublishedLists)
+	public.Get("/:id", s.handlers.SchoolSupplyListHandler.GetPublishedList)
+
+	lists := api.Group("/school-lists")
+
+	// All school supply list management routes require authentication
+	if s.authMiddleware != nil {
+		lists.Use(s.authMiddleware.Authenticate())
+	}
+
+	lists.Get("/", s.handlers.SchoolSupplyListHandler.ListLists)
+	lists.Post("/", s.handlers.SchoolSupplyListHandler.CreateList)
+	lists.Post("/recalculate-costs", s.handlers.SchoolSupplyListHandler.RecalculateActiveCosts)
+	lists.Post("/import/preview", s.handlers.SchoolSupplyListHandler.PreviewImport)
+	lists.Post("/import", s.handlers.SchoolSupplyListHandler.ConfirmImport)
+	lists.Get("/for-child/:childId", s.handlers.SchoolSupplyListHandler.GetListForChild)
+	lists.Get("/:id", s.handlers.SchoolSupplyListHandler.GetList)
+	lists.Put("/:id", s.handlers.SchoolSupplyListHandler.UpdateList)
+	lists.Delete("/:id", s.handlers.SchoolSupplyListHandler.DeleteList)
+	lists.Put("/:id/items", s.handlers.SchoolSupplyListHandler.ReplaceItems)
+	lists.Post("/:id/publish", s.handlers.SchoolSupplyListHandler.PublishList)
+	lists.Post("/:id/archive", s.handlers.SchoolSupplyListHandler.ArchiveList)
+	lists.Post("/:id/clone", s.handlers.SchoolSupplyListHandler.CloneList)
+	lists.Post("/:id/recalculate-cost", s.handlers.SchoolSupplyListHandler.RecalculateCost)
+	lists.Get("/:id/availability", s.handlers.SchoolSupplyListHandler.CheckAvailability)
+	lists.Post("/:id/convert", s.handlers.SchoolSupplyListHandler.ConvertList)
+}
+
+func (s *Server) setupForecastRoutes(api fiber.Router) {
+	if s.handlers.ForecastHandler == nil {
+		return
+	}
+
+	forecasts := api.Group("/forecasts")
+
+	// All forecast routes require authentication
+	if s.authMiddleware != nil {
+		forecasts.Use(s.authMiddleware.Authenticate())
+	}
+
+	forecasts.Get("/", s.handlers.ForecastHandler.ListForecasts)
+	forecasts.Post("/", s.handlers.ForecastHandler.GenerateForecasts)
+	forecasts.Post("/evaluate", s.handlers.ForecastHandler.EvaluateForecasts)
+	forecasts.Get("/:id", s.handlers.ForecastHandler.GetForecast)
+}
+
+func (s *Server) setupPreOrderRoutes(api fiber.Router) {
+	if s.handlers.PreOrderHandler == nil {
+		return
+	}
+
+	preOrders := api.Group("/pre-orders")
+
+	// All pre-order routes require authentication
+	if s.authMiddleware != nil {
+		preOrders.Use(s.authMiddleware.Authenticate())
+	}
+
+	preOrders.Get("/", s.handlers.PreOrderHandler.ListPreOrders)
+	preOrders.Post("/", s.handlers.PreOrderHandler.CreatePreOrder)
+	preOrders.Post("/expire", s.handlers.PreOrderHandler.ExpireUncollected)
+	preOrders.Get("/number/:number", s.handlers.PreOrderHandler.GetPreOrderByNumber)
+	preOrders.Get("/:id", s.handlers.PreOrderHandler.GetPreOrder)
+	preOrders.Post("/:id/confirm", s.handlers.PreOrderHandler.ConfirmPreOrder)
+	preOrders.Post("/:id/ready", s.handlers.PreOrderHandler.MarkPreOrderReady)
+	preOrders.Post("/:id/notify", s.handlers.PreOrderHandler.NotifyPreOrder)
+	preOrders.Post("/:id/deliver", s.handlers.PreOrderHandler.DeliverPreOrder)
+	preOrders.Post("/:id/cancel", s.handlers.PreOrderHandler.CancelPreOrder)
+}
+
+func (s *Server) setupJobRoutes(api fiber.Router) {
+	if s.handlers.SchedulerHandler == nil {
+		return
+	}
+
+	jobs := api.Group("/jobs")
+
+	// All job routes require authentication
+	if s.authMiddleware != nil {
+		jobs.Use(s.authMiddleware.Authenticate())
+	}
+
+	jobs.Get("/", s.handlers.SchedulerHandler.ListJobs)
+	jobs.Get("/runs", s.handlers.SchedulerHandler.ListJobRuns)
+	jobs.Get("/runs/:id", s.handlers.SchedulerHandler.GetJobRun)
+	jobs.Post("/:name/run", s.handlers.SchedulerHandler.TriggerJob)
+}
@@ -17,11 +17,25 @@ import (
 
 // Handlers holds all HTTP handlers
 type Handlers struct {
-	ProductHandler     *handlers.ProductHandler
-	CustomerHandler    *handlers.CustomerHandler
-	SaleHandler        *handlers.SaleHandler
-	ReservationHandler *handlers.ReservationHandler
-	InventoryHandler   *handlers.InventoryHandler
+	ProductHandler            *handlers.ProductHandler
+	CustomerHandler           *handlers.CustomerHandler
+	SaleHandler               *handlers.SaleHandler
+	AccountsReceivableHandler *handlers.AccountsReceivableHandler
+	AccountsPayableHandler    *handlers.AccountsPayableHandler
+	ExpenseHandler            *handlers.ExpenseHandler
+	PayrollHandler            *handlers.PayrollHandler
+	EmployeeHandler           *handlers.EmployeeHandler
+	ReservationHandler        *handlers.ReservationHandler
+	PreOrderHandler           *handlers.PreOrderHandler
+	InventoryHandler          *handlers.InventoryHandler
+	CampaignHandler           *handlers.CampaignHandler
+	LoyaltyHandler            *handlers.LoyaltyHandler
+	StoredValueHandler        *handlers.StoredValueHandler
+	QuotationHandler          *handlers.QuotationHandler
+	SchoolHandler             *handlers.SchoolHandler
+	SchoolSupplyListHandler   *handlers.SchoolSupplyListHandler
+	ForecastHandler           *handlers.ForecastHandler
+	SchedulerHandler          *handlers.SchedulerHandler
 }
 
 type Server struct {
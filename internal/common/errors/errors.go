@@ -152,3 +152,9 @@ func WrapError(err error, message string) *AppError {
 		Details:    err.Error(),
 	}
 }
+
+// IsNotFound reports whether err is a NOT_FOUND AppError
+func IsNotFound(err error) bool {
+	appErr, ok := err.(*AppError)
+	return ok && appErr.Code == ErrCodeNotFound
+}
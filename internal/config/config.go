@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -15,6 +17,21 @@ type Config struct {
 	DBPassword   string
 	DBName       string
 	FirebaseCred string
+
+	// SchedulerEnabled runs the background jobs in this instance
+	SchedulerEnabled bool
+
+	// AllocationStrategy picks the warehouses of orders that do not pin one: PRIMARY, NEAREST or SPLIT
+	AllocationStrategy string
+
+	// CreditSuspensionDays blocks credit sales to customers with balances overdue longer than this; 0 disables it
+	CreditSuspensionDays int
+
+	// CreditOverrideRoles can approve credit sales outside the customer's terms, besides store managers
+	CreditOverrideRoles []string
+
+	// InstallmentReminderDays is how many days before its due date an installment is reminded
+	InstallmentReminderDays int
 }
 
 func LoadConfig() (*Config, error) {
@@ -29,6 +46,14 @@ func LoadConfig() (*Config, error) {
 		DBPassword:   getEnv("DB_PASSWORD", "postgres"),
 		DBName:       getEnv("DB_NAME", "inventory"),
 		FirebaseCred: getEnv("FIREBASE_CREDENTIALS", "firebase-credentials.json"),
+
+		SchedulerEnabled:   getEnv("SCHEDULER_ENABLED", "true") != "false",
+		AllocationStrategy: getEnv("ALLOCATION_STRATEGY", "NEAREST"),
+
+		CreditSuspensionDays: getEnvInt("CREDIT_SUSPENSION_DAYS", 30),
+		CreditOverrideRoles:  strings.Split(getEnv("CREDIT_OVERRIDE_ROLES", "ADMIN,SUPERVISOR"), ","),
+
+		InstallmentReminderDays: getEnvInt("INSTALLMENT_REMINDER_DAYS", 3),
 	}
 
 	return config, nil
@@ -41,6 +66,15 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+func getEnvInt(key string, fallback int) int {
+	if value, ok := os.LookupEnv(key); ok {
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return fallback
+}
+
 func (c *Config) GetDSN() string {
 	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
 		c.DBHost, c.DBUser, c.DBPassword, c.DBName, c.DBPort)
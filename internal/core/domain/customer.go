@@ -47,12 +47,14 @@ type CustomerChild struct {
 	DateOfBirth *time.Time   `gorm:"type:date" json:"date_of_birth,omitempty"`
 	SchoolLevel *SchoolLevel `gorm:"type:school_level" json:"school_level,omitempty"`
 	Grade       *string      `gorm:"type:varchar(20)" json:"grade,omitempty"`
-	SchoolName  *string      `gorm:"type:varchar(200)" json:"school_name,omitempty"`
+	SchoolID    *uuid.UUID   `gorm:"type:uuid" json:"school_id,omitempty"`
+	SchoolName  *string      `gorm:"type:varchar(200)" json:"school_name,omitempty"` // Free text for schools not in the registry
 	Notes       *string      `gorm:"type:text" json:"notes,omitempty"`
 	BaseModel
 
 	// Relations
 	Customer *Customer `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	School   *School   `gorm:"foreignKey:SchoolID" json:"school,omitempty"`
 }
 
 func (CustomerChild) TableName() string {
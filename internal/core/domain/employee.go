@@ -8,26 +8,27 @@ import (
 
 // Employee represents an employee
 type Employee struct {
-	EmployeeID      uuid.UUID      `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"employee_id"`
-	NationalID      string         `gorm:"type:varchar(20);not null;uniqueIndex" json:"national_id"`
-	FirstName       string         `gorm:"type:varchar(100);not null" json:"first_name"`
-	LastName        string         `gorm:"type:varchar(100);not null" json:"last_name"`
-	DateOfBirth     *time.Time     `gorm:"type:date" json:"date_of_birth,omitempty"`
-	Gender          *GenderType    `gorm:"type:gender_type" json:"gender,omitempty"`
-	LocationID      *uuid.UUID     `gorm:"type:uuid" json:"location_id,omitempty"`
-	Address         *string        `gorm:"type:text" json:"address,omitempty"`
-	Phone           *string        `gorm:"type:varchar(20)" json:"phone,omitempty"`
-	Email           *string        `gorm:"type:varchar(100)" json:"email,omitempty"`
-	HireDate        time.Time      `gorm:"type:date;not null" json:"hire_date"`
-	TerminationDate *time.Time     `gorm:"type:date" json:"termination_date,omitempty"`
-	JobTitle        string         `gorm:"type:varchar(100);not null" json:"job_title"`
-	Department      *string        `gorm:"type:varchar(100)" json:"department,omitempty"`
-	StoreID         *uuid.UUID     `gorm:"type:uuid" json:"store_id,omitempty"`
-	BaseSalary      float64        `gorm:"type:decimal(15,2);not null" json:"base_salary"`
-	SalaryCurrency  CurrencyCode   `gorm:"type:currency_code;default:'VES'" json:"salary_currency"`
-	Status          EmployeeStatus `gorm:"type:employee_status;default:'ACTIVE'" json:"status"`
-	PhotoURL        *string        `gorm:"type:varchar(255)" json:"photo_url,omitempty"`
-	UserID          *uuid.UUID     `gorm:"type:uuid" json:"user_id,omitempty"`
+	EmployeeID        uuid.UUID      `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"employee_id"`
+	NationalID        string         `gorm:"type:varchar(20);not null;uniqueIndex" json:"national_id"`
+	FirstName         string         `gorm:"type:varchar(100);not null" json:"first_name"`
+	LastName          string         `gorm:"type:varchar(100);not null" json:"last_name"`
+	DateOfBirth       *time.Time     `gorm:"type:date" json:"date_of_birth,omitempty"`
+	Gender            *GenderType    `gorm:"type:gender_type" json:"gender,omitempty"`
+	LocationID        *uuid.UUID     `gorm:"type:uuid" json:"location_id,omitempty"`
+	Address           *string        `gorm:"type:text" json:"address,omitempty"`
+	Phone             *string        `gorm:"type:varchar(20)" json:"phone,omitempty"`
+	Email             *string        `gorm:"type:varchar(100)" json:"email,omitempty"`
+	HireDate          time.Time      `gorm:"type:date;not null" json:"hire_date"`
+	TerminationDate   *time.Time     `gorm:"type:date" json:"termination_date,omitempty"`
+	TerminationReason *string        `gorm:"type:text" json:"termination_reason,omitempty"`
+	JobTitle          string         `gorm:"type:varchar(100);not null" json:"job_title"`
+	Department        *string        `gorm:"type:varchar(100)" json:"department,omitempty"`
+	StoreID           *uuid.UUID     `gorm:"type:uuid" json:"store_id,omitempty"`
+	BaseSalary        float64        `gorm:"type:decimal(15,2);not null" json:"base_salary"`
+	SalaryCurrency    CurrencyCode   `gorm:"type:currency_code;default:'VES'" json:"salary_currency"`
+	Status            EmployeeStatus `gorm:"type:employee_status;default:'ACTIVE'" json:"status"`
+	PhotoURL          *string        `gorm:"type:varchar(255)" json:"photo_url,omitempty"`
+	UserID            *uuid.UUID     `gorm:"type:uuid" json:"user_id,omitempty"`
 	BaseModelWithUser
 
 	// Relations
@@ -39,3 +40,37 @@ type Employee struct {
 func (Employee) TableName() string {
 	return "employees"
 }
+
+// CanTransitionTo tells whether the employee can move to the given status.
+// Terminated employees can only be rehired.
+func (e *Employee) CanTransitionTo(status EmployeeStatus) bool {
+	switch e.Status {
+	case EmployeeStatusActive:
+		return status == EmployeeStatusOnLeave || status == EmployeeStatusInactive || status == EmployeeStatusTerminated
+	case EmployeeStatusOnLeave, EmployeeStatusInactive:
+		return status == EmployeeStatusActive || status == EmployeeStatusTerminated
+	case EmployeeStatusTerminated:
+		return status == EmployeeStatusActive
+	}
+	return false
+}
+
+// EmployeeStoreAssignment is a period an employee worked at a store. The
+// current assignment has no end date.
+type EmployeeStoreAssignment struct {
+	AssignmentID uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"assignment_id"`
+	EmployeeID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"employee_id"`
+	StoreID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"store_id"`
+	StartDate    time.Time  `gorm:"type:date;not null" json:"start_date"`
+	EndDate      *time.Time `gorm:"type:date" json:"end_date,omitempty"`
+	Reason       *string    `gorm:"type:text" json:"reason,omitempty"`
+	AssignedBy   *uuid.UUID `gorm:"type:uuid" json:"assigned_by,omitempty"`
+	CreatedAt    time.Time  `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+
+	// Relations
+	Store *Store `gorm:"foreignKey:StoreID" json:"store,omitempty"`
+}
+
+func (EmployeeStoreAssignment) TableName() string {
+	return "employee_store_assignments"
+}
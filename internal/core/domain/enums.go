@@ -72,6 +72,21 @@ const (
 	PayrollPeriodStatusClosed     PayrollPeriodStatus = "CLOSED"
 )
 
+type PayrollFrequency string
+
+const (
+	PayrollFrequencyWeekly   PayrollFrequency = "WEEKLY"
+	PayrollFrequencyBiweekly PayrollFrequency = "BIWEEKLY"
+	PayrollFrequencyMonthly  PayrollFrequency = "MONTHLY"
+)
+
+type PayrollCalculationType string
+
+const (
+	PayrollCalculationFixed   PayrollCalculationType = "FIXED"   // Same amount every period
+	PayrollCalculationFormula PayrollCalculationType = "FORMULA" // Expression over the employee's salary
+)
+
 // Customer Enums
 type CustomerType string
 
@@ -181,6 +196,19 @@ const (
 	ExpenseApprovalStatusRejected ExpenseApprovalStatus = "REJECTED"
 )
 
+type ExpenseCategory string
+
+const (
+	ExpenseCategoryRent        ExpenseCategory = "RENT"
+	ExpenseCategoryUtilities   ExpenseCategory = "UTILITIES"
+	ExpenseCategorySupplies    ExpenseCategory = "SUPPLIES"
+	ExpenseCategoryMaintenance ExpenseCategory = "MAINTENANCE"
+	ExpenseCategoryTransport   ExpenseCategory = "TRANSPORT"
+	ExpenseCategoryServices    ExpenseCategory = "SERVICES"
+	ExpenseCategoryTaxes       ExpenseCategory = "TAXES"
+	ExpenseCategoryOther       ExpenseCategory = "OTHER"
+)
+
 // Currency Enums
 type CurrencyCode string
 
@@ -230,6 +258,35 @@ const (
 	ReservationStatusExpired           ReservationStatus = "EXPIRED"
 )
 
+// ReservationPaymentType classifies the money movements of a reservation
+type ReservationPaymentType string
+
+const (
+	ReservationPaymentDeposit     ReservationPaymentType = "DEPOSIT"     // Paid to confirm the reservation
+	ReservationPaymentInstallment ReservationPaymentType = "INSTALLMENT" // Additional payment before pickup
+	ReservationPaymentRefund      ReservationPaymentType = "REFUND"      // Returned to the customer
+	ReservationPaymentForfeit     ReservationPaymentType = "FORFEIT"     // Retained by the store
+)
+
+// ReservationModificationType classifies the changes made to a reservation after it was created
+type ReservationModificationType string
+
+const (
+	ReservationModificationItemAdded       ReservationModificationType = "ITEM_ADDED"
+	ReservationModificationItemRemoved     ReservationModificationType = "ITEM_REMOVED"
+	ReservationModificationQuantityChanged ReservationModificationType = "QUANTITY_CHANGED"
+	ReservationModificationExtended        ReservationModificationType = "EXTENDED"
+	ReservationModificationStoreChanged    ReservationModificationType = "STORE_CHANGED"
+)
+
+// DepositApplication defines how a reservation deposit is credited across pickups
+type DepositApplication string
+
+const (
+	DepositApplicationProportional DepositApplication = "PROPORTIONAL" // Each pickup takes its share of the deposit
+	DepositApplicationFirstPickup  DepositApplication = "FIRST_PICKUP" // The deposit is used up by the earliest pickups
+)
+
 type PreOrderStatus string
 
 const (
@@ -241,6 +298,107 @@ const (
 	PreOrderStatusCancelled     PreOrderStatus = "CANCELLED"
 )
 
+type PromotionType string
+
+const (
+	PromotionTypePercentage PromotionType = "PERCENTAGE"
+	PromotionTypeBuyXGetY   PromotionType = "BUY_X_GET_Y"
+	PromotionTypeBundle     PromotionType = "BUNDLE"
+)
+
+type LoyaltyTransactionType string
+
+const (
+	LoyaltyTransactionTypeEarn   LoyaltyTransactionType = "EARN"
+	LoyaltyTransactionTypeRedeem LoyaltyTransactionType = "REDEEM"
+	LoyaltyTransactionTypeExpire LoyaltyTransactionType = "EXPIRE"
+	LoyaltyTransactionTypeAdjust LoyaltyTransactionType = "ADJUST"
+	LoyaltyTransactionTypeReturn LoyaltyTransactionType = "RETURN"
+)
+
+type TenderType string
+
+const (
+	TenderTypeLoyaltyPoints TenderType = "LOYALTY_POINTS"
+	TenderTypeGiftCard      TenderType = "GIFT_CARD"
+	TenderTypeStoreCredit   TenderType = "STORE_CREDIT"
+	TenderTypeDeposit       TenderType = "DEPOSIT" // Deposit paid in advance on a pre-order or reservation
+)
+
+type StoredValueType string
+
+const (
+	StoredValueTypeGiftCard    StoredValueType = "GIFT_CARD"
+	StoredValueTypeStoreCredit StoredValueType = "STORE_CREDIT"
+)
+
+type StoredValueStatus string
+
+const (
+	StoredValueStatusActive    StoredValueStatus = "ACTIVE"
+	StoredValueStatusExhausted StoredValueStatus = "EXHAUSTED"
+	StoredValueStatusExpired   StoredValueStatus = "EXPIRED"
+	StoredValueStatusCancelled StoredValueStatus = "CANCELLED"
+)
+
+type StoredValueTransactionType string
+
+const (
+	StoredValueTransactionTypeIssue  StoredValueTransactionType = "ISSUE"
+	StoredValueTransactionTypeRedeem StoredValueTransactionType = "REDEEM"
+	StoredValueTransactionTypeRefund StoredValueTransactionType = "REFUND"
+	StoredValueTransactionTypeExpire StoredValueTransactionType = "EXPIRE"
+	StoredValueTransactionTypeRevoke StoredValueTransactionType = "REVOKE" // Credit taken back, e.g. from a reversed payment
+)
+
+type QuotationStatus string
+
+const (
+	QuotationStatusDraft    QuotationStatus = "DRAFT"
+	QuotationStatusSent     QuotationStatus = "SENT"
+	QuotationStatusAccepted QuotationStatus = "ACCEPTED"
+	QuotationStatusExpired  QuotationStatus = "EXPIRED"
+)
+
+type QuotationConversionType string
+
+const (
+	QuotationConversionSale        QuotationConversionType = "SALE"
+	QuotationConversionCreditSale  QuotationConversionType = "CREDIT_SALE"
+	QuotationConversionReservation QuotationConversionType = "RESERVATION"
+)
+
+type ListConversionType string
+
+const (
+	ListConversionSale        ListConversionType = "SALE"
+	ListConversionReservation ListConversionType = "RESERVATION"
+)
+
+type ListImportFormat string
+
+const (
+	ListImportFormatCSV  ListImportFormat = "CSV"
+	ListImportFormatText ListImportFormat = "TEXT"
+)
+
+// ListBudgetPreference decides which product serves a school list item
+type ListBudgetPreference string
+
+const (
+	ListBudgetCheapest    ListBudgetPreference = "CHEAPEST"    // Cheapest in-stock product among the listed one and its alternatives
+	ListBudgetRecommended ListBudgetPreference = "RECOMMENDED" // Recommended alternatives first
+	ListBudgetExact       ListBudgetPreference = "EXACT"       // Listed product; alternatives only when it runs out
+)
+
+// ForecastMethod identifies how a demand forecast was computed
+type ForecastMethod string
+
+const (
+	ForecastMethodMovingAverage ForecastMethod = "MOVING_AVERAGE" // Mean of the past seasons
+	ForecastMethodSeasonalNaive ForecastMethod = "SEASONAL_NAIVE" // Same quantity as the last season
+)
+
 type NotificationType string
 
 const (
@@ -258,3 +416,94 @@ const (
 	NotificationStatusFailed  NotificationStatus = "FAILED"
 	NotificationStatusRead    NotificationStatus = "READ"
 )
+
+// JobRunStatus represents the outcome of a scheduled job run
+type JobRunStatus string
+
+const (
+	JobRunStatusRunning   JobRunStatus = "RUNNING"
+	JobRunStatusSucceeded JobRunStatus = "SUCCEEDED"
+	JobRunStatusFailed    JobRunStatus = "FAILED"
+)
+
+// JobTrigger identifies what started a job run
+type JobTrigger string
+
+const (
+	JobTriggerScheduled JobTrigger = "SCHEDULED"
+	JobTriggerManual    JobTrigger = "MANUAL"
+)
+
+// AllocationStrategy decides which warehouses serve the items of an order
+type AllocationStrategy string
+
+const (
+	// AllocationStrategyPrimary takes all the stock from the store's primary warehouse
+	AllocationStrategyPrimary AllocationStrategy = "PRIMARY"
+	// AllocationStrategyNearest takes each item whole from the first warehouse that
+	// covers it: the store's primary, its other warehouses, then the nearest ones
+	AllocationStrategyNearest AllocationStrategy = "NEAREST"
+	// AllocationStrategySplit follows the NEAREST order but splits an item across
+	// warehouses when none covers it alone
+	AllocationStrategySplit AllocationStrategy = "SPLIT"
+)
+
+// ReceivableKind tells apart the charges kept in accounts receivable
+type ReceivableKind string
+
+const (
+	ReceivableKindSale    ReceivableKind = "SALE"
+	ReceivableKindLateFee ReceivableKind = "LATE_FEE"
+)
+
+// InstallmentFrequency is the spacing between the due dates of an installment plan
+type InstallmentFrequency string
+
+const (
+	InstallmentFrequencyWeekly   InstallmentFrequency = "WEEKLY"
+	InstallmentFrequencyBiweekly InstallmentFrequency = "BIWEEKLY"
+	InstallmentFrequencyMonthly  InstallmentFrequency = "MONTHLY"
+)
+
+// ReceiptStatus tracks whether a customer receipt still stands
+type ReceiptStatus string
+
+const (
+	ReceiptStatusPosted   ReceiptStatus = "POSTED"
+	ReceiptStatusReversed ReceiptStatus = "REVERSED"
+)
+
+// AdjustmentType tells apart the ways a receivable is reduced without a payment
+type AdjustmentType string
+
+const (
+	AdjustmentTypeCreditNote AdjustmentType = "CREDIT_NOTE"
+	AdjustmentTypeWriteOff   AdjustmentType = "WRITE_OFF"
+)
+
+// CreditNoteReason is what a credit note compensates
+type CreditNoteReason string
+
+const (
+	CreditNoteReasonReturn   CreditNoteReason = "RETURN"
+	CreditNoteReasonDiscount CreditNoteReason = "DISCOUNT"
+)
+
+// AdjustmentStatus tracks the approval of a receivable adjustment
+type AdjustmentStatus string
+
+const (
+	AdjustmentStatusPending  AdjustmentStatus = "PENDING"
+	AdjustmentStatusApplied  AdjustmentStatus = "APPLIED"
+	AdjustmentStatusRejected AdjustmentStatus = "REJECTED"
+)
+
+// LateFeeType defines how a late fee is computed
+type LateFeeType string
+
+const (
+	// LateFeeTypeFlat charges a fixed amount once the grace period ends
+	LateFeeTypeFlat LateFeeType = "FLAT"
+	// LateFeeTypeDailyPercent charges a percent of the balance for each day past the grace period
+	LateFeeTypeDailyPercent LateFeeType = "DAILY_PERCENT"
+)
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Expense is an operating expense of a store (rent, utilities, supplies...)
+// that goes through the approval chain before it counts in the summaries
+type Expense struct {
+	ExpenseID     uuid.UUID             `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"expense_id"`
+	StoreID       uuid.UUID             `gorm:"type:uuid;not null;index" json:"store_id"`
+	Category      ExpenseCategory       `gorm:"type:varchar(20);not null;index" json:"category"`
+	Description   string                `gorm:"type:text;not null" json:"description"`
+	Amount        float64               `gorm:"type:decimal(15,2);not null" json:"amount"`
+	Currency      CurrencyCode          `gorm:"type:currency_code;default:'VES'" json:"currency"`
+	ExchangeRate  *float64              `gorm:"type:decimal(15,4)" json:"exchange_rate,omitempty"` // VES per unit of the expense currency
+	AmountVES     float64               `gorm:"type:decimal(15,2);not null" json:"amount_ves"`     // Amount the approval thresholds and summaries use
+	SupplierID    *uuid.UUID            `gorm:"type:uuid;index" json:"supplier_id,omitempty"`
+	Reference     *string               `gorm:"type:varchar(50)" json:"reference,omitempty"` // Supplier invoice or receipt number
+	AttachmentURL *string               `gorm:"type:text" json:"attachment_url,omitempty"`   // Scanned receipt or invoice
+	ExpenseDate   time.Time             `gorm:"type:date;not null;index" json:"expense_date"`
+	Status        ExpenseApprovalStatus `gorm:"type:varchar(20);default:'PENDING';index" json:"status"`
+	RequestedBy   uuid.UUID             `gorm:"type:uuid;not null" json:"requested_by"`
+	DecidedAt     *time.Time            `json:"decided_at,omitempty"` // When the last approval or the rejection was given
+	CreatedAt     time.Time             `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt     time.Time             `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+
+	// Relations
+	Store     *Store            `gorm:"foreignKey:StoreID" json:"store,omitempty"`
+	Supplier  *Supplier         `gorm:"foreignKey:SupplierID" json:"supplier,omitempty"`
+	Requester *User             `gorm:"foreignKey:RequestedBy" json:"requester,omitempty"`
+	Approvals []ExpenseApproval `gorm:"foreignKey:ExpenseID" json:"approvals,omitempty"`
+}
+
+func (Expense) TableName() string {
+	return "expenses"
+}
+
+// NextApproval returns the first step of the chain still waiting for a
+// decision, nil when every step is decided
+func (e *Expense) NextApproval() *ExpenseApproval {
+	for i := range e.Approvals {
+		if e.Approvals[i].Status == ExpenseApprovalStatusPending {
+			return &e.Approvals[i]
+		}
+	}
+	return nil
+}
+
+// ExpenseApprovalRule adds a step to the approval chain of the expenses whose
+// amount in VES reaches MinAmount. Steps are decided in level order, each by a
+// user holding RoleName.
+type ExpenseApprovalRule struct {
+	RuleID    uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"rule_id"`
+	Name      string     `gorm:"type:varchar(100);not null" json:"name"`
+	Level     int        `gorm:"not null" json:"level"`
+	RoleName  string     `gorm:"type:varchar(50);not null" json:"role_name"`
+	MinAmount float64    `gorm:"type:decimal(15,2);default:0" json:"min_amount"` // In VES
+	StoreID   *uuid.UUID `gorm:"type:uuid;index" json:"store_id,omitempty"`      // Empty for every store
+	IsActive  bool       `gorm:"default:true" json:"is_active"`
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt time.Time  `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt time.Time  `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (ExpenseApprovalRule) TableName() string {
+	return "expense_approval_rules"
+}
+
+// ExpenseApproval is a step of the approval chain of an expense, copied from
+// the rules when the expense is registered so later rule changes don't alter it
+type ExpenseApproval struct {
+	ApprovalID uuid.UUID             `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"approval_id"`
+	ExpenseID  uuid.UUID             `gorm:"type:uuid;not null;index" json:"expense_id"`
+	RuleID     *uuid.UUID            `gorm:"type:uuid" json:"rule_id,omitempty"`
+	Level      int                   `gorm:"not null" json:"level"`
+	RoleName   string                `gorm:"type:varchar(50);not null" json:"role_name"`
+	Status     ExpenseApprovalStatus `gorm:"type:varchar(20);default:'PENDING'" json:"status"`
+	ApproverID *uuid.UUID            `gorm:"type:uuid" json:"approver_id,omitempty"`
+	Comments   *string               `gorm:"type:text" json:"comments,omitempty"`
+	DecidedAt  *time.Time            `json:"decided_at,omitempty"`
+	CreatedAt  time.Time             `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+
+	// Relations
+	Approver *User `gorm:"foreignKey:ApproverID" json:"approver,omitempty"`
+}
+
+func (ExpenseApproval) TableName() string {
+	return "expense_approvals"
+}
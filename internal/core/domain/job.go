@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobRun is an entry of the background job run history
+type JobRun struct {
+	RunID          uuid.UUID    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"run_id"`
+	JobName        string       `gorm:"type:varchar(100);not null;index;uniqueIndex:idx_job_runs_slot" json:"job_name"`
+	Trigger        JobTrigger   `gorm:"type:varchar(20);not null" json:"trigger"`
+	Status         JobRunStatus `gorm:"type:varchar(20);not null" json:"status"`
+	ScheduledFor   *time.Time   `gorm:"uniqueIndex:idx_job_runs_slot" json:"scheduled_for,omitempty"` // Slot of a scheduled run, claimed once across instances
+	StartedAt      time.Time    `gorm:"not null" json:"started_at"`
+	FinishedAt     *time.Time   `json:"finished_at,omitempty"`
+	DurationMs     int64        `gorm:"default:0" json:"duration_ms"`
+	ItemsProcessed int          `gorm:"default:0" json:"items_processed"`
+	Error          *string      `gorm:"type:text" json:"error,omitempty"`
+	Instance       string       `gorm:"type:varchar(100)" json:"instance"` // Host and process that ran the job
+	TriggeredBy    *uuid.UUID   `gorm:"type:uuid" json:"triggered_by,omitempty"`
+}
+
+func (JobRun) TableName() string {
+	return "job_runs"
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoyaltyRule defines how many points are earned per amount spent.
+// Rules with a CategoryID take precedence over the general rule of the same currency.
+type LoyaltyRule struct {
+	RuleID      uuid.UUID    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"rule_id"`
+	Name        string       `gorm:"type:varchar(100);not null" json:"name"`
+	Currency    CurrencyCode `gorm:"type:currency_code;default:'VES'" json:"currency"`
+	CategoryID  *uuid.UUID   `gorm:"type:uuid" json:"category_id,omitempty"`
+	SpendAmount float64      `gorm:"type:decimal(15,2);not null" json:"spend_amount"` // Amount to spend to earn Points
+	Points      int          `gorm:"not null" json:"points"`
+	PointValue  *float64     `gorm:"type:decimal(15,4)" json:"point_value,omitempty"` // Value of one point when redeemed, general rules only
+	ExpiryDays  *int         `json:"expiry_days,omitempty"`
+	IsActive    bool         `gorm:"default:true" json:"is_active"`
+	CreatedAt   time.Time    `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt   time.Time    `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+	CreatedBy   *uuid.UUID   `gorm:"type:uuid" json:"created_by,omitempty"`
+
+	// Relations
+	Category *Category `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+}
+
+func (LoyaltyRule) TableName() string {
+	return "loyalty_rules"
+}
+
+// LoyaltyTransaction is an entry of the customer points ledger.
+// Positive entries are lots that can be consumed by later redemptions or expire.
+type LoyaltyTransaction struct {
+	TransactionID       uuid.UUID              `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"transaction_id"`
+	CustomerID          uuid.UUID              `gorm:"type:uuid;not null" json:"customer_id"`
+	TransactionType     LoyaltyTransactionType `gorm:"type:varchar(20);not null" json:"transaction_type"`
+	Points              int                    `gorm:"not null" json:"points"`
+	BalanceAfter        int                    `gorm:"not null" json:"balance_after"`
+	RemainingPoints     int                    `gorm:"default:0" json:"remaining_points"`
+	ExpiresAt           *time.Time             `json:"expires_at,omitempty"`
+	Amount              *float64               `gorm:"type:decimal(15,2)" json:"amount,omitempty"`
+	Currency            *CurrencyCode          `gorm:"type:currency_code" json:"currency,omitempty"`
+	SaleID              *uuid.UUID             `gorm:"type:uuid" json:"sale_id,omitempty"`
+	RuleID              *uuid.UUID             `gorm:"type:uuid" json:"rule_id,omitempty"`
+	SourceTransactionID *uuid.UUID             `gorm:"type:uuid" json:"source_transaction_id,omitempty"`
+	Description         *string                `gorm:"type:text" json:"description,omitempty"`
+	CreatedAt           time.Time              `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	CreatedBy           *uuid.UUID             `gorm:"type:uuid" json:"created_by,omitempty"`
+
+	// Relations
+	Customer *Customer `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	Sale     *Sale     `gorm:"foreignKey:SaleID" json:"sale,omitempty"`
+}
+
+func (LoyaltyTransaction) TableName() string {
+	return "loyalty_transactions"
+}
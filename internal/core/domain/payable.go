@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SupplierInvoice is an amount owed to a supplier, billed for a purchase
+// receipt or standalone (services, freight, rent)
+type SupplierInvoice struct {
+	InvoiceID          uuid.UUID     `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"invoice_id"`
+	SupplierID         uuid.UUID     `gorm:"type:uuid;not null;index" json:"supplier_id"`
+	StoreID            *uuid.UUID    `gorm:"type:uuid" json:"store_id,omitempty"`
+	InvoiceNumber      string        `gorm:"type:varchar(50);not null" json:"invoice_number"` // Number printed by the supplier
+	ReceiptID          *uuid.UUID    `gorm:"type:uuid;index" json:"receipt_id,omitempty"`     // Reference of the inbound movements of the purchase receipt
+	IssueDate          time.Time     `gorm:"type:date;not null" json:"issue_date"`
+	DueDate            time.Time     `gorm:"type:date;not null" json:"due_date"`
+	TotalAmount        float64       `gorm:"type:decimal(15,2);not null" json:"total_amount"`
+	PaidAmount         float64       `gorm:"type:decimal(15,2);default:0" json:"paid_amount"`
+	Balance            float64       `gorm:"type:decimal(15,2);not null" json:"balance"`
+	Currency           CurrencyCode  `gorm:"type:currency_code;default:'VES'" json:"currency"`
+	ExchangeRate       *float64      `gorm:"type:decimal(15,4)" json:"exchange_rate,omitempty"` // VES per unit of the invoice currency at issue
+	Status             AccountStatus `gorm:"type:account_status;default:'PENDING'" json:"status"`
+	Notes              *string       `gorm:"type:text" json:"notes,omitempty"`
+	CancellationReason *string       `gorm:"type:text" json:"cancellation_reason,omitempty"`
+	CreatedBy          *uuid.UUID    `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt          time.Time     `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt          time.Time     `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+
+	// Relations
+	Supplier *Supplier         `gorm:"foreignKey:SupplierID" json:"supplier,omitempty"`
+	Payments []SupplierPayment `gorm:"foreignKey:InvoiceID" json:"payments,omitempty"`
+}
+
+func (SupplierInvoice) TableName() string {
+	return "supplier_invoices"
+}
+
+// RefreshBalance recomputes the balance and status after a payment
+func (inv *SupplierInvoice) RefreshBalance(at time.Time) {
+	inv.Balance = math.Round((inv.TotalAmount-inv.PaidAmount)*100) / 100
+
+	switch {
+	case inv.Balance <= 0:
+		inv.Balance = 0
+		inv.Status = AccountStatusPaid
+	case inv.DueDate.Before(at):
+		inv.Status = AccountStatusOverdue
+	case inv.PaidAmount > 0:
+		inv.Status = AccountStatusPartiallyPaid
+	default:
+		inv.Status = AccountStatusPending
+	}
+}
+
+// SupplierPayment is a payment made to a supplier against an invoice, possibly
+// in another currency than the invoice
+type SupplierPayment struct {
+	PaymentID     uuid.UUID     `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"payment_id"`
+	InvoiceID     uuid.UUID     `gorm:"type:uuid;not null;index" json:"invoice_id"`
+	SupplierID    uuid.UUID     `gorm:"type:uuid;not null;index" json:"supplier_id"`
+	Amount        float64       `gorm:"type:decimal(15,2);not null" json:"amount"` // In the payment currency
+	Currency      CurrencyCode  `gorm:"type:currency_code;default:'VES'" json:"currency"`
+	ExchangeRate  *float64      `gorm:"type:decimal(15,4)" json:"exchange_rate,omitempty"` // VES per unit of foreign currency
+	AppliedAmount float64       `gorm:"type:decimal(15,2);not null" json:"applied_amount"` // In the invoice currency
+	PaymentMethod PaymentMethod `gorm:"type:payment_method;not null" json:"payment_method"`
+	Reference     *string       `gorm:"type:varchar(100)" json:"reference,omitempty"`
+	PaymentDate   time.Time     `gorm:"default:CURRENT_TIMESTAMP" json:"payment_date"`
+	Notes         *string       `gorm:"type:text" json:"notes,omitempty"`
+	CreatedBy     *uuid.UUID    `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt     time.Time     `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (SupplierPayment) TableName() string {
+	return "supplier_payments"
+}
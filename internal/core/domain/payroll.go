@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PayrollConcept is an allowance, deduction or employer contribution applied
+// to every payslip, either a fixed amount or a formula over the salary
+type PayrollConcept struct {
+	ConceptID       uuid.UUID              `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"concept_id"`
+	Code            string                 `gorm:"type:varchar(20);not null;uniqueIndex" json:"code"`
+	Name            string                 `gorm:"type:varchar(100);not null" json:"name"`
+	Type            PayrollConceptType     `gorm:"type:varchar(30);not null" json:"type"`
+	CalculationType PayrollCalculationType `gorm:"type:varchar(20);not null" json:"calculation_type"`
+	Amount          float64                `gorm:"type:decimal(15,2);default:0" json:"amount"`       // Fixed amount per period
+	Currency        CurrencyCode           `gorm:"type:currency_code;default:'VES'" json:"currency"` // Currency of the fixed amount
+	Formula         *string                `gorm:"type:text" json:"formula,omitempty"`               // e.g. PeriodSalary * 0.04
+	IsActive        bool                   `gorm:"default:true" json:"is_active"`
+	CreatedBy       *uuid.UUID             `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt       time.Time              `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt       time.Time              `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (PayrollConcept) TableName() string {
+	return "payroll_concepts"
+}
+
+// PayrollPeriod is a pay period of the employees of a store, or of every
+// store when StoreID is empty. It moves OPEN → PROCESSING → PROCESSED → PAID →
+// CLOSED and its payslips are locked once PROCESSED.
+type PayrollPeriod struct {
+	PeriodID     uuid.UUID           `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"period_id"`
+	Name         string              `gorm:"type:varchar(100);not null" json:"name"`
+	StoreID      *uuid.UUID          `gorm:"type:uuid;index" json:"store_id,omitempty"`
+	Frequency    PayrollFrequency    `gorm:"type:varchar(20);not null" json:"frequency"`
+	StartDate    time.Time           `gorm:"type:date;not null" json:"start_date"`
+	EndDate      time.Time           `gorm:"type:date;not null" json:"end_date"`
+	PayDate      time.Time           `gorm:"type:date;not null" json:"pay_date"`
+	Status       PayrollPeriodStatus `gorm:"type:varchar(20);default:'OPEN';index" json:"status"`
+	ExchangeRate *float64            `gorm:"type:decimal(15,4)" json:"exchange_rate,omitempty"` // VES per unit of foreign currency for the period
+	Notes        *string             `gorm:"type:text" json:"notes,omitempty"`
+	CalculatedAt *time.Time          `json:"calculated_at,omitempty"`
+	ProcessedAt  *time.Time          `json:"processed_at,omitempty"`
+	ProcessedBy  *uuid.UUID          `gorm:"type:uuid" json:"processed_by,omitempty"`
+	PaidAt       *time.Time          `json:"paid_at,omitempty"`
+	ClosedAt     *time.Time          `json:"closed_at,omitempty"`
+	CreatedBy    *uuid.UUID          `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt    time.Time           `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt    time.Time           `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+
+	// Relations
+	Store    *Store    `gorm:"foreignKey:StoreID" json:"store,omitempty"`
+	Payslips []Payslip `gorm:"foreignKey:PeriodID" json:"payslips,omitempty"`
+}
+
+func (PayrollPeriod) TableName() string {
+	return "payroll_periods"
+}
+
+// IsLocked tells whether the payslips of the period can no longer be recalculated
+func (p *PayrollPeriod) IsLocked() bool {
+	return p.Status != PayrollPeriodStatusOpen && p.Status != PayrollPeriodStatusProcessing
+}
+
+// Payslip is the pay of an employee for a period, in the employee's salary currency
+type Payslip struct {
+	PayslipID             uuid.UUID    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"payslip_id"`
+	PeriodID              uuid.UUID    `gorm:"type:uuid;not null;index" json:"period_id"`
+	EmployeeID            uuid.UUID    `gorm:"type:uuid;not null;index" json:"employee_id"`
+	StoreID               *uuid.UUID   `gorm:"type:uuid;index" json:"store_id,omitempty"` // Store of the employee when calculated
+	Currency              CurrencyCode `gorm:"type:currency_code;default:'VES'" json:"currency"`
+	BaseSalary            float64      `gorm:"type:decimal(15,2);not null" json:"base_salary"` // Monthly salary when calculated
+	PeriodDays            int          `gorm:"not null" json:"period_days"`
+	WorkedDays            int          `gorm:"not null" json:"worked_days"`
+	BasicPay              float64      `gorm:"type:decimal(15,2);not null" json:"basic_pay"` // Salary earned in the period
+	TotalAllowances       float64      `gorm:"type:decimal(15,2);default:0" json:"total_allowances"`
+	TotalDeductions       float64      `gorm:"type:decimal(15,2);default:0" json:"total_deductions"`
+	EmployerContributions float64      `gorm:"type:decimal(15,2);default:0" json:"employer_contributions"`
+	NetPay                float64      `gorm:"type:decimal(15,2);not null" json:"net_pay"`
+	CreatedAt             time.Time    `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+
+	// Relations
+	Employee *Employee     `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+	Store    *Store        `gorm:"foreignKey:StoreID" json:"store,omitempty"`
+	Lines    []PayslipLine `gorm:"foreignKey:PayslipID" json:"lines,omitempty"`
+}
+
+func (Payslip) TableName() string {
+	return "payslips"
+}
+
+// PayslipLine is the amount of a concept in a payslip, with the concept's
+// code and name copied so later concept changes don't alter it
+type PayslipLine struct {
+	LineID    uuid.UUID          `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"line_id"`
+	PayslipID uuid.UUID          `gorm:"type:uuid;not null;index" json:"payslip_id"`
+	ConceptID *uuid.UUID         `gorm:"type:uuid" json:"concept_id,omitempty"`
+	Code      string             `gorm:"type:varchar(20);not null" json:"code"`
+	Name      string             `gorm:"type:varchar(100);not null" json:"name"`
+	Type      PayrollConceptType `gorm:"type:varchar(30);not null" json:"type"`
+	Amount    float64            `gorm:"type:decimal(15,2);not null" json:"amount"` // In the payslip currency
+}
+
+func (PayslipLine) TableName() string {
+	return "payslip_lines"
+}
@@ -133,6 +133,7 @@ type Warehouse struct {
 	LocationID  *uuid.UUID `gorm:"type:uuid" json:"location_id,omitempty"`
 	Address     *string    `gorm:"type:text" json:"address,omitempty"`
 	ManagerID   *uuid.UUID `gorm:"type:uuid" json:"manager_id,omitempty"`
+	IsPrimary   bool       `gorm:"default:false" json:"is_primary"` // Serves the store's orders first
 	IsActive    bool       `gorm:"default:true" json:"is_active"`
 	CreatedAt   time.Time  `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
 
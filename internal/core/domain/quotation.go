@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Quotation represents a priced quote given to a customer before buying.
+// Quoted prices are locked until ValidUntil.
+type Quotation struct {
+	QuotationID     uuid.UUID                `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"quotation_id"`
+	QuotationNumber string                   `gorm:"type:varchar(50);not null;uniqueIndex" json:"quotation_number"`
+	CustomerID      *uuid.UUID               `gorm:"type:uuid" json:"customer_id,omitempty"`
+	StoreID         uuid.UUID                `gorm:"type:uuid;not null" json:"store_id"`
+	Status          QuotationStatus          `gorm:"type:varchar(20);default:'DRAFT'" json:"status"`
+	Currency        CurrencyCode             `gorm:"type:currency_code;default:'VES'" json:"currency"`
+	Subtotal        float64                  `gorm:"type:decimal(15,2);default:0" json:"subtotal"`
+	DiscountAmount  float64                  `gorm:"type:decimal(15,2);default:0" json:"discount_amount"`
+	TotalAmount     float64                  `gorm:"type:decimal(15,2);default:0" json:"total_amount"`
+	ValidUntil      time.Time                `gorm:"not null" json:"valid_until"`
+	Notes           *string                  `gorm:"type:text" json:"notes,omitempty"`
+	SentAt          *time.Time               `json:"sent_at,omitempty"`
+	AcceptedAt      *time.Time               `json:"accepted_at,omitempty"`
+	ConvertedAt     *time.Time               `json:"converted_at,omitempty"`
+	ConvertedTo     *QuotationConversionType `gorm:"type:varchar(20)" json:"converted_to,omitempty"`
+	SaleID          *uuid.UUID               `gorm:"type:uuid" json:"sale_id,omitempty"`
+	ReservationID   *uuid.UUID               `gorm:"type:uuid" json:"reservation_id,omitempty"`
+	CreatedAt       time.Time                `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt       time.Time                `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+	CreatedBy       *uuid.UUID               `gorm:"type:uuid" json:"created_by,omitempty"`
+
+	// Relations
+	Customer *Customer       `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	Store    *Store          `gorm:"foreignKey:StoreID" json:"store,omitempty"`
+	Items    []QuotationItem `gorm:"foreignKey:QuotationID" json:"items,omitempty"`
+}
+
+func (Quotation) TableName() string {
+	return "quotations"
+}
+
+// IsExpired checks whether the quoted prices are no longer valid
+func (q *Quotation) IsExpired(at time.Time) bool {
+	return q.ValidUntil.Before(at)
+}
+
+// IsConverted checks whether the quotation was already turned into a sale or reservation
+func (q *Quotation) IsConverted() bool {
+	return q.ConvertedAt != nil
+}
+
+// QuotationItem represents a priced line of a quotation
+type QuotationItem struct {
+	QuotationItemID uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"quotation_item_id"`
+	QuotationID     uuid.UUID  `gorm:"type:uuid;not null" json:"quotation_id"`
+	ProductID       uuid.UUID  `gorm:"type:uuid;not null" json:"product_id"`
+	Quantity        float64    `gorm:"type:decimal(15,3);not null" json:"quantity"`
+	UnitPrice       float64    `gorm:"type:decimal(15,2);not null" json:"unit_price"`
+	DiscountAmount  float64    `gorm:"type:decimal(15,2);default:0" json:"discount_amount"`
+	CampaignID      *uuid.UUID `gorm:"type:uuid" json:"campaign_id,omitempty"`
+	TotalAmount     float64    `gorm:"type:decimal(15,2);not null" json:"total_amount"`
+	CreatedAt       time.Time  `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+
+	// Relations
+	Product *Product `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+}
+
+func (QuotationItem) TableName() string {
+	return "quotation_items"
+}
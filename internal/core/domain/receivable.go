@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LateFeePolicy defines the fee charged on receivables paid late.
+// At most one active policy applies to each currency.
+type LateFeePolicy struct {
+	PolicyID  uuid.UUID    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"policy_id"`
+	Name      string       `gorm:"type:varchar(100);not null" json:"name"`
+	Currency  CurrencyCode `gorm:"type:currency_code;default:'VES'" json:"currency"`
+	FeeType   LateFeeType  `gorm:"type:varchar(20);not null" json:"fee_type"`
+	Amount    float64      `gorm:"type:decimal(15,4);not null" json:"amount"` // Flat fee, or percent of the balance per day
+	GraceDays int          `gorm:"default:0" json:"grace_days"`
+	MaxAmount *float64     `gorm:"type:decimal(15,2)" json:"max_amount,omitempty"` // Cap of the fees charged on one receivable
+	IsActive  bool         `gorm:"default:true" json:"is_active"`
+	CreatedAt time.Time    `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt time.Time    `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+	CreatedBy *uuid.UUID   `gorm:"type:uuid" json:"created_by,omitempty"`
+}
+
+func (LateFeePolicy) TableName() string {
+	return "late_fee_policies"
+}
+
+// CreditOverride records a credit sale a supervisor approved outside the customer's terms
+type CreditOverride struct {
+	OverrideID   uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"override_id"`
+	SaleID       uuid.UUID `gorm:"type:uuid;not null;index" json:"sale_id"`
+	CustomerID   uuid.UUID `gorm:"type:uuid;not null;index" json:"customer_id"`
+	SupervisorID uuid.UUID `gorm:"type:uuid;not null" json:"supervisor_id"`
+	Reason       string    `gorm:"type:text;not null" json:"reason"`
+	Violations   string    `gorm:"type:text;not null" json:"violations"` // Terms the sale broke, one per line
+	CreditLimit  float64   `gorm:"type:decimal(15,2)" json:"credit_limit"`
+	Outstanding  float64   `gorm:"type:decimal(15,2)" json:"outstanding"` // Customer balance before the sale, in VES
+	Amount       float64   `gorm:"type:decimal(15,2)" json:"amount"`      // Amount financed by the sale, in VES
+	CreatedAt    time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+
+	// Relations
+	Sale       *Sale     `gorm:"foreignKey:SaleID" json:"sale,omitempty"`
+	Customer   *Customer `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	Supervisor *User     `gorm:"foreignKey:SupervisorID" json:"supervisor,omitempty"`
+}
+
+func (CreditOverride) TableName() string {
+	return "credit_overrides"
+}
+
+// CustomerReceipt is a lump sum paid by a customer and allocated across their
+// receivables. Each allocation is a CustomerPayment in the receivable currency;
+// whatever is left is credited to the customer as store credit.
+type CustomerReceipt struct {
+	ReceiptID       uuid.UUID     `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"receipt_id"`
+	CustomerID      uuid.UUID     `gorm:"type:uuid;not null;index" json:"customer_id"`
+	ReceiptDate     time.Time     `gorm:"default:CURRENT_TIMESTAMP" json:"receipt_date"`
+	Amount          float64       `gorm:"type:decimal(15,2);not null" json:"amount"`
+	Currency        CurrencyCode  `gorm:"type:currency_code;default:'VES'" json:"currency"`
+	ExchangeRate    *float64      `gorm:"type:decimal(15,4)" json:"exchange_rate,omitempty"` // VES per unit of foreign currency
+	PaymentMethod   PaymentMethod `gorm:"type:payment_method;not null" json:"payment_method"`
+	Reference       *string       `gorm:"type:varchar(100)" json:"reference,omitempty"`
+	AllocatedAmount float64       `gorm:"type:decimal(15,2);default:0" json:"allocated_amount"` // In the receipt currency
+	CreditAmount    float64       `gorm:"type:decimal(15,2);default:0" json:"credit_amount"`    // Left over and credited to the customer
+	CreditAccountID *uuid.UUID    `gorm:"type:uuid" json:"credit_account_id,omitempty"`
+	Status          ReceiptStatus `gorm:"type:varchar(20);default:'POSTED'" json:"status"`
+	ReversedAt      *time.Time    `json:"reversed_at,omitempty"`
+	ReversedBy      *uuid.UUID    `gorm:"type:uuid" json:"reversed_by,omitempty"`
+	ReversalReason  *string       `gorm:"type:text" json:"reversal_reason,omitempty"`
+	Notes           *string       `gorm:"type:text" json:"notes,omitempty"`
+	CreatedAt       time.Time     `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	CreatedBy       *uuid.UUID    `gorm:"type:uuid" json:"created_by,omitempty"`
+
+	// Relations
+	Customer *Customer         `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	Payments []CustomerPayment `gorm:"foreignKey:ReceiptID" json:"payments,omitempty"`
+}
+
+func (CustomerReceipt) TableName() string {
+	return "customer_receipts"
+}
+
+// ReceivableAdjustment reduces a receivable without a payment: a credit note for
+// a return or a discount, applied at once, or a bad-debt write-off, applied once
+// a supervisor approves it.
+type ReceivableAdjustment struct {
+	AdjustmentID     uuid.UUID         `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"adjustment_id"`
+	ReceivableID     uuid.UUID         `gorm:"type:uuid;not null;index" json:"receivable_id"`
+	CustomerID       uuid.UUID         `gorm:"type:uuid;not null;index" json:"customer_id"`
+	AdjustmentType   AdjustmentType    `gorm:"type:varchar(20);not null" json:"adjustment_type"`
+	CreditNoteReason *CreditNoteReason `gorm:"type:varchar(20)" json:"credit_note_reason,omitempty"`
+	Amount           float64           `gorm:"type:decimal(15,2);not null" json:"amount"` // In the receivable currency
+	Currency         CurrencyCode      `gorm:"type:currency_code;default:'VES'" json:"currency"`
+	Reference        *string           `gorm:"type:varchar(100)" json:"reference,omitempty"` // Return or document it comes from
+	Description      string            `gorm:"type:text;not null" json:"description"`
+	Status           AdjustmentStatus  `gorm:"type:varchar(20);default:'PENDING'" json:"status"`
+	RequestedBy      uuid.UUID         `gorm:"type:uuid;not null" json:"requested_by"`
+	ReviewedBy       *uuid.UUID        `gorm:"type:uuid" json:"reviewed_by,omitempty"`
+	ReviewedAt       *time.Time        `json:"reviewed_at,omitempty"`
+	ReviewNotes      *string           `gorm:"type:text" json:"review_notes,omitempty"`
+	AppliedAt        *time.Time        `json:"applied_at,omitempty"`
+	CreatedAt        time.Time         `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt        time.Time         `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+
+	// Relations
+	Receivable *AccountsReceivable `gorm:"foreignKey:ReceivableID" json:"receivable,omitempty"`
+}
+
+func (ReceivableAdjustment) TableName() string {
+	return "receivable_adjustments"
+}
+
+// InstallmentPlan splits the balance of a credit sale into installments, each
+// kept as its own receivable so it is paid, aged and charged late fees apart.
+type InstallmentPlan struct {
+	PlanID           uuid.UUID            `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"plan_id"`
+	SaleID           uuid.UUID            `gorm:"type:uuid;not null;index" json:"sale_id"`
+	CustomerID       uuid.UUID            `gorm:"type:uuid;not null;index" json:"customer_id"`
+	Frequency        InstallmentFrequency `gorm:"type:varchar(20);not null" json:"frequency"`
+	InstallmentCount int                  `gorm:"not null" json:"installment_count"`
+	TotalAmount      float64              `gorm:"type:decimal(15,2);not null" json:"total_amount"`
+	Currency         CurrencyCode         `gorm:"type:currency_code;default:'VES'" json:"currency"`
+	FirstDueDate     time.Time            `gorm:"type:date;not null" json:"first_due_date"`
+	CreatedAt        time.Time            `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+
+	// Relations
+	Sale         *Sale                `gorm:"foreignKey:SaleID" json:"sale,omitempty"`
+	Customer     *Customer            `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	Installments []AccountsReceivable `gorm:"foreignKey:InstallmentPlanID" json:"installments,omitempty"`
+}
+
+func (InstallmentPlan) TableName() string {
+	return "installment_plans"
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -37,12 +38,22 @@ type Sale struct {
 	Warehouse   *Warehouse    `gorm:"foreignKey:WarehouseID" json:"warehouse,omitempty"`
 	Salesperson *User         `gorm:"foreignKey:SalespersonID" json:"salesperson,omitempty"`
 	Details     []SaleDetail  `gorm:"foreignKey:SaleID" json:"details,omitempty"`
+	Tenders     []SaleTender  `gorm:"foreignKey:SaleID" json:"tenders,omitempty"`
 }
 
 func (Sale) TableName() string {
 	return "sales"
 }
 
+// TenderedAmount returns the part of the total settled by non-cash tenders
+func (s *Sale) TenderedAmount() float64 {
+	total := 0.0
+	for _, tender := range s.Tenders {
+		total += tender.Amount
+	}
+	return total
+}
+
 // SaleDetail represents a line item in a sale
 type SaleDetail struct {
 	DetailID       uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"detail_id"`
@@ -51,6 +62,8 @@ type SaleDetail struct {
 	Quantity       float64   `gorm:"type:decimal(15,3);not null" json:"quantity"`
 	UnitPrice      float64   `gorm:"type:decimal(15,2);not null" json:"unit_price"`
 	DiscountAmount float64   `gorm:"type:decimal(15,2);default:0" json:"discount_amount"`
+	CampaignID     *uuid.UUID `gorm:"type:uuid" json:"campaign_id,omitempty"` // Promotion that produced DiscountAmount
+	WarehouseID    *uuid.UUID `gorm:"type:uuid" json:"warehouse_id,omitempty"` // Overrides the sale warehouse for this line
 	Subtotal       float64   `gorm:"type:decimal(15,2);not null" json:"subtotal"`
 	TaxPercentage  float64   `gorm:"type:decimal(5,2);default:0" json:"tax_percentage"`
 	TaxAmount      float64   `gorm:"type:decimal(15,2);default:0" json:"tax_amount"`
@@ -66,29 +79,75 @@ func (SaleDetail) TableName() string {
 	return "sale_details"
 }
 
+// SaleTender represents a non-cash tender applied to a sale (loyalty points, etc.)
+type SaleTender struct {
+	TenderID   uuid.UUID    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"tender_id"`
+	SaleID     uuid.UUID    `gorm:"type:uuid;not null" json:"sale_id"`
+	TenderType TenderType   `gorm:"type:varchar(20);not null" json:"tender_type"`
+	Amount     float64      `gorm:"type:decimal(15,2);not null" json:"amount"`
+	Currency   CurrencyCode `gorm:"type:currency_code;default:'VES'" json:"currency"`
+	Reference  *string      `gorm:"type:varchar(100)" json:"reference,omitempty"`
+	CreatedAt  time.Time    `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (SaleTender) TableName() string {
+	return "sale_tenders"
+}
+
 // AccountsReceivable represents money owed by customers
 type AccountsReceivable struct {
-	ReceivableID uuid.UUID     `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"receivable_id"`
-	SaleID       *uuid.UUID    `gorm:"type:uuid" json:"sale_id,omitempty"`
-	CustomerID   uuid.UUID     `gorm:"type:uuid;not null" json:"customer_id"`
-	TotalAmount  float64       `gorm:"type:decimal(15,2);not null" json:"total_amount"`
-	PaidAmount   float64       `gorm:"type:decimal(15,2);default:0" json:"paid_amount"`
-	Balance      float64       `gorm:"type:decimal(15,2);not null" json:"balance"`
-	Currency     CurrencyCode  `gorm:"type:currency_code;default:'VES'" json:"currency"`
-	DueDate      time.Time     `gorm:"type:date;not null" json:"due_date"`
-	Status       AccountStatus `gorm:"type:account_status;default:'PENDING'" json:"status"`
-	Notes        *string       `gorm:"type:text" json:"notes,omitempty"`
-	CreatedAt    time.Time     `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	ReceivableID       uuid.UUID      `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"receivable_id"`
+	SaleID             *uuid.UUID     `gorm:"type:uuid" json:"sale_id,omitempty"`
+	CustomerID         uuid.UUID      `gorm:"type:uuid;not null" json:"customer_id"`
+	TotalAmount        float64        `gorm:"type:decimal(15,2);not null" json:"total_amount"`
+	PaidAmount         float64        `gorm:"type:decimal(15,2);default:0" json:"paid_amount"`
+	CreditedAmount     float64        `gorm:"type:decimal(15,2);default:0" json:"credited_amount"`    // Reduced by credit notes
+	WrittenOffAmount   float64        `gorm:"type:decimal(15,2);default:0" json:"written_off_amount"` // Written off as bad debt
+	Balance            float64        `gorm:"type:decimal(15,2);not null" json:"balance"`
+	Currency           CurrencyCode   `gorm:"type:currency_code;default:'VES'" json:"currency"`
+	DueDate            time.Time      `gorm:"type:date;not null" json:"due_date"`
+	Status             AccountStatus  `gorm:"type:account_status;default:'PENDING'" json:"status"`
+	Kind               ReceivableKind `gorm:"type:varchar(20);default:'SALE'" json:"kind"`
+	SourceReceivableID *uuid.UUID     `gorm:"type:uuid;index" json:"source_receivable_id,omitempty"` // Receivable a late fee was charged on
+	LateFeePolicyID    *uuid.UUID     `gorm:"type:uuid" json:"late_fee_policy_id,omitempty"`
+	InstallmentPlanID  *uuid.UUID     `gorm:"type:uuid;index" json:"installment_plan_id,omitempty"`
+	InstallmentNumber  *int           `json:"installment_number,omitempty"` // Position in the plan, starting at 1
+	ReminderSentAt     *time.Time     `json:"reminder_sent_at,omitempty"`
+	Notes              *string        `gorm:"type:text" json:"notes,omitempty"`
+	CreatedAt          time.Time      `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
 
 	// Relations
-	Sale     *Sale     `gorm:"foreignKey:SaleID" json:"sale,omitempty"`
-	Customer *Customer `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	Sale            *Sale            `gorm:"foreignKey:SaleID" json:"sale,omitempty"`
+	Customer        *Customer        `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	InstallmentPlan *InstallmentPlan `gorm:"foreignKey:InstallmentPlanID" json:"installment_plan,omitempty"`
 }
 
 func (AccountsReceivable) TableName() string {
 	return "accounts_receivable"
 }
 
+// RefreshBalance recomputes the balance and status after a payment or adjustment.
+// A receivable closed by a write-off, or credited without any payment, is cancelled.
+func (ar *AccountsReceivable) RefreshBalance(at time.Time) {
+	ar.Balance = math.Round((ar.TotalAmount-ar.PaidAmount-ar.CreditedAmount-ar.WrittenOffAmount)*100) / 100
+
+	switch {
+	case ar.Balance <= 0:
+		ar.Balance = 0
+		if ar.PaidAmount > 0 && ar.WrittenOffAmount == 0 {
+			ar.Status = AccountStatusPaid
+		} else {
+			ar.Status = AccountStatusCancelled
+		}
+	case ar.DueDate.Before(at):
+		ar.Status = AccountStatusOverdue
+	case ar.PaidAmount > 0 || ar.CreditedAmount > 0:
+		ar.Status = AccountStatusPartiallyPaid
+	default:
+		ar.Status = AccountStatusPending
+	}
+}
+
 // CustomerPayment represents a payment made by a customer
 type CustomerPayment struct {
 	PaymentID    uuid.UUID     `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"payment_id"`
@@ -99,6 +158,11 @@ type CustomerPayment struct {
 	PaymentMethod PaymentMethod `gorm:"type:payment_method;not null" json:"payment_method"`
 	Reference    *string       `gorm:"type:varchar(100)" json:"reference,omitempty"`
 	Notes        *string       `gorm:"type:text" json:"notes,omitempty"`
+	ReceiptID    *uuid.UUID    `gorm:"type:uuid;index" json:"receipt_id,omitempty"`        // Customer receipt this payment was allocated from
+	ExchangeRate *float64      `gorm:"type:decimal(15,4)" json:"exchange_rate,omitempty"` // Set when the receipt currency differs
+	ReversedAt   *time.Time    `json:"reversed_at,omitempty"`
+	ReversedBy   *uuid.UUID    `gorm:"type:uuid" json:"reversed_by,omitempty"`
+	VoidReason   *string       `gorm:"type:text" json:"void_reason,omitempty"`
 	CreatedAt    time.Time     `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
 	CreatedBy    *uuid.UUID    `gorm:"type:uuid" json:"created_by,omitempty"`
 
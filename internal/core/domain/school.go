@@ -38,6 +38,41 @@ func (a *UUIDArray) Scan(value interface{}) error {
 	return nil
 }
 
+// School represents an educational institution whose supply lists we sell
+type School struct {
+	SchoolID    uuid.UUID        `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"school_id"`
+	Name        string           `gorm:"type:varchar(200);not null" json:"name"`
+	LocationID  *uuid.UUID       `gorm:"type:uuid" json:"location_id,omitempty"`
+	Address     *string          `gorm:"type:text" json:"address,omitempty"`
+	Levels      SchoolLevelArray `gorm:"type:school_level[]" json:"levels,omitempty"`
+	ContactName *string          `gorm:"type:varchar(200)" json:"contact_name,omitempty"`
+	Phone       *string          `gorm:"type:varchar(20)" json:"phone,omitempty"`
+	Email       *string          `gorm:"type:varchar(100)" json:"email,omitempty"`
+	IsActive    bool             `gorm:"default:true" json:"is_active"`
+	BaseModel
+
+	// Relations
+	Location *Location `gorm:"foreignKey:LocationID" json:"location,omitempty"`
+}
+
+func (School) TableName() string {
+	return "schools"
+}
+
+// OffersLevel checks whether the school teaches a level. Schools without
+// registered levels are assumed to offer all of them.
+func (s *School) OffersLevel(level SchoolLevel) bool {
+	if len(s.Levels) == 0 {
+		return true
+	}
+	for _, l := range s.Levels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
 // SchoolSupplyList represents a list of school supplies for a specific grade
 type SchoolSupplyList struct {
 	ListID             uuid.UUID        `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"list_id"`
@@ -51,16 +86,24 @@ type SchoolSupplyList struct {
 	ExpirationDate     *time.Time       `gorm:"type:date" json:"expiration_date,omitempty"`
 	TotalEstimatedCost *float64         `gorm:"type:decimal(15,2)" json:"total_estimated_cost,omitempty"`
 	IsTemplate         bool             `gorm:"default:false" json:"is_template"`
+	SchoolID           *uuid.UUID       `gorm:"type:uuid" json:"school_id,omitempty"` // Nil for generic lists of a level
+	ExpectedEnrollment *int             `json:"expected_enrollment,omitempty"`            // Students expected to buy the list
 	BaseModelWithUser
 
 	// Relations
-	Items []SchoolSupplyListItem `gorm:"foreignKey:ListID" json:"items,omitempty"`
+	School *School                `gorm:"foreignKey:SchoolID" json:"school,omitempty"`
+	Items  []SchoolSupplyListItem `gorm:"foreignKey:ListID" json:"items,omitempty"`
 }
 
 func (SchoolSupplyList) TableName() string {
 	return "school_supply_lists"
 }
 
+// IsPublic checks whether the list can be shown on the public catalog
+func (l *SchoolSupplyList) IsPublic() bool {
+	return l.Status == SchoolListStatusPublished || l.Status == SchoolListStatusActive
+}
+
 // SchoolSupplyListItem represents an item in a school supply list
 type SchoolSupplyListItem struct {
 	ListItemID         uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"list_item_id"`
@@ -115,27 +158,84 @@ type Reservation struct {
 	PickupDate        *time.Time        `json:"pickup_date,omitempty"`
 	TotalAmount       float64           `gorm:"type:decimal(15,2);default:0" json:"total_amount"`
 	DepositAmount     float64           `gorm:"type:decimal(15,2);default:0" json:"deposit_amount"`
+	AmountPaid        float64           `gorm:"type:decimal(15,2);default:0" json:"amount_paid"`      // Deposit and installments received, net of refunds
+	DepositApplied    float64           `gorm:"type:decimal(15,2);default:0" json:"deposit_applied"`  // Part of the amount paid already credited to pickups
+	ForfeitedAmount   float64           `gorm:"type:decimal(15,2);default:0" json:"forfeited_amount"` // Amount paid retained on cancellation or expiry
 	Balance           float64           `gorm:"type:decimal(15,2);default:0" json:"balance"`
 	Currency          CurrencyCode      `gorm:"type:currency_code;default:'VES'" json:"currency"`
 	Notes             *string           `gorm:"type:text" json:"notes,omitempty"`
 	ReminderSentAt    *time.Time        `json:"reminder_sent_at,omitempty"`
+	ExtensionCount    int               `gorm:"default:0" json:"extension_count"`
 	CreatedAt         time.Time         `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
 	CreatedBy         *uuid.UUID        `gorm:"type:uuid" json:"created_by,omitempty"`
 	FulfilledAt       *time.Time        `json:"fulfilled_at,omitempty"`
 	FulfilledBy       *uuid.UUID        `gorm:"type:uuid" json:"fulfilled_by,omitempty"`
 
 	// Relations
-	Customer *Customer          `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
-	Child    *CustomerChild     `gorm:"foreignKey:ChildID" json:"child,omitempty"`
-	List     *SchoolSupplyList  `gorm:"foreignKey:ListID" json:"list,omitempty"`
-	Store    *Store             `gorm:"foreignKey:StoreID" json:"store,omitempty"`
-	Items    []ReservationItem  `gorm:"foreignKey:ReservationID" json:"items,omitempty"`
+	Customer      *Customer                 `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	Child         *CustomerChild            `gorm:"foreignKey:ChildID" json:"child,omitempty"`
+	List          *SchoolSupplyList         `gorm:"foreignKey:ListID" json:"list,omitempty"`
+	Store         *Store                    `gorm:"foreignKey:StoreID" json:"store,omitempty"`
+	Items         []ReservationItem         `gorm:"foreignKey:ReservationID" json:"items,omitempty"`
+	Payments      []ReservationPayment      `gorm:"foreignKey:ReservationID" json:"payments,omitempty"`
+	Modifications []ReservationModification `gorm:"foreignKey:ReservationID" json:"modifications,omitempty"`
 }
 
 func (Reservation) TableName() string {
 	return "reservations"
 }
 
+// ReservationPayment represents money received for, or returned from, a reservation
+type ReservationPayment struct {
+	PaymentID     uuid.UUID              `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"payment_id"`
+	ReservationID uuid.UUID              `gorm:"type:uuid;not null;index" json:"reservation_id"`
+	PaymentType   ReservationPaymentType `gorm:"type:varchar(20);not null" json:"payment_type"`
+	Amount        float64                `gorm:"type:decimal(15,2);not null" json:"amount"`
+	Currency      CurrencyCode           `gorm:"type:currency_code;default:'VES'" json:"currency"`
+	ExchangeRate  *float64               `gorm:"type:decimal(15,4)" json:"exchange_rate,omitempty"`
+	AppliedAmount float64                `gorm:"type:decimal(15,2);not null" json:"applied_amount"` // Amount in the reservation currency
+	PaymentMethod *PaymentMethod         `gorm:"type:payment_method" json:"payment_method,omitempty"`
+	Reference     *string                `gorm:"type:varchar(100)" json:"reference,omitempty"`
+	Notes         *string                `gorm:"type:text" json:"notes,omitempty"`
+	PaymentDate   time.Time              `gorm:"default:CURRENT_TIMESTAMP" json:"payment_date"`
+	ReceivedBy    *uuid.UUID             `gorm:"type:uuid" json:"received_by,omitempty"`
+	CreatedAt     time.Time              `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+
+	// Relations
+	Reservation *Reservation `gorm:"foreignKey:ReservationID" json:"reservation,omitempty"`
+}
+
+func (ReservationPayment) TableName() string {
+	return "reservation_payments"
+}
+
+// ReservationModification is an entry of the change history of a reservation
+type ReservationModification struct {
+	ModificationID     uuid.UUID                   `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"modification_id"`
+	ReservationID      uuid.UUID                   `gorm:"type:uuid;not null;index" json:"reservation_id"`
+	ModificationType   ReservationModificationType `gorm:"type:varchar(30);not null" json:"modification_type"`
+	ReservationItemID  *uuid.UUID                  `gorm:"type:uuid" json:"reservation_item_id,omitempty"`
+	ProductID          *uuid.UUID                  `gorm:"type:uuid" json:"product_id,omitempty"`
+	PreviousQuantity   *float64                    `gorm:"type:decimal(15,3)" json:"previous_quantity,omitempty"`
+	NewQuantity        *float64                    `gorm:"type:decimal(15,3)" json:"new_quantity,omitempty"`
+	PreviousExpiration *time.Time                  `json:"previous_expiration,omitempty"`
+	NewExpiration      *time.Time                  `json:"new_expiration,omitempty"`
+	PreviousStoreID    *uuid.UUID                  `gorm:"type:uuid" json:"previous_store_id,omitempty"`
+	NewStoreID         *uuid.UUID                  `gorm:"type:uuid" json:"new_store_id,omitempty"`
+	PreviousTotal      float64                     `gorm:"type:decimal(15,2)" json:"previous_total"`
+	NewTotal           float64                     `gorm:"type:decimal(15,2)" json:"new_total"`
+	Reason             *string                     `gorm:"type:text" json:"reason,omitempty"`
+	CreatedAt          time.Time                   `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	CreatedBy          *uuid.UUID                  `gorm:"type:uuid" json:"created_by,omitempty"`
+
+	// Relations
+	Reservation *Reservation `gorm:"foreignKey:ReservationID" json:"reservation,omitempty"`
+}
+
+func (ReservationModification) TableName() string {
+	return "reservation_modifications"
+}
+
 // ReservationItem represents an item in a reservation
 type ReservationItem struct {
 	ReservationItemID uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"reservation_item_id"`
@@ -145,6 +245,9 @@ type ReservationItem struct {
 	ReservedQuantity  float64   `gorm:"type:decimal(15,3);not null" json:"reserved_quantity"`
 	FulfilledQuantity float64   `gorm:"type:decimal(15,3);default:0" json:"fulfilled_quantity"`
 	UnitPrice         float64   `gorm:"type:decimal(15,2);not null" json:"unit_price"`
+	DiscountAmount    float64   `gorm:"type:decimal(15,2);default:0" json:"discount_amount"`
+	CampaignID        *uuid.UUID `gorm:"type:uuid" json:"campaign_id,omitempty"`
+	WarehouseID       *uuid.UUID `gorm:"type:uuid" json:"warehouse_id,omitempty"` // Warehouse holding the reserved stock
 	TotalAmount       float64   `gorm:"type:decimal(15,2);not null" json:"total_amount"`
 	IsFulfilled       bool      `gorm:"default:false" json:"is_fulfilled"`
 	Notes             *string   `gorm:"type:text" json:"notes,omitempty"`
@@ -199,6 +302,7 @@ type PreOrderItem struct {
 	UnitPrice           float64    `gorm:"type:decimal(15,2);not null" json:"unit_price"`
 	TotalAmount         float64    `gorm:"type:decimal(15,2);not null" json:"total_amount"`
 	IsAvailable         bool       `gorm:"default:false" json:"is_available"`
+	WarehouseID         *uuid.UUID `gorm:"type:uuid" json:"warehouse_id,omitempty"` // Warehouse holding the stock once ready
 	ExpectedArrivalDate *time.Time `gorm:"type:date" json:"expected_arrival_date,omitempty"`
 	Notes               *string    `gorm:"type:text" json:"notes,omitempty"`
 	CreatedAt           time.Time  `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
@@ -242,12 +346,19 @@ type Campaign struct {
 	Description         *string          `gorm:"type:text" json:"description,omitempty"`
 	StartDate           time.Time        `gorm:"type:date;not null" json:"start_date"`
 	EndDate             time.Time        `gorm:"type:date;not null" json:"end_date"`
+	PromotionType       PromotionType    `gorm:"type:varchar(20);default:'PERCENTAGE'" json:"promotion_type"`
 	DiscountPercentage  *float64         `gorm:"type:decimal(5,2)" json:"discount_percentage,omitempty"`
+	BuyQuantity         *int             `json:"buy_quantity,omitempty"`
+	GetQuantity         *int             `json:"get_quantity,omitempty"`
+	BundleProducts      UUIDArray        `gorm:"type:uuid[]" json:"bundle_products,omitempty"`
+	BundlePrice         *float64         `gorm:"type:decimal(15,2)" json:"bundle_price,omitempty"`
+	TargetProducts      UUIDArray        `gorm:"type:uuid[]" json:"target_products,omitempty"`
 	TargetSchoolLevels  SchoolLevelArray `gorm:"type:school_level[]" json:"target_school_levels,omitempty"`
 	TargetCategories    UUIDArray        `gorm:"type:uuid[]" json:"target_categories,omitempty"`
 	TargetLocations     UUIDArray        `gorm:"type:uuid[]" json:"target_locations,omitempty"`
 	IsActive            bool             `gorm:"default:true" json:"is_active"`
-	Budget              *float64         `gorm:"type:decimal(15,2)" json:"budget,omitempty"`
+	Budget              *float64         `gorm:"type:decimal(15,2)" json:"budget,omitempty"` // Cap on DiscountGranted
+	DiscountGranted     float64          `gorm:"type:decimal(15,2);default:0" json:"discount_granted"`
 	ActualSales         float64          `gorm:"type:decimal(15,2);default:0" json:"actual_sales"`
 	CreatedAt           time.Time        `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
 	CreatedBy           *uuid.UUID       `gorm:"type:uuid" json:"created_by,omitempty"`
@@ -257,6 +368,19 @@ func (Campaign) TableName() string {
 	return "campaigns"
 }
 
+// RemainingBudget returns how much discount the campaign may still grant.
+// A nil result means the campaign has no budget cap.
+func (c *Campaign) RemainingBudget() *float64 {
+	if c.Budget == nil {
+		return nil
+	}
+	remaining := *c.Budget - c.DiscountGranted
+	if remaining < 0 {
+		remaining = 0
+	}
+	return &remaining
+}
+
 // DemandForecast represents a demand forecast for a product
 type DemandForecast struct {
 	ForecastID         uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"forecast_id"`
@@ -266,6 +390,8 @@ type DemandForecast struct {
 	ConfidenceLevel    *float64   `gorm:"type:decimal(5,2)" json:"confidence_level,omitempty"`
 	ForecastMethod     *string    `gorm:"type:varchar(50)" json:"forecast_method,omitempty"`
 	HistoricalData     JSONB      `gorm:"type:jsonb" json:"historical_data,omitempty"`
+	ActualQuantity     *int       `json:"actual_quantity,omitempty"` // Units sold in the season, set once it ends
+	EvaluatedAt        *time.Time `json:"evaluated_at,omitempty"`
 	CreatedAt          time.Time  `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
 	CreatedBy          *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
 
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StoredValueAccount represents a gift card or a customer store-credit account
+type StoredValueAccount struct {
+	AccountID     uuid.UUID         `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"account_id"`
+	AccountType   StoredValueType   `gorm:"type:varchar(20);not null" json:"account_type"`
+	Code          *string           `gorm:"type:varchar(30);uniqueIndex" json:"code,omitempty"` // Gift cards only
+	CustomerID    *uuid.UUID        `gorm:"type:uuid" json:"customer_id,omitempty"`             // Required for store credit
+	Currency      CurrencyCode      `gorm:"type:currency_code;default:'VES'" json:"currency"`
+	InitialAmount float64           `gorm:"type:decimal(15,2);not null" json:"initial_amount"`
+	Balance       float64           `gorm:"type:decimal(15,2);not null" json:"balance"`
+	Status        StoredValueStatus `gorm:"type:varchar(20);default:'ACTIVE'" json:"status"`
+	ExpiresAt     *time.Time        `json:"expires_at,omitempty"`
+	IssuedAt      time.Time         `gorm:"default:CURRENT_TIMESTAMP" json:"issued_at"`
+	IssuedBy      *uuid.UUID        `gorm:"type:uuid" json:"issued_by,omitempty"`
+	Notes         *string           `gorm:"type:text" json:"notes,omitempty"`
+
+	// Relations
+	Customer *Customer `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+}
+
+func (StoredValueAccount) TableName() string {
+	return "stored_value_accounts"
+}
+
+// IsExpired checks whether the account is past its expiry date
+func (a *StoredValueAccount) IsExpired(at time.Time) bool {
+	return a.ExpiresAt != nil && !a.ExpiresAt.After(at)
+}
+
+// StoredValueTransaction is a movement of a stored-value account balance.
+// Amount is signed and expressed in the account currency.
+type StoredValueTransaction struct {
+	TransactionID   uuid.UUID                  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"transaction_id"`
+	AccountID       uuid.UUID                  `gorm:"type:uuid;not null" json:"account_id"`
+	TransactionType StoredValueTransactionType `gorm:"type:varchar(20);not null" json:"transaction_type"`
+	Amount          float64                    `gorm:"type:decimal(15,2);not null" json:"amount"`
+	BalanceAfter    float64                    `gorm:"type:decimal(15,2);not null" json:"balance_after"`
+	ExchangeRate    *float64                   `gorm:"type:decimal(15,4)" json:"exchange_rate,omitempty"` // Set when the sale currency differs
+	SaleID          *uuid.UUID                 `gorm:"type:uuid" json:"sale_id,omitempty"`
+	Description     *string                    `gorm:"type:text" json:"description,omitempty"`
+	CreatedAt       time.Time                  `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	CreatedBy       *uuid.UUID                 `gorm:"type:uuid" json:"created_by,omitempty"`
+
+	// Relations
+	Account *StoredValueAccount `gorm:"foreignKey:AccountID" json:"account,omitempty"`
+	Sale    *Sale               `gorm:"foreignKey:SaleID" json:"sale,omitempty"`
+}
+
+func (StoredValueTransaction) TableName() string {
+	return "stored_value_transactions"
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+// CampaignFilters contains filter criteria for campaign queries
+type CampaignFilters struct {
+	IsActive      *bool
+	PromotionType *domain.PromotionType
+	ActiveAt      *time.Time
+	Search        string
+}
+
+// CampaignRepository defines the interface for campaign data access
+type CampaignRepository interface {
+	Create(ctx context.Context, campaign *domain.Campaign) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Campaign, error)
+	List(ctx context.Context, filters CampaignFilters, limit, offset int) ([]domain.Campaign, int64, error)
+	GetActive(ctx context.Context, at time.Time) ([]domain.Campaign, error)
+	Update(ctx context.Context, campaign *domain.Campaign) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	RecordUsage(ctx context.Context, id uuid.UUID, salesAmount, discountAmount float64) error
+}
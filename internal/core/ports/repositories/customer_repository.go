@@ -19,6 +19,9 @@ type CustomerFilters struct {
 type CustomerRepository interface {
 	Create(ctx context.Context, customer *domain.Customer) error
 	FindByID(ctx context.Context, id uuid.UUID) (*domain.Customer, error)
+	// FindByIDForUpdate reads a customer locking it until the transaction
+	// carried by ctx ends, serializing the decisions taken on its account
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Customer, error)
 	FindByTaxID(ctx context.Context, taxID string) (*domain.Customer, error)
 	FindByEmail(ctx context.Context, email string) (*domain.Customer, error)
 	FindByFirebaseUID(ctx context.Context, firebaseUID string) (*domain.Customer, error)
@@ -27,6 +30,8 @@ type CustomerRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetWithChildren(ctx context.Context, id uuid.UUID) (*domain.Customer, error)
 	UpdateLoyaltyPoints(ctx context.Context, customerID uuid.UUID, points int) error
+	// UpdateTotalPurchases adds amount, in VES, to the customer's total purchases; negative amounts reduce it
+	UpdateTotalPurchases(ctx context.Context, customerID uuid.UUID, amount float64) error
 }
 
 // CustomerChildRepository defines the interface for customer children data access
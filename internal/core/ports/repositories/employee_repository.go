@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+// EmployeeFilters contains filter criteria for employee queries
+type EmployeeFilters struct {
+	StoreID    *uuid.UUID
+	Department string
+	Status     *domain.EmployeeStatus
+	Search     string // Name, national ID or email
+}
+
+// EmployeeRepository defines the interface for employee data access
+type EmployeeRepository interface {
+	FindStoreByID(ctx context.Context, id uuid.UUID) (*domain.Store, error)
+
+	// Create stores an employee and opens its assignment to its store
+	Create(ctx context.Context, employee *domain.Employee) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Employee, error)
+	FindByNationalID(ctx context.Context, nationalID string) (*domain.Employee, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*domain.Employee, error)
+	List(ctx context.Context, filters EmployeeFilters, limit, offset int) ([]domain.Employee, int64, error)
+	// Update saves the employee data; its store and status have their own methods
+	Update(ctx context.Context, employee *domain.Employee) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// ChangeStatus saves a status transition of the employee, failing when it
+	// is no longer in status from. Terminating closes the open store assignment
+	// and deactivates the linked user; rehiring opens an assignment to the
+	// employee's store.
+	ChangeStatus(ctx context.Context, employee *domain.Employee, from domain.EmployeeStatus) error
+
+	// AssignStore closes the open store assignment of the employee, opens the
+	// given one and moves the employee to its store
+	AssignStore(ctx context.Context, assignment *domain.EmployeeStoreAssignment) error
+	// GetStoreHistory returns the store assignments of an employee, latest first
+	GetStoreHistory(ctx context.Context, employeeID uuid.UUID) ([]domain.EmployeeStoreAssignment, error)
+}
@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+// ExpenseFilters contains filter criteria for expense queries
+type ExpenseFilters struct {
+	StoreID    *uuid.UUID
+	Category   *domain.ExpenseCategory
+	Status     *domain.ExpenseApprovalStatus
+	SupplierID *uuid.UUID
+	DateFrom   *time.Time
+	DateTo     *time.Time
+}
+
+// ExpenseRepository defines the interface for expense and approval rule data access
+type ExpenseRepository interface {
+	FindStoreByID(ctx context.Context, id uuid.UUID) (*domain.Store, error)
+	FindSupplierByID(ctx context.Context, id uuid.UUID) (*domain.Supplier, error)
+
+	// CreateExpense stores an expense together with its approval chain
+	CreateExpense(ctx context.Context, expense *domain.Expense) error
+	FindExpenseByID(ctx context.Context, id uuid.UUID) (*domain.Expense, error)
+	ListExpenses(ctx context.Context, filters ExpenseFilters, limit, offset int) ([]domain.Expense, int64, error)
+	// GetExpenses returns every expense matching the filters, with its store
+	GetExpenses(ctx context.Context, filters ExpenseFilters) ([]domain.Expense, error)
+
+	// RecordDecision saves the decision on a step of the approval chain, the
+	// resulting expense status and its audit trail atomically. It fails when
+	// the expense or the step was decided in the meantime.
+	RecordDecision(ctx context.Context, expense *domain.Expense, approval *domain.ExpenseApproval, audit *domain.AuditLog) error
+
+	CreateApprovalRule(ctx context.Context, rule *domain.ExpenseApprovalRule) error
+	FindApprovalRuleByID(ctx context.Context, id uuid.UUID) (*domain.ExpenseApprovalRule, error)
+	ListApprovalRules(ctx context.Context) ([]domain.ExpenseApprovalRule, error)
+	// GetActiveApprovalRules returns the active rules for the store, global ones included
+	GetActiveApprovalRules(ctx context.Context, storeID uuid.UUID) ([]domain.ExpenseApprovalRule, error)
+	UpdateApprovalRule(ctx context.Context, rule *domain.ExpenseApprovalRule) error
+	DeleteApprovalRule(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+// DemandForecastFilters contains filter criteria for demand forecast queries
+type DemandForecastFilters struct {
+	ProductID  *uuid.UUID
+	SchoolYear *string
+	Method     *domain.ForecastMethod
+	Evaluated  *bool
+}
+
+// DemandForecastRepository defines the interface for demand forecast data access
+type DemandForecastRepository interface {
+	CreateBatch(ctx context.Context, forecasts []domain.DemandForecast) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.DemandForecast, error)
+	List(ctx context.Context, filters DemandForecastFilters, limit, offset int) ([]domain.DemandForecast, int64, error)
+	Update(ctx context.Context, forecast *domain.DemandForecast) error
+
+	// GetLatestBySchoolYear returns the most recent forecast of each product for a school year
+	GetLatestBySchoolYear(ctx context.Context, schoolYear string) ([]domain.DemandForecast, error)
+
+	// GetQuantitiesSold sums the units of completed sales per product within a period
+	GetQuantitiesSold(ctx context.Context, from, to time.Time, productIDs []uuid.UUID) (map[uuid.UUID]float64, error)
+
+	// GetOpenReservedQuantities sums the unfulfilled units of open reservations per product
+	GetOpenReservedQuantities(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]float64, error)
+}
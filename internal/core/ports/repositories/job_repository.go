@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+// JobRunFilters contains filter criteria for job run queries
+type JobRunFilters struct {
+	JobName *string
+	Status  *domain.JobRunStatus
+}
+
+// JobRunRepository defines the interface for job run history and job locking
+type JobRunRepository interface {
+	Create(ctx context.Context, run *domain.JobRun) error
+	// ClaimSlot creates a scheduled run unless its job already has one for the
+	// same slot; claimed is false when another instance got the slot first
+	ClaimSlot(ctx context.Context, run *domain.JobRun) (claimed bool, err error)
+	Update(ctx context.Context, run *domain.JobRun) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.JobRun, error)
+	List(ctx context.Context, filters JobRunFilters, limit, offset int) ([]domain.JobRun, int64, error)
+	GetLastRun(ctx context.Context, jobName string) (*domain.JobRun, error)
+
+	// AcquireLock takes a lock shared by every instance of the application so a
+	// job never runs twice at the same time. acquired is false when another
+	// instance holds it; release must be called once the job is done.
+	AcquireLock(ctx context.Context, jobName string) (release func(), acquired bool, err error)
+}
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+// LoyaltyRuleFilters contains filter criteria for loyalty rule queries
+type LoyaltyRuleFilters struct {
+	Currency   *domain.CurrencyCode
+	CategoryID *uuid.UUID
+	IsActive   *bool
+}
+
+// LoyaltyRepository defines the interface for loyalty rules and points ledger data access
+type LoyaltyRepository interface {
+	// Rules
+	CreateRule(ctx context.Context, rule *domain.LoyaltyRule) error
+	FindRuleByID(ctx context.Context, id uuid.UUID) (*domain.LoyaltyRule, error)
+	ListRules(ctx context.Context, filters LoyaltyRuleFilters, limit, offset int) ([]domain.LoyaltyRule, int64, error)
+	GetActiveRules(ctx context.Context, currency domain.CurrencyCode) ([]domain.LoyaltyRule, error)
+	UpdateRule(ctx context.Context, rule *domain.LoyaltyRule) error
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+
+	// Ledger. CreateTransaction updates the customer balance and consumes
+	// earned lots atomically; negative entries never overdraw the balance.
+	CreateTransaction(ctx context.Context, txn *domain.LoyaltyTransaction) error
+	CreateRedemption(ctx context.Context, txn *domain.LoyaltyTransaction, tender *domain.SaleTender) error
+	FindTransactionsBySale(ctx context.Context, saleID uuid.UUID) ([]domain.LoyaltyTransaction, error)
+	ListTransactions(ctx context.Context, customerID uuid.UUID, from, to *time.Time) ([]domain.LoyaltyTransaction, error)
+	GetBalanceAt(ctx context.Context, customerID uuid.UUID, at time.Time) (int, error)
+	GetExpiredLots(ctx context.Context, at time.Time) ([]domain.LoyaltyTransaction, error)
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+// SupplierInvoiceFilters contains filter criteria for supplier invoice queries
+type SupplierInvoiceFilters struct {
+	SupplierID *uuid.UUID
+	StoreID    *uuid.UUID
+	Status     *domain.AccountStatus
+	Currency   *domain.CurrencyCode
+	DueFrom    *time.Time
+	DueTo      *time.Time
+}
+
+// AccountsPayableRepository defines the interface for supplier invoice and payment data access
+type AccountsPayableRepository interface {
+	FindSupplierByID(ctx context.Context, id uuid.UUID) (*domain.Supplier, error)
+	// UpdateSupplierPurchases adds an amount in VES to the supplier's total purchases and stamps the last purchase date
+	UpdateSupplierPurchases(ctx context.Context, supplierID uuid.UUID, amount float64, at time.Time) error
+
+	CreateInvoice(ctx context.Context, invoice *domain.SupplierInvoice) error
+	FindInvoiceByID(ctx context.Context, id uuid.UUID) (*domain.SupplierInvoice, error)
+	FindInvoiceByNumber(ctx context.Context, supplierID uuid.UUID, number string) (*domain.SupplierInvoice, error)
+	// FindInvoiceByReceipt returns the invoice billing a purchase receipt, cancelled ones excluded
+	FindInvoiceByReceipt(ctx context.Context, receiptID uuid.UUID) (*domain.SupplierInvoice, error)
+	ListInvoices(ctx context.Context, filters SupplierInvoiceFilters, limit, offset int) ([]domain.SupplierInvoice, int64, error)
+	UpdateInvoice(ctx context.Context, invoice *domain.SupplierInvoice) error
+	// CancelInvoice cancels an invoice atomically, provided it has no payments
+	CancelInvoice(ctx context.Context, invoice *domain.SupplierInvoice) error
+
+	// GetOutstanding returns the unpaid invoices matching the filters, oldest due first
+	GetOutstanding(ctx context.Context, filters SupplierInvoiceFilters) ([]domain.SupplierInvoice, error)
+
+	// ReceiptExists tells whether inbound inventory movements reference the purchase receipt
+	ReceiptExists(ctx context.Context, receiptID uuid.UUID) (bool, error)
+
+	// AddPayment stores a payment and applies it to its invoice atomically
+	AddPayment(ctx context.Context, payment *domain.SupplierPayment) error
+	GetPayments(ctx context.Context, invoiceID uuid.UUID) ([]domain.SupplierPayment, error)
+
+	// MarkOverdue flags the unpaid invoices due before the given time as overdue
+	MarkOverdue(ctx context.Context, at time.Time) (int, error)
+}
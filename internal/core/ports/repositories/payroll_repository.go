@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+// PayrollPeriodFilters contains filter criteria for payroll period queries
+type PayrollPeriodFilters struct {
+	StoreID   *uuid.UUID
+	Status    *domain.PayrollPeriodStatus
+	Frequency *domain.PayrollFrequency
+	From      *time.Time // Periods ending on or after
+	To        *time.Time // Periods starting on or before
+}
+
+// PayrollRepository defines the interface for payroll data access
+type PayrollRepository interface {
+	FindStoreByID(ctx context.Context, id uuid.UUID) (*domain.Store, error)
+	// GetPayableEmployees returns the employees of the store (every store when
+	// empty) employed at some point between from and to
+	GetPayableEmployees(ctx context.Context, storeID *uuid.UUID, from, to time.Time) ([]domain.Employee, error)
+
+	// Concepts
+	CreateConcept(ctx context.Context, concept *domain.PayrollConcept) error
+	FindConceptByID(ctx context.Context, id uuid.UUID) (*domain.PayrollConcept, error)
+	FindConceptByCode(ctx context.Context, code string) (*domain.PayrollConcept, error)
+	ListConcepts(ctx context.Context) ([]domain.PayrollConcept, error)
+	GetActiveConcepts(ctx context.Context) ([]domain.PayrollConcept, error)
+	UpdateConcept(ctx context.Context, concept *domain.PayrollConcept) error
+	DeleteConcept(ctx context.Context, id uuid.UUID) error
+
+	// Periods
+	CreatePeriod(ctx context.Context, period *domain.PayrollPeriod) error
+	FindPeriodByID(ctx context.Context, id uuid.UUID) (*domain.PayrollPeriod, error)
+	ListPeriods(ctx context.Context, filters PayrollPeriodFilters, limit, offset int) ([]domain.PayrollPeriod, int64, error)
+	// FindOverlappingPeriod returns a period sharing days with the given dates
+	// that pays the same employees: those of the store, or of any store when empty
+	FindOverlappingPeriod(ctx context.Context, storeID *uuid.UUID, start, end time.Time) (*domain.PayrollPeriod, error)
+	// UpdatePeriodStatus saves the period if its stored status is still from
+	UpdatePeriodStatus(ctx context.Context, period *domain.PayrollPeriod, from domain.PayrollPeriodStatus) error
+
+	// Payslips
+	// ReplacePayslips swaps the payslips of a period for a new calculation run
+	// and moves it to PROCESSING, failing once the period is locked
+	ReplacePayslips(ctx context.Context, period *domain.PayrollPeriod, payslips []domain.Payslip) error
+	GetPayslips(ctx context.Context, periodID uuid.UUID) ([]domain.Payslip, error)
+	FindPayslipByID(ctx context.Context, id uuid.UUID) (*domain.Payslip, error)
+}
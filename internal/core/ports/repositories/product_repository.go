@@ -11,6 +11,7 @@ import (
 type ProductFilters struct {
 	CategoryID     *uuid.UUID
 	IsSchoolSupply *bool
+	SeasonalDemand *bool
 	SchoolLevel    *domain.SchoolLevel
 	Status         *domain.ProductStatus
 	Search         string
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+// QuotationFilters contains filter criteria for quotation queries
+type QuotationFilters struct {
+	CustomerID *uuid.UUID
+	StoreID    *uuid.UUID
+	Status     *domain.QuotationStatus
+	DateFrom   *time.Time
+	DateTo     *time.Time
+}
+
+// QuotationRepository defines the interface for quotation data access
+type QuotationRepository interface {
+	CreateWithItems(ctx context.Context, quotation *domain.Quotation, items []domain.QuotationItem) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Quotation, error)
+	FindByNumber(ctx context.Context, quotationNumber string) (*domain.Quotation, error)
+	List(ctx context.Context, filters QuotationFilters, limit, offset int) ([]domain.Quotation, int64, error)
+	Update(ctx context.Context, quotation *domain.Quotation) error
+	ReplaceItems(ctx context.Context, quotation *domain.Quotation, items []domain.QuotationItem) error
+
+	// MarkConverted claims an open, unconverted quotation for conversion,
+	// failing with a conflict when another conversion got it first
+	MarkConverted(ctx context.Context, quotation *domain.Quotation) error
+
+	// GetExpired returns open quotations whose validity ended before the given time
+	GetExpired(ctx context.Context, at time.Time) ([]domain.Quotation, error)
+}
@@ -33,6 +33,25 @@ type SaleRepository interface {
 	GetSalesByPeriod(ctx context.Context, from, to time.Time) ([]domain.Sale, error)
 }
 
+// AccountsReceivableFilters contains filter criteria for receivable queries
+type AccountsReceivableFilters struct {
+	CustomerID *uuid.UUID
+	StoreID    *uuid.UUID // Store of the originating sale
+	Status     *domain.AccountStatus
+	Currency   *domain.CurrencyCode
+	Kind       *domain.ReceivableKind
+	DueFrom    *time.Time
+	DueTo      *time.Time
+}
+
+// AdjustmentFilters contains filter criteria for receivable adjustment queries
+type AdjustmentFilters struct {
+	CustomerID   *uuid.UUID
+	ReceivableID *uuid.UUID
+	Type         *domain.AdjustmentType
+	Status       *domain.AdjustmentStatus
+}
+
 // AccountsReceivableRepository defines the interface for accounts receivable data access
 type AccountsReceivableRepository interface {
 	Create(ctx context.Context, receivable *domain.AccountsReceivable) error
@@ -40,8 +59,65 @@ type AccountsReceivableRepository interface {
 	FindBySale(ctx context.Context, saleID uuid.UUID) (*domain.AccountsReceivable, error)
 	FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]domain.AccountsReceivable, error)
 	GetOverdue(ctx context.Context) ([]domain.AccountsReceivable, error)
-	List(ctx context.Context, status *domain.AccountStatus, limit, offset int) ([]domain.AccountsReceivable, int64, error)
+	List(ctx context.Context, filters AccountsReceivableFilters, limit, offset int) ([]domain.AccountsReceivable, int64, error)
 	Update(ctx context.Context, receivable *domain.AccountsReceivable) error
 	AddPayment(ctx context.Context, payment *domain.CustomerPayment) error
 	GetPayments(ctx context.Context, receivableID uuid.UUID) ([]domain.CustomerPayment, error)
+
+	// GetOutstanding returns the unpaid receivables matching the filters, oldest due first
+	GetOutstanding(ctx context.Context, filters AccountsReceivableFilters) ([]domain.AccountsReceivable, error)
+
+	// Customer receipts
+	// CreateReceipt stores a receipt and applies its payments to their receivables atomically
+	CreateReceipt(ctx context.Context, receipt *domain.CustomerReceipt, payments []domain.CustomerPayment) error
+	FindReceiptByID(ctx context.Context, id uuid.UUID) (*domain.CustomerReceipt, error)
+	ListReceipts(ctx context.Context, customerID *uuid.UUID, limit, offset int) ([]domain.CustomerReceipt, int64, error)
+	UpdateReceipt(ctx context.Context, receipt *domain.CustomerReceipt) error
+	// ReverseReceipt undoes the payments of a receipt and saves it as reversed
+	ReverseReceipt(ctx context.Context, receipt *domain.CustomerReceipt) error
+
+	// Payment voids
+	FindPaymentByID(ctx context.Context, id uuid.UUID) (*domain.CustomerPayment, error)
+	// VoidPayment marks the payment reversed and restores the balance it paid
+	VoidPayment(ctx context.Context, payment *domain.CustomerPayment) error
+
+	// Credit notes and write-offs
+	// CreateAdjustment stores an adjustment and, when already applied, reduces its receivable
+	CreateAdjustment(ctx context.Context, adjustment *domain.ReceivableAdjustment) error
+	FindAdjustmentByID(ctx context.Context, id uuid.UUID) (*domain.ReceivableAdjustment, error)
+	ListAdjustments(ctx context.Context, filters AdjustmentFilters, limit, offset int) ([]domain.ReceivableAdjustment, int64, error)
+	// ReviewAdjustment saves the review of a pending adjustment, reducing the receivable if approved
+	ReviewAdjustment(ctx context.Context, adjustment *domain.ReceivableAdjustment) error
+	// GetCustomerAdjustments returns the adjustments applied to a customer's receivables up to the given time
+	GetCustomerAdjustments(ctx context.Context, customerID uuid.UUID, to time.Time) ([]domain.ReceivableAdjustment, error)
+
+	// GetCustomerPayments returns the payments on a customer's receivables made up to the given time, reversed ones excluded
+	GetCustomerPayments(ctx context.Context, customerID uuid.UUID, to time.Time) ([]domain.CustomerPayment, error)
+
+	// MarkOverdue flags the unpaid receivables due before the given time as overdue
+	MarkOverdue(ctx context.Context, at time.Time) (int, error)
+
+	// GetLateFeeTotal sums the late fees charged on a receivable, cancelled ones excluded
+	GetLateFeeTotal(ctx context.Context, sourceID uuid.UUID) (float64, error)
+
+	// CreateWithOverride stores a receivable together with the credit override that allowed it
+	CreateWithOverride(ctx context.Context, receivable *domain.AccountsReceivable, override *domain.CreditOverride) error
+	ListCreditOverrides(ctx context.Context, customerID *uuid.UUID, limit, offset int) ([]domain.CreditOverride, int64, error)
+
+	// Installment plans
+	// CreateInstallmentPlan stores a plan with its installment receivables and the credit override that allowed it
+	CreateInstallmentPlan(ctx context.Context, plan *domain.InstallmentPlan, override *domain.CreditOverride) error
+	FindInstallmentPlanByID(ctx context.Context, id uuid.UUID) (*domain.InstallmentPlan, error)
+	ListInstallmentPlans(ctx context.Context, customerID *uuid.UUID, limit, offset int) ([]domain.InstallmentPlan, int64, error)
+	// GetInstallmentsToRemind returns the unpaid installments due between the given times not yet reminded
+	GetInstallmentsToRemind(ctx context.Context, from, to time.Time) ([]domain.AccountsReceivable, error)
+	MarkReminderSent(ctx context.Context, receivableID uuid.UUID, at time.Time) error
+
+	// Late fee policies
+	CreateLateFeePolicy(ctx context.Context, policy *domain.LateFeePolicy) error
+	FindLateFeePolicyByID(ctx context.Context, id uuid.UUID) (*domain.LateFeePolicy, error)
+	ListLateFeePolicies(ctx context.Context) ([]domain.LateFeePolicy, error)
+	GetActiveLateFeePolicy(ctx context.Context, currency domain.CurrencyCode) (*domain.LateFeePolicy, error)
+	UpdateLateFeePolicy(ctx context.Context, policy *domain.LateFeePolicy) error
+	DeleteLateFeePolicy(ctx context.Context, id uuid.UUID) error
 }
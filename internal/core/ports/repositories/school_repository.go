@@ -8,6 +8,34 @@ import (
 	"github.com/jadiazinf/inventory/internal/core/domain"
 )
 
+// SchoolFilters contains filter criteria for school queries
+type SchoolFilters struct {
+	LocationID *uuid.UUID
+	Level      *domain.SchoolLevel
+	IsActive   *bool
+	Search     *string // Matches the school name
+}
+
+// SchoolRepository defines the interface for school data access
+type SchoolRepository interface {
+	Create(ctx context.Context, school *domain.School) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.School, error)
+	List(ctx context.Context, filters SchoolFilters, limit, offset int) ([]domain.School, int64, error)
+	Update(ctx context.Context, school *domain.School) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// SchoolSupplyListFilters contains filter criteria for school supply list queries
+type SchoolSupplyListFilters struct {
+	SchoolID    *uuid.UUID
+	SchoolLevel *domain.SchoolLevel
+	Grade       *string
+	SchoolYear  *string
+	Statuses    []domain.SchoolListStatus
+	IsTemplate  *bool
+	Search      *string // Matches the list name
+}
+
 // SchoolSupplyListRepository defines the interface for school supply list data access
 type SchoolSupplyListRepository interface {
 	Create(ctx context.Context, list *domain.SchoolSupplyList) error
@@ -15,8 +43,9 @@ type SchoolSupplyListRepository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*domain.SchoolSupplyList, error)
 	FindBySchoolLevel(ctx context.Context, level domain.SchoolLevel, schoolYear string) ([]domain.SchoolSupplyList, error)
 	GetActive(ctx context.Context) ([]domain.SchoolSupplyList, error)
-	List(ctx context.Context, limit, offset int) ([]domain.SchoolSupplyList, int64, error)
+	List(ctx context.Context, filters SchoolSupplyListFilters, limit, offset int) ([]domain.SchoolSupplyList, int64, error)
 	GetItems(ctx context.Context, listID uuid.UUID) ([]domain.SchoolSupplyListItem, error)
+	ReplaceItems(ctx context.Context, listID uuid.UUID, items []domain.SchoolSupplyListItem) error
 	Update(ctx context.Context, list *domain.SchoolSupplyList) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	Publish(ctx context.Context, id uuid.UUID) error
@@ -32,20 +61,66 @@ type ReservationFilters struct {
 	DateTo     *time.Time
 }
 
+// ReservationItemChanges groups the item changes of a reservation modification.
+// Items carry their new reserved quantity; the repository reserves or releases
+// the difference with the stock the reservation holds.
+type ReservationItemChanges struct {
+	Added   []domain.ReservationItem
+	Updated []domain.ReservationItem
+	Removed []domain.ReservationItem
+	// Original holds the items as read when the changes were planned, so the
+	// repository can refuse changes planned on items modified since
+	Original []domain.ReservationItem
+}
+
 // ReservationRepository defines the interface for reservation data access
 type ReservationRepository interface {
 	Create(ctx context.Context, reservation *domain.Reservation) error
 	CreateWithItems(ctx context.Context, reservation *domain.Reservation, items []domain.ReservationItem) error
 	FindByID(ctx context.Context, id uuid.UUID) (*domain.Reservation, error)
+	// FindByIDForUpdate reads a reservation locking it until the transaction
+	// carried by ctx ends, so changes computed from it cannot interleave
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Reservation, error)
 	FindByNumber(ctx context.Context, reservationNumber string) (*domain.Reservation, error)
 	List(ctx context.Context, filters ReservationFilters, limit, offset int) ([]domain.Reservation, int64, error)
 	GetItems(ctx context.Context, reservationID uuid.UUID) ([]domain.ReservationItem, error)
 	Update(ctx context.Context, reservation *domain.Reservation) error
 	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.ReservationStatus) error
 	MarkAsFulfilled(ctx context.Context, id uuid.UUID, fulfilledBy uuid.UUID) error
-	Cancel(ctx context.Context, id uuid.UUID) error
+	// Fulfill records a pickup: releases the picked quantities from the warehouse
+	// of each sale detail, creates the sale with its deposit tender and saves the
+	// updated items and reservation
+	Fulfill(ctx context.Context, reservation *domain.Reservation, items []domain.ReservationItem, sale *domain.Sale, details []domain.SaleDetail, deposit *domain.SaleTender) error
+	// Cancel releases the stock still held, records the refund and forfeit
+	// settlements and saves the cancelled or expired reservation
+	Cancel(ctx context.Context, reservation *domain.Reservation, settlements []domain.ReservationPayment) error
+	// AddPayment records a payment and adds its applied amount to the locked
+	// reservation; a deposit payment also saves the reservation status
+	AddPayment(ctx context.Context, reservation *domain.Reservation, payment *domain.ReservationPayment) error
+	GetPayments(ctx context.Context, reservationID uuid.UUID) ([]domain.ReservationPayment, error)
+	// ModifyItems applies item changes, adjusting the stock held with RESERVATION
+	// and RESERVATION_RELEASE movements, and records the modifications
+	ModifyItems(ctx context.Context, reservation *domain.Reservation, changes ReservationItemChanges, modifications []domain.ReservationModification) error
+	// ChangeStore moves the stock still held by each item to the warehouse given
+	// for it (keyed by reservation item ID) and assigns the new pickup store
+	ChangeStore(ctx context.Context, reservation *domain.Reservation, storeID uuid.UUID, warehouses map[uuid.UUID]uuid.UUID, modification *domain.ReservationModification) error
+	// Extend records an extension, saving only the expiration date, extension
+	// count and reminder time of the reservation
+	Extend(ctx context.Context, reservation *domain.Reservation, modification *domain.ReservationModification) error
+	GetModifications(ctx context.Context, reservationID uuid.UUID) ([]domain.ReservationModification, error)
 	GetExpired(ctx context.Context) ([]domain.Reservation, error)
 	GetExpiringFor(ctx context.Context, within time.Duration) ([]domain.Reservation, error)
+	// MarkReminderSent sets only reminder_sent_at, leaving payments and pickups untouched
+	MarkReminderSent(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+// PreOrderFilters contains filter criteria for pre-order queries
+type PreOrderFilters struct {
+	CustomerID *uuid.UUID
+	StoreID    *uuid.UUID
+	Status     *domain.PreOrderStatus
+	DateFrom   *time.Time
+	DateTo     *time.Time
 }
 
 // PreOrderRepository defines the interface for pre-order data access
@@ -55,11 +130,29 @@ type PreOrderRepository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*domain.PreOrder, error)
 	FindByNumber(ctx context.Context, preOrderNumber string) (*domain.PreOrder, error)
 	FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]domain.PreOrder, error)
-	List(ctx context.Context, status *domain.PreOrderStatus, limit, offset int) ([]domain.PreOrder, int64, error)
+	List(ctx context.Context, filters PreOrderFilters, limit, offset int) ([]domain.PreOrder, int64, error)
 	GetItems(ctx context.Context, preOrderID uuid.UUID) ([]domain.PreOrderItem, error)
 	Update(ctx context.Context, preOrder *domain.PreOrder) error
 	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.PreOrderStatus) error
-	MarkAsReady(ctx context.Context, id uuid.UUID) error
+	SetItemsAvailable(ctx context.Context, itemIDs []uuid.UUID) error
+
+	// GetOpenItems returns the items of a product in confirmed pre-orders of a
+	// store that do not hold stock yet, oldest pre-order first
+	GetOpenItems(ctx context.Context, productID, storeID uuid.UUID) ([]domain.PreOrderItem, error)
+
+	// MarkAsReady holds each item in the warehouse allocated to it, saving the
+	// items and the pre-order
+	MarkAsReady(ctx context.Context, preOrder *domain.PreOrder, items []domain.PreOrderItem) error
+
+	// Deliver releases the held stock, creates the sale with the deposit tender
+	// (if any) and saves the pre-order, all in one transaction
+	Deliver(ctx context.Context, preOrder *domain.PreOrder, sale *domain.Sale, details []domain.SaleDetail, deposit *domain.SaleTender) error
+
+	// Cancel saves the pre-order, releasing its held stock when asked to
+	Cancel(ctx context.Context, preOrder *domain.PreOrder, releaseStock bool) error
+
+	// GetUncollected returns ready pre-orders whose pickup deadline passed before the given time
+	GetUncollected(ctx context.Context, at time.Time) ([]domain.PreOrder, error)
 }
 
 // CustomerNotificationRepository defines the interface for notification data access
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+// StoredValueFilters contains filter criteria for stored-value account queries
+type StoredValueFilters struct {
+	AccountType *domain.StoredValueType
+	CustomerID  *uuid.UUID
+	Status      *domain.StoredValueStatus
+	Currency    *domain.CurrencyCode
+}
+
+// StoredValueRepository defines the interface for gift card and store credit data access
+type StoredValueRepository interface {
+	CreateAccount(ctx context.Context, account *domain.StoredValueAccount, issue *domain.StoredValueTransaction) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.StoredValueAccount, error)
+	FindByCode(ctx context.Context, code string) (*domain.StoredValueAccount, error)
+	FindStoreCredit(ctx context.Context, customerID uuid.UUID, currency domain.CurrencyCode) (*domain.StoredValueAccount, error)
+	List(ctx context.Context, filters StoredValueFilters, limit, offset int) ([]domain.StoredValueAccount, int64, error)
+	GetExpired(ctx context.Context, at time.Time) ([]domain.StoredValueAccount, error)
+
+	// CreateTransaction applies the movement to the account balance atomically
+	CreateTransaction(ctx context.Context, txn *domain.StoredValueTransaction) error
+	CreateRedemption(ctx context.Context, txn *domain.StoredValueTransaction, tender *domain.SaleTender) error
+	GetTransactions(ctx context.Context, accountID uuid.UUID) ([]domain.StoredValueTransaction, error)
+	FindTransactionsBySale(ctx context.Context, saleID uuid.UUID) ([]domain.StoredValueTransaction, error)
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+)
+
+// PricingLine represents a line to be priced by the pricing engine
+type PricingLine struct {
+	Product        *domain.Product
+	Quantity       float64
+	UnitPrice      float64
+	ManualDiscount float64 // Lines with a manual discount are not eligible for promotions
+}
+
+// PricedLine represents a line after promotions have been evaluated
+type PricedLine struct {
+	ProductID      uuid.UUID
+	Quantity       float64
+	UnitPrice      float64
+	DiscountAmount float64
+	CampaignID     *uuid.UUID
+}
+
+// CampaignApplication summarizes what a campaign contributed to a document
+type CampaignApplication struct {
+	CampaignID     uuid.UUID
+	DiscountAmount float64
+	SalesAmount    float64
+}
+
+// PricingRequest represents a request to price a set of lines
+type PricingRequest struct {
+	StoreID *uuid.UUID
+	Lines   []PricingLine
+	At      time.Time
+}
+
+// PricingResult contains priced lines and the campaigns that were applied
+type PricingResult struct {
+	Lines         []PricedLine
+	Applications  []CampaignApplication
+	TotalDiscount float64
+}
+
+// CampaignService defines the interface for campaign business logic
+type CampaignService interface {
+	CreateCampaign(ctx context.Context, campaign *domain.Campaign) error
+	GetCampaign(ctx context.Context, id uuid.UUID) (*domain.Campaign, error)
+	ListCampaigns(ctx context.Context, filters repositories.CampaignFilters, limit, offset int) ([]domain.Campaign, int64, error)
+	UpdateCampaign(ctx context.Context, campaign *domain.Campaign) error
+	DeleteCampaign(ctx context.Context, id uuid.UUID) error
+}
+
+// PricingService defines the interface for the promotion pricing engine
+type PricingService interface {
+	PriceLines(ctx context.Context, req PricingRequest) (*PricingResult, error)
+	RecordApplications(ctx context.Context, applications []CampaignApplication) error
+}
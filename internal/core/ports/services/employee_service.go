@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+)
+
+// ChangeEmployeeStatusRequest represents a status transition of an employee
+type ChangeEmployeeStatusRequest struct {
+	EmployeeID uuid.UUID
+	Status     domain.EmployeeStatus
+	Date       *time.Time // Termination or rehire date, defaults to today
+	Reason     *string    // Required to terminate
+	UserID     uuid.UUID
+}
+
+// AssignStoreRequest represents the move of an employee to another store
+type AssignStoreRequest struct {
+	EmployeeID uuid.UUID
+	StoreID    uuid.UUID
+	StartDate  *time.Time // Defaults to today
+	Reason     *string
+	UserID     uuid.UUID
+}
+
+// EmployeeService defines the interface for employee business logic
+type EmployeeService interface {
+	CreateEmployee(ctx context.Context, employee *domain.Employee) error
+	GetEmployee(ctx context.Context, id uuid.UUID) (*domain.Employee, error)
+	ListEmployees(ctx context.Context, filters repositories.EmployeeFilters, limit, offset int) ([]domain.Employee, int64, error)
+	// UpdateEmployee saves the employee data; store, status and user link
+	// change through their own methods
+	UpdateEmployee(ctx context.Context, employee *domain.Employee) error
+	DeleteEmployee(ctx context.Context, id uuid.UUID) error
+
+	// ChangeStatus moves an employee to leave, inactive, terminated or back to
+	// active; terminating deactivates the linked user
+	ChangeStatus(ctx context.Context, req ChangeEmployeeStatusRequest) (*domain.Employee, error)
+
+	// Store assignments
+	AssignStore(ctx context.Context, req AssignStoreRequest) (*domain.Employee, error)
+	GetStoreHistory(ctx context.Context, employeeID uuid.UUID) ([]domain.EmployeeStoreAssignment, error)
+
+	// System user link
+	LinkUser(ctx context.Context, employeeID, userID, updatedBy uuid.UUID) (*domain.Employee, error)
+	UnlinkUser(ctx context.Context, employeeID, updatedBy uuid.UUID) (*domain.Employee, error)
+}
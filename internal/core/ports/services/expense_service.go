@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+)
+
+// CreateExpenseRequest represents an expense to register for approval
+type CreateExpenseRequest struct {
+	StoreID       uuid.UUID
+	Category      domain.ExpenseCategory
+	Description   string
+	Amount        float64
+	Currency      domain.CurrencyCode // Defaults to VES
+	ExchangeRate  *float64            // Required for other currencies than VES
+	SupplierID    *uuid.UUID
+	Reference     *string
+	AttachmentURL *string
+	ExpenseDate   *time.Time // Defaults to today
+	UserID        uuid.UUID
+}
+
+// ExpenseSummaryRow is what a store spent on a category in a month
+type ExpenseSummaryRow struct {
+	Month     time.Time // First day of the month
+	StoreID   uuid.UUID
+	StoreName string
+	Category  domain.ExpenseCategory
+	Expenses  int
+	Amounts   map[domain.CurrencyCode]float64 // In the currency of each expense
+	AmountVES float64
+}
+
+// ExpenseSummary is the approved spending per month, store and category
+type ExpenseSummary struct {
+	From       time.Time
+	To         time.Time
+	Rows       []ExpenseSummaryRow
+	ByCategory map[domain.ExpenseCategory]float64 // In VES
+	TotalVES   float64
+}
+
+// ExpenseService defines the interface for expense business logic
+type ExpenseService interface {
+	// CreateExpense registers an expense with the approval chain of the rules
+	// its amount reaches; with no rule applying it is approved right away
+	CreateExpense(ctx context.Context, req CreateExpenseRequest) (*domain.Expense, error)
+	GetExpense(ctx context.Context, id uuid.UUID) (*domain.Expense, error)
+	ListExpenses(ctx context.Context, filters repositories.ExpenseFilters, limit, offset int) ([]domain.Expense, int64, error)
+
+	// Approval workflow
+	ApproveExpense(ctx context.Context, id, userID uuid.UUID, comments *string) (*domain.Expense, error)
+	RejectExpense(ctx context.Context, id, userID uuid.UUID, comments string) (*domain.Expense, error)
+
+	// Approval rules
+	CreateApprovalRule(ctx context.Context, rule *domain.ExpenseApprovalRule) error
+	GetApprovalRule(ctx context.Context, id uuid.UUID) (*domain.ExpenseApprovalRule, error)
+	ListApprovalRules(ctx context.Context) ([]domain.ExpenseApprovalRule, error)
+	UpdateApprovalRule(ctx context.Context, rule *domain.ExpenseApprovalRule) error
+	DeleteApprovalRule(ctx context.Context, id uuid.UUID) error
+
+	// Reports
+	GetMonthlySummary(ctx context.Context, storeID *uuid.UUID, from, to time.Time) (*ExpenseSummary, error)
+}
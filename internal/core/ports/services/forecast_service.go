@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+)
+
+// GenerateForecastRequest represents a request to forecast the demand of a school season
+type GenerateForecastRequest struct {
+	SchoolYear string      // Season to forecast (YYYY-YYYY)
+	ProductIDs []uuid.UUID // Empty forecasts seasonal products and those in the season's lists
+
+	// Method forces a statistical method. When empty, seasonal-naive is used
+	// for seasonal products and moving-average for the rest.
+	Method         *domain.ForecastMethod
+	HistorySeasons int // Past seasons considered, defaults to 3
+
+	// DefaultEnrollment is the number of students assumed for published lists
+	// without an expected enrollment
+	DefaultEnrollment int
+
+	UserID uuid.UUID
+}
+
+// ForecastAccuracy compares the forecast of a product with what was actually sold
+type ForecastAccuracy struct {
+	ForecastID      uuid.UUID
+	ProductID       uuid.UUID
+	ProductName     string
+	Method          string
+	Forecasted      int
+	Actual          int
+	Error           int      // Actual - Forecasted
+	AbsPercentError *float64 // Nil when nothing was sold
+}
+
+// ForecastEvaluation summarizes how accurate the forecasts of a season were
+type ForecastEvaluation struct {
+	SchoolYear string
+	Items      []ForecastAccuracy
+	MAPE       *float64 // Mean absolute percentage error over products with sales
+	Bias       float64  // Mean error; positive means demand was underestimated
+}
+
+// DemandForecastService defines the interface for demand forecasting business logic
+type DemandForecastService interface {
+	GenerateForecasts(ctx context.Context, req GenerateForecastRequest) ([]domain.DemandForecast, error)
+	GetForecast(ctx context.Context, id uuid.UUID) (*domain.DemandForecast, error)
+	ListForecasts(ctx context.Context, filters repositories.DemandForecastFilters, limit, offset int) ([]domain.DemandForecast, int64, error)
+
+	// EvaluateForecasts records the actual sales of an ended season on its
+	// latest forecasts and reports their accuracy
+	EvaluateForecasts(ctx context.Context, schoolYear string) (*ForecastEvaluation, error)
+}
@@ -25,3 +25,53 @@ type InventoryService interface {
 	GetMovements(ctx context.Context, productID uuid.UUID, limit, offset int) ([]domain.InventoryMovement, int64, error)
 	GetWarehouseMovements(ctx context.Context, warehouseID uuid.UUID, limit, offset int) ([]domain.InventoryMovement, int64, error)
 }
+
+// AllocationItem is a quantity of a product to source from the warehouses
+type AllocationItem struct {
+	ProductID uuid.UUID
+	Quantity  float64
+}
+
+// AllocationRequest asks which warehouses should serve some items for a store
+type AllocationRequest struct {
+	StoreID     uuid.UUID
+	Items       []AllocationItem
+	Strategy    domain.AllocationStrategy // Empty uses the configured strategy
+	WarehouseID *uuid.UUID                // Pins every item to this warehouse
+	NoSplit     bool                      // Each item is taken whole from one warehouse
+	Held        []AllocationLine          // Stock the items already hold, offered to them again
+}
+
+// AllocationLine is the quantity of a product taken from a warehouse
+type AllocationLine struct {
+	ItemIndex   int // Position of the item in the request
+	ProductID   uuid.UUID
+	WarehouseID uuid.UUID
+	Quantity    float64
+}
+
+// AllocationShortage is the quantity of a product the warehouses cannot cover
+type AllocationShortage struct {
+	ProductID uuid.UUID
+	Requested float64
+	Available float64
+}
+
+// AllocationPlan tells which warehouses serve each item. An item split across
+// warehouses has one line per warehouse.
+type AllocationPlan struct {
+	StoreID   uuid.UUID
+	Strategy  domain.AllocationStrategy
+	Lines     []AllocationLine
+	Shortages []AllocationShortage
+}
+
+// AllocationService defines the interface for choosing the warehouses that serve an order
+type AllocationService interface {
+	// Plan computes an allocation plan, reporting shortages instead of failing
+	Plan(ctx context.Context, req AllocationRequest) (*AllocationPlan, error)
+	// Allocate computes an allocation plan that covers every item
+	Allocate(ctx context.Context, req AllocationRequest) (*AllocationPlan, error)
+	// PrimaryWarehouse returns the warehouse that serves a store by default
+	PrimaryWarehouse(ctx context.Context, storeID uuid.UUID) (*domain.Warehouse, error)
+}
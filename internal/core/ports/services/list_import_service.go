@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+// ProductMatch is a product proposed for an imported line
+type ProductMatch struct {
+	ProductID    uuid.UUID
+	ProductName  string
+	SKU          string
+	SellingPrice float64
+	Confidence   float64 // 1 for SKU or barcode matches, lower for name matches
+	MatchedBy    string  // SKU, BARCODE or NAME
+}
+
+// ImportedLine is a line of a school list as read from the source document
+type ImportedLine struct {
+	LineNumber  int
+	Raw         string
+	Quantity    int
+	Description string
+	SKU         *string
+	Barcode     *string
+	IsOptional  bool
+	Candidates  []ProductMatch // Best first
+	Matched     bool           // The best candidate reaches the auto-match confidence
+}
+
+// ListImportPreview contains the parsed lines and their proposed products
+type ListImportPreview struct {
+	Format    domain.ListImportFormat
+	Lines     []ImportedLine
+	Unmatched []ImportedLine
+}
+
+// ImportMapping is a line confirmed by staff
+type ImportMapping struct {
+	LineNumber int
+	ProductID  uuid.UUID
+	Quantity   int
+	IsOptional bool
+	Notes      *string
+}
+
+// ConfirmListImportRequest creates a draft list from confirmed mappings
+type ConfirmListImportRequest struct {
+	List     *domain.SchoolSupplyList
+	Mappings []ImportMapping
+}
+
+// ListImportService defines the interface for importing school supply lists
+type ListImportService interface {
+	PreviewImport(ctx context.Context, format domain.ListImportFormat, content string) (*ListImportPreview, error)
+	ConfirmImport(ctx context.Context, req ConfirmListImportRequest) (*domain.SchoolSupplyList, error)
+}
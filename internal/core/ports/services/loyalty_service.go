@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+)
+
+// AdjustPointsRequest represents a manual correction of a customer balance
+type AdjustPointsRequest struct {
+	CustomerID uuid.UUID
+	Points     int // Positive to credit, negative to debit
+	Reason     string
+	UserID     uuid.UUID
+}
+
+// LoyaltyStatement summarizes the points ledger of a customer for a period
+type LoyaltyStatement struct {
+	CustomerID     uuid.UUID
+	From           time.Time
+	To             time.Time
+	OpeningBalance int
+	ClosingBalance int
+	TotalEarned    int
+	TotalRedeemed  int
+	TotalExpired   int
+	TotalAdjusted  int
+	TotalReturned  int
+	Transactions   []domain.LoyaltyTransaction
+}
+
+// LoyaltyService defines the interface for loyalty points business logic
+type LoyaltyService interface {
+	// Earn rules
+	CreateRule(ctx context.Context, rule *domain.LoyaltyRule) error
+	GetRule(ctx context.Context, id uuid.UUID) (*domain.LoyaltyRule, error)
+	ListRules(ctx context.Context, filters repositories.LoyaltyRuleFilters, limit, offset int) ([]domain.LoyaltyRule, int64, error)
+	UpdateRule(ctx context.Context, rule *domain.LoyaltyRule) error
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+
+	// Sales integration
+	QuoteRedemption(ctx context.Context, customerID uuid.UUID, points int, currency domain.CurrencyCode) (float64, error)
+	RedeemForSale(ctx context.Context, saleID uuid.UUID, points int, userID uuid.UUID) (*domain.LoyaltyTransaction, error)
+	EarnForSale(ctx context.Context, saleID uuid.UUID, userID *uuid.UUID) ([]domain.LoyaltyTransaction, error)
+	ReverseSale(ctx context.Context, saleID uuid.UUID, userID *uuid.UUID) error
+
+	// Ledger
+	AdjustPoints(ctx context.Context, req AdjustPointsRequest) (*domain.LoyaltyTransaction, error)
+	ExpirePoints(ctx context.Context, at time.Time) (int, error)
+	GetStatement(ctx context.Context, customerID uuid.UUID, from, to time.Time) (*LoyaltyStatement, error)
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+)
+
+// CreateSupplierInvoiceRequest represents a supplier invoice to record
+type CreateSupplierInvoiceRequest struct {
+	SupplierID    uuid.UUID
+	StoreID       *uuid.UUID
+	InvoiceNumber string
+	ReceiptID     *uuid.UUID // Purchase receipt billed, empty for standalone invoices
+	IssueDate     *time.Time // Defaults to today
+	DueDate       *time.Time // Defaults to the issue date plus the supplier's credit days
+	TotalAmount   float64
+	Currency      domain.CurrencyCode
+	ExchangeRate  *float64
+	Notes         *string
+	UserID        uuid.UUID
+}
+
+// SupplierPaymentRequest represents a payment to a supplier invoice
+type SupplierPaymentRequest struct {
+	InvoiceID     uuid.UUID
+	Amount        float64
+	Currency      domain.CurrencyCode // Defaults to the invoice currency
+	ExchangeRate  *float64            // Required when paying in another currency
+	PaymentMethod domain.PaymentMethod
+	Reference     *string
+	Notes         *string
+	UserID        uuid.UUID
+}
+
+// PayableAgingRow is the aging of a supplier in one currency
+type PayableAgingRow struct {
+	SupplierID   uuid.UUID
+	SupplierName string
+	Currency     domain.CurrencyCode
+	Invoices     int
+	Buckets      AgingBuckets
+}
+
+// PayableAgingReport is the accounts payable aging as of a date
+type PayableAgingReport struct {
+	AsOf   time.Time
+	Rows   []PayableAgingRow
+	Totals map[domain.CurrencyCode]AgingBuckets
+}
+
+// CashRequirementDay is the money due to suppliers on a date
+type CashRequirementDay struct {
+	Date       time.Time
+	Invoices   int
+	Amounts    map[domain.CurrencyCode]float64
+	Cumulative map[domain.CurrencyCode]float64 // Overdue balances plus everything due up to this date
+}
+
+// CashRequirements forecasts the cash needed to pay suppliers by due date
+type CashRequirements struct {
+	From    time.Time
+	To      time.Time
+	Overdue map[domain.CurrencyCode]float64 // Due before the period and still unpaid
+	Days    []CashRequirementDay
+	Totals  map[domain.CurrencyCode]float64
+}
+
+// AccountsPayableService defines the interface for accounts payable business logic
+type AccountsPayableService interface {
+	CreateInvoice(ctx context.Context, req CreateSupplierInvoiceRequest) (*domain.SupplierInvoice, error)
+	GetInvoice(ctx context.Context, id uuid.UUID) (*domain.SupplierInvoice, error)
+	ListInvoices(ctx context.Context, filters repositories.SupplierInvoiceFilters, limit, offset int) ([]domain.SupplierInvoice, int64, error)
+	// CancelInvoice cancels an invoice recorded by mistake; invoices with payments cannot be cancelled
+	CancelInvoice(ctx context.Context, id uuid.UUID, reason string, userID uuid.UUID) (*domain.SupplierInvoice, error)
+
+	// Payments
+	PayInvoice(ctx context.Context, req SupplierPaymentRequest) (*domain.SupplierPayment, error)
+	GetPayments(ctx context.Context, invoiceID uuid.UUID) ([]domain.SupplierPayment, error)
+
+	// Reports
+	GetAgingReport(ctx context.Context, filters repositories.SupplierInvoiceFilters, asOf time.Time) (*PayableAgingReport, error)
+	GetCashRequirements(ctx context.Context, filters repositories.SupplierInvoiceFilters, from, to time.Time) (*CashRequirements, error)
+
+	// Maintenance operations
+	MarkOverdueInvoices(ctx context.Context, at time.Time) (int, error)
+}
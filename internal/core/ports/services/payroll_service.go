@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+)
+
+// CreatePayrollPeriodRequest represents a pay period to open
+type CreatePayrollPeriodRequest struct {
+	Name         string
+	StoreID      *uuid.UUID // Empty for the employees of every store
+	Frequency    domain.PayrollFrequency
+	StartDate    time.Time
+	EndDate      time.Time
+	PayDate      *time.Time // Defaults to the end date
+	ExchangeRate *float64
+	Notes        *string
+	UserID       uuid.UUID
+}
+
+// PayrollAmounts are the totals of a group of payslips in one currency
+type PayrollAmounts struct {
+	Employees             int
+	BasicPay              float64
+	Allowances            float64
+	Deductions            float64
+	EmployerContributions float64
+	NetPay                float64
+}
+
+// PayrollTotalsRow is what a period pays the employees of a store in one currency
+type PayrollTotalsRow struct {
+	StoreID   *uuid.UUID
+	StoreName string
+	Currency  domain.CurrencyCode
+	PayrollAmounts
+}
+
+// PayrollTotals sums the payslips of a period per store and currency
+type PayrollTotals struct {
+	PeriodID   uuid.UUID
+	Rows       []PayrollTotalsRow
+	ByCurrency map[domain.CurrencyCode]PayrollAmounts
+	// In VES at the period exchange rate, empty when a currency cannot be converted
+	NetPayVES       *float64
+	EmployerCostVES *float64 // Basic pay, allowances and employer contributions
+}
+
+// PayrollService defines the interface for payroll business logic
+type PayrollService interface {
+	// Concepts
+	CreateConcept(ctx context.Context, concept *domain.PayrollConcept) error
+	GetConcept(ctx context.Context, id uuid.UUID) (*domain.PayrollConcept, error)
+	ListConcepts(ctx context.Context) ([]domain.PayrollConcept, error)
+	UpdateConcept(ctx context.Context, concept *domain.PayrollConcept) error
+	DeleteConcept(ctx context.Context, id uuid.UUID) error
+
+	// Periods
+	CreatePeriod(ctx context.Context, req CreatePayrollPeriodRequest) (*domain.PayrollPeriod, error)
+	GetPeriod(ctx context.Context, id uuid.UUID) (*domain.PayrollPeriod, error)
+	ListPeriods(ctx context.Context, filters repositories.PayrollPeriodFilters, limit, offset int) ([]domain.PayrollPeriod, int64, error)
+
+	// Status transitions
+	// CalculatePeriod (re)computes the payslips of an OPEN or PROCESSING period
+	CalculatePeriod(ctx context.Context, id uuid.UUID, exchangeRate *float64, userID uuid.UUID) (*domain.PayrollPeriod, error)
+	// ProcessPeriod locks the calculated payslips
+	ProcessPeriod(ctx context.Context, id, userID uuid.UUID) (*domain.PayrollPeriod, error)
+	PayPeriod(ctx context.Context, id, userID uuid.UUID) (*domain.PayrollPeriod, error)
+	ClosePeriod(ctx context.Context, id, userID uuid.UUID) (*domain.PayrollPeriod, error)
+
+	// Payslips and reports
+	GetPayslips(ctx context.Context, periodID uuid.UUID) ([]domain.Payslip, error)
+	GetPayslip(ctx context.Context, id uuid.UUID) (*domain.Payslip, error)
+	GetPeriodTotals(ctx context.Context, periodID uuid.UUID) (*PayrollTotals, error)
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+)
+
+// QuotationItem represents an item in a quotation request
+type QuotationItem struct {
+	ProductID      uuid.UUID
+	Quantity       float64
+	UnitPrice      *float64 // Optional, will use product price if not provided
+	DiscountAmount float64
+}
+
+// CreateQuotationRequest represents a request to create a quotation
+type CreateQuotationRequest struct {
+	CustomerID   *uuid.UUID
+	StoreID      uuid.UUID
+	Currency     domain.CurrencyCode
+	Items        []QuotationItem
+	ValidityDays int // Days the quoted prices are locked
+	Notes        *string
+	UserID       uuid.UUID
+}
+
+// UpdateQuotationRequest represents a request to reprice a draft quotation
+type UpdateQuotationRequest struct {
+	QuotationID  uuid.UUID
+	CustomerID   *uuid.UUID
+	Items        []QuotationItem
+	ValidityDays int
+	Notes        *string
+	UserID       uuid.UUID
+}
+
+// ConvertQuotationRequest represents a request to turn a quotation into a sale or reservation
+type ConvertQuotationRequest struct {
+	QuotationID uuid.UUID
+	ConvertTo   domain.QuotationConversionType
+	WarehouseID *uuid.UUID // Empty lets the allocation choose the warehouses
+
+	// Sales
+	PaymentMethod    *domain.PaymentMethod
+	PaymentReference *string
+	ExchangeRate     *float64
+	CreditDays       *int
+
+	// Reservations
+	ChildID        *uuid.UUID
+	DepositAmount  float64
+	ExpirationDays int // Defaults to the remaining validity of the quotation
+
+	UserID uuid.UUID
+}
+
+// QuotationConversion contains the documents created from a quotation
+type QuotationConversion struct {
+	Quotation   *domain.Quotation
+	Sale        *domain.Sale
+	Receivable  *domain.AccountsReceivable
+	Reservation *domain.Reservation
+}
+
+// QuotationService defines the interface for quotation business logic
+type QuotationService interface {
+	CreateQuotation(ctx context.Context, req CreateQuotationRequest) (*domain.Quotation, error)
+	GetQuotation(ctx context.Context, id uuid.UUID) (*domain.Quotation, error)
+	GetQuotationByNumber(ctx context.Context, quotationNumber string) (*domain.Quotation, error)
+	ListQuotations(ctx context.Context, filters repositories.QuotationFilters, limit, offset int) ([]domain.Quotation, int64, error)
+	UpdateQuotation(ctx context.Context, req UpdateQuotationRequest) (*domain.Quotation, error)
+
+	// Workflow operations
+	SendQuotation(ctx context.Context, id uuid.UUID) (*domain.Quotation, error)
+	AcceptQuotation(ctx context.Context, id uuid.UUID) (*domain.Quotation, error)
+	ConvertQuotation(ctx context.Context, req ConvertQuotationRequest) (*QuotationConversion, error)
+	RenderPDF(ctx context.Context, id uuid.UUID) ([]byte, error)
+
+	// Maintenance operations
+	ExpireQuotations(ctx context.Context, at time.Time) (int, error)
+}
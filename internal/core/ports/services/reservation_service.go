@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jadiazinf/inventory/internal/core/domain"
@@ -12,6 +13,11 @@ import (
 type ReservationItem struct {
 	ProductID uuid.UUID
 	Quantity  float64
+
+	// Locked pricing, only honored when the reservation comes from a quotation
+	UnitPrice      *float64
+	DiscountAmount float64
+	CampaignID     *uuid.UUID
 }
 
 // CreateReservationRequest represents a request to create a reservation
@@ -19,7 +25,9 @@ type CreateReservationRequest struct {
 	CustomerID     uuid.UUID
 	ChildID        *uuid.UUID
 	ListID         *uuid.UUID
+	QuotationID    *uuid.UUID // Quoted prices are locked; promotions are not re-evaluated
 	StoreID        uuid.UUID
+	WarehouseID    *uuid.UUID // Empty lets the allocation choose the warehouses
 	Items          []ReservationItem
 	DepositAmount  float64
 	Currency       domain.CurrencyCode
@@ -28,13 +36,74 @@ type CreateReservationRequest struct {
 	UserID         uuid.UUID
 }
 
+// FulfillReservationItem represents the quantity of a reservation item picked up
+type FulfillReservationItem struct {
+	ReservationItemID uuid.UUID
+	Quantity          float64
+}
+
 // FulfillReservationRequest represents a request to fulfill a reservation
 type FulfillReservationRequest struct {
-	ReservationID    uuid.UUID
-	PaymentMethod    domain.PaymentMethod
-	PaymentReference *string
-	ExchangeRate     *float64
-	UserID           uuid.UUID
+	ReservationID      uuid.UUID
+	Items              []FulfillReservationItem  // Empty picks up everything still reserved
+	DepositApplication domain.DepositApplication // Defaults to PROPORTIONAL
+	PaymentMethod      domain.PaymentMethod
+	PaymentReference   *string
+	ExchangeRate       *float64
+	UserID             uuid.UUID
+}
+
+// ReservationPaymentRequest represents money received for a reservation before pickup
+type ReservationPaymentRequest struct {
+	ReservationID uuid.UUID
+	Amount        float64
+	Currency      domain.CurrencyCode // Defaults to the reservation currency
+	ExchangeRate  *float64
+	PaymentMethod domain.PaymentMethod
+	Reference     *string
+	Notes         *string
+	UserID        uuid.UUID // Who received the payment
+}
+
+// CancelReservationRequest represents a request to cancel a reservation
+type CancelReservationRequest struct {
+	ReservationID   uuid.UUID
+	Reason          string
+	ForfeitDeposit  bool                  // Retain the agreed deposit instead of refunding it
+	RefundMethod    *domain.PaymentMethod // Required when money is returned
+	RefundReference *string
+	UserID          uuid.UUID
+}
+
+// ReservationItemChange is a change to the items of a reservation
+type ReservationItemChange struct {
+	ReservationItemID *uuid.UUID // Item to change; nil adds ProductID
+	ProductID         *uuid.UUID
+	Quantity          float64 // New quantity of the item; 0 removes it
+}
+
+// ModifyReservationItemsRequest represents a request to add, remove or resize reservation items
+type ModifyReservationItemsRequest struct {
+	ReservationID uuid.UUID
+	Changes       []ReservationItemChange
+	Reason        string
+	UserID        uuid.UUID
+}
+
+// ExtendReservationRequest represents a request to postpone the expiration of a reservation
+type ExtendReservationRequest struct {
+	ReservationID uuid.UUID
+	Days          int
+	Reason        string
+	UserID        uuid.UUID
+}
+
+// ChangeReservationStoreRequest represents a request to pick up a reservation at another store
+type ChangeReservationStoreRequest struct {
+	ReservationID uuid.UUID
+	StoreID       uuid.UUID
+	Reason        string
+	UserID        uuid.UUID
 }
 
 // ReservationService defines the interface for reservation business logic
@@ -45,34 +114,175 @@ type ReservationService interface {
 	ListReservations(ctx context.Context, filters repositories.ReservationFilters, limit, offset int) ([]domain.Reservation, int64, error)
 
 	// Workflow operations
-	ConfirmReservation(ctx context.Context, id uuid.UUID) error
+	ConfirmReservation(ctx context.Context, req ReservationPaymentRequest) error
 	FulfillReservation(ctx context.Context, req FulfillReservationRequest) (*domain.Sale, error)
-	CancelReservation(ctx context.Context, id, userID uuid.UUID, reason string) error
+	CancelReservation(ctx context.Context, req CancelReservationRequest) error
+
+	// Payments
+	RecordPayment(ctx context.Context, req ReservationPaymentRequest) (*domain.ReservationPayment, error)
+	GetPayments(ctx context.Context, reservationID uuid.UUID) ([]domain.ReservationPayment, error)
+
+	// Modifications
+	ModifyItems(ctx context.Context, req ModifyReservationItemsRequest) (*domain.Reservation, error)
+	ExtendReservation(ctx context.Context, req ExtendReservationRequest) (*domain.Reservation, error)
+	ChangeStore(ctx context.Context, req ChangeReservationStoreRequest) (*domain.Reservation, error)
+	GetModifications(ctx context.Context, reservationID uuid.UUID) ([]domain.ReservationModification, error)
 
 	// Maintenance operations
 	ExpireReservations(ctx context.Context) (int, error)
 	SendReminders(ctx context.Context, hoursBeforeExpiration int) (int, error)
 }
 
+// AlternativeAvailability reports the stock of an alternative product for a list item
+type AlternativeAvailability struct {
+	ProductID     uuid.UUID
+	Available     float64
+	IsRecommended bool
+}
+
+// ListItemAvailability reports the stock of a list item in a warehouse
+type ListItemAvailability struct {
+	ListItemID   uuid.UUID
+	ProductID    uuid.UUID
+	Required     float64
+	Available    float64
+	InStock      bool
+	IsOptional   bool
+	Alternatives []AlternativeAvailability
+}
+
+// ListAvailability reports whether a school supply list can be served from a warehouse
+type ListAvailability struct {
+	ListID         uuid.UUID
+	WarehouseID    uuid.UUID
+	Items          []ListItemAvailability
+	FullyAvailable bool // Every required item is in stock, counting allowed alternatives
+}
+
+// ConvertListRequest represents a request to turn a school supply list into a sale or reservation
+type ConvertListRequest struct {
+	ListID           uuid.UUID
+	ConvertTo        domain.ListConversionType
+	CustomerID       *uuid.UUID // Required for reservations; taken from the child when omitted
+	ChildID          *uuid.UUID
+	StoreID          uuid.UUID
+	WarehouseID      uuid.UUID
+	BudgetPreference domain.ListBudgetPreference
+	OptionalItemIDs  []uuid.UUID // Optional list items to include
+	Preview          bool        // Only build the plan, no documents are created
+	Currency         domain.CurrencyCode
+	ExchangeRate     *float64
+	PaymentMethod    *domain.PaymentMethod
+	PaymentReference *string
+	DepositAmount    float64
+	ExpirationDays   int
+	UserID           uuid.UUID
+}
+
+// ListConversionLine is a product chosen to serve a list item
+type ListConversionLine struct {
+	ListItemID         uuid.UUID
+	RequestedProductID uuid.UUID
+	ProductID          uuid.UUID
+	ProductName        string
+	Quantity           float64
+	UnitPrice          float64
+	Substituted        bool
+}
+
+// UnfulfilledListItem reports the quantity of a list item that could not be served
+type UnfulfilledListItem struct {
+	ListItemID uuid.UUID
+	ProductID  uuid.UUID
+	Required   float64
+	Missing    float64
+	Reason     string
+}
+
+// ListConversion contains the plan built from a list and the document created from it
+type ListConversion struct {
+	List           *domain.SchoolSupplyList
+	Lines          []ListConversionLine
+	Unfulfilled    []UnfulfilledListItem
+	EstimatedTotal float64 // At current prices, before promotions
+	Sale           *domain.Sale
+	Reservation    *domain.Reservation
+}
+
 // SchoolSupplyListService defines the interface for school supply list business logic
 type SchoolSupplyListService interface {
 	CreateList(ctx context.Context, list *domain.SchoolSupplyList, items []domain.SchoolSupplyListItem) error
 	GetList(ctx context.Context, id uuid.UUID) (*domain.SchoolSupplyList, error)
+	ListLists(ctx context.Context, filters repositories.SchoolSupplyListFilters, limit, offset int) ([]domain.SchoolSupplyList, int64, error)
+	UpdateList(ctx context.Context, list *domain.SchoolSupplyList) error
+	ReplaceItems(ctx context.Context, listID uuid.UUID, items []domain.SchoolSupplyListItem, userID uuid.UUID) (*domain.SchoolSupplyList, error)
+	DeleteList(ctx context.Context, id uuid.UUID) error
+
+	// Public catalog
 	GetActiveListsByLevel(ctx context.Context, level domain.SchoolLevel, schoolYear string) ([]domain.SchoolSupplyList, error)
+	GetPublishedList(ctx context.Context, id uuid.UUID) (*domain.SchoolSupplyList, error)
+
+	// Workflow operations
 	PublishList(ctx context.Context, id uuid.UUID) error
 	ArchiveList(ctx context.Context, id uuid.UUID) error
-	CheckAvailability(ctx context.Context, listID, warehouseID uuid.UUID) (map[uuid.UUID]float64, error) // Returns productID -> available quantity
+	CloneList(ctx context.Context, id uuid.UUID, schoolYear string, userID uuid.UUID) (*domain.SchoolSupplyList, error)
+
+	// Pricing and stock
+	RecalculateCost(ctx context.Context, id uuid.UUID) (*domain.SchoolSupplyList, error)
+	RecalculateActiveCosts(ctx context.Context) (int, error)
+	CheckAvailability(ctx context.Context, listID, warehouseID uuid.UUID) (*ListAvailability, error)
+	GetListForChild(ctx context.Context, childID uuid.UUID, schoolYear string) (*domain.SchoolSupplyList, error)
+	ConvertList(ctx context.Context, req ConvertListRequest) (*ListConversion, error)
+}
+
+// PreOrderItem represents an item in a pre-order request
+type PreOrderItem struct {
+	ProductID           uuid.UUID
+	Quantity            float64
+	ExpectedArrivalDate *time.Time
+}
+
+// CreatePreOrderRequest represents a request to order products that are not in stock yet
+type CreatePreOrderRequest struct {
+	CustomerID        uuid.UUID
+	StoreID           uuid.UUID
+	Items             []PreOrderItem
+	DepositAmount     float64
+	Currency          domain.CurrencyCode
+	ExpectedReadyDate *time.Time
+	Notes             *string
+	UserID            uuid.UUID
+}
+
+// DeliverPreOrderRequest represents a request to hand over a ready pre-order
+type DeliverPreOrderRequest struct {
+	PreOrderID       uuid.UUID
+	PaymentMethod    domain.PaymentMethod // Used for the balance left after the deposit
+	PaymentReference *string
+	ExchangeRate     *float64
+	UserID           uuid.UUID
 }
 
 // PreOrderService defines the interface for pre-order business logic
 type PreOrderService interface {
-	CreatePreOrder(ctx context.Context, customerID, storeID uuid.UUID, items []ReservationItem, depositAmount float64, currency domain.CurrencyCode, userID uuid.UUID) (*domain.PreOrder, error)
+	CreatePreOrder(ctx context.Context, req CreatePreOrderRequest) (*domain.PreOrder, error)
 	GetPreOrder(ctx context.Context, id uuid.UUID) (*domain.PreOrder, error)
-	ListPreOrders(ctx context.Context, status *domain.PreOrderStatus, limit, offset int) ([]domain.PreOrder, int64, error)
-	ConfirmPreOrder(ctx context.Context, id, userID uuid.UUID) error
-	MarkAsReady(ctx context.Context, id uuid.UUID) error
+	GetPreOrderByNumber(ctx context.Context, preOrderNumber string) (*domain.PreOrder, error)
+	ListPreOrders(ctx context.Context, filters repositories.PreOrderFilters, limit, offset int) ([]domain.PreOrder, int64, error)
+
+	// Workflow operations
+	ConfirmPreOrder(ctx context.Context, id, userID uuid.UUID) (*domain.PreOrder, error)
+	MarkAsReady(ctx context.Context, id uuid.UUID, pickupDays int, userID uuid.UUID) (*domain.PreOrder, error)
 	SendReadyNotification(ctx context.Context, id uuid.UUID) error
-	DeliverPreOrder(ctx context.Context, id uuid.UUID, paymentMethod domain.PaymentMethod, paymentReference *string, userID uuid.UUID) (*domain.Sale, error)
+	DeliverPreOrder(ctx context.Context, req DeliverPreOrderRequest) (*domain.Sale, error)
+	CancelPreOrder(ctx context.Context, id, userID uuid.UUID, reason string) error
+
+	// HandleInboundStock flags the items of confirmed pre-orders that the stock
+	// of a product now covers in a warehouse, oldest pre-order first
+	HandleInboundStock(ctx context.Context, productID, warehouseID uuid.UUID) (int, error)
+
+	// Maintenance operations
+	ExpireUncollected(ctx context.Context, at time.Time) (int, error)
 }
 
 // NotificationService defines the interface for notification operations
@@ -80,6 +290,7 @@ type NotificationService interface {
 	SendReservationConfirmation(ctx context.Context, reservationID uuid.UUID) error
 	SendReservationReminder(ctx context.Context, reservationID uuid.UUID) error
 	SendPreOrderReady(ctx context.Context, preOrderID uuid.UUID) error
+	SendInstallmentReminder(ctx context.Context, receivableID uuid.UUID) error
 	SendCustomNotification(ctx context.Context, customerID uuid.UUID, notificationType domain.NotificationType, subject, message string) error
 	ProcessPendingNotifications(ctx context.Context) (int, error)
 }
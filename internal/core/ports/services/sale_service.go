@@ -15,22 +15,75 @@ type SaleItem struct {
 	Quantity       float64
 	UnitPrice      *float64 // Optional, will use product price if not provided
 	DiscountAmount float64
+	CampaignID     *uuid.UUID // Only honored when prices are locked by a quotation
 }
 
 // CreateSaleRequest represents a request to create a sale
 type CreateSaleRequest struct {
-	CustomerID       *uuid.UUID
-	StoreID          uuid.UUID
-	WarehouseID      uuid.UUID
-	SaleType         domain.SaleType
-	Items            []SaleItem
-	DiscountAmount   float64
-	Currency         domain.CurrencyCode
-	ExchangeRate     *float64
-	PaymentMethod    *domain.PaymentMethod
-	PaymentReference *string
-	Notes            *string
-	SalespersonID    uuid.UUID
+	CustomerID         *uuid.UUID
+	StoreID            uuid.UUID
+	WarehouseID        *uuid.UUID // Empty lets the allocation choose the warehouses
+	SaleType           domain.SaleType
+	Items              []SaleItem
+	DiscountAmount     float64
+	Currency           domain.CurrencyCode
+	ExchangeRate       *float64
+	PaymentMethod      *domain.PaymentMethod
+	PaymentReference   *string
+	Notes              *string
+	SalespersonID      uuid.UUID
+	RedeemPoints       int // Loyalty points used as tender
+	StoredValueTenders []StoredValueTender
+	QuotationID        *uuid.UUID // Quoted prices are locked; promotions are not re-evaluated
+}
+
+// CustomerPaymentRequest represents a lump sum paid by a customer against their receivables
+type CustomerPaymentRequest struct {
+	CustomerID    uuid.UUID
+	Amount        float64
+	Currency      domain.CurrencyCode
+	ExchangeRate  *float64 // Required when a paid receivable is in another currency
+	PaymentMethod domain.PaymentMethod
+	Reference     *string
+	Notes         *string
+	Allocations   []PaymentAllocation // Empty allocates to the oldest receivables first
+	UserID        uuid.UUID
+}
+
+// PaymentAllocation applies part of a customer payment to one receivable
+type PaymentAllocation struct {
+	ReceivableID uuid.UUID
+	Amount       float64 // In the payment currency
+}
+
+// CreditTerms are the conditions requested for a credit sale
+type CreditTerms struct {
+	CreditDays   *int // Defaults to the customer's credit days
+	Installments *InstallmentTerms
+	Override     *CreditOverrideRequest
+}
+
+// InstallmentTerms split a credit sale into installments due one period apart,
+// the first one a period after the sale
+type InstallmentTerms struct {
+	Count     int
+	Frequency domain.InstallmentFrequency
+}
+
+// CreditOverrideRequest lets a supervisor approve a credit sale outside the customer's terms
+type CreditOverrideRequest struct {
+	SupervisorID uuid.UUID
+	Reason       string
+}
+
+// CreditCheck is the outcome of checking a credit sale against the customer's terms.
+// Amounts are in VES, the currency of credit limits.
+type CreditCheck struct {
+	CreditLimit float64
+	Outstanding float64
+	Amount      float64
+	CreditDays  int
+	Violations  []string
 }
 
 // SaleService defines the interface for sale business logic
@@ -44,14 +97,143 @@ type SaleService interface {
 	GetSalesByPeriod(ctx context.Context, from, to time.Time) ([]domain.Sale, error)
 
 	// Credit sales
-	CreateCreditSale(ctx context.Context, req CreateSaleRequest, creditDays int) (*domain.Sale, *domain.AccountsReceivable, error)
+	CreateCreditSale(ctx context.Context, req CreateSaleRequest, terms CreditTerms) (*domain.Sale, *domain.AccountsReceivable, error)
+}
+
+// AgingBuckets splits outstanding balances by days past due
+type AgingBuckets struct {
+	Current    float64 // Not yet due
+	Days1To30  float64
+	Days31To60 float64
+	Days61To90 float64
+	Over90     float64
+	Total      float64
+}
+
+// AgingRow is the aging of a customer at a store in one currency
+type AgingRow struct {
+	CustomerID   uuid.UUID
+	CustomerName string
+	StoreID      *uuid.UUID
+	Currency     domain.CurrencyCode
+	Receivables  int
+	Buckets      AgingBuckets
+}
+
+// AgingReport is the accounts receivable aging as of a date
+type AgingReport struct {
+	AsOf   time.Time
+	Rows   []AgingRow
+	Totals map[domain.CurrencyCode]AgingBuckets
+}
+
+// StatementLineType identifies the movements of a customer statement
+type StatementLineType string
+
+const (
+	StatementLineCharge     StatementLineType = "CHARGE"
+	StatementLineLateFee    StatementLineType = "LATE_FEE"
+	StatementLinePayment    StatementLineType = "PAYMENT"
+	StatementLineCreditNote StatementLineType = "CREDIT_NOTE"
+	StatementLineWriteOff   StatementLineType = "WRITE_OFF"
+)
+
+// StatementLine is a movement in a customer statement
+type StatementLine struct {
+	Date         time.Time
+	Type         StatementLineType
+	ReceivableID uuid.UUID
+	Reference    string // Invoice number or payment reference
+	Charge       float64
+	Payment      float64 // Payments and adjustments reducing the balance
+	Balance      float64 // Running balance after the line
+}
+
+// CustomerStatement summarizes a customer account in one currency for a period
+type CustomerStatement struct {
+	CustomerID       uuid.UUID
+	CustomerName     string
+	Currency         domain.CurrencyCode
+	From             time.Time
+	To               time.Time
+	OpeningBalance   float64
+	TotalCharges     float64
+	TotalPayments    float64
+	TotalAdjustments float64 // Credit notes and write-offs
+	ClosingBalance   float64
+	Lines            []StatementLine
+}
+
+// CreditNoteRequest represents a credit note reducing a receivable
+type CreditNoteRequest struct {
+	ReceivableID uuid.UUID
+	Amount       float64 // In the receivable currency
+	Reason       domain.CreditNoteReason
+	Reference    *string // Return or document the credit comes from
+	Description  string
+	UserID       uuid.UUID
+}
+
+// WriteOffRequest represents a request to write off a receivable as bad debt
+type WriteOffRequest struct {
+	ReceivableID uuid.UUID
+	Amount       *float64 // Defaults to the whole balance
+	Description  string
+	UserID       uuid.UUID
 }
 
 // AccountsReceivableService defines the interface for accounts receivable business logic
 type AccountsReceivableService interface {
 	GetAccountsReceivable(ctx context.Context, id uuid.UUID) (*domain.AccountsReceivable, error)
+	ListReceivables(ctx context.Context, filters repositories.AccountsReceivableFilters, limit, offset int) ([]domain.AccountsReceivable, int64, error)
 	GetCustomerReceivables(ctx context.Context, customerID uuid.UUID) ([]domain.AccountsReceivable, error)
 	GetOverdueReceivables(ctx context.Context) ([]domain.AccountsReceivable, error)
 	RegisterPayment(ctx context.Context, receivableID uuid.UUID, amount float64, currency domain.CurrencyCode, paymentMethod domain.PaymentMethod, reference, notes *string, userID uuid.UUID) error
 	GetPaymentHistory(ctx context.Context, receivableID uuid.UUID) ([]domain.CustomerPayment, error)
+
+	// VoidPayment undoes a single payment registered by mistake
+	VoidPayment(ctx context.Context, paymentID uuid.UUID, reason string, userID uuid.UUID) (*domain.CustomerPayment, error)
+
+	// Credit notes and write-offs
+	IssueCreditNote(ctx context.Context, req CreditNoteRequest) (*domain.ReceivableAdjustment, error)
+	RequestWriteOff(ctx context.Context, req WriteOffRequest) (*domain.ReceivableAdjustment, error)
+	ApproveWriteOff(ctx context.Context, id uuid.UUID, approverID uuid.UUID, notes *string) (*domain.ReceivableAdjustment, error)
+	RejectWriteOff(ctx context.Context, id uuid.UUID, approverID uuid.UUID, notes *string) (*domain.ReceivableAdjustment, error)
+	GetAdjustment(ctx context.Context, id uuid.UUID) (*domain.ReceivableAdjustment, error)
+	ListAdjustments(ctx context.Context, filters repositories.AdjustmentFilters, limit, offset int) ([]domain.ReceivableAdjustment, int64, error)
+
+	// Customer receipts
+	ReceiveCustomerPayment(ctx context.Context, req CustomerPaymentRequest) (*domain.CustomerReceipt, error)
+	ReverseCustomerPayment(ctx context.Context, receiptID uuid.UUID, reason string, userID uuid.UUID) (*domain.CustomerReceipt, error)
+	GetCustomerReceipt(ctx context.Context, id uuid.UUID) (*domain.CustomerReceipt, error)
+	ListCustomerReceipts(ctx context.Context, customerID *uuid.UUID, limit, offset int) ([]domain.CustomerReceipt, int64, error)
+
+	// Reports
+	GetAgingReport(ctx context.Context, filters repositories.AccountsReceivableFilters, asOf time.Time) (*AgingReport, error)
+	GetCustomerStatement(ctx context.Context, customerID uuid.UUID, currency domain.CurrencyCode, from, to time.Time) (*CustomerStatement, error)
+	RenderStatementPDF(ctx context.Context, customerID uuid.UUID, currency domain.CurrencyCode, from, to time.Time) ([]byte, error)
+
+	// Late fee policies
+	CreateLateFeePolicy(ctx context.Context, policy *domain.LateFeePolicy) error
+	GetLateFeePolicy(ctx context.Context, id uuid.UUID) (*domain.LateFeePolicy, error)
+	ListLateFeePolicies(ctx context.Context) ([]domain.LateFeePolicy, error)
+	UpdateLateFeePolicy(ctx context.Context, policy *domain.LateFeePolicy) error
+	DeleteLateFeePolicy(ctx context.Context, id uuid.UUID) error
+
+	// Credit control
+	CreateReceivable(ctx context.Context, receivable *domain.AccountsReceivable, override *domain.CreditOverride) error
+	EvaluateCredit(ctx context.Context, customer *domain.Customer, amount float64, currency domain.CurrencyCode, rate *float64, creditDays int, at time.Time) (*CreditCheck, error)
+	AuthorizeCreditOverride(ctx context.Context, override CreditOverrideRequest, storeID uuid.UUID) error
+	ListCreditOverrides(ctx context.Context, customerID *uuid.UUID, limit, offset int) ([]domain.CreditOverride, int64, error)
+
+	// Installment plans
+	CreateInstallmentPlan(ctx context.Context, plan *domain.InstallmentPlan, override *domain.CreditOverride) error
+	GetInstallmentPlan(ctx context.Context, id uuid.UUID) (*domain.InstallmentPlan, error)
+	ListInstallmentPlans(ctx context.Context, customerID *uuid.UUID, limit, offset int) ([]domain.InstallmentPlan, int64, error)
+
+	// Maintenance operations
+	MarkOverdueReceivables(ctx context.Context, at time.Time) (int, error)
+	ApplyLateFees(ctx context.Context, at time.Time) (int, error)
+	// SendInstallmentReminders notifies customers of the installments falling due within the given days
+	SendInstallmentReminders(ctx context.Context, daysBefore int, at time.Time) (int, error)
 }
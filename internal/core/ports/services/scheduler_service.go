@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+)
+
+// JobFunc is the work of a scheduled job. It returns the number of items processed.
+type JobFunc func(ctx context.Context) (int, error)
+
+// JobInfo describes a registered job
+type JobInfo struct {
+	Name     string
+	Schedule string
+	NextRun  *time.Time
+	LastRun  *domain.JobRun
+}
+
+// SchedulerService defines the interface for the background job scheduler
+type SchedulerService interface {
+	// RegisterJob adds a job that runs on a cron expression
+	RegisterJob(name, schedule string, fn JobFunc) error
+
+	// Start runs the scheduling loop until Stop is called or ctx is cancelled
+	Start(ctx context.Context)
+	// Stop ends the scheduling loop and waits for the running jobs
+	Stop()
+
+	ListJobs(ctx context.Context) ([]JobInfo, error)
+
+	// TriggerJob runs a job right away and waits for it to finish
+	TriggerJob(ctx context.Context, name string, userID *uuid.UUID) (*domain.JobRun, error)
+
+	// Run history
+	GetRun(ctx context.Context, id uuid.UUID) (*domain.JobRun, error)
+	ListRuns(ctx context.Context, filters repositories.JobRunFilters, limit, offset int) ([]domain.JobRun, int64, error)
+}
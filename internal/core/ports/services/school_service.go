@@ -0,0 +1,18 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+)
+
+// SchoolService defines the interface for the schools registry
+type SchoolService interface {
+	CreateSchool(ctx context.Context, school *domain.School) error
+	GetSchool(ctx context.Context, id uuid.UUID) (*domain.School, error)
+	ListSchools(ctx context.Context, filters repositories.SchoolFilters, limit, offset int) ([]domain.School, int64, error)
+	UpdateSchool(ctx context.Context, school *domain.School) error
+	DeleteSchool(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+)
+
+// IssueGiftCardRequest represents a request to issue a gift card
+type IssueGiftCardRequest struct {
+	Code       *string // Generated when empty
+	Amount     float64
+	Currency   domain.CurrencyCode
+	CustomerID *uuid.UUID
+	ExpiresAt  *time.Time
+	SaleID     *uuid.UUID // Sale in which the card was sold
+	Notes      *string
+	UserID     uuid.UUID
+}
+
+// IssueStoreCreditRequest represents a credit to a customer store-credit account
+type IssueStoreCreditRequest struct {
+	CustomerID uuid.UUID
+	Amount     float64
+	Currency   domain.CurrencyCode
+	ExpiresAt  *time.Time
+	SaleID     *uuid.UUID // Returned sale being refunded, if any
+	Reason     string
+	UserID     uuid.UUID
+}
+
+// StoredValueTender identifies a gift card or store-credit account used to pay a sale
+type StoredValueTender struct {
+	AccountID *uuid.UUID
+	Code      *string
+	Amount    float64 // In the sale currency
+}
+
+// StoredValueService defines the interface for gift card and store credit business logic
+type StoredValueService interface {
+	IssueGiftCard(ctx context.Context, req IssueGiftCardRequest) (*domain.StoredValueAccount, error)
+	IssueStoreCredit(ctx context.Context, req IssueStoreCreditRequest) (*domain.StoredValueAccount, error)
+	// RevokeStoreCredit takes back credit issued by mistake; it fails if the credit was already spent
+	RevokeStoreCredit(ctx context.Context, accountID uuid.UUID, amount float64, reason string, userID uuid.UUID) error
+	GetAccount(ctx context.Context, id uuid.UUID) (*domain.StoredValueAccount, error)
+	GetGiftCardByCode(ctx context.Context, code string) (*domain.StoredValueAccount, error)
+	ListAccounts(ctx context.Context, filters repositories.StoredValueFilters, limit, offset int) ([]domain.StoredValueAccount, int64, error)
+	GetTransactions(ctx context.Context, accountID uuid.UUID) ([]domain.StoredValueTransaction, error)
+
+	// Sales integration
+	QuoteTenders(ctx context.Context, customerID *uuid.UUID, currency domain.CurrencyCode, exchangeRate *float64, tenders []StoredValueTender) (float64, error)
+	RedeemForSale(ctx context.Context, saleID uuid.UUID, tenders []StoredValueTender, userID uuid.UUID) ([]domain.StoredValueTransaction, error)
+	ReverseSale(ctx context.Context, saleID uuid.UUID, userID *uuid.UUID) error
+
+	ExpireAccounts(ctx context.Context, at time.Time) (int, error)
+}
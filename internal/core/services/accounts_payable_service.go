@@ -0,0 +1,416 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type accountsPayableService struct {
+	apRepo repositories.AccountsPayableRepository
+	db     *gorm.DB
+}
+
+// NewAccountsPayableService creates a new accounts payable service
+func NewAccountsPayableService(
+	apRepo repositories.AccountsPayableRepository,
+	db *gorm.DB,
+) services.AccountsPayableService {
+	return &accountsPayableService{
+		apRepo: apRepo,
+		db:     db,
+	}
+}
+
+// CreateInvoice records a supplier invoice, due after the supplier's credit
+// days unless a due date is given
+func (s *accountsPayableService) CreateInvoice(ctx context.Context, req services.CreateSupplierInvoiceRequest) (*domain.SupplierInvoice, error) {
+	number := strings.TrimSpace(req.InvoiceNumber)
+	if number == "" {
+		return nil, errors.InvalidInput("Invoice number is required")
+	}
+
+	if req.TotalAmount <= 0 {
+		return nil, errors.InvalidInput("Invoice amount must be positive")
+	}
+
+	if req.Currency == "" {
+		req.Currency = domain.CurrencyVES
+	}
+
+	if req.ExchangeRate != nil && *req.ExchangeRate <= 0 {
+		return nil, errors.InvalidInput("Exchange rate must be positive")
+	}
+
+	// Supplier purchases are kept in VES, so foreign-currency invoices need their rate
+	if _, err := convertAmount(req.TotalAmount, req.Currency, domain.CurrencyVES, req.ExchangeRate); err != nil {
+		return nil, err
+	}
+
+	supplier, err := s.apRepo.FindSupplierByID(ctx, req.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Suppliers number their own invoices, so a number repeats only by mistake
+	if _, err := s.apRepo.FindInvoiceByNumber(ctx, supplier.SupplierID, number); err == nil {
+		return nil, errors.Conflict(fmt.Sprintf("Invoice %s of %s is already recorded", number, supplier.BusinessName))
+	} else if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if req.ReceiptID != nil {
+		exists, err := s.apRepo.ReceiptExists(ctx, *req.ReceiptID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, errors.NotFoundWithID("Purchase receipt", req.ReceiptID.String())
+		}
+
+		if billed, err := s.apRepo.FindInvoiceByReceipt(ctx, *req.ReceiptID); err == nil {
+			return nil, errors.Conflict(fmt.Sprintf("Purchase receipt is already billed by invoice %s", billed.InvoiceNumber))
+		} else if !errors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	issueDate := now
+	if req.IssueDate != nil {
+		issueDate = *req.IssueDate
+	}
+
+	dueDate := supplierDueDate(issueDate, supplier.CreditDays)
+	if req.DueDate != nil {
+		if req.DueDate.Before(issueDate) {
+			return nil, errors.InvalidInput("Due date cannot be before the issue date")
+		}
+		dueDate = *req.DueDate
+	}
+
+	invoice := &domain.SupplierInvoice{
+		InvoiceID:     uuid.New(),
+		SupplierID:    supplier.SupplierID,
+		StoreID:       req.StoreID,
+		InvoiceNumber: number,
+		ReceiptID:     req.ReceiptID,
+		IssueDate:     issueDate,
+		DueDate:       dueDate,
+		TotalAmount:   roundAmount(req.TotalAmount),
+		Currency:      req.Currency,
+		ExchangeRate:  req.ExchangeRate,
+		Notes:         req.Notes,
+		CreatedBy:     &req.UserID,
+	}
+	invoice.RefreshBalance(now)
+
+	if err := s.apRepo.CreateInvoice(ctx, invoice); err != nil {
+		return nil, err
+	}
+	s.recordPurchases(ctx, invoice, 1)
+
+	return invoice, nil
+}
+
+// GetInvoice retrieves a supplier invoice with its payments
+func (s *accountsPayableService) GetInvoice(ctx context.Context, id uuid.UUID) (*domain.SupplierInvoice, error) {
+	return s.apRepo.FindInvoiceByID(ctx, id)
+}
+
+// ListInvoices lists supplier invoices matching the filters
+func (s *accountsPayableService) ListInvoices(ctx context.Context, filters repositories.SupplierInvoiceFilters, limit, offset int) ([]domain.SupplierInvoice, int64, error) {
+	return s.apRepo.ListInvoices(ctx, filters, limit, offset)
+}
+
+// CancelInvoice cancels an invoice recorded by mistake
+func (s *accountsPayableService) CancelInvoice(ctx context.Context, id uuid.UUID, reason string, userID uuid.UUID) (*domain.SupplierInvoice, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.InvalidInput("Cancellation reason is required")
+	}
+
+	invoice, err := s.apRepo.FindInvoiceByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoice.Status == domain.AccountStatusCancelled {
+		return nil, errors.InvalidInput("Supplier invoice is already cancelled")
+	}
+
+	if invoice.PaidAmount > 0 {
+		return nil, errors.Conflict("Supplier invoice has payments and cannot be cancelled")
+	}
+
+	invoice.Status = domain.AccountStatusCancelled
+	invoice.Balance = 0
+	invoice.CancellationReason = &reason
+	invoice.UpdatedAt = time.Now()
+
+	// Payments are checked again on the locked invoice
+	if err := s.apRepo.CancelInvoice(ctx, invoice); err != nil {
+		return nil, err
+	}
+	s.recordPurchases(ctx, invoice, -1)
+
+	log.Printf("[AP] Supplier invoice %s cancelled by %s: %s", invoice.InvoiceNumber, userID, reason)
+
+	return invoice, nil
+}
+
+// PayInvoice records a payment to a supplier invoice. Payments in another
+// currency are converted to the invoice currency with the given exchange rate.
+func (s *accountsPayableService) PayInvoice(ctx context.Context, req services.SupplierPaymentRequest) (*domain.SupplierPayment, error) {
+	if req.Amount <= 0 {
+		return nil, errors.InvalidInput("Payment amount must be positive")
+	}
+
+	invoice, err := s.apRepo.FindInvoiceByID(ctx, req.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoice.Status == domain.AccountStatusPaid {
+		return nil, errors.InvalidInput("Supplier invoice is already fully paid")
+	}
+
+	if invoice.Status == domain.AccountStatusCancelled {
+		return nil, errors.InvalidInput("Supplier invoice is cancelled")
+	}
+
+	if req.Currency == "" {
+		req.Currency = invoice.Currency
+	}
+
+	applied, err := convertAmount(req.Amount, req.Currency, invoice.Currency, req.ExchangeRate)
+	if err != nil {
+		return nil, err
+	}
+
+	if applied > invoice.Balance {
+		return nil, errors.InvalidInput(fmt.Sprintf(
+			"Payment amount (%.2f %s) exceeds balance (%.2f %s)",
+			applied, invoice.Currency, invoice.Balance, invoice.Currency,
+		))
+	}
+
+	payment := &domain.SupplierPayment{
+		PaymentID:     uuid.New(),
+		InvoiceID:     invoice.InvoiceID,
+		SupplierID:    invoice.SupplierID,
+		Amount:        roundAmount(req.Amount),
+		Currency:      req.Currency,
+		AppliedAmount: applied,
+		PaymentMethod: req.PaymentMethod,
+		Reference:     req.Reference,
+		PaymentDate:   time.Now(),
+		Notes:         req.Notes,
+		CreatedBy:     &req.UserID,
+	}
+	if req.Currency != invoice.Currency {
+		payment.ExchangeRate = req.ExchangeRate
+	}
+
+	if err := s.apRepo.AddPayment(ctx, payment); err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// GetPayments retrieves the payments made to a supplier invoice
+func (s *accountsPayableService) GetPayments(ctx context.Context, invoiceID uuid.UUID) ([]domain.SupplierPayment, error) {
+	if _, err := s.apRepo.FindInvoiceByID(ctx, invoiceID); err != nil {
+		return nil, err
+	}
+	return s.apRepo.GetPayments(ctx, invoiceID)
+}
+
+// GetAgingReport groups the unpaid supplier invoices by supplier and days past due
+func (s *accountsPayableService) GetAgingReport(ctx context.Context, filters repositories.SupplierInvoiceFilters, asOf time.Time) (*services.PayableAgingReport, error) {
+	invoices, err := s.apRepo.GetOutstanding(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildPayableAgingReport(invoices, asOf), nil
+}
+
+// GetCashRequirements forecasts the cash needed each day of a period to pay
+// the unpaid supplier invoices falling due
+func (s *accountsPayableService) GetCashRequirements(ctx context.Context, filters repositories.SupplierInvoiceFilters, from, to time.Time) (*services.CashRequirements, error) {
+	if to.Before(from) {
+		return nil, errors.InvalidInput("End date must be after start date")
+	}
+
+	filters.DueFrom = nil
+	filters.DueTo = &to
+	invoices, err := s.apRepo.GetOutstanding(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildCashRequirements(invoices, from, to), nil
+}
+
+// MarkOverdueInvoices flags the unpaid supplier invoices past their due date
+func (s *accountsPayableService) MarkOverdueInvoices(ctx context.Context, at time.Time) (int, error) {
+	return s.apRepo.MarkOverdue(ctx, at)
+}
+
+// recordPurchases adds an invoice, in VES, to the supplier's total purchases,
+// or takes it back with a negative sign. A failure does not undo the invoice.
+func (s *accountsPayableService) recordPurchases(ctx context.Context, invoice *domain.SupplierInvoice, sign float64) {
+	amount, err := convertAmount(invoice.TotalAmount, invoice.Currency, domain.CurrencyVES, invoice.ExchangeRate)
+	if err != nil {
+		log.Printf("[ERROR] Failed to convert total of supplier invoice %s for supplier purchases: %v", invoice.InvoiceID, err)
+		return
+	}
+
+	if err := s.apRepo.UpdateSupplierPurchases(ctx, invoice.SupplierID, sign*amount, invoice.IssueDate); err != nil {
+		log.Printf("[ERROR] Failed to update purchases of supplier %s: %v", invoice.SupplierID, err)
+	}
+}
+
+// Helper functions
+
+// supplierDueDate returns the due date of an invoice issued on issueDate under
+// the supplier's credit days
+func supplierDueDate(issueDate time.Time, creditDays int) time.Time {
+	if creditDays < 0 {
+		creditDays = 0
+	}
+	return issueDate.AddDate(0, 0, creditDays)
+}
+
+// buildPayableAgingReport groups invoice balances by supplier and currency into aging buckets
+func buildPayableAgingReport(invoices []domain.SupplierInvoice, asOf time.Time) *services.PayableAgingReport {
+	type rowKey struct {
+		supplierID uuid.UUID
+		currency   domain.CurrencyCode
+	}
+
+	report := &services.PayableAgingReport{
+		AsOf:   asOf,
+		Rows:   []services.PayableAgingRow{},
+		Totals: make(map[domain.CurrencyCode]services.AgingBuckets),
+	}
+	index := make(map[rowKey]int)
+
+	for _, invoice := range invoices {
+		if invoice.Balance <= 0 {
+			continue
+		}
+
+		key := rowKey{supplierID: invoice.SupplierID, currency: invoice.Currency}
+		i, ok := index[key]
+		if !ok {
+			name := invoice.SupplierID.String()
+			if invoice.Supplier != nil {
+				name = invoice.Supplier.BusinessName
+			}
+			report.Rows = append(report.Rows, services.PayableAgingRow{
+				SupplierID:   invoice.SupplierID,
+				SupplierName: name,
+				Currency:     invoice.Currency,
+			})
+			i = len(report.Rows) - 1
+			index[key] = i
+		}
+
+		days := daysPastDue(invoice.DueDate, asOf)
+		report.Rows[i].Invoices++
+		addToAgingBucket(&report.Rows[i].Buckets, invoice.Balance, days)
+
+		totals := report.Totals[invoice.Currency]
+		addToAgingBucket(&totals, invoice.Balance, days)
+		report.Totals[invoice.Currency] = totals
+	}
+
+	sort.SliceStable(report.Rows, func(i, j int) bool {
+		if report.Rows[i].SupplierName != report.Rows[j].SupplierName {
+			return report.Rows[i].SupplierName < report.Rows[j].SupplierName
+		}
+		return report.Rows[i].Currency < report.Rows[j].Currency
+	})
+
+	return report
+}
+
+// buildCashRequirements sums invoice balances by due date between from and to.
+// Balances due before from count as overdue and open the running totals.
+func buildCashRequirements(invoices []domain.SupplierInvoice, from, to time.Time) *services.CashRequirements {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	forecast := &services.CashRequirements{
+		From:    start,
+		To:      end,
+		Overdue: make(map[domain.CurrencyCode]float64),
+		Days:    []services.CashRequirementDay{},
+		Totals:  make(map[domain.CurrencyCode]float64),
+	}
+	index := make(map[time.Time]int)
+
+	for _, invoice := range invoices {
+		if invoice.Balance <= 0 {
+			continue
+		}
+
+		due := time.Date(invoice.DueDate.Year(), invoice.DueDate.Month(), invoice.DueDate.Day(), 0, 0, 0, 0, time.UTC)
+		if due.After(end) {
+			continue
+		}
+
+		forecast.Totals[invoice.Currency] = roundAmount(forecast.Totals[invoice.Currency] + invoice.Balance)
+		if due.Before(start) {
+			forecast.Overdue[invoice.Currency] = roundAmount(forecast.Overdue[invoice.Currency] + invoice.Balance)
+			continue
+		}
+
+		i, ok := index[due]
+		if !ok {
+			forecast.Days = append(forecast.Days, services.CashRequirementDay{
+				Date:    due,
+				Amounts: make(map[domain.CurrencyCode]float64),
+			})
+			i = len(forecast.Days) - 1
+			index[due] = i
+		}
+		forecast.Days[i].Invoices++
+		forecast.Days[i].Amounts[invoice.Currency] = roundAmount(forecast.Days[i].Amounts[invoice.Currency] + invoice.Balance)
+	}
+
+	sort.Slice(forecast.Days, func(i, j int) bool {
+		return forecast.Days[i].Date.Before(forecast.Days[j].Date)
+	})
+
+	running := make(map[domain.CurrencyCode]float64)
+	for currency, amount := range forecast.Overdue {
+		running[currency] = amount
+	}
+	for i := range forecast.Days {
+		day := &forecast.Days[i]
+		for currency, amount := range day.Amounts {
+			running[currency] = roundAmount(running[currency] + amount)
+		}
+		day.Cumulative = make(map[domain.CurrencyCode]float64, len(running))
+		for currency, amount := range running {
+			day.Cumulative[currency] = amount
+		}
+	}
+
+	return forecast
+}
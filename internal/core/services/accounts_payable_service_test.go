@@ -0,0 +1,100 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+func TestSupplierDueDate(t *testing.T) {
+	issued := time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2024, 2, 19, 0, 0, 0, 0, time.UTC), supplierDueDate(issued, 30))
+	assert.Equal(t, issued, supplierDueDate(issued, 0))
+	assert.Equal(t, issued, supplierDueDate(issued, -5))
+}
+
+func TestSupplierInvoiceRefreshBalance(t *testing.T) {
+	now := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	invoice := &domain.SupplierInvoice{TotalAmount: 100, DueDate: now.AddDate(0, 0, 5)}
+
+	invoice.RefreshBalance(now)
+	assert.Equal(t, domain.AccountStatusPending, invoice.Status)
+	assert.Equal(t, 100.0, invoice.Balance)
+
+	invoice.PaidAmount = 40
+	invoice.RefreshBalance(now)
+	assert.Equal(t, domain.AccountStatusPartiallyPaid, invoice.Status)
+	assert.Equal(t, 60.0, invoice.Balance)
+
+	invoice.RefreshBalance(now.AddDate(0, 0, 10))
+	assert.Equal(t, domain.AccountStatusOverdue, invoice.Status)
+
+	invoice.PaidAmount = 100
+	invoice.RefreshBalance(now)
+	assert.Equal(t, domain.AccountStatusPaid, invoice.Status)
+	assert.Equal(t, 0.0, invoice.Balance)
+}
+
+func TestBuildPayableAgingReport(t *testing.T) {
+	asOf := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	acme := &domain.Supplier{SupplierID: uuid.New(), BusinessName: "Acme Papelería"}
+	bolis := &domain.Supplier{SupplierID: uuid.New(), BusinessName: "Bolígrafos del Centro"}
+
+	invoices := []domain.SupplierInvoice{
+		{SupplierID: bolis.SupplierID, Supplier: bolis, Balance: 50, Currency: domain.CurrencyUSD, DueDate: asOf.AddDate(0, 0, -45)},
+		{SupplierID: acme.SupplierID, Supplier: acme, Balance: 100, Currency: domain.CurrencyVES, DueDate: asOf.AddDate(0, 0, 10)},
+		{SupplierID: acme.SupplierID, Supplier: acme, Balance: 200, Currency: domain.CurrencyVES, DueDate: asOf.AddDate(0, 0, -5)},
+		{SupplierID: acme.SupplierID, Supplier: acme, Balance: 0, Currency: domain.CurrencyVES, DueDate: asOf.AddDate(0, 0, -100)},
+	}
+
+	report := buildPayableAgingReport(invoices, asOf)
+
+	require.Len(t, report.Rows, 2)
+	assert.Equal(t, "Acme Papelería", report.Rows[0].SupplierName)
+	assert.Equal(t, 2, report.Rows[0].Invoices)
+	assert.Equal(t, 100.0, report.Rows[0].Buckets.Current)
+	assert.Equal(t, 200.0, report.Rows[0].Buckets.Days1To30)
+	assert.Equal(t, 300.0, report.Rows[0].Buckets.Total)
+	assert.Equal(t, 50.0, report.Rows[1].Buckets.Days31To60)
+	assert.Equal(t, 300.0, report.Totals[domain.CurrencyVES].Total)
+	assert.Equal(t, 50.0, report.Totals[domain.CurrencyUSD].Total)
+}
+
+func TestBuildCashRequirements(t *testing.T) {
+	from := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2024, 7, d, 0, 0, 0, 0, time.UTC) }
+
+	invoices := []domain.SupplierInvoice{
+		{Balance: 80, Currency: domain.CurrencyVES, DueDate: time.Date(2024, 6, 20, 0, 0, 0, 0, time.UTC)},
+		{Balance: 100, Currency: domain.CurrencyVES, DueDate: day(15)},
+		{Balance: 40, Currency: domain.CurrencyUSD, DueDate: day(5)},
+		{Balance: 60, Currency: domain.CurrencyVES, DueDate: day(15)},
+		{Balance: 500, Currency: domain.CurrencyVES, DueDate: time.Date(2024, 8, 10, 0, 0, 0, 0, time.UTC)},
+	}
+
+	forecast := buildCashRequirements(invoices, from, to)
+
+	assert.Equal(t, day(1), forecast.From)
+	assert.Equal(t, 80.0, forecast.Overdue[domain.CurrencyVES])
+	require.Len(t, forecast.Days, 2)
+
+	assert.Equal(t, day(5), forecast.Days[0].Date)
+	assert.Equal(t, 40.0, forecast.Days[0].Amounts[domain.CurrencyUSD])
+	assert.Equal(t, 80.0, forecast.Days[0].Cumulative[domain.CurrencyVES])
+
+	assert.Equal(t, day(15), forecast.Days[1].Date)
+	assert.Equal(t, 2, forecast.Days[1].Invoices)
+	assert.Equal(t, 160.0, forecast.Days[1].Amounts[domain.CurrencyVES])
+	assert.Equal(t, 240.0, forecast.Days[1].Cumulative[domain.CurrencyVES])
+	assert.Equal(t, 40.0, forecast.Days[1].Cumulative[domain.CurrencyUSD])
+
+	assert.Equal(t, 240.0, forecast.Totals[domain.CurrencyVES])
+	assert.Equal(t, 40.0, forecast.Totals[domain.CurrencyUSD])
+}
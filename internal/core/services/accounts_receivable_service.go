@@ -3,6 +3,10 @@ package services
 import (
 	"context"
 	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,21 +16,48 @@ import (
 	"github.com/jadiazinf/inventory/internal/core/domain"
 	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
 	"github.com/jadiazinf/inventory/internal/core/ports/services"
+	"github.com/jadiazinf/inventory/internal/platform/database"
+	"github.com/jadiazinf/inventory/internal/platform/pdf"
 )
 
 type accountsReceivableService struct {
-	arRepo repositories.AccountsReceivableRepository
-	db     *gorm.DB
+	arRepo          repositories.AccountsReceivableRepository
+	customerRepo    repositories.CustomerRepository
+	userRepo        repositories.UserRepository
+	storedValueSvc  services.StoredValueService
+	notificationSvc services.NotificationService
+	db              *gorm.DB
+	credit          CreditPolicy
+}
+
+// CreditPolicy configures credit control on credit sales
+type CreditPolicy struct {
+	// SuspensionDays blocks customers with balances more than this many days
+	// past due; zero disables the check
+	SuspensionDays int
+	// OverrideRoles are the roles allowed to approve sales outside the
+	// customer's terms, besides the manager of the selling store
+	OverrideRoles []string
 }
 
 // NewAccountsReceivableService creates a new accounts receivable service
 func NewAccountsReceivableService(
 	arRepo repositories.AccountsReceivableRepository,
+	customerRepo repositories.CustomerRepository,
+	userRepo repositories.UserRepository,
+	storedValueSvc services.StoredValueService,
+	notificationSvc services.NotificationService,
 	db *gorm.DB,
+	credit CreditPolicy,
 ) services.AccountsReceivableService {
 	return &accountsReceivableService{
-		arRepo: arRepo,
-		db:     db,
+		arRepo:          arRepo,
+		customerRepo:    customerRepo,
+		userRepo:        userRepo,
+		storedValueSvc:  storedValueSvc,
+		notificationSvc: notificationSvc,
+		db:              db,
+		credit:          credit,
 	}
 }
 
@@ -35,6 +66,11 @@ func (s *accountsReceivableService) GetAccountsReceivable(ctx context.Context, i
 	return s.arRepo.FindByID(ctx, id)
 }
 
+// ListReceivables lists receivables matching the filters
+func (s *accountsReceivableService) ListReceivables(ctx context.Context, filters repositories.AccountsReceivableFilters, limit, offset int) ([]domain.AccountsReceivable, int64, error) {
+	return s.arRepo.List(ctx, filters, limit, offset)
+}
+
 // GetCustomerReceivables retrieves all receivables for a customer
 func (s *accountsReceivableService) GetCustomerReceivables(ctx context.Context, customerID uuid.UUID) ([]domain.AccountsReceivable, error) {
 	return s.arRepo.FindByCustomer(ctx, customerID)
@@ -114,3 +150,1349 @@ func (s *accountsReceivableService) RegisterPayment(
 func (s *accountsReceivableService) GetPaymentHistory(ctx context.Context, receivableID uuid.UUID) ([]domain.CustomerPayment, error) {
 	return s.arRepo.GetPayments(ctx, receivableID)
 }
+
+// VoidPayment undoes a payment registered by mistake, restoring the receivable balance.
+// Payments allocated from a customer receipt are undone by reversing the receipt.
+func (s *accountsReceivableService) VoidPayment(ctx context.Context, paymentID uuid.UUID, reason string, userID uuid.UUID) (*domain.CustomerPayment, error) {
+	payment, err := s.arRepo.FindPaymentByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.ReversedAt != nil {
+		return nil, errors.InvalidInput("Payment is already voided")
+	}
+
+	if payment.ReceiptID != nil {
+		return nil, errors.InvalidInput(fmt.Sprintf("Payment belongs to receipt %s; reverse the receipt instead", *payment.ReceiptID))
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.InvalidInput("Void reason is required")
+	}
+
+	now := time.Now()
+	payment.ReversedAt = &now
+	payment.ReversedBy = &userID
+	payment.VoidReason = &reason
+
+	if err := s.arRepo.VoidPayment(ctx, payment); err != nil {
+		return nil, err
+	}
+
+	return s.arRepo.FindPaymentByID(ctx, paymentID)
+}
+
+// IssueCreditNote reduces a receivable for a return or a discount
+func (s *accountsReceivableService) IssueCreditNote(ctx context.Context, req services.CreditNoteRequest) (*domain.ReceivableAdjustment, error) {
+	if req.Reason != domain.CreditNoteReasonReturn && req.Reason != domain.CreditNoteReasonDiscount {
+		return nil, errors.InvalidInput("Credit note reason must be RETURN or DISCOUNT")
+	}
+
+	ar, err := s.adjustableReceivable(ctx, req.ReceivableID, req.Amount, req.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	reason := req.Reason
+	adjustment := &domain.ReceivableAdjustment{
+		AdjustmentID:     uuid.New(),
+		ReceivableID:     ar.ReceivableID,
+		CustomerID:       ar.CustomerID,
+		AdjustmentType:   domain.AdjustmentTypeCreditNote,
+		CreditNoteReason: &reason,
+		Amount:           roundAmount(req.Amount),
+		Currency:         ar.Currency,
+		Reference:        req.Reference,
+		Description:      strings.TrimSpace(req.Description),
+		Status:           domain.AdjustmentStatusApplied,
+		RequestedBy:      req.UserID,
+		AppliedAt:        &now,
+	}
+
+	// The credit note and the customer's purchases change together
+	err = database.Transaction(ctx, s.db, func(ctx context.Context) error {
+		if err := s.arRepo.CreateAdjustment(ctx, adjustment); err != nil {
+			return err
+		}
+		return s.reducePurchases(ctx, ar, adjustment.Amount)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.arRepo.FindAdjustmentByID(ctx, adjustment.AdjustmentID)
+}
+
+// RequestWriteOff asks to write off a receivable balance as bad debt. Nothing
+// changes until a supervisor approves it.
+func (s *accountsReceivableService) RequestWriteOff(ctx context.Context, req services.WriteOffRequest) (*domain.ReceivableAdjustment, error) {
+	current, err := s.arRepo.FindByID(ctx, req.ReceivableID)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := current.Balance
+	if req.Amount != nil {
+		amount = *req.Amount
+	}
+
+	ar, err := s.adjustableReceivable(ctx, req.ReceivableID, amount, req.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := domain.AdjustmentStatusPending
+	writeOff := domain.AdjustmentTypeWriteOff
+	_, count, err := s.arRepo.ListAdjustments(ctx, repositories.AdjustmentFilters{
+		ReceivableID: &ar.ReceivableID,
+		Type:         &writeOff,
+		Status:       &pending,
+	}, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, errors.Conflict("Receivable already has a write-off awaiting approval")
+	}
+
+	adjustment := &domain.ReceivableAdjustment{
+		AdjustmentID:   uuid.New(),
+		ReceivableID:   ar.ReceivableID,
+		CustomerID:     ar.CustomerID,
+		AdjustmentType: domain.AdjustmentTypeWriteOff,
+		Amount:         roundAmount(amount),
+		Currency:       ar.Currency,
+		Description:    strings.TrimSpace(req.Description),
+		Status:         domain.AdjustmentStatusPending,
+		RequestedBy:    req.UserID,
+	}
+
+	if err := s.arRepo.CreateAdjustment(ctx, adjustment); err != nil {
+		return nil, err
+	}
+
+	return s.arRepo.FindAdjustmentByID(ctx, adjustment.AdjustmentID)
+}
+
+// ApproveWriteOff applies a pending write-off. The approver must be a supervisor
+// other than the requester.
+func (s *accountsReceivableService) ApproveWriteOff(ctx context.Context, id uuid.UUID, approverID uuid.UUID, notes *string) (*domain.ReceivableAdjustment, error) {
+	adjustment, err := s.reviewableWriteOff(ctx, id, approverID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	adjustment.Status = domain.AdjustmentStatusApplied
+	adjustment.ReviewedBy = &approverID
+	adjustment.ReviewedAt = &now
+	adjustment.ReviewNotes = notes
+	adjustment.AppliedAt = &now
+
+	// The write-off and the customer's purchases change together
+	err = database.Transaction(ctx, s.db, func(ctx context.Context) error {
+		if err := s.arRepo.ReviewAdjustment(ctx, adjustment); err != nil {
+			return err
+		}
+		return s.reducePurchases(ctx, adjustment.Receivable, adjustment.Amount)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.arRepo.FindAdjustmentByID(ctx, id)
+}
+
+// RejectWriteOff rejects a pending write-off, leaving the receivable untouched
+func (s *accountsReceivableService) RejectWriteOff(ctx context.Context, id uuid.UUID, approverID uuid.UUID, notes *string) (*domain.ReceivableAdjustment, error) {
+	adjustment, err := s.reviewableWriteOff(ctx, id, approverID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	adjustment.Status = domain.AdjustmentStatusRejected
+	adjustment.ReviewedBy = &approverID
+	adjustment.ReviewedAt = &now
+	adjustment.ReviewNotes = notes
+
+	if err := s.arRepo.ReviewAdjustment(ctx, adjustment); err != nil {
+		return nil, err
+	}
+
+	return s.arRepo.FindAdjustmentByID(ctx, id)
+}
+
+// GetAdjustment retrieves a credit note or write-off by ID
+func (s *accountsReceivableService) GetAdjustment(ctx context.Context, id uuid.UUID) (*domain.ReceivableAdjustment, error) {
+	return s.arRepo.FindAdjustmentByID(ctx, id)
+}
+
+// ListAdjustments lists credit notes and write-offs matching the filters
+func (s *accountsReceivableService) ListAdjustments(ctx context.Context, filters repositories.AdjustmentFilters, limit, offset int) ([]domain.ReceivableAdjustment, int64, error) {
+	return s.arRepo.ListAdjustments(ctx, filters, limit, offset)
+}
+
+// adjustableReceivable validates an adjustment of amount on the receivable
+func (s *accountsReceivableService) adjustableReceivable(ctx context.Context, receivableID uuid.UUID, amount float64, description string) (*domain.AccountsReceivable, error) {
+	if strings.TrimSpace(description) == "" {
+		return nil, errors.InvalidInput("Description is required")
+	}
+
+	if amount <= 0 {
+		return nil, errors.InvalidInput("Amount must be positive")
+	}
+
+	ar, err := s.arRepo.FindByID(ctx, receivableID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ar.Status == domain.AccountStatusPaid || ar.Status == domain.AccountStatusCancelled {
+		return nil, errors.InvalidInput(fmt.Sprintf("Receivable is %s", ar.Status))
+	}
+
+	if roundAmount(amount) > roundAmount(ar.Balance) {
+		return nil, errors.InvalidInput(fmt.Sprintf(
+			"Amount (%.2f) exceeds balance (%.2f)",
+			amount, ar.Balance,
+		))
+	}
+
+	return ar, nil
+}
+
+// reviewableWriteOff loads a pending write-off and checks the approver may review it
+func (s *accountsReceivableService) reviewableWriteOff(ctx context.Context, id uuid.UUID, approverID uuid.UUID) (*domain.ReceivableAdjustment, error) {
+	adjustment, err := s.arRepo.FindAdjustmentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if adjustment.AdjustmentType != domain.AdjustmentTypeWriteOff {
+		return nil, errors.InvalidInput("Only write-offs need approval")
+	}
+
+	if adjustment.Status != domain.AdjustmentStatusPending {
+		return nil, errors.InvalidInput(fmt.Sprintf("Write-off is already %s", adjustment.Status))
+	}
+
+	if adjustment.RequestedBy == approverID {
+		return nil, errors.Forbidden("A write-off cannot be approved by who requested it")
+	}
+
+	// Reload the receivable with its sale, which gives the store and exchange rate
+	ar, err := s.arRepo.FindByID(ctx, adjustment.ReceivableID)
+	if err != nil {
+		return nil, err
+	}
+	adjustment.Receivable = ar
+
+	var storeID *uuid.UUID
+	if ar.Sale != nil {
+		storeID = ar.Sale.StoreID
+	}
+
+	if err := s.authorizeSupervisor(ctx, approverID, storeID, "review write-offs"); err != nil {
+		return nil, err
+	}
+
+	return adjustment, nil
+}
+
+// reducePurchases takes an adjustment of a sale receivable off the customer's total purchases
+func (s *accountsReceivableService) reducePurchases(ctx context.Context, ar *domain.AccountsReceivable, amount float64) error {
+	if ar == nil || ar.Kind == domain.ReceivableKindLateFee {
+		return nil
+	}
+
+	var rate *float64
+	if ar.Sale != nil {
+		rate = ar.Sale.ExchangeRate
+	}
+
+	amountVES, err := convertAmount(amount, ar.Currency, domain.CurrencyVES, rate)
+	if err != nil {
+		return err
+	}
+
+	return s.customerRepo.UpdateTotalPurchases(ctx, ar.CustomerID, -amountVES)
+}
+
+// ReceiveCustomerPayment allocates a customer payment across their receivables,
+// oldest due first unless allocations are given, and credits any excess to the
+// customer's store credit
+func (s *accountsReceivableService) ReceiveCustomerPayment(ctx context.Context, req services.CustomerPaymentRequest) (*domain.CustomerReceipt, error) {
+	if req.Amount <= 0 {
+		return nil, errors.InvalidInput("Payment amount must be positive")
+	}
+
+	if req.ExchangeRate != nil && *req.ExchangeRate <= 0 {
+		return nil, errors.InvalidInput("Exchange rate must be positive")
+	}
+
+	if req.Currency == "" {
+		req.Currency = domain.CurrencyVES
+	}
+
+	if _, err := s.customerRepo.FindByID(ctx, req.CustomerID); err != nil {
+		return nil, err
+	}
+
+	receivables, err := s.arRepo.GetOutstanding(ctx, repositories.AccountsReceivableFilters{CustomerID: &req.CustomerID})
+	if err != nil {
+		return nil, err
+	}
+
+	amount := roundAmount(req.Amount)
+	allocations, err := allocatePayment(amount, req.Currency, req.ExchangeRate, receivables, req.Allocations)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := &domain.CustomerReceipt{
+		ReceiptID:     uuid.New(),
+		CustomerID:    req.CustomerID,
+		ReceiptDate:   time.Now(),
+		Amount:        amount,
+		Currency:      req.Currency,
+		ExchangeRate:  req.ExchangeRate,
+		PaymentMethod: req.PaymentMethod,
+		Reference:     req.Reference,
+		Status:        domain.ReceiptStatusPosted,
+		Notes:         req.Notes,
+		CreatedBy:     &req.UserID,
+	}
+
+	payments := make([]domain.CustomerPayment, len(allocations))
+	for i, allocation := range allocations {
+		payments[i] = domain.CustomerPayment{
+			PaymentID:     uuid.New(),
+			ReceivableID:  allocation.receivable.ReceivableID,
+			PaymentDate:   receipt.ReceiptDate,
+			Amount:        allocation.amount,
+			Currency:      allocation.receivable.Currency,
+			PaymentMethod: req.PaymentMethod,
+			Reference:     req.Reference,
+			Notes:         req.Notes,
+			CreatedBy:     &req.UserID,
+		}
+		if allocation.receivable.Currency != req.Currency {
+			payments[i].ExchangeRate = req.ExchangeRate
+		}
+		receipt.AllocatedAmount += allocation.applied
+	}
+	receipt.AllocatedAmount = roundAmount(receipt.AllocatedAmount)
+	receipt.CreditAmount = roundAmount(amount - receipt.AllocatedAmount)
+
+	// The receipt, its allocations and the overpayment credit are recorded
+	// together, so a failed credit leaves no receipt without it
+	err = database.Transaction(ctx, s.db, func(ctx context.Context) error {
+		if err := s.arRepo.CreateReceipt(ctx, receipt, payments); err != nil {
+			return err
+		}
+
+		// Keep the overpayment as customer credit
+		if receipt.CreditAmount <= 0 {
+			return nil
+		}
+		account, err := s.storedValueSvc.IssueStoreCredit(ctx, services.IssueStoreCreditRequest{
+			CustomerID: req.CustomerID,
+			Amount:     receipt.CreditAmount,
+			Currency:   req.Currency,
+			Reason:     fmt.Sprintf("Overpayment of receipt %s", receipt.ReceiptID),
+			UserID:     req.UserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		receipt.CreditAccountID = &account.AccountID
+		return s.arRepo.UpdateReceipt(ctx, receipt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.arRepo.FindReceiptByID(ctx, receipt.ReceiptID)
+}
+
+// ReverseCustomerPayment undoes every allocation of a receipt and takes back the credit it issued
+func (s *accountsReceivableService) ReverseCustomerPayment(ctx context.Context, receiptID uuid.UUID, reason string, userID uuid.UUID) (*domain.CustomerReceipt, error) {
+	receipt, err := s.arRepo.FindReceiptByID(ctx, receiptID)
+	if err != nil {
+		return nil, err
+	}
+
+	if receipt.Status == domain.ReceiptStatusReversed {
+		return nil, errors.InvalidInput("Receipt is already reversed")
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.InvalidInput("Reversal reason is required")
+	}
+
+	now := time.Now()
+	receipt.Status = domain.ReceiptStatusReversed
+	receipt.ReversedAt = &now
+	receipt.ReversedBy = &userID
+	receipt.ReversalReason = &reason
+
+	err = database.Transaction(ctx, s.db, func(ctx context.Context) error {
+		// Credit already spent by the customer cannot be taken back, so check it first
+		if receipt.CreditAccountID != nil && receipt.CreditAmount > 0 {
+			err := s.storedValueSvc.RevokeStoreCredit(ctx, *receipt.CreditAccountID, receipt.CreditAmount,
+				fmt.Sprintf("Reversal of receipt %s: %s", receipt.ReceiptID, reason), userID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.arRepo.ReverseReceipt(ctx, receipt)
+	})
+	if err != nil {
+		log.Printf("[ERROR] Failed to reverse receipt %s: %v", receipt.ReceiptID, err)
+		return nil, err
+	}
+
+	return s.arRepo.FindReceiptByID(ctx, receipt.ReceiptID)
+}
+
+// GetCustomerReceipt retrieves a customer receipt with its allocations
+func (s *accountsReceivableService) GetCustomerReceipt(ctx context.Context, id uuid.UUID) (*domain.CustomerReceipt, error) {
+	return s.arRepo.FindReceiptByID(ctx, id)
+}
+
+// ListCustomerReceipts lists customer receipts, newest first
+func (s *accountsReceivableService) ListCustomerReceipts(ctx context.Context, customerID *uuid.UUID, limit, offset int) ([]domain.CustomerReceipt, int64, error) {
+	return s.arRepo.ListReceipts(ctx, customerID, limit, offset)
+}
+
+// MarkOverdueReceivables flags the unpaid receivables past their due date as overdue
+func (s *accountsReceivableService) MarkOverdueReceivables(ctx context.Context, at time.Time) (int, error) {
+	return s.arRepo.MarkOverdue(ctx, at)
+}
+
+// ApplyLateFees charges the fees accrued on overdue sale receivables under the
+// active policy of their currency. Each charge is a separate receivable covering
+// what accrued since the previous run.
+func (s *accountsReceivableService) ApplyLateFees(ctx context.Context, at time.Time) (int, error) {
+	kind := domain.ReceivableKindSale
+	receivables, err := s.arRepo.GetOutstanding(ctx, repositories.AccountsReceivableFilters{
+		Kind:  &kind,
+		DueTo: &at,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	policies := make(map[domain.CurrencyCode]*domain.LateFeePolicy)
+	count := 0
+	for i := range receivables {
+		ar := &receivables[i]
+
+		policy, ok := policies[ar.Currency]
+		if !ok {
+			policy, err = s.arRepo.GetActiveLateFeePolicy(ctx, ar.Currency)
+			if err != nil {
+				return count, err
+			}
+			policies[ar.Currency] = policy
+		}
+		if policy == nil {
+			continue
+		}
+
+		days := daysPastDue(ar.DueDate, at)
+		accrued := accruedLateFee(policy, ar.Balance, days)
+		if accrued <= 0 {
+			continue
+		}
+
+		charged, err := s.arRepo.GetLateFeeTotal(ctx, ar.ReceivableID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to get late fees of receivable %s: %v", ar.ReceivableID, err)
+			continue
+		}
+
+		fee := roundAmount(accrued - charged)
+		if fee < 0.01 {
+			continue
+		}
+
+		charge := &domain.AccountsReceivable{
+			ReceivableID:       uuid.New(),
+			SaleID:             ar.SaleID,
+			CustomerID:         ar.CustomerID,
+			TotalAmount:        fee,
+			Balance:            fee,
+			Currency:           ar.Currency,
+			DueDate:            at,
+			Status:             domain.AccountStatusPending,
+			Kind:               domain.ReceivableKindLateFee,
+			SourceReceivableID: &ar.ReceivableID,
+			LateFeePolicyID:    &policy.PolicyID,
+			Notes:              stringPtr(fmt.Sprintf("Late fee for %d days past due (%s)", days, policy.Name)),
+		}
+		if err := s.arRepo.Create(ctx, charge); err != nil {
+			log.Printf("[ERROR] Failed to charge late fee on receivable %s: %v", ar.ReceivableID, err)
+			continue
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// CreateReceivable stores a receivable, with the credit override that allowed it if any
+func (s *accountsReceivableService) CreateReceivable(ctx context.Context, receivable *domain.AccountsReceivable, override *domain.CreditOverride) error {
+	return s.arRepo.CreateWithOverride(ctx, receivable, override)
+}
+
+// EvaluateCredit checks a credit sale of amount against the customer's limit,
+// credit days and overdue balances, returning every term the sale would break
+func (s *accountsReceivableService) EvaluateCredit(ctx context.Context, customer *domain.Customer, amount float64, currency domain.CurrencyCode, rate *float64, creditDays int, at time.Time) (*services.CreditCheck, error) {
+	receivables, err := s.arRepo.GetOutstanding(ctx, repositories.AccountsReceivableFilters{CustomerID: &customer.CustomerID})
+	if err != nil {
+		return nil, err
+	}
+
+	outstanding, err := creditExposure(receivables, currency, rate)
+	if err != nil {
+		return nil, err
+	}
+
+	amountVES, err := convertAmount(amount, currency, domain.CurrencyVES, rate)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &services.CreditCheck{
+		CreditLimit: customer.CreditLimit,
+		Outstanding: outstanding,
+		Amount:      roundAmount(amountVES),
+		CreditDays:  creditDays,
+	}
+	check.Violations = creditViolations(check, customer.CreditDays, oldestDaysPastDue(receivables, at), s.credit.SuspensionDays)
+
+	return check, nil
+}
+
+// AuthorizeCreditOverride verifies the supervisor may approve credit sales outside the customer's terms
+func (s *accountsReceivableService) AuthorizeCreditOverride(ctx context.Context, override services.CreditOverrideRequest, storeID uuid.UUID) error {
+	if strings.TrimSpace(override.Reason) == "" {
+		return errors.InvalidInput("A reason is required to override credit terms")
+	}
+
+	return s.authorizeSupervisor(ctx, override.SupervisorID, &storeID, "override credit terms")
+}
+
+// authorizeSupervisor verifies the user is active and either holds a supervisor
+// role or manages the given store
+func (s *accountsReceivableService) authorizeSupervisor(ctx context.Context, userID uuid.UUID, storeID *uuid.UUID, action string) error {
+	supervisor, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return errors.Forbidden("Supervisor not found")
+		}
+		return err
+	}
+
+	if supervisor.Status != domain.UserStatusActive {
+		return errors.Forbidden("Supervisor is not active")
+	}
+
+	if supervisor.Role != nil && canOverrideCredit(supervisor.Role.RoleName, s.credit.OverrideRoles) {
+		return nil
+	}
+
+	// The store manager can approve operations of their store
+	if storeID != nil {
+		var store domain.Store
+		if err := s.db.WithContext(ctx).First(&store, "store_id = ?", *storeID).Error; err != nil {
+			return errors.WrapError(err, "failed to find store")
+		}
+		if store.ManagerID != nil && *store.ManagerID == supervisor.UserID {
+			return nil
+		}
+	}
+
+	return errors.Forbidden(fmt.Sprintf("Only a supervisor or the store manager can %s", action))
+}
+
+// ListCreditOverrides lists recorded credit overrides, newest first
+func (s *accountsReceivableService) ListCreditOverrides(ctx context.Context, customerID *uuid.UUID, limit, offset int) ([]domain.CreditOverride, int64, error) {
+	return s.arRepo.ListCreditOverrides(ctx, customerID, limit, offset)
+}
+
+// CreateInstallmentPlan stores an installment plan with its installments and
+// the credit override that allowed it, if any
+func (s *accountsReceivableService) CreateInstallmentPlan(ctx context.Context, plan *domain.InstallmentPlan, override *domain.CreditOverride) error {
+	return s.arRepo.CreateInstallmentPlan(ctx, plan, override)
+}
+
+// GetInstallmentPlan retrieves an installment plan with its installments
+func (s *accountsReceivableService) GetInstallmentPlan(ctx context.Context, id uuid.UUID) (*domain.InstallmentPlan, error) {
+	return s.arRepo.FindInstallmentPlanByID(ctx, id)
+}
+
+// ListInstallmentPlans lists installment plans, optionally for one customer
+func (s *accountsReceivableService) ListInstallmentPlans(ctx context.Context, customerID *uuid.UUID, limit, offset int) ([]domain.InstallmentPlan, int64, error) {
+	return s.arRepo.ListInstallmentPlans(ctx, customerID, limit, offset)
+}
+
+// SendInstallmentReminders reminds customers of their unpaid installments due
+// within daysBefore days. Each installment is reminded once.
+func (s *accountsReceivableService) SendInstallmentReminders(ctx context.Context, daysBefore int, at time.Time) (int, error) {
+	from := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+	to := from.AddDate(0, 0, daysBefore)
+
+	installments, err := s.arRepo.GetInstallmentsToRemind(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, installment := range installments {
+		if err := s.notificationSvc.SendInstallmentReminder(ctx, installment.ReceivableID); err != nil {
+			log.Printf("[ERROR] Failed to remind installment %s: %v", installment.ReceivableID, err)
+			continue
+		}
+
+		if err := s.arRepo.MarkReminderSent(ctx, installment.ReceivableID, at); err != nil {
+			log.Printf("[ERROR] Failed to mark reminder of installment %s: %v", installment.ReceivableID, err)
+			continue
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// CreateLateFeePolicy creates a late fee policy
+func (s *accountsReceivableService) CreateLateFeePolicy(ctx context.Context, policy *domain.LateFeePolicy) error {
+	if err := s.validateLateFeePolicy(ctx, policy); err != nil {
+		return err
+	}
+
+	if policy.PolicyID == uuid.Nil {
+		policy.PolicyID = uuid.New()
+	}
+
+	return s.arRepo.CreateLateFeePolicy(ctx, policy)
+}
+
+// GetLateFeePolicy retrieves a late fee policy by ID
+func (s *accountsReceivableService) GetLateFeePolicy(ctx context.Context, id uuid.UUID) (*domain.LateFeePolicy, error) {
+	return s.arRepo.FindLateFeePolicyByID(ctx, id)
+}
+
+// ListLateFeePolicies lists all late fee policies
+func (s *accountsReceivableService) ListLateFeePolicies(ctx context.Context) ([]domain.LateFeePolicy, error) {
+	return s.arRepo.ListLateFeePolicies(ctx)
+}
+
+// UpdateLateFeePolicy updates a late fee policy. Fees already charged are not affected.
+func (s *accountsReceivableService) UpdateLateFeePolicy(ctx context.Context, policy *domain.LateFeePolicy) error {
+	existing, err := s.arRepo.FindLateFeePolicyByID(ctx, policy.PolicyID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.validateLateFeePolicy(ctx, policy); err != nil {
+		return err
+	}
+
+	policy.CreatedAt = existing.CreatedAt
+	policy.CreatedBy = existing.CreatedBy
+
+	return s.arRepo.UpdateLateFeePolicy(ctx, policy)
+}
+
+// DeleteLateFeePolicy deletes a late fee policy. Fees already charged are not affected.
+func (s *accountsReceivableService) DeleteLateFeePolicy(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.arRepo.FindLateFeePolicyByID(ctx, id); err != nil {
+		return err
+	}
+	return s.arRepo.DeleteLateFeePolicy(ctx, id)
+}
+
+// GetAgingReport groups the outstanding balances by customer, store and days past due
+func (s *accountsReceivableService) GetAgingReport(ctx context.Context, filters repositories.AccountsReceivableFilters, asOf time.Time) (*services.AgingReport, error) {
+	receivables, err := s.arRepo.GetOutstanding(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildAgingReport(receivables, asOf), nil
+}
+
+// GetCustomerStatement builds the account statement of a customer for a period
+func (s *accountsReceivableService) GetCustomerStatement(ctx context.Context, customerID uuid.UUID, currency domain.CurrencyCode, from, to time.Time) (*services.CustomerStatement, error) {
+	if to.Before(from) {
+		return nil, errors.InvalidInput("End date must be after start date")
+	}
+
+	customer, err := s.customerRepo.FindByID(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	receivables, err := s.arRepo.FindByCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := s.arRepo.GetCustomerPayments(ctx, customerID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	adjustments, err := s.arRepo.GetCustomerAdjustments(ctx, customerID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := buildCustomerStatement(receivables, payments, adjustments, currency, from, to)
+	statement.CustomerID = customerID
+	statement.CustomerName = getCustomerName(customer)
+	return statement, nil
+}
+
+// RenderStatementPDF renders the customer statement as a printable document
+func (s *accountsReceivableService) RenderStatementPDF(ctx context.Context, customerID uuid.UUID, currency domain.CurrencyCode, from, to time.Time) ([]byte, error) {
+	statement, err := s.GetCustomerStatement(ctx, customerID, currency, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	customer, err := s.customerRepo.FindByID(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := pdf.New()
+	doc.SetFooter(fmt.Sprintf("Estado de cuenta %s", statement.CustomerName))
+
+	doc.Title("Estado de cuenta")
+	doc.Field("Cliente", statement.CustomerName)
+	doc.Field("RIF/CI", customer.TaxID)
+	doc.Field("Período", fmt.Sprintf("%s al %s", from.Format("02/01/2006"), to.Format("02/01/2006")))
+	doc.Field("Moneda", string(statement.Currency))
+	doc.Separator()
+
+	doc.Field("Saldo inicial", fmt.Sprintf("%.2f %s", statement.OpeningBalance, statement.Currency))
+	doc.Space(6)
+
+	columns := []pdf.Column{
+		{Header: "Fecha", Width: 10},
+		{Header: "Tipo", Width: 10},
+		{Header: "Referencia", Width: 21},
+		{Header: "Cargo", Width: 13, Align: pdf.AlignRight},
+		{Header: "Abono", Width: 13, Align: pdf.AlignRight},
+		{Header: "Saldo", Width: 14, Align: pdf.AlignRight},
+	}
+	rows := make([][]string, 0, len(statement.Lines))
+	for _, line := range statement.Lines {
+		rows = append(rows, []string{
+			line.Date.Format("02/01/2006"),
+			statementLineLabel(line.Type),
+			line.Reference,
+			formatOptionalAmount(line.Charge),
+			formatOptionalAmount(line.Payment),
+			fmt.Sprintf("%.2f", line.Balance),
+		})
+	}
+	doc.Table(columns, rows)
+
+	doc.Separator()
+	doc.Field("Total cargos", fmt.Sprintf("%.2f %s", statement.TotalCharges, statement.Currency))
+	doc.Field("Total abonos", fmt.Sprintf("%.2f %s", statement.TotalPayments, statement.Currency))
+	if statement.TotalAdjustments != 0 {
+		doc.Field("Notas de crédito y castigos", fmt.Sprintf("%.2f %s", statement.TotalAdjustments, statement.Currency))
+	}
+	doc.Heading(fmt.Sprintf("Saldo final: %.2f %s", statement.ClosingBalance, statement.Currency))
+
+	return doc.Bytes(), nil
+}
+
+// Helper functions
+
+func (s *accountsReceivableService) validateLateFeePolicy(ctx context.Context, policy *domain.LateFeePolicy) error {
+	if policy.Name == "" {
+		return errors.InvalidInput("Policy name is required")
+	}
+
+	switch policy.FeeType {
+	case domain.LateFeeTypeFlat:
+	case domain.LateFeeTypeDailyPercent:
+		if policy.Amount > 100 {
+			return errors.InvalidInput("Daily percent cannot exceed 100")
+		}
+	default:
+		return errors.InvalidInput(fmt.Sprintf("Invalid fee type: %s", policy.FeeType))
+	}
+
+	if policy.Amount <= 0 {
+		return errors.InvalidInput("Fee amount must be positive")
+	}
+
+	if policy.GraceDays < 0 {
+		return errors.InvalidInput("Grace days cannot be negative")
+	}
+
+	if policy.MaxAmount != nil && *policy.MaxAmount <= 0 {
+		return errors.InvalidInput("Maximum amount must be positive")
+	}
+
+	if policy.Currency == "" {
+		policy.Currency = domain.CurrencyVES
+	}
+
+	// Only one policy applies to each currency
+	if policy.IsActive {
+		active, err := s.arRepo.GetActiveLateFeePolicy(ctx, policy.Currency)
+		if err != nil {
+			return err
+		}
+		if active != nil && active.PolicyID != policy.PolicyID {
+			return errors.Conflict(fmt.Sprintf("Policy %s is already active for %s", active.Name, policy.Currency))
+		}
+	}
+
+	return nil
+}
+
+// accruedLateFee returns the total fee owed on a balance after the given days past due
+func accruedLateFee(policy *domain.LateFeePolicy, balance float64, days int) float64 {
+	lateDays := days - policy.GraceDays
+	if lateDays <= 0 || balance <= 0 {
+		return 0
+	}
+
+	var fee float64
+	switch policy.FeeType {
+	case domain.LateFeeTypeFlat:
+		fee = policy.Amount
+	case domain.LateFeeTypeDailyPercent:
+		fee = balance * policy.Amount / 100 * float64(lateDays)
+	}
+
+	if policy.MaxAmount != nil && fee > *policy.MaxAmount {
+		fee = *policy.MaxAmount
+	}
+
+	return roundAmount(fee)
+}
+
+// daysPastDue counts the calendar days between the due date and the report date
+func daysPastDue(dueDate, asOf time.Time) int {
+	due := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, time.UTC)
+	day := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	return int(day.Sub(due).Hours() / 24)
+}
+
+// addToAgingBucket adds a balance to the bucket matching its days past due
+func addToAgingBucket(buckets *services.AgingBuckets, balance float64, days int) {
+	switch {
+	case days <= 0:
+		buckets.Current = roundAmount(buckets.Current + balance)
+	case days <= 30:
+		buckets.Days1To30 = roundAmount(buckets.Days1To30 + balance)
+	case days <= 60:
+		buckets.Days31To60 = roundAmount(buckets.Days31To60 + balance)
+	case days <= 90:
+		buckets.Days61To90 = roundAmount(buckets.Days61To90 + balance)
+	default:
+		buckets.Over90 = roundAmount(buckets.Over90 + balance)
+	}
+	buckets.Total = roundAmount(buckets.Total + balance)
+}
+
+// buildAgingReport groups outstanding receivables by customer, store and currency
+func buildAgingReport(receivables []domain.AccountsReceivable, asOf time.Time) *services.AgingReport {
+	type rowKey struct {
+		customerID uuid.UUID
+		storeID    uuid.UUID
+		currency   domain.CurrencyCode
+	}
+
+	report := &services.AgingReport{
+		AsOf:   asOf,
+		Rows:   []services.AgingRow{},
+		Totals: make(map[domain.CurrencyCode]services.AgingBuckets),
+	}
+	index := make(map[rowKey]int)
+
+	for _, ar := range receivables {
+		if ar.Balance <= 0 {
+			continue
+		}
+
+		var storeID *uuid.UUID
+		if ar.Sale != nil {
+			storeID = ar.Sale.StoreID
+		}
+
+		key := rowKey{customerID: ar.CustomerID, currency: ar.Currency}
+		if storeID != nil {
+			key.storeID = *storeID
+		}
+
+		i, ok := index[key]
+		if !ok {
+			name := ar.CustomerID.String()
+			if ar.Customer != nil {
+				name = getCustomerName(ar.Customer)
+			}
+			report.Rows = append(report.Rows, services.AgingRow{
+				CustomerID:   ar.CustomerID,
+				CustomerName: name,
+				StoreID:      storeID,
+				Currency:     ar.Currency,
+			})
+			i = len(report.Rows) - 1
+			index[key] = i
+		}
+
+		days := daysPastDue(ar.DueDate, asOf)
+		report.Rows[i].Receivables++
+		addToAgingBucket(&report.Rows[i].Buckets, ar.Balance, days)
+
+		totals := report.Totals[ar.Currency]
+		addToAgingBucket(&totals, ar.Balance, days)
+		report.Totals[ar.Currency] = totals
+	}
+
+	sort.SliceStable(report.Rows, func(i, j int) bool {
+		if report.Rows[i].CustomerName != report.Rows[j].CustomerName {
+			return report.Rows[i].CustomerName < report.Rows[j].CustomerName
+		}
+		return report.Rows[i].Currency < report.Rows[j].Currency
+	})
+
+	return report
+}
+
+// buildCustomerStatement computes the opening balance and the movements of a period.
+// Cancelled receivables and their payments are left out.
+func buildCustomerStatement(
+	receivables []domain.AccountsReceivable,
+	payments []domain.CustomerPayment,
+	adjustments []domain.ReceivableAdjustment,
+	currency domain.CurrencyCode,
+	from, to time.Time,
+) *services.CustomerStatement {
+	statement := &services.CustomerStatement{
+		Currency: currency,
+		From:     from,
+		To:       to,
+		Lines:    []services.StatementLine{},
+	}
+
+	included := make(map[uuid.UUID]bool)
+	for _, ar := range receivables {
+		if ar.Currency != currency || ar.CreatedAt.After(to) {
+			continue
+		}
+		// Receivables cancelled outright drop off; those closed by adjustments stay
+		if ar.Status == domain.AccountStatusCancelled && ar.CreditedAmount == 0 && ar.WrittenOffAmount == 0 {
+			continue
+		}
+		included[ar.ReceivableID] = true
+
+		if ar.CreatedAt.Before(from) {
+			statement.OpeningBalance += ar.TotalAmount
+			continue
+		}
+
+		lineType := services.StatementLineCharge
+		if ar.Kind == domain.ReceivableKindLateFee {
+			lineType = services.StatementLineLateFee
+		}
+
+		reference := ar.ReceivableID.String()[:8]
+		if ar.Sale != nil {
+			reference = ar.Sale.InvoiceNumber
+		}
+		if ar.InstallmentNumber != nil {
+			reference = fmt.Sprintf("%s #%d", reference, *ar.InstallmentNumber)
+		}
+		statement.Lines = append(statement.Lines, services.StatementLine{
+			Date:         ar.CreatedAt,
+			Type:         lineType,
+			ReceivableID: ar.ReceivableID,
+			Reference:    reference,
+			Charge:       ar.TotalAmount,
+		})
+	}
+
+	for _, payment := range payments {
+		if !included[payment.ReceivableID] || payment.PaymentDate.After(to) {
+			continue
+		}
+
+		if payment.PaymentDate.Before(from) {
+			statement.OpeningBalance -= payment.Amount
+			continue
+		}
+
+		reference := string(payment.PaymentMethod)
+		if payment.Reference != nil && *payment.Reference != "" {
+			reference = *payment.Reference
+		}
+		statement.Lines = append(statement.Lines, services.StatementLine{
+			Date:         payment.PaymentDate,
+			Type:         services.StatementLinePayment,
+			ReceivableID: payment.ReceivableID,
+			Reference:    reference,
+			Payment:      payment.Amount,
+		})
+	}
+
+	for _, adjustment := range adjustments {
+		if !included[adjustment.ReceivableID] || adjustment.AppliedAt == nil || adjustment.AppliedAt.After(to) {
+			continue
+		}
+
+		if adjustment.AppliedAt.Before(from) {
+			statement.OpeningBalance -= adjustment.Amount
+			continue
+		}
+
+		lineType := services.StatementLineCreditNote
+		if adjustment.AdjustmentType == domain.AdjustmentTypeWriteOff {
+			lineType = services.StatementLineWriteOff
+		}
+
+		reference := adjustment.Description
+		if adjustment.Reference != nil && *adjustment.Reference != "" {
+			reference = *adjustment.Reference
+		}
+		statement.Lines = append(statement.Lines, services.StatementLine{
+			Date:         *adjustment.AppliedAt,
+			Type:         lineType,
+			ReceivableID: adjustment.ReceivableID,
+			Reference:    reference,
+			Payment:      adjustment.Amount,
+		})
+	}
+
+	sort.SliceStable(statement.Lines, func(i, j int) bool {
+		return statement.Lines[i].Date.Before(statement.Lines[j].Date)
+	})
+
+	statement.OpeningBalance = roundAmount(statement.OpeningBalance)
+	balance := statement.OpeningBalance
+	for i := range statement.Lines {
+		line := &statement.Lines[i]
+		balance = roundAmount(balance + line.Charge - line.Payment)
+		line.Balance = balance
+		statement.TotalCharges = roundAmount(statement.TotalCharges + line.Charge)
+		switch line.Type {
+		case services.StatementLinePayment:
+			statement.TotalPayments = roundAmount(statement.TotalPayments + line.Payment)
+		case services.StatementLineCreditNote, services.StatementLineWriteOff:
+			statement.TotalAdjustments = roundAmount(statement.TotalAdjustments + line.Payment)
+		}
+	}
+	statement.ClosingBalance = balance
+
+	return statement
+}
+
+// statementLineLabel returns the printed name of a statement movement
+func statementLineLabel(lineType services.StatementLineType) string {
+	switch lineType {
+	case services.StatementLineCharge:
+		return "Cargo"
+	case services.StatementLineLateFee:
+		return "Mora"
+	case services.StatementLinePayment:
+		return "Abono"
+	case services.StatementLineCreditNote:
+		return "N. crédito"
+	case services.StatementLineWriteOff:
+		return "Castigo"
+	}
+	return string(lineType)
+}
+
+// formatOptionalAmount prints an amount, leaving zero blank
+func formatOptionalAmount(amount float64) string {
+	if amount == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.2f", amount)
+}
+
+// creditExposure totals outstanding balances in VES. Balances in the sale's
+// currency use the sale's rate; others use the rate of the sale they came from.
+func creditExposure(receivables []domain.AccountsReceivable, currency domain.CurrencyCode, rate *float64) (float64, error) {
+	total := 0.0
+	for _, ar := range receivables {
+		arRate := rate
+		if ar.Currency != currency || rate == nil {
+			arRate = nil
+			if ar.Sale != nil {
+				arRate = ar.Sale.ExchangeRate
+			}
+		}
+
+		amount, err := convertAmount(ar.Balance, ar.Currency, domain.CurrencyVES, arRate)
+		if err != nil {
+			return 0, err
+		}
+		total += amount
+	}
+	return roundAmount(total), nil
+}
+
+// oldestDaysPastDue returns how many days the oldest outstanding balance is past due
+func oldestDaysPastDue(receivables []domain.AccountsReceivable, at time.Time) int {
+	oldest := 0
+	for _, ar := range receivables {
+		if days := daysPastDue(ar.DueDate, at); days > oldest {
+			oldest = days
+		}
+	}
+	return oldest
+}
+
+// creditViolations lists the customer's credit terms a sale would break
+func creditViolations(check *services.CreditCheck, customerCreditDays, daysOverdue, suspensionDays int) []string {
+	var violations []string
+
+	if roundAmount(check.Outstanding+check.Amount) > roundAmount(check.CreditLimit) {
+		violations = append(violations, fmt.Sprintf(
+			"Outstanding balance %.2f plus sale %.2f exceeds the credit limit %.2f",
+			check.Outstanding, check.Amount, check.CreditLimit,
+		))
+	}
+
+	if check.CreditDays > customerCreditDays {
+		violations = append(violations, fmt.Sprintf(
+			"Credit days %d exceed the customer's %d days",
+			check.CreditDays, customerCreditDays,
+		))
+	}
+
+	if suspensionDays > 0 && daysOverdue > suspensionDays {
+		violations = append(violations, fmt.Sprintf(
+			"Customer has balances %d days past due (limit %d)",
+			daysOverdue, suspensionDays,
+		))
+	}
+
+	return violations
+}
+
+// canOverrideCredit reports whether the role may override credit terms
+func canOverrideCredit(roleName string, overrideRoles []string) bool {
+	for _, role := range overrideRoles {
+		if strings.EqualFold(strings.TrimSpace(role), roleName) {
+			return true
+		}
+	}
+	return false
+}
+
+// paymentAllocation is the part of a customer payment applied to one receivable
+type paymentAllocation struct {
+	receivable *domain.AccountsReceivable
+	amount     float64 // In the receivable currency
+	applied    float64 // In the payment currency
+}
+
+// allocatePayment splits a payment across outstanding receivables, either as
+// requested or oldest due first. What is not allocated is left to the caller.
+func allocatePayment(amount float64, currency domain.CurrencyCode, rate *float64, receivables []domain.AccountsReceivable, requested []services.PaymentAllocation) ([]paymentAllocation, error) {
+	var allocations []paymentAllocation
+
+	if len(requested) == 0 {
+		oldest := make([]domain.AccountsReceivable, len(receivables))
+		copy(oldest, receivables)
+		sort.SliceStable(oldest, func(i, j int) bool {
+			return oldest[i].DueDate.Before(oldest[j].DueDate)
+		})
+
+		remaining := amount
+		for i := range oldest {
+			if remaining <= 0 {
+				break
+			}
+
+			allocation, err := allocateTo(&oldest[i], remaining, currency, rate)
+			if err != nil {
+				return nil, err
+			}
+			if allocation.applied <= 0 {
+				continue
+			}
+
+			allocations = append(allocations, allocation)
+			remaining = roundAmount(remaining - allocation.applied)
+		}
+		return allocations, nil
+	}
+
+	outstanding := make(map[uuid.UUID]*domain.AccountsReceivable, len(receivables))
+	for i := range receivables {
+		outstanding[receivables[i].ReceivableID] = &receivables[i]
+	}
+
+	total := 0.0
+	allocated := make(map[uuid.UUID]bool, len(requested))
+	for _, req := range requested {
+		ar, ok := outstanding[req.ReceivableID]
+		if !ok {
+			return nil, errors.InvalidInput(fmt.Sprintf("Receivable %s is not an outstanding balance of the customer", req.ReceivableID))
+		}
+		if allocated[req.ReceivableID] {
+			return nil, errors.InvalidInput(fmt.Sprintf("Receivable %s is allocated more than once", req.ReceivableID))
+		}
+		allocated[req.ReceivableID] = true
+
+		if req.Amount <= 0 {
+			return nil, errors.InvalidInput("Allocated amounts must be positive")
+		}
+
+		allocation, err := allocateTo(ar, roundAmount(req.Amount), currency, rate)
+		if err != nil {
+			return nil, err
+		}
+		if allocation.applied < roundAmount(req.Amount) {
+			return nil, errors.InvalidInput(fmt.Sprintf(
+				"Allocation (%.2f %s) exceeds the balance of receivable %s (%.2f %s)",
+				req.Amount, currency, req.ReceivableID, allocation.applied, currency,
+			))
+		}
+
+		allocations = append(allocations, allocation)
+		total += allocation.applied
+	}
+
+	if roundAmount(total) > amount {
+		return nil, errors.InvalidInput(fmt.Sprintf(
+			"Allocations (%.2f) exceed the payment amount (%.2f)",
+			roundAmount(total), amount,
+		))
+	}
+
+	return allocations, nil
+}
+
+// allocateTo applies up to available, in the payment currency, to the receivable balance
+func allocateTo(ar *domain.AccountsReceivable, available float64, currency domain.CurrencyCode, rate *float64) (paymentAllocation, error) {
+	balance, err := convertAmount(ar.Balance, ar.Currency, currency, rate)
+	if err != nil {
+		return paymentAllocation{}, err
+	}
+
+	// Settle the whole balance in its own currency so no conversion dust is left
+	if available >= balance {
+		return paymentAllocation{receivable: ar, amount: ar.Balance, applied: balance}, nil
+	}
+
+	amount, err := convertAmount(available, currency, ar.Currency, rate)
+	if err != nil {
+		return paymentAllocation{}, err
+	}
+	return paymentAllocation{receivable: ar, amount: math.Min(amount, ar.Balance), applied: available}, nil
+}
+
+// maxInstallments caps the number of installments of a plan
+const maxInstallments = 24
+
+// validateInstallmentTerms checks the count and frequency of an installment plan
+func validateInstallmentTerms(terms services.InstallmentTerms) error {
+	if terms.Count < 2 || terms.Count > maxInstallments {
+		return errors.InvalidInput(fmt.Sprintf("Installments must be between 2 and %d", maxInstallments))
+	}
+
+	switch terms.Frequency {
+	case domain.InstallmentFrequencyWeekly, domain.InstallmentFrequencyBiweekly, domain.InstallmentFrequencyMonthly:
+		return nil
+	default:
+		return errors.InvalidInput(fmt.Sprintf("Invalid installment frequency: %s", terms.Frequency))
+	}
+}
+
+// installmentDueDate returns the due date of the nth installment, counted from 1,
+// n periods after start. Monthly installments keep the day of start, or the last
+// day of shorter months.
+func installmentDueDate(start time.Time, frequency domain.InstallmentFrequency, n int) time.Time {
+	switch frequency {
+	case domain.InstallmentFrequencyWeekly:
+		return start.AddDate(0, 0, 7*n)
+	case domain.InstallmentFrequencyBiweekly:
+		return start.AddDate(0, 0, 14*n)
+	}
+
+	firstOfMonth := time.Date(start.Year(), start.Month()+time.Month(n), 1, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	day := start.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+// splitInstallments splits an amount into count installments rounded to cents,
+// the last one taking the rounding difference
+func splitInstallments(amount float64, count int) []float64 {
+	amounts := make([]float64, count)
+	share := math.Floor(amount/float64(count)*100+1e-6) / 100
+	for i := 0; i < count-1; i++ {
+		amounts[i] = share
+	}
+	amounts[count-1] = roundAmount(amount - share*float64(count-1))
+	return amounts
+}
+
+// buildInstallmentPlan splits the receivable of a credit sale into installments
+// due one period apart from start, each carrying the receivable's notes
+func buildInstallmentPlan(ar *domain.AccountsReceivable, terms services.InstallmentTerms, start time.Time) (*domain.InstallmentPlan, error) {
+	// Every installment must be worth at least a cent
+	if math.Round(ar.TotalAmount*100) < float64(terms.Count) {
+		return nil, errors.InvalidInput(fmt.Sprintf(
+			"Financed amount of %.2f %s is too small for %d installments", ar.TotalAmount, ar.Currency, terms.Count,
+		))
+	}
+
+	plan := &domain.InstallmentPlan{
+		PlanID:           uuid.New(),
+		SaleID:           *ar.SaleID,
+		CustomerID:       ar.CustomerID,
+		Frequency:        terms.Frequency,
+		InstallmentCount: terms.Count,
+		TotalAmount:      roundAmount(ar.TotalAmount),
+		Currency:         ar.Currency,
+		FirstDueDate:     installmentDueDate(start, terms.Frequency, 1),
+	}
+
+	for i, amount := range splitInstallments(plan.TotalAmount, terms.Count) {
+		number := i + 1
+		plan.Installments = append(plan.Installments, domain.AccountsReceivable{
+			ReceivableID:      uuid.New(),
+			SaleID:            ar.SaleID,
+			CustomerID:        ar.CustomerID,
+			TotalAmount:       amount,
+			Balance:           amount,
+			Currency:          ar.Currency,
+			DueDate:           installmentDueDate(start, terms.Frequency, number),
+			Status:            domain.AccountStatusPending,
+			Kind:              domain.ReceivableKindSale,
+			InstallmentPlanID: &plan.PlanID,
+			InstallmentNumber: &number,
+			Notes:             ar.Notes,
+		})
+	}
+
+	return plan, nil
+}
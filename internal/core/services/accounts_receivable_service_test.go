@@ -0,0 +1,414 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+func TestDaysPastDue(t *testing.T) {
+	due := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, -1, daysPastDue(due, time.Date(2024, 2, 29, 18, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 0, daysPastDue(due, time.Date(2024, 3, 1, 23, 59, 0, 0, time.UTC)))
+	assert.Equal(t, 31, daysPastDue(due, time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC)))
+}
+
+func TestBuildAgingReport(t *testing.T) {
+	asOf := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	storeA, storeB := uuid.New(), uuid.New()
+	ana := &domain.Customer{CustomerID: uuid.New(), FirstName: stringPtr("Ana")}
+	luis := &domain.Customer{CustomerID: uuid.New(), FirstName: stringPtr("Luis")}
+
+	receivable := func(customer *domain.Customer, storeID uuid.UUID, balance float64, daysPast int, currency domain.CurrencyCode) domain.AccountsReceivable {
+		return domain.AccountsReceivable{
+			ReceivableID: uuid.New(),
+			CustomerID:   customer.CustomerID,
+			Customer:     customer,
+			Sale:         &domain.Sale{StoreID: &storeID},
+			Balance:      balance,
+			Currency:     currency,
+			DueDate:      asOf.AddDate(0, 0, -daysPast),
+		}
+	}
+
+	report := buildAgingReport([]domain.AccountsReceivable{
+		receivable(luis, storeA, 100, -5, domain.CurrencyVES),
+		receivable(ana, storeA, 10, 15, domain.CurrencyVES),
+		receivable(ana, storeA, 20, 45, domain.CurrencyVES),
+		receivable(ana, storeA, 30, 75, domain.CurrencyVES),
+		receivable(ana, storeA, 40, 120, domain.CurrencyVES),
+		receivable(ana, storeB, 5, 30, domain.CurrencyVES),
+		receivable(ana, storeA, 7, 31, domain.CurrencyUSD),
+		receivable(ana, storeA, 0, 200, domain.CurrencyVES),
+	}, asOf)
+
+	require.Len(t, report.Rows, 4)
+
+	assert.Equal(t, domain.CurrencyUSD, report.Rows[0].Currency)
+	assert.Equal(t, 7.0, report.Rows[0].Buckets.Days31To60)
+
+	anaStoreA := report.Rows[1]
+	assert.Equal(t, "Ana", anaStoreA.CustomerName)
+	assert.Equal(t, storeA, *anaStoreA.StoreID)
+	assert.Equal(t, domain.CurrencyVES, anaStoreA.Currency)
+	assert.Equal(t, 4, anaStoreA.Receivables, "settled receivables are left out")
+	assert.Equal(t, services.AgingBuckets{Days1To30: 10, Days31To60: 20, Days61To90: 30, Over90: 40, Total: 100}, anaStoreA.Buckets)
+
+	assert.Equal(t, storeB, *report.Rows[2].StoreID)
+	assert.Equal(t, 5.0, report.Rows[2].Buckets.Days1To30)
+	assert.Equal(t, "Luis", report.Rows[3].CustomerName)
+	assert.Equal(t, 100.0, report.Rows[3].Buckets.Current)
+
+	assert.Equal(t, services.AgingBuckets{Current: 100, Days1To30: 15, Days31To60: 20, Days61To90: 30, Over90: 40, Total: 205}, report.Totals[domain.CurrencyVES])
+	assert.Equal(t, 7.0, report.Totals[domain.CurrencyUSD].Total)
+}
+
+func TestBuildCustomerStatement(t *testing.T) {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 31, 23, 59, 0, 0, time.UTC)
+
+	old := domain.AccountsReceivable{ReceivableID: uuid.New(), TotalAmount: 100, Currency: domain.CurrencyVES, CreatedAt: from.AddDate(0, -1, 0), Status: domain.AccountStatusPartiallyPaid}
+	current := domain.AccountsReceivable{ReceivableID: uuid.New(), TotalAmount: 50, Currency: domain.CurrencyVES, CreatedAt: from.AddDate(0, 0, 10), Sale: &domain.Sale{InvoiceNumber: "2024-05-0001"}}
+	later := domain.AccountsReceivable{ReceivableID: uuid.New(), TotalAmount: 70, Currency: domain.CurrencyVES, CreatedAt: to.AddDate(0, 0, 1)}
+	cancelled := domain.AccountsReceivable{ReceivableID: uuid.New(), TotalAmount: 80, Currency: domain.CurrencyVES, CreatedAt: from.AddDate(0, 0, 2), Status: domain.AccountStatusCancelled}
+	dollars := domain.AccountsReceivable{ReceivableID: uuid.New(), TotalAmount: 9, Currency: domain.CurrencyUSD, CreatedAt: from.AddDate(0, 0, 3)}
+
+	payments := []domain.CustomerPayment{
+		{ReceivableID: old.ReceivableID, Amount: 30, PaymentDate: from.AddDate(0, 0, -5), PaymentMethod: domain.PaymentMethodCash},
+		{ReceivableID: old.ReceivableID, Amount: 20, PaymentDate: from.AddDate(0, 0, 5), Reference: stringPtr("REF-1")},
+		{ReceivableID: cancelled.ReceivableID, Amount: 10, PaymentDate: from.AddDate(0, 0, 6)},
+		{ReceivableID: dollars.ReceivableID, Amount: 9, PaymentDate: from.AddDate(0, 0, 7)},
+	}
+
+	statement := buildCustomerStatement([]domain.AccountsReceivable{old, current, later, cancelled, dollars}, payments, nil, domain.CurrencyVES, from, to)
+
+	assert.Equal(t, 70.0, statement.OpeningBalance)
+	assert.Equal(t, 50.0, statement.TotalCharges)
+	assert.Equal(t, 20.0, statement.TotalPayments)
+	assert.Equal(t, 100.0, statement.ClosingBalance)
+
+	require.Len(t, statement.Lines, 2)
+	assert.Equal(t, services.StatementLinePayment, statement.Lines[0].Type)
+	assert.Equal(t, "REF-1", statement.Lines[0].Reference)
+	assert.Equal(t, 50.0, statement.Lines[0].Balance)
+	assert.Equal(t, services.StatementLineCharge, statement.Lines[1].Type)
+	assert.Equal(t, "2024-05-0001", statement.Lines[1].Reference)
+	assert.Equal(t, 100.0, statement.Lines[1].Balance)
+}
+
+func TestAccruedLateFee(t *testing.T) {
+	flat := &domain.LateFeePolicy{FeeType: domain.LateFeeTypeFlat, Amount: 5, GraceDays: 3}
+	daily := &domain.LateFeePolicy{FeeType: domain.LateFeeTypeDailyPercent, Amount: 0.5, GraceDays: 5, MaxAmount: float64Ptr(20)}
+
+	t.Run("nothing accrues during the grace period", func(t *testing.T) {
+		assert.Zero(t, accruedLateFee(flat, 100, 3))
+		assert.Zero(t, accruedLateFee(daily, 100, 5))
+	})
+
+	t.Run("flat fee is charged once", func(t *testing.T) {
+		assert.Equal(t, 5.0, accruedLateFee(flat, 100, 4))
+		assert.Equal(t, 5.0, accruedLateFee(flat, 100, 90))
+	})
+
+	t.Run("daily percent accrues on the balance per late day", func(t *testing.T) {
+		assert.Equal(t, 1.5, accruedLateFee(daily, 100, 8))
+	})
+
+	t.Run("cap limits the total fee", func(t *testing.T) {
+		assert.Equal(t, 20.0, accruedLateFee(daily, 1000, 60))
+	})
+
+	t.Run("settled balances accrue nothing", func(t *testing.T) {
+		assert.Zero(t, accruedLateFee(daily, 0, 60))
+	})
+}
+
+func TestBuildCustomerStatementLateFees(t *testing.T) {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 31, 23, 59, 0, 0, time.UTC)
+	sale := &domain.Sale{InvoiceNumber: "2024-04-0007"}
+
+	source := domain.AccountsReceivable{ReceivableID: uuid.New(), TotalAmount: 100, Currency: domain.CurrencyVES, CreatedAt: from.AddDate(0, 0, -40), Sale: sale}
+	fee := domain.AccountsReceivable{
+		ReceivableID:       uuid.New(),
+		TotalAmount:        5,
+		Currency:           domain.CurrencyVES,
+		CreatedAt:          from.AddDate(0, 0, 4),
+		Kind:               domain.ReceivableKindLateFee,
+		SourceReceivableID: &source.ReceivableID,
+		Sale:               sale,
+	}
+
+	statement := buildCustomerStatement([]domain.AccountsReceivable{source, fee}, nil, nil, domain.CurrencyVES, from, to)
+
+	require.Len(t, statement.Lines, 1)
+	assert.Equal(t, services.StatementLineLateFee, statement.Lines[0].Type)
+	assert.Equal(t, "2024-04-0007", statement.Lines[0].Reference)
+	assert.Equal(t, 5.0, statement.TotalCharges)
+	assert.Equal(t, 105.0, statement.ClosingBalance)
+}
+
+func TestCreditExposure(t *testing.T) {
+	receivables := []domain.AccountsReceivable{
+		{Balance: 100, Currency: domain.CurrencyVES},
+		{Balance: 10, Currency: domain.CurrencyUSD, Sale: &domain.Sale{ExchangeRate: float64Ptr(30)}},
+	}
+
+	// USD balances use the rate of the new USD sale
+	total, err := creditExposure(receivables, domain.CurrencyUSD, float64Ptr(40))
+	require.NoError(t, err)
+	assert.Equal(t, 500.0, total)
+
+	// Otherwise they use the rate of the sale they came from
+	total, err = creditExposure(receivables, domain.CurrencyVES, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 400.0, total)
+
+	_, err = creditExposure([]domain.AccountsReceivable{{Balance: 10, Currency: domain.CurrencyUSD}}, domain.CurrencyVES, nil)
+	assert.Error(t, err)
+}
+
+func TestCreditViolations(t *testing.T) {
+	check := &services.CreditCheck{CreditLimit: 1000, Outstanding: 600, Amount: 400, CreditDays: 30}
+	assert.Empty(t, creditViolations(check, 30, 10, 30))
+
+	check.Amount = 400.01
+	check.CreditDays = 45
+	violations := creditViolations(check, 30, 31, 30)
+	require.Len(t, violations, 3)
+	assert.Contains(t, violations[0], "credit limit")
+	assert.Contains(t, violations[1], "Credit days 45")
+	assert.Contains(t, violations[2], "31 days past due")
+
+	// Zero suspension days disables the overdue check
+	assert.Len(t, creditViolations(check, 30, 365, 0), 2)
+}
+
+func TestCanOverrideCredit(t *testing.T) {
+	roles := []string{"ADMIN", " Supervisor"}
+
+	assert.True(t, canOverrideCredit("admin", roles))
+	assert.True(t, canOverrideCredit("SUPERVISOR", roles))
+	assert.False(t, canOverrideCredit("CASHIER", roles))
+	assert.False(t, canOverrideCredit("ADMIN", nil))
+}
+
+func TestAllocatePaymentOldestFirst(t *testing.T) {
+	now := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	newer := domain.AccountsReceivable{ReceivableID: uuid.New(), Balance: 100, Currency: domain.CurrencyVES, DueDate: now}
+	older := domain.AccountsReceivable{ReceivableID: uuid.New(), Balance: 50, Currency: domain.CurrencyVES, DueDate: now.AddDate(0, 0, -30)}
+
+	allocations, err := allocatePayment(80, domain.CurrencyVES, nil, []domain.AccountsReceivable{newer, older}, nil)
+	require.NoError(t, err)
+	require.Len(t, allocations, 2)
+	assert.Equal(t, older.ReceivableID, allocations[0].receivable.ReceivableID)
+	assert.Equal(t, 50.0, allocations[0].amount)
+	assert.Equal(t, newer.ReceivableID, allocations[1].receivable.ReceivableID)
+	assert.Equal(t, 30.0, allocations[1].amount)
+
+	// Overpayments leave the excess unallocated
+	allocations, err = allocatePayment(200, domain.CurrencyVES, nil, []domain.AccountsReceivable{newer, older}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 150.0, allocations[0].applied+allocations[1].applied)
+}
+
+func TestAllocatePaymentCurrencyConversion(t *testing.T) {
+	usd := domain.AccountsReceivable{ReceivableID: uuid.New(), Balance: 10, Currency: domain.CurrencyUSD}
+	ves := domain.AccountsReceivable{ReceivableID: uuid.New(), Balance: 100, Currency: domain.CurrencyVES, DueDate: time.Now()}
+
+	// 500 VES at 40 VES/USD settles the 10 USD (400 VES) and pays 100 VES
+	allocations, err := allocatePayment(500, domain.CurrencyVES, float64Ptr(40), []domain.AccountsReceivable{usd, ves}, nil)
+	require.NoError(t, err)
+	require.Len(t, allocations, 2)
+	assert.Equal(t, 10.0, allocations[0].amount)
+	assert.Equal(t, 400.0, allocations[0].applied)
+	assert.Equal(t, 100.0, allocations[1].amount)
+
+	// A partial USD payment is converted into VES
+	allocations, err = allocatePayment(5, domain.CurrencyUSD, float64Ptr(40), []domain.AccountsReceivable{ves}, nil)
+	require.NoError(t, err)
+	require.Len(t, allocations, 1)
+	assert.Equal(t, 100.0, allocations[0].amount)
+	assert.Equal(t, 2.5, allocations[0].applied)
+
+	_, err = allocatePayment(5, domain.CurrencyUSD, nil, []domain.AccountsReceivable{ves}, nil)
+	assert.Error(t, err)
+}
+
+func TestAllocatePaymentExplicit(t *testing.T) {
+	a := domain.AccountsReceivable{ReceivableID: uuid.New(), Balance: 100, Currency: domain.CurrencyVES}
+	b := domain.AccountsReceivable{ReceivableID: uuid.New(), Balance: 50, Currency: domain.CurrencyVES}
+	receivables := []domain.AccountsReceivable{a, b}
+
+	allocations, err := allocatePayment(100, domain.CurrencyVES, nil, receivables, []services.PaymentAllocation{
+		{ReceivableID: b.ReceivableID, Amount: 50},
+		{ReceivableID: a.ReceivableID, Amount: 20},
+	})
+	require.NoError(t, err)
+	require.Len(t, allocations, 2)
+	assert.Equal(t, b.ReceivableID, allocations[0].receivable.ReceivableID)
+	assert.Equal(t, 20.0, allocations[1].amount)
+
+	_, err = allocatePayment(100, domain.CurrencyVES, nil, receivables, []services.PaymentAllocation{{ReceivableID: b.ReceivableID, Amount: 60}})
+	assert.Error(t, err, "over the receivable balance")
+
+	_, err = allocatePayment(100, domain.CurrencyVES, nil, receivables, []services.PaymentAllocation{
+		{ReceivableID: a.ReceivableID, Amount: 80},
+		{ReceivableID: b.ReceivableID, Amount: 30},
+	})
+	assert.Error(t, err, "over the payment amount")
+
+	_, err = allocatePayment(100, domain.CurrencyVES, nil, receivables, []services.PaymentAllocation{{ReceivableID: uuid.New(), Amount: 10}})
+	assert.Error(t, err, "unknown receivable")
+}
+
+func TestRefreshBalance(t *testing.T) {
+	now := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	receivable := func(paid, credited, writtenOff float64, due time.Time) *domain.AccountsReceivable {
+		return &domain.AccountsReceivable{TotalAmount: 100, PaidAmount: paid, CreditedAmount: credited, WrittenOffAmount: writtenOff, DueDate: due}
+	}
+	future := now.AddDate(0, 0, 10)
+
+	ar := receivable(0, 0, 0, future)
+	ar.RefreshBalance(now)
+	assert.Equal(t, domain.AccountStatusPending, ar.Status)
+	assert.Equal(t, 100.0, ar.Balance)
+
+	ar = receivable(30, 20, 0, future)
+	ar.RefreshBalance(now)
+	assert.Equal(t, domain.AccountStatusPartiallyPaid, ar.Status)
+	assert.Equal(t, 50.0, ar.Balance)
+
+	ar = receivable(30, 0, 0, now.AddDate(0, 0, -1))
+	ar.RefreshBalance(now)
+	assert.Equal(t, domain.AccountStatusOverdue, ar.Status)
+
+	ar = receivable(60, 40, 0, future)
+	ar.RefreshBalance(now)
+	assert.Equal(t, domain.AccountStatusPaid, ar.Status)
+
+	// Closed by a write-off or fully credited without payments
+	ar = receivable(60, 0, 40, future)
+	ar.RefreshBalance(now)
+	assert.Equal(t, domain.AccountStatusCancelled, ar.Status)
+	assert.Equal(t, 0.0, ar.Balance)
+
+	ar = receivable(0, 100, 0, future)
+	ar.RefreshBalance(now)
+	assert.Equal(t, domain.AccountStatusCancelled, ar.Status)
+}
+
+func TestBuildCustomerStatementAdjustments(t *testing.T) {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 31, 23, 59, 0, 0, time.UTC)
+
+	credited := domain.AccountsReceivable{ReceivableID: uuid.New(), TotalAmount: 100, CreditedAmount: 30, Currency: domain.CurrencyVES, CreatedAt: from.AddDate(0, -1, 0)}
+	writtenOff := domain.AccountsReceivable{ReceivableID: uuid.New(), TotalAmount: 50, WrittenOffAmount: 50, Currency: domain.CurrencyVES, CreatedAt: from.AddDate(0, 0, 1), Status: domain.AccountStatusCancelled}
+
+	before := from.AddDate(0, 0, -3)
+	during := from.AddDate(0, 0, 10)
+	adjustments := []domain.ReceivableAdjustment{
+		{ReceivableID: credited.ReceivableID, AdjustmentType: domain.AdjustmentTypeCreditNote, Amount: 10, Description: "Discount", AppliedAt: &before},
+		{ReceivableID: credited.ReceivableID, AdjustmentType: domain.AdjustmentTypeCreditNote, Amount: 20, Reference: stringPtr("DEV-1"), AppliedAt: &during},
+		{ReceivableID: writtenOff.ReceivableID, AdjustmentType: domain.AdjustmentTypeWriteOff, Amount: 50, Description: "Bad debt", AppliedAt: &during},
+	}
+
+	statement := buildCustomerStatement([]domain.AccountsReceivable{credited, writtenOff}, nil, adjustments, domain.CurrencyVES, from, to)
+
+	assert.Equal(t, 90.0, statement.OpeningBalance)
+	assert.Equal(t, 50.0, statement.TotalCharges)
+	assert.Equal(t, 0.0, statement.TotalPayments)
+	assert.Equal(t, 70.0, statement.TotalAdjustments)
+	assert.Equal(t, 70.0, statement.ClosingBalance)
+
+	require.Len(t, statement.Lines, 3)
+	assert.Equal(t, services.StatementLineCharge, statement.Lines[0].Type)
+	assert.Equal(t, services.StatementLineCreditNote, statement.Lines[1].Type)
+	assert.Equal(t, "DEV-1", statement.Lines[1].Reference)
+	assert.Equal(t, services.StatementLineWriteOff, statement.Lines[2].Type)
+	assert.Equal(t, "Bad debt", statement.Lines[2].Reference)
+}
+
+func TestInstallmentDueDate(t *testing.T) {
+	start := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2024, 2, 7, 10, 0, 0, 0, time.UTC), installmentDueDate(start, domain.InstallmentFrequencyWeekly, 1))
+	assert.Equal(t, time.Date(2024, 2, 28, 10, 0, 0, 0, time.UTC), installmentDueDate(start, domain.InstallmentFrequencyBiweekly, 2))
+
+	// Monthly installments keep the day, or the last day of shorter months
+	assert.Equal(t, time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC), installmentDueDate(start, domain.InstallmentFrequencyMonthly, 1))
+	assert.Equal(t, time.Date(2024, 3, 31, 10, 0, 0, 0, time.UTC), installmentDueDate(start, domain.InstallmentFrequencyMonthly, 2))
+	assert.Equal(t, time.Date(2024, 4, 30, 10, 0, 0, 0, time.UTC), installmentDueDate(start, domain.InstallmentFrequencyMonthly, 3))
+	assert.Equal(t, time.Date(2025, 1, 31, 10, 0, 0, 0, time.UTC), installmentDueDate(start, domain.InstallmentFrequencyMonthly, 12))
+}
+
+func TestSplitInstallments(t *testing.T) {
+	assert.Equal(t, []float64{33.33, 33.33, 33.34}, splitInstallments(100, 3))
+	assert.Equal(t, []float64{0.29, 0.29}, splitInstallments(0.58, 2))
+	assert.Equal(t, []float64{25, 25, 25, 25}, splitInstallments(100, 4))
+}
+
+func TestValidateInstallmentTerms(t *testing.T) {
+	assert.NoError(t, validateInstallmentTerms(services.InstallmentTerms{Count: 3, Frequency: domain.InstallmentFrequencyMonthly}))
+	assert.Error(t, validateInstallmentTerms(services.InstallmentTerms{Count: 1, Frequency: domain.InstallmentFrequencyMonthly}))
+	assert.Error(t, validateInstallmentTerms(services.InstallmentTerms{Count: maxInstallments + 1, Frequency: domain.InstallmentFrequencyWeekly}))
+	assert.Error(t, validateInstallmentTerms(services.InstallmentTerms{Count: 3, Frequency: "DAILY"}))
+}
+
+func TestBuildInstallmentPlan(t *testing.T) {
+	start := time.Date(2024, 8, 15, 12, 0, 0, 0, time.UTC)
+	saleID := uuid.New()
+	ar := &domain.AccountsReceivable{
+		SaleID:      &saleID,
+		CustomerID:  uuid.New(),
+		TotalAmount: 250,
+		Currency:    domain.CurrencyUSD,
+		Notes:       stringPtr("Credit override: school supplies"),
+	}
+
+	plan, err := buildInstallmentPlan(ar, services.InstallmentTerms{Count: 3, Frequency: domain.InstallmentFrequencyMonthly}, start)
+	require.NoError(t, err)
+
+	require.Len(t, plan.Installments, 3)
+	assert.Equal(t, saleID, plan.SaleID)
+	assert.Equal(t, 3, plan.InstallmentCount)
+	assert.Equal(t, time.Date(2024, 9, 15, 12, 0, 0, 0, time.UTC), plan.FirstDueDate)
+
+	total := 0.0
+	for i, installment := range plan.Installments {
+		require.NotNil(t, installment.InstallmentNumber)
+		assert.Equal(t, i+1, *installment.InstallmentNumber)
+		assert.Equal(t, plan.PlanID, *installment.InstallmentPlanID)
+		assert.Equal(t, installment.TotalAmount, installment.Balance)
+		assert.Equal(t, domain.AccountStatusPending, installment.Status)
+		assert.Equal(t, domain.CurrencyUSD, installment.Currency)
+		assert.Equal(t, ar.Notes, installment.Notes)
+		total += installment.TotalAmount
+	}
+	assert.InDelta(t, 250, total, 0.001)
+	assert.Equal(t, 83.34, plan.Installments[2].TotalAmount)
+	assert.Equal(t, time.Date(2024, 11, 15, 12, 0, 0, 0, time.UTC), plan.Installments[2].DueDate)
+}
+
+func TestBuildInstallmentPlanTooSmall(t *testing.T) {
+	saleID := uuid.New()
+	terms := services.InstallmentTerms{Count: 3, Frequency: domain.InstallmentFrequencyWeekly}
+
+	for _, amount := range []float64{0, -5, 0.02} {
+		ar := &domain.AccountsReceivable{SaleID: &saleID, TotalAmount: amount, Currency: domain.CurrencyUSD}
+		_, err := buildInstallmentPlan(ar, terms, time.Now())
+		assert.Error(t, err, "amount %.2f", amount)
+	}
+
+	ar := &domain.AccountsReceivable{SaleID: &saleID, TotalAmount: 0.03, Currency: domain.CurrencyUSD}
+	plan, err := buildInstallmentPlan(ar, terms, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 0.01, plan.Installments[2].TotalAmount)
+}
@@ -0,0 +1,387 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+// earthRadiusKm is used to measure the distance between locations
+const earthRadiusKm = 6371.0
+
+type allocationService struct {
+	warehouseRepo   repositories.WarehouseRepository
+	inventoryRepo   repositories.InventoryRepository
+	db              *gorm.DB
+	defaultStrategy domain.AllocationStrategy
+}
+
+// NewAllocationService creates a new stock allocation service
+func NewAllocationService(
+	warehouseRepo repositories.WarehouseRepository,
+	inventoryRepo repositories.InventoryRepository,
+	db *gorm.DB,
+	defaultStrategy domain.AllocationStrategy,
+) services.AllocationService {
+	if !validAllocationStrategy(defaultStrategy) {
+		defaultStrategy = domain.AllocationStrategyNearest
+	}
+
+	return &allocationService{
+		warehouseRepo:   warehouseRepo,
+		inventoryRepo:   inventoryRepo,
+		db:              db,
+		defaultStrategy: defaultStrategy,
+	}
+}
+
+// Plan computes which warehouses serve the items, reporting the quantities
+// that cannot be covered as shortages
+func (s *allocationService) Plan(ctx context.Context, req services.AllocationRequest) (*services.AllocationPlan, error) {
+	plan, _, err := s.plan(ctx, req)
+	return plan, err
+}
+
+// Allocate computes an allocation plan and fails when an item cannot be covered
+func (s *allocationService) Allocate(ctx context.Context, req services.AllocationRequest) (*services.AllocationPlan, error) {
+	plan, names, err := s.plan(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(plan.Shortages) > 0 {
+		shortage := plan.Shortages[0]
+		name, ok := names[shortage.ProductID]
+		if !ok {
+			name = shortage.ProductID.String()
+		}
+		return nil, errors.InsufficientStock(name, shortage.Available, shortage.Requested)
+	}
+
+	return plan, nil
+}
+
+// PrimaryWarehouse returns the active warehouse that serves a store by default
+func (s *allocationService) PrimaryWarehouse(ctx context.Context, storeID uuid.UUID) (*domain.Warehouse, error) {
+	warehouses, err := s.warehouseRepo.FindByStore(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range warehouses {
+		if warehouses[i].IsActive {
+			return &warehouses[i], nil
+		}
+	}
+	return nil, errors.NotFound(fmt.Sprintf("Warehouse for store %s", storeID))
+}
+
+func (s *allocationService) plan(ctx context.Context, req services.AllocationRequest) (*services.AllocationPlan, map[uuid.UUID]string, error) {
+	strategy := req.Strategy
+	if strategy == "" {
+		strategy = s.defaultStrategy
+	}
+	if !validAllocationStrategy(strategy) {
+		return nil, nil, errors.InvalidInput(fmt.Sprintf("Invalid allocation strategy %s", strategy))
+	}
+	if req.NoSplit && strategy == domain.AllocationStrategySplit {
+		strategy = domain.AllocationStrategyNearest
+	}
+
+	if len(req.Items) == 0 {
+		return nil, nil, errors.InvalidInput("At least one item is required to allocate stock")
+	}
+	for _, item := range req.Items {
+		if item.Quantity <= 0 {
+			return nil, nil, errors.InvalidInput("Quantity must be positive")
+		}
+	}
+
+	candidates, err := s.candidateWarehouses(ctx, req, strategy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Available stock of each product per warehouse
+	stock := make(map[uuid.UUID]map[uuid.UUID]float64)
+	names := make(map[uuid.UUID]string)
+	for _, item := range req.Items {
+		if _, ok := stock[item.ProductID]; ok {
+			continue
+		}
+
+		inventories, err := s.inventoryRepo.GetByProduct(ctx, item.ProductID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		available := make(map[uuid.UUID]float64, len(inventories))
+		for _, inventory := range inventories {
+			available[inventory.WarehouseID] = inventory.AvailableQuantity
+			if inventory.Product != nil {
+				names[item.ProductID] = inventory.Product.Name
+			}
+		}
+		stock[item.ProductID] = available
+	}
+	for _, held := range req.Held {
+		if available, ok := stock[held.ProductID]; ok {
+			available[held.WarehouseID] += held.Quantity
+		}
+	}
+
+	lines, shortages := planAllocation(req.Items, candidates, stock, strategy)
+
+	return &services.AllocationPlan{
+		StoreID:   req.StoreID,
+		Strategy:  strategy,
+		Lines:     lines,
+		Shortages: shortages,
+	}, names, nil
+}
+
+// candidateWarehouses returns the warehouses that can serve the store, in the
+// order they are tried
+func (s *allocationService) candidateWarehouses(ctx context.Context, req services.AllocationRequest, strategy domain.AllocationStrategy) ([]uuid.UUID, error) {
+	if req.WarehouseID != nil {
+		warehouse, err := s.warehouseRepo.FindByID(ctx, *req.WarehouseID)
+		if err != nil {
+			return nil, err
+		}
+		if !warehouse.IsActive {
+			return nil, errors.InvalidInput(fmt.Sprintf("Warehouse %s is not active", warehouse.Code))
+		}
+		return []uuid.UUID{warehouse.WarehouseID}, nil
+	}
+
+	if strategy == domain.AllocationStrategyPrimary {
+		warehouse, err := s.PrimaryWarehouse(ctx, req.StoreID)
+		if err != nil {
+			return nil, err
+		}
+		return []uuid.UUID{warehouse.WarehouseID}, nil
+	}
+
+	var store domain.Store
+	if err := s.db.WithContext(ctx).Preload("Location").First(&store, "store_id = ?", req.StoreID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFoundWithID("Store", req.StoreID.String())
+		}
+		return nil, errors.WrapError(err, "failed to find store")
+	}
+
+	warehouses, err := s.warehouseRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := rankWarehouses(req.StoreID, store.Location, warehouses)
+	if len(candidates) == 0 {
+		return nil, errors.NotFound("Active warehouse")
+	}
+	return candidates, nil
+}
+
+// Helper functions
+
+func validAllocationStrategy(strategy domain.AllocationStrategy) bool {
+	switch strategy {
+	case domain.AllocationStrategyPrimary, domain.AllocationStrategyNearest, domain.AllocationStrategySplit:
+		return true
+	}
+	return false
+}
+
+// rankWarehouses orders the active warehouses for a store: its primary
+// warehouse, its other warehouses, then the rest by distance to the store.
+// Warehouses without coordinates go last.
+func rankWarehouses(storeID uuid.UUID, storeLocation *domain.Location, warehouses []domain.Warehouse) []uuid.UUID {
+	type ranked struct {
+		id       uuid.UUID
+		code     string
+		group    int // 0 primary, 1 same store, 2 located, 3 unlocated
+		distance float64
+	}
+
+	candidates := make([]ranked, 0, len(warehouses))
+	for _, warehouse := range warehouses {
+		if !warehouse.IsActive {
+			continue
+		}
+
+		candidate := ranked{id: warehouse.WarehouseID, code: warehouse.Code, group: 3}
+		switch {
+		case warehouse.StoreID != nil && *warehouse.StoreID == storeID && warehouse.IsPrimary:
+			candidate.group = 0
+		case warehouse.StoreID != nil && *warehouse.StoreID == storeID:
+			candidate.group = 1
+		default:
+			if distance, ok := locationDistance(storeLocation, warehouse.Location); ok {
+				candidate.group = 2
+				candidate.distance = distance
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.group != b.group {
+			return a.group < b.group
+		}
+		if a.distance != b.distance {
+			return a.distance < b.distance
+		}
+		return a.code < b.code
+	})
+
+	ids := make([]uuid.UUID, len(candidates))
+	for i, candidate := range candidates {
+		ids[i] = candidate.id
+	}
+	return ids
+}
+
+// locationDistance returns the great-circle distance in km between two locations
+func locationDistance(from, to *domain.Location) (float64, bool) {
+	if from == nil || to == nil ||
+		from.Latitude == nil || from.Longitude == nil ||
+		to.Latitude == nil || to.Longitude == nil {
+		return 0, false
+	}
+
+	lat1 := *from.Latitude * math.Pi / 180
+	lat2 := *to.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (*to.Longitude - *from.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h)), true
+}
+
+// planAllocation assigns each item to the candidate warehouses in order. An
+// item is taken whole from the first warehouse that covers it; with the SPLIT
+// strategy an item no warehouse covers alone is spread across them. Stock
+// assigned to an item is not offered to the following ones.
+func planAllocation(
+	items []services.AllocationItem,
+	candidates []uuid.UUID,
+	stock map[uuid.UUID]map[uuid.UUID]float64,
+	strategy domain.AllocationStrategy,
+) ([]services.AllocationLine, []services.AllocationShortage) {
+	remaining := make(map[uuid.UUID]map[uuid.UUID]float64, len(stock))
+	for productID, available := range stock {
+		copied := make(map[uuid.UUID]float64, len(available))
+		for warehouseID, quantity := range available {
+			copied[warehouseID] = quantity
+		}
+		remaining[productID] = copied
+	}
+
+	lines := make([]services.AllocationLine, 0, len(items))
+	var shortages []services.AllocationShortage
+
+	for index, item := range items {
+		available := remaining[item.ProductID]
+		if available == nil {
+			available = make(map[uuid.UUID]float64)
+			remaining[item.ProductID] = available
+		}
+
+		whole := false
+		best, total := 0.0, 0.0
+		for _, warehouseID := range candidates {
+			quantity := math.Max(available[warehouseID], 0)
+			if quantity >= item.Quantity {
+				lines = append(lines, services.AllocationLine{
+					ItemIndex:   index,
+					ProductID:   item.ProductID,
+					WarehouseID: warehouseID,
+					Quantity:    item.Quantity,
+				})
+				available[warehouseID] -= item.Quantity
+				whole = true
+				break
+			}
+			best = math.Max(best, quantity)
+			total += quantity
+		}
+		if whole {
+			continue
+		}
+
+		if strategy != domain.AllocationStrategySplit {
+			shortages = append(shortages, services.AllocationShortage{
+				ProductID: item.ProductID,
+				Requested: item.Quantity,
+				Available: best,
+			})
+			continue
+		}
+
+		if total < item.Quantity {
+			shortages = append(shortages, services.AllocationShortage{
+				ProductID: item.ProductID,
+				Requested: item.Quantity,
+				Available: total,
+			})
+			continue
+		}
+
+		pending := item.Quantity
+		for _, warehouseID := range candidates {
+			quantity := math.Min(math.Max(available[warehouseID], 0), pending)
+			if quantity <= 0 {
+				continue
+			}
+			lines = append(lines, services.AllocationLine{
+				ItemIndex:   index,
+				ProductID:   item.ProductID,
+				WarehouseID: warehouseID,
+				Quantity:    quantity,
+			})
+			available[warehouseID] -= quantity
+			pending -= quantity
+			if pending <= 0 {
+				break
+			}
+		}
+	}
+
+	return lines, shortages
+}
+
+// proratedDiscount returns the share of a line discount that goes with part of
+// its quantity. The last part takes what is left, so the parts add up exactly.
+func proratedDiscount(discount, quantity, partQuantity, assigned float64, last bool) float64 {
+	if last {
+		return roundAmount(discount - assigned)
+	}
+	if quantity <= 0 {
+		return 0
+	}
+	return roundAmount(discount * partQuantity / quantity)
+}
+
+// allocatedWarehouses returns the warehouses used by a plan in order of first use
+func allocatedWarehouses(plan *services.AllocationPlan) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool)
+	warehouses := make([]uuid.UUID, 0, 1)
+	for _, line := range plan.Lines {
+		if !seen[line.WarehouseID] {
+			seen[line.WarehouseID] = true
+			warehouses = append(warehouses, line.WarehouseID)
+		}
+	}
+	return warehouses
+}
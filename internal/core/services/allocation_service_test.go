@@ -0,0 +1,162 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+func TestRankWarehouses(t *testing.T) {
+	storeID := uuid.New()
+	otherStore := uuid.New()
+	caracas := &domain.Location{Latitude: float64Ptr(10.4806), Longitude: float64Ptr(-66.9036)}
+	valencia := &domain.Location{Latitude: float64Ptr(10.1620), Longitude: float64Ptr(-68.0077)}
+	maracaibo := &domain.Location{Latitude: float64Ptr(10.6427), Longitude: float64Ptr(-71.6125)}
+
+	primary := domain.Warehouse{WarehouseID: uuid.New(), Code: "B", StoreID: &storeID, IsPrimary: true, IsActive: true}
+	secondary := domain.Warehouse{WarehouseID: uuid.New(), Code: "A", StoreID: &storeID, IsActive: true}
+	near := domain.Warehouse{WarehouseID: uuid.New(), Code: "Z", StoreID: &otherStore, Location: valencia, IsActive: true}
+	far := domain.Warehouse{WarehouseID: uuid.New(), Code: "C", Location: maracaibo, IsActive: true}
+	unlocated := domain.Warehouse{WarehouseID: uuid.New(), Code: "D", IsActive: true}
+	inactive := domain.Warehouse{WarehouseID: uuid.New(), Code: "E", StoreID: &storeID, IsPrimary: true}
+
+	t.Run("primary, own warehouses, then by distance", func(t *testing.T) {
+		ranked := rankWarehouses(storeID, caracas, []domain.Warehouse{unlocated, far, near, secondary, inactive, primary})
+		assert.Equal(t, []uuid.UUID{
+			primary.WarehouseID, secondary.WarehouseID, near.WarehouseID, far.WarehouseID, unlocated.WarehouseID,
+		}, ranked)
+	})
+
+	t.Run("store without coordinates falls back to code order", func(t *testing.T) {
+		ranked := rankWarehouses(storeID, nil, []domain.Warehouse{near, far, unlocated})
+		assert.Equal(t, []uuid.UUID{far.WarehouseID, unlocated.WarehouseID, near.WarehouseID}, ranked)
+	})
+}
+
+func TestLocationDistance(t *testing.T) {
+	caracas := &domain.Location{Latitude: float64Ptr(10.4806), Longitude: float64Ptr(-66.9036)}
+	valencia := &domain.Location{Latitude: float64Ptr(10.1620), Longitude: float64Ptr(-68.0077)}
+
+	distance, ok := locationDistance(caracas, valencia)
+	require.True(t, ok)
+	assert.InDelta(t, 125, distance, 5)
+
+	_, ok = locationDistance(caracas, &domain.Location{})
+	assert.False(t, ok)
+}
+
+func TestPlanAllocation(t *testing.T) {
+	primary, near, far := uuid.New(), uuid.New(), uuid.New()
+	candidates := []uuid.UUID{primary, near, far}
+	pencil, notebook := uuid.New(), uuid.New()
+
+	stock := map[uuid.UUID]map[uuid.UUID]float64{
+		pencil:   {primary: 4, near: 10, far: 3},
+		notebook: {primary: 5},
+	}
+
+	t.Run("takes whole items from the first warehouse that covers them", func(t *testing.T) {
+		lines, shortages := planAllocation([]services.AllocationItem{
+			{ProductID: pencil, Quantity: 6},
+			{ProductID: notebook, Quantity: 2},
+		}, candidates, stock, domain.AllocationStrategyNearest)
+
+		assert.Empty(t, shortages)
+		assert.Equal(t, []services.AllocationLine{
+			{ItemIndex: 0, ProductID: pencil, WarehouseID: near, Quantity: 6},
+			{ItemIndex: 1, ProductID: notebook, WarehouseID: primary, Quantity: 2},
+		}, lines)
+	})
+
+	t.Run("nearest reports the best single warehouse as available", func(t *testing.T) {
+		lines, shortages := planAllocation([]services.AllocationItem{
+			{ProductID: pencil, Quantity: 12},
+		}, candidates, stock, domain.AllocationStrategyNearest)
+
+		assert.Empty(t, lines)
+		assert.Equal(t, []services.AllocationShortage{{ProductID: pencil, Requested: 12, Available: 10}}, shortages)
+	})
+
+	t.Run("split spreads an item across warehouses in order", func(t *testing.T) {
+		lines, shortages := planAllocation([]services.AllocationItem{
+			{ProductID: pencil, Quantity: 15},
+		}, candidates, stock, domain.AllocationStrategySplit)
+
+		assert.Empty(t, shortages)
+		assert.Equal(t, []services.AllocationLine{
+			{ItemIndex: 0, ProductID: pencil, WarehouseID: primary, Quantity: 4},
+			{ItemIndex: 0, ProductID: pencil, WarehouseID: near, Quantity: 10},
+			{ItemIndex: 0, ProductID: pencil, WarehouseID: far, Quantity: 1},
+		}, lines)
+	})
+
+	t.Run("split prefers a single warehouse when one covers the item", func(t *testing.T) {
+		lines, _ := planAllocation([]services.AllocationItem{
+			{ProductID: pencil, Quantity: 8},
+		}, candidates, stock, domain.AllocationStrategySplit)
+
+		assert.Equal(t, []services.AllocationLine{{ItemIndex: 0, ProductID: pencil, WarehouseID: near, Quantity: 8}}, lines)
+	})
+
+	t.Run("split reports the total available", func(t *testing.T) {
+		_, shortages := planAllocation([]services.AllocationItem{
+			{ProductID: pencil, Quantity: 20},
+		}, candidates, stock, domain.AllocationStrategySplit)
+
+		assert.Equal(t, []services.AllocationShortage{{ProductID: pencil, Requested: 20, Available: 17}}, shortages)
+	})
+
+	t.Run("stock given to an item is not offered again", func(t *testing.T) {
+		lines, shortages := planAllocation([]services.AllocationItem{
+			{ProductID: notebook, Quantity: 3},
+			{ProductID: notebook, Quantity: 3},
+		}, candidates, stock, domain.AllocationStrategyNearest)
+
+		assert.Len(t, lines, 1)
+		assert.Equal(t, []services.AllocationShortage{{ProductID: notebook, Requested: 3, Available: 2}}, shortages)
+		assert.Equal(t, 5.0, stock[notebook][primary], "the caller's stock is not modified")
+	})
+
+	t.Run("products without inventory are short", func(t *testing.T) {
+		_, shortages := planAllocation([]services.AllocationItem{
+			{ProductID: uuid.New(), Quantity: 1},
+		}, candidates, stock, domain.AllocationStrategySplit)
+
+		assert.Len(t, shortages, 1)
+		assert.Zero(t, shortages[0].Available)
+	})
+}
+
+func TestProratedDiscount(t *testing.T) {
+	first := proratedDiscount(10, 3, 1, 0, false)
+	second := proratedDiscount(10, 3, 1, first, false)
+	last := proratedDiscount(10, 3, 1, first+second, true)
+
+	assert.Equal(t, 3.33, first)
+	assert.Equal(t, 3.33, second)
+	assert.Equal(t, 3.34, last)
+}
+
+func TestSplitSaleDetails(t *testing.T) {
+	productID := uuid.New()
+	primary, near := uuid.New(), uuid.New()
+	details := []domain.SaleDetail{{ProductID: productID, Quantity: 4, UnitPrice: 5, DiscountAmount: 2}}
+
+	split := splitSaleDetails(details, &services.AllocationPlan{Lines: []services.AllocationLine{
+		{ItemIndex: 0, ProductID: productID, WarehouseID: primary, Quantity: 3},
+		{ItemIndex: 0, ProductID: productID, WarehouseID: near, Quantity: 1},
+	}})
+
+	require.Len(t, split, 2)
+	assert.Equal(t, primary, *split[0].WarehouseID)
+	assert.Equal(t, 3.0, split[0].Quantity)
+	assert.Equal(t, 1.5, split[0].DiscountAmount)
+	assert.Equal(t, near, *split[1].WarehouseID)
+	assert.Equal(t, 0.5, split[1].DiscountAmount)
+	assert.Nil(t, details[0].WarehouseID, "the original details are not modified")
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type campaignService struct {
+	campaignRepo repositories.CampaignRepository
+	db           *gorm.DB
+}
+
+// NewCampaignService creates a new campaign service
+func NewCampaignService(
+	campaignRepo repositories.CampaignRepository,
+	db *gorm.DB,
+) services.CampaignService {
+	return &campaignService{
+		campaignRepo: campaignRepo,
+		db:           db,
+	}
+}
+
+// CreateCampaign validates and creates a new campaign
+func (s *campaignService) CreateCampaign(ctx context.Context, campaign *domain.Campaign) error {
+	if campaign.PromotionType == "" {
+		campaign.PromotionType = domain.PromotionTypePercentage
+	}
+
+	if err := validateCampaign(campaign); err != nil {
+		return err
+	}
+
+	if campaign.CampaignID == uuid.Nil {
+		campaign.CampaignID = uuid.New()
+	}
+
+	// Counters are driven by the pricing engine only
+	campaign.ActualSales = 0
+	campaign.DiscountGranted = 0
+
+	return s.campaignRepo.Create(ctx, campaign)
+}
+
+// GetCampaign retrieves a campaign by ID
+func (s *campaignService) GetCampaign(ctx context.Context, id uuid.UUID) (*domain.Campaign, error) {
+	return s.campaignRepo.FindByID(ctx, id)
+}
+
+// ListCampaigns lists campaigns with filters
+func (s *campaignService) ListCampaigns(ctx context.Context, filters repositories.CampaignFilters, limit, offset int) ([]domain.Campaign, int64, error) {
+	return s.campaignRepo.List(ctx, filters, limit, offset)
+}
+
+// UpdateCampaign updates a campaign, preserving its accumulated counters
+func (s *campaignService) UpdateCampaign(ctx context.Context, campaign *domain.Campaign) error {
+	existing, err := s.campaignRepo.FindByID(ctx, campaign.CampaignID)
+	if err != nil {
+		return err
+	}
+
+	if campaign.PromotionType == "" {
+		campaign.PromotionType = existing.PromotionType
+	}
+
+	if err := validateCampaign(campaign); err != nil {
+		return err
+	}
+
+	campaign.ActualSales = existing.ActualSales
+	campaign.DiscountGranted = existing.DiscountGranted
+	campaign.CreatedAt = existing.CreatedAt
+	campaign.CreatedBy = existing.CreatedBy
+
+	return s.campaignRepo.Update(ctx, campaign)
+}
+
+// DeleteCampaign deletes a campaign
+func (s *campaignService) DeleteCampaign(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.campaignRepo.FindByID(ctx, id); err != nil {
+		return err
+	}
+	return s.campaignRepo.Delete(ctx, id)
+}
+
+// validateCampaign checks the fields required by each promotion type
+func validateCampaign(campaign *domain.Campaign) error {
+	if campaign.CampaignName == "" {
+		return errors.InvalidInput("Campaign name is required")
+	}
+
+	if campaign.EndDate.Before(campaign.StartDate) {
+		return errors.InvalidInput("End date must be after start date")
+	}
+
+	if campaign.Budget != nil && *campaign.Budget <= 0 {
+		return errors.InvalidInput("Budget must be positive")
+	}
+
+	switch campaign.PromotionType {
+	case domain.PromotionTypePercentage:
+		if campaign.DiscountPercentage == nil || *campaign.DiscountPercentage <= 0 || *campaign.DiscountPercentage > 100 {
+			return errors.InvalidInput("Discount percentage must be between 0 and 100")
+		}
+	case domain.PromotionTypeBuyXGetY:
+		if campaign.BuyQuantity == nil || *campaign.BuyQuantity <= 0 {
+			return errors.InvalidInput("Buy quantity must be positive")
+		}
+		if campaign.GetQuantity == nil || *campaign.GetQuantity <= 0 {
+			return errors.InvalidInput("Get quantity must be positive")
+		}
+	case domain.PromotionTypeBundle:
+		if len(campaign.BundleProducts) < 2 {
+			return errors.InvalidInput("Bundle must contain at least two products")
+		}
+		if campaign.BundlePrice == nil || *campaign.BundlePrice <= 0 {
+			return errors.InvalidInput("Bundle price must be positive")
+		}
+	default:
+		return errors.InvalidInput(fmt.Sprintf("Invalid promotion type %s", campaign.PromotionType))
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	t.Run("valid expressions", func(t *testing.T) {
+		for _, spec := range []string{"* * * * *", "*/15 8-18 * * 1-5", "0 0 1,15 * *", "30 2 * * 7", "@daily", "@hourly"} {
+			_, err := parseCronSchedule(spec)
+			assert.NoError(t, err, spec)
+		}
+	})
+
+	t.Run("invalid expressions", func(t *testing.T) {
+		for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@never"} {
+			_, err := parseCronSchedule(spec)
+			assert.Error(t, err, spec)
+		}
+	})
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// Saturday
+	base := time.Date(2026, time.August, 15, 10, 7, 30, 0, time.UTC)
+
+	cases := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, time.August, 15, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, time.August, 15, 10, 15, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2026, time.August, 15, 11, 0, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2026, time.August, 16, 2, 30, 0, 0, time.UTC)},
+		{"0 8 * * 1-5", time.Date(2026, time.August, 17, 8, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, time.August, 16, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 1 *", time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// Day of month and day of week are OR'ed when both are restricted
+		{"0 9 1 * 1", time.Date(2026, time.August, 17, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range cases {
+		schedule, err := parseCronSchedule(tc.spec)
+		require.NoError(t, err, tc.spec)
+		assert.Equal(t, tc.want, schedule.Next(base), tc.spec)
+	}
+
+	t.Run("never matches", func(t *testing.T) {
+		schedule, err := parseCronSchedule("0 0 30 2 *")
+		require.NoError(t, err)
+		assert.True(t, schedule.Next(base).IsZero())
+	})
+}
@@ -0,0 +1,385 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type employeeService struct {
+	employeeRepo repositories.EmployeeRepository
+	userRepo     repositories.UserRepository
+}
+
+// NewEmployeeService creates a new employee service
+func NewEmployeeService(
+	employeeRepo repositories.EmployeeRepository,
+	userRepo repositories.UserRepository,
+) services.EmployeeService {
+	return &employeeService{
+		employeeRepo: employeeRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// CreateEmployee hires an employee, opening its assignment to its store
+func (s *employeeService) CreateEmployee(ctx context.Context, employee *domain.Employee) error {
+	if err := validateEmployee(employee); err != nil {
+		return err
+	}
+
+	if employee.EmployeeID == uuid.Nil {
+		employee.EmployeeID = uuid.New()
+	}
+	employee.Status = domain.EmployeeStatusActive
+	employee.TerminationDate = nil
+	employee.TerminationReason = nil
+
+	if err := s.checkNationalID(ctx, employee); err != nil {
+		return err
+	}
+
+	if employee.StoreID != nil {
+		if _, err := s.findActiveStore(ctx, *employee.StoreID); err != nil {
+			return err
+		}
+	}
+
+	if employee.UserID != nil {
+		if err := s.checkUserLink(ctx, employee.EmployeeID, *employee.UserID); err != nil {
+			return err
+		}
+	}
+
+	return s.employeeRepo.Create(ctx, employee)
+}
+
+// GetEmployee retrieves an employee with its store and user
+func (s *employeeService) GetEmployee(ctx context.Context, id uuid.UUID) (*domain.Employee, error) {
+	return s.employeeRepo.FindByID(ctx, id)
+}
+
+// ListEmployees lists employees matching the filters
+func (s *employeeService) ListEmployees(ctx context.Context, filters repositories.EmployeeFilters, limit, offset int) ([]domain.Employee, int64, error) {
+	return s.employeeRepo.List(ctx, filters, limit, offset)
+}
+
+// UpdateEmployee saves the employee data. Store, status and user link keep
+// their current values; they change through their own methods.
+func (s *employeeService) UpdateEmployee(ctx context.Context, employee *domain.Employee) error {
+	existing, err := s.employeeRepo.FindByID(ctx, employee.EmployeeID)
+	if err != nil {
+		return err
+	}
+
+	if err := validateEmployee(employee); err != nil {
+		return err
+	}
+
+	if existing.TerminationDate != nil && employee.HireDate.After(*existing.TerminationDate) {
+		return errors.InvalidInput("Hire date cannot be after the termination date")
+	}
+
+	if err := s.checkNationalID(ctx, employee); err != nil {
+		return err
+	}
+
+	employee.StoreID = existing.StoreID
+	employee.Status = existing.Status
+	employee.TerminationDate = existing.TerminationDate
+	employee.TerminationReason = existing.TerminationReason
+	employee.UserID = existing.UserID
+	employee.CreatedBy = existing.CreatedBy
+	employee.CreatedAt = existing.CreatedAt
+
+	return s.employeeRepo.Update(ctx, employee)
+}
+
+// DeleteEmployee deletes an employee that no longer works for the company
+func (s *employeeService) DeleteEmployee(ctx context.Context, id uuid.UUID) error {
+	employee, err := s.employeeRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if employee.Status == domain.EmployeeStatusActive || employee.Status == domain.EmployeeStatusOnLeave {
+		return errors.BadRequest(fmt.Sprintf("Employee is %s; terminate or deactivate it before deleting it", employee.Status))
+	}
+
+	return s.employeeRepo.Delete(ctx, id)
+}
+
+// ChangeStatus moves an employee to another status. Terminating records the
+// date and reason and deactivates the linked user; activating a terminated
+// employee rehires it from the given date.
+func (s *employeeService) ChangeStatus(ctx context.Context, req services.ChangeEmployeeStatusRequest) (*domain.Employee, error) {
+	employee, err := s.employeeRepo.FindByID(ctx, req.EmployeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !employee.CanTransitionTo(req.Status) {
+		return nil, errors.BadRequest(fmt.Sprintf("Employee is %s and cannot become %s", employee.Status, req.Status))
+	}
+
+	today := dateOnly(time.Now())
+	date := today
+	if req.Date != nil {
+		date = dateOnly(*req.Date)
+	}
+
+	from := employee.Status
+	switch {
+	case req.Status == domain.EmployeeStatusTerminated:
+		if req.Reason == nil || strings.TrimSpace(*req.Reason) == "" {
+			return nil, errors.InvalidInput("Termination reason is required")
+		}
+		if date.After(today) {
+			return nil, errors.InvalidInput("Termination date cannot be in the future")
+		}
+		if date.Before(dateOnly(employee.HireDate)) {
+			return nil, errors.InvalidInput("Termination date cannot be before the hire date")
+		}
+		reason := strings.TrimSpace(*req.Reason)
+		employee.TerminationDate = &date
+		employee.TerminationReason = &reason
+
+	case from == domain.EmployeeStatusTerminated:
+		if employee.TerminationDate != nil && !date.After(dateOnly(*employee.TerminationDate)) {
+			return nil, errors.InvalidInput("Rehire date must be after the termination date")
+		}
+		employee.HireDate = date
+		employee.TerminationDate = nil
+		employee.TerminationReason = nil
+	}
+
+	employee.Status = req.Status
+	employee.UpdatedBy = &req.UserID
+
+	if err := s.employeeRepo.ChangeStatus(ctx, employee, from); err != nil {
+		return nil, err
+	}
+
+	return s.employeeRepo.FindByID(ctx, employee.EmployeeID)
+}
+
+// AssignStore moves an employee to another store, closing its current
+// assignment the day the new one starts
+func (s *employeeService) AssignStore(ctx context.Context, req services.AssignStoreRequest) (*domain.Employee, error) {
+	employee, err := s.employeeRepo.FindByID(ctx, req.EmployeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if employee.Status == domain.EmployeeStatusTerminated {
+		return nil, errors.BadRequest("Terminated employees cannot be assigned to a store")
+	}
+
+	store, err := s.findActiveStore(ctx, req.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	if employee.StoreID != nil && *employee.StoreID == store.StoreID {
+		return nil, errors.BadRequest(fmt.Sprintf("Employee is already assigned to %s", store.Name))
+	}
+
+	today := dateOnly(time.Now())
+	startDate := today
+	if req.StartDate != nil {
+		startDate = dateOnly(*req.StartDate)
+	}
+
+	if startDate.After(today) {
+		return nil, errors.InvalidInput("Assignment start date cannot be in the future")
+	}
+	if startDate.Before(dateOnly(employee.HireDate)) {
+		return nil, errors.InvalidInput("Assignment start date cannot be before the hire date")
+	}
+
+	history, err := s.employeeRepo.GetStoreHistory(ctx, employee.EmployeeID)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) > 0 && history[0].EndDate == nil && startDate.Before(dateOnly(history[0].StartDate)) {
+		return nil, errors.InvalidInput("Assignment start date cannot be before the start of the current assignment")
+	}
+
+	assignment := &domain.EmployeeStoreAssignment{
+		AssignmentID: uuid.New(),
+		EmployeeID:   employee.EmployeeID,
+		StoreID:      store.StoreID,
+		StartDate:    startDate,
+		Reason:       req.Reason,
+		AssignedBy:   &req.UserID,
+	}
+
+	if err := s.employeeRepo.AssignStore(ctx, assignment); err != nil {
+		return nil, err
+	}
+
+	return s.employeeRepo.FindByID(ctx, employee.EmployeeID)
+}
+
+// GetStoreHistory lists the store assignments of an employee, latest first
+func (s *employeeService) GetStoreHistory(ctx context.Context, employeeID uuid.UUID) ([]domain.EmployeeStoreAssignment, error) {
+	if _, err := s.employeeRepo.FindByID(ctx, employeeID); err != nil {
+		return nil, err
+	}
+	return s.employeeRepo.GetStoreHistory(ctx, employeeID)
+}
+
+// LinkUser links a system user to an employee. A user belongs to one
+// employee at most.
+func (s *employeeService) LinkUser(ctx context.Context, employeeID, userID, updatedBy uuid.UUID) (*domain.Employee, error) {
+	employee, err := s.employeeRepo.FindByID(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if employee.Status == domain.EmployeeStatusTerminated {
+		return nil, errors.BadRequest("Terminated employees cannot be linked to a user")
+	}
+
+	if employee.UserID != nil && *employee.UserID == userID {
+		return employee, nil
+	}
+
+	if err := s.checkUserLink(ctx, employee.EmployeeID, userID); err != nil {
+		return nil, err
+	}
+
+	employee.UserID = &userID
+	employee.UpdatedBy = &updatedBy
+
+	if err := s.employeeRepo.Update(ctx, employee); err != nil {
+		return nil, err
+	}
+
+	return s.employeeRepo.FindByID(ctx, employeeID)
+}
+
+// UnlinkUser removes the system user of an employee
+func (s *employeeService) UnlinkUser(ctx context.Context, employeeID, updatedBy uuid.UUID) (*domain.Employee, error) {
+	employee, err := s.employeeRepo.FindByID(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if employee.UserID == nil {
+		return nil, errors.BadRequest("Employee has no linked user")
+	}
+
+	employee.UserID = nil
+	employee.User = nil
+	employee.UpdatedBy = &updatedBy
+
+	if err := s.employeeRepo.Update(ctx, employee); err != nil {
+		return nil, err
+	}
+
+	return employee, nil
+}
+
+// Helper functions
+
+func (s *employeeService) findActiveStore(ctx context.Context, id uuid.UUID) (*domain.Store, error) {
+	store, err := s.employeeRepo.FindStoreByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !store.IsActive {
+		return nil, errors.InvalidInput(fmt.Sprintf("Store %s is not active", store.Name))
+	}
+	return store, nil
+}
+
+func (s *employeeService) checkNationalID(ctx context.Context, employee *domain.Employee) error {
+	if existing, err := s.employeeRepo.FindByNationalID(ctx, employee.NationalID); err == nil {
+		if existing.EmployeeID != employee.EmployeeID {
+			return errors.AlreadyExists("Employee", "national_id", employee.NationalID)
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// checkUserLink verifies the user exists and is not linked to another employee
+func (s *employeeService) checkUserLink(ctx context.Context, employeeID, userID uuid.UUID) error {
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return err
+	}
+
+	if linked, err := s.employeeRepo.FindByUserID(ctx, userID); err == nil {
+		if linked.EmployeeID != employeeID {
+			return errors.Conflict(fmt.Sprintf("User is already linked to employee %s %s", linked.FirstName, linked.LastName))
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// validateEmployee checks and normalizes the data of an employee
+func validateEmployee(employee *domain.Employee) error {
+	employee.NationalID = strings.ToUpper(strings.TrimSpace(employee.NationalID))
+	if employee.NationalID == "" {
+		return errors.InvalidInput("Employee national ID is required")
+	}
+
+	employee.FirstName = strings.TrimSpace(employee.FirstName)
+	employee.LastName = strings.TrimSpace(employee.LastName)
+	if employee.FirstName == "" || employee.LastName == "" {
+		return errors.InvalidInput("Employee first and last name are required")
+	}
+
+	employee.JobTitle = strings.TrimSpace(employee.JobTitle)
+	if employee.JobTitle == "" {
+		return errors.InvalidInput("Employee job title is required")
+	}
+
+	if employee.Department != nil {
+		employee.Department = optionalString(strings.TrimSpace(*employee.Department))
+	}
+
+	if employee.HireDate.IsZero() {
+		return errors.InvalidInput("Employee hire date is required")
+	}
+	employee.HireDate = dateOnly(employee.HireDate)
+
+	if employee.DateOfBirth != nil && !employee.DateOfBirth.Before(employee.HireDate) {
+		return errors.InvalidInput("Date of birth must be before the hire date")
+	}
+
+	if employee.Gender != nil {
+		switch *employee.Gender {
+		case domain.GenderMale, domain.GenderFemale, domain.GenderOther:
+		default:
+			return errors.InvalidInput(fmt.Sprintf("Invalid gender: %s", *employee.Gender))
+		}
+	}
+
+	if employee.BaseSalary <= 0 {
+		return errors.InvalidInput("Employee base salary must be positive")
+	}
+	employee.BaseSalary = roundAmount(employee.BaseSalary)
+
+	switch employee.SalaryCurrency {
+	case "":
+		employee.SalaryCurrency = domain.CurrencyVES
+	case domain.CurrencyVES, domain.CurrencyUSD:
+	default:
+		return errors.InvalidInput(fmt.Sprintf("Salaries are paid in VES or USD, not %s", employee.SalaryCurrency))
+	}
+
+	return nil
+}
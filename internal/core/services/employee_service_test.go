@@ -0,0 +1,95 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+func TestEmployeeCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from    domain.EmployeeStatus
+		allowed []domain.EmployeeStatus
+	}{
+		{domain.EmployeeStatusActive, []domain.EmployeeStatus{domain.EmployeeStatusOnLeave, domain.EmployeeStatusInactive, domain.EmployeeStatusTerminated}},
+		{domain.EmployeeStatusOnLeave, []domain.EmployeeStatus{domain.EmployeeStatusActive, domain.EmployeeStatusTerminated}},
+		{domain.EmployeeStatusInactive, []domain.EmployeeStatus{domain.EmployeeStatusActive, domain.EmployeeStatusTerminated}},
+		{domain.EmployeeStatusTerminated, []domain.EmployeeStatus{domain.EmployeeStatusActive}},
+	}
+
+	all := []domain.EmployeeStatus{
+		domain.EmployeeStatusActive, domain.EmployeeStatusOnLeave, domain.EmployeeStatusInactive, domain.EmployeeStatusTerminated,
+	}
+
+	for _, tt := range tests {
+		allowed := make(map[domain.EmployeeStatus]bool)
+		for _, status := range tt.allowed {
+			allowed[status] = true
+		}
+
+		employee := &domain.Employee{Status: tt.from}
+		for _, to := range all {
+			assert.Equal(t, allowed[to], employee.CanTransitionTo(to), "%s -> %s", tt.from, to)
+		}
+	}
+}
+
+func TestValidateEmployee(t *testing.T) {
+	department := "  "
+	employee := &domain.Employee{
+		NationalID: " v-12345678 ",
+		FirstName:  " Ana ",
+		LastName:   "Pérez",
+		JobTitle:   "Cajera",
+		Department: &department,
+		HireDate:   time.Date(2024, 3, 1, 15, 30, 0, 0, time.UTC),
+		BaseSalary: 450.456,
+	}
+
+	require.NoError(t, validateEmployee(employee))
+	assert.Equal(t, "V-12345678", employee.NationalID)
+	assert.Equal(t, "Ana", employee.FirstName)
+	assert.Nil(t, employee.Department)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), employee.HireDate)
+	assert.Equal(t, 450.46, employee.BaseSalary)
+	assert.Equal(t, domain.CurrencyVES, employee.SalaryCurrency)
+}
+
+func TestValidateEmployeeErrors(t *testing.T) {
+	valid := func() *domain.Employee {
+		return &domain.Employee{
+			NationalID: "V12345678",
+			FirstName:  "Luis",
+			LastName:   "Gómez",
+			JobTitle:   "Almacenista",
+			HireDate:   time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			BaseSalary: 300,
+		}
+	}
+
+	born := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	gender := domain.GenderType("X")
+
+	tests := map[string]func(e *domain.Employee){
+		"missing national id": func(e *domain.Employee) { e.NationalID = " " },
+		"missing name":        func(e *domain.Employee) { e.LastName = "" },
+		"missing job title":   func(e *domain.Employee) { e.JobTitle = "" },
+		"missing hire date":   func(e *domain.Employee) { e.HireDate = time.Time{} },
+		"born after hire":     func(e *domain.Employee) { e.DateOfBirth = &born },
+		"invalid gender":      func(e *domain.Employee) { e.Gender = &gender },
+		"no salary":           func(e *domain.Employee) { e.BaseSalary = 0 },
+		"salary in euros":     func(e *domain.Employee) { e.SalaryCurrency = domain.CurrencyEUR },
+	}
+
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			employee := valid()
+			mutate(employee)
+			assert.Error(t, validateEmployee(employee))
+		})
+	}
+}
@@ -0,0 +1,487 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+// maxSummaryMonths caps the period of the monthly expense summary
+const maxSummaryMonths = 24
+
+type expenseService struct {
+	expenseRepo repositories.ExpenseRepository
+	userRepo    repositories.UserRepository
+}
+
+// NewExpenseService creates a new expense service
+func NewExpenseService(
+	expenseRepo repositories.ExpenseRepository,
+	userRepo repositories.UserRepository,
+) services.ExpenseService {
+	return &expenseService{
+		expenseRepo: expenseRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// CreateExpense registers an expense with the approval chain of the rules its
+// amount reaches; with no rule applying it is approved right away
+func (s *expenseService) CreateExpense(ctx context.Context, req services.CreateExpenseRequest) (*domain.Expense, error) {
+	if !validExpenseCategory(req.Category) {
+		return nil, errors.InvalidInput(fmt.Sprintf("Invalid expense category: %s", req.Category))
+	}
+
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		return nil, errors.InvalidInput("Expense description is required")
+	}
+
+	if req.Amount <= 0 {
+		return nil, errors.InvalidInput("Expense amount must be positive")
+	}
+
+	if req.Currency == "" {
+		req.Currency = domain.CurrencyVES
+	}
+
+	if req.ExchangeRate != nil && *req.ExchangeRate <= 0 {
+		return nil, errors.InvalidInput("Exchange rate must be positive")
+	}
+
+	// Approval thresholds are in VES, so foreign-currency expenses need their rate
+	amount := roundAmount(req.Amount)
+	amountVES, err := convertAmount(amount, req.Currency, domain.CurrencyVES, req.ExchangeRate)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expenseDate := now
+	if req.ExpenseDate != nil {
+		if req.ExpenseDate.After(now) {
+			return nil, errors.InvalidInput("Expense date cannot be in the future")
+		}
+		expenseDate = *req.ExpenseDate
+	}
+
+	store, err := s.expenseRepo.FindStoreByID(ctx, req.StoreID)
+	if err != nil {
+		return nil, err
+	}
+	if !store.IsActive {
+		return nil, errors.InvalidInput(fmt.Sprintf("Store %s is not active", store.Name))
+	}
+
+	if req.SupplierID != nil {
+		if _, err := s.expenseRepo.FindSupplierByID(ctx, *req.SupplierID); err != nil {
+			return nil, err
+		}
+	}
+
+	rules, err := s.expenseRepo.GetActiveApprovalRules(ctx, store.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	expense := &domain.Expense{
+		ExpenseID:     uuid.New(),
+		StoreID:       store.StoreID,
+		Category:      req.Category,
+		Description:   description,
+		Amount:        amount,
+		Currency:      req.Currency,
+		ExchangeRate:  req.ExchangeRate,
+		AmountVES:     amountVES,
+		SupplierID:    req.SupplierID,
+		Reference:     req.Reference,
+		AttachmentURL: req.AttachmentURL,
+		ExpenseDate:   expenseDate,
+		Status:        domain.ExpenseApprovalStatusPending,
+		RequestedBy:   req.UserID,
+		Approvals:     buildApprovalChain(rules, store.StoreID, amountVES),
+	}
+
+	for i := range expense.Approvals {
+		expense.Approvals[i].ExpenseID = expense.ExpenseID
+	}
+
+	if len(expense.Approvals) == 0 {
+		expense.Status = domain.ExpenseApprovalStatusApproved
+		expense.DecidedAt = &now
+	}
+
+	if err := s.expenseRepo.CreateExpense(ctx, expense); err != nil {
+		return nil, err
+	}
+
+	return s.expenseRepo.FindExpenseByID(ctx, expense.ExpenseID)
+}
+
+// GetExpense retrieves an expense with its approval chain
+func (s *expenseService) GetExpense(ctx context.Context, id uuid.UUID) (*domain.Expense, error) {
+	return s.expenseRepo.FindExpenseByID(ctx, id)
+}
+
+// ListExpenses lists expenses matching the filters
+func (s *expenseService) ListExpenses(ctx context.Context, filters repositories.ExpenseFilters, limit, offset int) ([]domain.Expense, int64, error) {
+	return s.expenseRepo.ListExpenses(ctx, filters, limit, offset)
+}
+
+// ApproveExpense approves the next pending level of the expense, which becomes
+// approved once every level is
+func (s *expenseService) ApproveExpense(ctx context.Context, id, userID uuid.UUID, comments *string) (*domain.Expense, error) {
+	return s.decide(ctx, id, userID, domain.ExpenseApprovalStatusApproved, comments)
+}
+
+// RejectExpense rejects the expense at its next pending level, closing it
+func (s *expenseService) RejectExpense(ctx context.Context, id, userID uuid.UUID, comments string) (*domain.Expense, error) {
+	comments = strings.TrimSpace(comments)
+	if comments == "" {
+		return nil, errors.InvalidInput("Comments are required to reject an expense")
+	}
+	return s.decide(ctx, id, userID, domain.ExpenseApprovalStatusRejected, &comments)
+}
+
+// decide records the user's decision on the next pending level of the expense
+func (s *expenseService) decide(ctx context.Context, id, userID uuid.UUID, decision domain.ExpenseApprovalStatus, comments *string) (*domain.Expense, error) {
+	expense, err := s.expenseRepo.FindExpenseByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if expense.Status != domain.ExpenseApprovalStatusPending {
+		return nil, errors.BadRequest(fmt.Sprintf("Expense is already %s", expense.Status))
+	}
+
+	step := expense.NextApproval()
+	if step == nil {
+		return nil, errors.BadRequest("Expense has no pending approval")
+	}
+
+	// Segregation of duties: nobody decides on their own expense, and each
+	// level needs a different approver
+	if expense.RequestedBy == userID {
+		return nil, errors.Forbidden("Expenses cannot be approved or rejected by whoever registered them")
+	}
+	for _, approval := range expense.Approvals {
+		if approval.ApproverID != nil && *approval.ApproverID == userID {
+			return nil, errors.Forbidden("A user can decide on only one level of an expense")
+		}
+	}
+
+	if err := s.authorizeApprover(ctx, userID, step); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	step.Status = decision
+	step.ApproverID = &userID
+	step.Comments = comments
+	step.DecidedAt = &now
+
+	if decision == domain.ExpenseApprovalStatusRejected || expense.NextApproval() == nil {
+		expense.Status = decision
+		expense.DecidedAt = &now
+	}
+
+	action := domain.AuditActionApprove
+	if decision == domain.ExpenseApprovalStatusRejected {
+		action = domain.AuditActionReject
+	}
+
+	requestData := domain.JSONB{
+		"level":          step.Level,
+		"role":           step.RoleName,
+		"expense_status": expense.Status,
+		"amount_ves":     expense.AmountVES,
+	}
+	if comments != nil {
+		requestData["comments"] = *comments
+	}
+
+	audit := &domain.AuditLog{
+		UserID:      &userID,
+		Action:      action,
+		Module:      stringPtr("expenses"),
+		RecordID:    &expense.ExpenseID,
+		Description: stringPtr(fmt.Sprintf("Level %d of expense %s %s", step.Level, expense.ExpenseID, strings.ToLower(string(decision)))),
+		RequestData: requestData,
+	}
+
+	if err := s.expenseRepo.RecordDecision(ctx, expense, step, audit); err != nil {
+		return nil, err
+	}
+
+	return s.expenseRepo.FindExpenseByID(ctx, expense.ExpenseID)
+}
+
+// authorizeApprover verifies the user is active and holds the role the step requires
+func (s *expenseService) authorizeApprover(ctx context.Context, userID uuid.UUID, step *domain.ExpenseApproval) error {
+	approver, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return errors.Forbidden("Approver not found")
+		}
+		return err
+	}
+
+	if approver.Status != domain.UserStatusActive {
+		return errors.Forbidden("Approver is not active")
+	}
+
+	if approver.Role == nil || !strings.EqualFold(approver.Role.RoleName, step.RoleName) {
+		return errors.Forbidden(fmt.Sprintf("Level %d of this expense must be decided by a user with role %s", step.Level, step.RoleName))
+	}
+
+	return nil
+}
+
+// CreateApprovalRule creates an expense approval rule. Expenses already
+// registered keep their approval chain.
+func (s *expenseService) CreateApprovalRule(ctx context.Context, rule *domain.ExpenseApprovalRule) error {
+	if err := s.validateApprovalRule(ctx, rule); err != nil {
+		return err
+	}
+
+	if rule.RuleID == uuid.Nil {
+		rule.RuleID = uuid.New()
+	}
+
+	return s.expenseRepo.CreateApprovalRule(ctx, rule)
+}
+
+// GetApprovalRule retrieves an expense approval rule by ID
+func (s *expenseService) GetApprovalRule(ctx context.Context, id uuid.UUID) (*domain.ExpenseApprovalRule, error) {
+	return s.expenseRepo.FindApprovalRuleByID(ctx, id)
+}
+
+// ListApprovalRules lists every expense approval rule
+func (s *expenseService) ListApprovalRules(ctx context.Context) ([]domain.ExpenseApprovalRule, error) {
+	return s.expenseRepo.ListApprovalRules(ctx)
+}
+
+// UpdateApprovalRule updates an expense approval rule. Expenses already
+// registered keep their approval chain.
+func (s *expenseService) UpdateApprovalRule(ctx context.Context, rule *domain.ExpenseApprovalRule) error {
+	existing, err := s.expenseRepo.FindApprovalRuleByID(ctx, rule.RuleID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.validateApprovalRule(ctx, rule); err != nil {
+		return err
+	}
+
+	rule.CreatedBy = existing.CreatedBy
+	rule.CreatedAt = existing.CreatedAt
+
+	return s.expenseRepo.UpdateApprovalRule(ctx, rule)
+}
+
+// DeleteApprovalRule deletes an expense approval rule. Expenses already
+// registered keep their approval chain.
+func (s *expenseService) DeleteApprovalRule(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.expenseRepo.FindApprovalRuleByID(ctx, id); err != nil {
+		return err
+	}
+	return s.expenseRepo.DeleteApprovalRule(ctx, id)
+}
+
+// GetMonthlySummary totals the approved expenses per month, store and category
+// for the months between from and to
+func (s *expenseService) GetMonthlySummary(ctx context.Context, storeID *uuid.UUID, from, to time.Time) (*services.ExpenseSummary, error) {
+	start := monthStart(from)
+	end := monthStart(to).AddDate(0, 1, 0).Add(-time.Nanosecond)
+
+	if end.Before(start) {
+		return nil, errors.InvalidInput("Summary end cannot be before its start")
+	}
+
+	if start.AddDate(0, maxSummaryMonths, 0).Before(end) {
+		return nil, errors.InvalidInput(fmt.Sprintf("Summary period cannot exceed %d months", maxSummaryMonths))
+	}
+
+	approved := domain.ExpenseApprovalStatusApproved
+	expenses, err := s.expenseRepo.GetExpenses(ctx, repositories.ExpenseFilters{
+		StoreID:  storeID,
+		Status:   &approved,
+		DateFrom: &start,
+		DateTo:   &end,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buildExpenseSummary(expenses, start, end), nil
+}
+
+// Helper functions
+
+func (s *expenseService) validateApprovalRule(ctx context.Context, rule *domain.ExpenseApprovalRule) error {
+	rule.Name = strings.TrimSpace(rule.Name)
+	if rule.Name == "" {
+		return errors.InvalidInput("Rule name is required")
+	}
+
+	rule.RoleName = strings.TrimSpace(rule.RoleName)
+	if rule.RoleName == "" {
+		return errors.InvalidInput("Approver role is required")
+	}
+
+	if rule.Level < 1 {
+		return errors.InvalidInput("Approval level must be at least 1")
+	}
+
+	if rule.MinAmount < 0 {
+		return errors.InvalidInput("Minimum amount cannot be negative")
+	}
+	rule.MinAmount = roundAmount(rule.MinAmount)
+
+	if rule.StoreID != nil {
+		if _, err := s.expenseRepo.FindStoreByID(ctx, *rule.StoreID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validExpenseCategory tells whether the category is one of the known ones
+func validExpenseCategory(category domain.ExpenseCategory) bool {
+	switch category {
+	case domain.ExpenseCategoryRent, domain.ExpenseCategoryUtilities, domain.ExpenseCategorySupplies,
+		domain.ExpenseCategoryMaintenance, domain.ExpenseCategoryTransport, domain.ExpenseCategoryServices,
+		domain.ExpenseCategoryTaxes, domain.ExpenseCategoryOther:
+		return true
+	}
+	return false
+}
+
+// buildApprovalChain picks the steps an expense of the given amount in VES
+// needs: one per level among the active rules it reaches. When several rules
+// share a level, a rule of the store wins over a global one, then the highest
+// threshold wins.
+func buildApprovalChain(rules []domain.ExpenseApprovalRule, storeID uuid.UUID, amountVES float64) []domain.ExpenseApproval {
+	byLevel := make(map[int]*domain.ExpenseApprovalRule)
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.IsActive || amountVES < rule.MinAmount {
+			continue
+		}
+		if rule.StoreID != nil && *rule.StoreID != storeID {
+			continue
+		}
+
+		current, ok := byLevel[rule.Level]
+		if !ok {
+			byLevel[rule.Level] = rule
+			continue
+		}
+
+		ruleScoped, currentScoped := rule.StoreID != nil, current.StoreID != nil
+		if ruleScoped != currentScoped {
+			if ruleScoped {
+				byLevel[rule.Level] = rule
+			}
+			continue
+		}
+		if rule.MinAmount > current.MinAmount {
+			byLevel[rule.Level] = rule
+		}
+	}
+
+	chain := make([]domain.ExpenseApproval, 0, len(byLevel))
+	for level, rule := range byLevel {
+		chain = append(chain, domain.ExpenseApproval{
+			ApprovalID: uuid.New(),
+			RuleID:     &rule.RuleID,
+			Level:      level,
+			RoleName:   rule.RoleName,
+			Status:     domain.ExpenseApprovalStatusPending,
+		})
+	}
+
+	sort.Slice(chain, func(i, j int) bool {
+		return chain[i].Level < chain[j].Level
+	})
+
+	return chain
+}
+
+// monthStart returns the first instant of the month of t
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// buildExpenseSummary groups expenses by month, store and category
+func buildExpenseSummary(expenses []domain.Expense, from, to time.Time) *services.ExpenseSummary {
+	type summaryKey struct {
+		month    time.Time
+		storeID  uuid.UUID
+		category domain.ExpenseCategory
+	}
+
+	summary := &services.ExpenseSummary{
+		From:       from,
+		To:         to,
+		ByCategory: make(map[domain.ExpenseCategory]float64),
+	}
+
+	rows := make(map[summaryKey]*services.ExpenseSummaryRow)
+	for _, expense := range expenses {
+		key := summaryKey{
+			month:    monthStart(expense.ExpenseDate),
+			storeID:  expense.StoreID,
+			category: expense.Category,
+		}
+
+		row, ok := rows[key]
+		if !ok {
+			row = &services.ExpenseSummaryRow{
+				Month:    key.month,
+				StoreID:  expense.StoreID,
+				Category: expense.Category,
+				Amounts:  make(map[domain.CurrencyCode]float64),
+			}
+			if expense.Store != nil {
+				row.StoreName = expense.Store.Name
+			}
+			rows[key] = row
+		}
+
+		row.Expenses++
+		row.Amounts[expense.Currency] = roundAmount(row.Amounts[expense.Currency] + expense.Amount)
+		row.AmountVES = roundAmount(row.AmountVES + expense.AmountVES)
+
+		summary.ByCategory[expense.Category] = roundAmount(summary.ByCategory[expense.Category] + expense.AmountVES)
+		summary.TotalVES = roundAmount(summary.TotalVES + expense.AmountVES)
+	}
+
+	summary.Rows = make([]services.ExpenseSummaryRow, 0, len(rows))
+	for _, row := range rows {
+		summary.Rows = append(summary.Rows, *row)
+	}
+
+	sort.Slice(summary.Rows, func(i, j int) bool {
+		a, b := summary.Rows[i], summary.Rows[j]
+		if !a.Month.Equal(b.Month) {
+			return a.Month.Before(b.Month)
+		}
+		if a.StoreName != b.StoreName {
+			return a.StoreName < b.StoreName
+		}
+		return a.Category < b.Category
+	})
+
+	return summary
+}
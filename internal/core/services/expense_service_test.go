@@ -0,0 +1,111 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+func TestValidExpenseCategory(t *testing.T) {
+	assert.True(t, validExpenseCategory(domain.ExpenseCategoryRent))
+	assert.True(t, validExpenseCategory(domain.ExpenseCategoryOther))
+	assert.False(t, validExpenseCategory("PARTY"))
+	assert.False(t, validExpenseCategory(""))
+}
+
+func TestBuildApprovalChain(t *testing.T) {
+	storeID := uuid.New()
+	otherStore := uuid.New()
+
+	rules := []domain.ExpenseApprovalRule{
+		{RuleID: uuid.New(), Level: 2, RoleName: "ADMIN", MinAmount: 10000, IsActive: true},
+		{RuleID: uuid.New(), Level: 1, RoleName: "SUPERVISOR", MinAmount: 0, IsActive: true},
+		{RuleID: uuid.New(), Level: 1, RoleName: "MANAGER", MinAmount: 500, IsActive: true, StoreID: &storeID},
+		{RuleID: uuid.New(), Level: 1, RoleName: "OWNER", MinAmount: 0, IsActive: true, StoreID: &otherStore},
+		{RuleID: uuid.New(), Level: 3, RoleName: "OWNER", MinAmount: 0, IsActive: false},
+	}
+
+	// Small expenses only reach the global level 1 rule
+	chain := buildApprovalChain(rules, storeID, 100)
+	require.Len(t, chain, 1)
+	assert.Equal(t, "SUPERVISOR", chain[0].RoleName)
+	assert.Equal(t, domain.ExpenseApprovalStatusPending, chain[0].Status)
+
+	// The store's own rule wins at its level, and larger amounts add levels in order
+	chain = buildApprovalChain(rules, storeID, 20000)
+	require.Len(t, chain, 2)
+	assert.Equal(t, 1, chain[0].Level)
+	assert.Equal(t, "MANAGER", chain[0].RoleName)
+	assert.Equal(t, 2, chain[1].Level)
+	assert.Equal(t, "ADMIN", chain[1].RoleName)
+
+	// Without rules an expense needs no approval
+	assert.Empty(t, buildApprovalChain(nil, storeID, 20000))
+}
+
+func TestBuildApprovalChainPrefersHigherThreshold(t *testing.T) {
+	storeID := uuid.New()
+	rules := []domain.ExpenseApprovalRule{
+		{RuleID: uuid.New(), Level: 1, RoleName: "SUPERVISOR", MinAmount: 0, IsActive: true},
+		{RuleID: uuid.New(), Level: 1, RoleName: "MANAGER", MinAmount: 1000, IsActive: true},
+	}
+
+	assert.Equal(t, "SUPERVISOR", buildApprovalChain(rules, storeID, 999.99)[0].RoleName)
+	assert.Equal(t, "MANAGER", buildApprovalChain(rules, storeID, 1000)[0].RoleName)
+}
+
+func TestExpenseNextApproval(t *testing.T) {
+	expense := &domain.Expense{
+		Approvals: []domain.ExpenseApproval{
+			{Level: 1, Status: domain.ExpenseApprovalStatusApproved},
+			{Level: 2, Status: domain.ExpenseApprovalStatusPending},
+		},
+	}
+
+	next := expense.NextApproval()
+	require.NotNil(t, next)
+	assert.Equal(t, 2, next.Level)
+
+	next.Status = domain.ExpenseApprovalStatusApproved
+	assert.Nil(t, expense.NextApproval())
+}
+
+func TestBuildExpenseSummary(t *testing.T) {
+	centro := &domain.Store{StoreID: uuid.New(), Name: "Centro"}
+	este := &domain.Store{StoreID: uuid.New(), Name: "Este"}
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	expenses := []domain.Expense{
+		{StoreID: este.StoreID, Store: este, Category: domain.ExpenseCategoryRent, Amount: 500, Currency: domain.CurrencyUSD, AmountVES: 18000, ExpenseDate: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)},
+		{StoreID: centro.StoreID, Store: centro, Category: domain.ExpenseCategoryUtilities, Amount: 1200, Currency: domain.CurrencyVES, AmountVES: 1200, ExpenseDate: time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)},
+		{StoreID: centro.StoreID, Store: centro, Category: domain.ExpenseCategoryUtilities, Amount: 10, Currency: domain.CurrencyUSD, AmountVES: 360, ExpenseDate: time.Date(2024, 5, 28, 0, 0, 0, 0, time.UTC)},
+		{StoreID: centro.StoreID, Store: centro, Category: domain.ExpenseCategoryRent, Amount: 700, Currency: domain.CurrencyUSD, AmountVES: 25550, ExpenseDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	summary := buildExpenseSummary(expenses, from, to)
+
+	require.Len(t, summary.Rows, 3)
+
+	// Rows come by month, then store name, then category
+	first := summary.Rows[0]
+	assert.Equal(t, from, first.Month)
+	assert.Equal(t, "Centro", first.StoreName)
+	assert.Equal(t, domain.ExpenseCategoryUtilities, first.Category)
+	assert.Equal(t, 2, first.Expenses)
+	assert.Equal(t, 1200.0, first.Amounts[domain.CurrencyVES])
+	assert.Equal(t, 10.0, first.Amounts[domain.CurrencyUSD])
+	assert.Equal(t, 1560.0, first.AmountVES)
+
+	assert.Equal(t, "Este", summary.Rows[1].StoreName)
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), summary.Rows[2].Month)
+
+	assert.Equal(t, 43550.0, summary.ByCategory[domain.ExpenseCategoryRent])
+	assert.Equal(t, 1560.0, summary.ByCategory[domain.ExpenseCategoryUtilities])
+	assert.Equal(t, 45110.0, summary.TotalVES)
+}
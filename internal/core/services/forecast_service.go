@@ -0,0 +1,453 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+const (
+	// defaultHistorySeasons is the number of past seasons used when a request does not specify it
+	defaultHistorySeasons = 3
+
+	// maxHistorySeasons bounds the history a forecast can look back on
+	maxHistorySeasons = 10
+)
+
+type demandForecastService struct {
+	forecastRepo repositories.DemandForecastRepository
+	productRepo  repositories.ProductRepository
+	listRepo     repositories.SchoolSupplyListRepository
+	db           *gorm.DB
+}
+
+// NewDemandForecastService creates a new demand forecast service
+func NewDemandForecastService(
+	forecastRepo repositories.DemandForecastRepository,
+	productRepo repositories.ProductRepository,
+	listRepo repositories.SchoolSupplyListRepository,
+	db *gorm.DB,
+) services.DemandForecastService {
+	return &demandForecastService{
+		forecastRepo: forecastRepo,
+		productRepo:  productRepo,
+		listRepo:     listRepo,
+		db:           db,
+	}
+}
+
+// GenerateForecasts forecasts the season demand of each product from the sales of past
+// seasons, the published lists of the season and the open reservations, and saves them
+func (s *demandForecastService) GenerateForecasts(ctx context.Context, req services.GenerateForecastRequest) ([]domain.DemandForecast, error) {
+	if err := validateSchoolYear(req.SchoolYear); err != nil {
+		return nil, err
+	}
+
+	if req.Method != nil && *req.Method != domain.ForecastMethodMovingAverage && *req.Method != domain.ForecastMethodSeasonalNaive {
+		return nil, errors.InvalidInput(fmt.Sprintf("Invalid forecast method: %s", *req.Method))
+	}
+
+	if req.DefaultEnrollment < 0 {
+		return nil, errors.InvalidInput("Default enrollment cannot be negative")
+	}
+
+	seasons := req.HistorySeasons
+	if seasons <= 0 {
+		seasons = defaultHistorySeasons
+	}
+	if seasons > maxHistorySeasons {
+		return nil, errors.InvalidInput(fmt.Sprintf("At most %d past seasons can be considered", maxHistorySeasons))
+	}
+
+	listDemand, err := s.seasonListDemand(ctx, req.SchoolYear, req.DefaultEnrollment)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := s.forecastProducts(ctx, req.ProductIDs, listDemand)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) == 0 {
+		return nil, errors.InvalidInput("No products to forecast")
+	}
+
+	productIDs := make([]uuid.UUID, len(products))
+	for i, product := range products {
+		productIDs[i] = product.ProductID
+	}
+
+	// Sales of the past seasons, oldest first
+	pastYears := pastSchoolYears(req.SchoolYear, seasons)
+	sold := make([]map[uuid.UUID]float64, len(pastYears))
+	for i, year := range pastYears {
+		from, to := schoolSeasonWindow(year)
+		quantities, err := s.forecastRepo.GetQuantitiesSold(ctx, from, to, productIDs)
+		if err != nil {
+			return nil, err
+		}
+		sold[i] = quantities
+	}
+
+	reserved, err := s.forecastRepo.GetOpenReservedQuantities(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	forecasts := make([]domain.DemandForecast, 0, len(products))
+	for _, product := range products {
+		history := make([]float64, len(pastYears))
+		seasonData := make(map[string]interface{}, len(pastYears))
+		for i, year := range pastYears {
+			history[i] = sold[i][product.ProductID]
+			seasonData[year] = history[i]
+		}
+
+		active := trimLeadingZeros(history)
+		method := chooseForecastMethod(product, active, req.Method)
+
+		statistical := 0.0
+		if method == domain.ForecastMethodSeasonalNaive {
+			statistical = seasonalNaive(active)
+		} else {
+			statistical = movingAverage(active)
+		}
+
+		quantity := combineForecast(statistical, listDemand[product.ProductID], reserved[product.ProductID])
+		confidence := forecastConfidence(active, seasons)
+		methodName := string(method)
+
+		forecasts = append(forecasts, domain.DemandForecast{
+			ForecastID:         uuid.New(),
+			ProductID:          product.ProductID,
+			SchoolYear:         req.SchoolYear,
+			ForecastedQuantity: quantity,
+			ConfidenceLevel:    &confidence,
+			ForecastMethod:     &methodName,
+			HistoricalData: domain.JSONB{
+				"seasons":           seasonData,
+				"moving_average":    roundAmount(movingAverage(active)),
+				"seasonal_naive":    seasonalNaive(active),
+				"list_demand":       listDemand[product.ProductID],
+				"open_reservations": reserved[product.ProductID],
+				"seasonal_demand":   product.SeasonalDemand,
+			},
+			CreatedBy: &req.UserID,
+			Product:   product,
+		})
+	}
+
+	if err := s.forecastRepo.CreateBatch(ctx, forecasts); err != nil {
+		return nil, err
+	}
+
+	return forecasts, nil
+}
+
+// GetForecast retrieves a demand forecast by ID
+func (s *demandForecastService) GetForecast(ctx context.Context, id uuid.UUID) (*domain.DemandForecast, error) {
+	return s.forecastRepo.FindByID(ctx, id)
+}
+
+// ListForecasts lists demand forecasts with filters and pagination
+func (s *demandForecastService) ListForecasts(ctx context.Context, filters repositories.DemandForecastFilters, limit, offset int) ([]domain.DemandForecast, int64, error) {
+	return s.forecastRepo.List(ctx, filters, limit, offset)
+}
+
+// EvaluateForecasts records the actual sales of an ended season on its latest
+// forecasts and reports their accuracy
+func (s *demandForecastService) EvaluateForecasts(ctx context.Context, schoolYear string) (*services.ForecastEvaluation, error) {
+	if err := validateSchoolYear(schoolYear); err != nil {
+		return nil, err
+	}
+
+	from, to := schoolSeasonWindow(schoolYear)
+	now := time.Now()
+	if now.Before(to) {
+		return nil, errors.InvalidInput(fmt.Sprintf("The %s season has not ended yet", schoolYear))
+	}
+
+	forecasts, err := s.forecastRepo.GetLatestBySchoolYear(ctx, schoolYear)
+	if err != nil {
+		return nil, err
+	}
+	if len(forecasts) == 0 {
+		return nil, errors.NotFound(fmt.Sprintf("Demand forecasts for %s", schoolYear))
+	}
+
+	productIDs := make([]uuid.UUID, len(forecasts))
+	for i, forecast := range forecasts {
+		productIDs[i] = forecast.ProductID
+	}
+
+	sold, err := s.forecastRepo.GetQuantitiesSold(ctx, from, to, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]services.ForecastAccuracy, 0, len(forecasts))
+	for i := range forecasts {
+		forecast := &forecasts[i]
+		actual := int(math.Round(sold[forecast.ProductID]))
+
+		forecast.ActualQuantity = &actual
+		forecast.EvaluatedAt = &now
+		if err := s.forecastRepo.Update(ctx, forecast); err != nil {
+			return nil, err
+		}
+
+		item := services.ForecastAccuracy{
+			ForecastID: forecast.ForecastID,
+			ProductID:  forecast.ProductID,
+			Forecasted: forecast.ForecastedQuantity,
+			Actual:     actual,
+		}
+		if forecast.Product != nil {
+			item.ProductName = forecast.Product.Name
+		}
+		if forecast.ForecastMethod != nil {
+			item.Method = *forecast.ForecastMethod
+		}
+		items = append(items, item)
+	}
+
+	evaluation := evaluateForecasts(items)
+	evaluation.SchoolYear = schoolYear
+	return evaluation, nil
+}
+
+// Helper functions
+
+// seasonListDemand adds up the required items of the season's published lists
+// times the students expected to buy each list
+func (s *demandForecastService) seasonListDemand(ctx context.Context, schoolYear string, defaultEnrollment int) (map[uuid.UUID]float64, error) {
+	isTemplate := false
+	lists, _, err := s.listRepo.List(ctx, repositories.SchoolSupplyListFilters{
+		SchoolYear: &schoolYear,
+		Statuses:   []domain.SchoolListStatus{domain.SchoolListStatusPublished, domain.SchoolListStatusActive},
+		IsTemplate: &isTemplate,
+	}, 1000, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	demand := make(map[uuid.UUID]float64)
+	for _, list := range lists {
+		enrollment := defaultEnrollment
+		if list.ExpectedEnrollment != nil {
+			enrollment = *list.ExpectedEnrollment
+		}
+		if enrollment <= 0 {
+			continue
+		}
+
+		items, err := s.listRepo.GetItems(ctx, list.ListID)
+		if err != nil {
+			return nil, err
+		}
+
+		for productID, quantity := range listItemDemand(items, enrollment) {
+			demand[productID] += quantity
+		}
+	}
+
+	return demand, nil
+}
+
+// forecastProducts loads the requested products or, when none are given, the
+// seasonal products plus those required by the season's lists
+func (s *demandForecastService) forecastProducts(ctx context.Context, productIDs []uuid.UUID, listDemand map[uuid.UUID]float64) ([]*domain.Product, error) {
+	var products []*domain.Product
+
+	if len(productIDs) > 0 {
+		for _, id := range productIDs {
+			product, err := s.productRepo.FindByID(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			products = append(products, product)
+		}
+		return products, nil
+	}
+
+	seasonal := true
+	status := domain.ProductStatusActive
+	seasonalProducts, _, err := s.productRepo.List(ctx, repositories.ProductFilters{
+		SeasonalDemand: &seasonal,
+		Status:         &status,
+	}, 1000, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool, len(seasonalProducts))
+	for i := range seasonalProducts {
+		products = append(products, &seasonalProducts[i])
+		seen[seasonalProducts[i].ProductID] = true
+	}
+
+	listed := make([]uuid.UUID, 0, len(listDemand))
+	for id := range listDemand {
+		if !seen[id] {
+			listed = append(listed, id)
+		}
+	}
+	sort.Slice(listed, func(i, j int) bool { return listed[i].String() < listed[j].String() })
+
+	for _, id := range listed {
+		product, err := s.productRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+
+	return products, nil
+}
+
+// schoolSeasonWindow returns the back-to-school selling season of a school
+// year: from July 1 to October 31 of its first year
+func schoolSeasonWindow(schoolYear string) (time.Time, time.Time) {
+	start, _ := strconv.Atoi(schoolYear[:4])
+	from := time.Date(start, time.July, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(start, time.November, 1, 0, 0, 0, 0, time.Local).Add(-time.Nanosecond)
+	return from, to
+}
+
+// pastSchoolYears returns the given number of school years before a school year, oldest first
+func pastSchoolYears(schoolYear string, count int) []string {
+	start, _ := strconv.Atoi(schoolYear[:4])
+	years := make([]string, count)
+	for i := 0; i < count; i++ {
+		year := start - count + i
+		years[i] = fmt.Sprintf("%d-%d", year, year+1)
+	}
+	return years
+}
+
+// trimLeadingZeros drops the seasons before the product was first sold, so new
+// products are not averaged against seasons they were not on sale
+func trimLeadingZeros(history []float64) []float64 {
+	for i, quantity := range history {
+		if quantity > 0 {
+			return history[i:]
+		}
+	}
+	return nil
+}
+
+// movingAverage is the mean of the seasons in the history
+func movingAverage(history []float64) float64 {
+	if len(history) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, quantity := range history {
+		total += quantity
+	}
+	return total / float64(len(history))
+}
+
+// seasonalNaive repeats the last season
+func seasonalNaive(history []float64) float64 {
+	if len(history) == 0 {
+		return 0
+	}
+	return history[len(history)-1]
+}
+
+// chooseForecastMethod uses the forced method if any. Otherwise seasonal products
+// repeat their last season, while the rest are averaged to smooth out noise.
+func chooseForecastMethod(product *domain.Product, history []float64, forced *domain.ForecastMethod) domain.ForecastMethod {
+	if forced != nil {
+		return *forced
+	}
+	if product.SeasonalDemand && len(history) > 0 {
+		return domain.ForecastMethodSeasonalNaive
+	}
+	return domain.ForecastMethodMovingAverage
+}
+
+// listItemDemand returns the units the required items of a list need for the enrollment
+func listItemDemand(items []domain.SchoolSupplyListItem, enrollment int) map[uuid.UUID]float64 {
+	demand := make(map[uuid.UUID]float64)
+	for _, item := range items {
+		if item.IsOptional {
+			continue
+		}
+		demand[item.ProductID] += float64(item.Quantity * enrollment)
+	}
+	return demand
+}
+
+// combineForecast takes the largest of the estimates. Past sales, list enrollment
+// and open reservations each measure the same season demand from a different
+// source, so each one is a floor for the forecast rather than an addend.
+func combineForecast(statistical, lists, reserved float64) int {
+	return int(math.Ceil(math.Max(statistical, math.Max(lists, reserved))))
+}
+
+// forecastConfidence scores a forecast from 0 to 100. It grows with the share of
+// seasons with data and drops with the variation between them.
+func forecastConfidence(history []float64, seasons int) float64 {
+	if len(history) == 0 || seasons <= 0 {
+		return 0
+	}
+
+	mean := movingAverage(history)
+	variance := 0.0
+	for _, quantity := range history {
+		variance += (quantity - mean) * (quantity - mean)
+	}
+	variance /= float64(len(history))
+
+	variation := 1.0
+	if mean > 0 {
+		variation = math.Min(math.Sqrt(variance)/mean, 1)
+	}
+
+	coverage := float64(len(history)) / float64(seasons)
+	return roundAmount(100 * coverage * (1 - variation/2))
+}
+
+// evaluateForecasts fills the errors of each item and the overall accuracy
+func evaluateForecasts(items []services.ForecastAccuracy) *services.ForecastEvaluation {
+	evaluation := &services.ForecastEvaluation{Items: items}
+	if len(items) == 0 {
+		return evaluation
+	}
+
+	totalError := 0
+	totalPercent := 0.0
+	withSales := 0
+	for i := range items {
+		item := &items[i]
+		item.Error = item.Actual - item.Forecasted
+		totalError += item.Error
+
+		if item.Actual > 0 {
+			percent := roundAmount(math.Abs(float64(item.Error)) / float64(item.Actual) * 100)
+			item.AbsPercentError = &percent
+			totalPercent += percent
+			withSales++
+		}
+	}
+
+	evaluation.Bias = roundAmount(float64(totalError) / float64(len(items)))
+	if withSales > 0 {
+		evaluation.MAPE = float64Ptr(roundAmount(totalPercent / float64(withSales)))
+	}
+
+	return evaluation
+}
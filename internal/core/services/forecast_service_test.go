@@ -0,0 +1,96 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+func TestSchoolSeasonWindow(t *testing.T) {
+	from, to := schoolSeasonWindow("2025-2026")
+	assert.Equal(t, time.Date(2025, time.July, 1, 0, 0, 0, 0, time.Local), from)
+	assert.Equal(t, time.October, to.Month())
+	assert.Equal(t, 31, to.Day())
+	assert.Equal(t, 2025, to.Year())
+}
+
+func TestPastSchoolYears(t *testing.T) {
+	assert.Equal(t, []string{"2022-2023", "2023-2024", "2024-2025"}, pastSchoolYears("2025-2026", 3))
+}
+
+func TestForecastMethods(t *testing.T) {
+	history := trimLeadingZeros([]float64{0, 0, 100, 140})
+	assert.Equal(t, []float64{100, 140}, history)
+	assert.Equal(t, 120.0, movingAverage(history))
+	assert.Equal(t, 140.0, seasonalNaive(history))
+
+	assert.Nil(t, trimLeadingZeros([]float64{0, 0}))
+	assert.Equal(t, 0.0, movingAverage(nil))
+	assert.Equal(t, 0.0, seasonalNaive(nil))
+}
+
+func TestChooseForecastMethod(t *testing.T) {
+	seasonal := &domain.Product{SeasonalDemand: true}
+	regular := &domain.Product{}
+
+	assert.Equal(t, domain.ForecastMethodSeasonalNaive, chooseForecastMethod(seasonal, []float64{10}, nil))
+	assert.Equal(t, domain.ForecastMethodMovingAverage, chooseForecastMethod(seasonal, nil, nil))
+	assert.Equal(t, domain.ForecastMethodMovingAverage, chooseForecastMethod(regular, []float64{10}, nil))
+
+	forced := domain.ForecastMethodMovingAverage
+	assert.Equal(t, forced, chooseForecastMethod(seasonal, []float64{10}, &forced))
+}
+
+func TestListItemDemand(t *testing.T) {
+	notebook := uuid.New()
+	crayons := uuid.New()
+	items := []domain.SchoolSupplyListItem{
+		{ProductID: notebook, Quantity: 4},
+		{ProductID: notebook, Quantity: 1},
+		{ProductID: crayons, Quantity: 1, IsOptional: true},
+	}
+
+	demand := listItemDemand(items, 30)
+	assert.Equal(t, 150.0, demand[notebook])
+	_, ok := demand[crayons]
+	assert.False(t, ok)
+}
+
+func TestCombineForecast(t *testing.T) {
+	assert.Equal(t, 121, combineForecast(120.2, 80, 10))
+	assert.Equal(t, 150, combineForecast(120, 150, 10))
+	assert.Equal(t, 40, combineForecast(0, 0, 40))
+}
+
+func TestForecastConfidence(t *testing.T) {
+	assert.Equal(t, 0.0, forecastConfidence(nil, 3))
+	assert.Equal(t, 100.0, forecastConfidence([]float64{50, 50, 50}, 3))
+	assert.Less(t, forecastConfidence([]float64{20, 80, 50}, 3), 100.0)
+	assert.Less(t, forecastConfidence([]float64{50}, 3), forecastConfidence([]float64{50, 50, 50}, 3))
+}
+
+func TestEvaluateForecasts(t *testing.T) {
+	items := []services.ForecastAccuracy{
+		{Forecasted: 100, Actual: 120},
+		{Forecasted: 50, Actual: 40},
+		{Forecasted: 10, Actual: 0},
+	}
+
+	evaluation := evaluateForecasts(items)
+	require.Len(t, evaluation.Items, 3)
+	assert.Equal(t, 20, evaluation.Items[0].Error)
+	require.NotNil(t, evaluation.Items[0].AbsPercentError)
+	assert.Equal(t, 16.67, *evaluation.Items[0].AbsPercentError)
+	assert.Equal(t, 25.0, *evaluation.Items[1].AbsPercentError)
+	assert.Nil(t, evaluation.Items[2].AbsPercentError)
+
+	require.NotNil(t, evaluation.MAPE)
+	assert.Equal(t, 20.84, *evaluation.MAPE)
+	assert.Equal(t, 0.0, evaluation.Bias)
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"log"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -15,6 +16,7 @@ import (
 type inventoryService struct {
 	inventoryRepo repositories.InventoryRepository
 	productRepo   repositories.ProductRepository
+	preOrderSvc   services.PreOrderService
 	db            *gorm.DB
 }
 
@@ -22,11 +24,13 @@ type inventoryService struct {
 func NewInventoryService(
 	inventoryRepo repositories.InventoryRepository,
 	productRepo repositories.ProductRepository,
+	preOrderSvc services.PreOrderService,
 	db *gorm.DB,
 ) services.InventoryService {
 	return &inventoryService{
 		inventoryRepo: inventoryRepo,
 		productRepo:   productRepo,
+		preOrderSvc:   preOrderSvc,
 		db:            db,
 	}
 }
@@ -89,7 +93,19 @@ func (s *inventoryService) RegisterInboundMovement(
 		CreatedBy:     &userID,
 	}
 
-	return s.inventoryRepo.CreateMovement(ctx, movement)
+	if err := s.inventoryRepo.CreateMovement(ctx, movement); err != nil {
+		return err
+	}
+
+	// Flag the pre-orders waiting for this stock. The movement is already
+	// recorded, so a failure here must not undo it.
+	if s.preOrderSvc != nil {
+		if _, err := s.preOrderSvc.HandleInboundStock(ctx, productID, warehouseID); err != nil {
+			log.Printf("[ERROR] Failed to update pre-orders for product %s: %v", productID, err)
+		}
+	}
+
+	return nil
 }
 
 // RegisterOutboundMovement registers an outbound inventory movement
@@ -0,0 +1,555 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+const (
+	maxImportLines         = 500
+	maxImportCandidates    = 3
+	minCandidateConfidence = 0.3
+	autoMatchConfidence    = 0.6
+	maxNameConfidence      = 0.95 // Name matches never rank as high as a code match
+)
+
+var (
+	bulletPattern        = regexp.MustCompile(`^\s*(?:[-*•·]+|\d+[.)])\s+`)
+	leadingQtyPattern    = regexp.MustCompile(`^(\d+)\s*(?:[x×]\s+|unidades?\s+(?:de\s+)?)?(.+)$`)
+	trailingQtyPattern   = regexp.MustCompile(`^(.+?)\s*(?:[x×]\s*(\d+)|\((\d+)\))$`)
+	optionalPattern      = regexp.MustCompile(`(?i)\(?\s*opcional\s*\)?`)
+	codePattern          = regexp.MustCompile(`^[A-Za-z0-9-]*\d[A-Za-z0-9-]*$`)
+	tokenSplitPattern    = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+	accentReplacer       = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n")
+	importStopwords      = map[string]bool{"de": true, "del": true, "con": true, "para": true, "la": true, "el": true, "los": true, "las": true, "y": true, "o": true, "x": true, "en": true, "un": true, "una": true, "tipo": true, "marca": true}
+	spanishNumberWords   = map[string]int{"un": 1, "una": 1, "uno": 1, "dos": 2, "tres": 3, "cuatro": 4, "cinco": 5, "seis": 6, "siete": 7, "ocho": 8, "nueve": 9, "diez": 10, "once": 11, "doce": 12, "docena": 12, "quince": 15, "veinte": 20}
+	csvQuantityHeaders   = []string{"cantidad", "cant", "qty", "quantity"}
+	csvDescriptionHeader = []string{"descripcion", "producto", "articulo", "item", "description", "material"}
+	csvSKUHeaders        = []string{"sku", "codigo", "code"}
+	csvBarcodeHeaders    = []string{"codigo de barras", "barcode", "ean", "upc"}
+	csvOptionalHeaders   = []string{"opcional", "optional"}
+)
+
+type listImportService struct {
+	productRepo repositories.ProductRepository
+	listSvc     services.SchoolSupplyListService
+	db          *gorm.DB
+}
+
+// NewListImportService creates a new school supply list importer
+func NewListImportService(
+	productRepo repositories.ProductRepository,
+	listSvc services.SchoolSupplyListService,
+	db *gorm.DB,
+) services.ListImportService {
+	return &listImportService{
+		productRepo: productRepo,
+		listSvc:     listSvc,
+		db:          db,
+	}
+}
+
+// PreviewImport parses the document and proposes products for every line.
+// Nothing is saved; staff confirms the mappings with ConfirmImport.
+func (s *listImportService) PreviewImport(ctx context.Context, format domain.ListImportFormat, content string) (*services.ListImportPreview, error) {
+	var lines []services.ImportedLine
+	var err error
+
+	switch format {
+	case domain.ListImportFormatCSV:
+		lines, err = parseCSVList(content)
+	case domain.ListImportFormatText:
+		lines = parseTextList(content)
+	default:
+		return nil, errors.InvalidInput(fmt.Sprintf("Invalid import format %s", format))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 {
+		return nil, errors.InvalidInput("The document does not contain any list line")
+	}
+
+	if len(lines) > maxImportLines {
+		return nil, errors.InvalidInput(fmt.Sprintf("The document exceeds the maximum of %d lines", maxImportLines))
+	}
+
+	preview := &services.ListImportPreview{
+		Format:    format,
+		Lines:     lines,
+		Unmatched: make([]services.ImportedLine, 0),
+	}
+
+	for i := range preview.Lines {
+		line := &preview.Lines[i]
+		if err := s.matchLine(ctx, line); err != nil {
+			return nil, err
+		}
+		if !line.Matched {
+			preview.Unmatched = append(preview.Unmatched, *line)
+		}
+	}
+
+	return preview, nil
+}
+
+// ConfirmImport creates a draft list from the mappings confirmed by staff
+func (s *listImportService) ConfirmImport(ctx context.Context, req services.ConfirmListImportRequest) (*domain.SchoolSupplyList, error) {
+	if len(req.Mappings) == 0 {
+		return nil, errors.InvalidInput("At least one confirmed line is required")
+	}
+
+	// The same product may come from several lines of the source document
+	items := make([]domain.SchoolSupplyListItem, 0, len(req.Mappings))
+	index := make(map[uuid.UUID]int, len(req.Mappings))
+	for _, mapping := range req.Mappings {
+		if mapping.Quantity <= 0 {
+			return nil, errors.InvalidInput(fmt.Sprintf("Quantity of line %d must be positive", mapping.LineNumber))
+		}
+
+		if i, exists := index[mapping.ProductID]; exists {
+			items[i].Quantity += mapping.Quantity
+			items[i].IsOptional = items[i].IsOptional && mapping.IsOptional
+			continue
+		}
+
+		displayOrder := mapping.LineNumber
+		index[mapping.ProductID] = len(items)
+		items = append(items, domain.SchoolSupplyListItem{
+			ProductID:           mapping.ProductID,
+			Quantity:            mapping.Quantity,
+			IsOptional:          mapping.IsOptional,
+			AlternativesAllowed: true,
+			Notes:               mapping.Notes,
+			DisplayOrder:        &displayOrder,
+		})
+	}
+
+	if err := s.listSvc.CreateList(ctx, req.List, items); err != nil {
+		return nil, err
+	}
+
+	return s.listSvc.GetList(ctx, req.List.ListID)
+}
+
+// Helper functions
+
+// matchLine fills the candidates of a line, trying codes first and then the name
+func (s *listImportService) matchLine(ctx context.Context, line *services.ImportedLine) error {
+	codes := make([]string, 0, 2)
+	if line.SKU != nil {
+		codes = append(codes, *line.SKU)
+	}
+	if line.Barcode != nil {
+		codes = append(codes, *line.Barcode)
+	}
+	if codePattern.MatchString(line.Description) {
+		codes = append(codes, line.Description)
+	}
+
+	for _, code := range codes {
+		match, err := s.matchCode(ctx, code)
+		if err != nil {
+			return err
+		}
+		if match != nil {
+			line.Candidates = []services.ProductMatch{*match}
+			line.Matched = true
+			return nil
+		}
+	}
+
+	if line.Description == "" {
+		return nil
+	}
+
+	// SearchByName is a substring search, so look up the most specific words separately
+	found := make(map[uuid.UUID]domain.Product)
+	for _, term := range importSearchTerms(line.Description) {
+		products, _, err := s.productRepo.SearchByName(ctx, term, 20, 0)
+		if err != nil {
+			return err
+		}
+		for _, product := range products {
+			if product.Status == domain.ProductStatusActive {
+				found[product.ProductID] = product
+			}
+		}
+	}
+
+	descTokens := importTokens(line.Description)
+	candidates := make([]services.ProductMatch, 0, len(found))
+	for _, product := range found {
+		confidence := math.Min(nameSimilarity(descTokens, importTokens(product.Name)), maxNameConfidence)
+		if confidence < minCandidateConfidence {
+			continue
+		}
+		candidates = append(candidates, services.ProductMatch{
+			ProductID:    product.ProductID,
+			ProductName:  product.Name,
+			SKU:          product.SKU,
+			SellingPrice: product.SellingPrice,
+			Confidence:   roundAmount(confidence),
+			MatchedBy:    "NAME",
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Confidence != candidates[j].Confidence {
+			return candidates[i].Confidence > candidates[j].Confidence
+		}
+		return candidates[i].ProductName < candidates[j].ProductName
+	})
+	if len(candidates) > maxImportCandidates {
+		candidates = candidates[:maxImportCandidates]
+	}
+
+	line.Candidates = candidates
+	line.Matched = len(candidates) > 0 && candidates[0].Confidence >= autoMatchConfidence
+	return nil
+}
+
+// matchCode looks a code up as SKU and then as barcode
+func (s *listImportService) matchCode(ctx context.Context, code string) (*services.ProductMatch, error) {
+	product, err := s.productRepo.FindBySKU(ctx, code)
+	matchedBy := "SKU"
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		product, err = s.productRepo.FindByBarcode(ctx, code)
+		matchedBy = "BARCODE"
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+	}
+
+	return &services.ProductMatch{
+		ProductID:    product.ProductID,
+		ProductName:  product.Name,
+		SKU:          product.SKU,
+		SellingPrice: product.SellingPrice,
+		Confidence:   1,
+		MatchedBy:    matchedBy,
+	}, nil
+}
+
+// parseTextList reads one list line per text line, e.g. "2 cuadernos de 100 hojas doble línea"
+func parseTextList(content string) []services.ImportedLine {
+	lines := make([]services.ImportedLine, 0)
+	for number, raw := range strings.Split(content, "\n") {
+		text := strings.TrimSpace(raw)
+		if text == "" {
+			continue
+		}
+
+		line := services.ImportedLine{LineNumber: number + 1, Raw: text, Quantity: 1}
+
+		text = bulletPattern.ReplaceAllString(text, "")
+		if optionalPattern.MatchString(text) {
+			line.IsOptional = true
+			text = optionalPattern.ReplaceAllString(text, " ")
+		}
+		text = strings.TrimSpace(text)
+
+		line.Quantity, text = splitQuantity(text)
+		line.Description = strings.Trim(text, " .,;:-")
+		if line.Description == "" {
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// splitQuantity separates a leading or trailing quantity from the description
+func splitQuantity(text string) (int, string) {
+	if m := leadingQtyPattern.FindStringSubmatch(text); m != nil {
+		if qty, err := strconv.Atoi(m[1]); err == nil && qty > 0 {
+			return qty, m[2]
+		}
+	}
+
+	words := strings.Fields(text)
+	if len(words) > 1 {
+		first := accentReplacer.Replace(strings.ToLower(words[0]))
+		if first == "media" && len(words) > 2 && strings.ToLower(words[1]) == "docena" {
+			return 6, strings.TrimPrefix(strings.Join(words[2:], " "), "de ")
+		}
+		if qty, ok := spanishNumberWords[first]; ok {
+			rest := strings.Join(words[1:], " ")
+			if first == "docena" {
+				rest = strings.TrimPrefix(rest, "de ")
+			}
+			return qty, rest
+		}
+	}
+
+	if m := trailingQtyPattern.FindStringSubmatch(text); m != nil {
+		digits := m[2]
+		if digits == "" {
+			digits = m[3]
+		}
+		if qty, err := strconv.Atoi(digits); err == nil && qty > 0 {
+			return qty, m[1]
+		}
+	}
+
+	return 1, text
+}
+
+// parseCSVList reads a spreadsheet export. A header row is recognized by its
+// column names; without one the columns are taken as quantity and description.
+func parseCSVList(content string) ([]services.ImportedLine, error) {
+	content = strings.TrimPrefix(content, "\ufeff")
+	firstLine := content
+	if idx := strings.IndexByte(content, '\n'); idx >= 0 {
+		firstLine = content[:idx]
+	}
+
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.Comma = detectCSVDelimiter(firstLine)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records := make([][]string, 0)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.InvalidInputWithDetails("Invalid CSV document", err.Error())
+		}
+		records = append(records, record)
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns, hasHeader := csvColumns(records[0])
+	start := 0
+	if hasHeader {
+		start = 1
+	}
+
+	lines := make([]services.ImportedLine, 0, len(records))
+	for i := start; i < len(records); i++ {
+		record := records[i]
+		cell := func(col int) string {
+			if col < 0 || col >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[col])
+		}
+
+		line := services.ImportedLine{
+			LineNumber:  i + 1,
+			Raw:         strings.Join(record, string(reader.Comma)),
+			Quantity:    1,
+			Description: cell(columns["description"]),
+		}
+
+		if qtyText := cell(columns["quantity"]); qtyText != "" {
+			if qty, err := strconv.ParseFloat(strings.ReplaceAll(qtyText, ",", "."), 64); err == nil && qty > 0 {
+				line.Quantity = int(math.Ceil(qty))
+			}
+		}
+
+		if sku := cell(columns["sku"]); sku != "" {
+			line.SKU = &sku
+		}
+		if barcode := cell(columns["barcode"]); barcode != "" {
+			line.Barcode = &barcode
+		}
+
+		optional := accentReplacer.Replace(strings.ToLower(cell(columns["optional"])))
+		line.IsOptional = optional == "si" || optional == "yes" || optional == "x" || optional == "true" || optional == "1"
+
+		if optionalPattern.MatchString(line.Description) {
+			line.IsOptional = true
+			line.Description = strings.TrimSpace(optionalPattern.ReplaceAllString(line.Description, " "))
+		}
+
+		if line.Description == "" && line.SKU == nil && line.Barcode == nil {
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+// csvColumns maps column roles to indexes from the header row, or falls back
+// to the quantity,description layout when the row is not a header
+func csvColumns(header []string) (map[string]int, bool) {
+	columns := map[string]int{"quantity": -1, "description": -1, "sku": -1, "barcode": -1, "optional": -1}
+	found := false
+
+	for i, name := range header {
+		normalized := strings.TrimSpace(accentReplacer.Replace(strings.ToLower(name)))
+		role := ""
+		switch {
+		case containsString(csvBarcodeHeaders, normalized):
+			role = "barcode"
+		case containsString(csvQuantityHeaders, normalized):
+			role = "quantity"
+		case containsString(csvDescriptionHeader, normalized):
+			role = "description"
+		case containsString(csvSKUHeaders, normalized):
+			role = "sku"
+		case containsString(csvOptionalHeaders, normalized):
+			role = "optional"
+		}
+		if role != "" && columns[role] < 0 {
+			columns[role] = i
+			found = true
+		}
+	}
+
+	if found {
+		return columns, true
+	}
+
+	// No header: quantity first when the first cell is a number
+	columns["quantity"], columns["description"] = 0, 1
+	if len(header) > 0 {
+		if _, err := strconv.ParseFloat(strings.TrimSpace(header[0]), 64); err != nil {
+			columns["quantity"], columns["description"] = 1, 0
+		}
+	}
+	return columns, false
+}
+
+func detectCSVDelimiter(line string) rune {
+	best, bestCount := ',', strings.Count(line, ",")
+	for _, candidate := range []rune{';', '\t'} {
+		if count := strings.Count(line, string(candidate)); count > bestCount {
+			best, bestCount = candidate, count
+		}
+	}
+	return best
+}
+
+// importSearchTerms returns the longest words of a description, singularized, to query by name
+func importSearchTerms(description string) []string {
+	words := tokenSplitPattern.Split(strings.ToLower(description), -1)
+	terms := make([]string, 0, len(words))
+	seen := make(map[string]bool)
+	for _, word := range words {
+		if len([]rune(word)) < 3 || importStopwords[accentReplacer.Replace(word)] {
+			continue
+		}
+		if _, err := strconv.Atoi(word); err == nil {
+			continue
+		}
+		term := singularize(word)
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+
+	sort.SliceStable(terms, func(i, j int) bool {
+		return len([]rune(terms[i])) > len([]rune(terms[j]))
+	})
+	if len(terms) > 3 {
+		terms = terms[:3]
+	}
+	return terms
+}
+
+// importTokens normalizes a text into comparable words: lowercase, without
+// accents, stopwords or plural endings
+func importTokens(text string) []string {
+	words := tokenSplitPattern.Split(accentReplacer.Replace(strings.ToLower(text)), -1)
+	tokens := make([]string, 0, len(words))
+	for _, word := range words {
+		if word == "" || importStopwords[word] {
+			continue
+		}
+		tokens = append(tokens, singularize(word))
+	}
+	return tokens
+}
+
+// singularize strips common Spanish plural endings (lápices -> lápiz, colores -> color)
+func singularize(word string) string {
+	runes := []rune(word)
+	n := len(runes)
+	switch {
+	case n > 4 && strings.HasSuffix(word, "ces"):
+		return string(runes[:n-3]) + "z"
+	case n > 4 && strings.HasSuffix(word, "es") && strings.ContainsRune("rlndj", runes[n-3]):
+		return string(runes[:n-2])
+	case n > 3 && strings.HasSuffix(word, "s"):
+		return string(runes[:n-1])
+	}
+	return word
+}
+
+// nameSimilarity is a Dice coefficient over normalized tokens, where a shared
+// prefix of at least four letters counts as a partial match
+func nameSimilarity(line, product []string) float64 {
+	if len(line) == 0 || len(product) == 0 {
+		return 0
+	}
+
+	used := make([]bool, len(product))
+	score := 0.0
+	for _, token := range line {
+		best, bestIdx := 0.0, -1
+		for i, candidate := range product {
+			if used[i] {
+				continue
+			}
+			weight := 0.0
+			switch {
+			case token == candidate:
+				weight = 1
+			case len(token) >= 4 && len(candidate) >= 4 && (strings.HasPrefix(candidate, token) || strings.HasPrefix(token, candidate)):
+				weight = 0.75
+			}
+			if weight > best {
+				best, bestIdx = weight, i
+			}
+		}
+		if bestIdx >= 0 {
+			used[bestIdx] = true
+			score += best
+		}
+	}
+
+	return 2 * score / float64(len(line)+len(product))
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
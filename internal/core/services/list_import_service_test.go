@@ -0,0 +1,101 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTextList(t *testing.T) {
+	content := "2 cuadernos de 100 hojas doble línea\n" +
+		"\n" +
+		"- Lápiz de grafito x3\n" +
+		"1. una caja de colores (opcional)\n" +
+		"Tijera punta roma (2)\n" +
+		"Regla 30 cm\n"
+
+	lines := parseTextList(content)
+	require.Len(t, lines, 5)
+
+	assert.Equal(t, 1, lines[0].LineNumber)
+	assert.Equal(t, 2, lines[0].Quantity)
+	assert.Equal(t, "cuadernos de 100 hojas doble línea", lines[0].Description)
+
+	assert.Equal(t, 3, lines[1].LineNumber)
+	assert.Equal(t, 3, lines[1].Quantity)
+	assert.Equal(t, "Lápiz de grafito", lines[1].Description)
+
+	assert.Equal(t, 1, lines[2].Quantity)
+	assert.Equal(t, "caja de colores", lines[2].Description)
+	assert.True(t, lines[2].IsOptional)
+
+	assert.Equal(t, 2, lines[3].Quantity)
+	assert.Equal(t, "Tijera punta roma", lines[3].Description)
+
+	assert.Equal(t, 1, lines[4].Quantity)
+	assert.Equal(t, "Regla 30 cm", lines[4].Description)
+}
+
+func TestParseCSVList(t *testing.T) {
+	t.Run("with header", func(t *testing.T) {
+		content := "Cantidad;Descripción;Código;Opcional\n" +
+			"2;Cuaderno 100 hojas;CUA-100;\n" +
+			"1;Caja de creyones;;sí\n"
+
+		lines, err := parseCSVList(content)
+		require.NoError(t, err)
+		require.Len(t, lines, 2)
+
+		assert.Equal(t, 2, lines[0].LineNumber)
+		assert.Equal(t, 2, lines[0].Quantity)
+		assert.Equal(t, "Cuaderno 100 hojas", lines[0].Description)
+		require.NotNil(t, lines[0].SKU)
+		assert.Equal(t, "CUA-100", *lines[0].SKU)
+		assert.False(t, lines[0].IsOptional)
+
+		assert.Nil(t, lines[1].SKU)
+		assert.True(t, lines[1].IsOptional)
+	})
+
+	t.Run("without header", func(t *testing.T) {
+		lines, err := parseCSVList("3,Lápices\n1,Borrador\n")
+		require.NoError(t, err)
+		require.Len(t, lines, 2)
+		assert.Equal(t, 1, lines[0].LineNumber)
+		assert.Equal(t, 3, lines[0].Quantity)
+		assert.Equal(t, "Lápices", lines[0].Description)
+
+		lines, err = parseCSVList("Borrador,2\n")
+		require.NoError(t, err)
+		require.Len(t, lines, 1)
+		assert.Equal(t, 2, lines[0].Quantity)
+		assert.Equal(t, "Borrador", lines[0].Description)
+	})
+}
+
+func TestSingularize(t *testing.T) {
+	assert.Equal(t, "lápiz", singularize("lápices"))
+	assert.Equal(t, "color", singularize("colores"))
+	assert.Equal(t, "cuaderno", singularize("cuadernos"))
+	assert.Equal(t, "hoja", singularize("hojas"))
+	assert.Equal(t, "regla", singularize("regla"))
+}
+
+func TestNameSimilarity(t *testing.T) {
+	line := importTokens("cuadernos de 100 hojas doble línea")
+
+	exact := nameSimilarity(line, importTokens("Cuaderno 100 Hojas Doble Línea"))
+	otherSize := nameSimilarity(line, importTokens("Cuaderno 200 Hojas Doble Línea"))
+	unrelated := nameSimilarity(line, importTokens("Tijera Punta Roma"))
+
+	assert.Equal(t, 1.0, exact)
+	assert.Greater(t, exact, otherSize)
+	assert.Greater(t, otherSize, autoMatchConfidence)
+	assert.Equal(t, 0.0, unrelated)
+}
+
+func TestImportSearchTerms(t *testing.T) {
+	terms := importSearchTerms("2 cuadernos de 100 hojas doble línea")
+	assert.Equal(t, []string{"cuaderno", "doble", "línea"}, terms)
+}
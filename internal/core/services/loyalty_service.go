@@ -0,0 +1,508 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type loyaltyService struct {
+	loyaltyRepo  repositories.LoyaltyRepository
+	customerRepo repositories.CustomerRepository
+	saleRepo     repositories.SaleRepository
+	db           *gorm.DB
+}
+
+// NewLoyaltyService creates a new loyalty service
+func NewLoyaltyService(
+	loyaltyRepo repositories.LoyaltyRepository,
+	customerRepo repositories.CustomerRepository,
+	saleRepo repositories.SaleRepository,
+	db *gorm.DB,
+) services.LoyaltyService {
+	return &loyaltyService{
+		loyaltyRepo:  loyaltyRepo,
+		customerRepo: customerRepo,
+		saleRepo:     saleRepo,
+		db:           db,
+	}
+}
+
+// CreateRule validates and creates a new earn rule
+func (s *loyaltyService) CreateRule(ctx context.Context, rule *domain.LoyaltyRule) error {
+	if err := s.validateRule(ctx, rule); err != nil {
+		return err
+	}
+
+	if rule.RuleID == uuid.Nil {
+		rule.RuleID = uuid.New()
+	}
+
+	return s.loyaltyRepo.CreateRule(ctx, rule)
+}
+
+// GetRule retrieves an earn rule by ID
+func (s *loyaltyService) GetRule(ctx context.Context, id uuid.UUID) (*domain.LoyaltyRule, error) {
+	return s.loyaltyRepo.FindRuleByID(ctx, id)
+}
+
+// ListRules lists earn rules with filters
+func (s *loyaltyService) ListRules(ctx context.Context, filters repositories.LoyaltyRuleFilters, limit, offset int) ([]domain.LoyaltyRule, int64, error) {
+	return s.loyaltyRepo.ListRules(ctx, filters, limit, offset)
+}
+
+// UpdateRule updates an earn rule
+func (s *loyaltyService) UpdateRule(ctx context.Context, rule *domain.LoyaltyRule) error {
+	existing, err := s.loyaltyRepo.FindRuleByID(ctx, rule.RuleID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.validateRule(ctx, rule); err != nil {
+		return err
+	}
+
+	rule.CreatedAt = existing.CreatedAt
+	rule.CreatedBy = existing.CreatedBy
+
+	return s.loyaltyRepo.UpdateRule(ctx, rule)
+}
+
+// DeleteRule deletes an earn rule. Points already earned are not affected.
+func (s *loyaltyService) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.loyaltyRepo.FindRuleByID(ctx, id); err != nil {
+		return err
+	}
+	return s.loyaltyRepo.DeleteRule(ctx, id)
+}
+
+// QuoteRedemption returns the value of the given points in the requested currency
+func (s *loyaltyService) QuoteRedemption(ctx context.Context, customerID uuid.UUID, points int, currency domain.CurrencyCode) (float64, error) {
+	if points <= 0 {
+		return 0, errors.InvalidInput("Points to redeem must be positive")
+	}
+
+	customer, err := s.customerRepo.FindByID(ctx, customerID)
+	if err != nil {
+		return 0, err
+	}
+
+	if customer.LoyaltyPoints < points {
+		return 0, errors.BadRequest(fmt.Sprintf("Insufficient loyalty points. Available: %d, Requested: %d", customer.LoyaltyPoints, points))
+	}
+
+	rules, err := s.loyaltyRepo.GetActiveRules(ctx, currency)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, rule := range rules {
+		if rule.CategoryID == nil && rule.PointValue != nil {
+			return roundAmount(float64(points) * *rule.PointValue), nil
+		}
+	}
+
+	return 0, errors.InvalidInput(fmt.Sprintf("Loyalty points cannot be redeemed in %s", currency))
+}
+
+// RedeemForSale debits points and records them as a tender of the sale
+func (s *loyaltyService) RedeemForSale(ctx context.Context, saleID uuid.UUID, points int, userID uuid.UUID) (*domain.LoyaltyTransaction, error) {
+	sale, err := s.saleRepo.FindByID(ctx, saleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sale.CustomerID == nil {
+		return nil, errors.InvalidInput("Customer is required to redeem loyalty points")
+	}
+
+	value, err := s.QuoteRedemption(ctx, *sale.CustomerID, points, sale.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	if value > roundAmount(sale.TotalAmount-sale.TenderedAmount()) {
+		return nil, errors.BadRequest("Redeemed amount exceeds the sale total")
+	}
+
+	currency := sale.Currency
+	txn := &domain.LoyaltyTransaction{
+		TransactionID:   uuid.New(),
+		CustomerID:      *sale.CustomerID,
+		TransactionType: domain.LoyaltyTransactionTypeRedeem,
+		Points:          -points,
+		Amount:          &value,
+		Currency:        &currency,
+		SaleID:          &sale.SaleID,
+		Description:     stringPtr(fmt.Sprintf("Redeemed on sale %s", sale.InvoiceNumber)),
+		CreatedBy:       &userID,
+	}
+
+	tender := &domain.SaleTender{
+		TenderID:   uuid.New(),
+		SaleID:     sale.SaleID,
+		TenderType: domain.TenderTypeLoyaltyPoints,
+		Amount:     value,
+		Currency:   sale.Currency,
+		Reference:  stringPtr(txn.TransactionID.String()),
+	}
+
+	if err := s.loyaltyRepo.CreateRedemption(ctx, txn, tender); err != nil {
+		return nil, err
+	}
+
+	return txn, nil
+}
+
+// EarnForSale credits the points earned by a completed sale, one lot per applied rule
+func (s *loyaltyService) EarnForSale(ctx context.Context, saleID uuid.UUID, userID *uuid.UUID) ([]domain.LoyaltyTransaction, error) {
+	sale, err := s.saleRepo.FindByID(ctx, saleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sale.CustomerID == nil || sale.Status != domain.SaleStatusCompleted {
+		return nil, nil
+	}
+
+	existing, err := s.loyaltyRepo.FindTransactionsBySale(ctx, saleID)
+	if err != nil {
+		return nil, err
+	}
+	for _, txn := range existing {
+		if txn.TransactionType == domain.LoyaltyTransactionTypeEarn {
+			return nil, errors.Conflict("Loyalty points were already earned for this sale")
+		}
+	}
+
+	rules, err := s.loyaltyRepo.GetActiveRules(ctx, sale.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// The part paid with points does not earn new points
+	ratio := 1.0
+	if sale.TotalAmount > 0 {
+		redeemed := 0.0
+		for _, tender := range sale.Tenders {
+			if tender.TenderType == domain.TenderTypeLoyaltyPoints {
+				redeemed += tender.Amount
+			}
+		}
+		ratio = math.Max(0, 1-redeemed/sale.TotalAmount)
+	}
+
+	earned := make([]domain.LoyaltyTransaction, 0)
+	for _, lot := range calculateEarnedPoints(sale.Details, rules, ratio) {
+		amount := roundAmount(lot.amount)
+		currency := sale.Currency
+		ruleID := lot.rule.RuleID
+
+		txn := domain.LoyaltyTransaction{
+			TransactionID:   uuid.New(),
+			CustomerID:      *sale.CustomerID,
+			TransactionType: domain.LoyaltyTransactionTypeEarn,
+			Points:          lot.points,
+			Amount:          &amount,
+			Currency:        &currency,
+			SaleID:          &sale.SaleID,
+			RuleID:          &ruleID,
+			Description:     stringPtr(fmt.Sprintf("Earned on sale %s (%s)", sale.InvoiceNumber, lot.rule.Name)),
+			CreatedBy:       userID,
+		}
+		if lot.rule.ExpiryDays != nil {
+			txn.ExpiresAt = timePtr(sale.SaleDate.AddDate(0, 0, *lot.rule.ExpiryDays))
+		}
+
+		if err := s.loyaltyRepo.CreateTransaction(ctx, &txn); err != nil {
+			return earned, err
+		}
+		earned = append(earned, txn)
+	}
+
+	return earned, nil
+}
+
+// ReverseSale undoes the loyalty effects of a returned or cancelled sale:
+// redeemed points are refunded and earned points are taken back
+func (s *loyaltyService) ReverseSale(ctx context.Context, saleID uuid.UUID, userID *uuid.UUID) error {
+	txns, err := s.loyaltyRepo.FindTransactionsBySale(ctx, saleID)
+	if err != nil {
+		return err
+	}
+
+	for _, txn := range txns {
+		if txn.TransactionType == domain.LoyaltyTransactionTypeReturn {
+			return nil
+		}
+	}
+
+	// Refund redemptions first so the balance can absorb the earn reversal
+	for _, txn := range txns {
+		if txn.TransactionType != domain.LoyaltyTransactionTypeRedeem {
+			continue
+		}
+		refund := &domain.LoyaltyTransaction{
+			TransactionID:       uuid.New(),
+			CustomerID:          txn.CustomerID,
+			TransactionType:     domain.LoyaltyTransactionTypeReturn,
+			Points:              -txn.Points,
+			SaleID:              &saleID,
+			SourceTransactionID: &txn.TransactionID,
+			Description:         stringPtr("Refund of points redeemed on returned sale"),
+			CreatedBy:           userID,
+		}
+		if err := s.loyaltyRepo.CreateTransaction(ctx, refund); err != nil {
+			return err
+		}
+	}
+
+	for _, txn := range txns {
+		if txn.TransactionType != domain.LoyaltyTransactionTypeEarn {
+			continue
+		}
+
+		customer, err := s.customerRepo.FindByID(ctx, txn.CustomerID)
+		if err != nil {
+			return err
+		}
+
+		// Points already spent elsewhere cannot be taken back
+		points := txn.Points
+		if points > customer.LoyaltyPoints {
+			points = customer.LoyaltyPoints
+		}
+		if points <= 0 {
+			continue
+		}
+
+		reversal := &domain.LoyaltyTransaction{
+			TransactionID:       uuid.New(),
+			CustomerID:          txn.CustomerID,
+			TransactionType:     domain.LoyaltyTransactionTypeReturn,
+			Points:              -points,
+			SaleID:              &saleID,
+			SourceTransactionID: &txn.TransactionID,
+			Description:         stringPtr("Reversal of points earned on returned sale"),
+			CreatedBy:           userID,
+		}
+		if err := s.loyaltyRepo.CreateTransaction(ctx, reversal); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AdjustPoints records a manual correction of a customer balance
+func (s *loyaltyService) AdjustPoints(ctx context.Context, req services.AdjustPointsRequest) (*domain.LoyaltyTransaction, error) {
+	if req.Points == 0 {
+		return nil, errors.InvalidInput("Points must not be zero")
+	}
+
+	if req.Reason == "" {
+		return nil, errors.InvalidInput("Reason is required for loyalty adjustments")
+	}
+
+	if _, err := s.customerRepo.FindByID(ctx, req.CustomerID); err != nil {
+		return nil, err
+	}
+
+	txn := &domain.LoyaltyTransaction{
+		TransactionID:   uuid.New(),
+		CustomerID:      req.CustomerID,
+		TransactionType: domain.LoyaltyTransactionTypeAdjust,
+		Points:          req.Points,
+		Description:     &req.Reason,
+		CreatedBy:       &req.UserID,
+	}
+
+	if err := s.loyaltyRepo.CreateTransaction(ctx, txn); err != nil {
+		return nil, err
+	}
+
+	return txn, nil
+}
+
+// ExpirePoints expires the unused points of every lot past its expiry date
+func (s *loyaltyService) ExpirePoints(ctx context.Context, at time.Time) (int, error) {
+	lots, err := s.loyaltyRepo.GetExpiredLots(ctx, at)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, lot := range lots {
+		txn := &domain.LoyaltyTransaction{
+			TransactionID:       uuid.New(),
+			CustomerID:          lot.CustomerID,
+			TransactionType:     domain.LoyaltyTransactionTypeExpire,
+			Points:              -lot.RemainingPoints,
+			SourceTransactionID: &lot.TransactionID,
+			Description:         stringPtr(fmt.Sprintf("Expiration of points earned on %s", lot.CreatedAt.Format("2006-01-02"))),
+		}
+		if err := s.loyaltyRepo.CreateTransaction(ctx, txn); err != nil {
+			// Log error but continue processing
+			log.Printf("[ERROR] Failed to expire loyalty points %s: %v", lot.TransactionID, err)
+			continue
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// GetStatement builds the points statement of a customer for a period
+func (s *loyaltyService) GetStatement(ctx context.Context, customerID uuid.UUID, from, to time.Time) (*services.LoyaltyStatement, error) {
+	if to.Before(from) {
+		return nil, errors.InvalidInput("End date must be after start date")
+	}
+
+	if _, err := s.customerRepo.FindByID(ctx, customerID); err != nil {
+		return nil, err
+	}
+
+	opening, err := s.loyaltyRepo.GetBalanceAt(ctx, customerID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	txns, err := s.loyaltyRepo.ListTransactions(ctx, customerID, &from, &to)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &services.LoyaltyStatement{
+		CustomerID:     customerID,
+		From:           from,
+		To:             to,
+		OpeningBalance: opening,
+		ClosingBalance: opening,
+		Transactions:   txns,
+	}
+
+	for _, txn := range txns {
+		statement.ClosingBalance += txn.Points
+		switch txn.TransactionType {
+		case domain.LoyaltyTransactionTypeEarn:
+			statement.TotalEarned += txn.Points
+		case domain.LoyaltyTransactionTypeRedeem:
+			statement.TotalRedeemed -= txn.Points
+		case domain.LoyaltyTransactionTypeExpire:
+			statement.TotalExpired -= txn.Points
+		case domain.LoyaltyTransactionTypeAdjust:
+			statement.TotalAdjusted += txn.Points
+		case domain.LoyaltyTransactionTypeReturn:
+			statement.TotalReturned += txn.Points
+		}
+	}
+
+	return statement, nil
+}
+
+// Helper functions
+
+func (s *loyaltyService) validateRule(ctx context.Context, rule *domain.LoyaltyRule) error {
+	if rule.Name == "" {
+		return errors.InvalidInput("Rule name is required")
+	}
+
+	if rule.SpendAmount <= 0 {
+		return errors.InvalidInput("Spend amount must be positive")
+	}
+
+	if rule.Points <= 0 {
+		return errors.InvalidInput("Points must be positive")
+	}
+
+	if rule.ExpiryDays != nil && *rule.ExpiryDays <= 0 {
+		return errors.InvalidInput("Expiry days must be positive")
+	}
+
+	if rule.PointValue != nil {
+		if *rule.PointValue <= 0 {
+			return errors.InvalidInput("Point value must be positive")
+		}
+		if rule.CategoryID != nil {
+			return errors.InvalidInput("Point value can only be set on general rules")
+		}
+	}
+
+	if rule.Currency == "" {
+		rule.Currency = domain.CurrencyVES
+	}
+
+	if rule.CategoryID != nil {
+		var category domain.Category
+		if err := s.db.WithContext(ctx).First(&category, "category_id = ?", *rule.CategoryID).Error; err != nil {
+			return errors.NotFoundWithID("Category", rule.CategoryID.String())
+		}
+	}
+
+	return nil
+}
+
+// earnedLot is the amount and points accumulated under a single rule
+type earnedLot struct {
+	rule   *domain.LoyaltyRule
+	amount float64
+	points int
+}
+
+// calculateEarnedPoints groups the earnable amount of each line by the most
+// specific matching rule (category first, then general) and converts it to points
+func calculateEarnedPoints(details []domain.SaleDetail, rules []domain.LoyaltyRule, ratio float64) []earnedLot {
+	var general *domain.LoyaltyRule
+	byCategory := make(map[uuid.UUID]*domain.LoyaltyRule)
+	for i := range rules {
+		rule := &rules[i]
+		if rule.CategoryID == nil {
+			if general == nil {
+				general = rule
+			}
+		} else if _, exists := byCategory[*rule.CategoryID]; !exists {
+			byCategory[*rule.CategoryID] = rule
+		}
+	}
+
+	lots := make([]earnedLot, 0)
+	index := make(map[uuid.UUID]int)
+	for _, detail := range details {
+		rule := general
+		if detail.Product != nil && detail.Product.CategoryID != nil {
+			if categoryRule, ok := byCategory[*detail.Product.CategoryID]; ok {
+				rule = categoryRule
+			}
+		}
+		if rule == nil {
+			continue
+		}
+
+		i, exists := index[rule.RuleID]
+		if !exists {
+			i = len(lots)
+			index[rule.RuleID] = i
+			lots = append(lots, earnedLot{rule: rule})
+		}
+		lots[i].amount += detail.Subtotal * ratio
+	}
+
+	result := make([]earnedLot, 0, len(lots))
+	for _, lot := range lots {
+		lot.points = int(math.Floor(lot.amount/lot.rule.SpendAmount)) * lot.rule.Points
+		if lot.points > 0 {
+			result = append(result, lot)
+		}
+	}
+	return result
+}
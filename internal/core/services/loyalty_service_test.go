@@ -0,0 +1,53 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+func TestCalculateEarnedPoints_CategoryRuleTakesPrecedence(t *testing.T) {
+	books := uuid.New()
+	general := domain.LoyaltyRule{RuleID: uuid.New(), Name: "General", SpendAmount: 10, Points: 1}
+	bookRule := domain.LoyaltyRule{RuleID: uuid.New(), Name: "Books", CategoryID: &books, SpendAmount: 10, Points: 3}
+
+	details := []domain.SaleDetail{
+		{Subtotal: 25, Product: &domain.Product{CategoryID: &books}},
+		{Subtotal: 18, Product: &domain.Product{}},
+		{Subtotal: 7, Product: &domain.Product{}},
+	}
+
+	lots := calculateEarnedPoints(details, []domain.LoyaltyRule{general, bookRule}, 1)
+
+	require.Len(t, lots, 2)
+	assert.Equal(t, bookRule.RuleID, lots[0].rule.RuleID)
+	assert.Equal(t, 6, lots[0].points)
+	// Amounts under the same rule are accumulated before rounding down
+	assert.Equal(t, general.RuleID, lots[1].rule.RuleID)
+	assert.Equal(t, 25.0, lots[1].amount)
+	assert.Equal(t, 2, lots[1].points)
+}
+
+func TestCalculateEarnedPoints_RedeemedPartDoesNotEarn(t *testing.T) {
+	general := domain.LoyaltyRule{RuleID: uuid.New(), Name: "General", SpendAmount: 10, Points: 1}
+	details := []domain.SaleDetail{{Subtotal: 100, Product: &domain.Product{}}}
+
+	lots := calculateEarnedPoints(details, []domain.LoyaltyRule{general}, 0.75)
+
+	require.Len(t, lots, 1)
+	assert.Equal(t, 7, lots[0].points)
+}
+
+func TestCalculateEarnedPoints_NoMatchingRule(t *testing.T) {
+	toys := uuid.New()
+	toyRule := domain.LoyaltyRule{RuleID: uuid.New(), Name: "Toys", CategoryID: &toys, SpendAmount: 5, Points: 1}
+	details := []domain.SaleDetail{{Subtotal: 50, Product: &domain.Product{}}}
+
+	lots := calculateEarnedPoints(details, []domain.LoyaltyRule{toyRule}, 1)
+
+	assert.Empty(t, lots)
+}
@@ -72,7 +72,7 @@ func (s *notificationService) SendReservationConfirmation(ctx context.Context, r
 			"Su reserva %s ha sido creada exitosamente.\n\n"+
 			"Detalles:\n"+
 			"- Monto Total: %.2f %s\n"+
-			"- Depósito: %.2f %s\n"+
+			"- Depósito Requerido: %.2f %s\n"+
 			"- Saldo: %.2f %s\n"+
 			"- Fecha de Vencimiento: %s\n\n"+
 			"Gracias por su preferencia,\n"+
@@ -206,6 +206,9 @@ func (s *notificationService) SendPreOrderReady(ctx context.Context, preOrderID
 		preOrder.TotalAmount-preOrder.DepositPaid,
 		preOrder.Currency,
 	)
+	if preOrder.PickupDeadline != nil {
+		message += fmt.Sprintf("\n\nFecha límite de retiro: %s", preOrder.PickupDeadline.Format("02/01/2006"))
+	}
 
 	// Create notification record
 	notification := &domain.CustomerNotification{
@@ -232,6 +235,77 @@ func (s *notificationService) SendPreOrderReady(ctx context.Context, preOrderID
 	return nil
 }
 
+// SendInstallmentReminder reminds the customer of an installment falling due
+func (s *notificationService) SendInstallmentReminder(ctx context.Context, receivableID uuid.UUID) error {
+	var installment domain.AccountsReceivable
+	err := s.db.WithContext(ctx).
+		Preload("Sale").
+		Preload("InstallmentPlan").
+		First(&installment, "receivable_id = ?", receivableID).Error
+	if err != nil {
+		return errors.NotFoundWithID("AccountsReceivable", receivableID.String())
+	}
+	if installment.InstallmentPlan == nil || installment.InstallmentNumber == nil {
+		return errors.InvalidInput("Receivable is not an installment")
+	}
+
+	customer, err := s.customerRepo.FindByID(ctx, installment.CustomerID)
+	if err != nil {
+		return err
+	}
+
+	invoice := ""
+	if installment.Sale != nil {
+		invoice = installment.Sale.InvoiceNumber
+	}
+
+	message := fmt.Sprintf(
+		"Estimado/a %s,\n\n"+
+			"RECORDATORIO: La cuota %d de %d de su compra %s vence pronto.\n\n"+
+			"Fecha de vencimiento: %s\n"+
+			"Monto de la cuota: %.2f %s\n"+
+			"Saldo pendiente de la cuota: %.2f %s\n\n"+
+			"Por favor, realice su pago antes de la fecha de vencimiento.\n\n"+
+			"Gracias,\n"+
+			"Bazaar Araira",
+		getCustomerName(customer),
+		*installment.InstallmentNumber,
+		installment.InstallmentPlan.InstallmentCount,
+		invoice,
+		installment.DueDate.Format("02/01/2006"),
+		installment.TotalAmount,
+		installment.Currency,
+		installment.Balance,
+		installment.Currency,
+	)
+
+	// Create notification record
+	notification := &domain.CustomerNotification{
+		NotificationID:   uuid.New(),
+		CustomerID:       customer.CustomerID,
+		NotificationType: domain.NotificationTypeEmail,
+		Status:           domain.NotificationStatusPending,
+		Subject:          stringPtr("Recordatorio de Cuota"),
+		Message:          message,
+		ReferenceType:    stringPtr("RECEIVABLE"),
+		ReferenceID:      &receivableID,
+		ScheduledAt:      timePtr(time.Now()),
+	}
+
+	if err := s.db.WithContext(ctx).Create(notification).Error; err != nil {
+		return errors.WrapError(err, "failed to create notification")
+	}
+
+	log.Printf("[NOTIFICATION] Installment Reminder - Customer: %s, Sale: %s, Installment: %d/%d",
+		getCustomerName(customer),
+		invoice,
+		*installment.InstallmentNumber,
+		installment.InstallmentPlan.InstallmentCount,
+	)
+
+	return nil
+}
+
 // SendCustomNotification sends a custom notification
 func (s *notificationService) SendCustomNotification(
 	ctx context.Context,
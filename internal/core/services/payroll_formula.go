@@ -0,0 +1,173 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jadiazinf/inventory/internal/common/errors"
+)
+
+// Variables payroll formulas can use
+const (
+	formulaBaseSalary   = "BaseSalary"   // Monthly salary of the employee
+	formulaPeriodSalary = "PeriodSalary" // Salary earned in the period
+	formulaWorkedDays   = "WorkedDays"   // Days of the period the employee was employed
+	formulaPeriodDays   = "PeriodDays"   // Days of the period
+)
+
+// sampleFormulaVars are used to check a formula when its concept is saved
+var sampleFormulaVars = map[string]float64{
+	formulaBaseSalary:   1000,
+	formulaPeriodSalary: 500,
+	formulaWorkedDays:   15,
+	formulaPeriodDays:   15,
+}
+
+// evaluateFormula computes an arithmetic expression with numbers, the payroll
+// variables, + - * / and parentheses. Variable names are case-insensitive.
+func evaluateFormula(formula string, vars map[string]float64) (float64, error) {
+	p := &formulaParser{input: formula, vars: vars}
+
+	value, err := p.parseExpression()
+	if err != nil {
+		return 0, err
+	}
+
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return 0, p.errorf("unexpected %q", p.input[p.pos])
+	}
+
+	return value, nil
+}
+
+// formulaParser is a recursive-descent parser that evaluates as it parses
+type formulaParser struct {
+	input string
+	pos   int
+	vars  map[string]float64
+}
+
+// parseExpression parses terms joined by + and -
+func (p *formulaParser) parseExpression() (float64, error) {
+	value, err := p.parseTerm()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.input) || (p.input[p.pos] != '+' && p.input[p.pos] != '-') {
+			return value, nil
+		}
+		op := p.input[p.pos]
+		p.pos++
+
+		rhs, err := p.parseTerm()
+		if err != nil {
+			return 0, err
+		}
+		if op == '+' {
+			value += rhs
+		} else {
+			value -= rhs
+		}
+	}
+}
+
+// parseTerm parses factors joined by * and /
+func (p *formulaParser) parseTerm() (float64, error) {
+	value, err := p.parseFactor()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.input) || (p.input[p.pos] != '*' && p.input[p.pos] != '/') {
+			return value, nil
+		}
+		op := p.input[p.pos]
+		p.pos++
+
+		rhs, err := p.parseFactor()
+		if err != nil {
+			return 0, err
+		}
+		if op == '*' {
+			value *= rhs
+		} else {
+			if rhs == 0 {
+				return 0, p.errorf("division by zero")
+			}
+			value /= rhs
+		}
+	}
+}
+
+// parseFactor parses a number, a variable, a parenthesized expression or a negation
+func (p *formulaParser) parseFactor() (float64, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return 0, p.errorf("unexpected end of formula")
+	}
+
+	c := p.input[p.pos]
+	switch {
+	case c == '-':
+		p.pos++
+		value, err := p.parseFactor()
+		return -value, err
+
+	case c == '(':
+		p.pos++
+		value, err := p.parseExpression()
+		if err != nil {
+			return 0, err
+		}
+		p.skipSpaces()
+		if p.pos >= len(p.input) || p.input[p.pos] != ')' {
+			return 0, p.errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return value, nil
+
+	case c == '.' || unicode.IsDigit(rune(c)):
+		start := p.pos
+		for p.pos < len(p.input) && (p.input[p.pos] == '.' || unicode.IsDigit(rune(p.input[p.pos]))) {
+			p.pos++
+		}
+		value, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+		if err != nil {
+			return 0, p.errorf("invalid number %q", p.input[start:p.pos])
+		}
+		return value, nil
+
+	case unicode.IsLetter(rune(c)):
+		start := p.pos
+		for p.pos < len(p.input) && (unicode.IsLetter(rune(p.input[p.pos])) || unicode.IsDigit(rune(p.input[p.pos]))) {
+			p.pos++
+		}
+		name := p.input[start:p.pos]
+		for key, value := range p.vars {
+			if strings.EqualFold(key, name) {
+				return value, nil
+			}
+		}
+		return 0, p.errorf("unknown variable %s", name)
+	}
+
+	return 0, p.errorf("unexpected %q", c)
+}
+
+func (p *formulaParser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *formulaParser) errorf(format string, args ...interface{}) error {
+	return errors.InvalidInput(fmt.Sprintf("Invalid formula %q: %s", p.input, fmt.Sprintf(format, args...)))
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateFormula(t *testing.T) {
+	vars := map[string]float64{
+		formulaBaseSalary:   1200,
+		formulaPeriodSalary: 600,
+		formulaWorkedDays:   10,
+		formulaPeriodDays:   15,
+	}
+
+	tests := []struct {
+		formula string
+		want    float64
+	}{
+		{"PeriodSalary * 0.04", 24},
+		{"BaseSalary / 30 * WorkedDays", 400},
+		{"(BaseSalary + 300) * 0.1", 150},
+		{"2 + 3 * 4", 14},
+		{"-5 + 10", 5},
+		{"periodsalary*.5", 300},
+		{"  40  ", 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.formula, func(t *testing.T) {
+			got, err := evaluateFormula(tt.formula, vars)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
+
+func TestEvaluateFormulaErrors(t *testing.T) {
+	for _, formula := range []string{
+		"",
+		"Bonus * 2",
+		"BaseSalary *",
+		"(BaseSalary + 1",
+		"BaseSalary / 0",
+		"BaseSalary 2",
+		"BaseSalary % 2",
+		"1..2",
+	} {
+		t.Run(formula, func(t *testing.T) {
+			_, err := evaluateFormula(formula, sampleFormulaVars)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,564 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+// maxPayrollPeriodDays caps the length of a pay period
+const maxPayrollPeriodDays = 31
+
+type payrollService struct {
+	payrollRepo repositories.PayrollRepository
+}
+
+// NewPayrollService creates a new payroll service
+func NewPayrollService(payrollRepo repositories.PayrollRepository) services.PayrollService {
+	return &payrollService{
+		payrollRepo: payrollRepo,
+	}
+}
+
+// CreateConcept creates a payroll concept
+func (s *payrollService) CreateConcept(ctx context.Context, concept *domain.PayrollConcept) error {
+	if err := s.validateConcept(ctx, concept); err != nil {
+		return err
+	}
+
+	if concept.ConceptID == uuid.Nil {
+		concept.ConceptID = uuid.New()
+	}
+
+	return s.payrollRepo.CreateConcept(ctx, concept)
+}
+
+// GetConcept retrieves a payroll concept by ID
+func (s *payrollService) GetConcept(ctx context.Context, id uuid.UUID) (*domain.PayrollConcept, error) {
+	return s.payrollRepo.FindConceptByID(ctx, id)
+}
+
+// ListConcepts lists every payroll concept
+func (s *payrollService) ListConcepts(ctx context.Context) ([]domain.PayrollConcept, error) {
+	return s.payrollRepo.ListConcepts(ctx)
+}
+
+// UpdateConcept updates a payroll concept. Payslips already calculated keep
+// their amounts until the period is recalculated.
+func (s *payrollService) UpdateConcept(ctx context.Context, concept *domain.PayrollConcept) error {
+	existing, err := s.payrollRepo.FindConceptByID(ctx, concept.ConceptID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.validateConcept(ctx, concept); err != nil {
+		return err
+	}
+
+	concept.CreatedBy = existing.CreatedBy
+	concept.CreatedAt = existing.CreatedAt
+
+	return s.payrollRepo.UpdateConcept(ctx, concept)
+}
+
+// DeleteConcept deletes a payroll concept. Payslips keep the lines it produced.
+func (s *payrollService) DeleteConcept(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.payrollRepo.FindConceptByID(ctx, id); err != nil {
+		return err
+	}
+	return s.payrollRepo.DeleteConcept(ctx, id)
+}
+
+// CreatePeriod opens a pay period for the employees of a store, or of every store
+func (s *payrollService) CreatePeriod(ctx context.Context, req services.CreatePayrollPeriodRequest) (*domain.PayrollPeriod, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.InvalidInput("Period name is required")
+	}
+
+	if periodFraction(req.Frequency) == 0 {
+		return nil, errors.InvalidInput(fmt.Sprintf("Invalid payroll frequency: %s", req.Frequency))
+	}
+
+	if req.StartDate.IsZero() || req.EndDate.IsZero() {
+		return nil, errors.InvalidInput("Period start and end dates are required")
+	}
+
+	start, end := dateOnly(req.StartDate), dateOnly(req.EndDate)
+	if end.Before(start) {
+		return nil, errors.InvalidInput("Period end cannot be before its start")
+	}
+
+	if days := daysBetween(start, end); days > maxPayrollPeriodDays {
+		return nil, errors.InvalidInput(fmt.Sprintf("A pay period cannot exceed %d days", maxPayrollPeriodDays))
+	}
+
+	payDate := end
+	if req.PayDate != nil {
+		payDate = dateOnly(*req.PayDate)
+		if payDate.Before(start) {
+			return nil, errors.InvalidInput("Pay date cannot be before the period start")
+		}
+	}
+
+	if req.ExchangeRate != nil && *req.ExchangeRate <= 0 {
+		return nil, errors.InvalidInput("Exchange rate must be positive")
+	}
+
+	if req.StoreID != nil {
+		if _, err := s.payrollRepo.FindStoreByID(ctx, *req.StoreID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Overlapping periods would pay the same days twice
+	if overlapping, err := s.payrollRepo.FindOverlappingPeriod(ctx, req.StoreID, start, end); err == nil {
+		return nil, errors.Conflict(fmt.Sprintf("Period overlaps %s (%s to %s)",
+			overlapping.Name, overlapping.StartDate.Format("2006-01-02"), overlapping.EndDate.Format("2006-01-02")))
+	} else if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	period := &domain.PayrollPeriod{
+		PeriodID:     uuid.New(),
+		Name:         name,
+		StoreID:      req.StoreID,
+		Frequency:    req.Frequency,
+		StartDate:    start,
+		EndDate:      end,
+		PayDate:      payDate,
+		Status:       domain.PayrollPeriodStatusOpen,
+		ExchangeRate: req.ExchangeRate,
+		Notes:        req.Notes,
+		CreatedBy:    &req.UserID,
+	}
+
+	if err := s.payrollRepo.CreatePeriod(ctx, period); err != nil {
+		return nil, err
+	}
+
+	return period, nil
+}
+
+// GetPeriod retrieves a payroll period by ID
+func (s *payrollService) GetPeriod(ctx context.Context, id uuid.UUID) (*domain.PayrollPeriod, error) {
+	return s.payrollRepo.FindPeriodByID(ctx, id)
+}
+
+// ListPeriods lists payroll periods matching the filters
+func (s *payrollService) ListPeriods(ctx context.Context, filters repositories.PayrollPeriodFilters, limit, offset int) ([]domain.PayrollPeriod, int64, error) {
+	return s.payrollRepo.ListPeriods(ctx, filters, limit, offset)
+}
+
+// CalculatePeriod (re)computes the payslips of every employee the period pays
+// with the active concepts, replacing those of a previous run
+func (s *payrollService) CalculatePeriod(ctx context.Context, id uuid.UUID, exchangeRate *float64, userID uuid.UUID) (*domain.PayrollPeriod, error) {
+	period, err := s.payrollRepo.FindPeriodByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if period.IsLocked() {
+		return nil, errors.BadRequest(fmt.Sprintf("Payroll period is %s and its payslips are locked", period.Status))
+	}
+
+	if exchangeRate != nil {
+		if *exchangeRate <= 0 {
+			return nil, errors.InvalidInput("Exchange rate must be positive")
+		}
+		period.ExchangeRate = exchangeRate
+	}
+
+	employees, err := s.payrollRepo.GetPayableEmployees(ctx, period.StoreID, period.StartDate, period.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	concepts, err := s.payrollRepo.GetActiveConcepts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payslips := make([]domain.Payslip, 0, len(employees))
+	for i := range employees {
+		payslip, err := calculatePayslip(&employees[i], period, concepts)
+		if err != nil {
+			return nil, err
+		}
+		if payslip != nil {
+			payslips = append(payslips, *payslip)
+		}
+	}
+
+	now := time.Now()
+	period.Status = domain.PayrollPeriodStatusProcessing
+	period.CalculatedAt = &now
+
+	if err := s.payrollRepo.ReplacePayslips(ctx, period, payslips); err != nil {
+		return nil, err
+	}
+
+	return s.payrollRepo.FindPeriodByID(ctx, period.PeriodID)
+}
+
+// ProcessPeriod locks the calculated payslips of the period
+func (s *payrollService) ProcessPeriod(ctx context.Context, id, userID uuid.UUID) (*domain.PayrollPeriod, error) {
+	return s.transition(ctx, id, domain.PayrollPeriodStatusProcessing, domain.PayrollPeriodStatusProcessed, func(period *domain.PayrollPeriod, now time.Time) error {
+		payslips, err := s.payrollRepo.GetPayslips(ctx, period.PeriodID)
+		if err != nil {
+			return err
+		}
+		if len(payslips) == 0 {
+			return errors.BadRequest("Payroll period has no payslips to process")
+		}
+
+		period.ProcessedAt = &now
+		period.ProcessedBy = &userID
+		return nil
+	})
+}
+
+// PayPeriod records that the payslips of a processed period were paid
+func (s *payrollService) PayPeriod(ctx context.Context, id, userID uuid.UUID) (*domain.PayrollPeriod, error) {
+	return s.transition(ctx, id, domain.PayrollPeriodStatusProcessed, domain.PayrollPeriodStatusPaid, func(period *domain.PayrollPeriod, now time.Time) error {
+		period.PaidAt = &now
+		return nil
+	})
+}
+
+// ClosePeriod closes a paid period
+func (s *payrollService) ClosePeriod(ctx context.Context, id, userID uuid.UUID) (*domain.PayrollPeriod, error) {
+	return s.transition(ctx, id, domain.PayrollPeriodStatusPaid, domain.PayrollPeriodStatusClosed, func(period *domain.PayrollPeriod, now time.Time) error {
+		period.ClosedAt = &now
+		return nil
+	})
+}
+
+// transition moves a period from one status to the next, applying the
+// changes that come with it
+func (s *payrollService) transition(ctx context.Context, id uuid.UUID, from, to domain.PayrollPeriodStatus, apply func(*domain.PayrollPeriod, time.Time) error) (*domain.PayrollPeriod, error) {
+	period, err := s.payrollRepo.FindPeriodByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if period.Status != from {
+		return nil, errors.BadRequest(fmt.Sprintf("Payroll period is %s; only %s periods can become %s", period.Status, from, to))
+	}
+
+	if err := apply(period, time.Now()); err != nil {
+		return nil, err
+	}
+	period.Status = to
+
+	if err := s.payrollRepo.UpdatePeriodStatus(ctx, period, from); err != nil {
+		return nil, err
+	}
+
+	return period, nil
+}
+
+// GetPayslips lists the payslips of a period
+func (s *payrollService) GetPayslips(ctx context.Context, periodID uuid.UUID) ([]domain.Payslip, error) {
+	if _, err := s.payrollRepo.FindPeriodByID(ctx, periodID); err != nil {
+		return nil, err
+	}
+	return s.payrollRepo.GetPayslips(ctx, periodID)
+}
+
+// GetPayslip retrieves a payslip with its lines
+func (s *payrollService) GetPayslip(ctx context.Context, id uuid.UUID) (*domain.Payslip, error) {
+	return s.payrollRepo.FindPayslipByID(ctx, id)
+}
+
+// GetPeriodTotals sums the payslips of a period per store and currency
+func (s *payrollService) GetPeriodTotals(ctx context.Context, periodID uuid.UUID) (*services.PayrollTotals, error) {
+	period, err := s.payrollRepo.FindPeriodByID(ctx, periodID)
+	if err != nil {
+		return nil, err
+	}
+
+	payslips, err := s.payrollRepo.GetPayslips(ctx, periodID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildPayrollTotals(period, payslips), nil
+}
+
+// Helper functions
+
+func (s *payrollService) validateConcept(ctx context.Context, concept *domain.PayrollConcept) error {
+	concept.Code = strings.ToUpper(strings.TrimSpace(concept.Code))
+	if concept.Code == "" {
+		return errors.InvalidInput("Concept code is required")
+	}
+
+	concept.Name = strings.TrimSpace(concept.Name)
+	if concept.Name == "" {
+		return errors.InvalidInput("Concept name is required")
+	}
+
+	switch concept.Type {
+	case domain.PayrollConceptAllowance, domain.PayrollConceptDeduction, domain.PayrollConceptEmployerContribution:
+	default:
+		return errors.InvalidInput(fmt.Sprintf("Invalid concept type: %s", concept.Type))
+	}
+
+	switch concept.CalculationType {
+	case domain.PayrollCalculationFixed:
+		if concept.Amount <= 0 {
+			return errors.InvalidInput("Fixed concepts need a positive amount")
+		}
+		concept.Amount = roundAmount(concept.Amount)
+		if concept.Currency == "" {
+			concept.Currency = domain.CurrencyVES
+		}
+		concept.Formula = nil
+
+	case domain.PayrollCalculationFormula:
+		if concept.Formula == nil || strings.TrimSpace(*concept.Formula) == "" {
+			return errors.InvalidInput("Formula concepts need a formula")
+		}
+		formula := strings.TrimSpace(*concept.Formula)
+		if _, err := evaluateFormula(formula, sampleFormulaVars); err != nil {
+			return err
+		}
+		concept.Formula = &formula
+		concept.Amount = 0
+
+	default:
+		return errors.InvalidInput(fmt.Sprintf("Invalid calculation type: %s", concept.CalculationType))
+	}
+
+	if existing, err := s.payrollRepo.FindConceptByCode(ctx, concept.Code); err == nil {
+		if existing.ConceptID != concept.ConceptID {
+			return errors.AlreadyExists("PayrollConcept", "code", concept.Code)
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// periodFraction is the share of the monthly salary a period of the given
+// frequency pays, on 30-day months
+func periodFraction(frequency domain.PayrollFrequency) float64 {
+	switch frequency {
+	case domain.PayrollFrequencyMonthly:
+		return 1
+	case domain.PayrollFrequencyBiweekly:
+		return 0.5
+	case domain.PayrollFrequencyWeekly:
+		return 7.0 / 30.0
+	}
+	return 0
+}
+
+// dateOnly drops the time of day
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// daysBetween counts the days from start to end, both included
+func daysBetween(start, end time.Time) int {
+	days := int(dateOnly(end).Sub(dateOnly(start)).Hours()/24) + 1
+	if days < 0 {
+		return 0
+	}
+	return days
+}
+
+// employedDays counts the days between start and end the employee was employed
+func employedDays(employee *domain.Employee, start, end time.Time) int {
+	if hired := dateOnly(employee.HireDate); hired.After(dateOnly(start)) {
+		start = hired
+	}
+	if employee.TerminationDate != nil {
+		if terminated := dateOnly(*employee.TerminationDate); terminated.Before(dateOnly(end)) {
+			end = terminated
+		}
+	}
+	return daysBetween(start, end)
+}
+
+// calculatePayslip computes the pay of an employee for a period: the salary
+// share of the period, prorated by the days employed, plus the concepts. It
+// returns nil when the employee was not employed during the period.
+func calculatePayslip(employee *domain.Employee, period *domain.PayrollPeriod, concepts []domain.PayrollConcept) (*domain.Payslip, error) {
+	periodDays := daysBetween(period.StartDate, period.EndDate)
+	worked := employedDays(employee, period.StartDate, period.EndDate)
+	if worked == 0 || periodDays == 0 {
+		return nil, nil
+	}
+
+	currency := employee.SalaryCurrency
+	if currency == "" {
+		currency = domain.CurrencyVES
+	}
+
+	basicPay := roundAmount(employee.BaseSalary * periodFraction(period.Frequency) * float64(worked) / float64(periodDays))
+
+	payslip := &domain.Payslip{
+		PayslipID:  uuid.New(),
+		PeriodID:   period.PeriodID,
+		EmployeeID: employee.EmployeeID,
+		StoreID:    employee.StoreID,
+		Currency:   currency,
+		BaseSalary: employee.BaseSalary,
+		PeriodDays: periodDays,
+		WorkedDays: worked,
+		BasicPay:   basicPay,
+	}
+
+	vars := map[string]float64{
+		formulaBaseSalary:   employee.BaseSalary,
+		formulaPeriodSalary: basicPay,
+		formulaWorkedDays:   float64(worked),
+		formulaPeriodDays:   float64(periodDays),
+	}
+
+	employeeName := employee.FirstName + " " + employee.LastName
+	for _, concept := range concepts {
+		var amount float64
+		var err error
+
+		switch concept.CalculationType {
+		case domain.PayrollCalculationFormula:
+			if concept.Formula == nil {
+				continue
+			}
+			amount, err = evaluateFormula(*concept.Formula, vars)
+			amount = roundAmount(amount)
+		default:
+			// Fixed amounts in another currency use the period exchange rate
+			amount, err = convertAmount(concept.Amount, concept.Currency, currency, period.ExchangeRate)
+		}
+		if err != nil {
+			return nil, errors.InvalidInput(fmt.Sprintf("Concept %s for %s: %v", concept.Code, employeeName, err))
+		}
+
+		if amount < 0 {
+			return nil, errors.InvalidInput(fmt.Sprintf("Concept %s gives a negative amount for %s", concept.Code, employeeName))
+		}
+		if amount == 0 {
+			continue
+		}
+
+		conceptID := concept.ConceptID
+		payslip.Lines = append(payslip.Lines, domain.PayslipLine{
+			LineID:    uuid.New(),
+			PayslipID: payslip.PayslipID,
+			ConceptID: &conceptID,
+			Code:      concept.Code,
+			Name:      concept.Name,
+			Type:      concept.Type,
+			Amount:    amount,
+		})
+
+		switch concept.Type {
+		case domain.PayrollConceptAllowance:
+			payslip.TotalAllowances = roundAmount(payslip.TotalAllowances + amount)
+		case domain.PayrollConceptDeduction:
+			payslip.TotalDeductions = roundAmount(payslip.TotalDeductions + amount)
+		case domain.PayrollConceptEmployerContribution:
+			payslip.EmployerContributions = roundAmount(payslip.EmployerContributions + amount)
+		}
+	}
+
+	payslip.NetPay = roundAmount(payslip.BasicPay + payslip.TotalAllowances - payslip.TotalDeductions)
+	if payslip.NetPay < 0 {
+		return nil, errors.InvalidInput(fmt.Sprintf("Deductions exceed the pay of %s", employeeName))
+	}
+
+	return payslip, nil
+}
+
+// addPayslip adds a payslip to payroll amounts
+func addPayslip(amounts services.PayrollAmounts, payslip *domain.Payslip) services.PayrollAmounts {
+	amounts.Employees++
+	amounts.BasicPay = roundAmount(amounts.BasicPay + payslip.BasicPay)
+	amounts.Allowances = roundAmount(amounts.Allowances + payslip.TotalAllowances)
+	amounts.Deductions = roundAmount(amounts.Deductions + payslip.TotalDeductions)
+	amounts.EmployerContributions = roundAmount(amounts.EmployerContributions + payslip.EmployerContributions)
+	amounts.NetPay = roundAmount(amounts.NetPay + payslip.NetPay)
+	return amounts
+}
+
+// buildPayrollTotals sums payslips per store and currency, and converts the
+// grand totals to VES at the period exchange rate
+func buildPayrollTotals(period *domain.PayrollPeriod, payslips []domain.Payslip) *services.PayrollTotals {
+	type totalsKey struct {
+		storeID  uuid.UUID
+		currency domain.CurrencyCode
+	}
+
+	totals := &services.PayrollTotals{
+		PeriodID:   period.PeriodID,
+		ByCurrency: make(map[domain.CurrencyCode]services.PayrollAmounts),
+	}
+
+	rows := make(map[totalsKey]*services.PayrollTotalsRow)
+	for i := range payslips {
+		payslip := &payslips[i]
+
+		key := totalsKey{currency: payslip.Currency}
+		if payslip.StoreID != nil {
+			key.storeID = *payslip.StoreID
+		}
+
+		row, ok := rows[key]
+		if !ok {
+			row = &services.PayrollTotalsRow{StoreID: payslip.StoreID, Currency: payslip.Currency}
+			if payslip.Store != nil {
+				row.StoreName = payslip.Store.Name
+			}
+			rows[key] = row
+		}
+
+		row.PayrollAmounts = addPayslip(row.PayrollAmounts, payslip)
+		totals.ByCurrency[payslip.Currency] = addPayslip(totals.ByCurrency[payslip.Currency], payslip)
+	}
+
+	totals.Rows = make([]services.PayrollTotalsRow, 0, len(rows))
+	for _, row := range rows {
+		totals.Rows = append(totals.Rows, *row)
+	}
+
+	sort.Slice(totals.Rows, func(i, j int) bool {
+		a, b := totals.Rows[i], totals.Rows[j]
+		if a.StoreName != b.StoreName {
+			return a.StoreName < b.StoreName
+		}
+		return a.Currency < b.Currency
+	})
+
+	netPayVES, employerCostVES := 0.0, 0.0
+	for currency, amounts := range totals.ByCurrency {
+		net, err := convertAmount(amounts.NetPay, currency, domain.CurrencyVES, period.ExchangeRate)
+		if err != nil {
+			return totals
+		}
+		cost, err := convertAmount(amounts.BasicPay+amounts.Allowances+amounts.EmployerContributions, currency, domain.CurrencyVES, period.ExchangeRate)
+		if err != nil {
+			return totals
+		}
+		netPayVES += net
+		employerCostVES += cost
+	}
+
+	totals.NetPayVES = float64Ptr(roundAmount(netPayVES))
+	totals.EmployerCostVES = float64Ptr(roundAmount(employerCostVES))
+
+	return totals
+}
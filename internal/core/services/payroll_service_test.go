@@ -0,0 +1,171 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+func TestDaysBetween(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 6, d, 0, 0, 0, 0, time.UTC) }
+
+	assert.Equal(t, 15, daysBetween(day(1), day(15)))
+	assert.Equal(t, 1, daysBetween(day(1), time.Date(2024, 6, 1, 18, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 0, daysBetween(day(10), day(1)))
+}
+
+func TestPayrollPeriodIsLocked(t *testing.T) {
+	period := &domain.PayrollPeriod{Status: domain.PayrollPeriodStatusOpen}
+	assert.False(t, period.IsLocked())
+
+	period.Status = domain.PayrollPeriodStatusProcessing
+	assert.False(t, period.IsLocked())
+
+	for _, status := range []domain.PayrollPeriodStatus{
+		domain.PayrollPeriodStatusProcessed, domain.PayrollPeriodStatusPaid, domain.PayrollPeriodStatusClosed,
+	} {
+		period.Status = status
+		assert.True(t, period.IsLocked(), status)
+	}
+}
+
+func TestCalculatePayslip(t *testing.T) {
+	rate := 36.5
+	period := &domain.PayrollPeriod{
+		PeriodID:     uuid.New(),
+		Frequency:    domain.PayrollFrequencyBiweekly,
+		StartDate:    time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:      time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC),
+		ExchangeRate: &rate,
+	}
+
+	concepts := []domain.PayrollConcept{
+		{ConceptID: uuid.New(), Code: "BONO", Name: "Bono de alimentación", Type: domain.PayrollConceptAllowance,
+			CalculationType: domain.PayrollCalculationFixed, Amount: 20, Currency: domain.CurrencyUSD},
+		{ConceptID: uuid.New(), Code: "IVSS", Name: "Seguro social", Type: domain.PayrollConceptDeduction,
+			CalculationType: domain.PayrollCalculationFormula, Formula: stringPtr("PeriodSalary * 0.04")},
+		{ConceptID: uuid.New(), Code: "IVSS-P", Name: "Seguro social patronal", Type: domain.PayrollConceptEmployerContribution,
+			CalculationType: domain.PayrollCalculationFormula, Formula: stringPtr("PeriodSalary * 0.09")},
+		{ConceptID: uuid.New(), Code: "CERO", Name: "Sin monto", Type: domain.PayrollConceptAllowance,
+			CalculationType: domain.PayrollCalculationFormula, Formula: stringPtr("0")},
+	}
+
+	employee := &domain.Employee{
+		EmployeeID:     uuid.New(),
+		FirstName:      "Ana",
+		LastName:       "Pérez",
+		HireDate:       time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC),
+		BaseSalary:     6000,
+		SalaryCurrency: domain.CurrencyVES,
+	}
+
+	payslip, err := calculatePayslip(employee, period, concepts)
+	require.NoError(t, err)
+	require.NotNil(t, payslip)
+
+	assert.Equal(t, 15, payslip.PeriodDays)
+	assert.Equal(t, 15, payslip.WorkedDays)
+	assert.Equal(t, 3000.0, payslip.BasicPay)
+	require.Len(t, payslip.Lines, 3)
+	assert.Equal(t, 730.0, payslip.TotalAllowances) // 20 USD at 36.5
+	assert.Equal(t, 120.0, payslip.TotalDeductions)
+	assert.Equal(t, 270.0, payslip.EmployerContributions)
+	assert.Equal(t, 3610.0, payslip.NetPay)
+}
+
+func TestCalculatePayslipProratesPartialPeriods(t *testing.T) {
+	period := &domain.PayrollPeriod{
+		Frequency: domain.PayrollFrequencyMonthly,
+		StartDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+	}
+
+	hired := &domain.Employee{
+		HireDate:       time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC),
+		BaseSalary:     300,
+		SalaryCurrency: domain.CurrencyUSD,
+	}
+	payslip, err := calculatePayslip(hired, period, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 10, payslip.WorkedDays)
+	assert.Equal(t, 100.0, payslip.BasicPay)
+	assert.Equal(t, domain.CurrencyUSD, payslip.Currency)
+
+	terminated := time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+	gone := &domain.Employee{
+		HireDate:        time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		TerminationDate: &terminated,
+		BaseSalary:      300,
+	}
+	payslip, err = calculatePayslip(gone, period, nil)
+	require.NoError(t, err)
+	assert.Nil(t, payslip)
+}
+
+func TestCalculatePayslipErrors(t *testing.T) {
+	period := &domain.PayrollPeriod{
+		Frequency: domain.PayrollFrequencyWeekly,
+		StartDate: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2024, 6, 9, 0, 0, 0, 0, time.UTC),
+	}
+	employee := &domain.Employee{
+		FirstName:      "Luis",
+		LastName:       "Gómez",
+		HireDate:       time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		BaseSalary:     3000,
+		SalaryCurrency: domain.CurrencyVES,
+	}
+
+	// Fixed amounts in another currency need the period exchange rate
+	usdBonus := []domain.PayrollConcept{{Code: "BONO", Type: domain.PayrollConceptAllowance,
+		CalculationType: domain.PayrollCalculationFixed, Amount: 10, Currency: domain.CurrencyUSD}}
+	_, err := calculatePayslip(employee, period, usdBonus)
+	assert.Error(t, err)
+
+	loan := []domain.PayrollConcept{{Code: "PREST", Type: domain.PayrollConceptDeduction,
+		CalculationType: domain.PayrollCalculationFixed, Amount: 1000, Currency: domain.CurrencyVES}}
+	_, err = calculatePayslip(employee, period, loan)
+	assert.Error(t, err) // 700 of weekly pay cannot cover it
+}
+
+func TestBuildPayrollTotals(t *testing.T) {
+	rate := 40.0
+	centro := &domain.Store{StoreID: uuid.New(), Name: "Centro"}
+	este := &domain.Store{StoreID: uuid.New(), Name: "Este"}
+	period := &domain.PayrollPeriod{PeriodID: uuid.New(), ExchangeRate: &rate}
+
+	payslips := []domain.Payslip{
+		{StoreID: &este.StoreID, Store: este, Currency: domain.CurrencyVES, BasicPay: 3000, TotalAllowances: 500, TotalDeductions: 120, EmployerContributions: 270, NetPay: 3380},
+		{StoreID: &centro.StoreID, Store: centro, Currency: domain.CurrencyUSD, BasicPay: 150, TotalDeductions: 6, EmployerContributions: 13.5, NetPay: 144},
+		{StoreID: &centro.StoreID, Store: centro, Currency: domain.CurrencyVES, BasicPay: 2000, NetPay: 2000},
+		{StoreID: &este.StoreID, Store: este, Currency: domain.CurrencyVES, BasicPay: 1000, TotalDeductions: 40, NetPay: 960},
+	}
+
+	totals := buildPayrollTotals(period, payslips)
+
+	require.Len(t, totals.Rows, 3)
+	assert.Equal(t, "Centro", totals.Rows[0].StoreName)
+	assert.Equal(t, domain.CurrencyUSD, totals.Rows[0].Currency)
+	assert.Equal(t, "Este", totals.Rows[2].StoreName)
+	assert.Equal(t, 2, totals.Rows[2].Employees)
+	assert.Equal(t, 4340.0, totals.Rows[2].NetPay)
+
+	assert.Equal(t, 3, totals.ByCurrency[domain.CurrencyVES].Employees)
+	assert.Equal(t, 6340.0, totals.ByCurrency[domain.CurrencyVES].NetPay)
+	assert.Equal(t, 144.0, totals.ByCurrency[domain.CurrencyUSD].NetPay)
+
+	require.NotNil(t, totals.NetPayVES)
+	assert.Equal(t, 6340.0+144*40, *totals.NetPayVES)
+	require.NotNil(t, totals.EmployerCostVES)
+	assert.Equal(t, 6770.0+163.5*40, *totals.EmployerCostVES)
+
+	// Without a rate foreign salaries cannot be totalled in VES
+	period.ExchangeRate = nil
+	totals = buildPayrollTotals(period, payslips)
+	assert.Nil(t, totals.NetPayVES)
+}
@@ -0,0 +1,540 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+	"github.com/jadiazinf/inventory/internal/platform/database"
+)
+
+// defaultPickupDays is the time a customer has to collect a ready pre-order
+const defaultPickupDays = 7
+
+type preOrderService struct {
+	preOrderRepo    repositories.PreOrderRepository
+	customerRepo    repositories.CustomerRepository
+	productRepo     repositories.ProductRepository
+	inventoryRepo   repositories.InventoryRepository
+	saleRepo        repositories.SaleRepository
+	notificationSvc services.NotificationService
+	loyaltySvc      services.LoyaltyService
+	allocationSvc   services.AllocationService
+	db              *gorm.DB
+}
+
+// NewPreOrderService creates a new pre-order service
+func NewPreOrderService(
+	preOrderRepo repositories.PreOrderRepository,
+	customerRepo repositories.CustomerRepository,
+	productRepo repositories.ProductRepository,
+	inventoryRepo repositories.InventoryRepository,
+	saleRepo repositories.SaleRepository,
+	notificationSvc services.NotificationService,
+	loyaltySvc services.LoyaltyService,
+	allocationSvc services.AllocationService,
+	db *gorm.DB,
+) services.PreOrderService {
+	return &preOrderService{
+		preOrderRepo:    preOrderRepo,
+		customerRepo:    customerRepo,
+		productRepo:     productRepo,
+		inventoryRepo:   inventoryRepo,
+		saleRepo:        saleRepo,
+		notificationSvc: notificationSvc,
+		loyaltySvc:      loyaltySvc,
+		allocationSvc:   allocationSvc,
+		db:              db,
+	}
+}
+
+// CreatePreOrder registers an order for products that are not in stock yet
+func (s *preOrderService) CreatePreOrder(ctx context.Context, req services.CreatePreOrderRequest) (*domain.PreOrder, error) {
+	if _, err := s.customerRepo.FindByID(ctx, req.CustomerID); err != nil {
+		return nil, errors.NotFoundWithID("Customer", req.CustomerID.String())
+	}
+
+	if len(req.Items) == 0 {
+		return nil, errors.InvalidInput("Pre-order must have at least one item")
+	}
+
+	if _, err := s.allocationSvc.PrimaryWarehouse(ctx, req.StoreID); err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.PreOrderItem, 0, len(req.Items))
+	totalAmount := 0.0
+	for _, itemReq := range req.Items {
+		if itemReq.Quantity <= 0 {
+			return nil, errors.InvalidInput("Quantity must be positive")
+		}
+
+		product, err := s.productRepo.FindByID(ctx, itemReq.ProductID)
+		if err != nil {
+			return nil, errors.NotFoundWithID("Product", itemReq.ProductID.String())
+		}
+
+		if product.Status != domain.ProductStatusActive {
+			return nil, errors.InvalidInput(fmt.Sprintf("Product %s is not active", product.Name))
+		}
+
+		item := domain.PreOrderItem{
+			PreOrderItemID:      uuid.New(),
+			ProductID:           itemReq.ProductID,
+			Quantity:            itemReq.Quantity,
+			UnitPrice:           product.SellingPrice,
+			TotalAmount:         roundAmount(itemReq.Quantity * product.SellingPrice),
+			ExpectedArrivalDate: itemReq.ExpectedArrivalDate,
+		}
+		items = append(items, item)
+		totalAmount += item.TotalAmount
+	}
+
+	if req.DepositAmount < 0 {
+		return nil, errors.InvalidInput("Deposit amount cannot be negative")
+	}
+
+	if req.DepositAmount > totalAmount {
+		return nil, errors.InvalidInput("Deposit amount cannot exceed total amount")
+	}
+
+	expectedReady := req.ExpectedReadyDate
+	if expectedReady == nil {
+		expectedReady = latestArrival(items)
+	}
+
+	preOrder := &domain.PreOrder{
+		PreOrderID:        uuid.New(),
+		CustomerID:        req.CustomerID,
+		StoreID:           &req.StoreID,
+		Status:            domain.PreOrderStatusPending,
+		OrderDate:         time.Now(),
+		ExpectedReadyDate: expectedReady,
+		TotalAmount:       roundAmount(totalAmount),
+		DepositPaid:       req.DepositAmount,
+		Currency:          req.Currency,
+		Notes:             req.Notes,
+		CreatedBy:         &req.UserID,
+	}
+
+	if err := s.preOrderRepo.CreateWithItems(ctx, preOrder, items); err != nil {
+		return nil, err
+	}
+
+	return s.preOrderRepo.FindByID(ctx, preOrder.PreOrderID)
+}
+
+// GetPreOrder retrieves a pre-order by ID
+func (s *preOrderService) GetPreOrder(ctx context.Context, id uuid.UUID) (*domain.PreOrder, error) {
+	return s.preOrderRepo.FindByID(ctx, id)
+}
+
+// GetPreOrderByNumber retrieves a pre-order by number
+func (s *preOrderService) GetPreOrderByNumber(ctx context.Context, preOrderNumber string) (*domain.PreOrder, error) {
+	return s.preOrderRepo.FindByNumber(ctx, preOrderNumber)
+}
+
+// ListPreOrders lists pre-orders with filters
+func (s *preOrderService) ListPreOrders(ctx context.Context, filters repositories.PreOrderFilters, limit, offset int) ([]domain.PreOrder, int64, error) {
+	return s.preOrderRepo.List(ctx, filters, limit, offset)
+}
+
+// ConfirmPreOrder confirms a pre-order once its deposit is verified. Stock already
+// in the store's primary warehouse is assigned right away.
+func (s *preOrderService) ConfirmPreOrder(ctx context.Context, id, userID uuid.UUID) (*domain.PreOrder, error) {
+	preOrder, err := s.preOrderRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if preOrder.Status != domain.PreOrderStatusPending {
+		return nil, errors.InvalidInput(fmt.Sprintf("Cannot confirm pre-order with status %s. Must be PENDING", preOrder.Status))
+	}
+
+	now := time.Now()
+	preOrder.Status = domain.PreOrderStatusConfirmed
+	preOrder.ConfirmedAt = &now
+	preOrder.ConfirmedBy = &userID
+
+	if err := s.preOrderRepo.Update(ctx, preOrder); err != nil {
+		return nil, err
+	}
+
+	warehouse, err := s.allocationSvc.PrimaryWarehouse(ctx, *preOrder.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool, len(preOrder.Items))
+	for _, item := range preOrder.Items {
+		if seen[item.ProductID] {
+			continue
+		}
+		seen[item.ProductID] = true
+
+		if _, err := s.HandleInboundStock(ctx, item.ProductID, warehouse.WarehouseID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.preOrderRepo.FindByID(ctx, id)
+}
+
+// MarkAsReady holds the stock of a pre-order whose items all arrived, sets the
+// pickup deadline and notifies the customer. Each item is held whole in the
+// warehouse the allocation chooses for it.
+func (s *preOrderService) MarkAsReady(ctx context.Context, id uuid.UUID, pickupDays int, userID uuid.UUID) (*domain.PreOrder, error) {
+	preOrder, err := s.preOrderRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if preOrder.Status != domain.PreOrderStatusConfirmed && preOrder.Status != domain.PreOrderStatusInPreparation {
+		return nil, errors.InvalidInput(fmt.Sprintf("Cannot mark pre-order with status %s as ready. Must be CONFIRMED or IN_PREPARATION", preOrder.Status))
+	}
+
+	if !allPreOrderItemsAvailable(preOrder.Items) {
+		return nil, errors.InvalidInput("Not all pre-order items have arrived yet")
+	}
+
+	if pickupDays < 0 {
+		return nil, errors.InvalidInput("Pickup days cannot be negative")
+	}
+	if pickupDays == 0 {
+		pickupDays = defaultPickupDays
+	}
+
+	allocationItems := make([]services.AllocationItem, len(preOrder.Items))
+	for i, item := range preOrder.Items {
+		allocationItems[i] = services.AllocationItem{ProductID: item.ProductID, Quantity: item.Quantity}
+	}
+	plan, err := s.allocationSvc.Allocate(ctx, services.AllocationRequest{
+		StoreID: *preOrder.StoreID,
+		Items:   allocationItems,
+		NoSplit: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := preOrder.Items
+	for _, line := range plan.Lines {
+		warehouseID := line.WarehouseID
+		items[line.ItemIndex].WarehouseID = &warehouseID
+	}
+
+	now := time.Now()
+	preOrder.Status = domain.PreOrderStatusReady
+	preOrder.ReadyDate = &now
+	preOrder.PickupDeadline = timePtr(pickupDeadline(now, pickupDays))
+	if preOrder.ConfirmedBy == nil {
+		preOrder.ConfirmedBy = &userID
+	}
+
+	if err := s.preOrderRepo.MarkAsReady(ctx, preOrder, items); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		if err := s.SendReadyNotification(context.Background(), id); err != nil {
+			log.Printf("[ERROR] Failed to notify ready pre-order %s: %v", preOrder.PreOrderNumber, err)
+		}
+	}()
+
+	return s.preOrderRepo.FindByID(ctx, id)
+}
+
+// SendReadyNotification tells the customer the pre-order can be collected
+func (s *preOrderService) SendReadyNotification(ctx context.Context, id uuid.UUID) error {
+	preOrder, err := s.preOrderRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if preOrder.Status != domain.PreOrderStatusReady {
+		return errors.InvalidInput(fmt.Sprintf("Cannot notify pre-order with status %s. Must be READY", preOrder.Status))
+	}
+
+	if err := s.notificationSvc.SendPreOrderReady(ctx, id); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	preOrder.NotificationSentAt = &now
+	return s.preOrderRepo.Update(ctx, preOrder)
+}
+
+// DeliverPreOrder hands over a ready pre-order, creating the sale with the deposit
+// credited as a tender and releasing the stock held for it
+func (s *preOrderService) DeliverPreOrder(ctx context.Context, req services.DeliverPreOrderRequest) (*domain.Sale, error) {
+	preOrder, err := s.preOrderRepo.FindByID(ctx, req.PreOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if preOrder.Status != domain.PreOrderStatusReady {
+		return nil, errors.InvalidInput(fmt.Sprintf("Cannot deliver pre-order with status %s. Must be READY", preOrder.Status))
+	}
+
+	if pickupExpired(preOrder, time.Now()) {
+		return nil, errors.Expired(fmt.Sprintf("Pickup period of pre-order %s", preOrder.PreOrderNumber))
+	}
+
+	if len(preOrder.Items) == 0 {
+		return nil, errors.InvalidInput("Pre-order has no items")
+	}
+
+	warehouse, err := s.allocationSvc.PrimaryWarehouse(ctx, *preOrder.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	sale := &domain.Sale{
+		SaleID:           uuid.New(),
+		CustomerID:       &preOrder.CustomerID,
+		StoreID:          preOrder.StoreID,
+		WarehouseID:      &warehouse.WarehouseID,
+		SaleType:         domain.SaleTypePreOrder,
+		Status:           domain.SaleStatusCompleted,
+		Currency:         preOrder.Currency,
+		ExchangeRate:     req.ExchangeRate,
+		PaymentMethod:    &req.PaymentMethod,
+		PaymentReference: req.PaymentReference,
+		Notes:            stringPtr(fmt.Sprintf("Delivery of pre-order %s", preOrder.PreOrderNumber)),
+		SalespersonID:    &req.UserID,
+		PreOrderID:       &preOrder.PreOrderID,
+		CreatedBy:        &req.UserID,
+	}
+
+	details := make([]domain.SaleDetail, 0, len(preOrder.Items))
+	for _, item := range preOrder.Items {
+		details = append(details, domain.SaleDetail{
+			ProductID:   item.ProductID,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			WarehouseID: item.WarehouseID, // Held stock leaves from where it was held
+		})
+	}
+
+	var deposit *domain.SaleTender
+	if preOrder.DepositPaid > 0 {
+		deposit = &domain.SaleTender{
+			TenderID:   uuid.New(),
+			TenderType: domain.TenderTypeDeposit,
+			Amount:     preOrder.DepositPaid,
+			Currency:   preOrder.Currency,
+			Reference:  stringPtr(preOrder.PreOrderNumber),
+		}
+	}
+
+	preOrder.Status = domain.PreOrderStatusDelivered
+
+	// Deliver and earn the loyalty points of the sale in one transaction
+	err = database.Transaction(ctx, s.db, func(ctx context.Context) error {
+		if err := s.preOrderRepo.Deliver(ctx, preOrder, sale, details, deposit); err != nil {
+			return err
+		}
+		_, err := s.loyaltySvc.EarnForSale(ctx, sale.SaleID, &req.UserID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.saleRepo.FindByID(ctx, sale.SaleID)
+}
+
+// CancelPreOrder cancels a pre-order, releasing its stock if it was already held
+func (s *preOrderService) CancelPreOrder(ctx context.Context, id, userID uuid.UUID, reason string) error {
+	preOrder, err := s.preOrderRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if preOrder.Status == domain.PreOrderStatusDelivered {
+		return errors.InvalidInput("Cannot cancel a delivered pre-order")
+	}
+
+	if preOrder.Status == domain.PreOrderStatusCancelled {
+		return errors.InvalidInput("Pre-order is already cancelled")
+	}
+
+	return s.cancel(ctx, preOrder, fmt.Sprintf("Cancelled: %s", reason))
+}
+
+// HandleInboundStock flags the items of confirmed pre-orders that the available
+// stock of a product now covers. Arrivals are tracked in the primary warehouse
+// of their store, so stock arriving elsewhere is ignored.
+func (s *preOrderService) HandleInboundStock(ctx context.Context, productID, warehouseID uuid.UUID) (int, error) {
+	var warehouse domain.Warehouse
+	if err := s.db.WithContext(ctx).First(&warehouse, "warehouse_id = ?", warehouseID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, errors.NotFoundWithID("Warehouse", warehouseID.String())
+		}
+		return 0, errors.WrapError(err, "failed to find warehouse")
+	}
+
+	if warehouse.StoreID == nil {
+		return 0, nil
+	}
+
+	primary, err := s.allocationSvc.PrimaryWarehouse(ctx, *warehouse.StoreID)
+	if err != nil {
+		return 0, err
+	}
+	if primary.WarehouseID != warehouseID {
+		return 0, nil
+	}
+
+	items, err := s.preOrderRepo.GetOpenItems(ctx, productID, *warehouse.StoreID)
+	if err != nil {
+		return 0, err
+	}
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	available := 0.0
+	inventory, err := s.inventoryRepo.GetByProductAndWarehouse(ctx, productID, warehouseID)
+	if err != nil && !errors.IsNotFound(err) {
+		return 0, err
+	}
+	if inventory != nil {
+		available = inventory.AvailableQuantity
+	}
+
+	covered := allocateInboundStock(available, items)
+	if len(covered) == 0 {
+		return 0, nil
+	}
+
+	if err := s.preOrderRepo.SetItemsAvailable(ctx, covered); err != nil {
+		return 0, err
+	}
+
+	// Pre-orders whose items have all arrived move on to preparation
+	touched := make(map[uuid.UUID]bool)
+	for _, item := range items {
+		if containsUUID(covered, item.PreOrderItemID) {
+			touched[item.PreOrderID] = true
+		}
+	}
+	for preOrderID := range touched {
+		preOrderItems, err := s.preOrderRepo.GetItems(ctx, preOrderID)
+		if err != nil {
+			return 0, err
+		}
+		if allPreOrderItemsAvailable(preOrderItems) {
+			if err := s.preOrderRepo.UpdateStatus(ctx, preOrderID, domain.PreOrderStatusInPreparation); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return len(covered), nil
+}
+
+// ExpireUncollected cancels the ready pre-orders whose pickup deadline passed,
+// releasing their stock. The deposit is kept, as agreed when the pre-order was made.
+func (s *preOrderService) ExpireUncollected(ctx context.Context, at time.Time) (int, error) {
+	preOrders, err := s.preOrderRepo.GetUncollected(ctx, at)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := range preOrders {
+		if err := s.cancel(ctx, &preOrders[i], "Pickup deadline passed; deposit retained"); err != nil {
+			// Log error but continue processing
+			log.Printf("[ERROR] Failed to expire pre-order %s: %v", preOrders[i].PreOrderNumber, err)
+			continue
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// Helper functions
+
+// cancel records the reason and cancels the pre-order, releasing held stock
+func (s *preOrderService) cancel(ctx context.Context, preOrder *domain.PreOrder, reason string) error {
+	holdsStock := preOrder.Status == domain.PreOrderStatusReady
+
+	preOrder.Status = domain.PreOrderStatusCancelled
+	preOrder.Notes = appendNote(preOrder.Notes, reason)
+
+	return s.preOrderRepo.Cancel(ctx, preOrder, holdsStock)
+}
+
+// allocateInboundStock returns the items that the available stock covers. Items
+// already flagged keep their share; the rest are served strictly in order, so a
+// large older pre-order is not overtaken by smaller newer ones.
+func allocateInboundStock(available float64, items []domain.PreOrderItem) []uuid.UUID {
+	remaining := available
+	for _, item := range items {
+		if item.IsAvailable {
+			remaining -= item.Quantity
+		}
+	}
+
+	covered := make([]uuid.UUID, 0)
+	for _, item := range items {
+		if item.IsAvailable {
+			continue
+		}
+		if item.Quantity > remaining {
+			break
+		}
+		remaining -= item.Quantity
+		covered = append(covered, item.PreOrderItemID)
+	}
+	return covered
+}
+
+// allPreOrderItemsAvailable checks whether every item of a pre-order has arrived
+func allPreOrderItemsAvailable(items []domain.PreOrderItem) bool {
+	if len(items) == 0 {
+		return false
+	}
+	for _, item := range items {
+		if !item.IsAvailable {
+			return false
+		}
+	}
+	return true
+}
+
+// latestArrival returns the last expected arrival among the items, if all have one
+func latestArrival(items []domain.PreOrderItem) *time.Time {
+	var latest *time.Time
+	for _, item := range items {
+		if item.ExpectedArrivalDate == nil {
+			return nil
+		}
+		if latest == nil || item.ExpectedArrivalDate.After(*latest) {
+			latest = item.ExpectedArrivalDate
+		}
+	}
+	return latest
+}
+
+// pickupDeadline returns the last day the customer can collect the pre-order
+func pickupDeadline(readyAt time.Time, days int) time.Time {
+	day := time.Date(readyAt.Year(), readyAt.Month(), readyAt.Day(), 0, 0, 0, 0, readyAt.Location())
+	return day.AddDate(0, 0, days)
+}
+
+// pickupExpired checks whether the last pickup day of a pre-order is over. The
+// deadline is stored as a date, so only calendar days are compared.
+func pickupExpired(preOrder *domain.PreOrder, at time.Time) bool {
+	return preOrder.PickupDeadline != nil && at.Format("2006-01-02") > preOrder.PickupDeadline.Format("2006-01-02")
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+func TestAllocateInboundStock(t *testing.T) {
+	first := domain.PreOrderItem{PreOrderItemID: uuid.New(), Quantity: 5}
+	second := domain.PreOrderItem{PreOrderItemID: uuid.New(), Quantity: 10}
+	third := domain.PreOrderItem{PreOrderItemID: uuid.New(), Quantity: 2}
+
+	t.Run("serves oldest items first", func(t *testing.T) {
+		covered := allocateInboundStock(15, []domain.PreOrderItem{first, second, third})
+		assert.Equal(t, []uuid.UUID{first.PreOrderItemID, second.PreOrderItemID}, covered)
+	})
+
+	t.Run("does not let newer items overtake a large older one", func(t *testing.T) {
+		covered := allocateInboundStock(12, []domain.PreOrderItem{first, second, third})
+		assert.Equal(t, []uuid.UUID{first.PreOrderItemID}, covered)
+	})
+
+	t.Run("flagged items keep their share", func(t *testing.T) {
+		flagged := first
+		flagged.IsAvailable = true
+		covered := allocateInboundStock(15, []domain.PreOrderItem{flagged, second, third})
+		assert.Equal(t, []uuid.UUID{second.PreOrderItemID}, covered)
+	})
+
+	t.Run("no stock", func(t *testing.T) {
+		covered := allocateInboundStock(0, []domain.PreOrderItem{first})
+		assert.Empty(t, covered)
+	})
+}
+
+func TestAllPreOrderItemsAvailable(t *testing.T) {
+	assert.False(t, allPreOrderItemsAvailable(nil))
+	assert.False(t, allPreOrderItemsAvailable([]domain.PreOrderItem{{IsAvailable: true}, {IsAvailable: false}}))
+	assert.True(t, allPreOrderItemsAvailable([]domain.PreOrderItem{{IsAvailable: true}, {IsAvailable: true}}))
+}
+
+func TestLatestArrival(t *testing.T) {
+	early := time.Date(2026, time.August, 1, 0, 0, 0, 0, time.UTC)
+	late := time.Date(2026, time.August, 20, 0, 0, 0, 0, time.UTC)
+
+	latest := latestArrival([]domain.PreOrderItem{{ExpectedArrivalDate: &late}, {ExpectedArrivalDate: &early}})
+	if assert.NotNil(t, latest) {
+		assert.Equal(t, late, *latest)
+	}
+
+	assert.Nil(t, latestArrival([]domain.PreOrderItem{{ExpectedArrivalDate: &early}, {}}))
+}
+
+func TestPickupDeadline(t *testing.T) {
+	readyAt := time.Date(2026, time.August, 10, 17, 30, 0, 0, time.UTC)
+	deadline := pickupDeadline(readyAt, defaultPickupDays)
+	assert.Equal(t, time.Date(2026, time.August, 17, 0, 0, 0, 0, time.UTC), deadline)
+
+	preOrder := &domain.PreOrder{PickupDeadline: &deadline}
+	assert.False(t, pickupExpired(preOrder, time.Date(2026, time.August, 17, 23, 0, 0, 0, time.UTC)))
+	assert.True(t, pickupExpired(preOrder, time.Date(2026, time.August, 18, 8, 0, 0, 0, time.UTC)))
+	assert.False(t, pickupExpired(&domain.PreOrder{}, readyAt))
+}
+
+func TestAppendNote(t *testing.T) {
+	notes := appendNote(nil, "Cancelled: no stock")
+	assert.Equal(t, "Cancelled: no stock", *notes)
+
+	notes = appendNote(stringPtr("Gift wrap"), "Cancelled: no stock")
+	assert.Equal(t, "Gift wrap\nCancelled: no stock", *notes)
+}
@@ -0,0 +1,357 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type pricingService struct {
+	campaignRepo repositories.CampaignRepository
+	db           *gorm.DB
+}
+
+// NewPricingService creates a new pricing engine backed by active campaigns
+func NewPricingService(
+	campaignRepo repositories.CampaignRepository,
+	db *gorm.DB,
+) services.PricingService {
+	return &pricingService{
+		campaignRepo: campaignRepo,
+		db:           db,
+	}
+}
+
+// PriceLines evaluates the active campaigns against the given lines.
+// Each line receives at most one promotion: the one granting the largest discount.
+func (s *pricingService) PriceLines(ctx context.Context, req services.PricingRequest) (*services.PricingResult, error) {
+	at := req.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	campaigns, err := s.campaignRepo.GetActive(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+
+	var storeLocationID *uuid.UUID
+	if req.StoreID != nil {
+		var store domain.Store
+		if err := s.db.WithContext(ctx).First(&store, "store_id = ?", *req.StoreID).Error; err != nil {
+			if err != gorm.ErrRecordNotFound {
+				return nil, errors.WrapError(err, "failed to find store for pricing")
+			}
+		} else {
+			storeLocationID = store.LocationID
+		}
+	}
+
+	return evaluateCampaigns(req.Lines, campaigns, storeLocationID), nil
+}
+
+// RecordApplications accumulates sales and granted discount on each applied campaign
+func (s *pricingService) RecordApplications(ctx context.Context, applications []services.CampaignApplication) error {
+	for _, app := range applications {
+		if err := s.campaignRepo.RecordUsage(ctx, app.CampaignID, app.SalesAmount, app.DiscountAmount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockedPricing keeps the prices and discounts locked by a quotation and
+// rebuilds the campaign applications they stand for
+func lockedPricing(lines []services.PricingLine, campaigns []*uuid.UUID) *services.PricingResult {
+	result := &services.PricingResult{Lines: make([]services.PricedLine, len(lines))}
+	applications := make(map[uuid.UUID]*services.CampaignApplication)
+	order := make([]uuid.UUID, 0)
+
+	for idx, line := range lines {
+		priced := services.PricedLine{
+			Quantity:       line.Quantity,
+			UnitPrice:      line.UnitPrice,
+			DiscountAmount: line.ManualDiscount,
+		}
+		if line.Product != nil {
+			priced.ProductID = line.Product.ProductID
+		}
+
+		if idx < len(campaigns) && campaigns[idx] != nil {
+			campaignID := *campaigns[idx]
+			priced.CampaignID = &campaignID
+
+			app, exists := applications[campaignID]
+			if !exists {
+				app = &services.CampaignApplication{CampaignID: campaignID}
+				applications[campaignID] = app
+				order = append(order, campaignID)
+			}
+			app.DiscountAmount += line.ManualDiscount
+			app.SalesAmount += roundAmount(line.Quantity*line.UnitPrice - line.ManualDiscount)
+			result.TotalDiscount += line.ManualDiscount
+		}
+
+		result.Lines[idx] = priced
+	}
+
+	for _, id := range order {
+		result.Applications = append(result.Applications, *applications[id])
+	}
+	result.TotalDiscount = roundAmount(result.TotalDiscount)
+
+	return result
+}
+
+// campaignCandidate holds the discount a campaign would grant per line index
+type campaignCandidate struct {
+	campaign  *domain.Campaign
+	discounts map[int]float64
+	total     float64
+}
+
+// evaluateCampaigns is the pure pricing algorithm used by PriceLines
+func evaluateCampaigns(lines []services.PricingLine, campaigns []domain.Campaign, storeLocationID *uuid.UUID) *services.PricingResult {
+	candidates := make([]campaignCandidate, 0, len(campaigns))
+	for i := range campaigns {
+		campaign := &campaigns[i]
+		if !campaignTargetsLocation(campaign, storeLocationID) {
+			continue
+		}
+
+		var discounts map[int]float64
+		switch campaign.PromotionType {
+		case domain.PromotionTypeBuyXGetY:
+			discounts = buyXGetYDiscounts(campaign, lines)
+		case domain.PromotionTypeBundle:
+			discounts = bundleDiscounts(campaign, lines)
+		default:
+			discounts = percentageDiscounts(campaign, lines)
+		}
+
+		candidate := campaignCandidate{campaign: campaign, discounts: discounts}
+		for _, d := range discounts {
+			candidate.total += d
+		}
+		if candidate.total <= 0 {
+			continue
+		}
+
+		// Never grant more than the remaining budget
+		if remaining := campaign.RemainingBudget(); remaining != nil && candidate.total > *remaining {
+			if *remaining <= 0 {
+				continue
+			}
+			ratio := *remaining / candidate.total
+			for idx := range candidate.discounts {
+				candidate.discounts[idx] *= ratio
+			}
+			candidate.total = *remaining
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].total > candidates[j].total
+	})
+
+	// Assign lines greedily, best campaign first, without stacking promotions
+	assigned := make(map[int]*domain.Campaign)
+	lineDiscounts := make(map[int]float64)
+	for _, candidate := range candidates {
+		if candidate.campaign.PromotionType == domain.PromotionTypeBundle {
+			conflict := false
+			for idx := range candidate.discounts {
+				if _, taken := assigned[idx]; taken {
+					conflict = true
+					break
+				}
+			}
+			if conflict {
+				continue
+			}
+		}
+
+		for idx, d := range candidate.discounts {
+			if _, taken := assigned[idx]; taken {
+				continue
+			}
+			assigned[idx] = candidate.campaign
+			lineDiscounts[idx] = d
+		}
+	}
+
+	result := &services.PricingResult{
+		Lines: make([]services.PricedLine, len(lines)),
+	}
+	applications := make(map[uuid.UUID]*services.CampaignApplication)
+	order := make([]uuid.UUID, 0)
+
+	for idx, line := range lines {
+		priced := services.PricedLine{
+			Quantity:       line.Quantity,
+			UnitPrice:      line.UnitPrice,
+			DiscountAmount: line.ManualDiscount,
+		}
+		if line.Product != nil {
+			priced.ProductID = line.Product.ProductID
+		}
+
+		if campaign, ok := assigned[idx]; ok {
+			discount := roundAmount(lineDiscounts[idx])
+			campaignID := campaign.CampaignID
+			priced.DiscountAmount = discount
+			priced.CampaignID = &campaignID
+
+			app, exists := applications[campaignID]
+			if !exists {
+				app = &services.CampaignApplication{CampaignID: campaignID}
+				applications[campaignID] = app
+				order = append(order, campaignID)
+			}
+			app.DiscountAmount += discount
+			app.SalesAmount += roundAmount(line.Quantity*line.UnitPrice - discount)
+			result.TotalDiscount += discount
+		}
+
+		result.Lines[idx] = priced
+	}
+
+	for _, id := range order {
+		result.Applications = append(result.Applications, *applications[id])
+	}
+	result.TotalDiscount = roundAmount(result.TotalDiscount)
+
+	return result
+}
+
+func percentageDiscounts(campaign *domain.Campaign, lines []services.PricingLine) map[int]float64 {
+	discounts := make(map[int]float64)
+	if campaign.DiscountPercentage == nil {
+		return discounts
+	}
+	for idx, line := range lines {
+		if !lineEligible(campaign, line) {
+			continue
+		}
+		discounts[idx] = line.Quantity * line.UnitPrice * (*campaign.DiscountPercentage / 100)
+	}
+	return discounts
+}
+
+func buyXGetYDiscounts(campaign *domain.Campaign, lines []services.PricingLine) map[int]float64 {
+	discounts := make(map[int]float64)
+	if campaign.BuyQuantity == nil || campaign.GetQuantity == nil {
+		return discounts
+	}
+	group := float64(*campaign.BuyQuantity + *campaign.GetQuantity)
+	for idx, line := range lines {
+		if !lineEligible(campaign, line) {
+			continue
+		}
+		freeUnits := math.Floor(line.Quantity/group) * float64(*campaign.GetQuantity)
+		if freeUnits > 0 {
+			discounts[idx] = freeUnits * line.UnitPrice
+		}
+	}
+	return discounts
+}
+
+func bundleDiscounts(campaign *domain.Campaign, lines []services.PricingLine) map[int]float64 {
+	discounts := make(map[int]float64)
+	if campaign.BundlePrice == nil || len(campaign.BundleProducts) == 0 {
+		return discounts
+	}
+
+	// Locate one eligible line per bundle product
+	indexes := make([]int, 0, len(campaign.BundleProducts))
+	bundles := math.MaxFloat64
+	regularPrice := 0.0
+	for _, productID := range campaign.BundleProducts {
+		found := -1
+		for idx, line := range lines {
+			if line.ManualDiscount == 0 && line.Product != nil && line.Product.ProductID == productID {
+				found = idx
+				break
+			}
+		}
+		if found < 0 {
+			return discounts
+		}
+		indexes = append(indexes, found)
+		bundles = math.Min(bundles, math.Floor(lines[found].Quantity))
+		regularPrice += lines[found].UnitPrice
+	}
+
+	savingPerBundle := regularPrice - *campaign.BundlePrice
+	if bundles < 1 || savingPerBundle <= 0 {
+		return discounts
+	}
+
+	// Spread the bundle saving across its lines proportionally to price
+	total := bundles * savingPerBundle
+	for _, idx := range indexes {
+		discounts[idx] = total * (lines[idx].UnitPrice / regularPrice)
+	}
+	return discounts
+}
+
+// lineEligible checks product-level targeting for non-bundle promotions
+func lineEligible(campaign *domain.Campaign, line services.PricingLine) bool {
+	if line.ManualDiscount > 0 || line.Product == nil || line.Quantity <= 0 {
+		return false
+	}
+	product := line.Product
+
+	if len(campaign.TargetProducts) > 0 && !containsUUID(campaign.TargetProducts, product.ProductID) {
+		return false
+	}
+
+	if len(campaign.TargetCategories) > 0 {
+		if product.CategoryID == nil || !containsUUID(campaign.TargetCategories, *product.CategoryID) {
+			return false
+		}
+	}
+
+	if len(campaign.TargetSchoolLevels) > 0 {
+		match := false
+		for _, level := range product.GradeLevels {
+			for _, target := range campaign.TargetSchoolLevels {
+				if level == target {
+					match = true
+				}
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+
+	return true
+}
+
+func campaignTargetsLocation(campaign *domain.Campaign, storeLocationID *uuid.UUID) bool {
+	if len(campaign.TargetLocations) == 0 {
+		return true
+	}
+	return storeLocationID != nil && containsUUID(campaign.TargetLocations, *storeLocationID)
+}
+
+func containsUUID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
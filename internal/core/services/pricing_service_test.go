@@ -0,0 +1,188 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+func newPricingProduct() *domain.Product {
+	return &domain.Product{ProductID: uuid.New()}
+}
+
+func TestEvaluateCampaigns_Percentage(t *testing.T) {
+	product := newPricingProduct()
+	pct := 10.0
+	campaign := domain.Campaign{
+		CampaignID:         uuid.New(),
+		PromotionType:      domain.PromotionTypePercentage,
+		DiscountPercentage: &pct,
+	}
+
+	lines := []services.PricingLine{{Product: product, Quantity: 2, UnitPrice: 50}}
+	result := evaluateCampaigns(lines, []domain.Campaign{campaign}, nil)
+
+	require.Len(t, result.Lines, 1)
+	assert.Equal(t, 10.0, result.Lines[0].DiscountAmount)
+	require.NotNil(t, result.Lines[0].CampaignID)
+	assert.Equal(t, campaign.CampaignID, *result.Lines[0].CampaignID)
+	require.Len(t, result.Applications, 1)
+	assert.Equal(t, 90.0, result.Applications[0].SalesAmount)
+	assert.Equal(t, 10.0, result.TotalDiscount)
+}
+
+func TestEvaluateCampaigns_BuyXGetY(t *testing.T) {
+	product := newPricingProduct()
+	buy, get := 2, 1
+	campaign := domain.Campaign{
+		CampaignID:    uuid.New(),
+		PromotionType: domain.PromotionTypeBuyXGetY,
+		BuyQuantity:   &buy,
+		GetQuantity:   &get,
+	}
+
+	// 7 units of a 3x2 promotion: two groups, two free units
+	lines := []services.PricingLine{{Product: product, Quantity: 7, UnitPrice: 4}}
+	result := evaluateCampaigns(lines, []domain.Campaign{campaign}, nil)
+
+	assert.Equal(t, 8.0, result.Lines[0].DiscountAmount)
+}
+
+func TestEvaluateCampaigns_Bundle(t *testing.T) {
+	notebook := newPricingProduct()
+	pencil := newPricingProduct()
+	bundlePrice := 12.0
+	campaign := domain.Campaign{
+		CampaignID:     uuid.New(),
+		PromotionType:  domain.PromotionTypeBundle,
+		BundleProducts: domain.UUIDArray{notebook.ProductID, pencil.ProductID},
+		BundlePrice:    &bundlePrice,
+	}
+
+	lines := []services.PricingLine{
+		{Product: notebook, Quantity: 1, UnitPrice: 10},
+		{Product: pencil, Quantity: 2, UnitPrice: 5},
+	}
+	result := evaluateCampaigns(lines, []domain.Campaign{campaign}, nil)
+
+	// One complete bundle saves 3, split by price
+	assert.Equal(t, 2.0, result.Lines[0].DiscountAmount)
+	assert.Equal(t, 1.0, result.Lines[1].DiscountAmount)
+	assert.Equal(t, 3.0, result.TotalDiscount)
+}
+
+func TestEvaluateCampaigns_LineWithoutProduct(t *testing.T) {
+	notebook := newPricingProduct()
+	pct := 10.0
+	bundlePrice := 8.0
+	campaigns := []domain.Campaign{
+		{CampaignID: uuid.New(), PromotionType: domain.PromotionTypePercentage, DiscountPercentage: &pct},
+		{
+			CampaignID:     uuid.New(),
+			PromotionType:  domain.PromotionTypeBundle,
+			BundleProducts: domain.UUIDArray{notebook.ProductID},
+			BundlePrice:    &bundlePrice,
+		},
+	}
+
+	lines := []services.PricingLine{{Quantity: 1, UnitPrice: 10}}
+	result := evaluateCampaigns(lines, campaigns, nil)
+
+	require.Len(t, result.Lines, 1)
+	assert.Equal(t, uuid.Nil, result.Lines[0].ProductID)
+	assert.Nil(t, result.Lines[0].CampaignID)
+	assert.Zero(t, result.TotalDiscount)
+}
+
+func TestEvaluateCampaigns_BudgetCap(t *testing.T) {
+	product := newPricingProduct()
+	pct := 50.0
+	budget := 100.0
+	campaign := domain.Campaign{
+		CampaignID:         uuid.New(),
+		PromotionType:      domain.PromotionTypePercentage,
+		DiscountPercentage: &pct,
+		Budget:             &budget,
+		DiscountGranted:    95,
+	}
+
+	lines := []services.PricingLine{{Product: product, Quantity: 1, UnitPrice: 40}}
+	result := evaluateCampaigns(lines, []domain.Campaign{campaign}, nil)
+
+	assert.Equal(t, 5.0, result.Lines[0].DiscountAmount)
+
+	campaign.DiscountGranted = 100
+	result = evaluateCampaigns(lines, []domain.Campaign{campaign}, nil)
+
+	assert.Equal(t, 0.0, result.Lines[0].DiscountAmount)
+	assert.Nil(t, result.Lines[0].CampaignID)
+	assert.Empty(t, result.Applications)
+}
+
+func TestEvaluateCampaigns_NoStacking(t *testing.T) {
+	product := newPricingProduct()
+	small, large := 5.0, 20.0
+	smallCampaign := domain.Campaign{
+		CampaignID:         uuid.New(),
+		PromotionType:      domain.PromotionTypePercentage,
+		DiscountPercentage: &small,
+	}
+	largeCampaign := domain.Campaign{
+		CampaignID:         uuid.New(),
+		PromotionType:      domain.PromotionTypePercentage,
+		DiscountPercentage: &large,
+	}
+
+	lines := []services.PricingLine{{Product: product, Quantity: 1, UnitPrice: 100}}
+	result := evaluateCampaigns(lines, []domain.Campaign{smallCampaign, largeCampaign}, nil)
+
+	assert.Equal(t, 20.0, result.Lines[0].DiscountAmount)
+	assert.Equal(t, largeCampaign.CampaignID, *result.Lines[0].CampaignID)
+	require.Len(t, result.Applications, 1)
+}
+
+func TestEvaluateCampaigns_ManualDiscountKept(t *testing.T) {
+	product := newPricingProduct()
+	pct := 10.0
+	campaign := domain.Campaign{
+		CampaignID:         uuid.New(),
+		PromotionType:      domain.PromotionTypePercentage,
+		DiscountPercentage: &pct,
+	}
+
+	lines := []services.PricingLine{{Product: product, Quantity: 1, UnitPrice: 100, ManualDiscount: 3}}
+	result := evaluateCampaigns(lines, []domain.Campaign{campaign}, nil)
+
+	assert.Equal(t, 3.0, result.Lines[0].DiscountAmount)
+	assert.Nil(t, result.Lines[0].CampaignID)
+}
+
+func TestLockedPricing_KeepsQuotedPrices(t *testing.T) {
+	campaignID := uuid.New()
+	lines := []services.PricingLine{
+		{Product: newPricingProduct(), Quantity: 2, UnitPrice: 50, ManualDiscount: 10},
+		{Product: newPricingProduct(), Quantity: 1, UnitPrice: 30, ManualDiscount: 3},
+		{Product: newPricingProduct(), Quantity: 4, UnitPrice: 5},
+	}
+
+	result := lockedPricing(lines, []*uuid.UUID{&campaignID, nil, nil})
+
+	require.Len(t, result.Lines, 3)
+	assert.Equal(t, 10.0, result.Lines[0].DiscountAmount)
+	require.NotNil(t, result.Lines[0].CampaignID)
+	// A manual discount without campaign is not a campaign application
+	assert.Equal(t, 3.0, result.Lines[1].DiscountAmount)
+	assert.Nil(t, result.Lines[1].CampaignID)
+	assert.Equal(t, 0.0, result.Lines[2].DiscountAmount)
+
+	require.Len(t, result.Applications, 1)
+	assert.Equal(t, campaignID, result.Applications[0].CampaignID)
+	assert.Equal(t, 10.0, result.Applications[0].DiscountAmount)
+	assert.Equal(t, 90.0, result.Applications[0].SalesAmount)
+	assert.Equal(t, 10.0, result.TotalDiscount)
+}
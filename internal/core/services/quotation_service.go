@@ -0,0 +1,533 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+	"github.com/jadiazinf/inventory/internal/platform/database"
+	"github.com/jadiazinf/inventory/internal/platform/pdf"
+)
+
+// defaultQuotationValidityDays is used when a request does not specify a validity
+const defaultQuotationValidityDays = 15
+
+type quotationService struct {
+	quotationRepo   repositories.QuotationRepository
+	customerRepo    repositories.CustomerRepository
+	productRepo     repositories.ProductRepository
+	pricingSvc      services.PricingService
+	saleSvc         services.SaleService
+	reservationSvc  services.ReservationService
+	notificationSvc services.NotificationService
+	db              *gorm.DB
+}
+
+// NewQuotationService creates a new quotation service
+func NewQuotationService(
+	quotationRepo repositories.QuotationRepository,
+	customerRepo repositories.CustomerRepository,
+	productRepo repositories.ProductRepository,
+	pricingSvc services.PricingService,
+	saleSvc services.SaleService,
+	reservationSvc services.ReservationService,
+	notificationSvc services.NotificationService,
+	db *gorm.DB,
+) services.QuotationService {
+	return &quotationService{
+		quotationRepo:   quotationRepo,
+		customerRepo:    customerRepo,
+		productRepo:     productRepo,
+		pricingSvc:      pricingSvc,
+		saleSvc:         saleSvc,
+		reservationSvc:  reservationSvc,
+		notificationSvc: notificationSvc,
+		db:              db,
+	}
+}
+
+// CreateQuotation prices the requested lines and creates a draft quotation
+func (s *quotationService) CreateQuotation(ctx context.Context, req services.CreateQuotationRequest) (*domain.Quotation, error) {
+	if req.CustomerID != nil {
+		if _, err := s.customerRepo.FindByID(ctx, *req.CustomerID); err != nil {
+			return nil, errors.NotFoundWithID("Customer", req.CustomerID.String())
+		}
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = domain.CurrencyVES
+	}
+
+	quotation := &domain.Quotation{
+		QuotationID: uuid.New(),
+		CustomerID:  req.CustomerID,
+		StoreID:     req.StoreID,
+		Status:      domain.QuotationStatusDraft,
+		Currency:    currency,
+		Notes:       req.Notes,
+		CreatedBy:   &req.UserID,
+	}
+
+	items, err := s.priceItems(ctx, quotation, req.Items, req.ValidityDays)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.quotationRepo.CreateWithItems(ctx, quotation, items); err != nil {
+		return nil, err
+	}
+
+	return s.quotationRepo.FindByID(ctx, quotation.QuotationID)
+}
+
+// GetQuotation retrieves a quotation by ID
+func (s *quotationService) GetQuotation(ctx context.Context, id uuid.UUID) (*domain.Quotation, error) {
+	return s.quotationRepo.FindByID(ctx, id)
+}
+
+// GetQuotationByNumber retrieves a quotation by number
+func (s *quotationService) GetQuotationByNumber(ctx context.Context, quotationNumber string) (*domain.Quotation, error) {
+	return s.quotationRepo.FindByNumber(ctx, quotationNumber)
+}
+
+// ListQuotations lists quotations with filters
+func (s *quotationService) ListQuotations(ctx context.Context, filters repositories.QuotationFilters, limit, offset int) ([]domain.Quotation, int64, error) {
+	return s.quotationRepo.List(ctx, filters, limit, offset)
+}
+
+// UpdateQuotation replaces the lines of a draft quotation and prices them again
+func (s *quotationService) UpdateQuotation(ctx context.Context, req services.UpdateQuotationRequest) (*domain.Quotation, error) {
+	quotation, err := s.quotationRepo.FindByID(ctx, req.QuotationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if quotation.Status != domain.QuotationStatusDraft {
+		return nil, errors.InvalidInput(fmt.Sprintf("Cannot update quotation with status %s. Must be DRAFT", quotation.Status))
+	}
+
+	if req.CustomerID != nil {
+		if _, err := s.customerRepo.FindByID(ctx, *req.CustomerID); err != nil {
+			return nil, errors.NotFoundWithID("Customer", req.CustomerID.String())
+		}
+		quotation.CustomerID = req.CustomerID
+	}
+	if req.Notes != nil {
+		quotation.Notes = req.Notes
+	}
+
+	items, err := s.priceItems(ctx, quotation, req.Items, req.ValidityDays)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.quotationRepo.ReplaceItems(ctx, quotation, items); err != nil {
+		return nil, err
+	}
+
+	return s.quotationRepo.FindByID(ctx, quotation.QuotationID)
+}
+
+// SendQuotation marks a draft quotation as sent and notifies the customer
+func (s *quotationService) SendQuotation(ctx context.Context, id uuid.UUID) (*domain.Quotation, error) {
+	quotation, err := s.quotationRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if quotation.Status != domain.QuotationStatusDraft {
+		return nil, errors.InvalidInput(fmt.Sprintf("Cannot send quotation with status %s. Must be DRAFT", quotation.Status))
+	}
+
+	if quotation.IsExpired(time.Now()) {
+		return nil, errors.Expired("Quotation")
+	}
+
+	now := time.Now()
+	quotation.Status = domain.QuotationStatusSent
+	quotation.SentAt = &now
+	if err := s.quotationRepo.Update(ctx, quotation); err != nil {
+		return nil, err
+	}
+
+	if quotation.CustomerID != nil {
+		subject := fmt.Sprintf("Presupuesto %s", quotation.QuotationNumber)
+		message := fmt.Sprintf("Le enviamos el presupuesto %s por un total de %.2f %s, válido hasta el %s.",
+			quotation.QuotationNumber,
+			quotation.TotalAmount,
+			quotation.Currency,
+			quotation.ValidUntil.Format("02/01/2006"),
+		)
+		customerID := *quotation.CustomerID
+		go func() {
+			_ = s.notificationSvc.SendCustomNotification(context.Background(), customerID, domain.NotificationTypeEmail, subject, message)
+		}()
+	}
+
+	return quotation, nil
+}
+
+// AcceptQuotation records the customer's acceptance of a sent quotation
+func (s *quotationService) AcceptQuotation(ctx context.Context, id uuid.UUID) (*domain.Quotation, error) {
+	quotation, err := s.quotationRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if quotation.Status != domain.QuotationStatusSent {
+		return nil, errors.InvalidInput(fmt.Sprintf("Cannot accept quotation with status %s. Must be SENT", quotation.Status))
+	}
+
+	if quotation.IsExpired(time.Now()) {
+		return nil, errors.Expired("Quotation")
+	}
+
+	now := time.Now()
+	quotation.Status = domain.QuotationStatusAccepted
+	quotation.AcceptedAt = &now
+	if err := s.quotationRepo.Update(ctx, quotation); err != nil {
+		return nil, err
+	}
+
+	return quotation, nil
+}
+
+// ConvertQuotation creates a sale, credit sale or reservation at the quoted prices.
+// A sent quotation is accepted as part of the conversion.
+func (s *quotationService) ConvertQuotation(ctx context.Context, req services.ConvertQuotationRequest) (*services.QuotationConversion, error) {
+	quotation, err := s.quotationRepo.FindByID(ctx, req.QuotationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if quotation.IsConverted() {
+		return nil, errors.Conflict(fmt.Sprintf("Quotation %s was already converted", quotation.QuotationNumber))
+	}
+
+	if quotation.Status != domain.QuotationStatusSent && quotation.Status != domain.QuotationStatusAccepted {
+		return nil, errors.InvalidInput(fmt.Sprintf("Cannot convert quotation with status %s. Must be SENT or ACCEPTED", quotation.Status))
+	}
+
+	now := time.Now()
+	if quotation.IsExpired(now) {
+		return nil, errors.Expired("Quotation")
+	}
+
+	if req.ConvertTo != domain.QuotationConversionSale &&
+		req.ConvertTo != domain.QuotationConversionCreditSale &&
+		req.ConvertTo != domain.QuotationConversionReservation {
+		return nil, errors.InvalidInput(fmt.Sprintf("Invalid conversion target %s", req.ConvertTo))
+	}
+
+	convertTo := req.ConvertTo
+	quotation.Status = domain.QuotationStatusAccepted
+	if quotation.AcceptedAt == nil {
+		quotation.AcceptedAt = &now
+	}
+	quotation.ConvertedAt = &now
+	quotation.ConvertedTo = &convertTo
+
+	// Claim the quotation before creating the document, in the same
+	// transaction, so concurrent conversions cannot both go through
+	result := &services.QuotationConversion{}
+	err = database.Transaction(ctx, s.db, func(ctx context.Context) error {
+		if err := s.quotationRepo.MarkConverted(ctx, quotation); err != nil {
+			return err
+		}
+
+		switch req.ConvertTo {
+		case domain.QuotationConversionSale, domain.QuotationConversionCreditSale:
+			saleReq, err := s.buildSaleRequest(quotation, req)
+			if err != nil {
+				return err
+			}
+
+			if req.ConvertTo == domain.QuotationConversionCreditSale {
+				result.Sale, result.Receivable, err = s.saleSvc.CreateCreditSale(ctx, saleReq, services.CreditTerms{CreditDays: req.CreditDays})
+			} else {
+				result.Sale, err = s.saleSvc.CreateSale(ctx, saleReq)
+			}
+			if err != nil {
+				return err
+			}
+			quotation.SaleID = &result.Sale.SaleID
+
+		case domain.QuotationConversionReservation:
+			reservationReq, err := s.buildReservationRequest(quotation, req, now)
+			if err != nil {
+				return err
+			}
+
+			result.Reservation, err = s.reservationSvc.CreateReservation(ctx, reservationReq)
+			if err != nil {
+				return err
+			}
+			quotation.ReservationID = &result.Reservation.ReservationID
+		}
+
+		return s.quotationRepo.Update(ctx, quotation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Quotation = quotation
+	return result, nil
+}
+
+// RenderPDF renders the quotation as a printable document
+func (s *quotationService) RenderPDF(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	quotation, err := s.quotationRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := pdf.New()
+	doc.SetFooter(fmt.Sprintf("Presupuesto %s", quotation.QuotationNumber))
+
+	if quotation.Store != nil {
+		doc.Heading(quotation.Store.Name)
+		if quotation.Store.Address != nil {
+			doc.Text(*quotation.Store.Address)
+		}
+		if quotation.Store.Phone != nil {
+			doc.Text(*quotation.Store.Phone)
+		}
+		doc.Space(10)
+	}
+
+	doc.Title(fmt.Sprintf("Presupuesto %s", quotation.QuotationNumber))
+	doc.Field("Fecha", quotation.CreatedAt.Format("02/01/2006"))
+	doc.Field("Válido hasta", quotation.ValidUntil.Format("02/01/2006"))
+	if quotation.Customer != nil {
+		doc.Field("Cliente", getCustomerName(quotation.Customer))
+		doc.Field("RIF/CI", quotation.Customer.TaxID)
+	}
+	doc.Separator()
+
+	columns := []pdf.Column{
+		{Header: "Producto", Width: 38},
+		{Header: "Cant.", Width: 8, Align: pdf.AlignRight},
+		{Header: "Precio", Width: 12, Align: pdf.AlignRight},
+		{Header: "Desc.", Width: 10, Align: pdf.AlignRight},
+		{Header: "Total", Width: 13, Align: pdf.AlignRight},
+	}
+	rows := make([][]string, 0, len(quotation.Items))
+	for _, item := range quotation.Items {
+		name := item.ProductID.String()
+		if item.Product != nil {
+			name = item.Product.Name
+		}
+		rows = append(rows, []string{
+			name,
+			fmt.Sprintf("%.2f", item.Quantity),
+			fmt.Sprintf("%.2f", item.UnitPrice),
+			fmt.Sprintf("%.2f", item.DiscountAmount),
+			fmt.Sprintf("%.2f", item.TotalAmount),
+		})
+	}
+	doc.Table(columns, rows)
+
+	doc.Separator()
+	doc.Field("Subtotal", fmt.Sprintf("%.2f %s", quotation.Subtotal, quotation.Currency))
+	doc.Field("Descuento", fmt.Sprintf("%.2f %s", quotation.DiscountAmount, quotation.Currency))
+	doc.Heading(fmt.Sprintf("Total: %.2f %s", quotation.TotalAmount, quotation.Currency))
+
+	if quotation.Notes != nil && *quotation.Notes != "" {
+		doc.Space(10)
+		doc.Field("Notas", *quotation.Notes)
+	}
+
+	doc.Space(10)
+	doc.Text("Precios garantizados hasta la fecha de validez indicada, sujetos a disponibilidad.")
+
+	return doc.Bytes(), nil
+}
+
+// ExpireQuotations marks open quotations past their validity as expired
+func (s *quotationService) ExpireQuotations(ctx context.Context, at time.Time) (int, error) {
+	expired, err := s.quotationRepo.GetExpired(ctx, at)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := range expired {
+		expired[i].Status = domain.QuotationStatusExpired
+		if err := s.quotationRepo.Update(ctx, &expired[i]); err != nil {
+			// Log error but continue processing
+			log.Printf("[ERROR] Failed to expire quotation %s: %v", expired[i].QuotationNumber, err)
+			continue
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// Helper functions
+
+// priceItems validates and prices the requested lines through the promotion
+// engine, then sets the quotation totals and validity
+func (s *quotationService) priceItems(ctx context.Context, quotation *domain.Quotation, reqItems []services.QuotationItem, validityDays int) ([]domain.QuotationItem, error) {
+	if len(reqItems) == 0 {
+		return nil, errors.InvalidInput("Quotation must have at least one item")
+	}
+
+	if validityDays < 0 {
+		return nil, errors.InvalidInput("Validity days cannot be negative")
+	}
+	if validityDays == 0 {
+		validityDays = defaultQuotationValidityDays
+	}
+
+	items := make([]domain.QuotationItem, 0, len(reqItems))
+	pricingLines := make([]services.PricingLine, 0, len(reqItems))
+
+	for _, itemReq := range reqItems {
+		if itemReq.Quantity <= 0 {
+			return nil, errors.InvalidInput("Quantity must be positive")
+		}
+
+		product, err := s.productRepo.FindByID(ctx, itemReq.ProductID)
+		if err != nil {
+			return nil, errors.NotFoundWithID("Product", itemReq.ProductID.String())
+		}
+
+		if product.Status != domain.ProductStatusActive {
+			return nil, errors.InvalidInput(fmt.Sprintf("Product %s is not active", product.Name))
+		}
+
+		unitPrice := product.SellingPrice
+		if itemReq.UnitPrice != nil {
+			unitPrice = *itemReq.UnitPrice
+		}
+
+		items = append(items, domain.QuotationItem{
+			QuotationItemID: uuid.New(),
+			ProductID:       itemReq.ProductID,
+			Quantity:        itemReq.Quantity,
+			UnitPrice:       unitPrice,
+			DiscountAmount:  itemReq.DiscountAmount,
+		})
+		pricingLines = append(pricingLines, services.PricingLine{
+			Product:        product,
+			Quantity:       itemReq.Quantity,
+			UnitPrice:      unitPrice,
+			ManualDiscount: itemReq.DiscountAmount,
+		})
+	}
+
+	pricing, err := s.pricingSvc.PriceLines(ctx, services.PricingRequest{
+		StoreID: &quotation.StoreID,
+		Lines:   pricingLines,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	subtotal := 0.0
+	discount := 0.0
+	for i, line := range pricing.Lines {
+		items[i].DiscountAmount = line.DiscountAmount
+		items[i].CampaignID = line.CampaignID
+		items[i].TotalAmount = roundAmount(line.Quantity*line.UnitPrice - line.DiscountAmount)
+		subtotal += line.Quantity * line.UnitPrice
+		discount += line.DiscountAmount
+	}
+
+	quotation.Subtotal = roundAmount(subtotal)
+	quotation.DiscountAmount = roundAmount(discount)
+	quotation.TotalAmount = roundAmount(subtotal - discount)
+	quotation.ValidUntil = time.Now().AddDate(0, 0, validityDays)
+
+	return items, nil
+}
+
+func (s *quotationService) buildSaleRequest(quotation *domain.Quotation, req services.ConvertQuotationRequest) (services.CreateSaleRequest, error) {
+	if req.PaymentMethod == nil {
+		return services.CreateSaleRequest{}, errors.InvalidInput("Payment method is required to convert a quotation into a sale")
+	}
+
+	items := make([]services.SaleItem, len(quotation.Items))
+	for i, item := range quotation.Items {
+		unitPrice := item.UnitPrice
+		items[i] = services.SaleItem{
+			ProductID:      item.ProductID,
+			Quantity:       item.Quantity,
+			UnitPrice:      &unitPrice,
+			DiscountAmount: item.DiscountAmount,
+			CampaignID:     item.CampaignID,
+		}
+	}
+
+	saleType := domain.SaleTypeCash
+	if req.ConvertTo == domain.QuotationConversionCreditSale {
+		saleType = domain.SaleTypeCredit
+	}
+
+	return services.CreateSaleRequest{
+		CustomerID:       quotation.CustomerID,
+		StoreID:          quotation.StoreID,
+		WarehouseID:      req.WarehouseID,
+		SaleType:         saleType,
+		Items:            items,
+		Currency:         quotation.Currency,
+		ExchangeRate:     req.ExchangeRate,
+		PaymentMethod:    req.PaymentMethod,
+		PaymentReference: req.PaymentReference,
+		Notes:            stringPtr(fmt.Sprintf("Conversion of quotation %s", quotation.QuotationNumber)),
+		SalespersonID:    req.UserID,
+		QuotationID:      &quotation.QuotationID,
+	}, nil
+}
+
+func (s *quotationService) buildReservationRequest(quotation *domain.Quotation, req services.ConvertQuotationRequest, now time.Time) (services.CreateReservationRequest, error) {
+	if quotation.CustomerID == nil {
+		return services.CreateReservationRequest{}, errors.InvalidInput("Customer is required to convert a quotation into a reservation")
+	}
+
+	// Keep the reservation within the price lock unless told otherwise
+	expirationDays := req.ExpirationDays
+	if expirationDays <= 0 {
+		expirationDays = int(quotation.ValidUntil.Sub(now).Hours() / 24)
+		if expirationDays < 1 {
+			expirationDays = 1
+		}
+	}
+
+	items := make([]services.ReservationItem, len(quotation.Items))
+	for i, item := range quotation.Items {
+		unitPrice := item.UnitPrice
+		items[i] = services.ReservationItem{
+			ProductID:      item.ProductID,
+			Quantity:       item.Quantity,
+			UnitPrice:      &unitPrice,
+			DiscountAmount: item.DiscountAmount,
+			CampaignID:     item.CampaignID,
+		}
+	}
+
+	return services.CreateReservationRequest{
+		CustomerID:     *quotation.CustomerID,
+		ChildID:        req.ChildID,
+		QuotationID:    &quotation.QuotationID,
+		StoreID:        quotation.StoreID,
+		WarehouseID:    req.WarehouseID,
+		Items:          items,
+		DepositAmount:  req.DepositAmount,
+		Currency:       quotation.Currency,
+		ExpirationDays: expirationDays,
+		Notes:          stringPtr(fmt.Sprintf("Conversion of quotation %s", quotation.QuotationNumber)),
+		UserID:         req.UserID,
+	}, nil
+}
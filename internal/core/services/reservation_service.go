@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"fmt"
+	"log"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,15 +14,31 @@ import (
 	"github.com/jadiazinf/inventory/internal/core/domain"
 	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
 	"github.com/jadiazinf/inventory/internal/core/ports/services"
+	"github.com/jadiazinf/inventory/internal/platform/database"
+)
+
+const (
+	// maxReservationExtensions is the number of times a reservation can be extended
+	maxReservationExtensions = 2
+
+	// maxReservationExtensionDays bounds the days a single extension can add
+	maxReservationExtensionDays = 15
+
+	// maxReservationLifetimeDays bounds how long after it was made a reservation can be held
+	maxReservationLifetimeDays = 60
 )
 
 type reservationService struct {
 	reservationRepo repositories.ReservationRepository
 	customerRepo    repositories.CustomerRepository
+	childRepo       repositories.CustomerChildRepository
 	productRepo     repositories.ProductRepository
 	inventoryRepo   repositories.InventoryRepository
 	saleRepo        repositories.SaleRepository
 	notificationSvc services.NotificationService
+	pricingSvc      services.PricingService
+	loyaltySvc      services.LoyaltyService
+	allocationSvc   services.AllocationService
 	db              *gorm.DB
 }
 
@@ -28,19 +46,27 @@ type reservationService struct {
 func NewReservationService(
 	reservationRepo repositories.ReservationRepository,
 	customerRepo repositories.CustomerRepository,
+	childRepo repositories.CustomerChildRepository,
 	productRepo repositories.ProductRepository,
 	inventoryRepo repositories.InventoryRepository,
 	saleRepo repositories.SaleRepository,
 	notificationSvc services.NotificationService,
+	pricingSvc services.PricingService,
+	loyaltySvc services.LoyaltyService,
+	allocationSvc services.AllocationService,
 	db *gorm.DB,
 ) services.ReservationService {
 	return &reservationService{
 		reservationRepo: reservationRepo,
 		customerRepo:    customerRepo,
+		childRepo:       childRepo,
 		productRepo:     productRepo,
 		inventoryRepo:   inventoryRepo,
 		saleRepo:        saleRepo,
 		notificationSvc: notificationSvc,
+		pricingSvc:      pricingSvc,
+		loyaltySvc:      loyaltySvc,
+		allocationSvc:   allocationSvc,
 		db:              db,
 	}
 }
@@ -55,7 +81,7 @@ func (s *reservationService) CreateReservation(ctx context.Context, req services
 
 	// Validate child if provided
 	if req.ChildID != nil {
-		child, err := s.customerRepo.FindByID(ctx, *req.ChildID)
+		child, err := s.childRepo.FindByID(ctx, *req.ChildID)
 		if err != nil {
 			return nil, errors.NotFoundWithID("Child", req.ChildID.String())
 		}
@@ -72,7 +98,8 @@ func (s *reservationService) CreateReservation(ctx context.Context, req services
 
 	// Build reservation items
 	reservationItems := make([]domain.ReservationItem, 0, len(req.Items))
-	totalAmount := 0.0
+	pricingLines := make([]services.PricingLine, 0, len(req.Items))
+	lockedCampaigns := make([]*uuid.UUID, 0, len(req.Items))
 
 	for _, itemReq := range req.Items {
 		// Validate product exists
@@ -85,22 +112,66 @@ func (s *reservationService) CreateReservation(ctx context.Context, req services
 			return nil, errors.InvalidInput(fmt.Sprintf("Product %s is not active", product.Name))
 		}
 
-		// Use current sale price
+		// Use current sale price, or the one locked by a quotation
 		unitPrice := product.SellingPrice
-		itemTotal := unitPrice * itemReq.Quantity
+		lockedDiscount := 0.0
+		if req.QuotationID != nil {
+			if itemReq.UnitPrice != nil {
+				unitPrice = *itemReq.UnitPrice
+			}
+			lockedDiscount = itemReq.DiscountAmount
+		}
 
 		reservationItem := domain.ReservationItem{
 			ReservationItemID: uuid.New(),
 			ProductID:         itemReq.ProductID,
 			Quantity:          itemReq.Quantity,
 			UnitPrice:         unitPrice,
-			TotalAmount:       itemTotal,
 		}
 
 		reservationItems = append(reservationItems, reservationItem)
-		totalAmount += itemTotal
+		pricingLines = append(pricingLines, services.PricingLine{
+			Product:        product,
+			Quantity:       itemReq.Quantity,
+			UnitPrice:      unitPrice,
+			ManualDiscount: lockedDiscount,
+		})
+		lockedCampaigns = append(lockedCampaigns, itemReq.CampaignID)
+	}
+
+	// Apply active promotions, unless prices were locked by a quotation
+	var pricing *services.PricingResult
+	if req.QuotationID != nil {
+		pricing = lockedPricing(pricingLines, lockedCampaigns)
+	} else {
+		pricing, err = s.pricingSvc.PriceLines(ctx, services.PricingRequest{
+			StoreID: &req.StoreID,
+			Lines:   pricingLines,
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 
+	totalAmount := 0.0
+	for i, line := range pricing.Lines {
+		reservationItems[i].DiscountAmount = line.DiscountAmount
+		reservationItems[i].CampaignID = line.CampaignID
+		reservationItems[i].TotalAmount = line.Quantity*line.UnitPrice - line.DiscountAmount
+		totalAmount += reservationItems[i].TotalAmount
+	}
+
+	// Hold each item in the warehouses the allocation chooses, unless the caller pinned one
+	plan, err := s.allocationSvc.Allocate(ctx, services.AllocationRequest{
+		StoreID:     req.StoreID,
+		Items:       reservationAllocationItems(reservationItems),
+		WarehouseID: req.WarehouseID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	reservationItems = splitReservationItems(reservationItems, plan)
+
 	// Calculate expiration date
 	expirationDate := time.Now().AddDate(0, 0, req.ExpirationDays)
 
@@ -125,14 +196,21 @@ func (s *reservationService) CreateReservation(ctx context.Context, req services
 		ExpirationDate:  expirationDate,
 		TotalAmount:     totalAmount,
 		DepositAmount:   req.DepositAmount,
-		Balance:         totalAmount - req.DepositAmount,
+		Balance:         totalAmount, // Nothing is paid until the deposit is recorded
 		Currency:        req.Currency,
 		Notes:           req.Notes,
 		CreatedBy:       &req.UserID,
 	}
 
-	// Create reservation with items (transaction handled in repository)
-	if err := s.reservationRepo.CreateWithItems(ctx, reservation, reservationItems); err != nil {
+	// Create reservation with items; campaign budget is consumed in the same
+	// transaction, when the discounted price is locked in
+	err = database.Transaction(ctx, s.db, func(ctx context.Context) error {
+		if err := s.reservationRepo.CreateWithItems(ctx, reservation, reservationItems); err != nil {
+			return err
+		}
+		return s.pricingSvc.RecordApplications(ctx, pricing.Applications)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -160,9 +238,9 @@ func (s *reservationService) ListReservations(ctx context.Context, filters repos
 	return s.reservationRepo.List(ctx, filters, limit, offset)
 }
 
-// ConfirmReservation confirms a reservation (marks deposit as paid)
-func (s *reservationService) ConfirmReservation(ctx context.Context, id uuid.UUID) error {
-	reservation, err := s.reservationRepo.FindByID(ctx, id)
+// ConfirmReservation records the deposit payment and confirms the reservation
+func (s *reservationService) ConfirmReservation(ctx context.Context, req services.ReservationPaymentRequest) error {
+	reservation, err := s.reservationRepo.FindByID(ctx, req.ReservationID)
 	if err != nil {
 		return err
 	}
@@ -171,16 +249,64 @@ func (s *reservationService) ConfirmReservation(ctx context.Context, id uuid.UUI
 		return errors.InvalidInput(fmt.Sprintf("Cannot confirm reservation with status %s", reservation.Status))
 	}
 
+	var payment *domain.ReservationPayment
+	if req.Amount > 0 {
+		payment, err = newReservationPayment(reservation, req, domain.ReservationPaymentDeposit)
+		if err != nil {
+			return err
+		}
+		applyReservationPayment(reservation, payment.AppliedAmount)
+	}
+
+	if reservation.AmountPaid < reservation.DepositAmount {
+		return errors.InvalidInput(fmt.Sprintf(
+			"A deposit of %.2f %s is required to confirm the reservation, %.2f received",
+			reservation.DepositAmount, reservation.Currency, reservation.AmountPaid,
+		))
+	}
+
 	// Update status to confirmed
 	reservation.Status = domain.ReservationStatusConfirmed
-	if err := s.reservationRepo.Update(ctx, reservation); err != nil {
-		return err
+	if payment == nil {
+		return s.reservationRepo.Update(ctx, reservation)
 	}
+	return s.reservationRepo.AddPayment(ctx, reservation, payment)
+}
 
-	return nil
+// RecordPayment records an installment paid on a confirmed reservation before pickup
+func (s *reservationService) RecordPayment(ctx context.Context, req services.ReservationPaymentRequest) (*domain.ReservationPayment, error) {
+	reservation, err := s.reservationRepo.FindByID(ctx, req.ReservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reservation.Status != domain.ReservationStatusConfirmed &&
+		reservation.Status != domain.ReservationStatusPartiallyFulfilled {
+		return nil, errors.InvalidInput(fmt.Sprintf("Cannot record a payment on reservation with status %s", reservation.Status))
+	}
+
+	payment, err := newReservationPayment(reservation, req, domain.ReservationPaymentInstallment)
+	if err != nil {
+		return nil, err
+	}
+	applyReservationPayment(reservation, payment.AppliedAmount)
+
+	if err := s.reservationRepo.AddPayment(ctx, reservation, payment); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// GetPayments retrieves the payments, refunds and forfeits of a reservation
+func (s *reservationService) GetPayments(ctx context.Context, reservationID uuid.UUID) ([]domain.ReservationPayment, error) {
+	if _, err := s.reservationRepo.FindByID(ctx, reservationID); err != nil {
+		return nil, err
+	}
+	return s.reservationRepo.GetPayments(ctx, reservationID)
 }
 
-// FulfillReservation converts a reservation into a sale
+// FulfillReservation converts the picked up items of a reservation into a sale.
+// Items or quantities left out stay reserved for a later pickup.
 func (s *reservationService) FulfillReservation(ctx context.Context, req services.FulfillReservationRequest) (*domain.Sale, error) {
 	// Get reservation with items
 	reservation, err := s.reservationRepo.FindByID(ctx, req.ReservationID)
@@ -188,8 +314,18 @@ func (s *reservationService) FulfillReservation(ctx context.Context, req service
 		return nil, err
 	}
 
-	if reservation.Status != domain.ReservationStatusConfirmed {
-		return nil, errors.InvalidInput(fmt.Sprintf("Cannot fulfill reservation with status %s. Must be CONFIRMED", reservation.Status))
+	if reservation.Status != domain.ReservationStatusConfirmed &&
+		reservation.Status != domain.ReservationStatusPartiallyFulfilled {
+		return nil, errors.InvalidInput(fmt.Sprintf("Cannot fulfill reservation with status %s. Must be CONFIRMED or PARTIALLY_FULFILLED", reservation.Status))
+	}
+
+	depositApplication := req.DepositApplication
+	if depositApplication == "" {
+		depositApplication = domain.DepositApplicationProportional
+	}
+	if depositApplication != domain.DepositApplicationProportional &&
+		depositApplication != domain.DepositApplicationFirstPickup {
+		return nil, errors.InvalidInput(fmt.Sprintf("Invalid deposit application %s", depositApplication))
 	}
 
 	// Get reservation items
@@ -202,10 +338,9 @@ func (s *reservationService) FulfillReservation(ctx context.Context, req service
 		return nil, errors.InvalidInput("Reservation has no items")
 	}
 
-	// Get warehouse from store
-	var warehouse domain.Warehouse
-	if err := s.db.Where("store_id = ?", reservation.StoreID).First(&warehouse).Error; err != nil {
-		return nil, errors.WrapError(err, "failed to find warehouse for store")
+	pickups, err := planPickup(items, req.Items)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create sale from reservation
@@ -213,8 +348,7 @@ func (s *reservationService) FulfillReservation(ctx context.Context, req service
 		SaleID:           uuid.New(),
 		CustomerID:       &reservation.CustomerID,
 		StoreID:          reservation.StoreID,
-		WarehouseID:      &warehouse.WarehouseID,
-		SaleType:         domain.SaleTypeCash,
+		SaleType:         domain.SaleTypeReservation,
 		Status:           domain.SaleStatusCompleted,
 		Currency:         reservation.Currency,
 		ExchangeRate:     req.ExchangeRate,
@@ -222,64 +356,463 @@ func (s *reservationService) FulfillReservation(ctx context.Context, req service
 		PaymentReference: req.PaymentReference,
 		Notes:            stringPtr(fmt.Sprintf("Fulfillment of reservation %s", reservation.ReservationNumber)),
 		SalespersonID:    &req.UserID,
+		ReservationID:    &reservation.ReservationID,
+		CreatedBy:        &req.UserID,
+	}
+
+	// Convert the picked quantities to sale items
+	saleDetails := make([]domain.SaleDetail, 0, len(pickups))
+	pickupValue := 0.0
+	for _, pickup := range pickups {
+		item := &items[pickup.index]
+		lineTotal := reservationItemValue(item, item.FulfilledQuantity+pickup.quantity) -
+			reservationItemValue(item, item.FulfilledQuantity)
+
+		// The picked stock leaves from the warehouse holding it
+		warehouseID, err := s.itemWarehouse(ctx, reservation, item)
+		if err != nil {
+			return nil, err
+		}
+
+		saleDetails = append(saleDetails, domain.SaleDetail{
+			ProductID:      item.ProductID,
+			Quantity:       pickup.quantity,
+			UnitPrice:      item.UnitPrice,
+			DiscountAmount: roundAmount(pickup.quantity*item.UnitPrice - lineTotal),
+			CampaignID:     item.CampaignID,
+			WarehouseID:    &warehouseID,
+		})
+		pickupValue += lineTotal
+
+		item.FulfilledQuantity += pickup.quantity
+		item.ReservedQuantity = math.Max(item.ReservedQuantity-pickup.quantity, 0)
+		item.IsFulfilled = item.FulfilledQuantity >= item.Quantity
+	}
+	sale.WarehouseID = saleDetails[0].WarehouseID
+
+	completed := allReservationItemsFulfilled(items)
+
+	// Credit the deposit to this pickup
+	var deposit *domain.SaleTender
+	depositShare := depositForPickup(reservation, pickupValue, completed, depositApplication)
+	if depositShare > 0 {
+		deposit = &domain.SaleTender{
+			TenderID:   uuid.New(),
+			TenderType: domain.TenderTypeDeposit,
+			Amount:     depositShare,
+			Currency:   reservation.Currency,
+			Reference:  stringPtr(reservation.ReservationNumber),
+		}
+	}
+
+	now := time.Now()
+	reservation.DepositApplied = roundAmount(reservation.DepositApplied + depositShare)
+	reservation.Balance = reservationBalance(reservation, items)
+	reservation.PickupDate = &now
+	if completed {
+		reservation.Status = domain.ReservationStatusFulfilled
+		reservation.FulfilledAt = &now
+		reservation.FulfilledBy = &req.UserID
+	} else {
+		reservation.Status = domain.ReservationStatusPartiallyFulfilled
+	}
+
+	// Release, sell, update the reservation and earn the loyalty points of
+	// the sale in one transaction
+	err = database.Transaction(ctx, s.db, func(ctx context.Context) error {
+		if err := s.reservationRepo.Fulfill(ctx, reservation, items, sale, saleDetails, deposit); err != nil {
+			return err
+		}
+		_, err := s.loyaltySvc.EarnForSale(ctx, sale.SaleID, &req.UserID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.saleRepo.FindByID(ctx, sale.SaleID)
+}
+
+// CancelReservation cancels a reservation, releases inventory and settles
+// the amount paid that was not credited to pickups
+func (s *reservationService) CancelReservation(ctx context.Context, req services.CancelReservationRequest) error {
+	// Settle from the locked reservation, so no payment or pickup is missed
+	return database.Transaction(ctx, s.db, func(ctx context.Context) error {
+		reservation, err := s.reservationRepo.FindByIDForUpdate(ctx, req.ReservationID)
+		if err != nil {
+			return err
+		}
+
+		if reservation.Status == domain.ReservationStatusFulfilled {
+			return errors.InvalidInput("Cannot cancel a fulfilled reservation")
+		}
+
+		if reservation.Status == domain.ReservationStatusCancelled ||
+			reservation.Status == domain.ReservationStatusExpired {
+			return errors.InvalidInput("Reservation is already cancelled")
+		}
+
+		refund, forfeit := settlementAmounts(reservation, req.ForfeitDeposit)
+		if refund > 0 && req.RefundMethod == nil {
+			return errors.InvalidInput(fmt.Sprintf("Refund method is required to return %.2f %s", refund, reservation.Currency))
+		}
+
+		settlements := settleReservation(reservation, refund, forfeit, req.RefundMethod, req.RefundReference, &req.UserID, time.Now())
+		reservation.Status = domain.ReservationStatusCancelled
+		reservation.Notes = appendNote(reservation.Notes, "Cancelled: "+req.Reason)
+
+		// Cancel reservation (releases inventory via repository)
+		return s.reservationRepo.Cancel(ctx, reservation, settlements)
+	})
+}
+
+// ModifyItems adds, removes or resizes the items of an open reservation.
+// Existing items keep their locked prices; added items are priced at current
+// prices with active promotions.
+func (s *reservationService) ModifyItems(ctx context.Context, req services.ModifyReservationItemsRequest) (*domain.Reservation, error) {
+	reservation, err := s.reservationRepo.FindByID(ctx, req.ReservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !reservationHoldsStock(reservation.Status) {
+		return nil, errors.InvalidInput(fmt.Sprintf("Cannot modify reservation with status %s", reservation.Status))
+	}
+
+	if len(req.Changes) == 0 {
+		return nil, errors.InvalidInput("At least one item change is required")
+	}
+
+	items := reservation.Items
+	var changes repositories.ReservationItemChanges
+	changes.Original = append([]domain.ReservationItem(nil), items...)
+	indexByID := make(map[uuid.UUID]int, len(items))
+	reservedProducts := make(map[uuid.UUID]bool, len(items))
+	for i, item := range items {
+		indexByID[item.ReservationItemID] = i
+		reservedProducts[item.ProductID] = true
+	}
+
+	previousTotal := reservation.TotalAmount
+	modifications := make([]domain.ReservationModification, 0, len(req.Changes))
+	removed := make(map[uuid.UUID]bool)
+	seen := make(map[uuid.UUID]bool, len(req.Changes))
+	pricingLines := make([]services.PricingLine, 0)
+
+	for _, change := range req.Changes {
+		if change.Quantity < 0 {
+			return nil, errors.InvalidInput("Quantity cannot be negative")
+		}
+
+		// New item
+		if change.ReservationItemID == nil {
+			if change.ProductID == nil {
+				return nil, errors.InvalidInput("Product ID is required to add an item")
+			}
+			if change.Quantity <= 0 {
+				return nil, errors.InvalidInput("Quantity must be positive")
+			}
+			if reservedProducts[*change.ProductID] {
+				return nil, errors.InvalidInput(fmt.Sprintf("Product %s is already reserved, change its quantity instead", change.ProductID))
+			}
+			reservedProducts[*change.ProductID] = true
+
+			product, err := s.productRepo.FindByID(ctx, *change.ProductID)
+			if err != nil {
+				return nil, errors.NotFoundWithID("Product", change.ProductID.String())
+			}
+			if product.Status != domain.ProductStatusActive {
+				return nil, errors.InvalidInput(fmt.Sprintf("Product %s is not active", product.Name))
+			}
+
+			changes.Added = append(changes.Added, domain.ReservationItem{
+				ReservationItemID: uuid.New(),
+				ReservationID:     reservation.ReservationID,
+				ProductID:         product.ProductID,
+				Quantity:          change.Quantity,
+				ReservedQuantity:  change.Quantity,
+				UnitPrice:         product.SellingPrice,
+			})
+			pricingLines = append(pricingLines, services.PricingLine{
+				Product:   product,
+				Quantity:  change.Quantity,
+				UnitPrice: product.SellingPrice,
+			})
+			continue
+		}
+
+		// Existing item
+		i, ok := indexByID[*change.ReservationItemID]
+		if !ok {
+			return nil, errors.NotFoundWithID("Reservation item", change.ReservationItemID.String())
+		}
+		if seen[*change.ReservationItemID] {
+			return nil, errors.InvalidInput(fmt.Sprintf("Reservation item %s is listed more than once", change.ReservationItemID))
+		}
+		seen[*change.ReservationItemID] = true
+
+		item := &items[i]
+		if change.Quantity == item.Quantity {
+			continue
+		}
+		if change.Quantity < item.FulfilledQuantity {
+			return nil, errors.InvalidInput(fmt.Sprintf(
+				"Reservation item %s already had %.3f picked up",
+				item.ReservationItemID, item.FulfilledQuantity,
+			))
+		}
+
+		modification := domain.ReservationModification{
+			ModificationID:    uuid.New(),
+			ReservationID:     reservation.ReservationID,
+			ModificationType:  domain.ReservationModificationQuantityChanged,
+			ReservationItemID: &item.ReservationItemID,
+			ProductID:         &item.ProductID,
+			PreviousQuantity:  float64Ptr(item.Quantity),
+			NewQuantity:       float64Ptr(change.Quantity),
+		}
+
+		if change.Quantity == 0 {
+			modification.ModificationType = domain.ReservationModificationItemRemoved
+			changes.Removed = append(changes.Removed, *item)
+			removed[item.ReservationItemID] = true
+		} else {
+			resizeReservationItem(item, change.Quantity)
+			changes.Updated = append(changes.Updated, *item)
+		}
+		modifications = append(modifications, modification)
 	}
 
-	// Convert reservation items to sale items
-	saleDetails := make([]domain.SaleDetail, 0, len(items))
+	// Price the added items
+	var pricing *services.PricingResult
+	if len(pricingLines) > 0 {
+		pricing, err = s.pricingSvc.PriceLines(ctx, services.PricingRequest{
+			StoreID: reservation.StoreID,
+			Lines:   pricingLines,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for i, line := range pricing.Lines {
+			added := &changes.Added[i]
+			added.DiscountAmount = line.DiscountAmount
+			added.CampaignID = line.CampaignID
+			added.TotalAmount = roundAmount(line.Quantity*line.UnitPrice - line.DiscountAmount)
+		}
+
+		// Added items are held where the allocation finds stock
+		plan, err := s.allocationSvc.Allocate(ctx, services.AllocationRequest{
+			StoreID: *reservation.StoreID,
+			Items:   reservationAllocationItems(changes.Added),
+		})
+		if err != nil {
+			return nil, err
+		}
+		changes.Added = splitReservationItems(changes.Added, plan)
+
+		for i := range changes.Added {
+			added := &changes.Added[i]
+			modifications = append(modifications, domain.ReservationModification{
+				ModificationID:    uuid.New(),
+				ReservationID:     reservation.ReservationID,
+				ModificationType:  domain.ReservationModificationItemAdded,
+				ReservationItemID: &added.ReservationItemID,
+				ProductID:         &added.ProductID,
+				NewQuantity:       float64Ptr(added.Quantity),
+			})
+		}
+	}
+
+	if len(modifications) == 0 {
+		return nil, errors.InvalidInput("The requested changes do not modify the reservation")
+	}
+
+	remaining := make([]domain.ReservationItem, 0, len(items)+len(changes.Added))
 	for _, item := range items {
-		saleDetail := domain.SaleDetail{
-			ProductID:    item.ProductID,
-			Quantity:     item.Quantity,
-			UnitPrice:    item.UnitPrice,
+		if !removed[item.ReservationItemID] {
+			remaining = append(remaining, item)
 		}
-		saleDetails = append(saleDetails, saleDetail)
 	}
+	remaining = append(remaining, changes.Added...)
 
-	// Create sale with details in transaction
-	if err := s.saleRepo.CreateWithDetails(ctx, sale, saleDetails); err != nil {
+	if allReservationItemsFulfilled(remaining) {
+		return nil, errors.InvalidInput("The reservation must keep something to pick up, cancel it instead")
+	}
+
+	if err := repriceReservation(reservation, remaining); err != nil {
 		return nil, err
 	}
 
-	// Mark reservation as fulfilled
-	now := time.Now()
-	reservation.Status = domain.ReservationStatusFulfilled
-	reservation.FulfilledAt = &now
-	reservation.FulfilledBy = &req.UserID
+	for i := range modifications {
+		modifications[i].PreviousTotal = previousTotal
+		modifications[i].NewTotal = reservation.TotalAmount
+		modifications[i].Reason = optionalString(req.Reason)
+		modifications[i].CreatedBy = &req.UserID
+	}
 
-	if err := s.reservationRepo.Update(ctx, reservation); err != nil {
-		// Sale was created but reservation update failed - log this
-		return sale, errors.WrapError(err, "sale created but failed to update reservation status")
+	// Campaign budget is consumed in the same transaction, when the
+	// discounted price is locked in
+	err = database.Transaction(ctx, s.db, func(ctx context.Context) error {
+		if err := s.reservationRepo.ModifyItems(ctx, reservation, changes, modifications); err != nil {
+			return err
+		}
+		if pricing != nil {
+			return s.pricingSvc.RecordApplications(ctx, pricing.Applications)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return sale, nil
+	return s.reservationRepo.FindByID(ctx, reservation.ReservationID)
 }
 
-// CancelReservation cancels a reservation and releases inventory
-func (s *reservationService) CancelReservation(ctx context.Context, id, userID uuid.UUID, reason string) error {
-	reservation, err := s.reservationRepo.FindByID(ctx, id)
+// ExtendReservation postpones the expiration date of an open reservation
+// within the extension policy
+func (s *reservationService) ExtendReservation(ctx context.Context, req services.ExtendReservationRequest) (*domain.Reservation, error) {
+	// Extend the locked reservation, so it cannot be cancelled, expired or
+	// picked up between the checks and the new date
+	err := database.Transaction(ctx, s.db, func(ctx context.Context) error {
+		reservation, err := s.reservationRepo.FindByIDForUpdate(ctx, req.ReservationID)
+		if err != nil {
+			return err
+		}
+
+		if !reservationHoldsStock(reservation.Status) {
+			return errors.InvalidInput(fmt.Sprintf("Cannot extend reservation with status %s", reservation.Status))
+		}
+
+		previousExpiration := reservation.ExpirationDate
+		newExpiration, err := extendedExpiration(reservation, req.Days, time.Now())
+		if err != nil {
+			return err
+		}
+
+		reservation.ExpirationDate = newExpiration
+		reservation.ExtensionCount++
+		reservation.ReminderSentAt = nil // Remind again before the new date
+
+		modification := &domain.ReservationModification{
+			ModificationID:     uuid.New(),
+			ReservationID:      reservation.ReservationID,
+			ModificationType:   domain.ReservationModificationExtended,
+			PreviousExpiration: &previousExpiration,
+			NewExpiration:      &newExpiration,
+			PreviousTotal:      reservation.TotalAmount,
+			NewTotal:           reservation.TotalAmount,
+			Reason:             optionalString(req.Reason),
+			CreatedBy:          &req.UserID,
+		}
+
+		return s.reservationRepo.Extend(ctx, reservation, modification)
+	})
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return s.reservationRepo.FindByID(ctx, req.ReservationID)
+}
+
+// ChangeStore moves an open reservation, and the stock it holds, to another pickup store
+func (s *reservationService) ChangeStore(ctx context.Context, req services.ChangeReservationStoreRequest) (*domain.Reservation, error) {
+	reservation, err := s.reservationRepo.FindByID(ctx, req.ReservationID)
+	if err != nil {
+		return nil, err
 	}
 
-	if reservation.Status == domain.ReservationStatusFulfilled {
-		return errors.InvalidInput("Cannot cancel a fulfilled reservation")
+	if !reservationHoldsStock(reservation.Status) {
+		return nil, errors.InvalidInput(fmt.Sprintf("Cannot change the store of reservation with status %s", reservation.Status))
 	}
 
-	if reservation.Status == domain.ReservationStatusCancelled {
-		return errors.InvalidInput("Reservation is already cancelled")
+	if reservation.StoreID != nil && *reservation.StoreID == req.StoreID {
+		return nil, errors.InvalidInput("Reservation is already assigned to that store")
 	}
 
-	// Cancel reservation (releases inventory via repository)
-	if err := s.reservationRepo.Cancel(ctx, id); err != nil {
-		return err
+	// Choose where the held stock goes for the new store. Each item stays whole,
+	// and the stock it holds counts as available where it is.
+	holding := make([]domain.ReservationItem, 0, len(reservation.Items))
+	allocationItems := make([]services.AllocationItem, 0, len(reservation.Items))
+	held := make([]services.AllocationLine, 0, len(reservation.Items))
+	for i := range reservation.Items {
+		item := &reservation.Items[i]
+		if item.ReservedQuantity <= 0 {
+			continue
+		}
+		warehouseID, err := s.itemWarehouse(ctx, reservation, item)
+		if err != nil {
+			return nil, err
+		}
+		holding = append(holding, *item)
+		allocationItems = append(allocationItems, services.AllocationItem{ProductID: item.ProductID, Quantity: item.ReservedQuantity})
+		held = append(held, services.AllocationLine{ProductID: item.ProductID, WarehouseID: warehouseID, Quantity: item.ReservedQuantity})
 	}
 
-	// TODO: Record cancellation reason and user
+	warehouses := make(map[uuid.UUID]uuid.UUID, len(holding))
+	if len(holding) > 0 {
+		plan, err := s.allocationSvc.Allocate(ctx, services.AllocationRequest{
+			StoreID: req.StoreID,
+			Items:   allocationItems,
+			NoSplit: true,
+			Held:    held,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range plan.Lines {
+			warehouses[holding[line.ItemIndex].ReservationItemID] = line.WarehouseID
+		}
+	}
 
-	return nil
+	modification := &domain.ReservationModification{
+		ModificationID:   uuid.New(),
+		ReservationID:    reservation.ReservationID,
+		ModificationType: domain.ReservationModificationStoreChanged,
+		PreviousStoreID:  reservation.StoreID,
+		NewStoreID:       &req.StoreID,
+		PreviousTotal:    reservation.TotalAmount,
+		NewTotal:         reservation.TotalAmount,
+		Reason:           optionalString(req.Reason),
+		CreatedBy:        &req.UserID,
+	}
+
+	// Moves the held stock between warehouses in one transaction
+	if err := s.reservationRepo.ChangeStore(ctx, reservation, req.StoreID, warehouses, modification); err != nil {
+		return nil, err
+	}
+
+	return s.reservationRepo.FindByID(ctx, reservation.ReservationID)
+}
+
+// GetModifications retrieves the change history of a reservation
+func (s *reservationService) GetModifications(ctx context.Context, reservationID uuid.UUID) ([]domain.ReservationModification, error) {
+	if _, err := s.reservationRepo.FindByID(ctx, reservationID); err != nil {
+		return nil, err
+	}
+	return s.reservationRepo.GetModifications(ctx, reservationID)
 }
 
-// ExpireReservations expires all pending/confirmed reservations past their expiration date
+// itemWarehouse returns the warehouse holding a reservation item. Items reserved
+// before per-item warehouses were recorded are held in the store's primary warehouse.
+func (s *reservationService) itemWarehouse(ctx context.Context, reservation *domain.Reservation, item *domain.ReservationItem) (uuid.UUID, error) {
+	if item.WarehouseID != nil {
+		return *item.WarehouseID, nil
+	}
+	if reservation.StoreID == nil {
+		return uuid.Nil, errors.InvalidInput("Reservation has no store")
+	}
+
+	warehouse, err := s.allocationSvc.PrimaryWarehouse(ctx, *reservation.StoreID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return warehouse.WarehouseID, nil
+}
+
+// ExpireReservations expires all open reservations past their expiration date.
+// The agreed deposit is retained and any other amount paid is refunded through
+// the method of the last payment.
 func (s *reservationService) ExpireReservations(ctx context.Context) (int, error) {
 	expired, err := s.reservationRepo.GetExpired(ctx)
 	if err != nil {
@@ -287,9 +820,10 @@ func (s *reservationService) ExpireReservations(ctx context.Context) (int, error
 	}
 
 	count := 0
-	for _, reservation := range expired {
-		if err := s.reservationRepo.Cancel(ctx, reservation.ReservationID); err != nil {
+	for i := range expired {
+		if err := s.expire(ctx, expired[i].ReservationID); err != nil {
 			// Log error but continue processing
+			log.Printf("[ERROR] Failed to expire reservation %s: %v", expired[i].ReservationNumber, err)
 			continue
 		}
 		count++
@@ -298,6 +832,33 @@ func (s *reservationService) ExpireReservations(ctx context.Context) (int, error
 	return count, nil
 }
 
+// expire cancels an expired reservation, settling from the locked reservation
+// in case it was paid, picked up or extended since it was listed
+func (s *reservationService) expire(ctx context.Context, id uuid.UUID) error {
+	return database.Transaction(ctx, s.db, func(ctx context.Context) error {
+		reservation, err := s.reservationRepo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if !reservationHoldsStock(reservation.Status) || !reservation.ExpirationDate.Before(time.Now()) {
+			return errors.Conflict(fmt.Sprintf("Reservation %s is no longer expired", reservation.ReservationNumber))
+		}
+
+		payments, err := s.reservationRepo.GetPayments(ctx, reservation.ReservationID)
+		if err != nil {
+			return err
+		}
+
+		refund, forfeit := settlementAmounts(reservation, true)
+		settlements := settleReservation(reservation, refund, forfeit, lastPaymentMethod(payments), nil, nil, time.Now())
+		reservation.Status = domain.ReservationStatusExpired
+		reservation.Notes = appendNote(reservation.Notes, "Expired without pickup")
+
+		return s.reservationRepo.Cancel(ctx, reservation, settlements)
+	})
+}
+
 // SendReminders sends reminders for reservations expiring soon
 func (s *reservationService) SendReminders(ctx context.Context, hoursBeforeExpiration int) (int, error) {
 
@@ -319,9 +880,7 @@ func (s *reservationService) SendReminders(ctx context.Context, hoursBeforeExpir
 		}
 
 		// Update reminder sent time
-		now := time.Now()
-		reservation.ReminderSentAt = &now
-		if err := s.reservationRepo.Update(ctx, &reservation); err != nil {
+		if err := s.reservationRepo.MarkReminderSent(ctx, reservation.ReservationID, time.Now()); err != nil {
 			// Log error but continue
 			continue
 		}
@@ -331,3 +890,366 @@ func (s *reservationService) SendReminders(ctx context.Context, hoursBeforeExpir
 
 	return count, nil
 }
+
+// reservationPickup is the quantity of a reservation item taken in a pickup
+type reservationPickup struct {
+	index    int
+	quantity float64
+}
+
+// planPickup validates the requested pickup against what is still reserved.
+// An empty request picks up everything that remains.
+func planPickup(items []domain.ReservationItem, requested []services.FulfillReservationItem) ([]reservationPickup, error) {
+	pickups := make([]reservationPickup, 0, len(items))
+
+	if len(requested) == 0 {
+		for i, item := range items {
+			if remaining := item.Quantity - item.FulfilledQuantity; remaining > 0 {
+				pickups = append(pickups, reservationPickup{index: i, quantity: remaining})
+			}
+		}
+	} else {
+		indexByID := make(map[uuid.UUID]int, len(items))
+		for i, item := range items {
+			indexByID[item.ReservationItemID] = i
+		}
+
+		seen := make(map[uuid.UUID]bool, len(requested))
+		for _, req := range requested {
+			i, ok := indexByID[req.ReservationItemID]
+			if !ok {
+				return nil, errors.NotFoundWithID("Reservation item", req.ReservationItemID.String())
+			}
+			if seen[req.ReservationItemID] {
+				return nil, errors.InvalidInput(fmt.Sprintf("Reservation item %s is listed more than once", req.ReservationItemID))
+			}
+			seen[req.ReservationItemID] = true
+
+			if req.Quantity <= 0 {
+				return nil, errors.InvalidInput("Quantity must be positive")
+			}
+
+			remaining := items[i].Quantity - items[i].FulfilledQuantity
+			if req.Quantity > remaining {
+				return nil, errors.InvalidInput(fmt.Sprintf(
+					"Cannot pick up %.3f of reservation item %s, only %.3f remain",
+					req.Quantity, req.ReservationItemID, remaining,
+				))
+			}
+			pickups = append(pickups, reservationPickup{index: i, quantity: req.Quantity})
+		}
+	}
+
+	if len(pickups) == 0 {
+		return nil, errors.InvalidInput("Reservation has nothing left to pick up")
+	}
+	return pickups, nil
+}
+
+// reservationItemValue returns the value of the first quantity units of an
+// item. Pickup values are differences of this, so they add up to the item total.
+func reservationItemValue(item *domain.ReservationItem, quantity float64) float64 {
+	if item.Quantity <= 0 || quantity >= item.Quantity {
+		return item.TotalAmount
+	}
+	return roundAmount(item.TotalAmount * quantity / item.Quantity)
+}
+
+// allReservationItemsFulfilled checks whether every item has been picked up
+func allReservationItemsFulfilled(items []domain.ReservationItem) bool {
+	for _, item := range items {
+		if !item.IsFulfilled {
+			return false
+		}
+	}
+	return true
+}
+
+// depositForPickup returns the part of the amount paid credited to a pickup.
+// The pickup that completes the reservation takes whatever is left.
+func depositForPickup(reservation *domain.Reservation, pickupValue float64, completes bool, application domain.DepositApplication) float64 {
+	remaining := unappliedPayments(reservation)
+	if remaining <= 0 || pickupValue <= 0 {
+		return 0
+	}
+
+	share := remaining
+	if !completes && application == domain.DepositApplicationProportional {
+		if reservation.TotalAmount <= 0 {
+			return 0
+		}
+		share = roundAmount(reservation.AmountPaid * pickupValue / reservation.TotalAmount)
+	}
+	return min(share, remaining, roundAmount(pickupValue))
+}
+
+// reservationBalance returns what the customer still owes for the items not
+// picked up yet, after the payments not credited to pickups
+func reservationBalance(reservation *domain.Reservation, items []domain.ReservationItem) float64 {
+	pending := 0.0
+	for i := range items {
+		pending += items[i].TotalAmount - reservationItemValue(&items[i], items[i].FulfilledQuantity)
+	}
+	return math.Max(roundAmount(pending-unappliedPayments(reservation)), 0)
+}
+
+// unappliedPayments returns the money held for a reservation: paid, but neither
+// credited to a pickup nor retained by the store
+func unappliedPayments(reservation *domain.Reservation) float64 {
+	return roundAmount(reservation.AmountPaid - reservation.DepositApplied - reservation.ForfeitedAmount)
+}
+
+// newReservationPayment validates a payment and converts it to the reservation currency
+func newReservationPayment(reservation *domain.Reservation, req services.ReservationPaymentRequest, paymentType domain.ReservationPaymentType) (*domain.ReservationPayment, error) {
+	if req.Amount <= 0 {
+		return nil, errors.InvalidInput("Payment amount must be positive")
+	}
+
+	if req.PaymentMethod == "" {
+		return nil, errors.InvalidInput("Payment method is required")
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = reservation.Currency
+	}
+
+	applied, err := convertAmount(req.Amount, currency, reservation.Currency, req.ExchangeRate)
+	if err != nil {
+		return nil, err
+	}
+
+	if applied > reservation.Balance {
+		return nil, errors.InvalidInput(fmt.Sprintf(
+			"Payment of %.2f %s exceeds the outstanding balance of %.2f %s",
+			applied, reservation.Currency, reservation.Balance, reservation.Currency,
+		))
+	}
+
+	method := req.PaymentMethod
+	return &domain.ReservationPayment{
+		PaymentID:     uuid.New(),
+		ReservationID: reservation.ReservationID,
+		PaymentType:   paymentType,
+		Amount:        req.Amount,
+		Currency:      currency,
+		ExchangeRate:  req.ExchangeRate,
+		AppliedAmount: applied,
+		PaymentMethod: &method,
+		Reference:     req.Reference,
+		Notes:         req.Notes,
+		PaymentDate:   time.Now(),
+		ReceivedBy:    &req.UserID,
+	}, nil
+}
+
+// applyReservationPayment adds a payment to the totals of a reservation
+func applyReservationPayment(reservation *domain.Reservation, applied float64) {
+	reservation.AmountPaid = roundAmount(reservation.AmountPaid + applied)
+	reservation.Balance = reservationBalance(reservation, reservation.Items)
+}
+
+// settlementAmounts splits the money held for a reservation into what is
+// refunded and what is retained. Only the agreed deposit not yet credited to
+// pickups can be retained; installments above it are always refunded.
+func settlementAmounts(reservation *domain.Reservation, forfeitDeposit bool) (refund, forfeit float64) {
+	held := unappliedPayments(reservation)
+	if held <= 0 {
+		return 0, 0
+	}
+
+	if forfeitDeposit {
+		forfeit = min(held, math.Max(reservation.DepositAmount-reservation.DepositApplied, 0))
+	}
+	return roundAmount(held - forfeit), roundAmount(forfeit)
+}
+
+// settleReservation builds the refund and forfeit records of a reservation
+// being closed and updates its totals
+func settleReservation(
+	reservation *domain.Reservation,
+	refund, forfeit float64,
+	refundMethod *domain.PaymentMethod,
+	refundReference *string,
+	userID *uuid.UUID,
+	at time.Time,
+) []domain.ReservationPayment {
+	settlements := make([]domain.ReservationPayment, 0, 2)
+
+	if refund > 0 {
+		settlements = append(settlements, domain.ReservationPayment{
+			PaymentID:     uuid.New(),
+			ReservationID: reservation.ReservationID,
+			PaymentType:   domain.ReservationPaymentRefund,
+			Amount:        refund,
+			Currency:      reservation.Currency,
+			AppliedAmount: refund,
+			PaymentMethod: refundMethod,
+			Reference:     refundReference,
+			PaymentDate:   at,
+			ReceivedBy:    userID,
+		})
+	}
+
+	if forfeit > 0 {
+		settlements = append(settlements, domain.ReservationPayment{
+			PaymentID:     uuid.New(),
+			ReservationID: reservation.ReservationID,
+			PaymentType:   domain.ReservationPaymentForfeit,
+			Amount:        forfeit,
+			Currency:      reservation.Currency,
+			AppliedAmount: forfeit,
+			Notes:         stringPtr("Deposit retained"),
+			PaymentDate:   at,
+			ReceivedBy:    userID,
+		})
+	}
+
+	reservation.AmountPaid = roundAmount(reservation.AmountPaid - refund)
+	reservation.ForfeitedAmount = roundAmount(reservation.ForfeitedAmount + forfeit)
+	reservation.Balance = 0
+	return settlements
+}
+
+// lastPaymentMethod returns the method of the latest payment received
+func lastPaymentMethod(payments []domain.ReservationPayment) *domain.PaymentMethod {
+	var last *domain.ReservationPayment
+	for i := range payments {
+		payment := &payments[i]
+		if payment.PaymentMethod == nil {
+			continue
+		}
+		if payment.PaymentType != domain.ReservationPaymentDeposit &&
+			payment.PaymentType != domain.ReservationPaymentInstallment {
+			continue
+		}
+		if last == nil || !payment.PaymentDate.Before(last.PaymentDate) {
+			last = payment
+		}
+	}
+
+	if last == nil {
+		return nil
+	}
+	return last.PaymentMethod
+}
+
+// reservationHoldsStock checks whether a reservation in this status still holds stock
+func reservationHoldsStock(status domain.ReservationStatus) bool {
+	return status == domain.ReservationStatusPending ||
+		status == domain.ReservationStatusConfirmed ||
+		status == domain.ReservationStatusPartiallyFulfilled
+}
+
+// resizeReservationItem changes the quantity of an item keeping its unit
+// price and per-unit discount, so units already picked up keep their value
+func resizeReservationItem(item *domain.ReservationItem, quantity float64) {
+	if item.Quantity > 0 {
+		item.DiscountAmount = roundAmount(item.DiscountAmount * quantity / item.Quantity)
+	}
+	item.Quantity = quantity
+	item.TotalAmount = roundAmount(quantity*item.UnitPrice - item.DiscountAmount)
+	item.ReservedQuantity = math.Max(quantity-item.FulfilledQuantity, 0)
+	item.IsFulfilled = item.FulfilledQuantity >= quantity
+}
+
+// repriceReservation recalculates the totals of a reservation from its items.
+// The new total cannot leave less to pick up than what was already paid.
+func repriceReservation(reservation *domain.Reservation, items []domain.ReservationItem) error {
+	total, pending := 0.0, 0.0
+	for i := range items {
+		total += items[i].TotalAmount
+		pending += items[i].TotalAmount - reservationItemValue(&items[i], items[i].FulfilledQuantity)
+	}
+
+	held := unappliedPayments(reservation)
+	if roundAmount(pending) < held {
+		return errors.InvalidInput(fmt.Sprintf(
+			"The items left to pick up (%.2f %s) would be worth less than the %.2f %s already paid",
+			pending, reservation.Currency, held, reservation.Currency,
+		))
+	}
+
+	reservation.TotalAmount = roundAmount(total)
+	reservation.DepositAmount = math.Min(reservation.DepositAmount, reservation.TotalAmount)
+	reservation.Balance = reservationBalance(reservation, items)
+	return nil
+}
+
+// extendedExpiration validates an extension against the reservation policy and
+// returns the new expiration date
+func extendedExpiration(reservation *domain.Reservation, days int, now time.Time) (time.Time, error) {
+	if days <= 0 {
+		return time.Time{}, errors.InvalidInput("Extension days must be positive")
+	}
+
+	if days > maxReservationExtensionDays {
+		return time.Time{}, errors.InvalidInput(fmt.Sprintf("A reservation can be extended by at most %d days at a time", maxReservationExtensionDays))
+	}
+
+	if reservation.ExtensionCount >= maxReservationExtensions {
+		return time.Time{}, errors.InvalidInput(fmt.Sprintf("Reservation was already extended %d times", reservation.ExtensionCount))
+	}
+
+	if reservation.ExpirationDate.Before(now) {
+		return time.Time{}, errors.Expired("Reservation")
+	}
+
+	newExpiration := reservation.ExpirationDate.AddDate(0, 0, days)
+	limit := reservation.ReservationDate.AddDate(0, 0, maxReservationLifetimeDays)
+	if newExpiration.After(limit) {
+		return time.Time{}, errors.InvalidInput(fmt.Sprintf(
+			"A reservation cannot be held beyond %s (%d days after it was made)",
+			limit.Format("2006-01-02"), maxReservationLifetimeDays,
+		))
+	}
+
+	return newExpiration, nil
+}
+
+// reservationAllocationItems returns the quantities of reservation items to allocate
+func reservationAllocationItems(items []domain.ReservationItem) []services.AllocationItem {
+	allocationItems := make([]services.AllocationItem, len(items))
+	for i, item := range items {
+		allocationItems[i] = services.AllocationItem{ProductID: item.ProductID, Quantity: item.Quantity}
+	}
+	return allocationItems
+}
+
+// splitReservationItems assigns each item the warehouse the plan holds it in.
+// An item held in several warehouses is split into one item per warehouse,
+// prorating its discount.
+func splitReservationItems(items []domain.ReservationItem, plan *services.AllocationPlan) []domain.ReservationItem {
+	pending := make([]int, len(items))
+	for _, line := range plan.Lines {
+		pending[line.ItemIndex]++
+	}
+
+	split := make([]domain.ReservationItem, 0, len(plan.Lines))
+	started := make([]bool, len(items))
+	discounted := make([]float64, len(items))
+	for _, line := range plan.Lines {
+		original := items[line.ItemIndex]
+		item := original
+		if started[line.ItemIndex] {
+			item.ReservationItemID = uuid.New()
+		}
+		started[line.ItemIndex] = true
+
+		warehouseID := line.WarehouseID
+		item.WarehouseID = &warehouseID
+		item.Quantity = line.Quantity
+		if original.ReservedQuantity > 0 {
+			item.ReservedQuantity = line.Quantity
+		}
+
+		pending[line.ItemIndex]--
+		item.DiscountAmount = proratedDiscount(original.DiscountAmount, original.Quantity, line.Quantity,
+			discounted[line.ItemIndex], pending[line.ItemIndex] == 0)
+		discounted[line.ItemIndex] += item.DiscountAmount
+		item.TotalAmount = roundAmount(item.Quantity*item.UnitPrice - item.DiscountAmount)
+
+		split = append(split, item)
+	}
+	return split
+}
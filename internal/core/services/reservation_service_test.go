@@ -0,0 +1,335 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+func TestPlanPickup(t *testing.T) {
+	notebooks := domain.ReservationItem{ReservationItemID: uuid.New(), Quantity: 10, FulfilledQuantity: 4}
+	pencils := domain.ReservationItem{ReservationItemID: uuid.New(), Quantity: 2, FulfilledQuantity: 2, IsFulfilled: true}
+	items := []domain.ReservationItem{notebooks, pencils}
+
+	t.Run("empty request takes everything left", func(t *testing.T) {
+		pickups, err := planPickup(items, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []reservationPickup{{index: 0, quantity: 6}}, pickups)
+	})
+
+	t.Run("partial quantity", func(t *testing.T) {
+		pickups, err := planPickup(items, []services.FulfillReservationItem{
+			{ReservationItemID: notebooks.ReservationItemID, Quantity: 3},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []reservationPickup{{index: 0, quantity: 3}}, pickups)
+	})
+
+	t.Run("more than remains", func(t *testing.T) {
+		_, err := planPickup(items, []services.FulfillReservationItem{
+			{ReservationItemID: notebooks.ReservationItemID, Quantity: 7},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown item", func(t *testing.T) {
+		_, err := planPickup(items, []services.FulfillReservationItem{
+			{ReservationItemID: uuid.New(), Quantity: 1},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("duplicated item", func(t *testing.T) {
+		_, err := planPickup(items, []services.FulfillReservationItem{
+			{ReservationItemID: notebooks.ReservationItemID, Quantity: 1},
+			{ReservationItemID: notebooks.ReservationItemID, Quantity: 1},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("nothing left", func(t *testing.T) {
+		_, err := planPickup([]domain.ReservationItem{pencils}, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestReservationItemValue(t *testing.T) {
+	item := &domain.ReservationItem{Quantity: 3, TotalAmount: 10}
+
+	// Pickups one unit at a time add up to the item total
+	total := 0.0
+	for picked := 0.0; picked < item.Quantity; picked++ {
+		total += reservationItemValue(item, picked+1) - reservationItemValue(item, picked)
+	}
+	assert.InDelta(t, 10, total, 0.0001)
+	assert.Equal(t, 3.33, reservationItemValue(item, 1))
+}
+
+func TestDepositForPickup(t *testing.T) {
+	reservation := &domain.Reservation{TotalAmount: 100, DepositAmount: 30, AmountPaid: 30}
+
+	t.Run("proportional", func(t *testing.T) {
+		assert.Equal(t, 12.0, depositForPickup(reservation, 40, false, domain.DepositApplicationProportional))
+	})
+
+	t.Run("first pickup", func(t *testing.T) {
+		assert.Equal(t, 30.0, depositForPickup(reservation, 40, false, domain.DepositApplicationFirstPickup))
+		assert.Equal(t, 20.0, depositForPickup(reservation, 20, false, domain.DepositApplicationFirstPickup))
+	})
+
+	t.Run("completing pickup takes the rest", func(t *testing.T) {
+		partly := &domain.Reservation{TotalAmount: 100, DepositAmount: 30, AmountPaid: 30, DepositApplied: 12}
+		assert.Equal(t, 18.0, depositForPickup(partly, 60, true, domain.DepositApplicationProportional))
+	})
+
+	t.Run("deposit used up", func(t *testing.T) {
+		used := &domain.Reservation{TotalAmount: 100, DepositAmount: 30, AmountPaid: 30, DepositApplied: 30}
+		assert.Zero(t, depositForPickup(used, 60, true, domain.DepositApplicationFirstPickup))
+	})
+}
+
+func TestReservationBalance(t *testing.T) {
+	reservation := &domain.Reservation{TotalAmount: 100, DepositAmount: 30, AmountPaid: 30, DepositApplied: 12}
+	items := []domain.ReservationItem{
+		{Quantity: 4, FulfilledQuantity: 4, TotalAmount: 40, IsFulfilled: true},
+		{Quantity: 6, FulfilledQuantity: 0, TotalAmount: 60},
+	}
+
+	assert.Equal(t, 42.0, reservationBalance(reservation, items))
+	assert.False(t, allReservationItemsFulfilled(items))
+
+	items[1].FulfilledQuantity = 6
+	items[1].IsFulfilled = true
+	reservation.DepositApplied = 30
+	assert.Zero(t, reservationBalance(reservation, items))
+	assert.True(t, allReservationItemsFulfilled(items))
+}
+
+func TestNewReservationPayment(t *testing.T) {
+	reservation := &domain.Reservation{
+		ReservationID: uuid.New(),
+		Currency:      domain.CurrencyVES,
+		TotalAmount:   1000,
+		Balance:       1000,
+	}
+	userID := uuid.New()
+
+	t.Run("converts foreign currency", func(t *testing.T) {
+		payment, err := newReservationPayment(reservation, services.ReservationPaymentRequest{
+			Amount:        10,
+			Currency:      domain.CurrencyUSD,
+			ExchangeRate:  float64Ptr(40),
+			PaymentMethod: domain.PaymentMethodForeignCurrency,
+			UserID:        userID,
+		}, domain.ReservationPaymentDeposit)
+		require.NoError(t, err)
+		assert.Equal(t, 400.0, payment.AppliedAmount)
+		assert.Equal(t, domain.ReservationPaymentDeposit, payment.PaymentType)
+		assert.Equal(t, userID, *payment.ReceivedBy)
+	})
+
+	t.Run("requires a method", func(t *testing.T) {
+		_, err := newReservationPayment(reservation, services.ReservationPaymentRequest{Amount: 10}, domain.ReservationPaymentDeposit)
+		assert.Error(t, err)
+	})
+
+	t.Run("cannot exceed the balance", func(t *testing.T) {
+		_, err := newReservationPayment(reservation, services.ReservationPaymentRequest{
+			Amount:        1000.01,
+			PaymentMethod: domain.PaymentMethodCash,
+		}, domain.ReservationPaymentInstallment)
+		assert.Error(t, err)
+	})
+}
+
+func TestApplyReservationPayment(t *testing.T) {
+	reservation := &domain.Reservation{
+		TotalAmount: 100,
+		Balance:     100,
+		Items:       []domain.ReservationItem{{Quantity: 10, TotalAmount: 100}},
+	}
+
+	applyReservationPayment(reservation, 30)
+	assert.Equal(t, 30.0, reservation.AmountPaid)
+	assert.Equal(t, 70.0, reservation.Balance)
+}
+
+func TestSettlementAmounts(t *testing.T) {
+	// Deposit of 30 plus an installment of 20, nothing picked up
+	reservation := &domain.Reservation{TotalAmount: 100, DepositAmount: 30, AmountPaid: 50}
+
+	refund, forfeit := settlementAmounts(reservation, false)
+	assert.Equal(t, 50.0, refund)
+	assert.Zero(t, forfeit)
+
+	refund, forfeit = settlementAmounts(reservation, true)
+	assert.Equal(t, 20.0, refund)
+	assert.Equal(t, 30.0, forfeit)
+
+	// Part of the deposit was already credited to a pickup
+	reservation.DepositApplied = 12
+	refund, forfeit = settlementAmounts(reservation, true)
+	assert.Equal(t, 20.0, refund)
+	assert.Equal(t, 18.0, forfeit)
+
+	settlements := settleReservation(reservation, refund, forfeit, nil, nil, nil, time.Now())
+	require.Len(t, settlements, 2)
+	assert.Equal(t, domain.ReservationPaymentRefund, settlements[0].PaymentType)
+	assert.Equal(t, domain.ReservationPaymentForfeit, settlements[1].PaymentType)
+	assert.Equal(t, 30.0, reservation.AmountPaid)
+	assert.Equal(t, 18.0, reservation.ForfeitedAmount)
+	assert.Zero(t, unappliedPayments(reservation))
+}
+
+func TestLastPaymentMethod(t *testing.T) {
+	cash := domain.PaymentMethodCash
+	transfer := domain.PaymentMethodBankTransfer
+	now := time.Now()
+
+	payments := []domain.ReservationPayment{
+		{PaymentType: domain.ReservationPaymentDeposit, PaymentMethod: &cash, PaymentDate: now.Add(-48 * time.Hour)},
+		{PaymentType: domain.ReservationPaymentInstallment, PaymentMethod: &transfer, PaymentDate: now.Add(-24 * time.Hour)},
+		{PaymentType: domain.ReservationPaymentRefund, PaymentMethod: &cash, PaymentDate: now},
+	}
+
+	method := lastPaymentMethod(payments)
+	require.NotNil(t, method)
+	assert.Equal(t, transfer, *method)
+	assert.Nil(t, lastPaymentMethod(nil))
+}
+
+func TestResizeReservationItem(t *testing.T) {
+	item := &domain.ReservationItem{Quantity: 10, FulfilledQuantity: 4, ReservedQuantity: 6, UnitPrice: 5, DiscountAmount: 10, TotalAmount: 40}
+
+	resizeReservationItem(item, 6)
+	assert.Equal(t, 6.0, item.Quantity)
+	assert.Equal(t, 6.0, item.DiscountAmount)
+	assert.Equal(t, 24.0, item.TotalAmount)
+	assert.Equal(t, 2.0, item.ReservedQuantity)
+	assert.False(t, item.IsFulfilled)
+
+	// Units already picked up keep their value
+	assert.Equal(t, 16.0, reservationItemValue(item, 4))
+
+	resizeReservationItem(item, 4)
+	assert.Zero(t, item.ReservedQuantity)
+	assert.True(t, item.IsFulfilled)
+}
+
+func TestRepriceReservation(t *testing.T) {
+	items := []domain.ReservationItem{
+		{Quantity: 2, TotalAmount: 20},
+		{Quantity: 1, TotalAmount: 15},
+	}
+
+	t.Run("recalculates totals", func(t *testing.T) {
+		reservation := &domain.Reservation{TotalAmount: 100, DepositAmount: 50, AmountPaid: 20}
+		require.NoError(t, repriceReservation(reservation, items))
+		assert.Equal(t, 35.0, reservation.TotalAmount)
+		assert.Equal(t, 35.0, reservation.DepositAmount)
+		assert.Equal(t, 15.0, reservation.Balance)
+	})
+
+	t.Run("cannot go below what was paid", func(t *testing.T) {
+		reservation := &domain.Reservation{TotalAmount: 100, DepositAmount: 50, AmountPaid: 50}
+		assert.Error(t, repriceReservation(reservation, items))
+		assert.Equal(t, 100.0, reservation.TotalAmount)
+	})
+}
+
+func TestExtendedExpiration(t *testing.T) {
+	now := time.Date(2026, time.August, 10, 12, 0, 0, 0, time.UTC)
+	reservation := &domain.Reservation{
+		ReservationDate: time.Date(2026, time.August, 1, 12, 0, 0, 0, time.UTC),
+		ExpirationDate:  time.Date(2026, time.August, 15, 12, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("within policy", func(t *testing.T) {
+		expiration, err := extendedExpiration(reservation, 7, now)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2026, time.August, 22, 12, 0, 0, 0, time.UTC), expiration)
+	})
+
+	t.Run("too many days at once", func(t *testing.T) {
+		_, err := extendedExpiration(reservation, maxReservationExtensionDays+1, now)
+		assert.Error(t, err)
+	})
+
+	t.Run("not positive", func(t *testing.T) {
+		_, err := extendedExpiration(reservation, 0, now)
+		assert.Error(t, err)
+	})
+
+	t.Run("extension limit reached", func(t *testing.T) {
+		extended := *reservation
+		extended.ExtensionCount = maxReservationExtensions
+		_, err := extendedExpiration(&extended, 1, now)
+		assert.Error(t, err)
+	})
+
+	t.Run("beyond the lifetime", func(t *testing.T) {
+		late := *reservation
+		late.ExpirationDate = time.Date(2026, time.September, 25, 12, 0, 0, 0, time.UTC)
+		_, err := extendedExpiration(&late, 10, now)
+		assert.Error(t, err)
+	})
+
+	t.Run("already past expiration", func(t *testing.T) {
+		_, err := extendedExpiration(reservation, 3, reservation.ExpirationDate.Add(time.Hour))
+		assert.Error(t, err)
+	})
+}
+
+func TestReservationHoldsStock(t *testing.T) {
+	assert.True(t, reservationHoldsStock(domain.ReservationStatusPending))
+	assert.True(t, reservationHoldsStock(domain.ReservationStatusPartiallyFulfilled))
+	assert.False(t, reservationHoldsStock(domain.ReservationStatusFulfilled))
+	assert.False(t, reservationHoldsStock(domain.ReservationStatusExpired))
+}
+
+func TestSplitReservationItems(t *testing.T) {
+	productID := uuid.New()
+	primary, near := uuid.New(), uuid.New()
+	item := domain.ReservationItem{
+		ReservationItemID: uuid.New(),
+		ProductID:         productID,
+		Quantity:          5,
+		ReservedQuantity:  5,
+		UnitPrice:         2,
+		DiscountAmount:    1,
+		TotalAmount:       9,
+	}
+
+	t.Run("item held in one warehouse keeps its ID", func(t *testing.T) {
+		split := splitReservationItems([]domain.ReservationItem{item}, &services.AllocationPlan{Lines: []services.AllocationLine{
+			{ItemIndex: 0, ProductID: productID, WarehouseID: primary, Quantity: 5},
+		}})
+
+		require.Len(t, split, 1)
+		assert.Equal(t, item.ReservationItemID, split[0].ReservationItemID)
+		assert.Equal(t, primary, *split[0].WarehouseID)
+		assert.Equal(t, 9.0, split[0].TotalAmount)
+	})
+
+	t.Run("item split across warehouses", func(t *testing.T) {
+		split := splitReservationItems([]domain.ReservationItem{item}, &services.AllocationPlan{Lines: []services.AllocationLine{
+			{ItemIndex: 0, ProductID: productID, WarehouseID: primary, Quantity: 4},
+			{ItemIndex: 0, ProductID: productID, WarehouseID: near, Quantity: 1},
+		}})
+
+		require.Len(t, split, 2)
+		assert.Equal(t, item.ReservationItemID, split[0].ReservationItemID)
+		assert.NotEqual(t, item.ReservationItemID, split[1].ReservationItemID)
+		assert.Equal(t, 4.0, split[0].ReservedQuantity)
+		assert.Equal(t, 1.0, split[1].ReservedQuantity)
+		assert.Equal(t, 0.8, split[0].DiscountAmount)
+		assert.Equal(t, 0.2, split[1].DiscountAmount)
+		assert.Equal(t, item.TotalAmount, split[0].TotalAmount+split[1].TotalAmount)
+	})
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,14 +13,20 @@ import (
 	"github.com/jadiazinf/inventory/internal/core/domain"
 	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
 	"github.com/jadiazinf/inventory/internal/core/ports/services"
+	"github.com/jadiazinf/inventory/internal/platform/database"
 )
 
 type saleService struct {
-	saleRepo      repositories.SaleRepository
-	productRepo   repositories.ProductRepository
-	inventoryRepo repositories.InventoryRepository
-	customerRepo  repositories.CustomerRepository
-	db            *gorm.DB
+	saleRepo       repositories.SaleRepository
+	productRepo    repositories.ProductRepository
+	inventoryRepo  repositories.InventoryRepository
+	customerRepo   repositories.CustomerRepository
+	pricingSvc     services.PricingService
+	loyaltySvc     services.LoyaltyService
+	storedValueSvc services.StoredValueService
+	allocationSvc  services.AllocationService
+	arSvc          services.AccountsReceivableService
+	db             *gorm.DB
 }
 
 // NewSaleService creates a new sale service
@@ -28,19 +35,35 @@ func NewSaleService(
 	productRepo repositories.ProductRepository,
 	inventoryRepo repositories.InventoryRepository,
 	customerRepo repositories.CustomerRepository,
+	pricingSvc services.PricingService,
+	loyaltySvc services.LoyaltyService,
+	storedValueSvc services.StoredValueService,
+	allocationSvc services.AllocationService,
+	arSvc services.AccountsReceivableService,
 	db *gorm.DB,
 ) services.SaleService {
 	return &saleService{
-		saleRepo:      saleRepo,
-		productRepo:   productRepo,
-		inventoryRepo: inventoryRepo,
-		customerRepo:  customerRepo,
-		db:            db,
+		saleRepo:       saleRepo,
+		productRepo:    productRepo,
+		inventoryRepo:  inventoryRepo,
+		customerRepo:   customerRepo,
+		pricingSvc:     pricingSvc,
+		loyaltySvc:     loyaltySvc,
+		storedValueSvc: storedValueSvc,
+		allocationSvc:  allocationSvc,
+		arSvc:          arSvc,
+		db:             db,
 	}
 }
 
 // CreateSale creates a new sale
 func (s *saleService) CreateSale(ctx context.Context, req services.CreateSaleRequest) (*domain.Sale, error) {
+	return s.createSale(ctx, req, nil)
+}
+
+// createSale creates a sale. approve, when set, is called with the amount left
+// unpaid by the tenders once prices are known and before stock is moved.
+func (s *saleService) createSale(ctx context.Context, req services.CreateSaleRequest, approve func(amount float64) error) (*domain.Sale, error) {
 	// Validate customer if provided
 	if req.CustomerID != nil {
 		_, err := s.customerRepo.FindByID(ctx, *req.CustomerID)
@@ -56,6 +79,8 @@ func (s *saleService) CreateSale(ctx context.Context, req services.CreateSaleReq
 
 	// Build sale details and validate
 	saleDetails := make([]domain.SaleDetail, 0, len(req.Items))
+	pricingLines := make([]services.PricingLine, 0, len(req.Items))
+	allocationItems := make([]services.AllocationItem, 0, len(req.Items))
 
 	for _, itemReq := range req.Items {
 		// Validate product
@@ -68,21 +93,6 @@ func (s *saleService) CreateSale(ctx context.Context, req services.CreateSaleReq
 			return nil, errors.InvalidInput(fmt.Sprintf("Product %s is not active", product.Name))
 		}
 
-		// Check inventory availability
-		available, err := s.inventoryRepo.CheckAvailability(ctx, itemReq.ProductID, req.WarehouseID, itemReq.Quantity)
-		if err != nil {
-			return nil, err
-		}
-
-		if !available {
-			inventory, _ := s.inventoryRepo.GetByProductAndWarehouse(ctx, itemReq.ProductID, req.WarehouseID)
-			availableQty := 0.0
-			if inventory != nil {
-				availableQty = inventory.AvailableQuantity
-			}
-			return nil, errors.InsufficientStock(product.Name, availableQty, itemReq.Quantity)
-		}
-
 		// Determine unit price
 		unitPrice := product.SellingPrice
 		if itemReq.UnitPrice != nil {
@@ -97,6 +107,76 @@ func (s *saleService) CreateSale(ctx context.Context, req services.CreateSaleReq
 		}
 
 		saleDetails = append(saleDetails, saleDetail)
+		pricingLines = append(pricingLines, services.PricingLine{
+			Product:        product,
+			Quantity:       itemReq.Quantity,
+			UnitPrice:      unitPrice,
+			ManualDiscount: itemReq.DiscountAmount,
+		})
+		allocationItems = append(allocationItems, services.AllocationItem{
+			ProductID: itemReq.ProductID,
+			Quantity:  itemReq.Quantity,
+		})
+	}
+
+	// Choose the warehouses serving the items, unless the caller pinned one
+	plan, err := s.allocationSvc.Allocate(ctx, services.AllocationRequest{
+		StoreID:     req.StoreID,
+		Items:       allocationItems,
+		WarehouseID: req.WarehouseID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply active promotions, unless prices were locked by a quotation
+	var pricing *services.PricingResult
+	if req.QuotationID != nil {
+		pricing = lockedPricing(pricingLines, campaignIDs(req.Items))
+	} else {
+		var err error
+		pricing, err = s.pricingSvc.PriceLines(ctx, services.PricingRequest{
+			StoreID: &req.StoreID,
+			Lines:   pricingLines,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	estimatedTotal := -req.DiscountAmount
+	for i, line := range pricing.Lines {
+		saleDetails[i].DiscountAmount = line.DiscountAmount
+		saleDetails[i].CampaignID = line.CampaignID
+		estimatedTotal += line.Quantity*line.UnitPrice - line.DiscountAmount
+	}
+	saleDetails = splitSaleDetails(saleDetails, plan)
+
+	// Validate non-cash tenders before touching inventory
+	tendered := 0.0
+	if req.RedeemPoints > 0 {
+		if req.CustomerID == nil {
+			return nil, errors.InvalidInput("Customer is required to redeem loyalty points")
+		}
+		value, err := s.loyaltySvc.QuoteRedemption(ctx, *req.CustomerID, req.RedeemPoints, req.Currency)
+		if err != nil {
+			return nil, err
+		}
+		tendered += value
+	}
+	if len(req.StoredValueTenders) > 0 {
+		value, err := s.storedValueSvc.QuoteTenders(ctx, req.CustomerID, req.Currency, req.ExchangeRate, req.StoredValueTenders)
+		if err != nil {
+			return nil, err
+		}
+		tendered += value
+	}
+	if roundAmount(tendered) > roundAmount(estimatedTotal) {
+		return nil, errors.InvalidInput("Tenders exceed the sale total")
+	}
+	if approve != nil {
+		if err := approve(roundAmount(estimatedTotal - tendered)); err != nil {
+			return nil, err
+		}
 	}
 
 	// Create sale
@@ -104,7 +184,7 @@ func (s *saleService) CreateSale(ctx context.Context, req services.CreateSaleReq
 		SaleID:           uuid.New(),
 		CustomerID:       req.CustomerID,
 		StoreID:          &req.StoreID,
-		WarehouseID:      &req.WarehouseID,
+		WarehouseID:      &allocatedWarehouses(plan)[0],
 		SaleType:         req.SaleType,
 		Status:           domain.SaleStatusCompleted,
 		Currency:         req.Currency,
@@ -116,56 +196,241 @@ func (s *saleService) CreateSale(ctx context.Context, req services.CreateSaleReq
 		SalespersonID:    &req.SalespersonID,
 	}
 
-	// Create sale with details (transaction handled in repository)
-	if err := s.saleRepo.CreateWithDetails(ctx, sale, saleDetails); err != nil {
+	// Create the sale, its campaign usage, tenders, earned points and the
+	// customer's purchases atomically, so a failure leaves neither the sale
+	// nor its stock movements
+	var created *domain.Sale
+	err = database.Transaction(ctx, s.db, func(ctx context.Context) error {
+		if err := s.saleRepo.CreateWithDetails(ctx, sale, saleDetails); err != nil {
+			return err
+		}
+
+		// Accumulate campaign sales and consumed budget; a campaign whose
+		// budget ran out since pricing rejects the sale
+		if err := s.pricingSvc.RecordApplications(ctx, pricing.Applications); err != nil {
+			return err
+		}
+
+		// Loyalty points and stored value redeemed as tenders
+		if req.RedeemPoints > 0 {
+			if _, err := s.loyaltySvc.RedeemForSale(ctx, sale.SaleID, req.RedeemPoints, req.SalespersonID); err != nil {
+				return err
+			}
+		}
+		if len(req.StoredValueTenders) > 0 {
+			if _, err := s.storedValueSvc.RedeemForSale(ctx, sale.SaleID, req.StoredValueTenders, req.SalespersonID); err != nil {
+				return err
+			}
+		}
+
+		// Loyalty points earned on the part not paid with points
+		if _, err := s.loyaltySvc.EarnForSale(ctx, sale.SaleID, &req.SalespersonID); err != nil {
+			return err
+		}
+
+		// Reload with details and totals
+		var err error
+		created, err = s.saleRepo.FindByID(ctx, sale.SaleID)
+		if err != nil {
+			return err
+		}
+
+		return s.recordPurchases(ctx, created, 1)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// Reload with details
-	return s.saleRepo.FindByID(ctx, sale.SaleID)
+	return created, nil
+}
+
+// recordPurchases adds the sale total, in VES, to the customer's total purchases,
+// or takes it back when sign is negative
+func (s *saleService) recordPurchases(ctx context.Context, sale *domain.Sale, sign float64) error {
+	if sale.CustomerID == nil {
+		return nil
+	}
+
+	amount, err := convertAmount(sale.TotalAmount, sale.Currency, domain.CurrencyVES, sale.ExchangeRate)
+	if err != nil {
+		return err
+	}
+
+	return s.customerRepo.UpdateTotalPurchases(ctx, *sale.CustomerID, sign*amount)
+}
+
+// splitSaleDetails assigns each detail the warehouse the plan took it from.
+// A detail served by several warehouses is split, prorating its discount.
+func splitSaleDetails(details []domain.SaleDetail, plan *services.AllocationPlan) []domain.SaleDetail {
+	pending := make([]int, len(details))
+	for _, line := range plan.Lines {
+		pending[line.ItemIndex]++
+	}
+
+	split := make([]domain.SaleDetail, 0, len(plan.Lines))
+	discounted := make([]float64, len(details))
+	for _, line := range plan.Lines {
+		original := details[line.ItemIndex]
+		detail := original
+		warehouseID := line.WarehouseID
+		detail.WarehouseID = &warehouseID
+		detail.Quantity = line.Quantity
+
+		pending[line.ItemIndex]--
+		detail.DiscountAmount = proratedDiscount(original.DiscountAmount, original.Quantity, line.Quantity,
+			discounted[line.ItemIndex], pending[line.ItemIndex] == 0)
+		discounted[line.ItemIndex] += detail.DiscountAmount
+
+		split = append(split, detail)
+	}
+	return split
+}
+
+// campaignIDs returns the campaign locked on each sale item
+func campaignIDs(items []services.SaleItem) []*uuid.UUID {
+	ids := make([]*uuid.UUID, len(items))
+	for i, item := range items {
+		ids[i] = item.CampaignID
+	}
+	return ids
 }
 
-// CreateCreditSale creates a credit sale with accounts receivable
-func (s *saleService) CreateCreditSale(ctx context.Context, req services.CreateSaleRequest, creditDays int) (*domain.Sale, *domain.AccountsReceivable, error) {
+// CreateCreditSale creates a credit sale with accounts receivable.
+// The sale must fit the customer's credit limit and terms unless a supervisor overrides them.
+func (s *saleService) CreateCreditSale(ctx context.Context, req services.CreateSaleRequest, terms services.CreditTerms) (*domain.Sale, *domain.AccountsReceivable, error) {
 	// Customer is required for credit sales
 	if req.CustomerID == nil {
 		return nil, nil, errors.InvalidInput("Customer is required for credit sales")
 	}
 
-	// Validate customer
-	_, err := s.customerRepo.FindByID(ctx, *req.CustomerID)
+	// The sale and its receivable are created with the customer locked, so
+	// concurrent credit sales are evaluated one at a time, each one seeing
+	// the debt of the others
+	var sale *domain.Sale
+	var receivable *domain.AccountsReceivable
+	err := database.Transaction(ctx, s.db, func(ctx context.Context) error {
+		var err error
+		sale, receivable, err = s.createCreditSale(ctx, req, terms)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return sale, receivable, nil
+}
+
+// createCreditSale creates a credit sale and its receivable, within the
+// transaction carried by ctx
+func (s *saleService) createCreditSale(ctx context.Context, req services.CreateSaleRequest, terms services.CreditTerms) (*domain.Sale, *domain.AccountsReceivable, error) {
+	customer, err := s.customerRepo.FindByIDForUpdate(ctx, *req.CustomerID)
 	if err != nil {
-		return nil, nil, errors.NotFoundWithID("Customer", req.CustomerID.String())
+		return nil, nil, err
+	}
+
+	// Suspended or inactive customers cannot buy on credit, even with an override
+	if customer.Status != domain.CustomerStatusActive {
+		return nil, nil, errors.Conflict(fmt.Sprintf("Customer is %s and cannot buy on credit", customer.Status))
+	}
+
+	// Credit days default to the customer's terms
+	now := time.Now()
+	creditDays := customer.CreditDays
+	if terms.CreditDays != nil {
+		if *terms.CreditDays < 0 {
+			return nil, nil, errors.InvalidInput("Credit days cannot be negative")
+		}
+		creditDays = *terms.CreditDays
+	}
+
+	// An installment sale is financed until its last installment falls due
+	if terms.Installments != nil {
+		if terms.CreditDays != nil {
+			return nil, nil, errors.InvalidInput("Credit days cannot be combined with installments")
+		}
+		if err := validateInstallmentTerms(*terms.Installments); err != nil {
+			return nil, nil, err
+		}
+		lastDueDate := installmentDueDate(now, terms.Installments.Frequency, terms.Installments.Count)
+		creditDays = daysPastDue(now, lastDueDate)
+	}
+
+	if terms.Override != nil {
+		if err := s.arSvc.AuthorizeCreditOverride(ctx, *terms.Override, req.StoreID); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	// Set sale type to credit
 	req.SaleType = domain.SaleTypeCredit
 
-	// Create the sale
-	sale, err := s.CreateSale(ctx, req)
+	// Check the unpaid amount against the customer's credit before creating the sale
+	var check *services.CreditCheck
+	sale, err := s.createSale(ctx, req, func(amount float64) error {
+		var err error
+		check, err = s.arSvc.EvaluateCredit(ctx, customer, amount, req.Currency, req.ExchangeRate, creditDays, now)
+		if err != nil {
+			return err
+		}
+		if len(check.Violations) > 0 && terms.Override == nil {
+			return errors.InvalidInputWithDetails("Credit sale exceeds the customer's credit terms; a supervisor override is required", check.Violations)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// Calculate due date
-	dueDate := time.Now().AddDate(0, 0, creditDays)
+	dueDate := now.AddDate(0, 0, creditDays)
 
 	// Create accounts receivable
 	ar := &domain.AccountsReceivable{
-		ReceivableID:  uuid.New(),
-		SaleID:        &sale.SaleID,
-		CustomerID:    *req.CustomerID,
-		TotalAmount:   sale.TotalAmount,
-		PaidAmount:    0,
-		Balance:      sale.TotalAmount,
-		Currency:      sale.Currency,
-		DueDate:       dueDate,
-		Status:        domain.AccountStatusPending,
+		ReceivableID: uuid.New(),
+		SaleID:       &sale.SaleID,
+		CustomerID:   *req.CustomerID,
+		TotalAmount:  sale.TotalAmount - sale.TenderedAmount(),
+		PaidAmount:   0,
+		Balance:      sale.TotalAmount - sale.TenderedAmount(),
+		Currency:     sale.Currency,
+		DueDate:      dueDate,
+		Status:       domain.AccountStatusPending,
+		Kind:         domain.ReceivableKindSale,
+	}
+
+	// Record the supervisor's approval when the sale broke the customer's terms
+	var override *domain.CreditOverride
+	if terms.Override != nil && len(check.Violations) > 0 {
+		override = &domain.CreditOverride{
+			OverrideID:   uuid.New(),
+			SaleID:       sale.SaleID,
+			CustomerID:   customer.CustomerID,
+			SupervisorID: terms.Override.SupervisorID,
+			Reason:       strings.TrimSpace(terms.Override.Reason),
+			Violations:   strings.Join(check.Violations, "\n"),
+			CreditLimit:  check.CreditLimit,
+			Outstanding:  check.Outstanding,
+			Amount:       check.Amount,
+		}
+		ar.Notes = stringPtr(fmt.Sprintf("Credit override: %s", override.Reason))
 	}
 
-	// Create AR record directly
-	if err := s.db.WithContext(ctx).Create(ar).Error; err != nil {
-		return sale, nil, errors.WrapError(err, "failed to create accounts receivable")
+	if terms.Installments != nil {
+		plan, err := buildInstallmentPlan(ar, *terms.Installments, now)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := s.arSvc.CreateInstallmentPlan(ctx, plan, override); err != nil {
+			return sale, nil, err
+		}
+
+		// The first installment stands for the plan in the response
+		first := plan.Installments[0]
+		first.InstallmentPlan = plan
+		return sale, &first, nil
+	}
+
+	if err := s.arSvc.CreateReceivable(ctx, ar, override); err != nil {
+		return sale, nil, err
 	}
 
 	return sale, ar, nil
@@ -201,12 +466,26 @@ func (s *saleService) CancelSale(ctx context.Context, id uuid.UUID, reason strin
 		return errors.InvalidInput(fmt.Sprintf("Cannot cancel sale with status %s", sale.Status))
 	}
 
-	// Cancel sale (reverses inventory via repository)
-	if err := s.saleRepo.Cancel(ctx, id); err != nil {
-		return err
-	}
+	// Cancel the sale (reversing its inventory), its points, its stored value
+	// tenders and the customer's purchases together, so a failure leaves the
+	// sale completed and the cancel can be retried
+	return database.Transaction(ctx, s.db, func(ctx context.Context) error {
+		if err := s.saleRepo.Cancel(ctx, id); err != nil {
+			return err
+		}
+
+		// Refund redeemed points and take back earned ones
+		if err := s.loyaltySvc.ReverseSale(ctx, id, nil); err != nil {
+			return err
+		}
+
+		// Return gift card and store credit tenders to their accounts
+		if err := s.storedValueSvc.ReverseSale(ctx, id, nil); err != nil {
+			return err
+		}
 
-	return nil
+		return s.recordPurchases(ctx, sale, -1)
+	})
 }
 
 // GetDailySales retrieves sales for a specific date
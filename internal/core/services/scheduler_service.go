@@ -0,0 +1,313 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+// scheduledJob is a job registered in the scheduler
+type scheduledJob struct {
+	name     string
+	spec     string
+	schedule *cronSchedule
+	fn       services.JobFunc
+	next     time.Time
+}
+
+type schedulerService struct {
+	jobRunRepo repositories.JobRunRepository
+	instance   string
+
+	mu     sync.Mutex
+	jobs   map[string]*scheduledJob
+	wake   chan struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewSchedulerService creates a new background job scheduler
+func NewSchedulerService(jobRunRepo repositories.JobRunRepository) services.SchedulerService {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	return &schedulerService{
+		jobRunRepo: jobRunRepo,
+		instance:   fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		jobs:       make(map[string]*scheduledJob),
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// RegisterJob adds a job that runs on a cron expression
+func (s *schedulerService) RegisterJob(name, spec string, fn services.JobFunc) error {
+	if name == "" {
+		return errors.InvalidInput("Job name is required")
+	}
+	if fn == nil {
+		return errors.InvalidInput("Job function is required")
+	}
+
+	schedule, err := parseCronSchedule(spec)
+	if err != nil {
+		return errors.InvalidInput(err.Error())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.jobs[name]; exists {
+		return errors.AlreadyExists("Job", "name", name)
+	}
+
+	s.jobs[name] = &scheduledJob{
+		name:     name,
+		spec:     spec,
+		schedule: schedule,
+		fn:       fn,
+		next:     schedule.Next(time.Now()),
+	}
+
+	// Let a running loop pick up the new job
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Start runs the scheduling loop in the background until Stop is called or ctx is cancelled
+func (s *schedulerService) Start(ctx context.Context) {
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.mu.Unlock()
+		return
+	}
+	ctx, s.cancel = context.WithCancel(ctx)
+	jobCount := len(s.jobs)
+	s.mu.Unlock()
+
+	log.Printf("[SCHEDULER] Started on %s with %d jobs", s.instance, jobCount)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.loop(ctx)
+	}()
+}
+
+// Stop ends the scheduling loop and waits for the running jobs
+func (s *schedulerService) Stop() {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.cancel = nil
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	s.wg.Wait()
+
+	log.Printf("[SCHEDULER] Stopped")
+}
+
+func (s *schedulerService) loop(ctx context.Context) {
+	for {
+		wait := s.launchDueJobs(ctx, time.Now())
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// launchDueJobs starts the jobs whose next run has come and returns how long
+// to wait for the next one
+func (s *schedulerService) launchDueJobs(ctx context.Context, now time.Time) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var earliest time.Time
+	for _, job := range s.jobs {
+		if job.next.IsZero() {
+			continue
+		}
+
+		if !job.next.After(now) {
+			s.wg.Add(1)
+			go func(job *scheduledJob, slot time.Time) {
+				defer s.wg.Done()
+				if _, err := s.runJob(ctx, job, domain.JobTriggerScheduled, &slot, nil); err != nil && !isLockHeld(err) {
+					log.Printf("[ERROR] Job %s could not run: %v", job.name, err)
+				}
+			}(job, job.next)
+			job.next = job.schedule.Next(now)
+		}
+
+		if !job.next.IsZero() && (earliest.IsZero() || job.next.Before(earliest)) {
+			earliest = job.next
+		}
+	}
+
+	if earliest.IsZero() {
+		// Nothing scheduled, sleep until a job is registered
+		return time.Hour
+	}
+	return earliest.Sub(now)
+}
+
+// runJob executes a job while holding its lock and records the run. A
+// scheduled run first claims its slot, so each slot runs on one instance only.
+// It fails with a conflict when another instance is running the job or
+// already ran the slot.
+func (s *schedulerService) runJob(ctx context.Context, job *scheduledJob, trigger domain.JobTrigger, slot *time.Time, userID *uuid.UUID) (*domain.JobRun, error) {
+	release, acquired, err := s.jobRunRepo.AcquireLock(ctx, job.name)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, errors.Conflict(fmt.Sprintf("Job %s is already running", job.name))
+	}
+	defer release()
+
+	run := &domain.JobRun{
+		RunID:        uuid.New(),
+		JobName:      job.name,
+		Trigger:      trigger,
+		Status:       domain.JobRunStatusRunning,
+		ScheduledFor: slot,
+		StartedAt:    time.Now(),
+		Instance:     s.instance,
+		TriggeredBy:  userID,
+	}
+	if slot != nil {
+		claimed, err := s.jobRunRepo.ClaimSlot(ctx, run)
+		if err != nil {
+			return nil, err
+		}
+		if !claimed {
+			return nil, errors.Conflict(fmt.Sprintf("Job %s already ran for %s", job.name, slot.Format(time.RFC3339)))
+		}
+	} else if err := s.jobRunRepo.Create(ctx, run); err != nil {
+		return nil, err
+	}
+
+	processed, jobErr := executeJob(ctx, job.fn)
+	finishJobRun(run, processed, jobErr, time.Now())
+
+	if jobErr != nil {
+		log.Printf("[ERROR] Job %s failed: %v", job.name, jobErr)
+	} else {
+		log.Printf("[SCHEDULER] Job %s processed %d items in %dms", job.name, processed, run.DurationMs)
+	}
+
+	// Record the outcome even if the scheduler is stopping
+	if err := s.jobRunRepo.Update(context.WithoutCancel(ctx), run); err != nil {
+		log.Printf("[ERROR] Failed to record run of job %s: %v", job.name, err)
+	}
+
+	return run, nil
+}
+
+// ListJobs returns the registered jobs sorted by name with their last run
+func (s *schedulerService) ListJobs(ctx context.Context) ([]services.JobInfo, error) {
+	s.mu.Lock()
+	jobs := make([]services.JobInfo, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		info := services.JobInfo{Name: job.name, Schedule: job.spec}
+		if !job.next.IsZero() {
+			info.NextRun = timePtr(job.next)
+		}
+		jobs = append(jobs, info)
+	}
+	s.mu.Unlock()
+
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Name < jobs[j].Name })
+
+	for i := range jobs {
+		lastRun, err := s.jobRunRepo.GetLastRun(ctx, jobs[i].Name)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		jobs[i].LastRun = lastRun
+	}
+
+	return jobs, nil
+}
+
+// TriggerJob runs a job right away and waits for it to finish
+func (s *schedulerService) TriggerJob(ctx context.Context, name string, userID *uuid.UUID) (*domain.JobRun, error) {
+	s.mu.Lock()
+	job, exists := s.jobs[name]
+	s.mu.Unlock()
+
+	if !exists {
+		return nil, errors.NotFoundWithID("Job", name)
+	}
+
+	return s.runJob(ctx, job, domain.JobTriggerManual, nil, userID)
+}
+
+// GetRun retrieves a job run by ID
+func (s *schedulerService) GetRun(ctx context.Context, id uuid.UUID) (*domain.JobRun, error) {
+	return s.jobRunRepo.FindByID(ctx, id)
+}
+
+// ListRuns lists the job run history
+func (s *schedulerService) ListRuns(ctx context.Context, filters repositories.JobRunFilters, limit, offset int) ([]domain.JobRun, int64, error) {
+	return s.jobRunRepo.List(ctx, filters, limit, offset)
+}
+
+// Helper functions
+
+// executeJob calls a job function turning a panic into an error
+func executeJob(ctx context.Context, fn services.JobFunc) (processed int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
+
+// finishJobRun records the outcome of a run
+func finishJobRun(run *domain.JobRun, processed int, jobErr error, finishedAt time.Time) {
+	run.FinishedAt = &finishedAt
+	run.DurationMs = finishedAt.Sub(run.StartedAt).Milliseconds()
+	run.ItemsProcessed = processed
+	run.Status = domain.JobRunStatusSucceeded
+	if jobErr != nil {
+		run.Status = domain.JobRunStatusFailed
+		run.Error = stringPtr(jobErr.Error())
+	}
+}
+
+// isLockHeld reports whether a run was skipped because another instance holds the job lock
+func isLockHeld(err error) bool {
+	appErr, ok := err.(*errors.AppError)
+	return ok && appErr.Code == errors.ErrCodeConflict
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+func TestExecuteJob(t *testing.T) {
+	processed, err := executeJob(context.Background(), func(ctx context.Context) (int, error) {
+		return 3, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, processed)
+
+	_, err = executeJob(context.Background(), func(ctx context.Context) (int, error) {
+		panic("boom")
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+}
+
+func TestFinishJobRun(t *testing.T) {
+	startedAt := time.Date(2026, time.August, 15, 2, 30, 0, 0, time.UTC)
+	finishedAt := startedAt.Add(1500 * time.Millisecond)
+
+	run := &domain.JobRun{Status: domain.JobRunStatusRunning, StartedAt: startedAt}
+	finishJobRun(run, 7, nil, finishedAt)
+	assert.Equal(t, domain.JobRunStatusSucceeded, run.Status)
+	assert.Equal(t, int64(1500), run.DurationMs)
+	assert.Equal(t, 7, run.ItemsProcessed)
+	assert.Nil(t, run.Error)
+
+	failed := &domain.JobRun{Status: domain.JobRunStatusRunning, StartedAt: startedAt}
+	finishJobRun(failed, 2, stdErrors.New("database unavailable"), finishedAt)
+	assert.Equal(t, domain.JobRunStatusFailed, failed.Status)
+	require.NotNil(t, failed.Error)
+	assert.Equal(t, "database unavailable", *failed.Error)
+}
+
+func TestRegisterJob(t *testing.T) {
+	scheduler := NewSchedulerService(nil)
+	noop := func(ctx context.Context) (int, error) { return 0, nil }
+
+	require.NoError(t, scheduler.RegisterJob("reservations.expire", "*/15 * * * *", noop))
+	assert.Error(t, scheduler.RegisterJob("reservations.expire", "@daily", noop))
+	assert.Error(t, scheduler.RegisterJob("broken", "every minute", noop))
+	assert.Error(t, scheduler.RegisterJob("", "@daily", noop))
+
+	_, err := scheduler.TriggerJob(context.Background(), "unknown", nil)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestIsLockHeld(t *testing.T) {
+	assert.True(t, isLockHeld(errors.Conflict("Job x is already running")))
+	assert.False(t, isLockHeld(errors.InvalidInput("bad")))
+	assert.False(t, isLockHeld(stdErrors.New("plain")))
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+var validSchoolLevels = map[domain.SchoolLevel]bool{
+	domain.SchoolLevelPreschool:    true,
+	domain.SchoolLevelPrimary:      true,
+	domain.SchoolLevelMiddleSchool: true,
+	domain.SchoolLevelHighSchool:   true,
+	domain.SchoolLevelUniversity:   true,
+}
+
+type schoolService struct {
+	schoolRepo repositories.SchoolRepository
+	db         *gorm.DB
+}
+
+// NewSchoolService creates a new school registry service
+func NewSchoolService(
+	schoolRepo repositories.SchoolRepository,
+	db *gorm.DB,
+) services.SchoolService {
+	return &schoolService{
+		schoolRepo: schoolRepo,
+		db:         db,
+	}
+}
+
+// CreateSchool registers a new school
+func (s *schoolService) CreateSchool(ctx context.Context, school *domain.School) error {
+	if err := s.validateSchool(ctx, school); err != nil {
+		return err
+	}
+
+	if school.SchoolID == uuid.Nil {
+		school.SchoolID = uuid.New()
+	}
+
+	return s.schoolRepo.Create(ctx, school)
+}
+
+// GetSchool retrieves a school by ID
+func (s *schoolService) GetSchool(ctx context.Context, id uuid.UUID) (*domain.School, error) {
+	return s.schoolRepo.FindByID(ctx, id)
+}
+
+// ListSchools lists schools with filters
+func (s *schoolService) ListSchools(ctx context.Context, filters repositories.SchoolFilters, limit, offset int) ([]domain.School, int64, error) {
+	return s.schoolRepo.List(ctx, filters, limit, offset)
+}
+
+// UpdateSchool updates a school
+func (s *schoolService) UpdateSchool(ctx context.Context, school *domain.School) error {
+	existing, err := s.schoolRepo.FindByID(ctx, school.SchoolID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.validateSchool(ctx, school); err != nil {
+		return err
+	}
+
+	school.CreatedAt = existing.CreatedAt
+	school.Location = nil
+
+	return s.schoolRepo.Update(ctx, school)
+}
+
+// DeleteSchool removes a school that has no lists attached, otherwise it must be deactivated
+func (s *schoolService) DeleteSchool(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.schoolRepo.FindByID(ctx, id); err != nil {
+		return err
+	}
+
+	var lists int64
+	if err := s.db.WithContext(ctx).Model(&domain.SchoolSupplyList{}).Where("school_id = ?", id).Count(&lists).Error; err != nil {
+		return errors.WrapError(err, "failed to count school supply lists")
+	}
+	if lists > 0 {
+		return errors.Conflict("School has supply lists attached. Deactivate it instead")
+	}
+
+	return s.schoolRepo.Delete(ctx, id)
+}
+
+// Helper functions
+
+func (s *schoolService) validateSchool(ctx context.Context, school *domain.School) error {
+	school.Name = strings.TrimSpace(school.Name)
+	if school.Name == "" {
+		return errors.InvalidInput("School name is required")
+	}
+
+	for _, level := range school.Levels {
+		if !validSchoolLevels[level] {
+			return errors.InvalidInput(fmt.Sprintf("Invalid school level %s", level))
+		}
+	}
+
+	if school.Email != nil && *school.Email != "" && !strings.Contains(*school.Email, "@") {
+		return errors.InvalidInput("Invalid contact email")
+	}
+
+	if school.LocationID != nil {
+		var location domain.Location
+		if err := s.db.WithContext(ctx).First(&location, "location_id = ?", *school.LocationID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.NotFoundWithID("Location", school.LocationID.String())
+			}
+			return errors.WrapError(err, "failed to find location")
+		}
+	}
+
+	return nil
+}
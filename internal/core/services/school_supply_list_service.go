@@ -0,0 +1,879 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+var schoolYearPattern = regexp.MustCompile(`^(\d{4})-(\d{4})$`)
+
+type schoolSupplyListService struct {
+	listRepo          repositories.SchoolSupplyListRepository
+	schoolRepo        repositories.SchoolRepository
+	productRepo       repositories.ProductRepository
+	inventoryRepo     repositories.InventoryRepository
+	customerChildRepo repositories.CustomerChildRepository
+	saleSvc           services.SaleService
+	reservationSvc    services.ReservationService
+	db                *gorm.DB
+}
+
+// NewSchoolSupplyListService creates a new school supply list service
+func NewSchoolSupplyListService(
+	listRepo repositories.SchoolSupplyListRepository,
+	schoolRepo repositories.SchoolRepository,
+	productRepo repositories.ProductRepository,
+	inventoryRepo repositories.InventoryRepository,
+	customerChildRepo repositories.CustomerChildRepository,
+	saleSvc services.SaleService,
+	reservationSvc services.ReservationService,
+	db *gorm.DB,
+) services.SchoolSupplyListService {
+	return &schoolSupplyListService{
+		listRepo:          listRepo,
+		schoolRepo:        schoolRepo,
+		productRepo:       productRepo,
+		inventoryRepo:     inventoryRepo,
+		customerChildRepo: customerChildRepo,
+		saleSvc:           saleSvc,
+		reservationSvc:    reservationSvc,
+		db:                db,
+	}
+}
+
+// CreateList creates a draft list with its items and estimated cost
+func (s *schoolSupplyListService) CreateList(ctx context.Context, list *domain.SchoolSupplyList, items []domain.SchoolSupplyListItem) error {
+	if err := validateSchoolSupplyList(list); err != nil {
+		return err
+	}
+
+	if err := s.validateSchool(ctx, list); err != nil {
+		return err
+	}
+
+	if err := s.prepareItems(ctx, items); err != nil {
+		return err
+	}
+
+	list.ListID = uuid.New()
+	list.Status = domain.SchoolListStatusDraft
+	list.PublishDate = nil
+	cost := estimateListCost(items)
+	list.TotalEstimatedCost = &cost
+
+	return s.listRepo.CreateWithItems(ctx, list, items)
+}
+
+// GetList retrieves a list with items and alternatives
+func (s *schoolSupplyListService) GetList(ctx context.Context, id uuid.UUID) (*domain.SchoolSupplyList, error) {
+	return s.listRepo.FindByID(ctx, id)
+}
+
+// ListLists lists school supply lists with filters
+func (s *schoolSupplyListService) ListLists(ctx context.Context, filters repositories.SchoolSupplyListFilters, limit, offset int) ([]domain.SchoolSupplyList, int64, error) {
+	return s.listRepo.List(ctx, filters, limit, offset)
+}
+
+// UpdateList updates the header of a draft list
+func (s *schoolSupplyListService) UpdateList(ctx context.Context, list *domain.SchoolSupplyList) error {
+	existing, err := s.listRepo.FindByID(ctx, list.ListID)
+	if err != nil {
+		return err
+	}
+
+	if existing.Status != domain.SchoolListStatusDraft {
+		return errors.InvalidInput(fmt.Sprintf("Cannot update list with status %s. Must be DRAFT", existing.Status))
+	}
+
+	if err := validateSchoolSupplyList(list); err != nil {
+		return err
+	}
+
+	if err := s.validateSchool(ctx, list); err != nil {
+		return err
+	}
+
+	list.Status = existing.Status
+	list.PublishDate = existing.PublishDate
+	list.TotalEstimatedCost = existing.TotalEstimatedCost
+	list.CreatedAt = existing.CreatedAt
+	list.CreatedBy = existing.CreatedBy
+	list.Items = nil
+
+	return s.listRepo.Update(ctx, list)
+}
+
+// ReplaceItems replaces the items of a draft list and recomputes its cost
+func (s *schoolSupplyListService) ReplaceItems(ctx context.Context, listID uuid.UUID, items []domain.SchoolSupplyListItem, userID uuid.UUID) (*domain.SchoolSupplyList, error) {
+	list, err := s.listRepo.FindByID(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.Status != domain.SchoolListStatusDraft {
+		return nil, errors.InvalidInput(fmt.Sprintf("Cannot update items of list with status %s. Must be DRAFT", list.Status))
+	}
+
+	if err := s.prepareItems(ctx, items); err != nil {
+		return nil, err
+	}
+
+	if err := s.listRepo.ReplaceItems(ctx, listID, items); err != nil {
+		return nil, err
+	}
+
+	cost := estimateListCost(items)
+	list.TotalEstimatedCost = &cost
+	list.UpdatedBy = &userID
+	list.Items = nil
+	if err := s.listRepo.Update(ctx, list); err != nil {
+		return nil, err
+	}
+
+	return s.listRepo.FindByID(ctx, listID)
+}
+
+// DeleteList deletes a draft list
+func (s *schoolSupplyListService) DeleteList(ctx context.Context, id uuid.UUID) error {
+	list, err := s.listRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if list.Status != domain.SchoolListStatusDraft {
+		return errors.InvalidInput("Only draft lists can be deleted. Archive it instead")
+	}
+
+	return s.listRepo.Delete(ctx, id)
+}
+
+// GetActiveListsByLevel returns the published lists of a level for a school year
+func (s *schoolSupplyListService) GetActiveListsByLevel(ctx context.Context, level domain.SchoolLevel, schoolYear string) ([]domain.SchoolSupplyList, error) {
+	return s.listRepo.FindBySchoolLevel(ctx, level, schoolYear)
+}
+
+// GetPublishedList retrieves a list only if it is visible on the public catalog
+func (s *schoolSupplyListService) GetPublishedList(ctx context.Context, id uuid.UUID) (*domain.SchoolSupplyList, error) {
+	list, err := s.listRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !list.IsPublic() {
+		return nil, errors.NotFoundWithID("SchoolSupplyList", id.String())
+	}
+
+	return list, nil
+}
+
+// PublishList publishes a draft list with an up to date estimated cost
+func (s *schoolSupplyListService) PublishList(ctx context.Context, id uuid.UUID) error {
+	list, err := s.listRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if list.Status != domain.SchoolListStatusDraft {
+		return errors.InvalidInput(fmt.Sprintf("Cannot publish list with status %s. Must be DRAFT", list.Status))
+	}
+
+	if list.IsTemplate {
+		return errors.InvalidInput("Templates cannot be published. Clone it into a school year first")
+	}
+
+	if len(list.Items) == 0 {
+		return errors.InvalidInput("Cannot publish a list without items")
+	}
+
+	cost := estimateListCost(list.Items)
+	list.TotalEstimatedCost = &cost
+	list.Items = nil
+	if err := s.listRepo.Update(ctx, list); err != nil {
+		return err
+	}
+
+	return s.listRepo.Publish(ctx, id)
+}
+
+// ArchiveList removes a list from the catalog
+func (s *schoolSupplyListService) ArchiveList(ctx context.Context, id uuid.UUID) error {
+	list, err := s.listRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if list.Status == domain.SchoolListStatusArchived {
+		return errors.InvalidInput("List is already archived")
+	}
+
+	return s.listRepo.Archive(ctx, id)
+}
+
+// CloneList copies a list (usually a template) into a new draft for another school year
+func (s *schoolSupplyListService) CloneList(ctx context.Context, id uuid.UUID, schoolYear string, userID uuid.UUID) (*domain.SchoolSupplyList, error) {
+	source, err := s.listRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateSchoolYear(schoolYear); err != nil {
+		return nil, err
+	}
+
+	clone := &domain.SchoolSupplyList{
+		ListID:             uuid.New(),
+		ListName:           strings.ReplaceAll(source.ListName, source.SchoolYear, schoolYear),
+		SchoolLevel:        source.SchoolLevel,
+		Grade:              source.Grade,
+		SchoolYear:         schoolYear,
+		Status:             domain.SchoolListStatusDraft,
+		Description:        source.Description,
+		IsTemplate:         false,
+		SchoolID:           source.SchoolID,
+		ExpectedEnrollment: source.ExpectedEnrollment,
+	}
+	clone.CreatedBy = &userID
+
+	items := make([]domain.SchoolSupplyListItem, len(source.Items))
+	for i, item := range source.Items {
+		alternatives := make([]domain.ListItemAlternative, len(item.Alternatives))
+		for j, alt := range item.Alternatives {
+			alternatives[j] = domain.ListItemAlternative{
+				AlternativeID:        uuid.New(),
+				AlternativeProductID: alt.AlternativeProductID,
+				IsRecommended:        alt.IsRecommended,
+				AlternativeProduct:   alt.AlternativeProduct,
+			}
+		}
+
+		items[i] = domain.SchoolSupplyListItem{
+			ListItemID:          uuid.New(),
+			ProductID:           item.ProductID,
+			Quantity:            item.Quantity,
+			IsRequired:          item.IsRequired,
+			IsOptional:          item.IsOptional,
+			AlternativesAllowed: item.AlternativesAllowed,
+			Notes:               item.Notes,
+			DisplayOrder:        item.DisplayOrder,
+			Product:             item.Product,
+			Alternatives:        alternatives,
+		}
+	}
+
+	// Estimated with current prices, not the ones of the source year
+	cost := estimateListCost(items)
+	clone.TotalEstimatedCost = &cost
+
+	if err := s.listRepo.CreateWithItems(ctx, clone, items); err != nil {
+		return nil, err
+	}
+
+	return s.listRepo.FindByID(ctx, clone.ListID)
+}
+
+// RecalculateCost refreshes TotalEstimatedCost from current product prices
+func (s *schoolSupplyListService) RecalculateCost(ctx context.Context, id uuid.UUID) (*domain.SchoolSupplyList, error) {
+	list, err := s.listRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items := list.Items
+	cost := estimateListCost(items)
+	list.TotalEstimatedCost = &cost
+	list.Items = nil
+	if err := s.listRepo.Update(ctx, list); err != nil {
+		return nil, err
+	}
+
+	list.Items = items
+	return list, nil
+}
+
+// RecalculateActiveCosts refreshes the estimated cost of every published list
+func (s *schoolSupplyListService) RecalculateActiveCosts(ctx context.Context) (int, error) {
+	lists, err := s.listRepo.GetActive(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, list := range lists {
+		if _, err := s.RecalculateCost(ctx, list.ListID); err != nil {
+			// Log error but continue processing
+			log.Printf("[ERROR] Failed to recalculate cost of supply list %s: %v", list.ListID, err)
+			continue
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// CheckAvailability reports the stock of each list item, and of its alternatives, in a warehouse
+func (s *schoolSupplyListService) CheckAvailability(ctx context.Context, listID, warehouseID uuid.UUID) (*services.ListAvailability, error) {
+	list, err := s.listRepo.FindByID(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &services.ListAvailability{
+		ListID:         listID,
+		WarehouseID:    warehouseID,
+		Items:          make([]services.ListItemAvailability, 0, len(list.Items)),
+		FullyAvailable: true,
+	}
+
+	for _, item := range list.Items {
+		required := float64(item.Quantity)
+		available, err := s.availableQuantity(ctx, item.ProductID, warehouseID)
+		if err != nil {
+			return nil, err
+		}
+
+		itemAvailability := services.ListItemAvailability{
+			ListItemID: item.ListItemID,
+			ProductID:  item.ProductID,
+			Required:   required,
+			Available:  available,
+			InStock:    available >= required,
+			IsOptional: item.IsOptional,
+		}
+
+		if item.AlternativesAllowed {
+			for _, alt := range item.Alternatives {
+				altAvailable, err := s.availableQuantity(ctx, alt.AlternativeProductID, warehouseID)
+				if err != nil {
+					return nil, err
+				}
+				itemAvailability.Alternatives = append(itemAvailability.Alternatives, services.AlternativeAvailability{
+					ProductID:     alt.AlternativeProductID,
+					Available:     altAvailable,
+					IsRecommended: alt.IsRecommended,
+				})
+			}
+		}
+
+		if !item.IsOptional && !itemServable(itemAvailability) {
+			result.FullyAvailable = false
+		}
+
+		result.Items = append(result.Items, itemAvailability)
+	}
+
+	return result, nil
+}
+
+// GetListForChild resolves the published list a child needs for a school year
+// (the current one when empty), from the child's school, level and grade
+func (s *schoolSupplyListService) GetListForChild(ctx context.Context, childID uuid.UUID, schoolYear string) (*domain.SchoolSupplyList, error) {
+	child, err := s.customerChildRepo.FindByID(ctx, childID)
+	if err != nil {
+		return nil, err
+	}
+
+	if child.SchoolLevel == nil {
+		return nil, errors.InvalidInput("Child has no school level registered")
+	}
+
+	if schoolYear == "" {
+		schoolYear = currentSchoolYear(time.Now())
+	} else if err := validateSchoolYear(schoolYear); err != nil {
+		return nil, err
+	}
+
+	isTemplate := false
+	lists, _, err := s.listRepo.List(ctx, repositories.SchoolSupplyListFilters{
+		SchoolLevel: child.SchoolLevel,
+		SchoolYear:  &schoolYear,
+		Statuses:    []domain.SchoolListStatus{domain.SchoolListStatusPublished, domain.SchoolListStatusActive},
+		IsTemplate:  &isTemplate,
+	}, 100, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	list := resolveChildList(child, lists)
+	if list == nil {
+		return nil, errors.NotFound(fmt.Sprintf("Published school supply list for %s %s in %s", child.FirstName, child.LastName, schoolYear))
+	}
+
+	return s.listRepo.FindByID(ctx, list.ListID)
+}
+
+// ConvertList picks a product for every list item according to the budget preference
+// and the warehouse stock, then creates the sale or reservation for what could be served
+func (s *schoolSupplyListService) ConvertList(ctx context.Context, req services.ConvertListRequest) (*services.ListConversion, error) {
+	list, err := s.listRepo.FindByID(ctx, req.ListID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !list.IsPublic() || list.IsTemplate {
+		return nil, errors.InvalidInput(fmt.Sprintf("Cannot convert list with status %s. Must be PUBLISHED or ACTIVE", list.Status))
+	}
+
+	preference := req.BudgetPreference
+	if preference == "" {
+		preference = domain.ListBudgetExact
+	}
+	if preference != domain.ListBudgetExact && preference != domain.ListBudgetCheapest && preference != domain.ListBudgetRecommended {
+		return nil, errors.InvalidInput(fmt.Sprintf("Invalid budget preference %s", preference))
+	}
+
+	customerID := req.CustomerID
+	if req.ChildID != nil {
+		child, err := s.customerChildRepo.FindByID(ctx, *req.ChildID)
+		if err != nil {
+			return nil, errors.NotFoundWithID("Child", req.ChildID.String())
+		}
+		if customerID == nil {
+			customerID = &child.CustomerID
+		} else if child.CustomerID != *customerID {
+			return nil, errors.InvalidInput("Child does not belong to the specified customer")
+		}
+	}
+
+	// Stock of every product that may serve the list
+	stock := make(map[uuid.UUID]float64)
+	for _, item := range list.Items {
+		productIDs := []uuid.UUID{item.ProductID}
+		for _, alt := range item.Alternatives {
+			productIDs = append(productIDs, alt.AlternativeProductID)
+		}
+		for _, productID := range productIDs {
+			if _, known := stock[productID]; known {
+				continue
+			}
+			available, err := s.availableQuantity(ctx, productID, req.WarehouseID)
+			if err != nil {
+				return nil, err
+			}
+			stock[productID] = available
+		}
+	}
+
+	includeOptional := make(map[uuid.UUID]bool, len(req.OptionalItemIDs))
+	for _, id := range req.OptionalItemIDs {
+		includeOptional[id] = true
+	}
+
+	lines, unfulfilled := planListConversion(list.Items, preference, includeOptional, stock)
+
+	result := &services.ListConversion{
+		List:        list,
+		Lines:       lines,
+		Unfulfilled: unfulfilled,
+	}
+	for _, line := range lines {
+		result.EstimatedTotal += line.Quantity * line.UnitPrice
+	}
+	result.EstimatedTotal = roundAmount(result.EstimatedTotal)
+
+	if req.Preview {
+		return result, nil
+	}
+
+	if len(lines) == 0 {
+		return nil, errors.InvalidInput("None of the list items are available in the warehouse")
+	}
+
+	notes := stringPtr(fmt.Sprintf("School supply list %s (%s)", list.ListName, list.SchoolYear))
+
+	switch req.ConvertTo {
+	case domain.ListConversionSale:
+		if req.PaymentMethod == nil {
+			return nil, errors.InvalidInput("Payment method is required to convert a list into a sale")
+		}
+
+		result.Sale, err = s.saleSvc.CreateSale(ctx, services.CreateSaleRequest{
+			CustomerID:       customerID,
+			StoreID:          req.StoreID,
+			WarehouseID:      &req.WarehouseID,
+			SaleType:         domain.SaleTypeCash,
+			Items:            toListSaleItems(lines),
+			Currency:         req.Currency,
+			ExchangeRate:     req.ExchangeRate,
+			PaymentMethod:    req.PaymentMethod,
+			PaymentReference: req.PaymentReference,
+			Notes:            notes,
+			SalespersonID:    req.UserID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+	case domain.ListConversionReservation:
+		if customerID == nil {
+			return nil, errors.InvalidInput("Customer is required to convert a list into a reservation")
+		}
+
+		result.Reservation, err = s.reservationSvc.CreateReservation(ctx, services.CreateReservationRequest{
+			CustomerID:     *customerID,
+			ChildID:        req.ChildID,
+			ListID:         &list.ListID,
+			StoreID:        req.StoreID,
+			WarehouseID:    &req.WarehouseID,
+			Items:          toListReservationItems(lines),
+			DepositAmount:  req.DepositAmount,
+			Currency:       req.Currency,
+			ExpirationDays: req.ExpirationDays,
+			Notes:          notes,
+			UserID:         req.UserID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, errors.InvalidInput(fmt.Sprintf("Invalid conversion target %s", req.ConvertTo))
+	}
+
+	return result, nil
+}
+
+// Helper functions
+
+// validateSchool checks that the school of a list exists, is active and teaches the list level
+func (s *schoolSupplyListService) validateSchool(ctx context.Context, list *domain.SchoolSupplyList) error {
+	if list.SchoolID == nil {
+		return nil
+	}
+
+	school, err := s.schoolRepo.FindByID(ctx, *list.SchoolID)
+	if err != nil {
+		return err
+	}
+
+	if !school.IsActive {
+		return errors.InvalidInput(fmt.Sprintf("School %s is not active", school.Name))
+	}
+
+	if !school.OffersLevel(list.SchoolLevel) {
+		return errors.InvalidInput(fmt.Sprintf("School %s does not offer level %s", school.Name, list.SchoolLevel))
+	}
+
+	return nil
+}
+
+// prepareItems validates the items and their products, and assigns new IDs
+func (s *schoolSupplyListService) prepareItems(ctx context.Context, items []domain.SchoolSupplyListItem) error {
+	if len(items) == 0 {
+		return errors.InvalidInput("List must have at least one item")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(items))
+	for i := range items {
+		item := &items[i]
+		if item.Quantity <= 0 {
+			return errors.InvalidInput("Item quantity must be positive")
+		}
+
+		if seen[item.ProductID] {
+			return errors.InvalidInput(fmt.Sprintf("Product %s is listed more than once", item.ProductID))
+		}
+		seen[item.ProductID] = true
+
+		product, err := s.productRepo.FindByID(ctx, item.ProductID)
+		if err != nil {
+			return errors.NotFoundWithID("Product", item.ProductID.String())
+		}
+		item.Product = product
+		item.ListItemID = uuid.New()
+		item.IsRequired = !item.IsOptional
+
+		for j := range item.Alternatives {
+			alt := &item.Alternatives[j]
+			if alt.AlternativeProductID == item.ProductID {
+				return errors.InvalidInput(fmt.Sprintf("Product %s cannot be an alternative of itself", product.Name))
+			}
+			altProduct, err := s.productRepo.FindByID(ctx, alt.AlternativeProductID)
+			if err != nil {
+				return errors.NotFoundWithID("Product", alt.AlternativeProductID.String())
+			}
+			alt.AlternativeProduct = altProduct
+			alt.AlternativeID = uuid.New()
+		}
+	}
+
+	return nil
+}
+
+// availableQuantity returns the available stock, treating a missing inventory record as zero
+func (s *schoolSupplyListService) availableQuantity(ctx context.Context, productID, warehouseID uuid.UUID) (float64, error) {
+	inventory, err := s.inventoryRepo.GetByProductAndWarehouse(ctx, productID, warehouseID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return inventory.AvailableQuantity, nil
+}
+
+// listCandidate is a product that can serve a list item
+type listCandidate struct {
+	product     *domain.Product
+	listed      bool
+	recommended bool
+}
+
+// planListConversion assigns products to list items in the order given by the
+// budget preference, consuming the stock as it goes. An item may be served by
+// several candidates when none of them covers the whole quantity.
+func planListConversion(
+	items []domain.SchoolSupplyListItem,
+	preference domain.ListBudgetPreference,
+	includeOptional map[uuid.UUID]bool,
+	stock map[uuid.UUID]float64,
+) ([]services.ListConversionLine, []services.UnfulfilledListItem) {
+	remaining := make(map[uuid.UUID]float64, len(stock))
+	for id, qty := range stock {
+		remaining[id] = qty
+	}
+
+	lines := make([]services.ListConversionLine, 0, len(items))
+	unfulfilled := make([]services.UnfulfilledListItem, 0)
+
+	for _, item := range items {
+		if item.IsOptional && !includeOptional[item.ListItemID] {
+			continue
+		}
+
+		required := float64(item.Quantity)
+		missing := required
+
+		for _, candidate := range listCandidates(item, preference) {
+			if missing <= 0 {
+				break
+			}
+			productID := candidate.product.ProductID
+			take := math.Min(missing, remaining[productID])
+			if take <= 0 {
+				continue
+			}
+			remaining[productID] -= take
+			missing -= take
+
+			lines = append(lines, services.ListConversionLine{
+				ListItemID:         item.ListItemID,
+				RequestedProductID: item.ProductID,
+				ProductID:          productID,
+				ProductName:        candidate.product.Name,
+				Quantity:           take,
+				UnitPrice:          candidate.product.SellingPrice,
+				Substituted:        !candidate.listed,
+			})
+		}
+
+		if missing > 0 {
+			reason := "Insufficient stock"
+			if !item.AlternativesAllowed || len(item.Alternatives) == 0 {
+				reason = "Insufficient stock and no alternatives allowed"
+			}
+			unfulfilled = append(unfulfilled, services.UnfulfilledListItem{
+				ListItemID: item.ListItemID,
+				ProductID:  item.ProductID,
+				Required:   required,
+				Missing:    missing,
+				Reason:     reason,
+			})
+		}
+	}
+
+	return lines, unfulfilled
+}
+
+// listCandidates orders the listed product and its allowed alternatives by preference
+func listCandidates(item domain.SchoolSupplyListItem, preference domain.ListBudgetPreference) []listCandidate {
+	candidates := make([]listCandidate, 0, len(item.Alternatives)+1)
+	if item.Product != nil && item.Product.Status == domain.ProductStatusActive {
+		candidates = append(candidates, listCandidate{product: item.Product, listed: true})
+	}
+
+	if item.AlternativesAllowed {
+		for _, alt := range item.Alternatives {
+			if alt.AlternativeProduct == nil || alt.AlternativeProduct.Status != domain.ProductStatusActive {
+				continue
+			}
+			candidates = append(candidates, listCandidate{product: alt.AlternativeProduct, recommended: alt.IsRecommended})
+		}
+	}
+
+	rank := func(c listCandidate) int {
+		switch preference {
+		case domain.ListBudgetRecommended:
+			if c.recommended {
+				return 0
+			}
+			if c.listed {
+				return 1
+			}
+		case domain.ListBudgetExact:
+			if c.listed {
+				return 0
+			}
+			if c.recommended {
+				return 1
+			}
+		default:
+			return 0
+		}
+		return 2
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ri, rj := rank(candidates[i]), rank(candidates[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return candidates[i].product.SellingPrice < candidates[j].product.SellingPrice
+	})
+
+	return candidates
+}
+
+// toListSaleItems merges the plan lines into one sale item per product
+func toListSaleItems(lines []services.ListConversionLine) []services.SaleItem {
+	items := make([]services.SaleItem, 0, len(lines))
+	index := make(map[uuid.UUID]int)
+	for _, line := range lines {
+		if i, exists := index[line.ProductID]; exists {
+			items[i].Quantity += line.Quantity
+			continue
+		}
+		index[line.ProductID] = len(items)
+		items = append(items, services.SaleItem{ProductID: line.ProductID, Quantity: line.Quantity})
+	}
+	return items
+}
+
+// toListReservationItems merges the plan lines into one reservation item per product
+func toListReservationItems(lines []services.ListConversionLine) []services.ReservationItem {
+	saleItems := toListSaleItems(lines)
+	items := make([]services.ReservationItem, len(saleItems))
+	for i, item := range saleItems {
+		items[i] = services.ReservationItem{ProductID: item.ProductID, Quantity: item.Quantity}
+	}
+	return items
+}
+
+// itemServable checks whether the item or one of its alternatives covers the required quantity
+func itemServable(item services.ListItemAvailability) bool {
+	if item.InStock {
+		return true
+	}
+	for _, alt := range item.Alternatives {
+		if alt.Available >= item.Required {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveChildList picks the list that fits a child best: the list of the child's
+// school for its grade, then the school's list for the whole level, then the
+// generic lists of the level. Lists of other schools or grades never apply.
+func resolveChildList(child *domain.CustomerChild, lists []domain.SchoolSupplyList) *domain.SchoolSupplyList {
+	var best *domain.SchoolSupplyList
+	bestScore := -1
+
+	for i := range lists {
+		list := &lists[i]
+		score := 0
+
+		if list.SchoolID != nil {
+			if child.SchoolID == nil || *list.SchoolID != *child.SchoolID {
+				continue
+			}
+			score += 2
+		}
+
+		if list.Grade != nil {
+			if child.Grade == nil || !strings.EqualFold(strings.TrimSpace(*list.Grade), strings.TrimSpace(*child.Grade)) {
+				continue
+			}
+			score++
+		}
+
+		if score > bestScore {
+			best, bestScore = list, score
+		}
+	}
+
+	return best
+}
+
+// currentSchoolYear returns the school year in progress, or the one being
+// prepared from July on, e.g. "2025-2026"
+func currentSchoolYear(now time.Time) string {
+	start := now.Year()
+	if now.Month() < time.July {
+		start--
+	}
+	return fmt.Sprintf("%d-%d", start, start+1)
+}
+
+// estimateListCost prices the required items of a list at current selling prices
+func estimateListCost(items []domain.SchoolSupplyListItem) float64 {
+	total := 0.0
+	for _, item := range items {
+		if item.IsOptional || item.Product == nil {
+			continue
+		}
+		total += float64(item.Quantity) * item.Product.SellingPrice
+	}
+	return roundAmount(total)
+}
+
+// validateSchoolSupplyList checks the header fields of a list
+func validateSchoolSupplyList(list *domain.SchoolSupplyList) error {
+	if strings.TrimSpace(list.ListName) == "" {
+		return errors.InvalidInput("List name is required")
+	}
+
+	if list.SchoolLevel == "" {
+		return errors.InvalidInput("School level is required")
+	}
+
+	if list.ExpirationDate != nil && list.PublishDate != nil && list.ExpirationDate.Before(*list.PublishDate) {
+		return errors.InvalidInput("Expiration date must be after publish date")
+	}
+
+	if list.ExpectedEnrollment != nil && *list.ExpectedEnrollment < 0 {
+		return errors.InvalidInput("Expected enrollment cannot be negative")
+	}
+
+	return validateSchoolYear(list.SchoolYear)
+}
+
+// validateSchoolYear checks the YYYY-YYYY format of consecutive years
+func validateSchoolYear(schoolYear string) error {
+	matches := schoolYearPattern.FindStringSubmatch(schoolYear)
+	if matches == nil {
+		return errors.InvalidInput("School year must have the format YYYY-YYYY")
+	}
+
+	start, _ := strconv.Atoi(matches[1])
+	end, _ := strconv.Atoi(matches[2])
+	if end != start+1 {
+		return errors.InvalidInput("School year must span two consecutive years")
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+func TestValidateSchoolYear(t *testing.T) {
+	assert.NoError(t, validateSchoolYear("2025-2026"))
+	assert.Error(t, validateSchoolYear("2025-2027"))
+	assert.Error(t, validateSchoolYear("2025/2026"))
+	assert.Error(t, validateSchoolYear("25-26"))
+	assert.Error(t, validateSchoolYear(""))
+}
+
+func TestEstimateListCost(t *testing.T) {
+	notebook := &domain.Product{ProductID: uuid.New(), SellingPrice: 2.5}
+	pencil := &domain.Product{ProductID: uuid.New(), SellingPrice: 0.35}
+	backpack := &domain.Product{ProductID: uuid.New(), SellingPrice: 30}
+
+	items := []domain.SchoolSupplyListItem{
+		{ProductID: notebook.ProductID, Quantity: 4, Product: notebook},
+		{ProductID: pencil.ProductID, Quantity: 3, Product: pencil},
+		{ProductID: backpack.ProductID, Quantity: 1, IsOptional: true, Product: backpack},
+	}
+
+	assert.Equal(t, 11.05, estimateListCost(items))
+	assert.Equal(t, 0.0, estimateListCost(nil))
+}
+
+func TestItemServable(t *testing.T) {
+	item := services.ListItemAvailability{Required: 5, Available: 2}
+	assert.False(t, itemServable(item))
+
+	item.Alternatives = []services.AlternativeAvailability{{ProductID: uuid.New(), Available: 5}}
+	assert.True(t, itemServable(item))
+
+	item.Alternatives = nil
+	item.Available = 5
+	item.InStock = true
+	assert.True(t, itemServable(item))
+}
+
+func TestPlanListConversion(t *testing.T) {
+	listed := &domain.Product{ProductID: uuid.New(), Name: "Cuaderno A", SellingPrice: 3, Status: domain.ProductStatusActive}
+	cheap := &domain.Product{ProductID: uuid.New(), Name: "Cuaderno B", SellingPrice: 2, Status: domain.ProductStatusActive}
+	recommended := &domain.Product{ProductID: uuid.New(), Name: "Cuaderno C", SellingPrice: 4, Status: domain.ProductStatusActive}
+	crayons := &domain.Product{ProductID: uuid.New(), Name: "Creyones", SellingPrice: 5, Status: domain.ProductStatusActive}
+
+	notebookItem := domain.SchoolSupplyListItem{
+		ListItemID:          uuid.New(),
+		ProductID:           listed.ProductID,
+		Quantity:            5,
+		AlternativesAllowed: true,
+		Product:             listed,
+		Alternatives: []domain.ListItemAlternative{
+			{AlternativeProductID: cheap.ProductID, AlternativeProduct: cheap},
+			{AlternativeProductID: recommended.ProductID, AlternativeProduct: recommended, IsRecommended: true},
+		},
+	}
+	optionalItem := domain.SchoolSupplyListItem{
+		ListItemID: uuid.New(),
+		ProductID:  crayons.ProductID,
+		Quantity:   1,
+		IsOptional: true,
+		Product:    crayons,
+	}
+	items := []domain.SchoolSupplyListItem{notebookItem, optionalItem}
+
+	stock := map[uuid.UUID]float64{
+		listed.ProductID:      2,
+		cheap.ProductID:       10,
+		recommended.ProductID: 10,
+		crayons.ProductID:     0,
+	}
+
+	t.Run("exact substitutes only the missing quantity", func(t *testing.T) {
+		lines, unfulfilled := planListConversion(items, domain.ListBudgetExact, nil, stock)
+		require.Len(t, lines, 2)
+		assert.Equal(t, listed.ProductID, lines[0].ProductID)
+		assert.Equal(t, 2.0, lines[0].Quantity)
+		assert.False(t, lines[0].Substituted)
+		assert.Equal(t, recommended.ProductID, lines[1].ProductID)
+		assert.Equal(t, 3.0, lines[1].Quantity)
+		assert.True(t, lines[1].Substituted)
+		assert.Empty(t, unfulfilled)
+	})
+
+	t.Run("cheapest picks the lowest price in stock", func(t *testing.T) {
+		lines, _ := planListConversion(items, domain.ListBudgetCheapest, nil, stock)
+		require.Len(t, lines, 1)
+		assert.Equal(t, cheap.ProductID, lines[0].ProductID)
+		assert.Equal(t, 5.0, lines[0].Quantity)
+	})
+
+	t.Run("recommended prefers recommended alternatives", func(t *testing.T) {
+		lines, _ := planListConversion(items, domain.ListBudgetRecommended, nil, stock)
+		require.Len(t, lines, 1)
+		assert.Equal(t, recommended.ProductID, lines[0].ProductID)
+	})
+
+	t.Run("optional items are reported when included and out of stock", func(t *testing.T) {
+		include := map[uuid.UUID]bool{optionalItem.ListItemID: true}
+		_, unfulfilled := planListConversion(items, domain.ListBudgetExact, include, stock)
+		require.Len(t, unfulfilled, 1)
+		assert.Equal(t, optionalItem.ListItemID, unfulfilled[0].ListItemID)
+		assert.Equal(t, 1.0, unfulfilled[0].Missing)
+	})
+
+	t.Run("alternatives are ignored when not allowed", func(t *testing.T) {
+		strict := notebookItem
+		strict.AlternativesAllowed = false
+		lines, unfulfilled := planListConversion([]domain.SchoolSupplyListItem{strict}, domain.ListBudgetCheapest, nil, stock)
+		require.Len(t, lines, 1)
+		assert.Equal(t, listed.ProductID, lines[0].ProductID)
+		require.Len(t, unfulfilled, 1)
+		assert.Equal(t, 3.0, unfulfilled[0].Missing)
+	})
+}
+
+func TestResolveChildList(t *testing.T) {
+	schoolID := uuid.New()
+	otherSchool := uuid.New()
+	grade := "3er grado"
+
+	generic := domain.SchoolSupplyList{ListID: uuid.New()}
+	bySchool := domain.SchoolSupplyList{ListID: uuid.New(), SchoolID: &schoolID}
+	bySchoolAndGrade := domain.SchoolSupplyList{ListID: uuid.New(), SchoolID: &schoolID, Grade: stringPtr("3ER GRADO")}
+	foreign := domain.SchoolSupplyList{ListID: uuid.New(), SchoolID: &otherSchool, Grade: &grade}
+
+	child := &domain.CustomerChild{SchoolID: &schoolID, Grade: &grade}
+
+	best := resolveChildList(child, []domain.SchoolSupplyList{generic, foreign, bySchool, bySchoolAndGrade})
+	require.NotNil(t, best)
+	assert.Equal(t, bySchoolAndGrade.ListID, best.ListID)
+
+	best = resolveChildList(&domain.CustomerChild{}, []domain.SchoolSupplyList{bySchool, generic})
+	require.NotNil(t, best)
+	assert.Equal(t, generic.ListID, best.ListID)
+
+	assert.Nil(t, resolveChildList(&domain.CustomerChild{}, []domain.SchoolSupplyList{foreign}))
+}
+
+func TestCurrentSchoolYear(t *testing.T) {
+	assert.Equal(t, "2024-2025", currentSchoolYear(time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "2025-2026", currentSchoolYear(time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)))
+}
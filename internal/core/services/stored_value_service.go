@@ -0,0 +1,446 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+	"github.com/jadiazinf/inventory/internal/core/ports/repositories"
+	"github.com/jadiazinf/inventory/internal/core/ports/services"
+)
+
+type storedValueService struct {
+	storedValueRepo repositories.StoredValueRepository
+	customerRepo    repositories.CustomerRepository
+	saleRepo        repositories.SaleRepository
+	db              *gorm.DB
+}
+
+// NewStoredValueService creates a new gift card and store credit service
+func NewStoredValueService(
+	storedValueRepo repositories.StoredValueRepository,
+	customerRepo repositories.CustomerRepository,
+	saleRepo repositories.SaleRepository,
+	db *gorm.DB,
+) services.StoredValueService {
+	return &storedValueService{
+		storedValueRepo: storedValueRepo,
+		customerRepo:    customerRepo,
+		saleRepo:        saleRepo,
+		db:              db,
+	}
+}
+
+// IssueGiftCard issues a new gift card with its initial balance
+func (s *storedValueService) IssueGiftCard(ctx context.Context, req services.IssueGiftCardRequest) (*domain.StoredValueAccount, error) {
+	if req.Amount <= 0 {
+		return nil, errors.InvalidInput("Amount must be positive")
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, errors.InvalidInput("Expiry date must be in the future")
+	}
+
+	if req.CustomerID != nil {
+		if _, err := s.customerRepo.FindByID(ctx, *req.CustomerID); err != nil {
+			return nil, err
+		}
+	}
+
+	code := ""
+	if req.Code != nil {
+		code = *req.Code
+	}
+	if code == "" {
+		generated, err := generateGiftCardCode()
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to generate gift card code")
+		}
+		code = generated
+	}
+
+	if _, err := s.storedValueRepo.FindByCode(ctx, code); err == nil {
+		return nil, errors.AlreadyExists("GiftCard", "code", code)
+	} else if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = domain.CurrencyVES
+	}
+
+	account := &domain.StoredValueAccount{
+		AccountID:     uuid.New(),
+		AccountType:   domain.StoredValueTypeGiftCard,
+		Code:          &code,
+		CustomerID:    req.CustomerID,
+		Currency:      currency,
+		InitialAmount: roundAmount(req.Amount),
+		Balance:       roundAmount(req.Amount),
+		Status:        domain.StoredValueStatusActive,
+		ExpiresAt:     req.ExpiresAt,
+		IssuedBy:      &req.UserID,
+		Notes:         req.Notes,
+	}
+
+	issue := &domain.StoredValueTransaction{
+		TransactionID:   uuid.New(),
+		TransactionType: domain.StoredValueTransactionTypeIssue,
+		Amount:          account.Balance,
+		SaleID:          req.SaleID,
+		Description:     stringPtr("Gift card issued"),
+		CreatedBy:       &req.UserID,
+	}
+
+	if err := s.storedValueRepo.CreateAccount(ctx, account, issue); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// IssueStoreCredit credits the customer store-credit account of the given currency,
+// opening it on first use
+func (s *storedValueService) IssueStoreCredit(ctx context.Context, req services.IssueStoreCreditRequest) (*domain.StoredValueAccount, error) {
+	if req.Amount <= 0 {
+		return nil, errors.InvalidInput("Amount must be positive")
+	}
+
+	if req.Reason == "" {
+		return nil, errors.InvalidInput("Reason is required for store credit")
+	}
+
+	if _, err := s.customerRepo.FindByID(ctx, req.CustomerID); err != nil {
+		return nil, err
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = domain.CurrencyVES
+	}
+
+	amount := roundAmount(req.Amount)
+	credit := &domain.StoredValueTransaction{
+		TransactionID:   uuid.New(),
+		TransactionType: domain.StoredValueTransactionTypeIssue,
+		Amount:          amount,
+		SaleID:          req.SaleID,
+		Description:     &req.Reason,
+		CreatedBy:       &req.UserID,
+	}
+
+	account, err := s.storedValueRepo.FindStoreCredit(ctx, req.CustomerID, currency)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+
+		account = &domain.StoredValueAccount{
+			AccountID:     uuid.New(),
+			AccountType:   domain.StoredValueTypeStoreCredit,
+			CustomerID:    &req.CustomerID,
+			Currency:      currency,
+			InitialAmount: amount,
+			Balance:       amount,
+			Status:        domain.StoredValueStatusActive,
+			ExpiresAt:     req.ExpiresAt,
+			IssuedBy:      &req.UserID,
+		}
+		if err := s.storedValueRepo.CreateAccount(ctx, account, credit); err != nil {
+			return nil, err
+		}
+		return account, nil
+	}
+
+	credit.AccountID = account.AccountID
+	if err := s.storedValueRepo.CreateTransaction(ctx, credit); err != nil {
+		return nil, err
+	}
+
+	return s.storedValueRepo.FindByID(ctx, account.AccountID)
+}
+
+// RevokeStoreCredit debits credit back from a store-credit account
+func (s *storedValueService) RevokeStoreCredit(ctx context.Context, accountID uuid.UUID, amount float64, reason string, userID uuid.UUID) error {
+	if amount <= 0 {
+		return errors.InvalidInput("Amount must be positive")
+	}
+
+	account, err := s.storedValueRepo.FindByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	if account.AccountType != domain.StoredValueTypeStoreCredit {
+		return errors.InvalidInput("Only store credit can be revoked")
+	}
+
+	amount = roundAmount(amount)
+	if account.Balance < amount {
+		return errors.Conflict(fmt.Sprintf(
+			"Store credit balance (%.2f) is below the amount to revoke (%.2f); it was already used",
+			account.Balance, amount,
+		))
+	}
+
+	txn := &domain.StoredValueTransaction{
+		TransactionID:   uuid.New(),
+		AccountID:       accountID,
+		TransactionType: domain.StoredValueTransactionTypeRevoke,
+		Amount:          -amount,
+		Description:     &reason,
+		CreatedBy:       &userID,
+	}
+	return s.storedValueRepo.CreateTransaction(ctx, txn)
+}
+
+// GetAccount retrieves a stored-value account by ID
+func (s *storedValueService) GetAccount(ctx context.Context, id uuid.UUID) (*domain.StoredValueAccount, error) {
+	return s.storedValueRepo.FindByID(ctx, id)
+}
+
+// GetGiftCardByCode retrieves a gift card by its code (balance inquiry)
+func (s *storedValueService) GetGiftCardByCode(ctx context.Context, code string) (*domain.StoredValueAccount, error) {
+	return s.storedValueRepo.FindByCode(ctx, code)
+}
+
+// ListAccounts lists stored-value accounts with filters
+func (s *storedValueService) ListAccounts(ctx context.Context, filters repositories.StoredValueFilters, limit, offset int) ([]domain.StoredValueAccount, int64, error) {
+	return s.storedValueRepo.List(ctx, filters, limit, offset)
+}
+
+// GetTransactions retrieves the transaction history of an account
+func (s *storedValueService) GetTransactions(ctx context.Context, accountID uuid.UUID) ([]domain.StoredValueTransaction, error) {
+	if _, err := s.storedValueRepo.FindByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+	return s.storedValueRepo.GetTransactions(ctx, accountID)
+}
+
+// QuoteTenders validates the tenders against their accounts and returns
+// the total they cover in the sale currency
+func (s *storedValueService) QuoteTenders(ctx context.Context, customerID *uuid.UUID, currency domain.CurrencyCode, exchangeRate *float64, tenders []services.StoredValueTender) (float64, error) {
+	resolved, err := s.resolveTenders(ctx, customerID, currency, exchangeRate, tenders)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0.0
+	for _, r := range resolved {
+		total += r.tender.Amount
+	}
+	return roundAmount(total), nil
+}
+
+// RedeemForSale debits each tender from its account and records it on the sale
+func (s *storedValueService) RedeemForSale(ctx context.Context, saleID uuid.UUID, tenders []services.StoredValueTender, userID uuid.UUID) ([]domain.StoredValueTransaction, error) {
+	sale, err := s.saleRepo.FindByID(ctx, saleID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := s.resolveTenders(ctx, sale.CustomerID, sale.Currency, sale.ExchangeRate, tenders)
+	if err != nil {
+		return nil, err
+	}
+
+	total := 0.0
+	for _, r := range resolved {
+		total += r.tender.Amount
+	}
+	if roundAmount(total) > roundAmount(sale.TotalAmount-sale.TenderedAmount()) {
+		return nil, errors.BadRequest("Stored value tenders exceed the sale total")
+	}
+
+	txns := make([]domain.StoredValueTransaction, 0, len(resolved))
+	for _, r := range resolved {
+		txn := domain.StoredValueTransaction{
+			TransactionID:   uuid.New(),
+			AccountID:       r.account.AccountID,
+			TransactionType: domain.StoredValueTransactionTypeRedeem,
+			Amount:          -r.debit,
+			SaleID:          &sale.SaleID,
+			Description:     stringPtr(fmt.Sprintf("Redeemed on sale %s", sale.InvoiceNumber)),
+			CreatedBy:       &userID,
+		}
+		if r.account.Currency != sale.Currency {
+			txn.ExchangeRate = sale.ExchangeRate
+		}
+
+		tenderType := domain.TenderTypeStoreCredit
+		reference := r.account.AccountID.String()
+		if r.account.AccountType == domain.StoredValueTypeGiftCard {
+			tenderType = domain.TenderTypeGiftCard
+			if r.account.Code != nil {
+				reference = *r.account.Code
+			}
+		}
+
+		tender := &domain.SaleTender{
+			TenderID:   uuid.New(),
+			SaleID:     sale.SaleID,
+			TenderType: tenderType,
+			Amount:     roundAmount(r.tender.Amount),
+			Currency:   sale.Currency,
+			Reference:  &reference,
+		}
+
+		if err := s.storedValueRepo.CreateRedemption(ctx, &txn, tender); err != nil {
+			return txns, err
+		}
+		txns = append(txns, txn)
+	}
+
+	return txns, nil
+}
+
+// ReverseSale refunds every stored-value redemption of a returned or cancelled sale
+func (s *storedValueService) ReverseSale(ctx context.Context, saleID uuid.UUID, userID *uuid.UUID) error {
+	txns, err := s.storedValueRepo.FindTransactionsBySale(ctx, saleID)
+	if err != nil {
+		return err
+	}
+
+	for _, txn := range txns {
+		if txn.TransactionType == domain.StoredValueTransactionTypeRefund {
+			return nil
+		}
+	}
+
+	for _, txn := range txns {
+		if txn.TransactionType != domain.StoredValueTransactionTypeRedeem {
+			continue
+		}
+
+		refund := &domain.StoredValueTransaction{
+			TransactionID:   uuid.New(),
+			AccountID:       txn.AccountID,
+			TransactionType: domain.StoredValueTransactionTypeRefund,
+			Amount:          -txn.Amount,
+			ExchangeRate:    txn.ExchangeRate,
+			SaleID:          &saleID,
+			Description:     stringPtr("Refund of returned sale"),
+			CreatedBy:       userID,
+		}
+		if err := s.storedValueRepo.CreateTransaction(ctx, refund); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExpireAccounts zeroes the balance of every account past its expiry date
+func (s *storedValueService) ExpireAccounts(ctx context.Context, at time.Time) (int, error) {
+	accounts, err := s.storedValueRepo.GetExpired(ctx, at)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, account := range accounts {
+		txn := &domain.StoredValueTransaction{
+			TransactionID:   uuid.New(),
+			AccountID:       account.AccountID,
+			TransactionType: domain.StoredValueTransactionTypeExpire,
+			Amount:          -account.Balance,
+			Description:     stringPtr("Balance expired"),
+		}
+		if err := s.storedValueRepo.CreateTransaction(ctx, txn); err != nil {
+			// Log error but continue processing
+			log.Printf("[ERROR] Failed to expire stored value account %s: %v", account.AccountID, err)
+			continue
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// Helper functions
+
+// resolvedTender is a tender matched to its account and the amount to debit from it
+type resolvedTender struct {
+	tender  services.StoredValueTender
+	account *domain.StoredValueAccount
+	debit   float64 // In the account currency
+}
+
+func (s *storedValueService) resolveTenders(ctx context.Context, customerID *uuid.UUID, currency domain.CurrencyCode, exchangeRate *float64, tenders []services.StoredValueTender) ([]resolvedTender, error) {
+	resolved := make([]resolvedTender, 0, len(tenders))
+	debited := make(map[uuid.UUID]float64)
+	now := time.Now()
+
+	for _, tender := range tenders {
+		if tender.Amount <= 0 {
+			return nil, errors.InvalidInput("Tender amount must be positive")
+		}
+
+		var account *domain.StoredValueAccount
+		var err error
+		switch {
+		case tender.AccountID != nil:
+			account, err = s.storedValueRepo.FindByID(ctx, *tender.AccountID)
+		case tender.Code != nil:
+			account, err = s.storedValueRepo.FindByCode(ctx, *tender.Code)
+		default:
+			return nil, errors.InvalidInput("Tender must reference a gift card code or an account")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if account.Status != domain.StoredValueStatusActive || account.IsExpired(now) {
+			return nil, errors.BadRequest(fmt.Sprintf("Stored value account %s is not active", account.AccountID))
+		}
+
+		if account.AccountType == domain.StoredValueTypeStoreCredit {
+			if customerID == nil || account.CustomerID == nil || *account.CustomerID != *customerID {
+				return nil, errors.Forbidden("Store credit belongs to another customer")
+			}
+		}
+
+		debit, err := convertAmount(tender.Amount, currency, account.Currency, exchangeRate)
+		if err != nil {
+			return nil, err
+		}
+		debit = roundAmount(debit)
+
+		debited[account.AccountID] += debit
+		if debited[account.AccountID] > account.Balance {
+			return nil, errors.BadRequest(fmt.Sprintf("Insufficient balance. Available: %.2f %s", account.Balance, account.Currency))
+		}
+
+		resolved = append(resolved, resolvedTender{tender: tender, account: account, debit: debit})
+	}
+
+	return resolved, nil
+}
+
+const giftCardAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// generateGiftCardCode returns a random code like GC-7KQ2-MX9A-P4HT
+func generateGiftCardCode() (string, error) {
+	code := []byte("GC-XXXX-XXXX-XXXX")
+	max := big.NewInt(int64(len(giftCardAlphabet)))
+	for i := range code {
+		if code[i] != 'X' {
+			continue
+		}
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = giftCardAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
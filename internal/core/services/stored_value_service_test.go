@@ -0,0 +1,43 @@
+package services
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
+
+func TestConvertAmount(t *testing.T) {
+	rate := 36.5
+
+	amount, err := convertAmount(10, domain.CurrencyUSD, domain.CurrencyUSD, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, amount)
+
+	amount, err = convertAmount(10, domain.CurrencyUSD, domain.CurrencyVES, &rate)
+	require.NoError(t, err)
+	assert.Equal(t, 365.0, amount)
+
+	amount, err = convertAmount(100, domain.CurrencyVES, domain.CurrencyUSD, &rate)
+	require.NoError(t, err)
+	assert.Equal(t, 2.74, amount)
+
+	_, err = convertAmount(10, domain.CurrencyUSD, domain.CurrencyVES, nil)
+	assert.Error(t, err)
+}
+
+func TestGenerateGiftCardCode(t *testing.T) {
+	pattern := regexp.MustCompile(`^GC-[A-HJ-NP-Z2-9]{4}-[A-HJ-NP-Z2-9]{4}-[A-HJ-NP-Z2-9]{4}$`)
+
+	first, err := generateGiftCardCode()
+	require.NoError(t, err)
+	second, err := generateGiftCardCode()
+	require.NoError(t, err)
+
+	assert.Regexp(t, pattern, first)
+	assert.Regexp(t, pattern, second)
+	assert.NotEqual(t, first, second)
+}
@@ -1,6 +1,14 @@
 package services
 
-import "time"
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/jadiazinf/inventory/internal/common/errors"
+	"github.com/jadiazinf/inventory/internal/core/domain"
+)
 
 // Helper functions shared across services
 
@@ -15,3 +23,46 @@ func timePtr(t time.Time) *time.Time {
 func float64Ptr(f float64) *float64 {
 	return &f
 }
+
+// optionalString returns nil for blank text
+func optionalString(s string) *string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	return &s
+}
+
+// appendNote adds a line to free-text notes
+func appendNote(notes *string, line string) *string {
+	if notes == nil || *notes == "" {
+		return &line
+	}
+	joined := *notes + "\n" + line
+	return &joined
+}
+
+// roundAmount rounds a monetary amount to two decimals
+func roundAmount(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// convertAmount converts an amount between currencies. Rates are expressed
+// as VES per unit of foreign currency, the same way sales store them.
+func convertAmount(amount float64, from, to domain.CurrencyCode, rate *float64) (float64, error) {
+	if from == to {
+		return amount, nil
+	}
+
+	if rate == nil || *rate <= 0 {
+		return 0, errors.InvalidInput(fmt.Sprintf("Exchange rate is required to convert %s to %s", from, to))
+	}
+
+	switch {
+	case to == domain.CurrencyVES:
+		return roundAmount(amount * *rate), nil
+	case from == domain.CurrencyVES:
+		return roundAmount(amount / *rate), nil
+	}
+
+	return 0, errors.InvalidInput(fmt.Sprintf("Cannot convert %s to %s", from, to))
+}
@@ -0,0 +1,246 @@
+// Package pdf renders simple text documents such as quotations and
+// statements using the standard PDF fonts, without external dependencies.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Letter page size and layout, in points
+const (
+	pageWidth    = 612.0
+	pageHeight   = 792.0
+	margin       = 50.0
+	footerHeight = 30.0
+)
+
+// Font resource names, declared in the page resources
+const (
+	fontRegular  = "F1"
+	fontBold     = "F2"
+	fontMono     = "F3"
+	fontMonoBold = "F4"
+)
+
+var baseFonts = []string{"Helvetica", "Helvetica-Bold", "Courier", "Courier-Bold"}
+
+// Align controls the alignment of a table column
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignRight
+)
+
+// Column describes a table column. Width is expressed in characters.
+type Column struct {
+	Header string
+	Width  int
+	Align  Align
+}
+
+// Document is a multi-page text document laid out top to bottom
+type Document struct {
+	pages  []*bytes.Buffer
+	y      float64
+	footer string
+}
+
+// New creates an empty document with a first page
+func New() *Document {
+	d := &Document{}
+	d.newPage()
+	return d
+}
+
+// SetFooter sets a text printed at the bottom of every page
+func (d *Document) SetFooter(text string) {
+	d.footer = text
+}
+
+// Title writes a large bold line
+func (d *Document) Title(text string) {
+	d.writeLine(fontBold, 16, text)
+	d.Space(4)
+}
+
+// Heading writes a bold line
+func (d *Document) Heading(text string) {
+	d.writeLine(fontBold, 11, text)
+}
+
+// Text writes a regular line
+func (d *Document) Text(text string) {
+	d.writeLine(fontRegular, 10, text)
+}
+
+// Field writes a "label: value" line
+func (d *Document) Field(label, value string) {
+	d.Text(label + ": " + value)
+}
+
+// Space adds vertical space
+func (d *Document) Space(points float64) {
+	d.y -= points
+}
+
+// Separator draws a horizontal line across the page
+func (d *Document) Separator() {
+	d.ensureSpace(6)
+	d.y -= 3
+	fmt.Fprintf(d.current(), "%.2f %.2f m %.2f %.2f l S\n", margin, d.y, pageWidth-margin, d.y)
+	d.y -= 6
+}
+
+// Table writes a monospaced table. The header is repeated on each page.
+func (d *Document) Table(columns []Column, rows [][]string) {
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.Header
+	}
+
+	d.ensureSpace(40)
+	d.writeLine(fontMonoBold, 9, formatRow(columns, header))
+	for _, row := range rows {
+		if d.y-12 < margin+footerHeight {
+			d.newPage()
+			d.writeLine(fontMonoBold, 9, formatRow(columns, header))
+		}
+		d.writeLine(fontMono, 9, formatRow(columns, row))
+	}
+	d.Space(6)
+}
+
+// Bytes renders the document
+func (d *Document) Bytes() []byte {
+	var out bytes.Buffer
+	offsets := []int{}
+
+	// Objects: 1 catalog, 2 pages, 3..6 fonts, then page and content pairs
+	firstPage := 3 + len(baseFonts)
+	objCount := firstPage - 1 + 2*len(d.pages)
+
+	beginObj := func(num int) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n", num)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	beginObj(1)
+	out.WriteString("<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	beginObj(2)
+	fmt.Fprintf(&out, "<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", strings.Join(kids, " "), len(d.pages))
+
+	fonts := make([]string, len(baseFonts))
+	for i, name := range baseFonts {
+		beginObj(3 + i)
+		fmt.Fprintf(&out, "<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>\nendobj\n", name)
+		fonts[i] = fmt.Sprintf("/F%d %d 0 R", i+1, 3+i)
+	}
+
+	for i, page := range d.pages {
+		content := page.Bytes()
+		content = append(content, d.footerStream(i+1, len(d.pages))...)
+
+		pageObj := firstPage + 2*i
+		beginObj(pageObj)
+		fmt.Fprintf(&out, "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << %s >> >> /Contents %d 0 R >>\nendobj\n",
+			pageWidth, pageHeight, strings.Join(fonts, " "), pageObj+1)
+
+		beginObj(pageObj + 1)
+		fmt.Fprintf(&out, "<< /Length %d >>\nstream\n", len(content))
+		out.Write(content)
+		out.WriteString("\nendstream\nendobj\n")
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", objCount+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", objCount+1, xref)
+
+	return out.Bytes()
+}
+
+// Helper functions
+
+func (d *Document) current() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - margin
+}
+
+func (d *Document) ensureSpace(height float64) {
+	if d.y-height < margin+footerHeight {
+		d.newPage()
+	}
+}
+
+func (d *Document) writeLine(font string, size float64, text string) {
+	lineHeight := size * 1.4
+	d.ensureSpace(lineHeight)
+	d.y -= lineHeight
+	fmt.Fprintf(d.current(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, margin, d.y, escape(text))
+}
+
+func (d *Document) footerStream(page, total int) []byte {
+	text := fmt.Sprintf("%d / %d", page, total)
+	if d.footer != "" {
+		text = d.footer + "    " + text
+	}
+	return []byte(fmt.Sprintf("\nBT /%s 8.0 Tf %.2f %.2f Td (%s) Tj ET", fontRegular, margin, margin/2, escape(text)))
+}
+
+// formatRow pads or truncates each cell to its column width
+func formatRow(columns []Column, cells []string) string {
+	parts := make([]string, len(columns))
+	for i, col := range columns {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		runes := []rune(cell)
+		if len(runes) > col.Width {
+			runes = runes[:col.Width]
+		}
+		padding := strings.Repeat(" ", col.Width-len(runes))
+		if col.Align == AlignRight {
+			parts[i] = padding + string(runes)
+		} else {
+			parts[i] = string(runes) + padding
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// escape encodes text as WinAnsi and escapes PDF string delimiters.
+// Characters outside Latin-1 are replaced with '?'.
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteByte(byte(r))
+		case r == '\n' || r == '\r' || r == '\t':
+			b.WriteByte(' ')
+		case r < 256:
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}